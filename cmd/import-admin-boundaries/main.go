@@ -0,0 +1,77 @@
+// Command import-admin-boundaries loads province, kabupaten/kota or kecamatan polygons
+// from a GeoJSON FeatureCollection into admin_boundaries and re-assigns building region codes.
+//
+// Usage:
+//
+//	go run ./cmd/import-admin-boundaries -level province -file provinces.geojson
+//	go run ./cmd/import-admin-boundaries -level city -file kabkota.geojson -code-prop KDPKAB -name-prop WADMKK -parent-prop KDPPUM
+//
+// Import provinces first, then cities, then subdistricts.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/joho/godotenv"
+	"github.com/malikabdulaziz/tmn-backend/exceptions"
+	"github.com/malikabdulaziz/tmn-backend/helpers"
+	"github.com/malikabdulaziz/tmn-backend/injector"
+	webAdminBoundary "github.com/malikabdulaziz/tmn-backend/web/adminboundary"
+)
+
+func main() {
+	helpers.InitLogger()
+	logger := helpers.GetLogger()
+
+	level := flag.String("level", "", "boundary level: province, city or subdistrict")
+	file := flag.String("file", "", "path to a GeoJSON FeatureCollection")
+	codeProp := flag.String("code-prop", "code", "feature property holding the official region code")
+	nameProp := flag.String("name-prop", "name", "feature property holding the region name")
+	parentProp := flag.String("parent-prop", "parent_code", "feature property holding the parent region code")
+	flag.Parse()
+
+	if *level == "" || *file == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	if err := godotenv.Load(); err != nil {
+		logger.WithFields(map[string]interface{}{
+			"warning": ".env file not found",
+		}).Warn("Continuing without loading environment variables")
+	}
+
+	data, err := os.ReadFile(*file)
+	if err != nil {
+		logger.WithError(err).Error("Failed to read GeoJSON file")
+		os.Exit(1)
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			if badRequest, ok := r.(exceptions.BadRequestError); ok {
+				logger.WithField("details", badRequest.Extras).Error(badRequest.Error)
+			} else {
+				logger.WithField("error", fmt.Sprint(r)).Error("Admin boundary import failed")
+			}
+			os.Exit(1)
+		}
+	}()
+
+	service := injector.InitializeAdminBoundaryService()
+	result := service.ImportGeoJSON(context.Background(), webAdminBoundary.ImportGeoJSONRequest{
+		Level:              *level,
+		CodeProperty:       *codeProp,
+		NameProperty:       *nameProp,
+		ParentCodeProperty: *parentProp,
+	}, data)
+
+	logger.WithFields(map[string]interface{}{
+		"level":             result.Level,
+		"imported":          result.Imported,
+		"buildings_updated": result.BuildingsUpdated,
+	}).Info("Admin boundary import completed")
+}
@@ -0,0 +1,26 @@
+package adminboundary
+
+import (
+	"net/http"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/malikabdulaziz/tmn-backend/helpers"
+	servicesAdminBoundary "github.com/malikabdulaziz/tmn-backend/services/adminboundary"
+	"github.com/malikabdulaziz/tmn-backend/web"
+)
+
+type ControllerAdminBoundaryImpl struct {
+	service servicesAdminBoundary.ServiceAdminBoundaryInterface
+}
+
+func NewControllerAdminBoundaryImpl(service servicesAdminBoundary.ServiceAdminBoundaryInterface) ControllerAdminBoundaryInterface {
+	return &ControllerAdminBoundaryImpl{service: service}
+}
+
+// FindAll handles GET /admin-boundaries?level=&parent_code= (region dropdowns for mapping filters)
+func (c *ControllerAdminBoundaryImpl) FindAll(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+	level := r.URL.Query().Get("level")
+	parentCode := r.URL.Query().Get("parent_code")
+	list := c.service.FindAll(r.Context(), level, parentCode)
+	helpers.ReturnReponseJSON(w, web.WebResponse{Status: "OK", Code: http.StatusOK, Data: list})
+}
@@ -0,0 +1,11 @@
+package adminboundary
+
+import (
+	"net/http"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+type ControllerAdminBoundaryInterface interface {
+	FindAll(w http.ResponseWriter, r *http.Request, p httprouter.Params)
+}
@@ -109,6 +109,7 @@ func (implementation *ControllerAuthImpl) CurrentUser(w http.ResponseWriter, r *
 		Username:  user.Username,
 		Name:      user.Name,
 		Role:      user.Role,
+		Team:      user.Team,
 		LastLogin: lastLogin,
 	}
 
@@ -0,0 +1,143 @@
+package booking
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/malikabdulaziz/tmn-backend/exceptions"
+	"github.com/malikabdulaziz/tmn-backend/helpers"
+	servicesBooking "github.com/malikabdulaziz/tmn-backend/services/booking"
+	"github.com/malikabdulaziz/tmn-backend/web"
+	webBooking "github.com/malikabdulaziz/tmn-backend/web/booking"
+)
+
+type ControllerBookingImpl struct {
+	service servicesBooking.ServiceBookingInterface
+}
+
+func NewControllerBookingImpl(service servicesBooking.ServiceBookingInterface) ControllerBookingInterface {
+	return &ControllerBookingImpl{service: service}
+}
+
+// Create handles POST /bookings
+func (c *ControllerBookingImpl) Create(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+	request := r.Context().Value(helpers.ContextKey("createBookingRequest")).(webBooking.CreateBookingRequest)
+	resp := c.service.Create(r.Context(), request)
+	helpers.ReturnReponseJSON(w, web.WebResponse{Status: "OK", Code: http.StatusCreated, Data: resp})
+}
+
+// FindAll handles GET /bookings?status=&client_name=&sales_package_id=&building_id=&from=&to=
+func (c *ControllerBookingImpl) FindAll(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+	var request webBooking.BookingRequestFindAll
+	web.SetPagination(&request, r)
+	web.SetOrder(&request, r)
+	query := r.URL.Query()
+	request.SetStatus(query.Get("status"))
+	request.SetClientName(query.Get("client_name"))
+	request.SetSalesPackageId(queryInt(query.Get("sales_package_id"), "sales_package_id"))
+	request.SetBuildingId(queryInt(query.Get("building_id"), "building_id"))
+	request.SetFrom(query.Get("from"))
+	request.SetTo(query.Get("to"))
+
+	list, total := c.service.FindAll(r.Context(), request)
+	pagination := web.Pagination{Take: request.GetTake(), Skip: request.GetSkip(), Total: total}
+	helpers.ReturnReponseJSON(w, web.WebResponse{Status: "OK", Code: http.StatusOK, Data: list, Extras: pagination})
+}
+
+// FindById handles GET /bookings/:id
+func (c *ControllerBookingImpl) FindById(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+	id, err := strconv.Atoi(p.ByName("id"))
+	if err != nil {
+		panic(exceptions.NewBadRequest("invalid booking id"))
+	}
+	resp := c.service.FindById(r.Context(), id)
+	helpers.ReturnReponseJSON(w, web.WebResponse{Status: "OK", Code: http.StatusOK, Data: resp})
+}
+
+// Update handles PUT /bookings/:id
+func (c *ControllerBookingImpl) Update(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+	id := r.Context().Value(helpers.ContextKey("bookingId")).(int)
+	request := r.Context().Value(helpers.ContextKey("updateBookingRequest")).(webBooking.UpdateBookingRequest)
+	resp := c.service.Update(r.Context(), request, id)
+	helpers.ReturnReponseJSON(w, web.WebResponse{Status: "OK", Code: http.StatusOK, Data: resp})
+}
+
+// UpdateStatus handles PUT /bookings/:id/status
+func (c *ControllerBookingImpl) UpdateStatus(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+	id := r.Context().Value(helpers.ContextKey("bookingId")).(int)
+	request := r.Context().Value(helpers.ContextKey("updateBookingStatusRequest")).(webBooking.UpdateBookingStatusRequest)
+	resp := c.service.UpdateStatus(r.Context(), request, id)
+	helpers.ReturnReponseJSON(w, web.WebResponse{Status: "OK", Code: http.StatusOK, Data: resp})
+}
+
+// Delete handles DELETE /bookings/:id
+func (c *ControllerBookingImpl) Delete(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+	id, err := strconv.Atoi(p.ByName("id"))
+	if err != nil {
+		panic(exceptions.NewBadRequest("invalid booking id"))
+	}
+	c.service.Delete(r.Context(), id)
+	helpers.ReturnReponseJSON(w, web.WebResponse{Status: "OK", Code: http.StatusOK, Data: "Booking deleted successfully"})
+}
+
+// Availability handles GET /bookings-availability?building_ids=1,2&sales_package_id=&sales_package_version=&from=&to=&screens=
+func (c *ControllerBookingImpl) Availability(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+	query := r.URL.Query()
+	request := availabilityRequest(r)
+	request.SalesPackageId = queryInt(query.Get("sales_package_id"), "sales_package_id")
+	request.SalesPackageVersion = queryInt(query.Get("sales_package_version"), "sales_package_version")
+	if raw := query.Get("building_ids"); raw != "" {
+		for _, part := range strings.Split(raw, ",") {
+			request.BuildingIds = append(request.BuildingIds, queryInt(strings.TrimSpace(part), "building_ids"))
+		}
+	}
+	resp := c.service.Availability(r.Context(), request)
+	helpers.ReturnReponseJSON(w, web.WebResponse{Status: "OK", Code: http.StatusOK, Data: resp})
+}
+
+// BuildingCalendar handles GET /buildings/:id/booking-calendar?from=&to=
+func (c *ControllerBookingImpl) BuildingCalendar(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+	id, err := strconv.Atoi(p.ByName("id"))
+	if err != nil {
+		panic(exceptions.NewBadRequest("invalid building id"))
+	}
+	request := availabilityRequest(r)
+	request.BuildingIds = []int{id}
+	resp := c.service.Availability(r.Context(), request)
+	helpers.ReturnReponseJSON(w, web.WebResponse{Status: "OK", Code: http.StatusOK, Data: resp})
+}
+
+// SalesPackageCalendar handles GET /sales-packages/:id/booking-calendar?from=&to=
+func (c *ControllerBookingImpl) SalesPackageCalendar(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+	id, err := strconv.Atoi(p.ByName("id"))
+	if err != nil {
+		panic(exceptions.NewBadRequest("invalid sales package id"))
+	}
+	request := availabilityRequest(r)
+	request.SalesPackageId = id
+	resp := c.service.Availability(r.Context(), request)
+	helpers.ReturnReponseJSON(w, web.WebResponse{Status: "OK", Code: http.StatusOK, Data: resp})
+}
+
+// availabilityRequest reads the from, to and screens query parameters
+func availabilityRequest(r *http.Request) webBooking.AvailabilityRequest {
+	query := r.URL.Query()
+	return webBooking.AvailabilityRequest{
+		From:    query.Get("from"),
+		To:      query.Get("to"),
+		Screens: queryInt(query.Get("screens"), "screens"),
+	}
+}
+
+func queryInt(value string, name string) int {
+	if value == "" {
+		return 0
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		panic(exceptions.NewBadRequest(name + " must be a number"))
+	}
+	return n
+}
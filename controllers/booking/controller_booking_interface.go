@@ -0,0 +1,19 @@
+package booking
+
+import (
+	"net/http"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+type ControllerBookingInterface interface {
+	Create(w http.ResponseWriter, r *http.Request, p httprouter.Params)
+	FindAll(w http.ResponseWriter, r *http.Request, p httprouter.Params)
+	FindById(w http.ResponseWriter, r *http.Request, p httprouter.Params)
+	Update(w http.ResponseWriter, r *http.Request, p httprouter.Params)
+	UpdateStatus(w http.ResponseWriter, r *http.Request, p httprouter.Params)
+	Delete(w http.ResponseWriter, r *http.Request, p httprouter.Params)
+	Availability(w http.ResponseWriter, r *http.Request, p httprouter.Params)
+	BuildingCalendar(w http.ResponseWriter, r *http.Request, p httprouter.Params)
+	SalesPackageCalendar(w http.ResponseWriter, r *http.Request, p httprouter.Params)
+}
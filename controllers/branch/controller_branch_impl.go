@@ -1,16 +1,14 @@
 package branch
 
 import (
-	"io"
 	"net/http"
-	"path/filepath"
 	"strconv"
-	"strings"
 	"time"
 
 	"github.com/julienschmidt/httprouter"
 	"github.com/malikabdulaziz/tmn-backend/exceptions"
 	"github.com/malikabdulaziz/tmn-backend/helpers"
+	"github.com/malikabdulaziz/tmn-backend/importer"
 	servicesBranch "github.com/malikabdulaziz/tmn-backend/services/branch"
 	"github.com/malikabdulaziz/tmn-backend/web"
 	webBranch "github.com/malikabdulaziz/tmn-backend/web/branch"
@@ -76,27 +74,10 @@ func (c *ControllerBranchImpl) FindAllDropdown(w http.ResponseWriter, r *http.Re
 }
 
 func (c *ControllerBranchImpl) Import(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
-	err := r.ParseMultipartForm(32 << 20)
-	if err != nil {
-		panic(exceptions.NewBadRequestError("Failed to parse upload. Max file size is 32MB."))
-	}
-
-	file, header, err := r.FormFile("file")
-	if err != nil {
-		panic(exceptions.NewBadRequestError("File is required. Use form field 'file'."))
-	}
-	defer file.Close()
+	fileBytes, ext := importer.ReadUpload(r)
 
-	fileBytes, err := io.ReadAll(file)
-	helpers.PanicIfError(err)
-
-	ext := strings.ToLower(strings.TrimPrefix(filepath.Ext(header.Filename), "."))
-	if ext != "xlsx" && ext != "csv" {
-		panic(exceptions.NewBadRequestError("Unsupported file type. Use .xlsx or .csv files."))
-	}
-
-	responses := c.service.Import(r.Context(), fileBytes, ext)
-	helpers.ReturnReponseJSON(w, web.WebResponse{Status: "OK", Code: http.StatusCreated, Data: responses})
+	responses, report := c.service.Import(r.Context(), fileBytes, ext, importer.OnErrorFromRequest(r))
+	helpers.ReturnReponseJSON(w, web.WebResponse{Status: "OK", Code: http.StatusCreated, Data: responses, Extras: report})
 }
 
 func (c *ControllerBranchImpl) Export(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
@@ -113,3 +94,16 @@ func (c *ControllerBranchImpl) Export(w http.ResponseWriter, r *http.Request, p
 	w.WriteHeader(http.StatusOK)
 	w.Write(excelBytes)
 }
+
+func (c *ControllerBranchImpl) ImportTemplate(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+	excelBytes, err := c.service.ImportTemplate(r.Context())
+	helpers.PanicIfError(err)
+
+	filename := "Branch_Import_Template.xlsx"
+
+	w.Header().Set("Content-Type", "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet")
+	w.Header().Set("Content-Disposition", "attachment; filename=\""+filename+"\"")
+	w.Header().Set("Content-Length", strconv.Itoa(len(excelBytes)))
+	w.WriteHeader(http.StatusOK)
+	w.Write(excelBytes)
+}
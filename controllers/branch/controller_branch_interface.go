@@ -15,4 +15,5 @@ type ControllerBranchInterface interface {
 	FindAllDropdown(w http.ResponseWriter, r *http.Request, p httprouter.Params)
 	Import(w http.ResponseWriter, r *http.Request, p httprouter.Params)
 	Export(w http.ResponseWriter, r *http.Request, p httprouter.Params)
+	ImportTemplate(w http.ResponseWriter, r *http.Request, p httprouter.Params)
 }
@@ -10,17 +10,21 @@ import (
 	"github.com/malikabdulaziz/tmn-backend/exceptions"
 	"github.com/malikabdulaziz/tmn-backend/helpers"
 	servicesBuilding "github.com/malikabdulaziz/tmn-backend/services/building"
+	servicesSavedView "github.com/malikabdulaziz/tmn-backend/services/savedview"
 	"github.com/malikabdulaziz/tmn-backend/web"
 	webBuilding "github.com/malikabdulaziz/tmn-backend/web/building"
 )
 
 type ControllerBuildingImpl struct {
 	service servicesBuilding.ServiceBuildingInterface
+	// savedViews resolves the view_id of mapping and export bodies
+	savedViews servicesSavedView.ServiceSavedViewInterface
 }
 
-func NewControllerBuildingImpl(service servicesBuilding.ServiceBuildingInterface) ControllerBuildingInterface {
+func NewControllerBuildingImpl(service servicesBuilding.ServiceBuildingInterface, savedViews servicesSavedView.ServiceSavedViewInterface) ControllerBuildingInterface {
 	return &ControllerBuildingImpl{
-		service: service,
+		service:    service,
+		savedViews: savedViews,
 	}
 }
 
@@ -114,12 +118,15 @@ func (controller *ControllerBuildingImpl) GetFilterOptions(w http.ResponseWriter
 	helpers.ReturnReponseJSON(w, response)
 }
 
-// FindAllForMapping handles POST /mapping-buildings (body: filters + map_center + bounds)
+// FindAllForMapping handles POST /mapping-buildings (body: filters or view_id + map_center + bounds)
 func (controller *ControllerBuildingImpl) FindAllForMapping(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
 	var body webBuilding.MappingByFilterRequest
 	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
 		panic(exceptions.NewBadRequest("invalid request body"))
 	}
+	if body.ViewId > 0 {
+		controller.savedViews.ApplyToMapping(r.Context(), body.ViewId, &body)
+	}
 
 	request := webBuilding.BuildMappingRequestFromBody(&body)
 
@@ -134,12 +141,15 @@ func (controller *ControllerBuildingImpl) FindAllForMapping(w http.ResponseWrite
 	helpers.ReturnReponseJSON(w, response)
 }
 
-// ExportMappingBuildings handles POST /admin/mapping-building/export (body: filters + map_center, bounds null)
+// ExportMappingBuildings handles POST /admin/mapping-building/export (body: filters or view_id + map_center, bounds null)
 func (controller *ControllerBuildingImpl) ExportMappingBuildings(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
 	var body webBuilding.ExportMappingByFilterRequest
 	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
 		panic(exceptions.NewBadRequest("invalid request body"))
 	}
+	if body.ViewId > 0 {
+		controller.savedViews.ApplyToExport(r.Context(), body.ViewId, &body)
+	}
 	request := webBuilding.BuildMappingRequestFromExportBody(&body)
 	excelBytes, err := controller.service.ExportForMappingWithFilters(r.Context(), request)
 	if err != nil {
@@ -177,3 +187,83 @@ func (controller *ControllerBuildingImpl) GetLCDPresenceSummary(w http.ResponseW
 
 	helpers.ReturnReponseJSON(w, response)
 }
+
+// FindNearest handles GET /buildings-nearest?lat=&lng=&limit=&building_type=
+func (controller *ControllerBuildingImpl) FindNearest(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+	query := r.URL.Query()
+	lat, errLat := strconv.ParseFloat(query.Get("lat"), 64)
+	lng, errLng := strconv.ParseFloat(query.Get("lng"), 64)
+	if errLat != nil || errLng != nil {
+		panic(exceptions.NewBadRequest("lat and lng are required"))
+	}
+	limit, _ := strconv.Atoi(query.Get("limit"))
+
+	nearest := controller.service.FindNearest(r.Context(), webBuilding.NearestBuildingsRequest{
+		Lat:          lat,
+		Lng:          lng,
+		Limit:        limit,
+		BuildingType: query.Get("building_type"),
+	})
+
+	helpers.ReturnReponseJSON(w, web.WebResponse{
+		Status: "OK",
+		Code:   http.StatusOK,
+		Data:   nearest,
+	})
+}
+
+// FindNearbyPOIs handles GET /buildings/:id/nearby-pois?limit=&category_ids=&sub_category_ids=&mother_brand_ids=
+func (controller *ControllerBuildingImpl) FindNearbyPOIs(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+	buildingId, err := strconv.Atoi(p.ByName("id"))
+	if err != nil {
+		panic(exceptions.NewBadRequest("invalid building id"))
+	}
+	query := r.URL.Query()
+	limit, _ := strconv.Atoi(query.Get("limit"))
+
+	nearby := controller.service.FindNearbyPOIs(r.Context(), buildingId, webBuilding.NearbyPOIsRequest{
+		Limit:          limit,
+		CategoryIds:    query.Get("category_ids"),
+		SubCategoryIds: query.Get("sub_category_ids"),
+		MotherBrandIds: query.Get("mother_brand_ids"),
+	})
+
+	helpers.ReturnReponseJSON(w, web.WebResponse{
+		Status: "OK",
+		Code:   http.StatusOK,
+		Data:   nearby,
+	})
+}
+
+// AnalyzePOIDensity handles POST /analytics/poi-density (body: building_ids or mapping filters, rings, sort)
+func (controller *ControllerBuildingImpl) AnalyzePOIDensity(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+	var body webBuilding.POIDensityRequest
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		panic(exceptions.NewBadRequest("invalid request body"))
+	}
+
+	density := controller.service.AnalyzePOIDensity(r.Context(), body)
+
+	helpers.ReturnReponseJSON(w, web.WebResponse{
+		Status: "OK",
+		Code:   http.StatusOK,
+		Data:   density,
+	})
+}
+
+// ExportPOIDensity handles POST /analytics/poi-density/export (same body as AnalyzePOIDensity)
+func (controller *ControllerBuildingImpl) ExportPOIDensity(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+	var body webBuilding.POIDensityRequest
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		panic(exceptions.NewBadRequest("invalid request body"))
+	}
+	excelBytes, err := controller.service.ExportPOIDensity(r.Context(), body)
+	if err != nil {
+		panic(exceptions.NewBadRequest("export failed: " + err.Error()))
+	}
+	filename := "Target Media Nusantara - POI Density - " + time.Now().Format("02-01-2006") + ".xlsx"
+	w.Header().Set("Content-Type", "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet")
+	w.Header().Set("Content-Disposition", "attachment; filename=\""+filename+"\"")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(excelBytes)
+}
@@ -16,5 +16,9 @@ type ControllerBuildingInterface interface {
 	ExportMappingBuildings(w http.ResponseWriter, r *http.Request, p httprouter.Params)
 	GetLCDPresenceSummary(w http.ResponseWriter, r *http.Request, p httprouter.Params)
 	GetDropdownOptions(w http.ResponseWriter, r *http.Request, p httprouter.Params)
+	FindNearest(w http.ResponseWriter, r *http.Request, p httprouter.Params)
+	FindNearbyPOIs(w http.ResponseWriter, r *http.Request, p httprouter.Params)
+	AnalyzePOIDensity(w http.ResponseWriter, r *http.Request, p httprouter.Params)
+	ExportPOIDensity(w http.ResponseWriter, r *http.Request, p httprouter.Params)
 }
 
@@ -1,16 +1,14 @@
 package buildingrestriction
 
 import (
-	"io"
 	"net/http"
-	"path/filepath"
 	"strconv"
-	"strings"
 	"time"
 
 	"github.com/julienschmidt/httprouter"
 	"github.com/malikabdulaziz/tmn-backend/exceptions"
 	"github.com/malikabdulaziz/tmn-backend/helpers"
+	"github.com/malikabdulaziz/tmn-backend/importer"
 	servicesBuildingRestriction "github.com/malikabdulaziz/tmn-backend/services/buildingrestriction"
 	"github.com/malikabdulaziz/tmn-backend/web"
 	webBuildingRestriction "github.com/malikabdulaziz/tmn-backend/web/buildingrestriction"
@@ -71,31 +69,15 @@ func (c *ControllerBuildingRestrictionImpl) Delete(w http.ResponseWriter, r *htt
 
 // Import handles POST /building-restrictions-import
 func (c *ControllerBuildingRestrictionImpl) Import(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
-	err := r.ParseMultipartForm(32 << 20) // 32MB max
-	if err != nil {
-		panic(exceptions.NewBadRequestError("Failed to parse upload. Max file size is 32MB."))
-	}
-
-	file, header, err := r.FormFile("file")
-	if err != nil {
-		panic(exceptions.NewBadRequestError("File is required. Use form field 'file'."))
-	}
-	defer file.Close()
+	fileBytes, ext := importer.ReadUpload(r)
 
-	fileBytes, err := io.ReadAll(file)
-	helpers.PanicIfError(err)
-
-	ext := strings.ToLower(strings.TrimPrefix(filepath.Ext(header.Filename), "."))
-	if ext != "xlsx" && ext != "csv" {
-		panic(exceptions.NewBadRequestError("Unsupported file type. Use .xlsx or .csv files."))
-	}
-
-	responses := c.service.Import(r.Context(), fileBytes, ext)
+	responses, report := c.service.Import(r.Context(), fileBytes, ext, importer.OnErrorFromRequest(r))
 
 	helpers.ReturnReponseJSON(w, web.WebResponse{
 		Status: "OK",
 		Code:   http.StatusCreated,
 		Data:   responses,
+		Extras: report,
 	})
 }
 
@@ -114,3 +96,17 @@ func (c *ControllerBuildingRestrictionImpl) Export(w http.ResponseWriter, r *htt
 	w.WriteHeader(http.StatusOK)
 	w.Write(excelBytes)
 }
+
+// ImportTemplate handles GET /building-restrictions-import-template
+func (c *ControllerBuildingRestrictionImpl) ImportTemplate(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+	excelBytes, err := c.service.ImportTemplate(r.Context())
+	helpers.PanicIfError(err)
+
+	filename := "BuildingRestriction_Import_Template.xlsx"
+
+	w.Header().Set("Content-Type", "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet")
+	w.Header().Set("Content-Disposition", "attachment; filename=\""+filename+"\"")
+	w.Header().Set("Content-Length", strconv.Itoa(len(excelBytes)))
+	w.WriteHeader(http.StatusOK)
+	w.Write(excelBytes)
+}
@@ -14,4 +14,5 @@ type ControllerBuildingRestrictionInterface interface {
 	Delete(w http.ResponseWriter, r *http.Request, p httprouter.Params)
 	Import(w http.ResponseWriter, r *http.Request, p httprouter.Params)
 	Export(w http.ResponseWriter, r *http.Request, p httprouter.Params)
+	ImportTemplate(w http.ResponseWriter, r *http.Request, p httprouter.Params)
 }
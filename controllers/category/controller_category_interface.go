@@ -13,6 +13,8 @@ type ControllerCategoryInterface interface {
 	Update(w http.ResponseWriter, r *http.Request, p httprouter.Params)
 	Delete(w http.ResponseWriter, r *http.Request, p httprouter.Params)
 	FindAllDropdown(w http.ResponseWriter, r *http.Request, p httprouter.Params)
+	FindTree(w http.ResponseWriter, r *http.Request, p httprouter.Params)
 	Import(w http.ResponseWriter, r *http.Request, p httprouter.Params)
 	Export(w http.ResponseWriter, r *http.Request, p httprouter.Params)
+	ImportTemplate(w http.ResponseWriter, r *http.Request, p httprouter.Params)
 }
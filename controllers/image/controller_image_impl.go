@@ -9,6 +9,7 @@ import (
 
 	"github.com/julienschmidt/httprouter"
 	"github.com/malikabdulaziz/tmn-backend/helpers"
+	"github.com/malikabdulaziz/tmn-backend/services/erp"
 )
 
 type ControllerImageImpl struct{}
@@ -46,24 +47,14 @@ func (controller *ControllerImageImpl) ProxyImage(w http.ResponseWriter, r *http
 	apiKey := os.Getenv("ERP_API_KEY")
 	apiSecret := os.Getenv("ERP_API_SECRET")
 
-	// Construct the full URL to the ERP image server
-	// Remove leading slash from imagePath if present
-	imagePath = strings.TrimPrefix(imagePath, "/")
-	erpBaseURL = strings.TrimSuffix(erpBaseURL, "/")
-	erpImageURL := erpBaseURL + "/" + imagePath
-
-	// Create request to ERP server
-	req, err := http.NewRequest("GET", erpImageURL, nil)
+	// Create request to ERP server, authorized with the ERP credentials when available
+	req, err := erp.NewImageRequest(r.Context(), erpBaseURL, apiKey, apiSecret, imagePath)
 	if err != nil {
 		helpers.GetLogger().WithError(err).Error("Failed to create request to ERP image server")
 		http.Error(w, "Failed to create request", http.StatusInternalServerError)
 		return
 	}
-
-	// Add authorization header if credentials are available
-	if apiKey != "" && apiSecret != "" {
-		req.Header.Set("Authorization", "Token "+apiKey+":"+apiSecret)
-	}
+	erpImageURL := req.URL.String()
 
 	// Forward other headers that might be useful
 	req.Header.Set("Accept", r.Header.Get("Accept"))
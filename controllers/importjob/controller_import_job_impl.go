@@ -0,0 +1,60 @@
+package importjob
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/malikabdulaziz/tmn-backend/exceptions"
+	"github.com/malikabdulaziz/tmn-backend/helpers"
+	"github.com/malikabdulaziz/tmn-backend/importer"
+	servicesImportJob "github.com/malikabdulaziz/tmn-backend/services/importjob"
+	"github.com/malikabdulaziz/tmn-backend/web"
+	webImportJob "github.com/malikabdulaziz/tmn-backend/web/importjob"
+)
+
+type ControllerImportJobImpl struct {
+	service servicesImportJob.ServiceImportJobInterface
+}
+
+func NewControllerImportJobImpl(service servicesImportJob.ServiceImportJobInterface) ControllerImportJobInterface {
+	return &ControllerImportJobImpl{service: service}
+}
+
+// Create handles POST /import-jobs. Form fields: file, kind, and optionally on_error plus, for
+// POI imports, mode and delete_missing (as accepted by /pois-import).
+func (c *ControllerImportJobImpl) Create(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+	fileBytes, ext := importer.ReadUpload(r)
+
+	options := webImportJob.ImportJobOptions{Mode: r.FormValue("mode"), OnError: importer.OnErrorFromRequest(r)}
+	if raw := r.FormValue("delete_missing"); raw != "" {
+		deleteMissing, err := strconv.ParseBool(raw)
+		if err != nil {
+			panic(exceptions.NewBadRequestError("delete_missing must be true or false."))
+		}
+		options.DeleteMissing = deleteMissing
+	}
+
+	resp := c.service.Enqueue(r.Context(), r.FormValue("kind"), fileBytes, ext, options)
+	helpers.ReturnReponseJSON(w, web.WebResponse{Status: "OK", Code: http.StatusAccepted, Data: resp})
+}
+
+// FindById handles GET /import-jobs/:id
+func (c *ControllerImportJobImpl) FindById(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+	id, err := strconv.Atoi(p.ByName("id"))
+	if err != nil {
+		panic(exceptions.NewBadRequest("invalid import job id"))
+	}
+	resp := c.service.FindById(r.Context(), id)
+	helpers.ReturnReponseJSON(w, web.WebResponse{Status: "OK", Code: http.StatusOK, Data: resp})
+}
+
+// Cancel handles POST /import-jobs/:id/cancel
+func (c *ControllerImportJobImpl) Cancel(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+	id, err := strconv.Atoi(p.ByName("id"))
+	if err != nil {
+		panic(exceptions.NewBadRequest("invalid import job id"))
+	}
+	resp := c.service.Cancel(r.Context(), id)
+	helpers.ReturnReponseJSON(w, web.WebResponse{Status: "OK", Code: http.StatusAccepted, Data: resp})
+}
@@ -0,0 +1,13 @@
+package importjob
+
+import (
+	"net/http"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+type ControllerImportJobInterface interface {
+	Create(w http.ResponseWriter, r *http.Request, p httprouter.Params)
+	FindById(w http.ResponseWriter, r *http.Request, p httprouter.Params)
+	Cancel(w http.ResponseWriter, r *http.Request, p httprouter.Params)
+}
@@ -0,0 +1,32 @@
+package masterdata
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/malikabdulaziz/tmn-backend/exceptions"
+	"github.com/malikabdulaziz/tmn-backend/helpers"
+	servicesMasterData "github.com/malikabdulaziz/tmn-backend/services/masterdata"
+	"github.com/malikabdulaziz/tmn-backend/web"
+	webMasterData "github.com/malikabdulaziz/tmn-backend/web/masterdata"
+)
+
+type ControllerMasterDataImpl struct {
+	service servicesMasterData.ServiceMasterDataInterface
+}
+
+func NewControllerMasterDataImpl(service servicesMasterData.ServiceMasterDataInterface) ControllerMasterDataInterface {
+	return &ControllerMasterDataImpl{service: service}
+}
+
+// Merge handles POST /master-data/:type/:id/merge
+func (c *ControllerMasterDataImpl) Merge(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+	id, err := strconv.Atoi(p.ByName("id"))
+	if err != nil {
+		panic(exceptions.NewBadRequest("invalid id"))
+	}
+	request := r.Context().Value(helpers.ContextKey("mergeMasterDataRequest")).(webMasterData.MergeMasterDataRequest)
+	resp := c.service.Merge(r.Context(), p.ByName("type"), id, request)
+	helpers.ReturnReponseJSON(w, web.WebResponse{Status: "OK", Code: http.StatusOK, Data: resp})
+}
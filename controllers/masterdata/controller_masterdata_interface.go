@@ -0,0 +1,11 @@
+package masterdata
+
+import (
+	"net/http"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+type ControllerMasterDataInterface interface {
+	Merge(w http.ResponseWriter, r *http.Request, p httprouter.Params)
+}
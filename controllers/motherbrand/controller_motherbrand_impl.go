@@ -1,16 +1,14 @@
 package motherbrand
 
 import (
-	"io"
 	"net/http"
-	"path/filepath"
 	"strconv"
-	"strings"
 	"time"
 
 	"github.com/julienschmidt/httprouter"
 	"github.com/malikabdulaziz/tmn-backend/exceptions"
 	"github.com/malikabdulaziz/tmn-backend/helpers"
+	"github.com/malikabdulaziz/tmn-backend/importer"
 	servicesMotherBrand "github.com/malikabdulaziz/tmn-backend/services/motherbrand"
 	"github.com/malikabdulaziz/tmn-backend/web"
 	webMotherBrand "github.com/malikabdulaziz/tmn-backend/web/motherbrand"
@@ -75,28 +73,17 @@ func (c *ControllerMotherBrandImpl) FindAllDropdown(w http.ResponseWriter, r *ht
 	helpers.ReturnReponseJSON(w, web.WebResponse{Status: "OK", Code: http.StatusOK, Data: list})
 }
 
-func (c *ControllerMotherBrandImpl) Import(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
-	err := r.ParseMultipartForm(32 << 20)
-	if err != nil {
-		panic(exceptions.NewBadRequestError("Failed to parse upload. Max file size is 32MB."))
-	}
-
-	file, header, err := r.FormFile("file")
-	if err != nil {
-		panic(exceptions.NewBadRequestError("File is required. Use form field 'file'."))
-	}
-	defer file.Close()
-
-	fileBytes, err := io.ReadAll(file)
-	helpers.PanicIfError(err)
+// FindTree handles GET /mother-brands-tree
+func (c *ControllerMotherBrandImpl) FindTree(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+	resp := c.service.FindTree(r.Context())
+	helpers.ReturnReponseJSON(w, web.WebResponse{Status: "OK", Code: http.StatusOK, Data: resp})
+}
 
-	ext := strings.ToLower(strings.TrimPrefix(filepath.Ext(header.Filename), "."))
-	if ext != "xlsx" && ext != "csv" {
-		panic(exceptions.NewBadRequestError("Unsupported file type. Use .xlsx or .csv files."))
-	}
+func (c *ControllerMotherBrandImpl) Import(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+	fileBytes, ext := importer.ReadUpload(r)
 
-	responses := c.service.Import(r.Context(), fileBytes, ext)
-	helpers.ReturnReponseJSON(w, web.WebResponse{Status: "OK", Code: http.StatusCreated, Data: responses})
+	responses, report := c.service.Import(r.Context(), fileBytes, ext, importer.OnErrorFromRequest(r))
+	helpers.ReturnReponseJSON(w, web.WebResponse{Status: "OK", Code: http.StatusCreated, Data: responses, Extras: report})
 }
 
 func (c *ControllerMotherBrandImpl) Export(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
@@ -113,3 +100,16 @@ func (c *ControllerMotherBrandImpl) Export(w http.ResponseWriter, r *http.Reques
 	w.WriteHeader(http.StatusOK)
 	w.Write(excelBytes)
 }
+
+func (c *ControllerMotherBrandImpl) ImportTemplate(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+	excelBytes, err := c.service.ImportTemplate(r.Context())
+	helpers.PanicIfError(err)
+
+	filename := "MotherBrand_Import_Template.xlsx"
+
+	w.Header().Set("Content-Type", "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet")
+	w.Header().Set("Content-Disposition", "attachment; filename=\""+filename+"\"")
+	w.Header().Set("Content-Length", strconv.Itoa(len(excelBytes)))
+	w.WriteHeader(http.StatusOK)
+	w.Write(excelBytes)
+}
@@ -1,16 +1,14 @@
 package poi
 
 import (
-	"io"
 	"net/http"
-	"path/filepath"
 	"strconv"
-	"strings"
 	"time"
 
 	"github.com/julienschmidt/httprouter"
 	"github.com/malikabdulaziz/tmn-backend/exceptions"
 	"github.com/malikabdulaziz/tmn-backend/helpers"
+	"github.com/malikabdulaziz/tmn-backend/importer"
 	servicesPOI "github.com/malikabdulaziz/tmn-backend/services/poi"
 	"github.com/malikabdulaziz/tmn-backend/web"
 	webPOI "github.com/malikabdulaziz/tmn-backend/web/poi"
@@ -124,37 +122,65 @@ func (controller *ControllerPOIImpl) Delete(w http.ResponseWriter, r *http.Reque
 
 // Import handles POST /pois/import
 func (controller *ControllerPOIImpl) Import(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
-	err := r.ParseMultipartForm(32 << 20) // 32MB max
-	if err != nil {
-		panic(exceptions.NewBadRequestError("Failed to parse upload. Max file size is 32MB."))
-	}
+	fileBytes, ext := importer.ReadUpload(r)
 
-	file, header, err := r.FormFile("file")
-	if err != nil {
-		panic(exceptions.NewBadRequestError("File is required. Use form field 'file'."))
+	poiResponses, report := controller.service.Import(r.Context(), fileBytes, ext, readImportOptions(r))
+
+	response := web.WebResponse{
+		Status: "OK",
+		Code:   http.StatusCreated,
+		Data:   poiResponses,
+		Extras: report,
 	}
-	defer file.Close()
 
-	fileBytes, err := io.ReadAll(file)
-	helpers.PanicIfError(err)
+	helpers.ReturnReponseJSON(w, response)
+}
+
+// PreviewImport handles POST /pois-import-preview
+func (controller *ControllerPOIImpl) PreviewImport(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+	fileBytes, ext := importer.ReadUpload(r)
+
+	preview := controller.service.PreviewImport(r.Context(), fileBytes, ext, readImportOptions(r))
 
-	// Determine file type from extension
-	ext := strings.ToLower(strings.TrimPrefix(filepath.Ext(header.Filename), "."))
-	if ext != "xlsx" && ext != "csv" {
-		panic(exceptions.NewBadRequestError("Unsupported file type. Use .xlsx or .csv files."))
+	response := web.WebResponse{
+		Status: "OK",
+		Code:   http.StatusOK,
+		Data:   preview,
 	}
 
-	poiResponses := controller.service.Import(r.Context(), fileBytes, ext)
+	helpers.ReturnReponseJSON(w, response)
+}
+
+// ConfirmImport handles POST /pois-import-confirm
+func (controller *ControllerPOIImpl) ConfirmImport(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+	request := r.Context().Value(helpers.ContextKey("confirmPOIImportRequest")).(webPOI.ConfirmPOIImportRequest)
+
+	poiResponses, report := controller.service.ConfirmImport(r.Context(), request)
 
 	response := web.WebResponse{
 		Status: "OK",
 		Code:   http.StatusCreated,
 		Data:   poiResponses,
+		Extras: report,
 	}
 
 	helpers.ReturnReponseJSON(w, response)
 }
 
+// readImportOptions reads the optional "mode", "delete_missing" and "on_error" form fields of an
+// import upload. Call after importer.ReadUpload, which parses the multipart form.
+func readImportOptions(r *http.Request) webPOI.POIImportOptions {
+	options := webPOI.POIImportOptions{Mode: r.FormValue("mode"), OnError: importer.OnErrorFromRequest(r)}
+	if raw := r.FormValue("delete_missing"); raw != "" {
+		deleteMissing, err := strconv.ParseBool(raw)
+		if err != nil {
+			panic(exceptions.NewBadRequestError("delete_missing must be true or false."))
+		}
+		options.DeleteMissing = deleteMissing
+	}
+	return options
+}
+
 // Export handles GET /pois/export
 func (controller *ControllerPOIImpl) Export(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
 	search := r.URL.Query().Get("search")
@@ -173,3 +199,17 @@ func (controller *ControllerPOIImpl) Export(w http.ResponseWriter, r *http.Reque
 	w.WriteHeader(http.StatusOK)
 	w.Write(excelBytes)
 }
+
+// ImportTemplate handles GET /pois-import-template
+func (controller *ControllerPOIImpl) ImportTemplate(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+	excelBytes, err := controller.service.ImportTemplate(r.Context())
+	helpers.PanicIfError(err)
+
+	filename := "POI_Import_Template.xlsx"
+
+	w.Header().Set("Content-Type", "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet")
+	w.Header().Set("Content-Disposition", "attachment; filename=\""+filename+"\"")
+	w.Header().Set("Content-Length", strconv.Itoa(len(excelBytes)))
+	w.WriteHeader(http.StatusOK)
+	w.Write(excelBytes)
+}
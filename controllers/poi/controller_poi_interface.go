@@ -13,5 +13,8 @@ type ControllerPOIInterface interface {
 	Update(w http.ResponseWriter, r *http.Request, p httprouter.Params)
 	Delete(w http.ResponseWriter, r *http.Request, p httprouter.Params)
 	Import(w http.ResponseWriter, r *http.Request, p httprouter.Params)
+	PreviewImport(w http.ResponseWriter, r *http.Request, p httprouter.Params)
+	ConfirmImport(w http.ResponseWriter, r *http.Request, p httprouter.Params)
 	Export(w http.ResponseWriter, r *http.Request, p httprouter.Params)
+	ImportTemplate(w http.ResponseWriter, r *http.Request, p httprouter.Params)
 }
@@ -0,0 +1,94 @@
+package poiduplicate
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/malikabdulaziz/tmn-backend/exceptions"
+	"github.com/malikabdulaziz/tmn-backend/helpers"
+	servicesPOIDuplicate "github.com/malikabdulaziz/tmn-backend/services/poiduplicate"
+	"github.com/malikabdulaziz/tmn-backend/web"
+	webPOIDuplicate "github.com/malikabdulaziz/tmn-backend/web/poiduplicate"
+)
+
+type ControllerPOIDuplicateImpl struct {
+	service servicesPOIDuplicate.ServicePOIDuplicateInterface
+}
+
+func NewControllerPOIDuplicateImpl(service servicesPOIDuplicate.ServicePOIDuplicateInterface) ControllerPOIDuplicateInterface {
+	return &ControllerPOIDuplicateImpl{
+		service: service,
+	}
+}
+
+// FindAll handles GET /poi-duplicates?radius_meters=&same_spot_meters=&min_similarity=&category_ids=&mother_brand_ids=
+func (controller *ControllerPOIDuplicateImpl) FindAll(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+	var request webPOIDuplicate.POIDuplicateRequestFindAll
+
+	web.SetPagination(&request, r)
+	query := r.URL.Query()
+	for _, param := range []struct {
+		name string
+		set  func(float64)
+	}{
+		{"radius_meters", request.SetRadiusMeters},
+		{"same_spot_meters", request.SetSameSpotMeters},
+		{"min_similarity", request.SetMinSimilarity},
+	} {
+		if !query.Has(param.name) {
+			continue
+		}
+		value, err := strconv.ParseFloat(query.Get(param.name), 64)
+		if err != nil {
+			panic(exceptions.NewBadRequest(param.name + " must be a number"))
+		}
+		param.set(value)
+	}
+	request.SetCategoryIds(query.Get("category_ids"))
+	request.SetMotherBrandIds(query.Get("mother_brand_ids"))
+
+	duplicates, total := controller.service.FindAll(r.Context(), request)
+
+	response := web.WebResponse{
+		Status: "OK",
+		Code:   http.StatusOK,
+		Data:   duplicates,
+		Extras: web.Pagination{
+			Take:  request.GetTake(),
+			Skip:  request.GetSkip(),
+			Total: total,
+		},
+	}
+
+	helpers.ReturnReponseJSON(w, response)
+}
+
+// Merge handles POST /poi-duplicates/merge
+func (controller *ControllerPOIDuplicateImpl) Merge(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+	request := r.Context().Value(helpers.ContextKey("mergePOIDuplicateRequest")).(webPOIDuplicate.MergePOIDuplicateRequest)
+
+	merged := controller.service.Merge(r.Context(), request)
+
+	response := web.WebResponse{
+		Status: "OK",
+		Code:   http.StatusOK,
+		Data:   merged,
+	}
+
+	helpers.ReturnReponseJSON(w, response)
+}
+
+// Dismiss handles POST /poi-duplicates/dismiss
+func (controller *ControllerPOIDuplicateImpl) Dismiss(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+	request := r.Context().Value(helpers.ContextKey("dismissPOIDuplicateRequest")).(webPOIDuplicate.DismissPOIDuplicateRequest)
+
+	controller.service.Dismiss(r.Context(), request)
+
+	response := web.WebResponse{
+		Status: "OK",
+		Code:   http.StatusOK,
+	}
+
+	helpers.ReturnReponseJSON(w, response)
+}
@@ -0,0 +1,13 @@
+package poiduplicate
+
+import (
+	"net/http"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+type ControllerPOIDuplicateInterface interface {
+	FindAll(w http.ResponseWriter, r *http.Request, p httprouter.Params)
+	Merge(w http.ResponseWriter, r *http.Request, p httprouter.Params)
+	Dismiss(w http.ResponseWriter, r *http.Request, p httprouter.Params)
+}
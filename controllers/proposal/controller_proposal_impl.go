@@ -0,0 +1,58 @@
+package proposal
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/malikabdulaziz/tmn-backend/exceptions"
+	"github.com/malikabdulaziz/tmn-backend/helpers"
+	servicesProposal "github.com/malikabdulaziz/tmn-backend/services/proposal"
+	"github.com/malikabdulaziz/tmn-backend/web"
+	webProposal "github.com/malikabdulaziz/tmn-backend/web/proposal"
+)
+
+type ControllerProposalImpl struct {
+	service servicesProposal.ServiceProposalInterface
+}
+
+func NewControllerProposalImpl(service servicesProposal.ServiceProposalInterface) ControllerProposalInterface {
+	return &ControllerProposalImpl{service: service}
+}
+
+// Generate handles GET /sales-packages/:id/proposal?format=pdf|pptx&template=&client_name=&version=
+func (c *ControllerProposalImpl) Generate(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+	id, err := strconv.Atoi(p.ByName("id"))
+	if err != nil {
+		panic(exceptions.NewBadRequest("invalid sales package id"))
+	}
+	query := r.URL.Query()
+	request := webProposal.GenerateProposalRequest{
+		Format:     query.Get("format"),
+		Template:   query.Get("template"),
+		ClientName: query.Get("client_name"),
+	}
+	if raw := query.Get("version"); raw != "" {
+		version, err := strconv.Atoi(raw)
+		if err != nil || version <= 0 {
+			panic(exceptions.NewBadRequest("version must be a version number"))
+		}
+		request.Version = version
+	}
+
+	file, err := c.service.Generate(r.Context(), id, request)
+	helpers.PanicIfError(err)
+
+	w.Header().Set("Content-Type", file.ContentType)
+	w.Header().Set("Content-Disposition", "attachment; filename=\""+file.Filename+"\"")
+	w.Header().Set("Content-Length", strconv.Itoa(len(file.Content)))
+	w.Header().Set("X-Sales-Package-Version", strconv.Itoa(file.Version))
+	w.WriteHeader(http.StatusOK)
+	w.Write(file.Content)
+}
+
+// Templates handles GET /proposal-templates
+func (c *ControllerProposalImpl) Templates(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+	resp := c.service.Templates(r.Context())
+	helpers.ReturnReponseJSON(w, web.WebResponse{Status: "OK", Code: http.StatusOK, Data: resp})
+}
@@ -0,0 +1,12 @@
+package proposal
+
+import (
+	"net/http"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+type ControllerProposalInterface interface {
+	Generate(w http.ResponseWriter, r *http.Request, p httprouter.Params)
+	Templates(w http.ResponseWriter, r *http.Request, p httprouter.Params)
+}
@@ -1,16 +1,14 @@
 package salespackage
 
 import (
-	"io"
 	"net/http"
-	"path/filepath"
 	"strconv"
-	"strings"
 	"time"
 
 	"github.com/julienschmidt/httprouter"
 	"github.com/malikabdulaziz/tmn-backend/exceptions"
 	"github.com/malikabdulaziz/tmn-backend/helpers"
+	"github.com/malikabdulaziz/tmn-backend/importer"
 	servicesSalesPackage "github.com/malikabdulaziz/tmn-backend/services/salespackage"
 	"github.com/malikabdulaziz/tmn-backend/web"
 	webSalesPackage "github.com/malikabdulaziz/tmn-backend/web/salespackage"
@@ -69,33 +67,111 @@ func (c *ControllerSalesPackageImpl) Delete(w http.ResponseWriter, r *http.Reque
 	helpers.ReturnReponseJSON(w, web.WebResponse{Status: "OK", Code: http.StatusOK, Data: "Sales package deleted successfully"})
 }
 
-// Import handles POST /sales-packages-import
-func (c *ControllerSalesPackageImpl) Import(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
-	err := r.ParseMultipartForm(32 << 20) // 32MB max
+// Summary handles GET /sales-packages/:id/summary
+func (c *ControllerSalesPackageImpl) Summary(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+	id, err := strconv.Atoi(p.ByName("id"))
 	if err != nil {
-		panic(exceptions.NewBadRequestError("Failed to parse upload. Max file size is 32MB."))
+		panic(exceptions.NewBadRequest("invalid sales package id"))
 	}
+	resp := c.service.Summary(r.Context(), id)
+	helpers.ReturnReponseJSON(w, web.WebResponse{Status: "OK", Code: http.StatusOK, Data: resp})
+}
+
+// CreateFromFilter handles POST /sales-packages-from-filter
+func (c *ControllerSalesPackageImpl) CreateFromFilter(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+	request := r.Context().Value(helpers.ContextKey("salesPackageFromFilterRequest")).(webSalesPackage.SalesPackageFromFilterRequest)
+	resp := c.service.CreateFromFilter(r.Context(), request)
+	helpers.ReturnReponseJSON(w, web.WebResponse{Status: "OK", Code: http.StatusCreated, Data: resp})
+}
 
-	file, header, err := r.FormFile("file")
+// UpdateFromFilter handles PUT /sales-packages/:id/from-filter
+func (c *ControllerSalesPackageImpl) UpdateFromFilter(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+	id := r.Context().Value(helpers.ContextKey("salesPackageId")).(int)
+	request := r.Context().Value(helpers.ContextKey("salesPackageFromFilterRequest")).(webSalesPackage.SalesPackageFromFilterRequest)
+	resp := c.service.UpdateFromFilter(r.Context(), request, id)
+	helpers.ReturnReponseJSON(w, web.WebResponse{Status: "OK", Code: http.StatusOK, Data: resp})
+}
+
+// PreviewRefresh handles GET /sales-packages/:id/refresh-preview
+func (c *ControllerSalesPackageImpl) PreviewRefresh(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+	id, err := strconv.Atoi(p.ByName("id"))
 	if err != nil {
-		panic(exceptions.NewBadRequestError("File is required. Use form field 'file'."))
+		panic(exceptions.NewBadRequest("invalid sales package id"))
 	}
-	defer file.Close()
+	resp := c.service.PreviewRefresh(r.Context(), id)
+	helpers.ReturnReponseJSON(w, web.WebResponse{Status: "OK", Code: http.StatusOK, Data: resp})
+}
 
-	fileBytes, err := io.ReadAll(file)
-	helpers.PanicIfError(err)
+// Refresh handles POST /sales-packages/:id/refresh
+func (c *ControllerSalesPackageImpl) Refresh(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+	id, err := strconv.Atoi(p.ByName("id"))
+	if err != nil {
+		panic(exceptions.NewBadRequest("invalid sales package id"))
+	}
+	resp := c.service.Refresh(r.Context(), id)
+	helpers.ReturnReponseJSON(w, web.WebResponse{Status: "OK", Code: http.StatusOK, Data: resp})
+}
+
+// Versions handles GET /sales-packages/:id/versions
+func (c *ControllerSalesPackageImpl) Versions(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+	id, err := strconv.Atoi(p.ByName("id"))
+	if err != nil {
+		panic(exceptions.NewBadRequest("invalid sales package id"))
+	}
+	resp := c.service.Versions(r.Context(), id)
+	helpers.ReturnReponseJSON(w, web.WebResponse{Status: "OK", Code: http.StatusOK, Data: resp})
+}
+
+// FindVersion handles GET /sales-packages/:id/versions/:version
+func (c *ControllerSalesPackageImpl) FindVersion(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+	id, err := strconv.Atoi(p.ByName("id"))
+	if err != nil {
+		panic(exceptions.NewBadRequest("invalid sales package id"))
+	}
+	version, err := strconv.Atoi(p.ByName("version"))
+	if err != nil || version <= 0 {
+		panic(exceptions.NewBadRequest("invalid sales package version"))
+	}
+	resp := c.service.FindVersion(r.Context(), id, version)
+	helpers.ReturnReponseJSON(w, web.WebResponse{Status: "OK", Code: http.StatusOK, Data: resp})
+}
 
-	ext := strings.ToLower(strings.TrimPrefix(filepath.Ext(header.Filename), "."))
-	if ext != "xlsx" && ext != "csv" {
-		panic(exceptions.NewBadRequestError("Unsupported file type. Use .xlsx or .csv files."))
+// DiffVersions handles GET /sales-packages/:id/version-diff?from=&to=
+func (c *ControllerSalesPackageImpl) DiffVersions(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+	id, err := strconv.Atoi(p.ByName("id"))
+	if err != nil {
+		panic(exceptions.NewBadRequest("invalid sales package id"))
 	}
+	query := r.URL.Query()
+	resp := c.service.DiffVersions(r.Context(), id, queryInt(query.Get("from"), "from"), queryInt(query.Get("to"), "to"))
+	helpers.ReturnReponseJSON(w, web.WebResponse{Status: "OK", Code: http.StatusOK, Data: resp})
+}
 
-	responses := c.service.Import(r.Context(), fileBytes, ext)
+// RestoreVersion handles POST /sales-packages/:id/versions/:version/restore
+func (c *ControllerSalesPackageImpl) RestoreVersion(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+	id, err := strconv.Atoi(p.ByName("id"))
+	if err != nil {
+		panic(exceptions.NewBadRequest("invalid sales package id"))
+	}
+	version, err := strconv.Atoi(p.ByName("version"))
+	if err != nil || version <= 0 {
+		panic(exceptions.NewBadRequest("invalid sales package version"))
+	}
+	resp := c.service.RestoreVersion(r.Context(), id, version)
+	helpers.ReturnReponseJSON(w, web.WebResponse{Status: "OK", Code: http.StatusOK, Data: resp})
+}
+
+// Import handles POST /sales-packages-import
+func (c *ControllerSalesPackageImpl) Import(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+	fileBytes, ext := importer.ReadUpload(r)
+
+	responses, report := c.service.Import(r.Context(), fileBytes, ext, importer.OnErrorFromRequest(r))
 
 	helpers.ReturnReponseJSON(w, web.WebResponse{
 		Status: "OK",
 		Code:   http.StatusCreated,
 		Data:   responses,
+		Extras: report,
 	})
 }
 
@@ -114,3 +190,28 @@ func (c *ControllerSalesPackageImpl) Export(w http.ResponseWriter, r *http.Reque
 	w.WriteHeader(http.StatusOK)
 	w.Write(excelBytes)
 }
+
+// ImportTemplate handles GET /sales-packages-import-template
+func (c *ControllerSalesPackageImpl) ImportTemplate(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+	excelBytes, err := c.service.ImportTemplate(r.Context())
+	helpers.PanicIfError(err)
+
+	filename := "SalesPackage_Import_Template.xlsx"
+
+	w.Header().Set("Content-Type", "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet")
+	w.Header().Set("Content-Disposition", "attachment; filename=\""+filename+"\"")
+	w.Header().Set("Content-Length", strconv.Itoa(len(excelBytes)))
+	w.WriteHeader(http.StatusOK)
+	w.Write(excelBytes)
+}
+
+func queryInt(value string, name string) int {
+	if value == "" {
+		return 0
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		panic(exceptions.NewBadRequest(name + " must be a number"))
+	}
+	return n
+}
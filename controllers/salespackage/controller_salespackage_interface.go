@@ -12,6 +12,16 @@ type ControllerSalesPackageInterface interface {
 	FindById(w http.ResponseWriter, r *http.Request, p httprouter.Params)
 	Update(w http.ResponseWriter, r *http.Request, p httprouter.Params)
 	Delete(w http.ResponseWriter, r *http.Request, p httprouter.Params)
+	Summary(w http.ResponseWriter, r *http.Request, p httprouter.Params)
+	CreateFromFilter(w http.ResponseWriter, r *http.Request, p httprouter.Params)
+	UpdateFromFilter(w http.ResponseWriter, r *http.Request, p httprouter.Params)
+	PreviewRefresh(w http.ResponseWriter, r *http.Request, p httprouter.Params)
+	Refresh(w http.ResponseWriter, r *http.Request, p httprouter.Params)
+	Versions(w http.ResponseWriter, r *http.Request, p httprouter.Params)
+	FindVersion(w http.ResponseWriter, r *http.Request, p httprouter.Params)
+	DiffVersions(w http.ResponseWriter, r *http.Request, p httprouter.Params)
+	RestoreVersion(w http.ResponseWriter, r *http.Request, p httprouter.Params)
 	Import(w http.ResponseWriter, r *http.Request, p httprouter.Params)
 	Export(w http.ResponseWriter, r *http.Request, p httprouter.Params)
+	ImportTemplate(w http.ResponseWriter, r *http.Request, p httprouter.Params)
 }
@@ -0,0 +1,69 @@
+package savedview
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/malikabdulaziz/tmn-backend/exceptions"
+	"github.com/malikabdulaziz/tmn-backend/helpers"
+	servicesSavedView "github.com/malikabdulaziz/tmn-backend/services/savedview"
+	"github.com/malikabdulaziz/tmn-backend/web"
+	webSavedView "github.com/malikabdulaziz/tmn-backend/web/savedview"
+)
+
+type ControllerSavedViewImpl struct {
+	service servicesSavedView.ServiceSavedViewInterface
+}
+
+func NewControllerSavedViewImpl(service servicesSavedView.ServiceSavedViewInterface) ControllerSavedViewInterface {
+	return &ControllerSavedViewImpl{service: service}
+}
+
+// Create handles POST /saved-views
+func (c *ControllerSavedViewImpl) Create(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+	request := r.Context().Value(helpers.ContextKey("createSavedViewRequest")).(webSavedView.CreateSavedViewRequest)
+	resp := c.service.Create(r.Context(), request)
+	helpers.ReturnReponseJSON(w, web.WebResponse{Status: "OK", Code: http.StatusCreated, Data: resp})
+}
+
+// FindAll handles GET /saved-views?scope=mine|shared&name=
+func (c *ControllerSavedViewImpl) FindAll(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+	var request webSavedView.SavedViewRequestFindAll
+	web.SetPagination(&request, r)
+	web.SetOrder(&request, r)
+	query := r.URL.Query()
+	request.SetScope(query.Get("scope"))
+	request.SetName(query.Get("name"))
+	list, total := c.service.FindAll(r.Context(), request)
+	pagination := web.Pagination{Take: request.GetTake(), Skip: request.GetSkip(), Total: total}
+	helpers.ReturnReponseJSON(w, web.WebResponse{Status: "OK", Code: http.StatusOK, Data: list, Extras: pagination})
+}
+
+// FindById handles GET /saved-views/:id
+func (c *ControllerSavedViewImpl) FindById(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+	id, err := strconv.Atoi(p.ByName("id"))
+	if err != nil {
+		panic(exceptions.NewBadRequest("invalid saved view id"))
+	}
+	resp := c.service.FindById(r.Context(), id)
+	helpers.ReturnReponseJSON(w, web.WebResponse{Status: "OK", Code: http.StatusOK, Data: resp})
+}
+
+// Update handles PUT /saved-views/:id
+func (c *ControllerSavedViewImpl) Update(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+	id := r.Context().Value(helpers.ContextKey("savedViewId")).(int)
+	request := r.Context().Value(helpers.ContextKey("updateSavedViewRequest")).(webSavedView.UpdateSavedViewRequest)
+	resp := c.service.Update(r.Context(), request, id)
+	helpers.ReturnReponseJSON(w, web.WebResponse{Status: "OK", Code: http.StatusOK, Data: resp})
+}
+
+// Delete handles DELETE /saved-views/:id
+func (c *ControllerSavedViewImpl) Delete(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+	id, err := strconv.Atoi(p.ByName("id"))
+	if err != nil {
+		panic(exceptions.NewBadRequest("invalid saved view id"))
+	}
+	c.service.Delete(r.Context(), id)
+	helpers.ReturnReponseJSON(w, web.WebResponse{Status: "OK", Code: http.StatusOK, Data: "Saved view deleted successfully"})
+}
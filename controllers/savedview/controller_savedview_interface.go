@@ -0,0 +1,15 @@
+package savedview
+
+import (
+	"net/http"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+type ControllerSavedViewInterface interface {
+	Create(w http.ResponseWriter, r *http.Request, p httprouter.Params)
+	FindAll(w http.ResponseWriter, r *http.Request, p httprouter.Params)
+	FindById(w http.ResponseWriter, r *http.Request, p httprouter.Params)
+	Update(w http.ResponseWriter, r *http.Request, p httprouter.Params)
+	Delete(w http.ResponseWriter, r *http.Request, p httprouter.Params)
+}
@@ -0,0 +1,71 @@
+package sharelink
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/malikabdulaziz/tmn-backend/exceptions"
+	"github.com/malikabdulaziz/tmn-backend/helpers"
+	servicesShareLink "github.com/malikabdulaziz/tmn-backend/services/sharelink"
+	"github.com/malikabdulaziz/tmn-backend/web"
+	webShareLink "github.com/malikabdulaziz/tmn-backend/web/sharelink"
+)
+
+type ControllerShareLinkImpl struct {
+	service servicesShareLink.ServiceShareLinkInterface
+}
+
+func NewControllerShareLinkImpl(service servicesShareLink.ServiceShareLinkInterface) ControllerShareLinkInterface {
+	return &ControllerShareLinkImpl{service: service}
+}
+
+// Create handles POST /share-links
+func (c *ControllerShareLinkImpl) Create(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+	request := r.Context().Value(helpers.ContextKey("createShareLinkRequest")).(webShareLink.CreateShareLinkRequest)
+	resp := c.service.Create(r.Context(), request)
+	helpers.ReturnReponseJSON(w, web.WebResponse{Status: "OK", Code: http.StatusCreated, Data: resp})
+}
+
+// FindAll handles GET /share-links?target_type=&sales_package_id=&saved_polygon_id=
+func (c *ControllerShareLinkImpl) FindAll(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+	var request webShareLink.ShareLinkRequestFindAll
+	web.SetPagination(&request, r)
+	web.SetOrder(&request, r)
+	query := r.URL.Query()
+	request.SetTargetType(query.Get("target_type"))
+	request.SetSalesPackageId(queryInt(query.Get("sales_package_id"), "sales_package_id"))
+	request.SetSavedPolygonId(queryInt(query.Get("saved_polygon_id"), "saved_polygon_id"))
+
+	list, total := c.service.FindAll(r.Context(), request)
+	pagination := web.Pagination{Take: request.GetTake(), Skip: request.GetSkip(), Total: total}
+	helpers.ReturnReponseJSON(w, web.WebResponse{Status: "OK", Code: http.StatusOK, Data: list, Extras: pagination})
+}
+
+// Revoke handles DELETE /share-links/:id
+func (c *ControllerShareLinkImpl) Revoke(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+	id, err := strconv.Atoi(p.ByName("id"))
+	if err != nil {
+		panic(exceptions.NewBadRequest("invalid share link id"))
+	}
+	resp := c.service.Revoke(r.Context(), id)
+	helpers.ReturnReponseJSON(w, web.WebResponse{Status: "OK", Code: http.StatusOK, Data: resp})
+}
+
+// View handles the public GET /shared/:token; password protected links take the password in
+// the X-Share-Password header
+func (c *ControllerShareLinkImpl) View(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+	resp := c.service.View(r.Context(), p.ByName("token"), r.Header.Get("X-Share-Password"))
+	helpers.ReturnReponseJSON(w, web.WebResponse{Status: "OK", Code: http.StatusOK, Data: resp})
+}
+
+func queryInt(value string, name string) int {
+	if value == "" {
+		return 0
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		panic(exceptions.NewBadRequest(name + " must be a number"))
+	}
+	return n
+}
@@ -0,0 +1,14 @@
+package sharelink
+
+import (
+	"net/http"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+type ControllerShareLinkInterface interface {
+	Create(w http.ResponseWriter, r *http.Request, p httprouter.Params)
+	FindAll(w http.ResponseWriter, r *http.Request, p httprouter.Params)
+	Revoke(w http.ResponseWriter, r *http.Request, p httprouter.Params)
+	View(w http.ResponseWriter, r *http.Request, p httprouter.Params)
+}
@@ -0,0 +1,41 @@
+package trash
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/malikabdulaziz/tmn-backend/exceptions"
+	"github.com/malikabdulaziz/tmn-backend/helpers"
+	servicesTrash "github.com/malikabdulaziz/tmn-backend/services/trash"
+	"github.com/malikabdulaziz/tmn-backend/web"
+	webTrash "github.com/malikabdulaziz/tmn-backend/web/trash"
+)
+
+type ControllerTrashImpl struct {
+	service servicesTrash.ServiceTrashInterface
+}
+
+func NewControllerTrashImpl(service servicesTrash.ServiceTrashInterface) ControllerTrashInterface {
+	return &ControllerTrashImpl{service: service}
+}
+
+// FindAll handles GET /trash?type=
+func (c *ControllerTrashImpl) FindAll(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+	var request webTrash.TrashRequestFindAll
+	web.SetPagination(&request, r)
+	request.SetType(r.URL.Query().Get("type"))
+	list, total := c.service.FindAll(r.Context(), request)
+	pagination := web.Pagination{Take: request.GetTake(), Skip: request.GetSkip(), Total: total}
+	helpers.ReturnReponseJSON(w, web.WebResponse{Status: "OK", Code: http.StatusOK, Data: list, Extras: pagination})
+}
+
+// Restore handles POST /trash/:type/:id/restore
+func (c *ControllerTrashImpl) Restore(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+	id, err := strconv.Atoi(p.ByName("id"))
+	if err != nil {
+		panic(exceptions.NewBadRequest("invalid id"))
+	}
+	resp := c.service.Restore(r.Context(), p.ByName("type"), id)
+	helpers.ReturnReponseJSON(w, web.WebResponse{Status: "OK", Code: http.StatusOK, Data: resp})
+}
@@ -0,0 +1,12 @@
+package trash
+
+import (
+	"net/http"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+type ControllerTrashInterface interface {
+	FindAll(w http.ResponseWriter, r *http.Request, p httprouter.Params)
+	Restore(w http.ResponseWriter, r *http.Request, p httprouter.Params)
+}
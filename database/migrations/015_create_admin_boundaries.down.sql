@@ -0,0 +1,9 @@
+DROP INDEX IF EXISTS idx_buildings_subdistrict_code;
+DROP INDEX IF EXISTS idx_buildings_city_code;
+DROP INDEX IF EXISTS idx_buildings_province_code;
+
+ALTER TABLE buildings DROP COLUMN IF EXISTS subdistrict_code;
+ALTER TABLE buildings DROP COLUMN IF EXISTS city_code;
+ALTER TABLE buildings DROP COLUMN IF EXISTS province_code;
+
+DROP TABLE IF EXISTS admin_boundaries;
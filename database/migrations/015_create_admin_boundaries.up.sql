@@ -0,0 +1,24 @@
+-- Official administrative boundaries (province, kabupaten/kota, kecamatan)
+CREATE TABLE IF NOT EXISTS admin_boundaries (
+    id BIGSERIAL PRIMARY KEY,
+    code VARCHAR(20) NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    level VARCHAR(20) NOT NULL CHECK (level IN ('province', 'city', 'subdistrict')),
+    parent_code VARCHAR(20),
+    geom GEOMETRY(MULTIPOLYGON, 4326) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT uq_admin_boundaries_level_code UNIQUE (level, code)
+);
+
+CREATE INDEX IF NOT EXISTS idx_admin_boundaries_parent_code ON admin_boundaries(parent_code);
+CREATE INDEX IF NOT EXISTS idx_admin_boundaries_geom_gist ON admin_boundaries USING GIST(geom);
+
+-- Region codes resolved from buildings.location by spatial join
+ALTER TABLE buildings ADD COLUMN IF NOT EXISTS province_code VARCHAR(20);
+ALTER TABLE buildings ADD COLUMN IF NOT EXISTS city_code VARCHAR(20);
+ALTER TABLE buildings ADD COLUMN IF NOT EXISTS subdistrict_code VARCHAR(20);
+
+CREATE INDEX IF NOT EXISTS idx_buildings_province_code ON buildings(province_code);
+CREATE INDEX IF NOT EXISTS idx_buildings_city_code ON buildings(city_code);
+CREATE INDEX IF NOT EXISTS idx_buildings_subdistrict_code ON buildings(subdistrict_code);
@@ -0,0 +1,3 @@
+-- pgRouting powers drive-time / walking catchments for the mapping POI filter.
+-- The road network itself (ways, ways_vertices_pgr) is loaded from an OSM extract with osm2pgrouting.
+CREATE EXTENSION IF NOT EXISTS pgrouting;
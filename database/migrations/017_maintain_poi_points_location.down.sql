@@ -0,0 +1,2 @@
+DROP TRIGGER IF EXISTS trg_poi_points_set_location ON poi_points;
+DROP FUNCTION IF EXISTS poi_points_set_location();
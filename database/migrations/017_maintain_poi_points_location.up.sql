@@ -0,0 +1,27 @@
+-- poi_points.location (GEOGRAPHY) was added in 003 but only the insert path filled it.
+-- A trigger now derives it from latitude/longitude on every insert and coordinate update,
+-- so create, update, import and ad-hoc SQL all keep the spatial index in step.
+CREATE OR REPLACE FUNCTION poi_points_set_location() RETURNS TRIGGER AS $$
+BEGIN
+    IF NEW.latitude IS NOT NULL AND NEW.longitude IS NOT NULL AND NEW.latitude != 0 AND NEW.longitude != 0 THEN
+        NEW.location := ST_SetSRID(ST_MakePoint(NEW.longitude, NEW.latitude), 4326)::geography;
+    ELSE
+        NEW.location := NULL;
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS trg_poi_points_set_location ON poi_points;
+CREATE TRIGGER trg_poi_points_set_location
+    BEFORE INSERT OR UPDATE OF latitude, longitude ON poi_points
+    FOR EACH ROW EXECUTE FUNCTION poi_points_set_location();
+
+-- Backfill rows written before the trigger existed
+UPDATE poi_points
+SET location = ST_SetSRID(ST_MakePoint(longitude, latitude), 4326)::geography
+WHERE location IS NULL
+  AND latitude IS NOT NULL AND longitude IS NOT NULL
+  AND latitude != 0 AND longitude != 0;
+
+CREATE INDEX IF NOT EXISTS idx_poi_points_location_gist ON poi_points USING GIST(location);
@@ -0,0 +1,15 @@
+-- Dry-run import previews: the uploaded file is kept until the user confirms (or it expires),
+-- together with a fingerprint of the diff shown so the confirm step applies exactly that preview.
+CREATE TABLE IF NOT EXISTS import_previews (
+    id BIGSERIAL PRIMARY KEY,
+    token VARCHAR(64) NOT NULL UNIQUE,
+    kind VARCHAR(50) NOT NULL,
+    file_type VARCHAR(10) NOT NULL,
+    file_bytes BYTEA NOT NULL,
+    fingerprint VARCHAR(64) NOT NULL,
+    created_by BIGINT REFERENCES users(id) ON DELETE CASCADE,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_import_previews_expires_at ON import_previews(expires_at);
@@ -0,0 +1,4 @@
+ALTER TABLE import_previews DROP COLUMN IF EXISTS options;
+
+DROP INDEX IF EXISTS idx_poi_points_poi_id_external_key;
+ALTER TABLE poi_points DROP COLUMN IF EXISTS external_key;
@@ -0,0 +1,9 @@
+-- Stable per-brand key for POI points (e.g. a store code) so upsert imports can match points
+-- even when their name or address changes.
+ALTER TABLE poi_points ADD COLUMN IF NOT EXISTS external_key VARCHAR(100);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_poi_points_poi_id_external_key
+    ON poi_points(poi_id, external_key) WHERE external_key IS NOT NULL;
+
+-- Import options (mode, delete_missing) chosen at preview time, applied on confirm
+ALTER TABLE import_previews ADD COLUMN IF NOT EXISTS options TEXT NOT NULL DEFAULT '{}';
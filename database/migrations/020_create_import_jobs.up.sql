@@ -0,0 +1,23 @@
+-- Background import jobs: the upload is stored here and applied by the import job worker in one
+-- transaction, writing progress after every batch so clients can poll /import-jobs/:id.
+CREATE TABLE IF NOT EXISTS import_jobs (
+    id BIGSERIAL PRIMARY KEY,
+    kind VARCHAR(50) NOT NULL,
+    file_type VARCHAR(10) NOT NULL,
+    file_bytes BYTEA,
+    options TEXT NOT NULL DEFAULT '{}',
+    status VARCHAR(20) NOT NULL DEFAULT 'queued',
+    progress_done INTEGER NOT NULL DEFAULT 0,
+    progress_total INTEGER NOT NULL DEFAULT 0,
+    cancel_requested BOOLEAN NOT NULL DEFAULT FALSE,
+    imported_count INTEGER NOT NULL DEFAULT 0,
+    report TEXT,
+    error TEXT,
+    created_by BIGINT REFERENCES users(id) ON DELETE CASCADE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    started_at TIMESTAMP WITH TIME ZONE,
+    finished_at TIMESTAMP WITH TIME ZONE,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_import_jobs_status_id ON import_jobs(status, id);
@@ -0,0 +1,15 @@
+-- Geocoder answers, so repeated imports of the same addresses do not query the provider again.
+-- kind is 'forward' (query_key is the normalized address) or 'reverse' (query_key is "lat,lng"
+-- rounded to 5 decimals). Misses are stored with found = FALSE and retried after a week.
+CREATE TABLE IF NOT EXISTS geocode_cache (
+    id BIGSERIAL PRIMARY KEY,
+    provider VARCHAR(50) NOT NULL,
+    kind VARCHAR(10) NOT NULL,
+    query_key TEXT NOT NULL,
+    found BOOLEAN NOT NULL,
+    latitude DOUBLE PRECISION,
+    longitude DOUBLE PRECISION,
+    address TEXT,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT uq_geocode_cache_provider_kind_key UNIQUE (provider, kind, query_key)
+);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS poi_point_duplicate_dismissals;
+-- pg_trgm is left installed; other objects may depend on it
@@ -0,0 +1,17 @@
+-- pg_trgm scores how alike two point names are for duplicate detection
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+-- Candidate pairs a reviewer marked as distinct outlets, so they are not suggested again.
+-- A pair is stored once with point_id < other_point_id.
+CREATE TABLE IF NOT EXISTS poi_point_duplicate_dismissals (
+    id BIGSERIAL PRIMARY KEY,
+    point_id BIGINT NOT NULL REFERENCES poi_points(id) ON DELETE CASCADE,
+    other_point_id BIGINT NOT NULL REFERENCES poi_points(id) ON DELETE CASCADE,
+    dismissed_by BIGINT REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT chk_poi_point_duplicate_dismissals_order CHECK (point_id < other_point_id),
+    CONSTRAINT uq_poi_point_duplicate_dismissals_pair UNIQUE (point_id, other_point_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_poi_point_duplicate_dismissals_other_point_id
+    ON poi_point_duplicate_dismissals(other_point_id);
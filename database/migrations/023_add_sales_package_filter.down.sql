@@ -0,0 +1,2 @@
+ALTER TABLE sales_packages DROP COLUMN IF EXISTS filter_refreshed_at;
+ALTER TABLE sales_packages DROP COLUMN IF EXISTS filter;
@@ -0,0 +1,4 @@
+-- Mapping filter a sales package was built from (the /mapping-buildings "filters" object),
+-- kept so the package can be refreshed when buildings change. NULL for hand-picked packages.
+ALTER TABLE sales_packages ADD COLUMN IF NOT EXISTS filter JSONB;
+ALTER TABLE sales_packages ADD COLUMN IF NOT EXISTS filter_refreshed_at TIMESTAMP;
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS booking_buildings;
+DROP TABLE IF EXISTS bookings;
@@ -0,0 +1,32 @@
+-- Campaign bookings: a client reserving screens in a set of buildings for a date range.
+-- Hold and confirmed bookings both count against a building's screen capacity; cancelled ones do not.
+CREATE TABLE IF NOT EXISTS bookings (
+    id BIGSERIAL PRIMARY KEY,
+    client_name VARCHAR(255) NOT NULL,
+    sales_package_id BIGINT REFERENCES sales_packages(id) ON DELETE SET NULL,
+    start_date DATE NOT NULL,
+    end_date DATE NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'hold',
+    notes TEXT,
+    created_by BIGINT REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT chk_bookings_status CHECK (status IN ('hold', 'confirmed', 'cancelled')),
+    CONSTRAINT chk_bookings_dates CHECK (end_date >= start_date)
+);
+
+-- Buildings of a booking with the number of screens reserved in each. Package bookings copy the
+-- package buildings at booking time so later package edits do not move existing campaigns.
+CREATE TABLE IF NOT EXISTS booking_buildings (
+    id BIGSERIAL PRIMARY KEY,
+    booking_id BIGINT NOT NULL REFERENCES bookings(id) ON DELETE CASCADE,
+    building_id BIGINT NOT NULL REFERENCES buildings(id) ON DELETE CASCADE,
+    screens INTEGER NOT NULL DEFAULT 1,
+    CONSTRAINT chk_booking_buildings_screens CHECK (screens > 0),
+    UNIQUE (booking_id, building_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_bookings_dates ON bookings(start_date, end_date);
+CREATE INDEX IF NOT EXISTS idx_bookings_status ON bookings(status);
+CREATE INDEX IF NOT EXISTS idx_bookings_sales_package_id ON bookings(sales_package_id);
+CREATE INDEX IF NOT EXISTS idx_booking_buildings_building_id ON booking_buildings(building_id);
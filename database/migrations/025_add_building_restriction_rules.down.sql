@@ -0,0 +1,8 @@
+ALTER TABLE bookings DROP COLUMN IF EXISTS advertiser_mother_brand_id;
+ALTER TABLE bookings DROP COLUMN IF EXISTS advertiser_category_id;
+DROP TABLE IF EXISTS building_restriction_mother_brands;
+DROP TABLE IF EXISTS building_restriction_categories;
+ALTER TABLE building_restrictions DROP CONSTRAINT IF EXISTS chk_building_restrictions_enforcement;
+ALTER TABLE building_restrictions DROP COLUMN IF EXISTS valid_until;
+ALTER TABLE building_restrictions DROP COLUMN IF EXISTS valid_from;
+ALTER TABLE building_restrictions DROP COLUMN IF EXISTS enforcement;
@@ -0,0 +1,29 @@
+-- Restriction rules: while valid, a restriction bars the listed categories and mother brands from
+-- advertising in its buildings. "warn" only flags the conflict, "block" rejects the package or
+-- booking. A restriction without categories or mother brands stays a plain building list.
+ALTER TABLE building_restrictions ADD COLUMN IF NOT EXISTS enforcement VARCHAR(10) NOT NULL DEFAULT 'warn';
+ALTER TABLE building_restrictions ADD COLUMN IF NOT EXISTS valid_from DATE;
+ALTER TABLE building_restrictions ADD COLUMN IF NOT EXISTS valid_until DATE;
+ALTER TABLE building_restrictions DROP CONSTRAINT IF EXISTS chk_building_restrictions_enforcement;
+ALTER TABLE building_restrictions ADD CONSTRAINT chk_building_restrictions_enforcement CHECK (enforcement IN ('warn', 'block'));
+
+CREATE TABLE IF NOT EXISTS building_restriction_categories (
+    id BIGSERIAL PRIMARY KEY,
+    building_restriction_id BIGINT NOT NULL REFERENCES building_restrictions(id) ON DELETE CASCADE,
+    category_id BIGINT NOT NULL REFERENCES categories(id) ON DELETE CASCADE,
+    UNIQUE (building_restriction_id, category_id)
+);
+
+CREATE TABLE IF NOT EXISTS building_restriction_mother_brands (
+    id BIGSERIAL PRIMARY KEY,
+    building_restriction_id BIGINT NOT NULL REFERENCES building_restrictions(id) ON DELETE CASCADE,
+    mother_brand_id BIGINT NOT NULL REFERENCES mother_brands(id) ON DELETE CASCADE,
+    UNIQUE (building_restriction_id, mother_brand_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_building_restriction_categories_category_id ON building_restriction_categories(category_id);
+CREATE INDEX IF NOT EXISTS idx_building_restriction_mother_brands_mother_brand_id ON building_restriction_mother_brands(mother_brand_id);
+
+-- Advertiser a booking is sold to, checked against the restrictions over the booking dates
+ALTER TABLE bookings ADD COLUMN IF NOT EXISTS advertiser_category_id BIGINT REFERENCES categories(id) ON DELETE SET NULL;
+ALTER TABLE bookings ADD COLUMN IF NOT EXISTS advertiser_mother_brand_id BIGINT REFERENCES mother_brands(id) ON DELETE SET NULL;
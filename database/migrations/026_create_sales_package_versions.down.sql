@@ -0,0 +1,3 @@
+ALTER TABLE bookings DROP COLUMN IF EXISTS sales_package_version_id;
+DROP TABLE IF EXISTS sales_package_version_buildings;
+DROP TABLE IF EXISTS sales_package_versions;
@@ -0,0 +1,66 @@
+-- Immutable snapshots of a sales package, one per change. Version numbers count up per package.
+-- Totals are stored as computed when the version was taken, so they do not move when building
+-- figures change later.
+CREATE TABLE IF NOT EXISTS sales_package_versions (
+    id BIGSERIAL PRIMARY KEY,
+    sales_package_id BIGINT NOT NULL REFERENCES sales_packages(id) ON DELETE CASCADE,
+    version INTEGER NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    filter JSONB,
+    note VARCHAR(255),
+    building_count INTEGER NOT NULL DEFAULT 0,
+    total_audience BIGINT NOT NULL DEFAULT 0,
+    total_impression BIGINT NOT NULL DEFAULT 0,
+    total_screens INTEGER NOT NULL DEFAULT 0,
+    created_by BIGINT REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (sales_package_id, version)
+);
+
+-- Buildings of a version with their figures at the time. The name is kept so a version still
+-- lists a building after it is deleted.
+CREATE TABLE IF NOT EXISTS sales_package_version_buildings (
+    id BIGSERIAL PRIMARY KEY,
+    sales_package_version_id BIGINT NOT NULL REFERENCES sales_package_versions(id) ON DELETE CASCADE,
+    building_id BIGINT REFERENCES buildings(id) ON DELETE SET NULL,
+    building_name VARCHAR(255) NOT NULL,
+    audience INTEGER NOT NULL DEFAULT 0,
+    impression INTEGER NOT NULL DEFAULT 0,
+    screens INTEGER NOT NULL DEFAULT 0
+);
+
+CREATE INDEX IF NOT EXISTS idx_sales_package_version_buildings_version_id ON sales_package_version_buildings(sales_package_version_id);
+
+-- Bookings remember the package version they were made from
+ALTER TABLE bookings ADD COLUMN IF NOT EXISTS sales_package_version_id BIGINT REFERENCES sales_package_versions(id) ON DELETE SET NULL;
+
+-- Existing packages start at version 1
+INSERT INTO sales_package_versions (sales_package_id, version, name, filter, note)
+SELECT sp.id, 1, sp.name, sp.filter, 'initial version'
+FROM sales_packages sp
+WHERE NOT EXISTS (SELECT 1 FROM sales_package_versions v WHERE v.sales_package_id = sp.id);
+
+INSERT INTO sales_package_version_buildings (sales_package_version_id, building_id, building_name, audience, impression, screens)
+SELECT v.id, b.id, b.name, COALESCE(b.audience, 0), COALESCE(b.impression, 0), COALESCE(bp.number_of_screen, 0)
+FROM sales_package_versions v
+INNER JOIN sales_package_buildings spb ON spb.sales_package_id = v.sales_package_id
+INNER JOIN buildings b ON b.id = spb.building_id
+LEFT JOIN LATERAL (
+    SELECT number_of_screen FROM building_proposals
+    WHERE building_project = b.project_name
+    ORDER BY modified DESC NULLS LAST, id DESC LIMIT 1
+) bp ON b.project_name <> ''
+WHERE v.version = 1 AND v.note = 'initial version'
+    AND NOT EXISTS (SELECT 1 FROM sales_package_version_buildings vb WHERE vb.sales_package_version_id = v.id);
+
+UPDATE sales_package_versions v SET
+    building_count = t.building_count,
+    total_audience = t.total_audience,
+    total_impression = t.total_impression,
+    total_screens = t.total_screens
+FROM (
+    SELECT sales_package_version_id, COUNT(*) AS building_count, SUM(audience) AS total_audience,
+        SUM(impression) AS total_impression, SUM(screens) AS total_screens
+    FROM sales_package_version_buildings GROUP BY sales_package_version_id
+) t
+WHERE t.sales_package_version_id = v.id;
@@ -0,0 +1,23 @@
+-- Read-only links to a sales package version, a saved polygon or a set of mapping filters that
+-- can be opened without logging in. The key is random; the token handed out is the key plus an
+-- HMAC of it, so links stop working when the signing secret is rotated.
+CREATE TABLE IF NOT EXISTS share_links (
+    id BIGSERIAL PRIMARY KEY,
+    key VARCHAR(64) NOT NULL UNIQUE,
+    target_type VARCHAR(32) NOT NULL,
+    sales_package_id BIGINT REFERENCES sales_packages(id) ON DELETE CASCADE,
+    sales_package_version_id BIGINT REFERENCES sales_package_versions(id) ON DELETE CASCADE,
+    saved_polygon_id BIGINT REFERENCES saved_polygons(id) ON DELETE CASCADE,
+    filter JSONB,
+    title VARCHAR(255) NOT NULL,
+    password_hash VARCHAR(255),
+    expires_at TIMESTAMP NOT NULL,
+    revoked_at TIMESTAMP,
+    view_count INTEGER NOT NULL DEFAULT 0,
+    last_viewed_at TIMESTAMP,
+    created_by BIGINT REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_share_links_sales_package_id ON share_links(sales_package_id);
+CREATE INDEX IF NOT EXISTS idx_share_links_created_by ON share_links(created_by);
@@ -0,0 +1,18 @@
+-- Named mapping filter presets. Each view belongs to the user who saved it; shared views are
+-- visible to every user but can only be changed by their owner.
+CREATE TABLE IF NOT EXISTS saved_views (
+    id BIGSERIAL PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    filters JSONB NOT NULL DEFAULT '{}',
+    map_center_lat DOUBLE PRECISION,
+    map_center_lng DOUBLE PRECISION,
+    zoom DOUBLE PRECISION,
+    sort_by VARCHAR(32),
+    is_shared BOOLEAN NOT NULL DEFAULT FALSE,
+    created_by BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (created_by, name)
+);
+
+CREATE INDEX IF NOT EXISTS idx_saved_views_is_shared ON saved_views(is_shared) WHERE is_shared;
@@ -0,0 +1,4 @@
+ALTER TABLE building_restrictions DROP COLUMN IF EXISTS visibility, DROP COLUMN IF EXISTS updated_by, DROP COLUMN IF EXISTS created_by;
+ALTER TABLE sales_packages DROP COLUMN IF EXISTS visibility, DROP COLUMN IF EXISTS updated_by, DROP COLUMN IF EXISTS created_by;
+ALTER TABLE saved_polygons DROP COLUMN IF EXISTS visibility, DROP COLUMN IF EXISTS updated_by, DROP COLUMN IF EXISTS created_by;
+ALTER TABLE users DROP COLUMN IF EXISTS team;
@@ -0,0 +1,23 @@
+-- Users may belong to a team; objects with team visibility are shared with the owner's team
+ALTER TABLE users ADD COLUMN IF NOT EXISTS team VARCHAR(100);
+
+-- Saved polygons, sales packages and building restrictions record who created and last changed
+-- them. Rows that existed before stay public with no owner, so everyone can still use them.
+ALTER TABLE saved_polygons
+    ADD COLUMN IF NOT EXISTS created_by BIGINT REFERENCES users(id) ON DELETE SET NULL,
+    ADD COLUMN IF NOT EXISTS updated_by BIGINT REFERENCES users(id) ON DELETE SET NULL,
+    ADD COLUMN IF NOT EXISTS visibility VARCHAR(16) NOT NULL DEFAULT 'public';
+
+ALTER TABLE sales_packages
+    ADD COLUMN IF NOT EXISTS created_by BIGINT REFERENCES users(id) ON DELETE SET NULL,
+    ADD COLUMN IF NOT EXISTS updated_by BIGINT REFERENCES users(id) ON DELETE SET NULL,
+    ADD COLUMN IF NOT EXISTS visibility VARCHAR(16) NOT NULL DEFAULT 'public';
+
+ALTER TABLE building_restrictions
+    ADD COLUMN IF NOT EXISTS created_by BIGINT REFERENCES users(id) ON DELETE SET NULL,
+    ADD COLUMN IF NOT EXISTS updated_by BIGINT REFERENCES users(id) ON DELETE SET NULL,
+    ADD COLUMN IF NOT EXISTS visibility VARCHAR(16) NOT NULL DEFAULT 'public';
+
+CREATE INDEX IF NOT EXISTS idx_saved_polygons_created_by ON saved_polygons(created_by);
+CREATE INDEX IF NOT EXISTS idx_sales_packages_created_by ON sales_packages(created_by);
+CREATE INDEX IF NOT EXISTS idx_building_restrictions_created_by ON building_restrictions(created_by);
@@ -0,0 +1,28 @@
+-- Rows still in the trash are removed for good before the columns go away
+DELETE FROM pois WHERE deleted_at IS NOT NULL;
+DELETE FROM sales_packages WHERE deleted_at IS NOT NULL;
+DELETE FROM building_restrictions WHERE deleted_at IS NOT NULL;
+DELETE FROM saved_polygons WHERE deleted_at IS NOT NULL;
+DELETE FROM categories WHERE deleted_at IS NOT NULL;
+DELETE FROM sub_categories WHERE deleted_at IS NOT NULL;
+DELETE FROM mother_brands WHERE deleted_at IS NOT NULL;
+DELETE FROM branches WHERE deleted_at IS NOT NULL;
+
+DROP INDEX IF EXISTS uq_branches_name;
+DROP INDEX IF EXISTS uq_mother_brands_name;
+DROP INDEX IF EXISTS uq_sub_categories_name;
+DROP INDEX IF EXISTS uq_categories_name;
+
+ALTER TABLE branches ADD CONSTRAINT branches_name_key UNIQUE (name);
+ALTER TABLE mother_brands ADD CONSTRAINT mother_brands_name_key UNIQUE (name);
+ALTER TABLE sub_categories ADD CONSTRAINT sub_categories_name_key UNIQUE (name);
+ALTER TABLE categories ADD CONSTRAINT categories_name_key UNIQUE (name);
+
+ALTER TABLE branches DROP COLUMN IF EXISTS deleted_by, DROP COLUMN IF EXISTS deleted_at;
+ALTER TABLE mother_brands DROP COLUMN IF EXISTS deleted_by, DROP COLUMN IF EXISTS deleted_at;
+ALTER TABLE sub_categories DROP COLUMN IF EXISTS deleted_by, DROP COLUMN IF EXISTS deleted_at;
+ALTER TABLE categories DROP COLUMN IF EXISTS deleted_by, DROP COLUMN IF EXISTS deleted_at;
+ALTER TABLE saved_polygons DROP COLUMN IF EXISTS deleted_by, DROP COLUMN IF EXISTS deleted_at;
+ALTER TABLE building_restrictions DROP COLUMN IF EXISTS deleted_by, DROP COLUMN IF EXISTS deleted_at;
+ALTER TABLE sales_packages DROP COLUMN IF EXISTS deleted_by, DROP COLUMN IF EXISTS deleted_at;
+ALTER TABLE pois DROP COLUMN IF EXISTS deleted_by, DROP COLUMN IF EXISTS deleted_at;
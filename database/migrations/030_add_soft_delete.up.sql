@@ -0,0 +1,56 @@
+-- Deleting a POI, sales package, building restriction, saved polygon or master data entry moves
+-- it to the trash: deleted_at/deleted_by are set and the row is hidden from every listing until it
+-- is restored or purged after the retention period.
+ALTER TABLE pois
+    ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMP,
+    ADD COLUMN IF NOT EXISTS deleted_by BIGINT REFERENCES users(id) ON DELETE SET NULL;
+
+ALTER TABLE sales_packages
+    ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMP,
+    ADD COLUMN IF NOT EXISTS deleted_by BIGINT REFERENCES users(id) ON DELETE SET NULL;
+
+ALTER TABLE building_restrictions
+    ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMP,
+    ADD COLUMN IF NOT EXISTS deleted_by BIGINT REFERENCES users(id) ON DELETE SET NULL;
+
+ALTER TABLE saved_polygons
+    ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMP,
+    ADD COLUMN IF NOT EXISTS deleted_by BIGINT REFERENCES users(id) ON DELETE SET NULL;
+
+ALTER TABLE categories
+    ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMP,
+    ADD COLUMN IF NOT EXISTS deleted_by BIGINT REFERENCES users(id) ON DELETE SET NULL;
+
+ALTER TABLE sub_categories
+    ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMP,
+    ADD COLUMN IF NOT EXISTS deleted_by BIGINT REFERENCES users(id) ON DELETE SET NULL;
+
+ALTER TABLE mother_brands
+    ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMP,
+    ADD COLUMN IF NOT EXISTS deleted_by BIGINT REFERENCES users(id) ON DELETE SET NULL;
+
+ALTER TABLE branches
+    ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMP,
+    ADD COLUMN IF NOT EXISTS deleted_by BIGINT REFERENCES users(id) ON DELETE SET NULL;
+
+-- The trash listing and the purge only look at deleted rows
+CREATE INDEX IF NOT EXISTS idx_pois_deleted_at ON pois(deleted_at) WHERE deleted_at IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_sales_packages_deleted_at ON sales_packages(deleted_at) WHERE deleted_at IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_building_restrictions_deleted_at ON building_restrictions(deleted_at) WHERE deleted_at IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_saved_polygons_deleted_at ON saved_polygons(deleted_at) WHERE deleted_at IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_categories_deleted_at ON categories(deleted_at) WHERE deleted_at IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_sub_categories_deleted_at ON sub_categories(deleted_at) WHERE deleted_at IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_mother_brands_deleted_at ON mother_brands(deleted_at) WHERE deleted_at IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_branches_deleted_at ON branches(deleted_at) WHERE deleted_at IS NOT NULL;
+
+-- Master data names only need to be unique among entries that are not in the trash, so a deleted
+-- name can be created again
+ALTER TABLE categories DROP CONSTRAINT IF EXISTS categories_name_key;
+ALTER TABLE sub_categories DROP CONSTRAINT IF EXISTS sub_categories_name_key;
+ALTER TABLE mother_brands DROP CONSTRAINT IF EXISTS mother_brands_name_key;
+ALTER TABLE branches DROP CONSTRAINT IF EXISTS branches_name_key;
+
+CREATE UNIQUE INDEX IF NOT EXISTS uq_categories_name ON categories(name) WHERE deleted_at IS NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS uq_sub_categories_name ON sub_categories(name) WHERE deleted_at IS NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS uq_mother_brands_name ON mother_brands(name) WHERE deleted_at IS NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS uq_branches_name ON branches(name) WHERE deleted_at IS NULL;
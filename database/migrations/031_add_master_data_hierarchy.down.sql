@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_branches_mother_brand_id;
+DROP INDEX IF EXISTS idx_sub_categories_category_id;
+ALTER TABLE branches DROP COLUMN IF EXISTS mother_brand_id;
+ALTER TABLE sub_categories DROP COLUMN IF EXISTS category_id;
@@ -0,0 +1,35 @@
+-- Sub-categories belong to a category and branches to a mother brand. The parent is optional so
+-- existing entries stay valid; a POI may only combine a sub-category (or branch) with its parent.
+ALTER TABLE sub_categories
+    ADD COLUMN IF NOT EXISTS category_id BIGINT REFERENCES categories(id) ON DELETE SET NULL;
+
+ALTER TABLE branches
+    ADD COLUMN IF NOT EXISTS mother_brand_id BIGINT REFERENCES mother_brands(id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS idx_sub_categories_category_id ON sub_categories(category_id);
+CREATE INDEX IF NOT EXISTS idx_branches_mother_brand_id ON branches(mother_brand_id);
+
+-- Adopt the parent existing POIs already agree on: a sub-category used under exactly one
+-- category, and a branch whose points all belong to POIs of one mother brand.
+UPDATE sub_categories sc
+SET category_id = used.category_id
+FROM (
+    SELECT sub_category_id, MIN(category_id) AS category_id
+    FROM pois
+    WHERE sub_category_id IS NOT NULL AND category_id IS NOT NULL
+    GROUP BY sub_category_id
+    HAVING COUNT(DISTINCT category_id) = 1
+) used
+WHERE sc.id = used.sub_category_id AND sc.category_id IS NULL;
+
+UPDATE branches b
+SET mother_brand_id = used.mother_brand_id
+FROM (
+    SELECT pp.branch_id, MIN(p.mother_brand_id) AS mother_brand_id
+    FROM poi_points pp
+    INNER JOIN pois p ON p.id = pp.poi_id
+    WHERE pp.branch_id IS NOT NULL AND p.mother_brand_id IS NOT NULL
+    GROUP BY pp.branch_id
+    HAVING COUNT(DISTINCT p.mother_brand_id) = 1
+) used
+WHERE b.id = used.branch_id AND b.mother_brand_id IS NULL;
@@ -0,0 +1,15 @@
+-- Merging master data entries leaves the old spelling behind as an alias of the entry it was merged
+-- into, so imports that still use it resolve to the target instead of creating the entry again.
+-- target_id points at categories, sub_categories, mother_brands or branches depending on type.
+CREATE TABLE IF NOT EXISTS master_data_aliases (
+    id BIGSERIAL PRIMARY KEY,
+    type VARCHAR(20) NOT NULL,
+    alias VARCHAR(255) NOT NULL,
+    target_id BIGINT NOT NULL,
+    created_by BIGINT REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    CONSTRAINT chk_master_data_aliases_type CHECK (type IN ('category', 'sub_category', 'mother_brand', 'branch'))
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_master_data_aliases_type_alias ON master_data_aliases(type, LOWER(alias));
+CREATE INDEX IF NOT EXISTS idx_master_data_aliases_type_target_id ON master_data_aliases(type, target_id);
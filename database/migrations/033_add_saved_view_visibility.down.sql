@@ -0,0 +1,6 @@
+ALTER TABLE saved_views ADD COLUMN IF NOT EXISTS is_shared BOOLEAN NOT NULL DEFAULT FALSE;
+
+UPDATE saved_views SET is_shared = visibility <> 'private';
+
+CREATE INDEX IF NOT EXISTS idx_saved_views_is_shared ON saved_views(is_shared) WHERE is_shared;
+ALTER TABLE saved_views DROP COLUMN IF EXISTS visibility;
@@ -0,0 +1,9 @@
+-- Saved views use the same visibility levels as saved polygons, sales packages and building
+-- restrictions instead of a shared flag that exposed them to every user. Shared views become team
+-- views; the others stay private to their owner.
+ALTER TABLE saved_views ADD COLUMN IF NOT EXISTS visibility VARCHAR(16) NOT NULL DEFAULT 'private';
+
+UPDATE saved_views SET visibility = 'team' WHERE is_shared;
+
+DROP INDEX IF EXISTS idx_saved_views_is_shared;
+ALTER TABLE saved_views DROP COLUMN IF EXISTS is_shared;
@@ -0,0 +1,11 @@
+package exceptions
+
+// Forbidden is raised when a logged in user may not change or see an object, as opposed to
+// Unauthorized, which means the request is not logged in at all
+type Forbidden struct {
+	Error string
+}
+
+func NewForbidden(error string) Forbidden {
+	return Forbidden{Error: error}
+}
@@ -1,5 +1,32 @@
 package helpers
 
+import (
+	"context"
+	"strconv"
+)
+
 // ContextKey is a custom type for context keys to avoid collisions
 type ContextKey string
 
+
+// UserIdFromContext returns the authenticated user id stored by RequireAuth, or 0 when absent
+func UserIdFromContext(ctx context.Context) int {
+	userIdStr, ok := ctx.Value(ContextKey("userId")).(string)
+	if !ok {
+		return 0
+	}
+	userId, err := strconv.Atoi(userIdStr)
+	if err != nil {
+		return 0
+	}
+	return userId
+}
+
+// OptionalUserIdFromContext returns the authenticated user id for nullable created_by and
+// updated_by columns, or nil when absent
+func OptionalUserIdFromContext(ctx context.Context) *int {
+	if userId := UserIdFromContext(ctx); userId > 0 {
+		return &userId
+	}
+	return nil
+}
@@ -1,6 +1,11 @@
 package helpers
 
-import "golang.org/x/crypto/bcrypt"
+import (
+	"crypto/rand"
+	"encoding/hex"
+
+	"golang.org/x/crypto/bcrypt"
+)
 
 func HashPassword(plainPassword string) (string, error) {
 	hashed, err := bcrypt.GenerateFromPassword([]byte(plainPassword), 10)
@@ -19,3 +24,12 @@ func CheckPassword(plainPassword string, hashedPassword string) bool {
 	return true
 }
 
+
+// GenerateToken returns a random hex token of byteLength random bytes
+func GenerateToken(byteLength int) (string, error) {
+	b := make([]byte, byteLength)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,79 @@
+package importer
+
+import (
+	"strings"
+
+	"github.com/malikabdulaziz/tmn-backend/web"
+	"github.com/xuri/excelize/v2"
+)
+
+// annotatedErrorsHeader is the column appended to an annotated upload. Read ignores it, so the
+// corrected file can be uploaded again as is.
+const annotatedErrorsHeader = "Errors"
+
+// Annotate returns the upload as xlsx with an Errors column appended. Rejected rows are
+// highlighted and list their reasons; an Errors column already present in the upload is replaced.
+func Annotate(sheet Sheet, errors []web.ImportRowError) ([]byte, error) {
+	reasons := map[int][]string{}
+	for _, e := range errors {
+		reason := e.Reason
+		if e.Column != "" {
+			reason = e.Column + ": " + e.Reason
+		}
+		reasons[e.Row] = append(reasons[e.Row], reason)
+	}
+
+	header := sheet.Header
+	errorsCol := len(header) + 1
+	if sheet.errorsCol >= 0 {
+		errorsCol = sheet.errorsCol + 1
+	}
+
+	f := excelize.NewFile()
+	defer f.Close()
+	const name = "Sheet1"
+
+	highlight, err := f.NewStyle(&excelize.Style{
+		Fill: excelize.Fill{Type: "pattern", Color: []string{"#FFC7CE"}, Pattern: 1},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	writeRow := func(rowNum int, cells []string) {
+		for i, value := range cells {
+			if i+1 == errorsCol {
+				continue
+			}
+			cell, _ := excelize.CoordinatesToCellName(i+1, rowNum)
+			_ = f.SetCellValue(name, cell, value)
+		}
+	}
+
+	writeRow(1, header)
+	cell, _ := excelize.CoordinatesToCellName(errorsCol, 1)
+	_ = f.SetCellValue(name, cell, annotatedErrorsHeader)
+
+	lastCol := errorsCol
+	if len(header) > lastCol {
+		lastCol = len(header)
+	}
+	for i, row := range sheet.Rows {
+		rowNum := RowNumber(i)
+		writeRow(rowNum, row)
+		rowReasons, rejected := reasons[rowNum]
+		cell, _ := excelize.CoordinatesToCellName(errorsCol, rowNum)
+		_ = f.SetCellValue(name, cell, strings.Join(rowReasons, "; "))
+		if rejected {
+			first, _ := excelize.CoordinatesToCellName(1, rowNum)
+			last, _ := excelize.CoordinatesToCellName(lastCol, rowNum)
+			_ = f.SetCellStyle(name, first, last, highlight)
+		}
+	}
+
+	buf, err := f.WriteToBuffer()
+	if err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,200 @@
+package importer_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"testing"
+
+	"github.com/malikabdulaziz/tmn-backend/exceptions"
+	"github.com/malikabdulaziz/tmn-backend/importer"
+	"github.com/malikabdulaziz/tmn-backend/web"
+	"github.com/stretchr/testify/assert"
+	"github.com/xuri/excelize/v2"
+)
+
+var testColumns = map[string][]string{
+	"name":          {"name"},
+	"building_name": {"building_name", "buildings"},
+}
+
+const testCSV = "Name,Building Name\nPackage X,Tower A\n,\nPackage Y,\n"
+
+func TestRead_MapsAliasesAndTrimsValues(t *testing.T) {
+	sheet := importer.Read([]byte("Name , Buildings\n  Package X ,Tower A\n"), "csv", testColumns)
+
+	assert.True(t, sheet.Has("building_name"))
+	assert.Equal(t, "Package X", sheet.Value(0, "name"))
+	assert.Equal(t, "Buildings", sheet.ColumnName("building_name"))
+	assert.Equal(t, "", sheet.Value(0, "missing"))
+}
+
+func TestRead_UnsupportedFileType(t *testing.T) {
+	assert.PanicsWithValue(t,
+		exceptions.BadRequestError{Error: "Unsupported file type. Use xlsx or csv."},
+		func() { importer.Read([]byte("data"), "txt", testColumns) },
+	)
+}
+
+func TestSheet_RequireColumns(t *testing.T) {
+	sheet := importer.Read([]byte("Name\nPackage X\n"), "csv", testColumns)
+
+	assert.PanicsWithValue(t,
+		exceptions.BadRequestError{Error: "Missing required column: building_name"},
+		func() { sheet.RequireColumns("name", "building_name") },
+	)
+}
+
+func TestParseOnError(t *testing.T) {
+	assert.Equal(t, importer.OnErrorAbort, importer.ParseOnError(""))
+	assert.Equal(t, importer.OnErrorSkip, importer.ParseOnError(" SKIP "))
+	assert.PanicsWithValue(t,
+		exceptions.BadRequestError{Error: "Unsupported on_error: ignore. Use abort or skip."},
+		func() { importer.ParseOnError("ignore") },
+	)
+}
+
+func TestReport_AbortPanicsWithEveryRowError(t *testing.T) {
+	sheet := importer.Read([]byte(testCSV), "csv", testColumns)
+	report := importer.NewReport(sheet, importer.OnErrorAbort)
+	report.Reject(2, "building_name", "Building not found")
+	report.Reject(4, "building_name", "Building is required")
+
+	defer func() {
+		err, ok := recover().(exceptions.BadRequestError)
+		assert.True(t, ok)
+		result := err.Extras.(web.ImportReport)
+		assert.Equal(t, []web.ImportRowError{
+			{Row: 2, Column: "Building Name", Value: "Tower A", Reason: "Building not found"},
+			{Row: 4, Column: "Building Name", Value: "", Reason: "Building is required"},
+		}, result.Errors)
+		assert.Equal(t, 0, result.ImportedRows)
+		assert.NotEmpty(t, result.AnnotatedFile)
+	}()
+	report.Check()
+	t.Fatal("Check should panic")
+}
+
+func TestReport_SkipCountsRowsAndAnnotatesFile(t *testing.T) {
+	sheet := importer.Read([]byte(testCSV), "csv", testColumns)
+	report := importer.NewReport(sheet, importer.OnErrorSkip)
+	report.RequireText(4, "building_name", 255)
+	report.Check()
+
+	result := report.Result()
+	assert.Equal(t, 2, result.TotalRows)
+	assert.Equal(t, 1, result.ImportedRows)
+	assert.Equal(t, 1, result.SkippedRows)
+	assert.Equal(t, "Value is required", result.Errors[0].Reason)
+
+	annotated, err := base64.StdEncoding.DecodeString(result.AnnotatedFile)
+	assert.NoError(t, err)
+	f, err := excelize.OpenReader(bytes.NewReader(annotated))
+	assert.NoError(t, err)
+	rows, err := f.GetRows(f.GetSheetName(0))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Name", "Building Name", "Errors"}, rows[0])
+	assert.Equal(t, []string{"Package Y", "", "Building Name: Value is required"}, rows[3])
+
+	// The annotated file can be uploaded again; its Errors column is ignored
+	again := importer.Read(annotated, "xlsx", testColumns)
+	assert.Equal(t, "Package Y", again.Value(2, "name"))
+	assert.True(t, again.IsBlank(1))
+}
+
+func TestReport_NoErrorsHasNoAnnotatedFile(t *testing.T) {
+	sheet := importer.Read([]byte(testCSV), "csv", testColumns)
+	report := importer.NewReport(sheet, importer.OnErrorAbort)
+	report.Check()
+
+	result := report.Result()
+	assert.Equal(t, 2, result.ImportedRows)
+	assert.Empty(t, result.Errors)
+	assert.Empty(t, result.AnnotatedFile)
+}
+
+func TestTracker_ReportsEveryBatchAndAtTheEnd(t *testing.T) {
+	var reported []int
+	ctx := importer.WithProgress(context.Background(), func(done int, total int) {
+		assert.Equal(t, importer.BatchSize+50, total)
+		reported = append(reported, done)
+	})
+
+	tracker := importer.NewTracker(ctx, importer.BatchSize+50)
+	for i := 0; i < importer.BatchSize+50; i++ {
+		tracker.Advance(1)
+	}
+
+	assert.Equal(t, []int{0, importer.BatchSize, importer.BatchSize + 50}, reported)
+}
+
+func TestTracker_PanicsOnceCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	tracker := importer.NewTracker(ctx, 2*importer.BatchSize)
+
+	tracker.Advance(importer.BatchSize - 1)
+	cancel()
+	assert.PanicsWithValue(t, context.Canceled, func() { tracker.Advance(1) })
+}
+
+var testTemplate = importer.Template{
+	SheetName: "Packages",
+	Columns: []importer.TemplateColumn{
+		{Key: "name", Header: "Name", Required: true},
+		{Key: "building_name", Header: "Building Name", Options: []string{"Tower A", "Tower B"}, Strict: true},
+	},
+	Instructions: []string{"One row per building."},
+}
+
+func TestBuildTemplate_HeadersDropdownsAndInstructions(t *testing.T) {
+	fileBytes, err := importer.BuildTemplate(testTemplate, testColumns)
+	assert.NoError(t, err)
+
+	f, err := excelize.OpenReader(bytes.NewReader(fileBytes))
+	assert.NoError(t, err)
+	defer f.Close()
+
+	assert.Equal(t, []string{"Packages", "Lists", "Instructions"}, f.GetSheetList())
+	rows, err := f.GetRows("Packages")
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{{"Name", "Building Name"}}, rows)
+
+	visible, err := f.GetSheetVisible("Lists")
+	assert.NoError(t, err)
+	assert.False(t, visible)
+
+	validations, err := f.GetDataValidations("Packages")
+	assert.NoError(t, err)
+	assert.Len(t, validations, 1)
+	assert.Equal(t, "B2:B1048576", validations[0].Sqref)
+	assert.Contains(t, validations[0].Formula1, "Lists")
+
+	instructions, err := f.GetRows("Instructions")
+	assert.NoError(t, err)
+	assert.Contains(t, instructions, []string{"Building Name", "No", "Pick from the dropdown."})
+}
+
+func TestBuildTemplate_FilledTemplateReadsBack(t *testing.T) {
+	fileBytes, err := importer.BuildTemplate(testTemplate, testColumns)
+	assert.NoError(t, err)
+
+	f, err := excelize.OpenReader(bytes.NewReader(fileBytes))
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetSheetRow("Packages", "A2", &[]string{"Package X", "Tower A"}))
+	buf, err := f.WriteToBuffer()
+	assert.NoError(t, err)
+
+	sheet := importer.Read(buf.Bytes(), "xlsx", testColumns)
+	sheet.RequireColumns("name", "building_name")
+	assert.Equal(t, "Tower A", sheet.Value(0, "building_name"))
+}
+
+func TestBuildTemplate_RejectsUnknownHeader(t *testing.T) {
+	template := importer.Template{
+		SheetName: "Packages",
+		Columns:   []importer.TemplateColumn{{Key: "building_name", Header: "Building"}},
+	}
+
+	_, err := importer.BuildTemplate(template, testColumns)
+	assert.EqualError(t, err, `template header "Building" does not map to import column "building_name"`)
+}
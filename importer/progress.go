@@ -0,0 +1,60 @@
+package importer
+
+import "context"
+
+// BatchSize is how many units of work (rows, points, packages...) an import writes between
+// progress reports and cancellation checks
+const BatchSize = 200
+
+// ProgressFunc receives how many units of an import are done out of total
+type ProgressFunc func(done int, total int)
+
+type progressKey struct{}
+
+// WithProgress attaches fn to ctx so imports running with ctx report their progress to it.
+// Import jobs use it to persist progress; plain HTTP imports have no ProgressFunc.
+func WithProgress(ctx context.Context, fn ProgressFunc) context.Context {
+	return context.WithValue(ctx, progressKey{}, fn)
+}
+
+// Tracker counts the units an import has written. Every BatchSize units (and at the end) it
+// reports to the ProgressFunc on ctx and panics with ctx.Err() once ctx is cancelled, which
+// rolls back the import transaction through helpers.CommitOrRollback.
+type Tracker struct {
+	ctx   context.Context
+	fn    ProgressFunc
+	total int
+	done  int
+}
+
+// NewTracker starts tracking an import of total units and reports 0/total
+func NewTracker(ctx context.Context, total int) *Tracker {
+	fn, _ := ctx.Value(progressKey{}).(ProgressFunc)
+	t := &Tracker{ctx: ctx, fn: fn, total: total}
+	t.report()
+	return t
+}
+
+// Advance marks n more units as written
+func (t *Tracker) Advance(n int) {
+	previous := t.done
+	t.done += n
+	if t.done/BatchSize == previous/BatchSize && t.done < t.total {
+		return
+	}
+	t.report()
+}
+
+// Remaining returns how many units are not done yet
+func (t *Tracker) Remaining() int {
+	return t.total - t.done
+}
+
+func (t *Tracker) report() {
+	if t.fn != nil {
+		t.fn(t.done, t.total)
+	}
+	if err := t.ctx.Err(); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,142 @@
+package importer
+
+import (
+	"encoding/base64"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/malikabdulaziz/tmn-backend/exceptions"
+	"github.com/malikabdulaziz/tmn-backend/helpers"
+	"github.com/malikabdulaziz/tmn-backend/web"
+)
+
+// What an import does with invalid rows
+const (
+	OnErrorAbort = "abort" // all-or-nothing: any invalid row rejects the whole file
+	OnErrorSkip  = "skip"  // import the valid rows and report the invalid ones
+)
+
+// ParseOnError validates the on_error option; an empty value means abort
+func ParseOnError(value string) string {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "", OnErrorAbort:
+		return OnErrorAbort
+	case OnErrorSkip:
+		return OnErrorSkip
+	default:
+		panic(exceptions.NewBadRequestError(fmt.Sprintf("Unsupported on_error: %s. Use abort or skip.", value)))
+	}
+}
+
+// Report collects the row errors of one import. Validate every row first, call Check, and only
+// then write the rows that were not rejected.
+type Report struct {
+	onError  string
+	sheet    Sheet
+	errors   []web.ImportRowError
+	rejected map[int]bool // sheet row number -> has at least one error
+}
+
+func NewReport(sheet Sheet, onError string) *Report {
+	return &Report{
+		onError:  ParseOnError(onError),
+		sheet:    sheet,
+		errors:   []web.ImportRowError{},
+		rejected: map[int]bool{},
+	}
+}
+
+// OnError returns the normalized on_error option
+func (r *Report) OnError() string {
+	return r.onError
+}
+
+// Reject records a problem with a sheet row. column is a column key as passed to Read; the error
+// carries its header text and the cell value.
+func (r *Report) Reject(row int, column string, reason string) {
+	r.errors = append(r.errors, web.ImportRowError{
+		Row:    row,
+		Column: r.sheet.ColumnName(column),
+		Value:  r.sheet.Value(row-2, column),
+		Reason: reason,
+	})
+	r.rejected[row] = true
+}
+
+// RequireText rejects the row when its cell is empty or longer than maxLength characters, and
+// reports whether the value is usable
+func (r *Report) RequireText(row int, column string, maxLength int) bool {
+	value := r.sheet.Value(row-2, column)
+	if value == "" {
+		r.Reject(row, column, "Value is required")
+		return false
+	}
+	if utf8.RuneCountInString(value) > maxLength {
+		r.Reject(row, column, fmt.Sprintf("Value must be at most %d characters", maxLength))
+		return false
+	}
+	return true
+}
+
+// Rejected reports whether a sheet row has any error
+func (r *Report) Rejected(row int) bool {
+	return r.rejected[row]
+}
+
+// RejectedRows returns how many distinct rows have errors
+func (r *Report) RejectedRows() int {
+	return len(r.rejected)
+}
+
+// HasErrors reports whether any row was rejected
+func (r *Report) HasErrors() bool {
+	return len(r.errors) > 0
+}
+
+// Errors returns the collected errors ordered by row
+func (r *Report) Errors() []web.ImportRowError {
+	sorted := append([]web.ImportRowError{}, r.errors...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Row < sorted[j].Row })
+	return sorted
+}
+
+// Check panics with a BadRequest carrying the full report when the import is all-or-nothing
+// and any row was rejected. Nothing must have been written before it is called.
+func (r *Report) Check() {
+	if r.onError != OnErrorAbort || !r.HasErrors() {
+		return
+	}
+	panic(exceptions.NewBadRequestWithExtras(
+		fmt.Sprintf("Import rejected: %d row(s) have errors. Nothing was imported; fix the rows listed and upload the file again.", r.RejectedRows()),
+		r.Result(),
+	))
+}
+
+// Result summarizes the import for the response. Blank rows are not counted; a rejected
+// all-or-nothing import reports no imported rows.
+func (r *Report) Result() web.ImportReport {
+	total := 0
+	for i := range r.sheet.Rows {
+		if !r.sheet.IsBlank(i) {
+			total++
+		}
+	}
+
+	report := web.ImportReport{
+		OnError:   r.onError,
+		TotalRows: total,
+		Errors:    r.Errors(),
+	}
+	if r.onError == OnErrorSkip || !r.HasErrors() {
+		report.SkippedRows = r.RejectedRows()
+		report.ImportedRows = total - report.SkippedRows
+	}
+	if r.HasErrors() {
+		annotated, err := Annotate(r.sheet, report.Errors)
+		helpers.PanicIfError(err)
+		report.AnnotatedFile = base64.StdEncoding.EncodeToString(annotated)
+	}
+	return report
+}
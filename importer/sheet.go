@@ -0,0 +1,149 @@
+// Package importer holds the XLSX/CSV handling shared by every spreadsheet import: reading the
+// upload, mapping header cells to columns, collecting row errors and annotating the file with them.
+package importer
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/malikabdulaziz/tmn-backend/exceptions"
+	"github.com/xuri/excelize/v2"
+)
+
+// Sheet is an uploaded spreadsheet. Rows holds the data rows only; Rows[i] is sheet row i+2
+// because the header is row 1.
+type Sheet struct {
+	Header    []string
+	Rows      [][]string
+	columns   map[string]int
+	errorsCol int // index of an Errors column left by Annotate, or -1
+}
+
+// Read parses an xlsx (first sheet) or csv upload. aliases maps each column key to the header
+// spellings that select it, written as NormalizeHeader returns them. A file with no data rows
+// is rejected.
+func Read(fileBytes []byte, fileType string, aliases map[string][]string) Sheet {
+	var rows [][]string
+	var err error
+
+	switch strings.ToLower(fileType) {
+	case "xlsx":
+		rows, err = readXLSX(fileBytes)
+	case "csv":
+		rows, err = readCSV(fileBytes)
+	default:
+		panic(exceptions.NewBadRequestError("Unsupported file type. Use xlsx or csv."))
+	}
+	if err != nil {
+		panic(exceptions.NewBadRequestError(fmt.Sprintf("Failed to read %s file: %s", strings.ToLower(fileType), err.Error())))
+	}
+
+	if len(rows) < 2 {
+		panic(exceptions.NewBadRequestError("File must contain a header row and at least one data row."))
+	}
+
+	lookup := map[string]string{}
+	for key, spellings := range aliases {
+		for _, spelling := range spellings {
+			lookup[spelling] = key
+		}
+	}
+	columns := map[string]int{}
+	errorsCol := -1
+	for i, h := range rows[0] {
+		if key, ok := lookup[NormalizeHeader(h)]; ok {
+			columns[key] = i
+		} else if NormalizeHeader(h) == NormalizeHeader(annotatedErrorsHeader) {
+			errorsCol = i
+		}
+	}
+
+	return Sheet{Header: rows[0], Rows: rows[1:], columns: columns, errorsCol: errorsCol}
+}
+
+// NormalizeHeader lower-cases a header cell and turns spaces and dashes into underscores,
+// so "Sub-Category" and "sub category" both become "sub_category".
+func NormalizeHeader(h string) string {
+	normalized := strings.ToLower(strings.TrimSpace(h))
+	normalized = strings.ReplaceAll(normalized, "-", "_")
+	normalized = strings.ReplaceAll(normalized, " ", "_")
+	return normalized
+}
+
+// RowNumber converts an index into Rows to the sheet row number shown to users
+func RowNumber(index int) int {
+	return index + 2
+}
+
+// Has reports whether the header contains the column
+func (s Sheet) Has(key string) bool {
+	_, ok := s.columns[key]
+	return ok
+}
+
+// RequireColumns panics with a BadRequest naming the first column missing from the header
+func (s Sheet) RequireColumns(keys ...string) {
+	for _, key := range keys {
+		if !s.Has(key) {
+			panic(exceptions.NewBadRequestError(fmt.Sprintf("Missing required column: %s", key)))
+		}
+	}
+}
+
+// Value returns the trimmed cell of a data row, or "" when the column or cell is absent
+func (s Sheet) Value(index int, key string) string {
+	idx, ok := s.columns[key]
+	if !ok || index < 0 || index >= len(s.Rows) || idx >= len(s.Rows[index]) {
+		return ""
+	}
+	return strings.TrimSpace(s.Rows[index][idx])
+}
+
+// ColumnName returns the header cell of a column as written in the file, or the key itself
+// when the file has no such column
+func (s Sheet) ColumnName(key string) string {
+	if idx, ok := s.columns[key]; ok && idx < len(s.Header) {
+		return strings.TrimSpace(s.Header[idx])
+	}
+	return key
+}
+
+// IsBlank reports whether every cell of a data row is empty after trimming. The Errors column
+// of a re-uploaded annotated file does not count.
+func (s Sheet) IsBlank(index int) bool {
+	for i, cell := range s.Rows[index] {
+		if i != s.errorsCol && strings.TrimSpace(cell) != "" {
+			return false
+		}
+	}
+	return true
+}
+
+func readXLSX(fileBytes []byte) ([][]string, error) {
+	f, err := excelize.OpenReader(bytes.NewReader(fileBytes))
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return f.GetRows(f.GetSheetName(0))
+}
+
+func readCSV(fileBytes []byte) ([][]string, error) {
+	reader := csv.NewReader(bytes.NewReader(fileBytes))
+	reader.FieldsPerRecord = -1
+	var rows [][]string
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		rows = append(rows, record)
+	}
+	return rows, nil
+}
@@ -0,0 +1,160 @@
+package importer
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+)
+
+const (
+	templateInstructionsSheet = "Instructions"
+	templateListsSheet        = "Lists"
+)
+
+// TemplateColumn is one column of an import template. Key is the import column the header maps
+// to (see Read); Options, when set, become a dropdown on every data row of the column.
+type TemplateColumn struct {
+	Key         string
+	Header      string
+	Description string
+	Required    bool
+	Options     []string
+	// Strict dropdowns reject other values; otherwise Excel only warns, for columns where the
+	// import creates missing values (e.g. a new category)
+	Strict bool
+}
+
+// Template describes the blank xlsx offered for download next to an import endpoint
+type Template struct {
+	SheetName    string
+	Columns      []TemplateColumn
+	Instructions []string
+}
+
+// BuildTemplate returns an xlsx with the header row on the data sheet, dropdowns fed from a
+// hidden Lists sheet, and an Instructions sheet describing every column. Each header must
+// resolve to its Key through columns, the same aliases the import passes to Read, so a template
+// always uploads without "Missing required column" errors.
+func BuildTemplate(template Template, columns map[string][]string) ([]byte, error) {
+	for _, column := range template.Columns {
+		if !headerMatches(column.Header, columns[column.Key]) {
+			return nil, fmt.Errorf("template header %q does not map to import column %q", column.Header, column.Key)
+		}
+	}
+
+	f := excelize.NewFile()
+	defer f.Close()
+
+	sheet := template.SheetName
+	if err := f.SetSheetName("Sheet1", sheet); err != nil {
+		return nil, err
+	}
+
+	headerStyle, err := f.NewStyle(&excelize.Style{
+		Font: &excelize.Font{Bold: true},
+		Fill: excelize.Fill{Type: "pattern", Color: []string{"#D9E1F2"}, Pattern: 1},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	listCol := 0
+	for i, column := range template.Columns {
+		colName, _ := excelize.ColumnNumberToName(i + 1)
+		_ = f.SetCellValue(sheet, colName+"1", column.Header)
+		_ = f.SetColWidth(sheet, colName, colName, 24)
+
+		if len(column.Options) == 0 {
+			continue
+		}
+		if listCol == 0 {
+			if _, err := f.NewSheet(templateListsSheet); err != nil {
+				return nil, err
+			}
+		}
+		listCol++
+		listColName, _ := excelize.ColumnNumberToName(listCol)
+		_ = f.SetCellValue(templateListsSheet, listColName+"1", column.Header)
+		for j, option := range column.Options {
+			_ = f.SetCellValue(templateListsSheet, fmt.Sprintf("%s%d", listColName, j+2), option)
+		}
+
+		dv := excelize.NewDataValidation(true)
+		dv.SetSqref(fmt.Sprintf("%s2:%s%d", colName, colName, excelize.TotalRows))
+		dv.SetSqrefDropList(fmt.Sprintf("'%s'!$%s$2:$%s$%d", templateListsSheet, listColName, listColName, len(column.Options)+1))
+		if column.Strict {
+			dv.SetError(excelize.DataValidationErrorStyleStop, column.Header, "Pick a value from the list.")
+		} else {
+			dv.SetError(excelize.DataValidationErrorStyleWarning, column.Header, "This value is not in the list yet; the import will create it.")
+		}
+		if err := f.AddDataValidation(sheet, dv); err != nil {
+			return nil, err
+		}
+	}
+	if len(template.Columns) > 0 {
+		lastCol, _ := excelize.ColumnNumberToName(len(template.Columns))
+		_ = f.SetCellStyle(sheet, "A1", lastCol+"1", headerStyle)
+	}
+	_ = f.SetPanes(sheet, &excelize.Panes{Freeze: true, YSplit: 1, TopLeftCell: "A2", ActivePane: "bottomLeft"})
+	if listCol > 0 {
+		_ = f.SetSheetVisible(templateListsSheet, false)
+	}
+
+	if err := writeTemplateInstructions(f, template, headerStyle); err != nil {
+		return nil, err
+	}
+
+	buf, err := f.WriteToBuffer()
+	if err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func writeTemplateInstructions(f *excelize.File, template Template, headerStyle int) error {
+	const sheet = templateInstructionsSheet
+	if _, err := f.NewSheet(sheet); err != nil {
+		return err
+	}
+	_ = f.SetColWidth(sheet, "A", "A", 24)
+	_ = f.SetColWidth(sheet, "B", "B", 12)
+	_ = f.SetColWidth(sheet, "C", "C", 80)
+
+	row := 1
+	_ = f.SetCellValue(sheet, "A1", "How to fill in the "+template.SheetName+" sheet")
+	_ = f.SetCellStyle(sheet, "A1", "A1", headerStyle)
+	row += 2
+	for _, line := range template.Instructions {
+		_ = f.SetCellValue(sheet, fmt.Sprintf("A%d", row), line)
+		row++
+	}
+	row++
+
+	_ = f.SetSheetRow(sheet, fmt.Sprintf("A%d", row), &[]string{"Column", "Required", "Description"})
+	_ = f.SetCellStyle(sheet, fmt.Sprintf("A%d", row), fmt.Sprintf("C%d", row), headerStyle)
+	row++
+	for _, column := range template.Columns {
+		required := "No"
+		if column.Required {
+			required = "Yes"
+		}
+		description := column.Description
+		if len(column.Options) > 0 {
+			description = strings.TrimSpace(description + " Pick from the dropdown.")
+		}
+		_ = f.SetSheetRow(sheet, fmt.Sprintf("A%d", row), &[]string{column.Header, required, description})
+		row++
+	}
+	return nil
+}
+
+func headerMatches(header string, aliases []string) bool {
+	normalized := NormalizeHeader(header)
+	for _, alias := range aliases {
+		if alias == normalized {
+			return true
+		}
+	}
+	return false
+}
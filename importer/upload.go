@@ -0,0 +1,41 @@
+package importer
+
+import (
+	"io"
+	"net/http"
+	"path/filepath"
+	"strings"
+
+	"github.com/malikabdulaziz/tmn-backend/exceptions"
+	"github.com/malikabdulaziz/tmn-backend/helpers"
+)
+
+// ReadUpload reads the multipart "file" field and returns its bytes and extension (xlsx or csv)
+func ReadUpload(r *http.Request) ([]byte, string) {
+	err := r.ParseMultipartForm(32 << 20) // 32MB max
+	if err != nil {
+		panic(exceptions.NewBadRequestError("Failed to parse upload. Max file size is 32MB."))
+	}
+
+	file, header, err := r.FormFile("file")
+	if err != nil {
+		panic(exceptions.NewBadRequestError("File is required. Use form field 'file'."))
+	}
+	defer file.Close()
+
+	fileBytes, err := io.ReadAll(file)
+	helpers.PanicIfError(err)
+
+	ext := strings.ToLower(strings.TrimPrefix(filepath.Ext(header.Filename), "."))
+	if ext != "xlsx" && ext != "csv" {
+		panic(exceptions.NewBadRequestError("Unsupported file type. Use .xlsx or .csv files."))
+	}
+
+	return fileBytes, ext
+}
+
+// OnErrorFromRequest returns the "on_error" form field of an upload (abort or skip, see
+// ParseOnError). Call after ReadUpload, which parses the multipart form.
+func OnErrorFromRequest(r *http.Request) string {
+	return ParseOnError(r.FormValue("on_error"))
+}
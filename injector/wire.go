@@ -6,46 +6,75 @@ package injector
 import (
 	"github.com/google/wire"
 	"github.com/julienschmidt/httprouter"
+	controllersAdminBoundary "github.com/malikabdulaziz/tmn-backend/controllers/adminboundary"
 	controllersAuth "github.com/malikabdulaziz/tmn-backend/controllers/auth"
 	controllersBuilding "github.com/malikabdulaziz/tmn-backend/controllers/building"
+	controllersBooking "github.com/malikabdulaziz/tmn-backend/controllers/booking"
 	controllersBranch "github.com/malikabdulaziz/tmn-backend/controllers/branch"
 	controllersBuildingRestriction "github.com/malikabdulaziz/tmn-backend/controllers/buildingrestriction"
 	controllersCategory "github.com/malikabdulaziz/tmn-backend/controllers/category"
 	controllersDashboard "github.com/malikabdulaziz/tmn-backend/controllers/dashboard"
 	controllersImage "github.com/malikabdulaziz/tmn-backend/controllers/image"
+	controllersImportJob "github.com/malikabdulaziz/tmn-backend/controllers/importjob"
+	controllersMasterData "github.com/malikabdulaziz/tmn-backend/controllers/masterdata"
 	controllersMotherBrand "github.com/malikabdulaziz/tmn-backend/controllers/motherbrand"
 	controllersPOI "github.com/malikabdulaziz/tmn-backend/controllers/poi"
+	controllersPOIDuplicate "github.com/malikabdulaziz/tmn-backend/controllers/poiduplicate"
+	controllersProposal "github.com/malikabdulaziz/tmn-backend/controllers/proposal"
 	controllersSalesPackage "github.com/malikabdulaziz/tmn-backend/controllers/salespackage"
 	controllersSavedPolygon "github.com/malikabdulaziz/tmn-backend/controllers/savedpolygon"
+	controllersSavedView "github.com/malikabdulaziz/tmn-backend/controllers/savedview"
+	controllersShareLink "github.com/malikabdulaziz/tmn-backend/controllers/sharelink"
 	controllersSubCategory "github.com/malikabdulaziz/tmn-backend/controllers/subcategory"
+	controllersTrash "github.com/malikabdulaziz/tmn-backend/controllers/trash"
 	"github.com/malikabdulaziz/tmn-backend/libs"
 	"github.com/malikabdulaziz/tmn-backend/middlewares"
+	repositoriesAdminBoundary "github.com/malikabdulaziz/tmn-backend/repositories/adminboundary"
 	repositoriesAuth "github.com/malikabdulaziz/tmn-backend/repositories/auth"
+	repositoriesBooking "github.com/malikabdulaziz/tmn-backend/repositories/booking"
 	repositoriesBranch "github.com/malikabdulaziz/tmn-backend/repositories/branch"
 	repositoriesBuilding "github.com/malikabdulaziz/tmn-backend/repositories/building"
 	repositoriesBuildingRestriction "github.com/malikabdulaziz/tmn-backend/repositories/buildingrestriction"
 	repositoriesCategory "github.com/malikabdulaziz/tmn-backend/repositories/category"
 	repositoriesDashboard "github.com/malikabdulaziz/tmn-backend/repositories/dashboard"
+	repositoriesImportJob "github.com/malikabdulaziz/tmn-backend/repositories/importjob"
+	repositoriesGeocodeCache "github.com/malikabdulaziz/tmn-backend/repositories/geocodecache"
+	repositoriesImportPreview "github.com/malikabdulaziz/tmn-backend/repositories/importpreview"
+	repositoriesMasterData "github.com/malikabdulaziz/tmn-backend/repositories/masterdata"
 	repositoriesMotherBrand "github.com/malikabdulaziz/tmn-backend/repositories/motherbrand"
 	repositoriesPOI "github.com/malikabdulaziz/tmn-backend/repositories/poi"
+	repositoriesPOIDuplicate "github.com/malikabdulaziz/tmn-backend/repositories/poiduplicate"
 	repositoriesSalesPackage "github.com/malikabdulaziz/tmn-backend/repositories/salespackage"
 	repositoriesSavedPolygon "github.com/malikabdulaziz/tmn-backend/repositories/savedpolygon"
+	repositoriesSavedView "github.com/malikabdulaziz/tmn-backend/repositories/savedview"
+	repositoriesShareLink "github.com/malikabdulaziz/tmn-backend/repositories/sharelink"
 	repositoriesSubCategory "github.com/malikabdulaziz/tmn-backend/repositories/subcategory"
+	repositoriesTrash "github.com/malikabdulaziz/tmn-backend/repositories/trash"
 	repositoriesUser "github.com/malikabdulaziz/tmn-backend/repositories/user"
 	servicesAcquisition "github.com/malikabdulaziz/tmn-backend/services/acquisition"
+	servicesAdminBoundary "github.com/malikabdulaziz/tmn-backend/services/adminboundary"
 	servicesAuth "github.com/malikabdulaziz/tmn-backend/services/auth"
+	servicesBooking "github.com/malikabdulaziz/tmn-backend/services/booking"
 	servicesBranch "github.com/malikabdulaziz/tmn-backend/services/branch"
 	servicesBuilding "github.com/malikabdulaziz/tmn-backend/services/building"
 	servicesBuildingProposal "github.com/malikabdulaziz/tmn-backend/services/buildingproposal"
 	servicesBuildingRestriction "github.com/malikabdulaziz/tmn-backend/services/buildingrestriction"
 	servicesCategory "github.com/malikabdulaziz/tmn-backend/services/category"
 	servicesDashboard "github.com/malikabdulaziz/tmn-backend/services/dashboard"
+	servicesImportJob "github.com/malikabdulaziz/tmn-backend/services/importjob"
 	servicesLOI "github.com/malikabdulaziz/tmn-backend/services/loi"
+	servicesMasterData "github.com/malikabdulaziz/tmn-backend/services/masterdata"
 	servicesMotherBrand "github.com/malikabdulaziz/tmn-backend/services/motherbrand"
+	servicesGeocoding "github.com/malikabdulaziz/tmn-backend/services/geocoding"
 	servicesPOI "github.com/malikabdulaziz/tmn-backend/services/poi"
+	servicesPOIDuplicate "github.com/malikabdulaziz/tmn-backend/services/poiduplicate"
+	servicesProposal "github.com/malikabdulaziz/tmn-backend/services/proposal"
 	servicesSalesPackage "github.com/malikabdulaziz/tmn-backend/services/salespackage"
 	servicesSavedPolygon "github.com/malikabdulaziz/tmn-backend/services/savedpolygon"
+	servicesSavedView "github.com/malikabdulaziz/tmn-backend/services/savedview"
+	servicesShareLink "github.com/malikabdulaziz/tmn-backend/services/sharelink"
 	servicesSubCategory "github.com/malikabdulaziz/tmn-backend/services/subcategory"
+	servicesTrash "github.com/malikabdulaziz/tmn-backend/services/trash"
 )
 
 var authSet = wire.NewSet(
@@ -89,18 +118,50 @@ var branchSet = wire.NewSet(
 	controllersBranch.NewControllerBranchImpl,
 )
 
+var geocodingSet = wire.NewSet(
+	libs.ProvideGeocoder,
+	repositoriesGeocodeCache.NewRepositoryGeocodeCacheImpl,
+	servicesGeocoding.NewServiceGeocodingImpl,
+)
+
 var poiSet = wire.NewSet(
 	repositoriesPOI.NewRepositoryPOIImpl,
+	repositoriesImportPreview.NewRepositoryImportPreviewImpl,
 	servicesPOI.NewServicePOIImpl,
 	controllersPOI.NewControllerPOIImpl,
 )
 
+var poiDuplicateSet = wire.NewSet(
+	repositoriesPOIDuplicate.NewRepositoryPOIDuplicateImpl,
+	servicesPOIDuplicate.NewServicePOIDuplicateImpl,
+	controllersPOIDuplicate.NewControllerPOIDuplicateImpl,
+)
+
 var salespackageSet = wire.NewSet(
 	repositoriesSalesPackage.NewRepositorySalesPackageImpl,
 	servicesSalesPackage.NewServiceSalesPackageImpl,
 	controllersSalesPackage.NewControllerSalesPackageImpl,
 )
 
+var bookingSet = wire.NewSet(
+	repositoriesBooking.NewRepositoryBookingImpl,
+	servicesBooking.NewServiceBookingImpl,
+	controllersBooking.NewControllerBookingImpl,
+)
+
+var proposalSet = wire.NewSet(
+	libs.ProvideProposalConfig,
+	servicesProposal.NewServiceProposalImpl,
+	controllersProposal.NewControllerProposalImpl,
+)
+
+var shareLinkSet = wire.NewSet(
+	libs.ProvideShareLinkConfig,
+	repositoriesShareLink.NewRepositoryShareLinkImpl,
+	servicesShareLink.NewServiceShareLinkImpl,
+	controllersShareLink.NewControllerShareLinkImpl,
+)
+
 var buildingrestrictionSet = wire.NewSet(
 	repositoriesBuildingRestriction.NewRepositoryBuildingRestrictionImpl,
 	servicesBuildingRestriction.NewServiceBuildingRestrictionImpl,
@@ -113,12 +174,43 @@ var savedpolygonSet = wire.NewSet(
 	controllersSavedPolygon.NewControllerSavedPolygonImpl,
 )
 
+var savedViewSet = wire.NewSet(
+	repositoriesSavedView.NewRepositorySavedViewImpl,
+	servicesSavedView.NewServiceSavedViewImpl,
+	controllersSavedView.NewControllerSavedViewImpl,
+)
+
+var trashSet = wire.NewSet(
+	libs.ProvideTrashConfig,
+	repositoriesTrash.NewRepositoryTrashImpl,
+	servicesTrash.NewServiceTrashImpl,
+	controllersTrash.NewControllerTrashImpl,
+)
+
+var masterDataSet = wire.NewSet(
+	repositoriesMasterData.NewRepositoryMasterDataImpl,
+	servicesMasterData.NewServiceMasterDataImpl,
+	controllersMasterData.NewControllerMasterDataImpl,
+)
+
 var dashboardSet = wire.NewSet(
 	repositoriesDashboard.NewRepositoryDashboardImpl,
 	servicesDashboard.NewServiceDashboardImpl,
 	controllersDashboard.NewControllerDashboardImpl,
 )
 
+var adminBoundarySet = wire.NewSet(
+	repositoriesAdminBoundary.NewRepositoryAdminBoundaryImpl,
+	servicesAdminBoundary.NewServiceAdminBoundaryImpl,
+	controllersAdminBoundary.NewControllerAdminBoundaryImpl,
+)
+
+var importJobSet = wire.NewSet(
+	repositoriesImportJob.NewRepositoryImportJobImpl,
+	servicesImportJob.NewServiceImportJobImpl,
+	controllersImportJob.NewControllerImportJobImpl,
+)
+
 var middlewareSet = wire.NewSet(
 	middlewares.NewAuthMiddleware,
 	middlewares.NewBuildingMiddleware,
@@ -131,6 +223,10 @@ var middlewareSet = wire.NewSet(
 	middlewares.NewSubCategoryMiddleware,
 	middlewares.NewMotherBrandMiddleware,
 	middlewares.NewBranchMiddleware,
+	middlewares.NewBookingMiddleware,
+	middlewares.NewShareLinkMiddleware,
+	middlewares.NewSavedViewMiddleware,
+	middlewares.NewMasterDataMiddleware,
 )
 
 func InitializeRouter() *httprouter.Router {
@@ -146,11 +242,21 @@ func InitializeRouter() *httprouter.Router {
 		subCategorySet,
 		motherBrandSet,
 		branchSet,
+		geocodingSet,
 		poiSet,
+		poiDuplicateSet,
 		salespackageSet,
+		bookingSet,
+		proposalSet,
+		shareLinkSet,
 		buildingrestrictionSet,
 		savedpolygonSet,
+		savedViewSet,
+		trashSet,
+		masterDataSet,
 		dashboardSet,
+		adminBoundarySet,
+		importJobSet,
 		middlewareSet,
 		libs.NewRouter,
 	)
@@ -169,6 +275,16 @@ func InitializeBuildingService() servicesBuilding.ServiceBuildingInterface {
 	return nil
 }
 
+func InitializeAdminBoundaryService() servicesAdminBoundary.ServiceAdminBoundaryInterface {
+	wire.Build(
+		libs.NewDatabase,
+		repositoriesAdminBoundary.NewRepositoryAdminBoundaryImpl,
+		repositoriesBuilding.NewRepositoryBuildingImpl,
+		servicesAdminBoundary.NewServiceAdminBoundaryImpl,
+	)
+	return nil
+}
+
 func InitializeAcquisitionService() servicesAcquisition.ServiceAcquisitionInterface {
 	wire.Build(
 		libs.NewDatabase,
@@ -198,3 +314,42 @@ func InitializeLOIService() servicesLOI.ServiceLOIInterface {
 	)
 	return nil
 }
+
+func InitializeImportJobService() servicesImportJob.ServiceImportJobInterface {
+	wire.Build(
+		libs.NewDatabase,
+		libs.NewLogger,
+		libs.ProvideERPClient,
+		geocodingSet,
+		repositoriesImportJob.NewRepositoryImportJobImpl,
+		repositoriesPOI.NewRepositoryPOIImpl,
+		repositoriesCategory.NewRepositoryCategoryImpl,
+		repositoriesSubCategory.NewRepositorySubCategoryImpl,
+		repositoriesMotherBrand.NewRepositoryMotherBrandImpl,
+		repositoriesBranch.NewRepositoryBranchImpl,
+		repositoriesImportPreview.NewRepositoryImportPreviewImpl,
+		repositoriesSalesPackage.NewRepositorySalesPackageImpl,
+		repositoriesBuildingRestriction.NewRepositoryBuildingRestrictionImpl,
+		repositoriesBuilding.NewRepositoryBuildingImpl,
+		servicesPOI.NewServicePOIImpl,
+		servicesBuilding.NewServiceBuildingImpl,
+		servicesSalesPackage.NewServiceSalesPackageImpl,
+		servicesBuildingRestriction.NewServiceBuildingRestrictionImpl,
+		servicesCategory.NewServiceCategoryImpl,
+		servicesSubCategory.NewServiceSubCategoryImpl,
+		servicesMotherBrand.NewServiceMotherBrandImpl,
+		servicesBranch.NewServiceBranchImpl,
+		servicesImportJob.NewServiceImportJobImpl,
+	)
+	return nil
+}
+
+func InitializeTrashService() servicesTrash.ServiceTrashInterface {
+	wire.Build(
+		libs.NewDatabase,
+		libs.ProvideTrashConfig,
+		repositoriesTrash.NewRepositoryTrashImpl,
+		servicesTrash.NewServiceTrashImpl,
+	)
+	return nil
+}
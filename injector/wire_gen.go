@@ -9,46 +9,75 @@ package injector
 import (
 	"github.com/google/wire"
 	"github.com/julienschmidt/httprouter"
+	adminboundary3 "github.com/malikabdulaziz/tmn-backend/controllers/adminboundary"
 	auth3 "github.com/malikabdulaziz/tmn-backend/controllers/auth"
+	booking3 "github.com/malikabdulaziz/tmn-backend/controllers/booking"
 	branch3 "github.com/malikabdulaziz/tmn-backend/controllers/branch"
 	building3 "github.com/malikabdulaziz/tmn-backend/controllers/building"
 	buildingrestriction3 "github.com/malikabdulaziz/tmn-backend/controllers/buildingrestriction"
 	category3 "github.com/malikabdulaziz/tmn-backend/controllers/category"
 	dashboard3 "github.com/malikabdulaziz/tmn-backend/controllers/dashboard"
 	"github.com/malikabdulaziz/tmn-backend/controllers/image"
+	importjob3 "github.com/malikabdulaziz/tmn-backend/controllers/importjob"
+	masterdata3 "github.com/malikabdulaziz/tmn-backend/controllers/masterdata"
 	motherbrand3 "github.com/malikabdulaziz/tmn-backend/controllers/motherbrand"
 	poi3 "github.com/malikabdulaziz/tmn-backend/controllers/poi"
+	poiduplicate3 "github.com/malikabdulaziz/tmn-backend/controllers/poiduplicate"
+	proposal2 "github.com/malikabdulaziz/tmn-backend/controllers/proposal"
 	salespackage3 "github.com/malikabdulaziz/tmn-backend/controllers/salespackage"
 	savedpolygon3 "github.com/malikabdulaziz/tmn-backend/controllers/savedpolygon"
+	savedview3 "github.com/malikabdulaziz/tmn-backend/controllers/savedview"
+	sharelink3 "github.com/malikabdulaziz/tmn-backend/controllers/sharelink"
 	subcategory3 "github.com/malikabdulaziz/tmn-backend/controllers/subcategory"
+	trash3 "github.com/malikabdulaziz/tmn-backend/controllers/trash"
 	"github.com/malikabdulaziz/tmn-backend/libs"
 	"github.com/malikabdulaziz/tmn-backend/middlewares"
+	"github.com/malikabdulaziz/tmn-backend/repositories/adminboundary"
 	"github.com/malikabdulaziz/tmn-backend/repositories/auth"
+	"github.com/malikabdulaziz/tmn-backend/repositories/booking"
 	"github.com/malikabdulaziz/tmn-backend/repositories/branch"
 	"github.com/malikabdulaziz/tmn-backend/repositories/building"
 	"github.com/malikabdulaziz/tmn-backend/repositories/buildingrestriction"
 	"github.com/malikabdulaziz/tmn-backend/repositories/category"
 	"github.com/malikabdulaziz/tmn-backend/repositories/dashboard"
+	"github.com/malikabdulaziz/tmn-backend/repositories/geocodecache"
+	"github.com/malikabdulaziz/tmn-backend/repositories/importjob"
+	"github.com/malikabdulaziz/tmn-backend/repositories/importpreview"
+	"github.com/malikabdulaziz/tmn-backend/repositories/masterdata"
 	"github.com/malikabdulaziz/tmn-backend/repositories/motherbrand"
 	"github.com/malikabdulaziz/tmn-backend/repositories/poi"
+	"github.com/malikabdulaziz/tmn-backend/repositories/poiduplicate"
 	"github.com/malikabdulaziz/tmn-backend/repositories/salespackage"
 	"github.com/malikabdulaziz/tmn-backend/repositories/savedpolygon"
+	"github.com/malikabdulaziz/tmn-backend/repositories/savedview"
+	"github.com/malikabdulaziz/tmn-backend/repositories/sharelink"
 	"github.com/malikabdulaziz/tmn-backend/repositories/subcategory"
+	"github.com/malikabdulaziz/tmn-backend/repositories/trash"
 	"github.com/malikabdulaziz/tmn-backend/repositories/user"
 	"github.com/malikabdulaziz/tmn-backend/services/acquisition"
+	adminboundary2 "github.com/malikabdulaziz/tmn-backend/services/adminboundary"
 	auth2 "github.com/malikabdulaziz/tmn-backend/services/auth"
+	booking2 "github.com/malikabdulaziz/tmn-backend/services/booking"
 	branch2 "github.com/malikabdulaziz/tmn-backend/services/branch"
 	building2 "github.com/malikabdulaziz/tmn-backend/services/building"
 	"github.com/malikabdulaziz/tmn-backend/services/buildingproposal"
 	buildingrestriction2 "github.com/malikabdulaziz/tmn-backend/services/buildingrestriction"
 	category2 "github.com/malikabdulaziz/tmn-backend/services/category"
 	dashboard2 "github.com/malikabdulaziz/tmn-backend/services/dashboard"
+	"github.com/malikabdulaziz/tmn-backend/services/geocoding"
+	importjob2 "github.com/malikabdulaziz/tmn-backend/services/importjob"
 	"github.com/malikabdulaziz/tmn-backend/services/loi"
+	masterdata2 "github.com/malikabdulaziz/tmn-backend/services/masterdata"
 	motherbrand2 "github.com/malikabdulaziz/tmn-backend/services/motherbrand"
 	poi2 "github.com/malikabdulaziz/tmn-backend/services/poi"
+	poiduplicate2 "github.com/malikabdulaziz/tmn-backend/services/poiduplicate"
+	"github.com/malikabdulaziz/tmn-backend/services/proposal"
 	salespackage2 "github.com/malikabdulaziz/tmn-backend/services/salespackage"
 	savedpolygon2 "github.com/malikabdulaziz/tmn-backend/services/savedpolygon"
+	savedview2 "github.com/malikabdulaziz/tmn-backend/services/savedview"
+	sharelink2 "github.com/malikabdulaziz/tmn-backend/services/sharelink"
 	subcategory2 "github.com/malikabdulaziz/tmn-backend/services/subcategory"
+	trash2 "github.com/malikabdulaziz/tmn-backend/services/trash"
 )
 
 // Injectors from wire.go:
@@ -77,34 +106,71 @@ func InitializeRouter() *httprouter.Router {
 	motherBrandMiddleware := middlewares.NewMotherBrandMiddleware(validate, db, repositoryMotherBrandInterface)
 	repositoryBranchInterface := branch.NewRepositoryBranchImpl()
 	branchMiddleware := middlewares.NewBranchMiddleware(validate, db, repositoryBranchInterface)
+	repositoryBookingInterface := booking.NewRepositoryBookingImpl()
+	bookingMiddleware := middlewares.NewBookingMiddleware(validate, db, repositoryBookingInterface)
+	shareLinkMiddleware := middlewares.NewShareLinkMiddleware(validate)
+	repositorySavedViewInterface := savedview.NewRepositorySavedViewImpl()
+	savedViewMiddleware := middlewares.NewSavedViewMiddleware(validate, db, repositorySavedViewInterface)
 	repositoryUserInterface := user.NewRepositoryUserImpl()
 	serviceAuthInterface := auth2.NewServiceAuthImpl(db, repositoryAuthInterface, repositoryUserInterface)
 	controllerAuthInterface := auth3.NewControllerAuthImpl(db, serviceAuthInterface, repositoryUserInterface)
 	erpClient := libs.ProvideERPClient()
 	logger := libs.NewLogger()
 	serviceBuildingInterface := building2.NewServiceBuildingImpl(db, repositoryBuildingInterface, repositoryPOIInterface, erpClient, logger)
-	controllerBuildingInterface := building3.NewControllerBuildingImpl(serviceBuildingInterface)
+	serviceSavedViewInterface := savedview2.NewServiceSavedViewImpl(db, repositorySavedViewInterface)
+	controllerBuildingInterface := building3.NewControllerBuildingImpl(serviceBuildingInterface, serviceSavedViewInterface)
 	controllerImageInterface := image.NewControllerImageImpl()
-	servicePOIInterface := poi2.NewServicePOIImpl(db, repositoryPOIInterface, repositoryCategoryInterface, repositorySubCategoryInterface, repositoryMotherBrandInterface, repositoryBranchInterface)
+	repositoryImportPreviewInterface := importpreview.NewRepositoryImportPreviewImpl()
+	repositoryGeocodeCacheInterface := geocodecache.NewRepositoryGeocodeCacheImpl()
+	provider := libs.ProvideGeocoder()
+	serviceGeocodingInterface := geocoding.NewServiceGeocodingImpl(db, repositoryGeocodeCacheInterface, provider, logger)
+	servicePOIInterface := poi2.NewServicePOIImpl(db, repositoryPOIInterface, repositoryCategoryInterface, repositorySubCategoryInterface, repositoryMotherBrandInterface, repositoryBranchInterface, repositoryImportPreviewInterface, serviceGeocodingInterface)
 	controllerPOIInterface := poi3.NewControllerPOIImpl(servicePOIInterface)
-	serviceSalesPackageInterface := salespackage2.NewServiceSalesPackageImpl(db, repositorySalesPackageInterface, repositoryBuildingInterface)
+	serviceSalesPackageInterface := salespackage2.NewServiceSalesPackageImpl(db, repositorySalesPackageInterface, repositoryBuildingInterface, serviceBuildingInterface, repositoryBuildingRestrictionInterface)
 	controllerSalesPackageInterface := salespackage3.NewControllerSalesPackageImpl(serviceSalesPackageInterface)
-	serviceBuildingRestrictionInterface := buildingrestriction2.NewServiceBuildingRestrictionImpl(db, repositoryBuildingRestrictionInterface, repositoryBuildingInterface)
+	serviceBookingInterface := booking2.NewServiceBookingImpl(db, repositoryBookingInterface, repositorySalesPackageInterface, repositoryBuildingRestrictionInterface, repositoryCategoryInterface, repositoryMotherBrandInterface)
+	controllerBookingInterface := booking3.NewControllerBookingImpl(serviceBookingInterface)
+	serviceBuildingRestrictionInterface := buildingrestriction2.NewServiceBuildingRestrictionImpl(db, repositoryBuildingRestrictionInterface, repositoryBuildingInterface, repositoryCategoryInterface, repositoryMotherBrandInterface)
 	controllerBuildingRestrictionInterface := buildingrestriction3.NewControllerBuildingRestrictionImpl(serviceBuildingRestrictionInterface)
 	serviceSavedPolygonInterface := savedpolygon2.NewServiceSavedPolygonImpl(db, repositorySavedPolygonInterface)
 	controllerSavedPolygonInterface := savedpolygon3.NewControllerSavedPolygonImpl(serviceSavedPolygonInterface)
+	controllerSavedViewInterface := savedview3.NewControllerSavedViewImpl(serviceSavedViewInterface)
+	repositoryTrashInterface := trash.NewRepositoryTrashImpl()
+	trashConfig := libs.ProvideTrashConfig()
+	serviceTrashInterface := trash2.NewServiceTrashImpl(db, repositoryTrashInterface, trashConfig)
+	controllerTrashInterface := trash3.NewControllerTrashImpl(serviceTrashInterface)
+	masterDataMiddleware := middlewares.NewMasterDataMiddleware(validate)
+	repositoryMasterDataInterface := masterdata.NewRepositoryMasterDataImpl()
+	serviceMasterDataInterface := masterdata2.NewServiceMasterDataImpl(db, repositoryMasterDataInterface)
+	controllerMasterDataInterface := masterdata3.NewControllerMasterDataImpl(serviceMasterDataInterface)
 	repositoryDashboardInterface := dashboard.NewRepositoryDashboardImpl()
 	serviceDashboardInterface := dashboard2.NewServiceDashboardImpl(db, repositoryDashboardInterface, logger)
 	controllerDashboardInterface := dashboard3.NewControllerDashboardImpl(serviceDashboardInterface)
-	serviceCategoryInterface := category2.NewServiceCategoryImpl(db, repositoryCategoryInterface)
+	serviceCategoryInterface := category2.NewServiceCategoryImpl(db, repositoryCategoryInterface, repositorySubCategoryInterface)
 	controllerCategoryInterface := category3.NewControllerCategoryImpl(serviceCategoryInterface)
-	serviceSubCategoryInterface := subcategory2.NewServiceSubCategoryImpl(db, repositorySubCategoryInterface)
+	serviceSubCategoryInterface := subcategory2.NewServiceSubCategoryImpl(db, repositorySubCategoryInterface, repositoryCategoryInterface)
 	controllerSubCategoryInterface := subcategory3.NewControllerSubCategoryImpl(serviceSubCategoryInterface)
-	serviceMotherBrandInterface := motherbrand2.NewServiceMotherBrandImpl(db, repositoryMotherBrandInterface)
+	serviceMotherBrandInterface := motherbrand2.NewServiceMotherBrandImpl(db, repositoryMotherBrandInterface, repositoryBranchInterface)
 	controllerMotherBrandInterface := motherbrand3.NewControllerMotherBrandImpl(serviceMotherBrandInterface)
-	serviceBranchInterface := branch2.NewServiceBranchImpl(db, repositoryBranchInterface)
+	serviceBranchInterface := branch2.NewServiceBranchImpl(db, repositoryBranchInterface, repositoryMotherBrandInterface)
 	controllerBranchInterface := branch3.NewControllerBranchImpl(serviceBranchInterface)
-	router := libs.NewRouter(authMiddleware, buildingMiddleware, poiMiddleware, salesPackageMiddleware, buildingRestrictionMiddleware, savedPolygonMiddleware, loggingMiddleware, categoryMiddleware, subCategoryMiddleware, motherBrandMiddleware, branchMiddleware, controllerAuthInterface, controllerBuildingInterface, controllerImageInterface, controllerPOIInterface, controllerSalesPackageInterface, controllerBuildingRestrictionInterface, controllerSavedPolygonInterface, controllerDashboardInterface, controllerCategoryInterface, controllerSubCategoryInterface, controllerMotherBrandInterface, controllerBranchInterface)
+	repositoryAdminBoundaryInterface := adminboundary.NewRepositoryAdminBoundaryImpl()
+	serviceAdminBoundaryInterface := adminboundary2.NewServiceAdminBoundaryImpl(db, repositoryAdminBoundaryInterface, repositoryBuildingInterface)
+	controllerAdminBoundaryInterface := adminboundary3.NewControllerAdminBoundaryImpl(serviceAdminBoundaryInterface)
+	repositoryImportJobInterface := importjob.NewRepositoryImportJobImpl()
+	serviceImportJobInterface := importjob2.NewServiceImportJobImpl(db, repositoryImportJobInterface, servicePOIInterface, serviceSalesPackageInterface, serviceBuildingRestrictionInterface, serviceCategoryInterface, serviceSubCategoryInterface, serviceMotherBrandInterface, serviceBranchInterface)
+	controllerImportJobInterface := importjob3.NewControllerImportJobImpl(serviceImportJobInterface)
+	repositoryPOIDuplicateInterface := poiduplicate.NewRepositoryPOIDuplicateImpl()
+	servicePOIDuplicateInterface := poiduplicate2.NewServicePOIDuplicateImpl(db, repositoryPOIDuplicateInterface, repositoryPOIInterface)
+	controllerPOIDuplicateInterface := poiduplicate3.NewControllerPOIDuplicateImpl(servicePOIDuplicateInterface)
+	config := libs.ProvideProposalConfig()
+	serviceProposalInterface := proposal.NewServiceProposalImpl(db, repositorySalesPackageInterface, repositoryBuildingInterface, erpClient, config, logger)
+	controllerProposalInterface := proposal2.NewControllerProposalImpl(serviceProposalInterface)
+	repositoryShareLinkInterface := sharelink.NewRepositoryShareLinkImpl()
+	sharelinkConfig := libs.ProvideShareLinkConfig()
+	serviceShareLinkInterface := sharelink2.NewServiceShareLinkImpl(db, repositoryShareLinkInterface, repositorySalesPackageInterface, repositorySavedPolygonInterface, repositoryBuildingRestrictionInterface, repositoryBuildingInterface, serviceBuildingInterface, sharelinkConfig)
+	controllerShareLinkInterface := sharelink3.NewControllerShareLinkImpl(serviceShareLinkInterface)
+	router := libs.NewRouter(authMiddleware, buildingMiddleware, poiMiddleware, salesPackageMiddleware, buildingRestrictionMiddleware, savedPolygonMiddleware, loggingMiddleware, categoryMiddleware, subCategoryMiddleware, motherBrandMiddleware, branchMiddleware, bookingMiddleware, shareLinkMiddleware, savedViewMiddleware, masterDataMiddleware, controllerAuthInterface, controllerBuildingInterface, controllerImageInterface, controllerPOIInterface, controllerSalesPackageInterface, controllerBuildingRestrictionInterface, controllerSavedPolygonInterface, controllerDashboardInterface, controllerCategoryInterface, controllerSubCategoryInterface, controllerMotherBrandInterface, controllerBranchInterface, controllerAdminBoundaryInterface, controllerImportJobInterface, controllerPOIDuplicateInterface, controllerBookingInterface, controllerProposalInterface, controllerShareLinkInterface, controllerSavedViewInterface, controllerTrashInterface, controllerMasterDataInterface)
 	return router
 }
 
@@ -118,6 +184,14 @@ func InitializeBuildingService() building2.ServiceBuildingInterface {
 	return serviceBuildingInterface
 }
 
+func InitializeAdminBoundaryService() adminboundary2.ServiceAdminBoundaryInterface {
+	db := libs.NewDatabase()
+	repositoryAdminBoundaryInterface := adminboundary.NewRepositoryAdminBoundaryImpl()
+	repositoryBuildingInterface := building.NewRepositoryBuildingImpl()
+	serviceAdminBoundaryInterface := adminboundary2.NewServiceAdminBoundaryImpl(db, repositoryAdminBoundaryInterface, repositoryBuildingInterface)
+	return serviceAdminBoundaryInterface
+}
+
 func InitializeAcquisitionService() acquisition.ServiceAcquisitionInterface {
 	db := libs.NewDatabase()
 	erpClient := libs.ProvideERPClient()
@@ -142,6 +216,43 @@ func InitializeLOIService() loi.ServiceLOIInterface {
 	return serviceLOIInterface
 }
 
+func InitializeImportJobService() importjob2.ServiceImportJobInterface {
+	db := libs.NewDatabase()
+	logger := libs.NewLogger()
+	repositoryImportJobInterface := importjob.NewRepositoryImportJobImpl()
+	repositoryPOIInterface := poi.NewRepositoryPOIImpl()
+	repositoryCategoryInterface := category.NewRepositoryCategoryImpl()
+	repositorySubCategoryInterface := subcategory.NewRepositorySubCategoryImpl()
+	repositoryMotherBrandInterface := motherbrand.NewRepositoryMotherBrandImpl()
+	repositoryBranchInterface := branch.NewRepositoryBranchImpl()
+	repositoryImportPreviewInterface := importpreview.NewRepositoryImportPreviewImpl()
+	repositoryGeocodeCacheInterface := geocodecache.NewRepositoryGeocodeCacheImpl()
+	provider := libs.ProvideGeocoder()
+	serviceGeocodingInterface := geocoding.NewServiceGeocodingImpl(db, repositoryGeocodeCacheInterface, provider, logger)
+	servicePOIInterface := poi2.NewServicePOIImpl(db, repositoryPOIInterface, repositoryCategoryInterface, repositorySubCategoryInterface, repositoryMotherBrandInterface, repositoryBranchInterface, repositoryImportPreviewInterface, serviceGeocodingInterface)
+	repositorySalesPackageInterface := salespackage.NewRepositorySalesPackageImpl()
+	repositoryBuildingInterface := building.NewRepositoryBuildingImpl()
+	erpClient := libs.ProvideERPClient()
+	serviceBuildingInterface := building2.NewServiceBuildingImpl(db, repositoryBuildingInterface, repositoryPOIInterface, erpClient, logger)
+	repositoryBuildingRestrictionInterface := buildingrestriction.NewRepositoryBuildingRestrictionImpl()
+	serviceSalesPackageInterface := salespackage2.NewServiceSalesPackageImpl(db, repositorySalesPackageInterface, repositoryBuildingInterface, serviceBuildingInterface, repositoryBuildingRestrictionInterface)
+	serviceBuildingRestrictionInterface := buildingrestriction2.NewServiceBuildingRestrictionImpl(db, repositoryBuildingRestrictionInterface, repositoryBuildingInterface, repositoryCategoryInterface, repositoryMotherBrandInterface)
+	serviceCategoryInterface := category2.NewServiceCategoryImpl(db, repositoryCategoryInterface, repositorySubCategoryInterface)
+	serviceSubCategoryInterface := subcategory2.NewServiceSubCategoryImpl(db, repositorySubCategoryInterface, repositoryCategoryInterface)
+	serviceMotherBrandInterface := motherbrand2.NewServiceMotherBrandImpl(db, repositoryMotherBrandInterface, repositoryBranchInterface)
+	serviceBranchInterface := branch2.NewServiceBranchImpl(db, repositoryBranchInterface, repositoryMotherBrandInterface)
+	serviceImportJobInterface := importjob2.NewServiceImportJobImpl(db, repositoryImportJobInterface, servicePOIInterface, serviceSalesPackageInterface, serviceBuildingRestrictionInterface, serviceCategoryInterface, serviceSubCategoryInterface, serviceMotherBrandInterface, serviceBranchInterface)
+	return serviceImportJobInterface
+}
+
+func InitializeTrashService() trash2.ServiceTrashInterface {
+	db := libs.NewDatabase()
+	config := libs.ProvideTrashConfig()
+	repositoryTrashInterface := trash.NewRepositoryTrashImpl()
+	serviceTrashInterface := trash2.NewServiceTrashImpl(db, repositoryTrashInterface, config)
+	return serviceTrashInterface
+}
+
 // wire.go:
 
 var authSet = wire.NewSet(auth.NewRepositoryAuthJWTImpl, user.NewRepositoryUserImpl, auth2.NewServiceAuthImpl, auth3.NewControllerAuthImpl)
@@ -158,14 +269,34 @@ var motherBrandSet = wire.NewSet(motherbrand.NewRepositoryMotherBrandImpl, mothe
 
 var branchSet = wire.NewSet(branch.NewRepositoryBranchImpl, branch2.NewServiceBranchImpl, branch3.NewControllerBranchImpl)
 
-var poiSet = wire.NewSet(poi.NewRepositoryPOIImpl, poi2.NewServicePOIImpl, poi3.NewControllerPOIImpl)
+var geocodingSet = wire.NewSet(libs.ProvideGeocoder, geocodecache.NewRepositoryGeocodeCacheImpl, geocoding.NewServiceGeocodingImpl)
+
+var poiSet = wire.NewSet(poi.NewRepositoryPOIImpl, importpreview.NewRepositoryImportPreviewImpl, poi2.NewServicePOIImpl, poi3.NewControllerPOIImpl)
+
+var poiDuplicateSet = wire.NewSet(poiduplicate.NewRepositoryPOIDuplicateImpl, poiduplicate2.NewServicePOIDuplicateImpl, poiduplicate3.NewControllerPOIDuplicateImpl)
 
 var salespackageSet = wire.NewSet(salespackage.NewRepositorySalesPackageImpl, salespackage2.NewServiceSalesPackageImpl, salespackage3.NewControllerSalesPackageImpl)
 
+var bookingSet = wire.NewSet(booking.NewRepositoryBookingImpl, booking2.NewServiceBookingImpl, booking3.NewControllerBookingImpl)
+
+var proposalSet = wire.NewSet(libs.ProvideProposalConfig, proposal.NewServiceProposalImpl, proposal2.NewControllerProposalImpl)
+
+var shareLinkSet = wire.NewSet(libs.ProvideShareLinkConfig, sharelink.NewRepositoryShareLinkImpl, sharelink2.NewServiceShareLinkImpl, sharelink3.NewControllerShareLinkImpl)
+
 var buildingrestrictionSet = wire.NewSet(buildingrestriction.NewRepositoryBuildingRestrictionImpl, buildingrestriction2.NewServiceBuildingRestrictionImpl, buildingrestriction3.NewControllerBuildingRestrictionImpl)
 
 var savedpolygonSet = wire.NewSet(savedpolygon.NewRepositorySavedPolygonImpl, savedpolygon2.NewServiceSavedPolygonImpl, savedpolygon3.NewControllerSavedPolygonImpl)
 
+var savedViewSet = wire.NewSet(savedview.NewRepositorySavedViewImpl, savedview2.NewServiceSavedViewImpl, savedview3.NewControllerSavedViewImpl)
+
+var trashSet = wire.NewSet(libs.ProvideTrashConfig, trash.NewRepositoryTrashImpl, trash2.NewServiceTrashImpl, trash3.NewControllerTrashImpl)
+
+var masterDataSet = wire.NewSet(masterdata.NewRepositoryMasterDataImpl, masterdata2.NewServiceMasterDataImpl, masterdata3.NewControllerMasterDataImpl)
+
 var dashboardSet = wire.NewSet(dashboard.NewRepositoryDashboardImpl, dashboard2.NewServiceDashboardImpl, dashboard3.NewControllerDashboardImpl)
 
-var middlewareSet = wire.NewSet(middlewares.NewAuthMiddleware, middlewares.NewBuildingMiddleware, middlewares.NewPOIMiddleware, middlewares.NewSalesPackageMiddleware, middlewares.NewBuildingRestrictionMiddleware, middlewares.NewSavedPolygonMiddleware, middlewares.NewLoggingMiddleware, middlewares.NewCategoryMiddleware, middlewares.NewSubCategoryMiddleware, middlewares.NewMotherBrandMiddleware, middlewares.NewBranchMiddleware)
+var adminBoundarySet = wire.NewSet(adminboundary.NewRepositoryAdminBoundaryImpl, adminboundary2.NewServiceAdminBoundaryImpl, adminboundary3.NewControllerAdminBoundaryImpl)
+
+var importJobSet = wire.NewSet(importjob.NewRepositoryImportJobImpl, importjob2.NewServiceImportJobImpl, importjob3.NewControllerImportJobImpl)
+
+var middlewareSet = wire.NewSet(middlewares.NewAuthMiddleware, middlewares.NewBuildingMiddleware, middlewares.NewPOIMiddleware, middlewares.NewSalesPackageMiddleware, middlewares.NewBuildingRestrictionMiddleware, middlewares.NewSavedPolygonMiddleware, middlewares.NewLoggingMiddleware, middlewares.NewCategoryMiddleware, middlewares.NewSubCategoryMiddleware, middlewares.NewMotherBrandMiddleware, middlewares.NewBranchMiddleware, middlewares.NewBookingMiddleware, middlewares.NewShareLinkMiddleware, middlewares.NewSavedViewMiddleware, middlewares.NewMasterDataMiddleware)
@@ -0,0 +1,42 @@
+package libs
+
+import (
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/malikabdulaziz/tmn-backend/helpers"
+	"github.com/malikabdulaziz/tmn-backend/services/geocoding"
+)
+
+// ProvideGeocoder provides the geocoding provider selected by GEOCODER_PROVIDER (nominatim,
+// gazetteer or none). It returns nil, which disables geocoding, when none is configured.
+func ProvideGeocoder() geocoding.Provider {
+	switch strings.ToLower(os.Getenv("GEOCODER_PROVIDER")) {
+	case "nominatim":
+		baseURL := os.Getenv("GEOCODER_URL")
+		if baseURL == "" {
+			baseURL = "https://nominatim.openstreetmap.org"
+		}
+		userAgent := os.Getenv("GEOCODER_USER_AGENT")
+		if userAgent == "" {
+			userAgent = "tmn-backend"
+		}
+		// The public Nominatim service allows one request per second
+		intervalMs := 1000
+		if v, err := strconv.Atoi(os.Getenv("GEOCODER_MIN_INTERVAL_MS")); err == nil && v >= 0 {
+			intervalMs = v
+		}
+		return geocoding.NewNominatimProvider(baseURL, userAgent, time.Duration(intervalMs)*time.Millisecond)
+	case "gazetteer":
+		provider, err := geocoding.LoadGazetteer(os.Getenv("GEOCODER_GAZETTEER_FILE"))
+		if err != nil {
+			helpers.Logger.WithError(err).Error("Failed to load gazetteer, geocoding is disabled")
+			return nil
+		}
+		return provider
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,23 @@
+package libs
+
+import (
+	"os"
+
+	"github.com/malikabdulaziz/tmn-backend/helpers"
+	"github.com/malikabdulaziz/tmn-backend/services/proposal"
+)
+
+// ProvideProposalConfig provides the proposal templates from PROPOSAL_TEMPLATES_FILE, on top of
+// the built-in default template, and the static map URL from PROPOSAL_STATIC_MAP_URL
+func ProvideProposalConfig() proposal.Config {
+	var templates []proposal.Template
+	if path := os.Getenv("PROPOSAL_TEMPLATES_FILE"); path != "" {
+		loaded, err := proposal.LoadTemplates(path)
+		if err != nil {
+			helpers.Logger.WithError(err).Error("Failed to load proposal templates, only the default template is available")
+		} else {
+			templates = loaded
+		}
+	}
+	return proposal.NewConfig(templates, os.Getenv("PROPOSAL_STATIC_MAP_URL"))
+}
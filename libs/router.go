@@ -4,18 +4,27 @@ import (
 	"net/http"
 
 	"github.com/julienschmidt/httprouter"
+	controllersAdminBoundary "github.com/malikabdulaziz/tmn-backend/controllers/adminboundary"
 	controllersAuth "github.com/malikabdulaziz/tmn-backend/controllers/auth"
+	controllersBooking "github.com/malikabdulaziz/tmn-backend/controllers/booking"
 	controllersBranch "github.com/malikabdulaziz/tmn-backend/controllers/branch"
 	controllersBuilding "github.com/malikabdulaziz/tmn-backend/controllers/building"
 	controllersBuildingRestriction "github.com/malikabdulaziz/tmn-backend/controllers/buildingrestriction"
 	controllersCategory "github.com/malikabdulaziz/tmn-backend/controllers/category"
 	controllersDashboard "github.com/malikabdulaziz/tmn-backend/controllers/dashboard"
 	controllersImage "github.com/malikabdulaziz/tmn-backend/controllers/image"
+	controllersImportJob "github.com/malikabdulaziz/tmn-backend/controllers/importjob"
+	controllersMasterData "github.com/malikabdulaziz/tmn-backend/controllers/masterdata"
 	controllersMotherBrand "github.com/malikabdulaziz/tmn-backend/controllers/motherbrand"
 	controllersPOI "github.com/malikabdulaziz/tmn-backend/controllers/poi"
+	controllersPOIDuplicate "github.com/malikabdulaziz/tmn-backend/controllers/poiduplicate"
+	controllersProposal "github.com/malikabdulaziz/tmn-backend/controllers/proposal"
 	controllersSalesPackage "github.com/malikabdulaziz/tmn-backend/controllers/salespackage"
 	controllersSavedPolygon "github.com/malikabdulaziz/tmn-backend/controllers/savedpolygon"
+	controllersSavedView "github.com/malikabdulaziz/tmn-backend/controllers/savedview"
+	controllersShareLink "github.com/malikabdulaziz/tmn-backend/controllers/sharelink"
 	controllersSubCategory "github.com/malikabdulaziz/tmn-backend/controllers/subcategory"
+	controllersTrash "github.com/malikabdulaziz/tmn-backend/controllers/trash"
 	"github.com/malikabdulaziz/tmn-backend/exceptions"
 	"github.com/malikabdulaziz/tmn-backend/middlewares"
 )
@@ -32,6 +41,10 @@ func NewRouter(
 	subCategoryMiddleware *middlewares.SubCategoryMiddleware,
 	motherBrandMiddleware *middlewares.MotherBrandMiddleware,
 	branchMiddleware *middlewares.BranchMiddleware,
+	bookingMiddleware *middlewares.BookingMiddleware,
+	shareLinkMiddleware *middlewares.ShareLinkMiddleware,
+	savedViewMiddleware *middlewares.SavedViewMiddleware,
+	masterDataMiddleware *middlewares.MasterDataMiddleware,
 	controllersAuth controllersAuth.ControllerAuthInterface,
 	controllersBuilding controllersBuilding.ControllerBuildingInterface,
 	controllersImage controllersImage.ControllerImageInterface,
@@ -44,6 +57,15 @@ func NewRouter(
 	controllersSubCategory controllersSubCategory.ControllerSubCategoryInterface,
 	controllersMotherBrand controllersMotherBrand.ControllerMotherBrandInterface,
 	controllersBranch controllersBranch.ControllerBranchInterface,
+	controllersAdminBoundary controllersAdminBoundary.ControllerAdminBoundaryInterface,
+	controllersImportJob controllersImportJob.ControllerImportJobInterface,
+	controllersPOIDuplicate controllersPOIDuplicate.ControllerPOIDuplicateInterface,
+	controllersBooking controllersBooking.ControllerBookingInterface,
+	controllersProposal controllersProposal.ControllerProposalInterface,
+	controllersShareLink controllersShareLink.ControllerShareLinkInterface,
+	controllersSavedView controllersSavedView.ControllerSavedViewInterface,
+	controllersTrash controllersTrash.ControllerTrashInterface,
+	controllersMasterData controllersMasterData.ControllerMasterDataInterface,
 ) *httprouter.Router {
 	router := httprouter.New()
 
@@ -64,6 +86,10 @@ func NewRouter(
 	router.POST("/logout",
 		loggingMiddleware.Log(controllersAuth.Logout))
 
+	// Read-only share links open without login; the signed token is the credential
+	router.GET("/shared/:token",
+		loggingMiddleware.Log(controllersShareLink.View))
+
 	// Protected routes (with logging)
 	router.GET("/current-user",
 		loggingMiddleware.Log(
@@ -78,6 +104,10 @@ func NewRouter(
 		loggingMiddleware.Log(
 			authMiddleware.RequireAuth(controllersBuilding.FindById)))
 
+	router.GET("/buildings/:id/nearby-pois",
+		loggingMiddleware.Log(
+			authMiddleware.RequireAuth(controllersBuilding.FindNearbyPOIs)))
+
 	router.PUT("/buildings/:id",
 		loggingMiddleware.Log(
 			authMiddleware.RequireAuth(
@@ -91,6 +121,11 @@ func NewRouter(
 		loggingMiddleware.Log(
 			authMiddleware.RequireAuth(controllersBuilding.GetFilterOptions)))
 
+	// Hyphenated like /building-filter-options: httprouter cannot register /buildings/nearest next to /buildings/:id
+	router.GET("/buildings-nearest",
+		loggingMiddleware.Log(
+			authMiddleware.RequireAuth(controllersBuilding.FindNearest)))
+
 	router.GET("/building-dropdown",
 		loggingMiddleware.Log(
 			authMiddleware.RequireAuth(controllersBuilding.GetDropdownOptions)))
@@ -103,6 +138,15 @@ func NewRouter(
 		loggingMiddleware.Log(
 			authMiddleware.RequireAuth(controllersBuilding.ExportMappingBuildings)))
 
+	// POI density analytics around buildings
+	router.POST("/analytics/poi-density",
+		loggingMiddleware.Log(
+			authMiddleware.RequireAuth(controllersBuilding.AnalyzePOIDensity)))
+
+	router.POST("/analytics/poi-density/export",
+		loggingMiddleware.Log(
+			authMiddleware.RequireAuth(controllersBuilding.ExportPOIDensity)))
+
 	// Image proxy route (protected)
 	router.GET("/erp-images/*filepath",
 		loggingMiddleware.Log(
@@ -113,10 +157,23 @@ func NewRouter(
 		loggingMiddleware.Log(
 			authMiddleware.RequireAuth(controllersPOI.Import)))
 
+	router.POST("/pois-import-preview",
+		loggingMiddleware.Log(
+			authMiddleware.RequireAuth(controllersPOI.PreviewImport)))
+
+	router.POST("/pois-import-confirm",
+		loggingMiddleware.Log(
+			authMiddleware.RequireAuth(
+				poiMiddleware.ValidateConfirmImport(controllersPOI.ConfirmImport))))
+
 	router.GET("/pois-export",
 		loggingMiddleware.Log(
 			authMiddleware.RequireAuth(controllersPOI.Export)))
 
+	router.GET("/pois-import-template",
+		loggingMiddleware.Log(
+			authMiddleware.RequireAuth(controllersPOI.ImportTemplate)))
+
 	router.POST("/pois",
 		loggingMiddleware.Log(
 			authMiddleware.RequireAuth(
@@ -139,6 +196,21 @@ func NewRouter(
 		loggingMiddleware.Log(
 			authMiddleware.RequireAuth(controllersPOI.Delete)))
 
+	// POI duplicate review routes (protected)
+	router.GET("/poi-duplicates",
+		loggingMiddleware.Log(
+			authMiddleware.RequireAuth(controllersPOIDuplicate.FindAll)))
+
+	router.POST("/poi-duplicates/merge",
+		loggingMiddleware.Log(
+			authMiddleware.RequireAuth(
+				poiMiddleware.ValidateMergeDuplicate(controllersPOIDuplicate.Merge))))
+
+	router.POST("/poi-duplicates/dismiss",
+		loggingMiddleware.Log(
+			authMiddleware.RequireAuth(
+				poiMiddleware.ValidateDismissDuplicate(controllersPOIDuplicate.Dismiss))))
+
 	// Sales package routes (protected)
 	router.POST("/sales-packages",
 		loggingMiddleware.Log(
@@ -153,6 +225,18 @@ func NewRouter(
 		loggingMiddleware.Log(
 			authMiddleware.RequireAuth(controllersSalesPackage.FindById)))
 
+	router.GET("/sales-packages/:id/summary",
+		loggingMiddleware.Log(
+			authMiddleware.RequireAuth(controllersSalesPackage.Summary)))
+
+	router.GET("/sales-packages/:id/proposal",
+		loggingMiddleware.Log(
+			authMiddleware.RequireAuth(controllersProposal.Generate)))
+
+	router.GET("/proposal-templates",
+		loggingMiddleware.Log(
+			authMiddleware.RequireAuth(controllersProposal.Templates)))
+
 	router.PUT("/sales-packages/:id",
 		loggingMiddleware.Log(
 			authMiddleware.RequireAuth(
@@ -162,6 +246,40 @@ func NewRouter(
 		loggingMiddleware.Log(
 			authMiddleware.RequireAuth(controllersSalesPackage.Delete)))
 
+	router.POST("/sales-packages-from-filter",
+		loggingMiddleware.Log(
+			authMiddleware.RequireAuth(
+				salesPackageMiddleware.ValidateCreateFromFilter(controllersSalesPackage.CreateFromFilter))))
+
+	router.PUT("/sales-packages/:id/from-filter",
+		loggingMiddleware.Log(
+			authMiddleware.RequireAuth(
+				salesPackageMiddleware.ValidateUpdateFromFilter(controllersSalesPackage.UpdateFromFilter))))
+
+	router.GET("/sales-packages/:id/refresh-preview",
+		loggingMiddleware.Log(
+			authMiddleware.RequireAuth(controllersSalesPackage.PreviewRefresh)))
+
+	router.POST("/sales-packages/:id/refresh",
+		loggingMiddleware.Log(
+			authMiddleware.RequireAuth(controllersSalesPackage.Refresh)))
+
+	router.GET("/sales-packages/:id/versions",
+		loggingMiddleware.Log(
+			authMiddleware.RequireAuth(controllersSalesPackage.Versions)))
+
+	router.GET("/sales-packages/:id/versions/:version",
+		loggingMiddleware.Log(
+			authMiddleware.RequireAuth(controllersSalesPackage.FindVersion)))
+
+	router.POST("/sales-packages/:id/versions/:version/restore",
+		loggingMiddleware.Log(
+			authMiddleware.RequireAuth(controllersSalesPackage.RestoreVersion)))
+
+	router.GET("/sales-packages/:id/version-diff",
+		loggingMiddleware.Log(
+			authMiddleware.RequireAuth(controllersSalesPackage.DiffVersions)))
+
 	router.POST("/sales-packages-import",
 		loggingMiddleware.Log(
 			authMiddleware.RequireAuth(controllersSalesPackage.Import)))
@@ -170,6 +288,64 @@ func NewRouter(
 		loggingMiddleware.Log(
 			authMiddleware.RequireAuth(controllersSalesPackage.Export)))
 
+	router.GET("/sales-packages-import-template",
+		loggingMiddleware.Log(
+			authMiddleware.RequireAuth(controllersSalesPackage.ImportTemplate)))
+
+	// Booking routes (protected)
+	router.POST("/bookings",
+		loggingMiddleware.Log(
+			authMiddleware.RequireAuth(
+				bookingMiddleware.ValidateCreate(controllersBooking.Create))))
+
+	router.GET("/bookings",
+		loggingMiddleware.Log(
+			authMiddleware.RequireAuth(controllersBooking.FindAll)))
+
+	router.GET("/bookings/:id",
+		loggingMiddleware.Log(
+			authMiddleware.RequireAuth(controllersBooking.FindById)))
+
+	router.PUT("/bookings/:id",
+		loggingMiddleware.Log(
+			authMiddleware.RequireAuth(
+				bookingMiddleware.ValidateUpdate(controllersBooking.Update))))
+
+	router.PUT("/bookings/:id/status",
+		loggingMiddleware.Log(
+			authMiddleware.RequireAuth(
+				bookingMiddleware.ValidateUpdateStatus(controllersBooking.UpdateStatus))))
+
+	router.DELETE("/bookings/:id",
+		loggingMiddleware.Log(
+			authMiddleware.RequireAuth(controllersBooking.Delete)))
+
+	router.GET("/bookings-availability",
+		loggingMiddleware.Log(
+			authMiddleware.RequireAuth(controllersBooking.Availability)))
+
+	router.GET("/buildings/:id/booking-calendar",
+		loggingMiddleware.Log(
+			authMiddleware.RequireAuth(controllersBooking.BuildingCalendar)))
+
+	router.GET("/sales-packages/:id/booking-calendar",
+		loggingMiddleware.Log(
+			authMiddleware.RequireAuth(controllersBooking.SalesPackageCalendar)))
+
+	// Share link routes (protected)
+	router.POST("/share-links",
+		loggingMiddleware.Log(
+			authMiddleware.RequireAuth(
+				shareLinkMiddleware.ValidateCreate(controllersShareLink.Create))))
+
+	router.GET("/share-links",
+		loggingMiddleware.Log(
+			authMiddleware.RequireAuth(controllersShareLink.FindAll)))
+
+	router.DELETE("/share-links/:id",
+		loggingMiddleware.Log(
+			authMiddleware.RequireAuth(controllersShareLink.Revoke)))
+
 	// Building restriction routes (protected)
 	router.POST("/building-restrictions",
 		loggingMiddleware.Log(
@@ -201,6 +377,10 @@ func NewRouter(
 		loggingMiddleware.Log(
 			authMiddleware.RequireAuth(controllersBuildingRestriction.Export)))
 
+	router.GET("/building-restrictions-import-template",
+		loggingMiddleware.Log(
+			authMiddleware.RequireAuth(controllersBuildingRestriction.ImportTemplate)))
+
 	// Saved polygon routes (protected)
 	router.POST("/saved-polygons",
 		loggingMiddleware.Log(
@@ -224,6 +404,44 @@ func NewRouter(
 		loggingMiddleware.Log(
 			authMiddleware.RequireAuth(controllersSavedPolygon.Delete)))
 
+	// Saved mapping view routes (protected)
+	router.POST("/saved-views",
+		loggingMiddleware.Log(
+			authMiddleware.RequireAuth(
+				savedViewMiddleware.ValidateCreate(controllersSavedView.Create))))
+
+	router.GET("/saved-views",
+		loggingMiddleware.Log(
+			authMiddleware.RequireAuth(controllersSavedView.FindAll)))
+
+	router.GET("/saved-views/:id",
+		loggingMiddleware.Log(
+			authMiddleware.RequireAuth(controllersSavedView.FindById)))
+
+	router.PUT("/saved-views/:id",
+		loggingMiddleware.Log(
+			authMiddleware.RequireAuth(
+				savedViewMiddleware.ValidateUpdate(controllersSavedView.Update))))
+
+	router.DELETE("/saved-views/:id",
+		loggingMiddleware.Log(
+			authMiddleware.RequireAuth(controllersSavedView.Delete)))
+
+	// Trash routes (protected)
+	router.GET("/trash",
+		loggingMiddleware.Log(
+			authMiddleware.RequireAuth(controllersTrash.FindAll)))
+
+	router.POST("/trash/:type/:id/restore",
+		loggingMiddleware.Log(
+			authMiddleware.RequireAuth(controllersTrash.Restore)))
+
+	// Master data merge routes (protected)
+	router.POST("/master-data/:type/:id/merge",
+		loggingMiddleware.Log(
+			authMiddleware.RequireAuth(
+				masterDataMiddleware.ValidateMerge(controllersMasterData.Merge))))
+
 	// Category routes (protected)
 	router.POST("/categories",
 		loggingMiddleware.Log(
@@ -238,6 +456,10 @@ func NewRouter(
 		loggingMiddleware.Log(
 			authMiddleware.RequireAuth(controllersCategory.FindAllDropdown)))
 
+	router.GET("/categories-tree",
+		loggingMiddleware.Log(
+			authMiddleware.RequireAuth(controllersCategory.FindTree)))
+
 	router.GET("/categories/:id",
 		loggingMiddleware.Log(
 			authMiddleware.RequireAuth(controllersCategory.FindById)))
@@ -259,6 +481,10 @@ func NewRouter(
 		loggingMiddleware.Log(
 			authMiddleware.RequireAuth(controllersCategory.Export)))
 
+	router.GET("/categories-import-template",
+		loggingMiddleware.Log(
+			authMiddleware.RequireAuth(controllersCategory.ImportTemplate)))
+
 	// Sub-Category routes (protected)
 	router.POST("/sub-categories",
 		loggingMiddleware.Log(
@@ -294,6 +520,10 @@ func NewRouter(
 		loggingMiddleware.Log(
 			authMiddleware.RequireAuth(controllersSubCategory.Export)))
 
+	router.GET("/sub-categories-import-template",
+		loggingMiddleware.Log(
+			authMiddleware.RequireAuth(controllersSubCategory.ImportTemplate)))
+
 	// Mother Brand routes (protected)
 	router.POST("/mother-brands",
 		loggingMiddleware.Log(
@@ -308,6 +538,10 @@ func NewRouter(
 		loggingMiddleware.Log(
 			authMiddleware.RequireAuth(controllersMotherBrand.FindAllDropdown)))
 
+	router.GET("/mother-brands-tree",
+		loggingMiddleware.Log(
+			authMiddleware.RequireAuth(controllersMotherBrand.FindTree)))
+
 	router.GET("/mother-brands/:id",
 		loggingMiddleware.Log(
 			authMiddleware.RequireAuth(controllersMotherBrand.FindById)))
@@ -329,6 +563,10 @@ func NewRouter(
 		loggingMiddleware.Log(
 			authMiddleware.RequireAuth(controllersMotherBrand.Export)))
 
+	router.GET("/mother-brands-import-template",
+		loggingMiddleware.Log(
+			authMiddleware.RequireAuth(controllersMotherBrand.ImportTemplate)))
+
 	// Branch routes (protected)
 	router.POST("/branches",
 		loggingMiddleware.Log(
@@ -364,6 +602,28 @@ func NewRouter(
 		loggingMiddleware.Log(
 			authMiddleware.RequireAuth(controllersBranch.Export)))
 
+	router.GET("/branches-import-template",
+		loggingMiddleware.Log(
+			authMiddleware.RequireAuth(controllersBranch.ImportTemplate)))
+
+	// Import job routes (protected); uploads are applied in the background by the import job worker
+	router.POST("/import-jobs",
+		loggingMiddleware.Log(
+			authMiddleware.RequireAuth(controllersImportJob.Create)))
+
+	router.GET("/import-jobs/:id",
+		loggingMiddleware.Log(
+			authMiddleware.RequireAuth(controllersImportJob.FindById)))
+
+	router.POST("/import-jobs/:id/cancel",
+		loggingMiddleware.Log(
+			authMiddleware.RequireAuth(controllersImportJob.Cancel)))
+
+	// Admin boundary routes (protected); boundaries are loaded via cmd/import-admin-boundaries
+	router.GET("/admin-boundaries",
+		loggingMiddleware.Log(
+			authMiddleware.RequireAuth(controllersAdminBoundary.FindAll)))
+
 	router.GET("/dashboard/building-lcd-presence",
 		loggingMiddleware.Log(
 			authMiddleware.RequireAuth(controllersBuilding.GetLCDPresenceSummary)))
@@ -0,0 +1,21 @@
+package libs
+
+import (
+	"os"
+
+	"github.com/malikabdulaziz/tmn-backend/helpers"
+	"github.com/malikabdulaziz/tmn-backend/services/sharelink"
+)
+
+// ProvideShareLinkConfig provides the share link signing secret from SHARE_LINK_SECRET, falling
+// back to APP_SECRET_KEY. Rotating the secret invalidates every share link handed out so far.
+func ProvideShareLinkConfig() sharelink.Config {
+	secret := os.Getenv("SHARE_LINK_SECRET")
+	if secret == "" {
+		secret = os.Getenv("APP_SECRET_KEY")
+	}
+	if secret == "" {
+		helpers.Logger.Warn("Neither SHARE_LINK_SECRET nor APP_SECRET_KEY is set, share link tokens are signed with an empty secret")
+	}
+	return sharelink.NewConfig(secret)
+}
@@ -0,0 +1,15 @@
+package libs
+
+import (
+	"os"
+	"strconv"
+
+	"github.com/malikabdulaziz/tmn-backend/services/trash"
+)
+
+// ProvideTrashConfig provides how many days deleted rows stay in the trash from
+// TRASH_RETENTION_DAYS, 30 when it is not set
+func ProvideTrashConfig() trash.Config {
+	days, _ := strconv.Atoi(os.Getenv("TRASH_RETENTION_DAYS"))
+	return trash.NewConfig(days)
+}
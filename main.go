@@ -11,7 +11,9 @@ import (
 	servicesAcquisition "github.com/malikabdulaziz/tmn-backend/services/acquisition"
 	servicesBuilding "github.com/malikabdulaziz/tmn-backend/services/building"
 	servicesBuildingProposal "github.com/malikabdulaziz/tmn-backend/services/buildingproposal"
+	servicesImportJob "github.com/malikabdulaziz/tmn-backend/services/importjob"
 	servicesLOI "github.com/malikabdulaziz/tmn-backend/services/loi"
+	servicesTrash "github.com/malikabdulaziz/tmn-backend/services/trash"
 )
 
 func main() {
@@ -42,6 +44,24 @@ func main() {
 		}
 	}
 
+	// Get import job worker poll interval
+	importJobPollStr := os.Getenv("IMPORT_JOB_POLL_SECONDS")
+	importJobPoll := 5
+	if importJobPollStr != "" {
+		if val, err := strconv.Atoi(importJobPollStr); err == nil {
+			importJobPoll = val
+		}
+	}
+
+	// Get trash purge interval
+	trashPurgeStr := os.Getenv("TRASH_PURGE_INTERVAL_MINUTES")
+	trashPurge := 60
+	if trashPurgeStr != "" {
+		if val, err := strconv.Atoi(trashPurgeStr); err == nil {
+			trashPurge = val
+		}
+	}
+
 	// Initialize router with all dependencies
 	router := injector.InitializeRouter()
 
@@ -59,6 +79,14 @@ func main() {
 	loiService := injector.InitializeLOIService()
 	servicesLOI.StartLOISyncScheduler(loiService, helpers.Logger, syncInterval)
 
+	// Initialize import job worker
+	importJobService := injector.InitializeImportJobService()
+	servicesImportJob.StartImportJobWorker(importJobService, helpers.Logger, importJobPoll)
+
+	// Initialize trash purge scheduler
+	trashService := injector.InitializeTrashService()
+	servicesTrash.StartTrashPurgeScheduler(trashService, helpers.Logger, trashPurge)
+
 	// Create HTTP server
 	server := http.Server{
 		Addr:    ":" + APP_PORT,
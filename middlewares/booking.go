@@ -0,0 +1,92 @@
+package middlewares
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+	"strconv"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/julienschmidt/httprouter"
+	"github.com/malikabdulaziz/tmn-backend/exceptions"
+	"github.com/malikabdulaziz/tmn-backend/helpers"
+	repositoriesBooking "github.com/malikabdulaziz/tmn-backend/repositories/booking"
+	webBooking "github.com/malikabdulaziz/tmn-backend/web/booking"
+)
+
+type BookingMiddleware struct {
+	*validator.Validate
+	DB *sql.DB
+	repositoriesBooking.RepositoryBookingInterface
+}
+
+func NewBookingMiddleware(
+	validate *validator.Validate,
+	db *sql.DB,
+	repoBooking repositoriesBooking.RepositoryBookingInterface,
+) *BookingMiddleware {
+	return &BookingMiddleware{
+		Validate:                   validate,
+		DB:                         db,
+		RepositoryBookingInterface: repoBooking,
+	}
+}
+
+// ValidateCreate validates create booking request
+func (m *BookingMiddleware) ValidateCreate(next httprouter.Handle) httprouter.Handle {
+	return func(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+		var req webBooking.CreateBookingRequest
+		helpers.DecodeRequest(r, &req)
+		if err := m.Validate.Struct(req); err != nil {
+			helpers.PanicIfError(err)
+		}
+		ctx := context.WithValue(r.Context(), helpers.ContextKey("createBookingRequest"), req)
+		next(w, r.WithContext(ctx), p)
+	}
+}
+
+// ValidateUpdate validates update booking request and verifies booking exists
+func (m *BookingMiddleware) ValidateUpdate(next httprouter.Handle) httprouter.Handle {
+	return func(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+		var req webBooking.UpdateBookingRequest
+		helpers.DecodeRequest(r, &req)
+		if err := m.Validate.Struct(req); err != nil {
+			helpers.PanicIfError(err)
+		}
+		id := m.findBookingId(r, p)
+		ctx := context.WithValue(r.Context(), helpers.ContextKey("updateBookingRequest"), req)
+		ctx = context.WithValue(ctx, helpers.ContextKey("bookingId"), id)
+		next(w, r.WithContext(ctx), p)
+	}
+}
+
+// ValidateUpdateStatus validates booking status change request and verifies booking exists
+func (m *BookingMiddleware) ValidateUpdateStatus(next httprouter.Handle) httprouter.Handle {
+	return func(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+		var req webBooking.UpdateBookingStatusRequest
+		helpers.DecodeRequest(r, &req)
+		if err := m.Validate.Struct(req); err != nil {
+			helpers.PanicIfError(err)
+		}
+		id := m.findBookingId(r, p)
+		ctx := context.WithValue(r.Context(), helpers.ContextKey("updateBookingStatusRequest"), req)
+		ctx = context.WithValue(ctx, helpers.ContextKey("bookingId"), id)
+		next(w, r.WithContext(ctx), p)
+	}
+}
+
+func (m *BookingMiddleware) findBookingId(r *http.Request, p httprouter.Params) int {
+	id, err := strconv.Atoi(p.ByName("id"))
+	if err != nil {
+		panic(exceptions.NewBadRequest("invalid booking id"))
+	}
+	tx, err := m.DB.Begin()
+	helpers.PanicIfError(err)
+	defer helpers.CommitOrRollback(tx)
+	_, err = m.RepositoryBookingInterface.FindById(r.Context(), tx, id)
+	if err == sql.ErrNoRows {
+		panic(exceptions.NewNotFoundError("booking not found"))
+	}
+	helpers.PanicIfError(err)
+	return id
+}
@@ -0,0 +1,32 @@
+package middlewares
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/julienschmidt/httprouter"
+	"github.com/malikabdulaziz/tmn-backend/helpers"
+	webMasterData "github.com/malikabdulaziz/tmn-backend/web/masterdata"
+)
+
+type MasterDataMiddleware struct {
+	*validator.Validate
+}
+
+func NewMasterDataMiddleware(validate *validator.Validate) *MasterDataMiddleware {
+	return &MasterDataMiddleware{Validate: validate}
+}
+
+// ValidateMerge validates merge master data request
+func (m *MasterDataMiddleware) ValidateMerge(next httprouter.Handle) httprouter.Handle {
+	return func(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+		var req webMasterData.MergeMasterDataRequest
+		helpers.DecodeRequest(r, &req)
+		if err := m.Validate.Struct(req); err != nil {
+			helpers.PanicIfError(err)
+		}
+		ctx := context.WithValue(r.Context(), helpers.ContextKey("mergeMasterDataRequest"), req)
+		next(w, r.WithContext(ctx), p)
+	}
+}
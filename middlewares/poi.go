@@ -12,6 +12,7 @@ import (
 	"github.com/malikabdulaziz/tmn-backend/helpers"
 	repositoriesPOI "github.com/malikabdulaziz/tmn-backend/repositories/poi"
 	webPOI "github.com/malikabdulaziz/tmn-backend/web/poi"
+	webPOIDuplicate "github.com/malikabdulaziz/tmn-backend/web/poiduplicate"
 )
 
 type POIMiddleware struct {
@@ -83,3 +84,48 @@ func (m *POIMiddleware) ValidateUpdate(next httprouter.Handle) httprouter.Handle
 		next(w, r, p)
 	}
 }
+
+// ValidateConfirmImport validates the confirm step of a previewed POI import
+func (m *POIMiddleware) ValidateConfirmImport(next httprouter.Handle) httprouter.Handle {
+	return func(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+		var req webPOI.ConfirmPOIImportRequest
+		helpers.DecodeRequest(r, &req)
+
+		err := m.Validate.Struct(req)
+		helpers.PanicIfError(err)
+
+		ctx := context.WithValue(r.Context(), helpers.ContextKey("confirmPOIImportRequest"), req)
+		r = r.WithContext(ctx)
+		next(w, r, p)
+	}
+}
+
+// ValidateMergeDuplicate validates a duplicate POI point merge
+func (m *POIMiddleware) ValidateMergeDuplicate(next httprouter.Handle) httprouter.Handle {
+	return func(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+		var req webPOIDuplicate.MergePOIDuplicateRequest
+		helpers.DecodeRequest(r, &req)
+
+		err := m.Validate.Struct(req)
+		helpers.PanicIfError(err)
+
+		ctx := context.WithValue(r.Context(), helpers.ContextKey("mergePOIDuplicateRequest"), req)
+		r = r.WithContext(ctx)
+		next(w, r, p)
+	}
+}
+
+// ValidateDismissDuplicate validates dismissing a duplicate POI point candidate
+func (m *POIMiddleware) ValidateDismissDuplicate(next httprouter.Handle) httprouter.Handle {
+	return func(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+		var req webPOIDuplicate.DismissPOIDuplicateRequest
+		helpers.DecodeRequest(r, &req)
+
+		err := m.Validate.Struct(req)
+		helpers.PanicIfError(err)
+
+		ctx := context.WithValue(r.Context(), helpers.ContextKey("dismissPOIDuplicateRequest"), req)
+		r = r.WithContext(ctx)
+		next(w, r, p)
+	}
+}
@@ -70,3 +70,42 @@ func (m *SalesPackageMiddleware) ValidateUpdate(next httprouter.Handle) httprout
 		next(w, r.WithContext(ctx), p)
 	}
 }
+
+// ValidateCreateFromFilter validates create sales package from mapping filter request
+func (m *SalesPackageMiddleware) ValidateCreateFromFilter(next httprouter.Handle) httprouter.Handle {
+	return func(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+		var req webSalesPackage.SalesPackageFromFilterRequest
+		helpers.DecodeRequest(r, &req)
+		if err := m.Validate.Struct(req); err != nil {
+			helpers.PanicIfError(err)
+		}
+		ctx := context.WithValue(r.Context(), helpers.ContextKey("salesPackageFromFilterRequest"), req)
+		next(w, r.WithContext(ctx), p)
+	}
+}
+
+// ValidateUpdateFromFilter validates update sales package from mapping filter request and verifies package exists
+func (m *SalesPackageMiddleware) ValidateUpdateFromFilter(next httprouter.Handle) httprouter.Handle {
+	return func(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+		var req webSalesPackage.SalesPackageFromFilterRequest
+		helpers.DecodeRequest(r, &req)
+		if err := m.Validate.Struct(req); err != nil {
+			helpers.PanicIfError(err)
+		}
+		id, err := strconv.Atoi(p.ByName("id"))
+		if err != nil {
+			panic(exceptions.NewBadRequest("invalid sales package id"))
+		}
+		tx, err := m.DB.Begin()
+		helpers.PanicIfError(err)
+		defer helpers.CommitOrRollback(tx)
+		_, err = m.RepositorySalesPackageInterface.FindById(r.Context(), tx, id)
+		if err == sql.ErrNoRows {
+			panic(exceptions.NewNotFoundError("sales package not found"))
+		}
+		helpers.PanicIfError(err)
+		ctx := context.WithValue(r.Context(), helpers.ContextKey("salesPackageFromFilterRequest"), req)
+		ctx = context.WithValue(ctx, helpers.ContextKey("salesPackageId"), id)
+		next(w, r.WithContext(ctx), p)
+	}
+}
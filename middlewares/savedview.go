@@ -0,0 +1,72 @@
+package middlewares
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+	"strconv"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/julienschmidt/httprouter"
+	"github.com/malikabdulaziz/tmn-backend/exceptions"
+	"github.com/malikabdulaziz/tmn-backend/helpers"
+	repositoriesSavedView "github.com/malikabdulaziz/tmn-backend/repositories/savedview"
+	webSavedView "github.com/malikabdulaziz/tmn-backend/web/savedview"
+)
+
+type SavedViewMiddleware struct {
+	*validator.Validate
+	DB *sql.DB
+	repositoriesSavedView.RepositorySavedViewInterface
+}
+
+func NewSavedViewMiddleware(
+	validate *validator.Validate,
+	db *sql.DB,
+	repoSavedView repositoriesSavedView.RepositorySavedViewInterface,
+) *SavedViewMiddleware {
+	return &SavedViewMiddleware{
+		Validate:                     validate,
+		DB:                           db,
+		RepositorySavedViewInterface: repoSavedView,
+	}
+}
+
+// ValidateCreate validates create saved view request
+func (m *SavedViewMiddleware) ValidateCreate(next httprouter.Handle) httprouter.Handle {
+	return func(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+		var req webSavedView.CreateSavedViewRequest
+		helpers.DecodeRequest(r, &req)
+		if err := m.Validate.Struct(req); err != nil {
+			helpers.PanicIfError(err)
+		}
+		ctx := context.WithValue(r.Context(), helpers.ContextKey("createSavedViewRequest"), req)
+		next(w, r.WithContext(ctx), p)
+	}
+}
+
+// ValidateUpdate validates update saved view request and verifies entity exists
+func (m *SavedViewMiddleware) ValidateUpdate(next httprouter.Handle) httprouter.Handle {
+	return func(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+		var req webSavedView.UpdateSavedViewRequest
+		helpers.DecodeRequest(r, &req)
+		if err := m.Validate.Struct(req); err != nil {
+			helpers.PanicIfError(err)
+		}
+		id, err := strconv.Atoi(p.ByName("id"))
+		if err != nil {
+			panic(exceptions.NewBadRequest("invalid saved view id"))
+		}
+		tx, err := m.DB.Begin()
+		helpers.PanicIfError(err)
+		defer helpers.CommitOrRollback(tx)
+		_, err = m.RepositorySavedViewInterface.FindById(r.Context(), tx, id)
+		if err == sql.ErrNoRows {
+			panic(exceptions.NewNotFoundError("saved view not found"))
+		}
+		helpers.PanicIfError(err)
+		ctx := context.WithValue(r.Context(), helpers.ContextKey("updateSavedViewRequest"), req)
+		ctx = context.WithValue(ctx, helpers.ContextKey("savedViewId"), id)
+		next(w, r.WithContext(ctx), p)
+	}
+}
@@ -0,0 +1,32 @@
+package middlewares
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/julienschmidt/httprouter"
+	"github.com/malikabdulaziz/tmn-backend/helpers"
+	webShareLink "github.com/malikabdulaziz/tmn-backend/web/sharelink"
+)
+
+type ShareLinkMiddleware struct {
+	*validator.Validate
+}
+
+func NewShareLinkMiddleware(validate *validator.Validate) *ShareLinkMiddleware {
+	return &ShareLinkMiddleware{Validate: validate}
+}
+
+// ValidateCreate validates create share link request
+func (m *ShareLinkMiddleware) ValidateCreate(next httprouter.Handle) httprouter.Handle {
+	return func(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+		var req webShareLink.CreateShareLinkRequest
+		helpers.DecodeRequest(r, &req)
+		if err := m.Validate.Struct(req); err != nil {
+			helpers.PanicIfError(err)
+		}
+		ctx := context.WithValue(r.Context(), helpers.ContextKey("createShareLinkRequest"), req)
+		next(w, r.WithContext(ctx), p)
+	}
+}
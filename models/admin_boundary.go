@@ -0,0 +1,46 @@
+package models
+
+import (
+	"database/sql"
+)
+
+// Administrative boundary levels, from coarsest to finest
+const (
+	AdminBoundaryLevelProvince    = "province"
+	AdminBoundaryLevelCity        = "city"
+	AdminBoundaryLevelSubdistrict = "subdistrict"
+)
+
+type AdminBoundary struct {
+	Id         int    `json:"id"`
+	Code       string `json:"code"`
+	Name       string `json:"name"`
+	Level      string `json:"level"`
+	ParentCode string `json:"parent_code"`
+	CreatedAt  string `json:"created_at"`
+	UpdatedAt  string `json:"updated_at"`
+}
+
+type NullAbleAdminBoundary struct {
+	Id         sql.NullInt64
+	Code       sql.NullString
+	Name       sql.NullString
+	Level      sql.NullString
+	ParentCode sql.NullString
+	CreatedAt  sql.NullString
+	UpdatedAt  sql.NullString
+}
+
+var AdminBoundaryTable string = "admin_boundaries"
+
+func NullAbleAdminBoundaryToAdminBoundary(nullable NullAbleAdminBoundary) AdminBoundary {
+	return AdminBoundary{
+		Id:         int(nullable.Id.Int64),
+		Code:       nullable.Code.String,
+		Name:       nullable.Name.String,
+		Level:      nullable.Level.String,
+		ParentCode: nullable.ParentCode.String,
+		CreatedAt:  nullable.CreatedAt.String,
+		UpdatedAt:  nullable.UpdatedAt.String,
+	}
+}
@@ -0,0 +1,106 @@
+package models
+
+import (
+	"database/sql"
+)
+
+// Booking statuses. Holds and confirmed bookings both occupy screens.
+const (
+	BookingStatusHold      = "hold"
+	BookingStatusConfirmed = "confirmed"
+	BookingStatusCancelled = "cancelled"
+)
+
+type Booking struct {
+	Id               int    `json:"id"`
+	ClientName       string `json:"client_name"`
+	SalesPackageId   *int   `json:"sales_package_id"`
+	SalesPackageName string `json:"sales_package_name"`
+	// SalesPackageVersionId is the package version the buildings were copied from
+	SalesPackageVersionId *int   `json:"sales_package_version_id"`
+	SalesPackageVersion   int    `json:"sales_package_version"`
+	StartDate             string `json:"start_date"`
+	EndDate               string `json:"end_date"`
+	Status                string `json:"status"`
+	Notes                 string `json:"notes"`
+	// Advertiser the booking is sold to, checked against the building restrictions
+	AdvertiserCategoryId      *int              `json:"advertiser_category_id"`
+	AdvertiserCategoryName    string            `json:"advertiser_category_name"`
+	AdvertiserMotherBrandId   *int              `json:"advertiser_mother_brand_id"`
+	AdvertiserMotherBrandName string            `json:"advertiser_mother_brand_name"`
+	CreatedBy                 *int              `json:"created_by"`
+	Buildings                 []BookingBuilding `json:"buildings"`
+	CreatedAt                 string            `json:"created_at"`
+	UpdatedAt                 string            `json:"updated_at"`
+}
+
+// BookingBuilding is a booking_buildings row with the building name joined in
+type BookingBuilding struct {
+	Id           int    `json:"id"`
+	BookingId    int    `json:"booking_id"`
+	BuildingId   int    `json:"building_id"`
+	BuildingName string `json:"building_name"`
+	Screens      int    `json:"screens"`
+}
+
+type NullAbleBooking struct {
+	Id                        sql.NullInt64
+	ClientName                sql.NullString
+	SalesPackageId            sql.NullInt64
+	SalesPackageName          sql.NullString
+	SalesPackageVersionId     sql.NullInt64
+	SalesPackageVersion       sql.NullInt64
+	StartDate                 sql.NullString
+	EndDate                   sql.NullString
+	Status                    sql.NullString
+	Notes                     sql.NullString
+	AdvertiserCategoryId      sql.NullInt64
+	AdvertiserCategoryName    sql.NullString
+	AdvertiserMotherBrandId   sql.NullInt64
+	AdvertiserMotherBrandName sql.NullString
+	CreatedBy                 sql.NullInt64
+	CreatedAt                 sql.NullString
+	UpdatedAt                 sql.NullString
+}
+
+var BookingTable string = "bookings"
+var BookingBuildingTable string = "booking_buildings"
+
+func NullAbleBookingToBooking(nullable NullAbleBooking) Booking {
+	b := Booking{
+		Id:                        int(nullable.Id.Int64),
+		ClientName:                nullable.ClientName.String,
+		SalesPackageName:          nullable.SalesPackageName.String,
+		SalesPackageVersion:       int(nullable.SalesPackageVersion.Int64),
+		StartDate:                 nullable.StartDate.String,
+		EndDate:                   nullable.EndDate.String,
+		Status:                    nullable.Status.String,
+		Notes:                     nullable.Notes.String,
+		AdvertiserCategoryName:    nullable.AdvertiserCategoryName.String,
+		AdvertiserMotherBrandName: nullable.AdvertiserMotherBrandName.String,
+		Buildings:                 []BookingBuilding{},
+		CreatedAt:                 nullable.CreatedAt.String,
+		UpdatedAt:                 nullable.UpdatedAt.String,
+	}
+	if nullable.SalesPackageId.Valid {
+		id := int(nullable.SalesPackageId.Int64)
+		b.SalesPackageId = &id
+	}
+	if nullable.SalesPackageVersionId.Valid {
+		id := int(nullable.SalesPackageVersionId.Int64)
+		b.SalesPackageVersionId = &id
+	}
+	if nullable.AdvertiserCategoryId.Valid {
+		id := int(nullable.AdvertiserCategoryId.Int64)
+		b.AdvertiserCategoryId = &id
+	}
+	if nullable.AdvertiserMotherBrandId.Valid {
+		id := int(nullable.AdvertiserMotherBrandId.Int64)
+		b.AdvertiserMotherBrandId = &id
+	}
+	if nullable.CreatedBy.Valid {
+		id := int(nullable.CreatedBy.Int64)
+		b.CreatedBy = &id
+	}
+	return b
+}
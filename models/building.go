@@ -29,6 +29,9 @@ type Building struct {
 	Subdistrict         string          `json:"subdistrict"`
 	Citytown            string          `json:"citytown"`
 	Province            string          `json:"province"`
+	ProvinceCode        string          `json:"province_code"`
+	CityCode            string          `json:"city_code"`
+	SubdistrictCode     string          `json:"subdistrict_code"`
 	GradeResource       string          `json:"grade_resource"`
 	BuildingType        string          `json:"building_type"`
 	CompletionYear      int             `json:"completion_year"`
@@ -60,6 +63,9 @@ type NullAbleBuilding struct {
 	Subdistrict         sql.NullString
 	Citytown            sql.NullString
 	Province            sql.NullString
+	ProvinceCode        sql.NullString
+	CityCode            sql.NullString
+	SubdistrictCode     sql.NullString
 	GradeResource       sql.NullString
 	BuildingType        sql.NullString
 	CompletionYear      sql.NullInt64
@@ -103,6 +109,9 @@ func NullAbleBuildingToBuilding(nullable NullAbleBuilding) Building {
 		Subdistrict:         nullable.Subdistrict.String,
 		Citytown:            nullable.Citytown.String,
 		Province:            nullable.Province.String,
+		ProvinceCode:        nullable.ProvinceCode.String,
+		CityCode:            nullable.CityCode.String,
+		SubdistrictCode:     nullable.SubdistrictCode.String,
 		GradeResource:       nullable.GradeResource.String,
 		BuildingType:        nullable.BuildingType.String,
 		CompletionYear:      int(nullable.CompletionYear.Int64),
@@ -4,12 +4,30 @@ import (
 	"database/sql"
 )
 
+// Building restriction enforcement levels: a warn restriction only flags a conflicting package
+// or booking, a block restriction rejects it
+const (
+	RestrictionEnforcementWarn  = "warn"
+	RestrictionEnforcementBlock = "block"
+)
+
+// BuildingRestriction lists buildings and, optionally, the categories and mother brands barred from
+// advertising in them between ValidFrom and ValidUntil (either end may be empty for open-ended)
 type BuildingRestriction struct {
-	Id        int           `json:"id"`
-	Name      string        `json:"name"`
-	Buildings []BuildingRef `json:"buildings"`
-	CreatedAt string        `json:"created_at"`
-	UpdatedAt string        `json:"updated_at"`
+	Id           int           `json:"id"`
+	Name         string        `json:"name"`
+	Enforcement  string        `json:"enforcement"`
+	ValidFrom    string        `json:"valid_from"`
+	ValidUntil   string        `json:"valid_until"`
+	Buildings    []BuildingRef `json:"buildings"`
+	Categories   []Category    `json:"categories"`
+	MotherBrands []MotherBrand `json:"mother_brands"`
+	// CreatedBy and UpdatedBy are user ids, nil for restrictions made before ownership was tracked
+	CreatedBy  *int   `json:"created_by"`
+	UpdatedBy  *int   `json:"updated_by"`
+	Visibility string `json:"visibility"`
+	CreatedAt  string `json:"created_at"`
+	UpdatedAt  string `json:"updated_at"`
 }
 
 // BuildingRestrictionBuilding is a junction row (building_restriction_buildings table)
@@ -20,10 +38,16 @@ type BuildingRestrictionBuilding struct {
 }
 
 type NullAbleBuildingRestriction struct {
-	Id        sql.NullInt64
-	Name      sql.NullString
-	CreatedAt sql.NullString
-	UpdatedAt sql.NullString
+	Id          sql.NullInt64
+	Name        sql.NullString
+	Enforcement sql.NullString
+	ValidFrom   sql.NullString
+	ValidUntil  sql.NullString
+	CreatedBy   sql.NullInt64
+	UpdatedBy   sql.NullInt64
+	Visibility  sql.NullString
+	CreatedAt   sql.NullString
+	UpdatedAt   sql.NullString
 }
 
 type NullAbleBuildingRestrictionBuilding struct {
@@ -34,14 +58,28 @@ type NullAbleBuildingRestrictionBuilding struct {
 
 var BuildingRestrictionTable string = "building_restrictions"
 var BuildingRestrictionBuildingTable string = "building_restriction_buildings"
+var BuildingRestrictionCategoryTable string = "building_restriction_categories"
+var BuildingRestrictionMotherBrandTable string = "building_restriction_mother_brands"
 
 func NullAbleBuildingRestrictionToBuildingRestriction(nullable NullAbleBuildingRestriction) BuildingRestriction {
+	enforcement := nullable.Enforcement.String
+	if enforcement == "" {
+		enforcement = RestrictionEnforcementWarn
+	}
 	return BuildingRestriction{
-		Id:        int(nullable.Id.Int64),
-		Name:      nullable.Name.String,
-		Buildings: []BuildingRef{},
-		CreatedAt: nullable.CreatedAt.String,
-		UpdatedAt: nullable.UpdatedAt.String,
+		Id:           int(nullable.Id.Int64),
+		Name:         nullable.Name.String,
+		Enforcement:  enforcement,
+		ValidFrom:    nullable.ValidFrom.String,
+		ValidUntil:   nullable.ValidUntil.String,
+		Buildings:    []BuildingRef{},
+		Categories:   []Category{},
+		MotherBrands: []MotherBrand{},
+		CreatedBy:    nullIntToPtr(nullable.CreatedBy),
+		UpdatedBy:    nullIntToPtr(nullable.UpdatedBy),
+		Visibility:   nullable.Visibility.String,
+		CreatedAt:    nullable.CreatedAt.String,
+		UpdatedAt:    nullable.UpdatedAt.String,
 	}
 }
 
@@ -0,0 +1,51 @@
+package models
+
+import (
+	"database/sql"
+)
+
+// Geocode cache kinds
+const (
+	GeocodeKindForward = "forward"
+	GeocodeKindReverse = "reverse"
+)
+
+type GeocodeCache struct {
+	Id        int     `json:"id"`
+	Provider  string  `json:"provider"`
+	Kind      string  `json:"kind"`
+	QueryKey  string  `json:"query_key"`
+	Found     bool    `json:"found"`
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+	Address   string  `json:"address"`
+	CreatedAt string  `json:"created_at"`
+}
+
+type NullAbleGeocodeCache struct {
+	Id        sql.NullInt64
+	Provider  sql.NullString
+	Kind      sql.NullString
+	QueryKey  sql.NullString
+	Found     sql.NullBool
+	Latitude  sql.NullFloat64
+	Longitude sql.NullFloat64
+	Address   sql.NullString
+	CreatedAt sql.NullString
+}
+
+var GeocodeCacheTable string = "geocode_cache"
+
+func NullAbleGeocodeCacheToGeocodeCache(nullable NullAbleGeocodeCache) GeocodeCache {
+	return GeocodeCache{
+		Id:        int(nullable.Id.Int64),
+		Provider:  nullable.Provider.String,
+		Kind:      nullable.Kind.String,
+		QueryKey:  nullable.QueryKey.String,
+		Found:     nullable.Found.Bool,
+		Latitude:  nullable.Latitude.Float64,
+		Longitude: nullable.Longitude.Float64,
+		Address:   nullable.Address.String,
+		CreatedAt: nullable.CreatedAt.String,
+	}
+}
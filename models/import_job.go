@@ -0,0 +1,93 @@
+package models
+
+import (
+	"database/sql"
+)
+
+// Import job kinds
+const (
+	ImportJobKindPOI                 = "poi"
+	ImportJobKindSalesPackage        = "sales_package"
+	ImportJobKindBuildingRestriction = "building_restriction"
+	ImportJobKindCategory            = "category"
+	ImportJobKindSubCategory         = "sub_category"
+	ImportJobKindMotherBrand         = "mother_brand"
+	ImportJobKindBranch              = "branch"
+)
+
+// Import job statuses
+const (
+	ImportJobStatusQueued    = "queued"
+	ImportJobStatusRunning   = "running"
+	ImportJobStatusSucceeded = "succeeded"
+	ImportJobStatusFailed    = "failed"
+	ImportJobStatusCancelled = "cancelled"
+)
+
+type ImportJob struct {
+	Id              int    `json:"id"`
+	Kind            string `json:"kind"`
+	FileType        string `json:"file_type"`
+	FileBytes       []byte `json:"-"`
+	Options         string `json:"options"`
+	Status          string `json:"status"`
+	ProgressDone    int    `json:"progress_done"`
+	ProgressTotal   int    `json:"progress_total"`
+	CancelRequested bool   `json:"cancel_requested"`
+	ImportedCount   int    `json:"imported_count"`
+	Report          string `json:"report"`
+	Error           string `json:"error"`
+	CreatedBy       *int   `json:"created_by"`
+	CreatedAt       string `json:"created_at"`
+	StartedAt       string `json:"started_at"`
+	FinishedAt      string `json:"finished_at"`
+	UpdatedAt       string `json:"updated_at"`
+}
+
+type NullAbleImportJob struct {
+	Id              sql.NullInt64
+	Kind            sql.NullString
+	FileType        sql.NullString
+	FileBytes       []byte
+	Options         sql.NullString
+	Status          sql.NullString
+	ProgressDone    sql.NullInt64
+	ProgressTotal   sql.NullInt64
+	CancelRequested sql.NullBool
+	ImportedCount   sql.NullInt64
+	Report          sql.NullString
+	Error           sql.NullString
+	CreatedBy       sql.NullInt64
+	CreatedAt       sql.NullString
+	StartedAt       sql.NullString
+	FinishedAt      sql.NullString
+	UpdatedAt       sql.NullString
+}
+
+var ImportJobTable string = "import_jobs"
+
+func NullAbleImportJobToImportJob(nullable NullAbleImportJob) ImportJob {
+	j := ImportJob{
+		Id:              int(nullable.Id.Int64),
+		Kind:            nullable.Kind.String,
+		FileType:        nullable.FileType.String,
+		FileBytes:       nullable.FileBytes,
+		Options:         nullable.Options.String,
+		Status:          nullable.Status.String,
+		ProgressDone:    int(nullable.ProgressDone.Int64),
+		ProgressTotal:   int(nullable.ProgressTotal.Int64),
+		CancelRequested: nullable.CancelRequested.Bool,
+		ImportedCount:   int(nullable.ImportedCount.Int64),
+		Report:          nullable.Report.String,
+		Error:           nullable.Error.String,
+		CreatedAt:       nullable.CreatedAt.String,
+		StartedAt:       nullable.StartedAt.String,
+		FinishedAt:      nullable.FinishedAt.String,
+		UpdatedAt:       nullable.UpdatedAt.String,
+	}
+	if nullable.CreatedBy.Valid {
+		id := int(nullable.CreatedBy.Int64)
+		j.CreatedBy = &id
+	}
+	return j
+}
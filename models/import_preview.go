@@ -0,0 +1,57 @@
+package models
+
+import (
+	"database/sql"
+)
+
+// Import preview kinds
+const (
+	ImportPreviewKindPOI = "poi"
+)
+
+type ImportPreview struct {
+	Id          int    `json:"id"`
+	Token       string `json:"token"`
+	Kind        string `json:"kind"`
+	FileType    string `json:"file_type"`
+	FileBytes   []byte `json:"-"`
+	Fingerprint string `json:"fingerprint"`
+	Options     string `json:"options"`
+	CreatedBy   *int   `json:"created_by"`
+	ExpiresAt   string `json:"expires_at"`
+	CreatedAt   string `json:"created_at"`
+}
+
+type NullAbleImportPreview struct {
+	Id          sql.NullInt64
+	Token       sql.NullString
+	Kind        sql.NullString
+	FileType    sql.NullString
+	FileBytes   []byte
+	Fingerprint sql.NullString
+	Options     sql.NullString
+	CreatedBy   sql.NullInt64
+	ExpiresAt   sql.NullString
+	CreatedAt   sql.NullString
+}
+
+var ImportPreviewTable string = "import_previews"
+
+func NullAbleImportPreviewToImportPreview(nullable NullAbleImportPreview) ImportPreview {
+	p := ImportPreview{
+		Id:          int(nullable.Id.Int64),
+		Token:       nullable.Token.String,
+		Kind:        nullable.Kind.String,
+		FileType:    nullable.FileType.String,
+		FileBytes:   nullable.FileBytes,
+		Fingerprint: nullable.Fingerprint.String,
+		Options:     nullable.Options.String,
+		ExpiresAt:   nullable.ExpiresAt.String,
+		CreatedAt:   nullable.CreatedAt.String,
+	}
+	if nullable.CreatedBy.Valid {
+		id := int(nullable.CreatedBy.Int64)
+		p.CreatedBy = &id
+	}
+	return p
+}
@@ -31,28 +31,35 @@ func NullAbleCategoryToCategory(n NullAbleCategory) Category {
 
 // --- SubCategory ---
 
+// SubCategory optionally belongs to a category; CategoryName is read along with it
 type SubCategory struct {
-	Id        int    `json:"id"`
-	Name      string `json:"name"`
-	CreatedAt string `json:"created_at"`
-	UpdatedAt string `json:"updated_at"`
+	Id           int    `json:"id"`
+	Name         string `json:"name"`
+	CategoryId   *int   `json:"category_id"`
+	CategoryName string `json:"category_name"`
+	CreatedAt    string `json:"created_at"`
+	UpdatedAt    string `json:"updated_at"`
 }
 
 type NullAbleSubCategory struct {
-	Id        sql.NullInt64
-	Name      sql.NullString
-	CreatedAt sql.NullString
-	UpdatedAt sql.NullString
+	Id           sql.NullInt64
+	Name         sql.NullString
+	CategoryId   sql.NullInt64
+	CategoryName sql.NullString
+	CreatedAt    sql.NullString
+	UpdatedAt    sql.NullString
 }
 
 var SubCategoryTable string = "sub_categories"
 
 func NullAbleSubCategoryToSubCategory(n NullAbleSubCategory) SubCategory {
 	return SubCategory{
-		Id:        int(n.Id.Int64),
-		Name:      n.Name.String,
-		CreatedAt: n.CreatedAt.String,
-		UpdatedAt: n.UpdatedAt.String,
+		Id:           int(n.Id.Int64),
+		Name:         n.Name.String,
+		CategoryId:   nullIntToPtr(n.CategoryId),
+		CategoryName: n.CategoryName.String,
+		CreatedAt:    n.CreatedAt.String,
+		UpdatedAt:    n.UpdatedAt.String,
 	}
 }
 
@@ -85,27 +92,34 @@ func NullAbleMotherBrandToMotherBrand(n NullAbleMotherBrand) MotherBrand {
 
 // --- Branch ---
 
+// Branch optionally belongs to a mother brand; MotherBrandName is read along with it
 type Branch struct {
-	Id        int    `json:"id"`
-	Name      string `json:"name"`
-	CreatedAt string `json:"created_at"`
-	UpdatedAt string `json:"updated_at"`
+	Id              int    `json:"id"`
+	Name            string `json:"name"`
+	MotherBrandId   *int   `json:"mother_brand_id"`
+	MotherBrandName string `json:"mother_brand_name"`
+	CreatedAt       string `json:"created_at"`
+	UpdatedAt       string `json:"updated_at"`
 }
 
 type NullAbleBranch struct {
-	Id        sql.NullInt64
-	Name      sql.NullString
-	CreatedAt sql.NullString
-	UpdatedAt sql.NullString
+	Id              sql.NullInt64
+	Name            sql.NullString
+	MotherBrandId   sql.NullInt64
+	MotherBrandName sql.NullString
+	CreatedAt       sql.NullString
+	UpdatedAt       sql.NullString
 }
 
 var BranchTable string = "branches"
 
 func NullAbleBranchToBranch(n NullAbleBranch) Branch {
 	return Branch{
-		Id:        int(n.Id.Int64),
-		Name:      n.Name.String,
-		CreatedAt: n.CreatedAt.String,
-		UpdatedAt: n.UpdatedAt.String,
+		Id:              int(n.Id.Int64),
+		Name:            n.Name.String,
+		MotherBrandId:   nullIntToPtr(n.MotherBrandId),
+		MotherBrandName: n.MotherBrandName.String,
+		CreatedAt:       n.CreatedAt.String,
+		UpdatedAt:       n.UpdatedAt.String,
 	}
 }
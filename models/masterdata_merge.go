@@ -0,0 +1,102 @@
+package models
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// Types of master data entries that can be merged
+const (
+	MasterDataTypeCategory    = "category"
+	MasterDataTypeSubCategory = "sub_category"
+	MasterDataTypeMotherBrand = "mother_brand"
+	MasterDataTypeBranch      = "branch"
+)
+
+var MasterDataAliasTable string = "master_data_aliases"
+
+// MergeReference is a column that points at a master data entry. When Key is set, (Key, Column)
+// is unique, so a row that would end up next to one already pointing at the target is dropped
+// instead of repointed.
+type MergeReference struct {
+	Table  string
+	Column string
+	Key    string
+}
+
+// MergeSource describes the table behind one master data type, the column holding its parent
+// (empty for types without one) and every column that points at its entries
+type MergeSource struct {
+	Type         string
+	Label        string
+	Table        string
+	ParentColumn string
+	ParentTable  string
+	ParentLabel  string
+	References   []MergeReference
+}
+
+// MergeSources lists every master data type that can be merged
+var MergeSources = []MergeSource{
+	{Type: MasterDataTypeCategory, Label: "category", Table: CategoryTable, References: []MergeReference{
+		{Table: POITable, Column: "category_id"},
+		{Table: SubCategoryTable, Column: "category_id"},
+		{Table: BookingTable, Column: "advertiser_category_id"},
+		{Table: BuildingRestrictionCategoryTable, Column: "category_id", Key: "building_restriction_id"},
+	}},
+	{Type: MasterDataTypeSubCategory, Label: "sub-category", Table: SubCategoryTable,
+		ParentColumn: "category_id", ParentTable: CategoryTable, ParentLabel: "category", References: []MergeReference{
+			{Table: POITable, Column: "sub_category_id"},
+		}},
+	{Type: MasterDataTypeMotherBrand, Label: "mother brand", Table: MotherBrandTable, References: []MergeReference{
+		{Table: POITable, Column: "mother_brand_id"},
+		{Table: BranchTable, Column: "mother_brand_id"},
+		{Table: BookingTable, Column: "advertiser_mother_brand_id"},
+		{Table: BuildingRestrictionMotherBrandTable, Column: "mother_brand_id", Key: "building_restriction_id"},
+	}},
+	{Type: MasterDataTypeBranch, Label: "branch", Table: BranchTable,
+		ParentColumn: "mother_brand_id", ParentTable: MotherBrandTable, ParentLabel: "mother brand", References: []MergeReference{
+			{Table: POIPointTable, Column: "branch_id"},
+		}},
+}
+
+// FindMergeSource returns the source of a master data type; false when the type is unknown
+func FindMergeSource(masterDataType string) (MergeSource, bool) {
+	for _, source := range MergeSources {
+		if source.Type == masterDataType {
+			return source, true
+		}
+	}
+	return MergeSource{}, false
+}
+
+// AliasCondition returns a SQL condition that holds when column is the id of the masterDataType
+// entry that the name bound to param was merged into
+func AliasCondition(masterDataType string, column string, param string) string {
+	return fmt.Sprintf(`%s IN (SELECT target_id FROM %s WHERE type = '%s' AND LOWER(alias) = LOWER(%s))`,
+		column, MasterDataAliasTable, masterDataType, param)
+}
+
+// MasterDataEntry is a live master data entry with its parent, if its type has one
+type MasterDataEntry struct {
+	Id         int    `json:"id"`
+	Name       string `json:"name"`
+	ParentId   *int   `json:"parent_id"`
+	ParentName string `json:"parent_name"`
+}
+
+type NullAbleMasterDataEntry struct {
+	Id         sql.NullInt64
+	Name       sql.NullString
+	ParentId   sql.NullInt64
+	ParentName sql.NullString
+}
+
+func NullAbleMasterDataEntryToMasterDataEntry(nullable NullAbleMasterDataEntry) MasterDataEntry {
+	return MasterDataEntry{
+		Id:         int(nullable.Id.Int64),
+		Name:       nullable.Name.String,
+		ParentId:   nullIntToPtr(nullable.ParentId),
+		ParentName: nullable.ParentName.String,
+	}
+}
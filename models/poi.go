@@ -20,16 +20,17 @@ type POI struct {
 }
 
 type POIPoint struct {
-	Id         int     `json:"id"`
-	POIId      int     `json:"poi_id"`
-	POIName    string  `json:"poi_name"`
-	Address    string  `json:"address"`
-	Latitude   float64 `json:"latitude"`
-	Longitude  float64 `json:"longitude"`
-	BranchId   *int    `json:"branch_id"`
-	BranchName string  `json:"branch_name"`
-	CreatedAt  string  `json:"created_at"`
-	UpdatedAt  string  `json:"updated_at"`
+	Id          int     `json:"id"`
+	POIId       int     `json:"poi_id"`
+	POIName     string  `json:"poi_name"`
+	Address     string  `json:"address"`
+	Latitude    float64 `json:"latitude"`
+	Longitude   float64 `json:"longitude"`
+	ExternalKey string  `json:"external_key"`
+	BranchId    *int    `json:"branch_id"`
+	BranchName  string  `json:"branch_name"`
+	CreatedAt   string  `json:"created_at"`
+	UpdatedAt   string  `json:"updated_at"`
 }
 
 type NullAblePOI struct {
@@ -47,16 +48,17 @@ type NullAblePOI struct {
 }
 
 type NullAblePOIPoint struct {
-	Id         sql.NullInt64
-	POIId      sql.NullInt64
-	POIName    sql.NullString
-	Address    sql.NullString
-	Latitude   sql.NullFloat64
-	Longitude  sql.NullFloat64
-	BranchId   sql.NullInt64
-	BranchName sql.NullString
-	CreatedAt  sql.NullString
-	UpdatedAt  sql.NullString
+	Id          sql.NullInt64
+	POIId       sql.NullInt64
+	POIName     sql.NullString
+	Address     sql.NullString
+	Latitude    sql.NullFloat64
+	Longitude   sql.NullFloat64
+	ExternalKey sql.NullString
+	BranchId    sql.NullInt64
+	BranchName  sql.NullString
+	CreatedAt   sql.NullString
+	UpdatedAt   sql.NullString
 }
 
 var POITable string = "pois"
@@ -91,15 +93,16 @@ func NullAblePOIToPOI(nullable NullAblePOI) POI {
 
 func NullAblePOIPointToPOIPoint(nullable NullAblePOIPoint) POIPoint {
 	p := POIPoint{
-		Id:         int(nullable.Id.Int64),
-		POIId:      int(nullable.POIId.Int64),
-		POIName:    nullable.POIName.String,
-		Address:    nullable.Address.String,
-		Latitude:   nullable.Latitude.Float64,
-		Longitude:  nullable.Longitude.Float64,
-		BranchName: nullable.BranchName.String,
-		CreatedAt:  nullable.CreatedAt.String,
-		UpdatedAt:  nullable.UpdatedAt.String,
+		Id:          int(nullable.Id.Int64),
+		POIId:       int(nullable.POIId.Int64),
+		POIName:     nullable.POIName.String,
+		Address:     nullable.Address.String,
+		Latitude:    nullable.Latitude.Float64,
+		Longitude:   nullable.Longitude.Float64,
+		ExternalKey: nullable.ExternalKey.String,
+		BranchName:  nullable.BranchName.String,
+		CreatedAt:   nullable.CreatedAt.String,
+		UpdatedAt:   nullable.UpdatedAt.String,
 	}
 	if nullable.BranchId.Valid {
 		id := int(nullable.BranchId.Int64)
@@ -0,0 +1,14 @@
+package models
+
+// POIPointDuplicate is a pair of POI points that may be the same outlet. Brand and OtherBrand
+// are the brands of the POIs owning Point and OtherPoint.
+type POIPointDuplicate struct {
+	Point          POIPoint `json:"point"`
+	Brand          string   `json:"brand"`
+	OtherPoint     POIPoint `json:"other_point"`
+	OtherBrand     string   `json:"other_brand"`
+	DistanceMeters float64  `json:"distance_meters"`
+	NameSimilarity float64  `json:"name_similarity"`
+}
+
+var POIPointDuplicateDismissalTable string = "poi_point_duplicate_dismissals"
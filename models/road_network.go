@@ -0,0 +1,19 @@
+package models
+
+// Road network tables as created by osm2pgrouting from an OSM extract
+var RoadNetworkEdgeTable string = "ways"
+var RoadNetworkVertexTable string = "ways_vertices_pgr"
+
+// Catchment modes for the mapping POI / lat-lng filter
+const (
+	CatchmentModeRadius = "radius" // straight-line ST_DWithin circle (default)
+	CatchmentModeDrive  = "drive"  // drive-time isochrone over the road network
+	CatchmentModeWalk   = "walk"   // walking isochrone over the road network
+)
+
+// WalkingSpeedMetersPerSecond is the assumed pedestrian speed (~5 km/h) for walking isochrones
+const WalkingSpeedMetersPerSecond = 1.4
+
+// MaxDrivingSpeedMetersPerSecond (~120 km/h) bounds how far a drive isochrone can reach, which
+// limits the road network searched around each origin
+const MaxDrivingSpeedMetersPerSecond = 33.4
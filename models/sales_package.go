@@ -8,8 +8,15 @@ type SalesPackage struct {
 	Id        int           `json:"id"`
 	Name      string        `json:"name"`
 	Buildings []BuildingRef `json:"buildings"`
-	CreatedAt string        `json:"created_at"`
-	UpdatedAt string        `json:"updated_at"`
+	// Filter is the stored mapping filter as JSON, empty for hand-picked packages
+	Filter            string `json:"filter"`
+	FilterRefreshedAt string `json:"filter_refreshed_at"`
+	// CreatedBy and UpdatedBy are user ids, nil for packages made before ownership was tracked
+	CreatedBy  *int   `json:"created_by"`
+	UpdatedBy  *int   `json:"updated_by"`
+	Visibility string `json:"visibility"`
+	CreatedAt  string `json:"created_at"`
+	UpdatedAt  string `json:"updated_at"`
 }
 
 // BuildingRef holds a lightweight subset of building fields used in relation responses
@@ -33,10 +40,15 @@ type SalesPackageBuilding struct {
 }
 
 type NullAbleSalesPackage struct {
-	Id        sql.NullInt64
-	Name      sql.NullString
-	CreatedAt sql.NullString
-	UpdatedAt sql.NullString
+	Id                sql.NullInt64
+	Name              sql.NullString
+	Filter            sql.NullString
+	FilterRefreshedAt sql.NullString
+	CreatedBy         sql.NullInt64
+	UpdatedBy         sql.NullInt64
+	Visibility        sql.NullString
+	CreatedAt         sql.NullString
+	UpdatedAt         sql.NullString
 }
 
 type NullAbleSalesPackageBuilding struct {
@@ -50,11 +62,16 @@ var SalesPackageBuildingTable string = "sales_package_buildings"
 
 func NullAbleSalesPackageToSalesPackage(nullable NullAbleSalesPackage) SalesPackage {
 	return SalesPackage{
-		Id:        int(nullable.Id.Int64),
-		Name:      nullable.Name.String,
-		Buildings: []BuildingRef{},
-		CreatedAt: nullable.CreatedAt.String,
-		UpdatedAt: nullable.UpdatedAt.String,
+		Id:                int(nullable.Id.Int64),
+		Name:              nullable.Name.String,
+		Buildings:         []BuildingRef{},
+		Filter:            nullable.Filter.String,
+		FilterRefreshedAt: nullable.FilterRefreshedAt.String,
+		CreatedBy:         nullIntToPtr(nullable.CreatedBy),
+		UpdatedBy:         nullIntToPtr(nullable.UpdatedBy),
+		Visibility:        nullable.Visibility.String,
+		CreatedAt:         nullable.CreatedAt.String,
+		UpdatedAt:         nullable.UpdatedAt.String,
 	}
 }
 
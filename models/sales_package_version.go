@@ -0,0 +1,75 @@
+package models
+
+import (
+	"database/sql"
+)
+
+// SalesPackageVersion is an immutable snapshot of a sales package, taken every time the package
+// changes. Totals and building figures are the ones computed when the version was taken.
+type SalesPackageVersion struct {
+	Id              int                           `json:"id"`
+	SalesPackageId  int                           `json:"sales_package_id"`
+	Version         int                           `json:"version"`
+	Name            string                        `json:"name"`
+	Filter          string                        `json:"filter"`
+	Note            string                        `json:"note"`
+	BuildingCount   int                           `json:"building_count"`
+	TotalAudience   int                           `json:"total_audience"`
+	TotalImpression int                           `json:"total_impression"`
+	TotalScreens    int                           `json:"total_screens"`
+	CreatedBy       *int                          `json:"created_by"`
+	Buildings       []SalesPackageVersionBuilding `json:"buildings"`
+	CreatedAt       string                        `json:"created_at"`
+}
+
+// SalesPackageVersionBuilding is a building of a version. BuildingId is nil once the building
+// has been deleted; BuildingName is the name it had when the version was taken.
+type SalesPackageVersionBuilding struct {
+	BuildingId   *int   `json:"building_id"`
+	BuildingName string `json:"building_name"`
+	Citytown     string `json:"citytown"`
+	BuildingType string `json:"building_type"`
+	Audience     int    `json:"audience"`
+	Impression   int    `json:"impression"`
+	Screens      int    `json:"screens"`
+}
+
+type NullAbleSalesPackageVersion struct {
+	Id              sql.NullInt64
+	SalesPackageId  sql.NullInt64
+	Version         sql.NullInt64
+	Name            sql.NullString
+	Filter          sql.NullString
+	Note            sql.NullString
+	BuildingCount   sql.NullInt64
+	TotalAudience   sql.NullInt64
+	TotalImpression sql.NullInt64
+	TotalScreens    sql.NullInt64
+	CreatedBy       sql.NullInt64
+	CreatedAt       sql.NullString
+}
+
+var SalesPackageVersionTable string = "sales_package_versions"
+var SalesPackageVersionBuildingTable string = "sales_package_version_buildings"
+
+func NullAbleSalesPackageVersionToSalesPackageVersion(nullable NullAbleSalesPackageVersion) SalesPackageVersion {
+	v := SalesPackageVersion{
+		Id:              int(nullable.Id.Int64),
+		SalesPackageId:  int(nullable.SalesPackageId.Int64),
+		Version:         int(nullable.Version.Int64),
+		Name:            nullable.Name.String,
+		Filter:          nullable.Filter.String,
+		Note:            nullable.Note.String,
+		BuildingCount:   int(nullable.BuildingCount.Int64),
+		TotalAudience:   int(nullable.TotalAudience.Int64),
+		TotalImpression: int(nullable.TotalImpression.Int64),
+		TotalScreens:    int(nullable.TotalScreens.Int64),
+		Buildings:       []SalesPackageVersionBuilding{},
+		CreatedAt:       nullable.CreatedAt.String,
+	}
+	if nullable.CreatedBy.Valid {
+		id := int(nullable.CreatedBy.Int64)
+		v.CreatedBy = &id
+	}
+	return v
+}
@@ -5,11 +5,15 @@ import (
 )
 
 type SavedPolygon struct {
-	Id        int                 `json:"id"`
-	Name      string              `json:"name"`
-	Points    []SavedPolygonPoint `json:"points"`
-	CreatedAt string              `json:"created_at"`
-	UpdatedAt string             `json:"updated_at"`
+	Id     int                 `json:"id"`
+	Name   string              `json:"name"`
+	Points []SavedPolygonPoint `json:"points"`
+	// CreatedBy and UpdatedBy are user ids, nil for polygons saved before ownership was tracked
+	CreatedBy  *int   `json:"created_by"`
+	UpdatedBy  *int   `json:"updated_by"`
+	Visibility string `json:"visibility"`
+	CreatedAt  string `json:"created_at"`
+	UpdatedAt  string `json:"updated_at"`
 }
 
 type SavedPolygonPoint struct {
@@ -22,10 +26,13 @@ type SavedPolygonPoint struct {
 }
 
 type NullAbleSavedPolygon struct {
-	Id        sql.NullInt64
-	Name      sql.NullString
-	CreatedAt sql.NullString
-	UpdatedAt sql.NullString
+	Id         sql.NullInt64
+	Name       sql.NullString
+	CreatedBy  sql.NullInt64
+	UpdatedBy  sql.NullInt64
+	Visibility sql.NullString
+	CreatedAt  sql.NullString
+	UpdatedAt  sql.NullString
 }
 
 type NullAbleSavedPolygonPoint struct {
@@ -42,11 +49,14 @@ var SavedPolygonPointTable string = "saved_polygon_points"
 
 func NullAbleSavedPolygonToSavedPolygon(nullable NullAbleSavedPolygon) SavedPolygon {
 	return SavedPolygon{
-		Id:        int(nullable.Id.Int64),
-		Name:      nullable.Name.String,
-		Points:    []SavedPolygonPoint{},
-		CreatedAt: nullable.CreatedAt.String,
-		UpdatedAt: nullable.UpdatedAt.String,
+		Id:         int(nullable.Id.Int64),
+		Name:       nullable.Name.String,
+		Points:     []SavedPolygonPoint{},
+		CreatedBy:  nullIntToPtr(nullable.CreatedBy),
+		UpdatedBy:  nullIntToPtr(nullable.UpdatedBy),
+		Visibility: nullable.Visibility.String,
+		CreatedAt:  nullable.CreatedAt.String,
+		UpdatedAt:  nullable.UpdatedAt.String,
 	}
 }
 
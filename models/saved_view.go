@@ -0,0 +1,62 @@
+package models
+
+import (
+	"database/sql"
+)
+
+// SavedView is a named snapshot of the mapping filter state. Filters holds the
+// ExportMappingFilters JSON; the map center and zoom are optional.
+type SavedView struct {
+	Id            int      `json:"id"`
+	Name          string   `json:"name"`
+	Filters       string   `json:"filters"`
+	MapCenterLat  *float64 `json:"map_center_lat"`
+	MapCenterLng  *float64 `json:"map_center_lng"`
+	Zoom          *float64 `json:"zoom"`
+	SortBy        string   `json:"sort_by"`
+	Visibility    string   `json:"visibility"`
+	CreatedBy     int      `json:"created_by"`
+	CreatedByName string   `json:"created_by_name"`
+	CreatedAt     string   `json:"created_at"`
+	UpdatedAt     string   `json:"updated_at"`
+}
+
+type NullAbleSavedView struct {
+	Id            sql.NullInt64
+	Name          sql.NullString
+	Filters       sql.NullString
+	MapCenterLat  sql.NullFloat64
+	MapCenterLng  sql.NullFloat64
+	Zoom          sql.NullFloat64
+	SortBy        sql.NullString
+	Visibility    sql.NullString
+	CreatedBy     sql.NullInt64
+	CreatedByName sql.NullString
+	CreatedAt     sql.NullString
+	UpdatedAt     sql.NullString
+}
+
+var SavedViewTable string = "saved_views"
+
+func NullAbleSavedViewToSavedView(nullable NullAbleSavedView) SavedView {
+	v := SavedView{
+		Id:            int(nullable.Id.Int64),
+		Name:          nullable.Name.String,
+		Filters:       nullable.Filters.String,
+		SortBy:        nullable.SortBy.String,
+		Visibility:    nullable.Visibility.String,
+		CreatedBy:     int(nullable.CreatedBy.Int64),
+		CreatedByName: nullable.CreatedByName.String,
+		CreatedAt:     nullable.CreatedAt.String,
+		UpdatedAt:     nullable.UpdatedAt.String,
+	}
+	if nullable.MapCenterLat.Valid && nullable.MapCenterLng.Valid {
+		lat, lng := nullable.MapCenterLat.Float64, nullable.MapCenterLng.Float64
+		v.MapCenterLat, v.MapCenterLng = &lat, &lng
+	}
+	if nullable.Zoom.Valid {
+		zoom := nullable.Zoom.Float64
+		v.Zoom = &zoom
+	}
+	return v
+}
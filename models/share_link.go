@@ -0,0 +1,91 @@
+package models
+
+import (
+	"database/sql"
+)
+
+// Share link target types
+const (
+	ShareLinkTargetSalesPackage  = "sales_package"
+	ShareLinkTargetMappingFilter = "mapping_filter"
+	ShareLinkTargetSavedPolygon  = "saved_polygon"
+)
+
+// ShareLink is a read-only link that can be opened without logging in until it expires or is
+// revoked. Sales package links are pinned to the version current when the link was created.
+type ShareLink struct {
+	Id                    int    `json:"id"`
+	Key                   string `json:"key"`
+	TargetType            string `json:"target_type"`
+	SalesPackageId        *int   `json:"sales_package_id"`
+	SalesPackageVersionId *int   `json:"sales_package_version_id"`
+	SalesPackageVersion   int    `json:"sales_package_version"`
+	SavedPolygonId        *int   `json:"saved_polygon_id"`
+	Filter                string `json:"filter"`
+	Title                 string `json:"title"`
+	PasswordHash          string `json:"-"`
+	ExpiresAt             string `json:"expires_at"`
+	Expired               bool   `json:"expired"`
+	RevokedAt             string `json:"revoked_at"`
+	ViewCount             int    `json:"view_count"`
+	LastViewedAt          string `json:"last_viewed_at"`
+	CreatedBy             *int   `json:"created_by"`
+	CreatedAt             string `json:"created_at"`
+}
+
+type NullAbleShareLink struct {
+	Id                    sql.NullInt64
+	Key                   sql.NullString
+	TargetType            sql.NullString
+	SalesPackageId        sql.NullInt64
+	SalesPackageVersionId sql.NullInt64
+	SalesPackageVersion   sql.NullInt64
+	SavedPolygonId        sql.NullInt64
+	Filter                sql.NullString
+	Title                 sql.NullString
+	PasswordHash          sql.NullString
+	ExpiresAt             sql.NullString
+	Expired               sql.NullBool
+	RevokedAt             sql.NullString
+	ViewCount             sql.NullInt64
+	LastViewedAt          sql.NullString
+	CreatedBy             sql.NullInt64
+	CreatedAt             sql.NullString
+}
+
+var ShareLinkTable string = "share_links"
+
+func NullAbleShareLinkToShareLink(nullable NullAbleShareLink) ShareLink {
+	l := ShareLink{
+		Id:                  int(nullable.Id.Int64),
+		Key:                 nullable.Key.String,
+		TargetType:          nullable.TargetType.String,
+		SalesPackageVersion: int(nullable.SalesPackageVersion.Int64),
+		Filter:              nullable.Filter.String,
+		Title:               nullable.Title.String,
+		PasswordHash:        nullable.PasswordHash.String,
+		ExpiresAt:           nullable.ExpiresAt.String,
+		Expired:             nullable.Expired.Bool,
+		RevokedAt:           nullable.RevokedAt.String,
+		ViewCount:           int(nullable.ViewCount.Int64),
+		LastViewedAt:        nullable.LastViewedAt.String,
+		CreatedAt:           nullable.CreatedAt.String,
+	}
+	if nullable.SalesPackageId.Valid {
+		id := int(nullable.SalesPackageId.Int64)
+		l.SalesPackageId = &id
+	}
+	if nullable.SalesPackageVersionId.Valid {
+		id := int(nullable.SalesPackageVersionId.Int64)
+		l.SalesPackageVersionId = &id
+	}
+	if nullable.SavedPolygonId.Valid {
+		id := int(nullable.SavedPolygonId.Int64)
+		l.SavedPolygonId = &id
+	}
+	if nullable.CreatedBy.Valid {
+		id := int(nullable.CreatedBy.Int64)
+		l.CreatedBy = &id
+	}
+	return l
+}
@@ -0,0 +1,87 @@
+package models
+
+import (
+	"database/sql"
+)
+
+// Types of rows that can be moved to the trash
+const (
+	TrashTypePOI                 = "poi"
+	TrashTypeSalesPackage        = "sales_package"
+	TrashTypeBuildingRestriction = "building_restriction"
+	TrashTypeSavedPolygon        = "saved_polygon"
+	TrashTypeCategory            = "category"
+	TrashTypeSubCategory         = "sub_category"
+	TrashTypeMotherBrand         = "mother_brand"
+	TrashTypeBranch              = "branch"
+)
+
+// TrashSource describes the table behind one trash type. Owned tables follow the visibility
+// rules of their rows; UniqueName tables only allow one live row per name.
+type TrashSource struct {
+	Type       string
+	Label      string
+	Table      string
+	NameColumn string
+	Owned      bool
+	UniqueName bool
+}
+
+// TrashSources lists every trash type in the order the trash is searched and purged
+var TrashSources = []TrashSource{
+	{Type: TrashTypePOI, Label: "POI", Table: POITable, NameColumn: "brand"},
+	{Type: TrashTypeSalesPackage, Label: "sales package", Table: SalesPackageTable, NameColumn: "name", Owned: true},
+	{Type: TrashTypeBuildingRestriction, Label: "building restriction", Table: BuildingRestrictionTable, NameColumn: "name", Owned: true},
+	{Type: TrashTypeSavedPolygon, Label: "saved polygon", Table: SavedPolygonTable, NameColumn: "name", Owned: true},
+	{Type: TrashTypeCategory, Label: "category", Table: CategoryTable, NameColumn: "name", UniqueName: true},
+	{Type: TrashTypeSubCategory, Label: "sub-category", Table: SubCategoryTable, NameColumn: "name", UniqueName: true},
+	{Type: TrashTypeMotherBrand, Label: "mother brand", Table: MotherBrandTable, NameColumn: "name", UniqueName: true},
+	{Type: TrashTypeBranch, Label: "branch", Table: BranchTable, NameColumn: "name", UniqueName: true},
+}
+
+// FindTrashSource returns the source of a trash type; false when the type is unknown
+func FindTrashSource(trashType string) (TrashSource, bool) {
+	for _, source := range TrashSources {
+		if source.Type == trashType {
+			return source, true
+		}
+	}
+	return TrashSource{}, false
+}
+
+// TrashItem is a deleted row waiting to be restored or purged. Restorable tells whether the
+// user who asked may restore it.
+type TrashItem struct {
+	Type          string `json:"type"`
+	Id            int    `json:"id"`
+	Name          string `json:"name"`
+	DeletedAt     string `json:"deleted_at"`
+	DeletedBy     *int   `json:"deleted_by"`
+	DeletedByName string `json:"deleted_by_name"`
+	PurgeAt       string `json:"purge_at"`
+	Restorable    bool   `json:"restorable"`
+}
+
+type NullAbleTrashItem struct {
+	Type          sql.NullString
+	Id            sql.NullInt64
+	Name          sql.NullString
+	DeletedAt     sql.NullString
+	DeletedBy     sql.NullInt64
+	DeletedByName sql.NullString
+	PurgeAt       sql.NullString
+	Restorable    sql.NullBool
+}
+
+func NullAbleTrashItemToTrashItem(nullable NullAbleTrashItem) TrashItem {
+	return TrashItem{
+		Type:          nullable.Type.String,
+		Id:            int(nullable.Id.Int64),
+		Name:          nullable.Name.String,
+		DeletedAt:     nullable.DeletedAt.String,
+		DeletedBy:     nullIntToPtr(nullable.DeletedBy),
+		DeletedByName: nullable.DeletedByName.String,
+		PurgeAt:       nullable.PurgeAt.String,
+		Restorable:    nullable.Restorable.Bool,
+	}
+}
@@ -9,6 +9,7 @@ type User struct {
 	Email    string
 	Password string
 	Role     string
+	Team     string
 }
 
 type NullAbleUser struct {
@@ -18,6 +19,7 @@ type NullAbleUser struct {
 	Email    sql.NullString
 	Password sql.NullString
 	Role     sql.NullString
+	Team     sql.NullString
 }
 
 var UserTable string = "users"
@@ -30,6 +32,7 @@ func NullAbleUserToUser(nullAbleUser NullAbleUser) User {
 		Email:    nullAbleUser.Email.String,
 		Password: nullAbleUser.Password.String,
 		Role:     nullAbleUser.Role.String,
+		Team:     nullAbleUser.Team.String,
 	}
 }
 
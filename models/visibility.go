@@ -0,0 +1,60 @@
+package models
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// Visibility levels of owned objects (saved polygons, sales packages, building restrictions).
+// Private objects are seen by their owner only, team objects also by users of the owner's team
+// and public objects by everyone.
+const (
+	VisibilityPrivate = "private"
+	VisibilityTeam    = "team"
+	VisibilityPublic  = "public"
+)
+
+// VisibilityOrDefault returns visibility, or team when it is empty
+func VisibilityOrDefault(visibility string) string {
+	if visibility == "" {
+		return VisibilityTeam
+	}
+	return visibility
+}
+
+// UserRoleAdmin users see and may change every owned object
+const UserRoleAdmin = "admin"
+
+// Access tells what one user may do with one owned object
+type Access struct {
+	Visible  bool
+	Editable bool
+}
+
+// VisibleToCondition returns a SQL condition on table (or alias) that holds for rows the user
+// bound to param may see: public rows, their own rows, team rows owned by a teammate and, for
+// admins, every row
+func VisibleToCondition(table string, param string) string {
+	return fmt.Sprintf(`(%[1]s.visibility = '%[3]s' OR %[1]s.created_by = %[2]s
+		OR EXISTS (SELECT 1 FROM %[5]s viewer WHERE viewer.id = %[2]s AND (viewer.role = '%[6]s'
+			OR (%[1]s.visibility = '%[4]s' AND viewer.team IS NOT NULL
+				AND viewer.team = (SELECT owner.team FROM %[5]s owner WHERE owner.id = %[1]s.created_by)))))`,
+		table, param, VisibilityPublic, VisibilityTeam, UserTable, UserRoleAdmin)
+}
+
+// EditableByCondition returns a SQL condition on table (or alias) that holds for rows the user
+// bound to param may change: their own rows, public rows without an owner and, for admins, every row
+func EditableByCondition(table string, param string) string {
+	return fmt.Sprintf(`(%[1]s.created_by = %[2]s
+		OR (%[1]s.created_by IS NULL AND %[1]s.visibility = '%[3]s')
+		OR EXISTS (SELECT 1 FROM %[4]s viewer WHERE viewer.id = %[2]s AND viewer.role = '%[5]s'))`,
+		table, param, VisibilityPublic, UserTable, UserRoleAdmin)
+}
+
+func nullIntToPtr(n sql.NullInt64) *int {
+	if !n.Valid {
+		return nil
+	}
+	v := int(n.Int64)
+	return &v
+}
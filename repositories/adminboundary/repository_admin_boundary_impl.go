@@ -0,0 +1,69 @@
+package adminboundary
+
+import (
+	"context"
+	"database/sql"
+	"strconv"
+
+	"github.com/malikabdulaziz/tmn-backend/models"
+)
+
+type RepositoryAdminBoundaryImpl struct{}
+
+func NewRepositoryAdminBoundaryImpl() RepositoryAdminBoundaryInterface {
+	return &RepositoryAdminBoundaryImpl{}
+}
+
+// Upsert inserts or replaces a boundary keyed by (level, code).
+// geometry is a GeoJSON geometry object; polygons are promoted to multipolygons.
+func (r *RepositoryAdminBoundaryImpl) Upsert(ctx context.Context, tx *sql.Tx, boundary models.AdminBoundary, geometry string) (models.AdminBoundary, error) {
+	SQL := `INSERT INTO ` + models.AdminBoundaryTable + ` (code, name, level, parent_code, geom)
+		VALUES ($1, $2, $3, $4, ST_Multi(ST_SetSRID(ST_GeomFromGeoJSON($5), 4326)))
+		ON CONFLICT (level, code) DO UPDATE
+		SET name = EXCLUDED.name, parent_code = EXCLUDED.parent_code, geom = EXCLUDED.geom, updated_at = CURRENT_TIMESTAMP
+		RETURNING id, created_at, updated_at`
+	var parentCode interface{}
+	if boundary.ParentCode != "" {
+		parentCode = boundary.ParentCode
+	}
+	err := tx.QueryRowContext(ctx, SQL, boundary.Code, boundary.Name, boundary.Level, parentCode, geometry).
+		Scan(&boundary.Id, &boundary.CreatedAt, &boundary.UpdatedAt)
+	if err != nil {
+		return models.AdminBoundary{}, err
+	}
+	return boundary, nil
+}
+
+// FindAll retrieves boundaries (without geometry) optionally filtered by level and parent code
+func (r *RepositoryAdminBoundaryImpl) FindAll(ctx context.Context, tx *sql.Tx, level string, parentCode string) ([]models.AdminBoundary, error) {
+	SQL := `SELECT id, code, name, level, parent_code, created_at, updated_at FROM ` + models.AdminBoundaryTable + ` WHERE 1=1`
+	args := []interface{}{}
+	argIndex := 1
+	if level != "" {
+		SQL += ` AND level = $` + strconv.Itoa(argIndex)
+		args = append(args, level)
+		argIndex++
+	}
+	if parentCode != "" {
+		SQL += ` AND parent_code = $` + strconv.Itoa(argIndex)
+		args = append(args, parentCode)
+		argIndex++
+	}
+	SQL += ` ORDER BY level ASC, name ASC`
+
+	rows, err := tx.QueryContext(ctx, SQL, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var boundaries []models.AdminBoundary
+	for rows.Next() {
+		var n models.NullAbleAdminBoundary
+		if err := rows.Scan(&n.Id, &n.Code, &n.Name, &n.Level, &n.ParentCode, &n.CreatedAt, &n.UpdatedAt); err != nil {
+			return nil, err
+		}
+		boundaries = append(boundaries, models.NullAbleAdminBoundaryToAdminBoundary(n))
+	}
+	return boundaries, rows.Err()
+}
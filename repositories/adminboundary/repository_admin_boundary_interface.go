@@ -0,0 +1,13 @@
+package adminboundary
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/malikabdulaziz/tmn-backend/models"
+)
+
+type RepositoryAdminBoundaryInterface interface {
+	Upsert(ctx context.Context, tx *sql.Tx, boundary models.AdminBoundary, geometry string) (models.AdminBoundary, error)
+	FindAll(ctx context.Context, tx *sql.Tx, level string, parentCode string) ([]models.AdminBoundary, error)
+}
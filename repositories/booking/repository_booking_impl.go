@@ -0,0 +1,320 @@
+package booking
+
+import (
+	"context"
+	"database/sql"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/malikabdulaziz/tmn-backend/models"
+)
+
+type RepositoryBookingImpl struct{}
+
+func NewRepositoryBookingImpl() RepositoryBookingInterface {
+	return &RepositoryBookingImpl{}
+}
+
+var allowedOrderBy = map[string]bool{"id": true, "client_name": true, "start_date": true, "end_date": true, "status": true, "created_at": true, "updated_at": true}
+var allowedOrderDir = map[string]bool{"ASC": true, "DESC": true}
+
+func safeOrder(orderBy, orderDirection string) (string, string) {
+	if !allowedOrderBy[orderBy] {
+		orderBy = "start_date"
+	}
+	if !allowedOrderDir[orderDirection] {
+		orderDirection = "DESC"
+	}
+	return orderBy, orderDirection
+}
+
+const bookingCols = `bk.id, bk.client_name, bk.sales_package_id, sp.name, bk.sales_package_version_id, spv.version, to_char(bk.start_date, 'YYYY-MM-DD'),
+	to_char(bk.end_date, 'YYYY-MM-DD'), bk.status, bk.notes, bk.advertiser_category_id, cat.name,
+	bk.advertiser_mother_brand_id, mb.name, bk.created_by, bk.created_at, bk.updated_at`
+
+// bookingJoins brings in the names behind bookingCols
+var bookingJoins = `
+		LEFT JOIN ` + models.SalesPackageTable + ` sp ON sp.id = bk.sales_package_id
+		LEFT JOIN ` + models.SalesPackageVersionTable + ` spv ON spv.id = bk.sales_package_version_id
+		LEFT JOIN ` + models.CategoryTable + ` cat ON cat.id = bk.advertiser_category_id
+		LEFT JOIN ` + models.MotherBrandTable + ` mb ON mb.id = bk.advertiser_mother_brand_id`
+
+func scanBooking(scanner interface{ Scan(...interface{}) error }) (models.Booking, error) {
+	var n models.NullAbleBooking
+	if err := scanner.Scan(&n.Id, &n.ClientName, &n.SalesPackageId, &n.SalesPackageName, &n.SalesPackageVersionId, &n.SalesPackageVersion, &n.StartDate,
+		&n.EndDate, &n.Status, &n.Notes, &n.AdvertiserCategoryId, &n.AdvertiserCategoryName,
+		&n.AdvertiserMotherBrandId, &n.AdvertiserMotherBrandName, &n.CreatedBy, &n.CreatedAt, &n.UpdatedAt); err != nil {
+		return models.Booking{}, err
+	}
+	return models.NullAbleBookingToBooking(n), nil
+}
+
+func idPlaceholders(ids []int, start int) (string, []interface{}) {
+	placeholders := make([]string, len(ids))
+	args := make([]interface{}, len(ids))
+	for i, id := range ids {
+		placeholders[i] = "$" + strconv.Itoa(start+i)
+		args[i] = id
+	}
+	return strings.Join(placeholders, ","), args
+}
+
+func buildWhere(filter BookingFilter) (string, []interface{}) {
+	var conditions []string
+	var args []interface{}
+	add := func(condition string, value interface{}) {
+		args = append(args, value)
+		conditions = append(conditions, strings.ReplaceAll(condition, "?", "$"+strconv.Itoa(len(args))))
+	}
+	if filter.Status != "" {
+		add("bk.status = ?", filter.Status)
+	}
+	if filter.ClientName != "" {
+		add("bk.client_name ILIKE ?", "%"+filter.ClientName+"%")
+	}
+	if filter.SalesPackageId > 0 {
+		add("bk.sales_package_id = ?", filter.SalesPackageId)
+	}
+	if filter.BuildingId > 0 {
+		add("EXISTS (SELECT 1 FROM "+models.BookingBuildingTable+" bb WHERE bb.booking_id = bk.id AND bb.building_id = ?)", filter.BuildingId)
+	}
+	if filter.From != "" {
+		add("bk.end_date >= ?::date", filter.From)
+	}
+	if filter.To != "" {
+		add("bk.start_date <= ?::date", filter.To)
+	}
+	if len(conditions) == 0 {
+		return "", args
+	}
+	return " WHERE " + strings.Join(conditions, " AND "), args
+}
+
+// Create inserts a booking with its buildings
+func (r *RepositoryBookingImpl) Create(ctx context.Context, tx *sql.Tx, booking models.Booking, buildings []models.BookingBuilding) (models.Booking, error) {
+	SQL := `INSERT INTO ` + models.BookingTable + ` (client_name, sales_package_id, sales_package_version_id, start_date, end_date, status, notes,
+		advertiser_category_id, advertiser_mother_brand_id, created_by)
+		VALUES ($1, $2, $3, $4, $5, $6, NULLIF($7, ''), $8, $9, $10) RETURNING id`
+	var id int
+	err := tx.QueryRowContext(ctx, SQL, booking.ClientName, booking.SalesPackageId, booking.SalesPackageVersionId, booking.StartDate, booking.EndDate,
+		booking.Status, booking.Notes, booking.AdvertiserCategoryId, booking.AdvertiserMotherBrandId, booking.CreatedBy).Scan(&id)
+	if err != nil {
+		return models.Booking{}, err
+	}
+	if err := r.insertBuildings(ctx, tx, id, buildings); err != nil {
+		return models.Booking{}, err
+	}
+	return r.FindById(ctx, tx, id)
+}
+
+func (r *RepositoryBookingImpl) insertBuildings(ctx context.Context, tx *sql.Tx, bookingId int, buildings []models.BookingBuilding) error {
+	SQL := `INSERT INTO ` + models.BookingBuildingTable + ` (booking_id, building_id, screens) VALUES ($1, $2, $3)`
+	for _, b := range buildings {
+		if _, err := tx.ExecContext(ctx, SQL, bookingId, b.BuildingId, b.Screens); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// FindAll retrieves bookings matching the filter with their buildings
+func (r *RepositoryBookingImpl) FindAll(ctx context.Context, tx *sql.Tx, filter BookingFilter, take int, skip int, orderBy string, orderDirection string) ([]models.Booking, error) {
+	orderBy, orderDirection = safeOrder(orderBy, orderDirection)
+	where, args := buildWhere(filter)
+	args = append(args, take, skip)
+	SQL := `SELECT ` + bookingCols + ` FROM ` + models.BookingTable + ` bk` + bookingJoins + where + `
+		ORDER BY bk.` + orderBy + ` ` + orderDirection + `, bk.id DESC
+		LIMIT $` + strconv.Itoa(len(args)-1) + ` OFFSET $` + strconv.Itoa(len(args))
+	rows, err := tx.QueryContext(ctx, SQL, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	bookings := []models.Booking{}
+	var ids []int
+	for rows.Next() {
+		booking, err := scanBooking(rows)
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, booking.Id)
+		bookings = append(bookings, booking)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	buildingsMap, err := r.findBuildingsByBookingIds(ctx, tx, ids)
+	if err != nil {
+		return nil, err
+	}
+	for i := range bookings {
+		if buildings, ok := buildingsMap[bookings[i].Id]; ok {
+			bookings[i].Buildings = buildings
+		}
+	}
+	return bookings, nil
+}
+
+// CountAll returns the number of bookings matching the filter
+func (r *RepositoryBookingImpl) CountAll(ctx context.Context, tx *sql.Tx, filter BookingFilter) (int, error) {
+	where, args := buildWhere(filter)
+	SQL := `SELECT COUNT(*) FROM ` + models.BookingTable + ` bk` + where
+	var total int
+	err := tx.QueryRowContext(ctx, SQL, args...).Scan(&total)
+	return total, err
+}
+
+// FindById retrieves a booking by ID with its buildings
+func (r *RepositoryBookingImpl) FindById(ctx context.Context, tx *sql.Tx, id int) (models.Booking, error) {
+	SQL := `SELECT ` + bookingCols + ` FROM ` + models.BookingTable + ` bk` + bookingJoins + `
+		WHERE bk.id = $1`
+	booking, err := scanBooking(tx.QueryRowContext(ctx, SQL, id))
+	if err != nil {
+		return models.Booking{}, err
+	}
+	buildingsMap, err := r.findBuildingsByBookingIds(ctx, tx, []int{id})
+	if err != nil {
+		return models.Booking{}, err
+	}
+	if buildings, ok := buildingsMap[id]; ok {
+		booking.Buildings = buildings
+	}
+	return booking, nil
+}
+
+func (r *RepositoryBookingImpl) findBuildingsByBookingIds(ctx context.Context, tx *sql.Tx, bookingIds []int) (map[int][]models.BookingBuilding, error) {
+	out := make(map[int][]models.BookingBuilding)
+	if len(bookingIds) == 0 {
+		return out, nil
+	}
+	placeholders, args := idPlaceholders(bookingIds, 1)
+	SQL := `SELECT bb.id, bb.booking_id, bb.building_id, b.name, bb.screens FROM ` + models.BookingBuildingTable + ` bb
+		INNER JOIN ` + models.BuildingTable + ` b ON b.id = bb.building_id
+		WHERE bb.booking_id IN (` + placeholders + `) ORDER BY bb.booking_id, b.name`
+	rows, err := tx.QueryContext(ctx, SQL, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var bb models.BookingBuilding
+		if err := rows.Scan(&bb.Id, &bb.BookingId, &bb.BuildingId, &bb.BuildingName, &bb.Screens); err != nil {
+			return nil, err
+		}
+		out[bb.BookingId] = append(out[bb.BookingId], bb)
+	}
+	return out, rows.Err()
+}
+
+// Update updates a booking and replaces its buildings
+func (r *RepositoryBookingImpl) Update(ctx context.Context, tx *sql.Tx, booking models.Booking, buildings []models.BookingBuilding) (models.Booking, error) {
+	SQL := `UPDATE ` + models.BookingTable + ` SET client_name = $1, sales_package_id = $2, sales_package_version_id = $3, start_date = $4,
+		end_date = $5, notes = NULLIF($6, ''), advertiser_category_id = $7, advertiser_mother_brand_id = $8, updated_at = $9 WHERE id = $10`
+	_, err := tx.ExecContext(ctx, SQL, booking.ClientName, booking.SalesPackageId, booking.SalesPackageVersionId, booking.StartDate, booking.EndDate,
+		booking.Notes, booking.AdvertiserCategoryId, booking.AdvertiserMotherBrandId, time.Now(), booking.Id)
+	if err != nil {
+		return models.Booking{}, err
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM `+models.BookingBuildingTable+` WHERE booking_id = $1`, booking.Id); err != nil {
+		return models.Booking{}, err
+	}
+	if err := r.insertBuildings(ctx, tx, booking.Id, buildings); err != nil {
+		return models.Booking{}, err
+	}
+	return r.FindById(ctx, tx, booking.Id)
+}
+
+// UpdateStatus sets a booking's status and returns the new updated_at
+func (r *RepositoryBookingImpl) UpdateStatus(ctx context.Context, tx *sql.Tx, id int, status string) (string, error) {
+	SQL := `UPDATE ` + models.BookingTable + ` SET status = $1, updated_at = $2 WHERE id = $3 RETURNING updated_at`
+	var updatedAt string
+	err := tx.QueryRowContext(ctx, SQL, status, time.Now(), id).Scan(&updatedAt)
+	return updatedAt, err
+}
+
+// Delete deletes a booking (CASCADE deletes its buildings)
+func (r *RepositoryBookingImpl) Delete(ctx context.Context, tx *sql.Tx, id int) error {
+	SQL := `DELETE FROM ` + models.BookingTable + ` WHERE id = $1`
+	_, err := tx.ExecContext(ctx, SQL, id)
+	return err
+}
+
+// LockBuildings row-locks the buildings until the transaction ends, so two bookings on the same
+// buildings are conflict-checked one after the other instead of both passing
+func (r *RepositoryBookingImpl) LockBuildings(ctx context.Context, tx *sql.Tx, buildingIds []int) error {
+	if len(buildingIds) == 0 {
+		return nil
+	}
+	placeholders, args := idPlaceholders(buildingIds, 1)
+	SQL := `SELECT id FROM ` + models.BuildingTable + ` WHERE id IN (` + placeholders + `) ORDER BY id FOR UPDATE`
+	_, err := tx.ExecContext(ctx, SQL, args...)
+	return err
+}
+
+// FindBuildingCapacities returns the given buildings with the screen count of their latest
+// proposal, ordered by name. Unknown ids are left out.
+func (r *RepositoryBookingImpl) FindBuildingCapacities(ctx context.Context, tx *sql.Tx, buildingIds []int) ([]BuildingCapacityRow, error) {
+	if len(buildingIds) == 0 {
+		return []BuildingCapacityRow{}, nil
+	}
+	placeholders, args := idPlaceholders(buildingIds, 1)
+	SQL := `SELECT b.id, b.name, COALESCE(bp.number_of_screen, 0) FROM ` + models.BuildingTable + ` b
+		LEFT JOIN LATERAL (
+			SELECT number_of_screen FROM ` + models.BuildingProposalTable + `
+			WHERE building_project = b.project_name
+			ORDER BY modified DESC NULLS LAST, id DESC LIMIT 1
+		) bp ON b.project_name <> ''
+		WHERE b.id IN (` + placeholders + `)
+		ORDER BY b.name, b.id`
+	rows, err := tx.QueryContext(ctx, SQL, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := []BuildingCapacityRow{}
+	for rows.Next() {
+		var row BuildingCapacityRow
+		if err := rows.Scan(&row.BuildingId, &row.BuildingName, &row.Screens); err != nil {
+			return nil, err
+		}
+		result = append(result, row)
+	}
+	return result, rows.Err()
+}
+
+// FindAllocations returns the hold and confirmed bookings on the given buildings that overlap
+// [startDate, endDate], one row per booking and building. excludeBookingId leaves out the
+// booking being edited; pass 0 to keep all.
+func (r *RepositoryBookingImpl) FindAllocations(ctx context.Context, tx *sql.Tx, buildingIds []int, startDate string, endDate string, excludeBookingId int) ([]BookingAllocationRow, error) {
+	if len(buildingIds) == 0 {
+		return []BookingAllocationRow{}, nil
+	}
+	placeholders, args := idPlaceholders(buildingIds, 5)
+	args = append([]interface{}{startDate, endDate, excludeBookingId, models.BookingStatusCancelled}, args...)
+	SQL := `SELECT bk.id, bk.client_name, bk.status, to_char(bk.start_date, 'YYYY-MM-DD'), to_char(bk.end_date, 'YYYY-MM-DD'),
+		bb.building_id, bb.screens
+		FROM ` + models.BookingTable + ` bk
+		INNER JOIN ` + models.BookingBuildingTable + ` bb ON bb.booking_id = bk.id
+		WHERE bk.start_date <= $2::date AND bk.end_date >= $1::date AND bk.id <> $3 AND bk.status <> $4
+			AND bb.building_id IN (` + placeholders + `)
+		ORDER BY bk.start_date, bk.id, bb.building_id`
+	rows, err := tx.QueryContext(ctx, SQL, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := []BookingAllocationRow{}
+	for rows.Next() {
+		var row BookingAllocationRow
+		if err := rows.Scan(&row.BookingId, &row.ClientName, &row.Status, &row.StartDate, &row.EndDate,
+			&row.BuildingId, &row.Screens); err != nil {
+			return nil, err
+		}
+		result = append(result, row)
+	}
+	return result, rows.Err()
+}
@@ -0,0 +1,51 @@
+package booking
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/malikabdulaziz/tmn-backend/models"
+)
+
+// BookingFilter narrows FindAll and CountAll; zero values are ignored. From and To keep bookings
+// whose date range overlaps [From, To].
+type BookingFilter struct {
+	Status         string
+	ClientName     string
+	SalesPackageId int
+	BuildingId     int
+	From           string
+	To             string
+}
+
+// BuildingCapacityRow is a building with the screen count of its latest proposal, 0 when no
+// proposal records one
+type BuildingCapacityRow struct {
+	BuildingId   int
+	BuildingName string
+	Screens      int
+}
+
+// BookingAllocationRow is one building of a hold or confirmed booking and the screens it takes
+type BookingAllocationRow struct {
+	BookingId  int
+	ClientName string
+	Status     string
+	StartDate  string
+	EndDate    string
+	BuildingId int
+	Screens    int
+}
+
+type RepositoryBookingInterface interface {
+	Create(ctx context.Context, tx *sql.Tx, booking models.Booking, buildings []models.BookingBuilding) (models.Booking, error)
+	FindAll(ctx context.Context, tx *sql.Tx, filter BookingFilter, take int, skip int, orderBy string, orderDirection string) ([]models.Booking, error)
+	CountAll(ctx context.Context, tx *sql.Tx, filter BookingFilter) (int, error)
+	FindById(ctx context.Context, tx *sql.Tx, id int) (models.Booking, error)
+	Update(ctx context.Context, tx *sql.Tx, booking models.Booking, buildings []models.BookingBuilding) (models.Booking, error)
+	UpdateStatus(ctx context.Context, tx *sql.Tx, id int, status string) (string, error)
+	Delete(ctx context.Context, tx *sql.Tx, id int) error
+	LockBuildings(ctx context.Context, tx *sql.Tx, buildingIds []int) error
+	FindBuildingCapacities(ctx context.Context, tx *sql.Tx, buildingIds []int) ([]BuildingCapacityRow, error)
+	FindAllocations(ctx context.Context, tx *sql.Tx, buildingIds []int, startDate string, endDate string, excludeBookingId int) ([]BookingAllocationRow, error)
+}
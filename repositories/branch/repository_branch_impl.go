@@ -27,9 +27,34 @@ func mdBrSafeOrder(orderBy, orderDirection string) (string, string) {
 	return orderBy, orderDirection
 }
 
+// mdBrSelect reads branches together with the name of their mother brand
+var mdBrSelect = `SELECT b.id, b.name, b.mother_brand_id, mb.name, b.created_at, b.updated_at FROM ` + models.BranchTable + ` b
+	LEFT JOIN ` + models.MotherBrandTable + ` mb ON mb.id = b.mother_brand_id`
+
+func mdBrScan(scanner interface{ Scan(...interface{}) error }) (models.Branch, error) {
+	var n models.NullAbleBranch
+	if err := scanner.Scan(&n.Id, &n.Name, &n.MotherBrandId, &n.MotherBrandName, &n.CreatedAt, &n.UpdatedAt); err != nil {
+		return models.Branch{}, err
+	}
+	return models.NullAbleBranchToBranch(n), nil
+}
+
+func mdBrScanRows(rows *sql.Rows) ([]models.Branch, error) {
+	defer rows.Close()
+	var list []models.Branch
+	for rows.Next() {
+		branch, err := mdBrScan(rows)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, branch)
+	}
+	return list, rows.Err()
+}
+
 func (r *RepositoryBranchImpl) Create(ctx context.Context, tx *sql.Tx, branch models.Branch) (models.Branch, error) {
-	SQL := `INSERT INTO ` + models.BranchTable + ` (name) VALUES ($1) RETURNING id, created_at, updated_at`
-	err := tx.QueryRowContext(ctx, SQL, branch.Name).Scan(&branch.Id, &branch.CreatedAt, &branch.UpdatedAt)
+	SQL := `INSERT INTO ` + models.BranchTable + ` (name, mother_brand_id) VALUES ($1, $2) RETURNING id, created_at, updated_at`
+	err := tx.QueryRowContext(ctx, SQL, branch.Name, branch.MotherBrandId).Scan(&branch.Id, &branch.CreatedAt, &branch.UpdatedAt)
 	return branch, err
 }
 
@@ -38,86 +63,71 @@ func (r *RepositoryBranchImpl) FindAll(ctx context.Context, tx *sql.Tx, take int
 	var rows *sql.Rows
 	var err error
 	if search != "" {
-		SQL := `SELECT id, name, created_at, updated_at FROM ` + models.BranchTable + ` WHERE name ILIKE $1 ORDER BY ` + orderBy + ` ` + orderDirection + `, name ASC LIMIT $2 OFFSET $3`
+		SQL := mdBrSelect + ` WHERE b.deleted_at IS NULL AND b.name ILIKE $1 ORDER BY b.` + orderBy + ` ` + orderDirection + `, b.name ASC LIMIT $2 OFFSET $3`
 		rows, err = tx.QueryContext(ctx, SQL, "%"+search+"%", take, skip)
 	} else {
-		SQL := `SELECT id, name, created_at, updated_at FROM ` + models.BranchTable + ` ORDER BY ` + orderBy + ` ` + orderDirection + `, name ASC LIMIT $1 OFFSET $2`
+		SQL := mdBrSelect + ` WHERE b.deleted_at IS NULL ORDER BY b.` + orderBy + ` ` + orderDirection + `, b.name ASC LIMIT $1 OFFSET $2`
 		rows, err = tx.QueryContext(ctx, SQL, take, skip)
 	}
 	if err != nil {
 		return nil, err
 	}
-	defer rows.Close()
-	var list []models.Branch
-	for rows.Next() {
-		var n models.NullAbleBranch
-		if err := rows.Scan(&n.Id, &n.Name, &n.CreatedAt, &n.UpdatedAt); err != nil {
-			return nil, err
-		}
-		list = append(list, models.NullAbleBranchToBranch(n))
-	}
-	return list, rows.Err()
+	return mdBrScanRows(rows)
 }
 
 func (r *RepositoryBranchImpl) CountAll(ctx context.Context, tx *sql.Tx, search string) (int, error) {
 	var total int
 	var err error
 	if search != "" {
-		SQL := `SELECT COUNT(*) FROM ` + models.BranchTable + ` WHERE name ILIKE $1`
+		SQL := `SELECT COUNT(*) FROM ` + models.BranchTable + ` WHERE deleted_at IS NULL AND name ILIKE $1`
 		err = tx.QueryRowContext(ctx, SQL, "%"+search+"%").Scan(&total)
 	} else {
-		SQL := `SELECT COUNT(*) FROM ` + models.BranchTable
+		SQL := `SELECT COUNT(*) FROM ` + models.BranchTable + ` WHERE deleted_at IS NULL`
 		err = tx.QueryRowContext(ctx, SQL).Scan(&total)
 	}
 	return total, err
 }
 
 func (r *RepositoryBranchImpl) FindById(ctx context.Context, tx *sql.Tx, id int) (models.Branch, error) {
-	SQL := `SELECT id, name, created_at, updated_at FROM ` + models.BranchTable + ` WHERE id = $1`
-	var n models.NullAbleBranch
-	err := tx.QueryRowContext(ctx, SQL, id).Scan(&n.Id, &n.Name, &n.CreatedAt, &n.UpdatedAt)
-	if err != nil {
-		return models.Branch{}, err
-	}
-	return models.NullAbleBranchToBranch(n), nil
+	SQL := mdBrSelect + ` WHERE b.id = $1 AND b.deleted_at IS NULL`
+	return mdBrScan(tx.QueryRowContext(ctx, SQL, id))
 }
 
 func (r *RepositoryBranchImpl) Update(ctx context.Context, tx *sql.Tx, branch models.Branch) (models.Branch, error) {
-	SQL := `UPDATE ` + models.BranchTable + ` SET name = $1, updated_at = $2 WHERE id = $3 RETURNING updated_at`
-	err := tx.QueryRowContext(ctx, SQL, branch.Name, time.Now(), branch.Id).Scan(&branch.UpdatedAt)
+	SQL := `UPDATE ` + models.BranchTable + ` SET name = $1, mother_brand_id = $2, updated_at = $3 WHERE id = $4 AND deleted_at IS NULL RETURNING updated_at`
+	err := tx.QueryRowContext(ctx, SQL, branch.Name, branch.MotherBrandId, time.Now(), branch.Id).Scan(&branch.UpdatedAt)
 	return branch, err
 }
 
-func (r *RepositoryBranchImpl) Delete(ctx context.Context, tx *sql.Tx, id int) error {
-	SQL := `DELETE FROM ` + models.BranchTable + ` WHERE id = $1`
-	_, err := tx.ExecContext(ctx, SQL, id)
+// Delete moves a branch to the trash; POIs keep pointing at it until it is purged
+func (r *RepositoryBranchImpl) Delete(ctx context.Context, tx *sql.Tx, id int, deletedBy *int) error {
+	SQL := `UPDATE ` + models.BranchTable + ` SET deleted_at = $1, deleted_by = $2 WHERE id = $3 AND deleted_at IS NULL`
+	_, err := tx.ExecContext(ctx, SQL, time.Now(), deletedBy, id)
 	return err
 }
 
+// FindByName retrieves a branch by name, ignoring case, or the branch a name was merged into
 func (r *RepositoryBranchImpl) FindByName(ctx context.Context, tx *sql.Tx, name string) (models.Branch, error) {
-	SQL := `SELECT id, name, created_at, updated_at FROM ` + models.BranchTable + ` WHERE LOWER(name) = LOWER($1) LIMIT 1`
-	var n models.NullAbleBranch
-	err := tx.QueryRowContext(ctx, SQL, name).Scan(&n.Id, &n.Name, &n.CreatedAt, &n.UpdatedAt)
-	if err != nil {
-		return models.Branch{}, err
-	}
-	return models.NullAbleBranchToBranch(n), nil
+	SQL := mdBrSelect + ` WHERE b.deleted_at IS NULL AND (LOWER(b.name) = LOWER($1) OR ` + models.AliasCondition(models.MasterDataTypeBranch, "b.id", "$1") + `)
+		ORDER BY LOWER(b.name) = LOWER($1) DESC LIMIT 1`
+	return mdBrScan(tx.QueryRowContext(ctx, SQL, name))
 }
 
 func (r *RepositoryBranchImpl) FindAllDropdown(ctx context.Context, tx *sql.Tx) ([]models.Branch, error) {
-	SQL := `SELECT id, name, created_at, updated_at FROM ` + models.BranchTable + ` ORDER BY name ASC`
+	SQL := mdBrSelect + ` WHERE b.deleted_at IS NULL ORDER BY b.name ASC`
 	rows, err := tx.QueryContext(ctx, SQL)
 	if err != nil {
 		return nil, err
 	}
-	defer rows.Close()
-	var list []models.Branch
-	for rows.Next() {
-		var n models.NullAbleBranch
-		if err := rows.Scan(&n.Id, &n.Name, &n.CreatedAt, &n.UpdatedAt); err != nil {
-			return nil, err
-		}
-		list = append(list, models.NullAbleBranchToBranch(n))
-	}
-	return list, rows.Err()
+	return mdBrScanRows(rows)
+}
+
+// CountPOIsOutsideMotherBrand counts the live POIs with a point at the branch whose mother brand is other than motherBrandId
+func (r *RepositoryBranchImpl) CountPOIsOutsideMotherBrand(ctx context.Context, tx *sql.Tx, id int, motherBrandId int) (int, error) {
+	SQL := `SELECT COUNT(DISTINCT p.id) FROM ` + models.POIPointTable + ` pp
+		INNER JOIN ` + models.POITable + ` p ON p.id = pp.poi_id
+		WHERE pp.branch_id = $1 AND p.mother_brand_id IS NOT NULL AND p.mother_brand_id <> $2 AND p.deleted_at IS NULL`
+	var total int
+	err := tx.QueryRowContext(ctx, SQL, id, motherBrandId).Scan(&total)
+	return total, err
 }
@@ -13,7 +13,8 @@ type RepositoryBranchInterface interface {
 	CountAll(ctx context.Context, tx *sql.Tx, search string) (int, error)
 	FindById(ctx context.Context, tx *sql.Tx, id int) (models.Branch, error)
 	Update(ctx context.Context, tx *sql.Tx, branch models.Branch) (models.Branch, error)
-	Delete(ctx context.Context, tx *sql.Tx, id int) error
+	Delete(ctx context.Context, tx *sql.Tx, id int, deletedBy *int) error
 	FindByName(ctx context.Context, tx *sql.Tx, name string) (models.Branch, error)
 	FindAllDropdown(ctx context.Context, tx *sql.Tx) ([]models.Branch, error)
+	CountPOIsOutsideMotherBrand(ctx context.Context, tx *sql.Tx, id int, motherBrandId int) (int, error)
 }
@@ -584,11 +584,12 @@ func (repository *RepositoryBuildingImpl) GetDistinctValues(ctx context.Context,
 	return values, nil
 }
 
-// FindAllForMapping retrieves all buildings for mapping with filters (no pagination)
-func (repository *RepositoryBuildingImpl) FindAllForMapping(ctx context.Context, tx *sql.Tx, buildingType string, buildingGrade string, year string, subdistrict string, progress string, sellable string, connectivity string, lcdPresence string, salesPackageIds string, buildingRestrictionIds string, lat *float64, lng *float64, radius *int, poiPoints []struct{ Lat float64; Lng float64 }, polygonPoints []struct{ Lat float64; Lng float64 }, minLat *float64, maxLat *float64, minLng *float64, maxLng *float64) ([]models.Building, error) {
+// FindAllForMapping retrieves all buildings for mapping with filters (no pagination). Sales packages
+// and building restrictions only filter when userId may see them and they are not in the trash.
+func (repository *RepositoryBuildingImpl) FindAllForMapping(ctx context.Context, tx *sql.Tx, userId int, buildingType string, buildingGrade string, year string, subdistrict string, progress string, sellable string, connectivity string, lcdPresence string, salesPackageIds string, buildingRestrictionIds string, provinceCodes string, cityCodes string, subdistrictCodes string, lat *float64, lng *float64, radius *int, catchmentMode string, travelSeconds int, poiIds []int, polygonPoints []struct{ Lat float64; Lng float64 }, minLat *float64, maxLat *float64, minLng *float64, maxLng *float64) ([]models.Building, error) {
 	SQL := `SELECT DISTINCT b.id, b.external_building_id, b.iris_code, b.name, b.project_name, b.audience, 
 		b.impression, b.cbd_area, b.building_status, b.competitor_location, b.competitor_exclusive, b.competitor_presence, b.sellable, b.connectivity, 
-		b.resource_type, b.subdistrict, b.citytown, b.province, b.grade_resource, b.building_type, b.completion_year, b.latitude, b.longitude, b.images, b.lcd_presence_status, b.synced_at, b.created_at, b.updated_at 
+		b.resource_type, b.subdistrict, b.citytown, b.province, b.province_code, b.city_code, b.subdistrict_code, b.grade_resource, b.building_type, b.completion_year, b.latitude, b.longitude, b.images, b.lcd_presence_status, b.synced_at, b.created_at, b.updated_at 
 		FROM ` + models.BuildingTable + ` b`
 
 	args := []interface{}{}
@@ -596,6 +597,20 @@ func (repository *RepositoryBuildingImpl) FindAllForMapping(ctx context.Context,
 	whereConditions := []string{}
 	joinClauses := []string{}
 
+	// Sales packages and building restrictions the user cannot see are ignored
+	userIndex := 0
+	if salesPackageIds != "" || buildingRestrictionIds != "" {
+		userIndex = argIndex
+		args = append(args, userId)
+		argIndex++
+	}
+	visiblePackageJoin := `INNER JOIN ` + models.SalesPackageBuildingTable + ` spb ON b.id = spb.building_id
+		INNER JOIN ` + models.SalesPackageTable + ` sp ON sp.id = spb.sales_package_id AND sp.deleted_at IS NULL
+			AND ` + models.VisibleToCondition("sp", "$"+strconv.Itoa(userIndex))
+	visibleRestrictionBuildings := `SELECT brb.building_id FROM ` + models.BuildingRestrictionBuildingTable + ` brb
+		INNER JOIN ` + models.BuildingRestrictionTable + ` br ON br.id = brb.building_restriction_id AND br.deleted_at IS NULL
+			AND ` + models.VisibleToCondition("br", "$"+strconv.Itoa(userIndex))
+
 	// Add sales package filter - JOIN with sales_package_buildings table
 	if salesPackageIds != "" {
 		if strings.Contains(salesPackageIds, ",") {
@@ -606,12 +621,12 @@ func (repository *RepositoryBuildingImpl) FindAllForMapping(ctx context.Context,
 				placeholders[i] = "$" + strconv.Itoa(argIndex+i)
 				args = append(args, strings.TrimSpace(packageIds[i]))
 			}
-			joinClauses = append(joinClauses, `INNER JOIN `+models.SalesPackageBuildingTable+` spb ON b.id = spb.building_id`)
+			joinClauses = append(joinClauses, visiblePackageJoin)
 			whereConditions = append(whereConditions, `spb.sales_package_id IN (`+strings.Join(placeholders, ",")+`)`)
 			argIndex += len(packageIds)
 		} else {
 			// Single value
-			joinClauses = append(joinClauses, `INNER JOIN `+models.SalesPackageBuildingTable+` spb ON b.id = spb.building_id`)
+			joinClauses = append(joinClauses, visiblePackageJoin)
 			whereConditions = append(whereConditions, `spb.sales_package_id = $`+strconv.Itoa(argIndex))
 			args = append(args, strings.TrimSpace(salesPackageIds))
 			argIndex++
@@ -629,14 +644,12 @@ func (repository *RepositoryBuildingImpl) FindAllForMapping(ctx context.Context,
 				args = append(args, strings.TrimSpace(restrictionIds[i]))
 			}
 			whereConditions = append(whereConditions,
-				`b.id NOT IN (SELECT building_id FROM `+models.BuildingRestrictionBuildingTable+
-					` WHERE building_restriction_id IN (`+strings.Join(placeholders, ",")+`))`)
+				`b.id NOT IN (`+visibleRestrictionBuildings+` WHERE brb.building_restriction_id IN (`+strings.Join(placeholders, ",")+`))`)
 			argIndex += len(restrictionIds)
 		} else {
 			// Single value
 			whereConditions = append(whereConditions,
-				`b.id NOT IN (SELECT building_id FROM `+models.BuildingRestrictionBuildingTable+
-					` WHERE building_restriction_id = $`+strconv.Itoa(argIndex)+`)`)
+				`b.id NOT IN (`+visibleRestrictionBuildings+` WHERE brb.building_restriction_id = $`+strconv.Itoa(argIndex)+`)`)
 			args = append(args, strings.TrimSpace(buildingRestrictionIds))
 			argIndex++
 		}
@@ -799,6 +812,26 @@ func (repository *RepositoryBuildingImpl) FindAllForMapping(ctx context.Context,
 		}
 	}
 
+	// Official region code filters (resolved from admin_boundaries during sync)
+	if provinceCodes != "" {
+		cond, condArgs, nextIndex := buildInCondition("b.province_code", provinceCodes, argIndex)
+		whereConditions = append(whereConditions, cond)
+		args = append(args, condArgs...)
+		argIndex = nextIndex
+	}
+	if cityCodes != "" {
+		cond, condArgs, nextIndex := buildInCondition("b.city_code", cityCodes, argIndex)
+		whereConditions = append(whereConditions, cond)
+		args = append(args, condArgs...)
+		argIndex = nextIndex
+	}
+	if subdistrictCodes != "" {
+		cond, condArgs, nextIndex := buildInCondition("b.subdistrict_code", subdistrictCodes, argIndex)
+		whereConditions = append(whereConditions, cond)
+		args = append(args, condArgs...)
+		argIndex = nextIndex
+	}
+
 	// Spatial filter: polygon (ST_Within) takes priority; else POI/radius (ST_DWithin)
 	if len(polygonPoints) >= 3 {
 		// Build closed WKT POLYGON: lng lat order, first point = last point
@@ -812,15 +845,40 @@ func (repository *RepositoryBuildingImpl) FindAllForMapping(ctx context.Context,
 		whereConditions = append(whereConditions, `ST_Within(location::geometry, ST_SetSRID(ST_GeomFromText($`+strconv.Itoa(argIndex)+`), 4326)::geometry)`)
 		args = append(args, wkt)
 		argIndex++
-	} else if len(poiPoints) > 0 && radius != nil && *radius > 0 {
-		orConditions := make([]string, len(poiPoints))
-		for i, point := range poiPoints {
-			orConditions[i] = `ST_DWithin(location, ST_SetSRID(ST_MakePoint($` + strconv.Itoa(argIndex+i*2) + `, $` + strconv.Itoa(argIndex+i*2+1) + `), 4326)::geography, $` + strconv.Itoa(argIndex+len(poiPoints)*2) + `)`
-			args = append(args, point.Lng, point.Lat)
+	} else if (catchmentMode == models.CatchmentModeDrive || catchmentMode == models.CatchmentModeWalk) && travelSeconds > 0 && (len(poiIds) > 0 || (lat != nil && lng != nil)) {
+		// Isochrone catchment: travel-time polygons over the road network, unioned across origins
+		var originsSQL string
+		var originArgs []interface{}
+		if len(poiIds) > 0 {
+			var inClause string
+			inClause, originArgs, argIndex = buildIntInPlaceholders(poiIds, argIndex)
+			originsSQL = `SELECT DISTINCT ST_X(pp.location::geometry) AS lng, ST_Y(pp.location::geometry) AS lat
+				FROM ` + models.POIPointTable + ` pp
+				INNER JOIN ` + models.POITable + ` p ON p.id = pp.poi_id AND p.deleted_at IS NULL
+				WHERE pp.poi_id IN (` + inClause + `) AND pp.location IS NOT NULL`
+		} else {
+			originsSQL = `SELECT $` + strconv.Itoa(argIndex) + `::DOUBLE PRECISION AS lng, $` + strconv.Itoa(argIndex+1) + `::DOUBLE PRECISION AS lat`
+			originArgs = []interface{}{*lng, *lat}
+			argIndex += 2
 		}
+		args = append(args, originArgs...)
+		cond, condArgs, nextIndex := buildCatchmentCondition(originsSQL, catchmentMode, travelSeconds, argIndex)
+		whereConditions = append(whereConditions, cond)
+		args = append(args, condArgs...)
+		argIndex = nextIndex
+	} else if len(poiIds) > 0 && radius != nil && *radius > 0 {
+		// Semi-join against the GIST-indexed poi_points.location instead of one ST_DWithin per outlet
+		inClause, inArgs, nextIndex := buildIntInPlaceholders(poiIds, argIndex)
+		args = append(args, inArgs...)
+		argIndex = nextIndex
+		whereConditions = append(whereConditions, `EXISTS (
+			SELECT 1 FROM `+models.POIPointTable+` pp
+			INNER JOIN `+models.POITable+` p ON p.id = pp.poi_id AND p.deleted_at IS NULL
+			WHERE pp.poi_id IN (`+inClause+`) AND pp.location IS NOT NULL
+			AND ST_DWithin(b.location, pp.location, $`+strconv.Itoa(argIndex)+`)
+		)`)
 		args = append(args, *radius)
-		whereConditions = append(whereConditions, `(`+strings.Join(orConditions, " OR ")+`)`)
-		argIndex += len(poiPoints)*2 + 1
+		argIndex++
 	} else if lat != nil && lng != nil && radius != nil && *radius > 0 {
 		whereConditions = append(whereConditions, `ST_DWithin(location, ST_SetSRID(ST_MakePoint($`+strconv.Itoa(argIndex)+`, $`+strconv.Itoa(argIndex+1)+`), 4326)::geography, $`+strconv.Itoa(argIndex+2)+`)`)
 		args = append(args, *lng, *lat, *radius)
@@ -877,6 +935,9 @@ func (repository *RepositoryBuildingImpl) FindAllForMapping(ctx context.Context,
 			&building.Subdistrict,
 			&building.Citytown,
 			&building.Province,
+			&building.ProvinceCode,
+			&building.CityCode,
+			&building.SubdistrictCode,
 			&building.GradeResource,
 			&building.BuildingType,
 			&building.CompletionYear,
@@ -975,6 +1036,236 @@ func (repository *RepositoryBuildingImpl) UpdateFromSync(ctx context.Context, tx
 	return building, nil
 }
 
+// AssignAdminBoundaries resolves province_code, city_code and subdistrict_code for every building
+// by spatial join of buildings.location against admin_boundaries. Buildings outside every boundary
+// (or without a location) get NULL codes. Returns the number of rows changed.
+func (repository *RepositoryBuildingImpl) AssignAdminBoundaries(ctx context.Context, tx *sql.Tx) (int, error) {
+	levelColumns := []struct {
+		level  string
+		column string
+	}{
+		{models.AdminBoundaryLevelProvince, "province_code"},
+		{models.AdminBoundaryLevelCity, "city_code"},
+		{models.AdminBoundaryLevelSubdistrict, "subdistrict_code"},
+	}
+
+	total := 0
+	for _, lc := range levelColumns {
+		SQL := `UPDATE ` + models.BuildingTable + ` b SET ` + lc.column + ` = m.code
+			FROM (
+				SELECT b2.id, (
+					SELECT ab.code FROM ` + models.AdminBoundaryTable + ` ab
+					WHERE ab.level = $1 AND b2.location IS NOT NULL AND ST_Covers(ab.geom, b2.location::geometry)
+					ORDER BY ab.code ASC LIMIT 1
+				) AS code
+				FROM ` + models.BuildingTable + ` b2
+			) m
+			WHERE m.id = b.id AND b.` + lc.column + ` IS DISTINCT FROM m.code`
+		result, err := tx.ExecContext(ctx, SQL, lc.level)
+		if err != nil {
+			return 0, err
+		}
+		affected, err := result.RowsAffected()
+		if err != nil {
+			return 0, err
+		}
+		total += int(affected)
+	}
+	return total, nil
+}
+
+// FindNearestPOIPoints returns, for each building with a location, the closest point belonging to
+// one of the given POIs and its geodesic distance in meters. Buildings without any located point are omitted.
+func (repository *RepositoryBuildingImpl) FindNearestPOIPoints(ctx context.Context, tx *sql.Tx, buildingIds []int, poiIds []int) ([]NearestPOIPointRow, error) {
+	if len(buildingIds) == 0 || len(poiIds) == 0 {
+		return []NearestPOIPointRow{}, nil
+	}
+
+	args := make([]interface{}, 0, len(buildingIds)+len(poiIds))
+	buildingPlaceholders := make([]string, len(buildingIds))
+	for i, id := range buildingIds {
+		buildingPlaceholders[i] = "$" + strconv.Itoa(len(args)+1)
+		args = append(args, id)
+	}
+	poiPlaceholders := make([]string, len(poiIds))
+	for i, id := range poiIds {
+		poiPlaceholders[i] = "$" + strconv.Itoa(len(args)+1)
+		args = append(args, id)
+	}
+
+	SQL := `SELECT DISTINCT ON (b.id) b.id, pp.id, pp.poi_id, COALESCE(pp.poi_name, ''), COALESCE(p.brand, ''),
+		ST_Distance(b.location, pp.location) AS distance
+		FROM ` + models.BuildingTable + ` b
+		INNER JOIN ` + models.POIPointTable + ` pp ON pp.poi_id IN (` + strings.Join(poiPlaceholders, ",") + `) AND pp.location IS NOT NULL
+		INNER JOIN ` + models.POITable + ` p ON p.id = pp.poi_id AND p.deleted_at IS NULL
+		WHERE b.id IN (` + strings.Join(buildingPlaceholders, ",") + `) AND b.location IS NOT NULL
+		ORDER BY b.id, distance ASC, pp.id ASC`
+
+	rows, err := tx.QueryContext(ctx, SQL, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := []NearestPOIPointRow{}
+	for rows.Next() {
+		var row NearestPOIPointRow
+		if err := rows.Scan(&row.BuildingId, &row.POIPointId, &row.POIId, &row.POIName, &row.Brand, &row.DistanceMeters); err != nil {
+			return nil, err
+		}
+		result = append(result, row)
+	}
+	return result, rows.Err()
+}
+
+// FindNearest returns the buildings closest to a coordinate using KNN ordering on buildings.location.
+// buildingType is an optional comma-separated, case-insensitive list.
+func (repository *RepositoryBuildingImpl) FindNearest(ctx context.Context, tx *sql.Tx, lat float64, lng float64, limit int, buildingType string) ([]NearestBuildingRow, error) {
+	origin := `ST_SetSRID(ST_MakePoint($1, $2), 4326)::geography`
+	args := []interface{}{lng, lat}
+	argIndex := 3
+
+	SQL := `SELECT id, external_building_id, name, building_type, grade_resource, subdistrict, citytown, province,
+		latitude, longitude, lcd_presence_status, ST_Distance(location, ` + origin + `)
+		FROM ` + models.BuildingTable + ` WHERE location IS NOT NULL`
+	if buildingType != "" {
+		cond, condArgs, nextIndex := buildInCondition("LOWER(building_type)", strings.ToLower(buildingType), argIndex)
+		SQL += ` AND ` + cond
+		args = append(args, condArgs...)
+		argIndex = nextIndex
+	}
+	SQL += ` ORDER BY location <-> ` + origin + ` LIMIT $` + strconv.Itoa(argIndex)
+	args = append(args, limit)
+
+	rows, err := tx.QueryContext(ctx, SQL, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := []NearestBuildingRow{}
+	for rows.Next() {
+		building := models.NullAbleBuilding{}
+		var distance float64
+		err := rows.Scan(
+			&building.Id,
+			&building.ExternalBuildingId,
+			&building.Name,
+			&building.BuildingType,
+			&building.GradeResource,
+			&building.Subdistrict,
+			&building.Citytown,
+			&building.Province,
+			&building.Latitude,
+			&building.Longitude,
+			&building.LcdPresenceStatus,
+			&distance,
+		)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, NearestBuildingRow{
+			Building:       models.NullAbleBuildingToBuilding(building),
+			DistanceMeters: distance,
+		})
+	}
+	return result, rows.Err()
+}
+
+// FindDistancesToPoint returns the geodesic distance in meters from each located building to the given coordinate
+func (repository *RepositoryBuildingImpl) FindDistancesToPoint(ctx context.Context, tx *sql.Tx, buildingIds []int, lat float64, lng float64) (map[int]float64, error) {
+	distances := make(map[int]float64)
+	if len(buildingIds) == 0 {
+		return distances, nil
+	}
+
+	args := []interface{}{lng, lat}
+	placeholders := make([]string, len(buildingIds))
+	for i, id := range buildingIds {
+		placeholders[i] = "$" + strconv.Itoa(len(args)+1)
+		args = append(args, id)
+	}
+
+	SQL := `SELECT id, ST_Distance(location, ST_SetSRID(ST_MakePoint($1, $2), 4326)::geography)
+		FROM ` + models.BuildingTable + `
+		WHERE id IN (` + strings.Join(placeholders, ",") + `) AND location IS NOT NULL`
+
+	rows, err := tx.QueryContext(ctx, SQL, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var id int
+		var distance float64
+		if err := rows.Scan(&id, &distance); err != nil {
+			return nil, err
+		}
+		distances[id] = distance
+	}
+	return distances, rows.Err()
+}
+
+// CountPOIPointsInRings counts, for each located building, the POI points within each ring radius
+// grouped by the parent POI's category and mother brand. Rings are cumulative: a point 200 m away is
+// counted in the 250 m ring and in every larger one. categoryIds / motherBrandIds optionally restrict the POIs.
+func (repository *RepositoryBuildingImpl) CountPOIPointsInRings(ctx context.Context, tx *sql.Tx, buildingIds []int, ringMeters []int, categoryIds []int, motherBrandIds []int) ([]POIDensityRow, error) {
+	if len(buildingIds) == 0 || len(ringMeters) == 0 {
+		return []POIDensityRow{}, nil
+	}
+
+	args := make([]interface{}, 0, len(ringMeters)+len(buildingIds)+len(categoryIds)+len(motherBrandIds))
+	placeholders := func(ids []int) string {
+		list := make([]string, len(ids))
+		for i, id := range ids {
+			list[i] = "$" + strconv.Itoa(len(args)+1)
+			args = append(args, id)
+		}
+		return strings.Join(list, ",")
+	}
+
+	rings := make([]string, len(ringMeters))
+	for i, ring := range ringMeters {
+		rings[i] = "($" + strconv.Itoa(len(args)+1) + "::int)"
+		args = append(args, ring)
+	}
+
+	SQL := `SELECT b.id, r.ring_meters, COALESCE(p.category_id, 0), COALESCE(c.name, ''),
+		COALESCE(p.mother_brand_id, 0), COALESCE(mb.name, ''), COUNT(*)
+		FROM ` + models.BuildingTable + ` b
+		CROSS JOIN (VALUES ` + strings.Join(rings, ",") + `) AS r(ring_meters)
+		INNER JOIN ` + models.POIPointTable + ` pp ON pp.location IS NOT NULL AND ST_DWithin(b.location, pp.location, r.ring_meters)
+		INNER JOIN ` + models.POITable + ` p ON p.id = pp.poi_id AND p.deleted_at IS NULL
+		LEFT JOIN ` + models.CategoryTable + ` c ON c.id = p.category_id
+		LEFT JOIN ` + models.MotherBrandTable + ` mb ON mb.id = p.mother_brand_id
+		WHERE b.id IN (` + placeholders(buildingIds) + `) AND b.location IS NOT NULL`
+	if len(categoryIds) > 0 {
+		SQL += ` AND p.category_id IN (` + placeholders(categoryIds) + `)`
+	}
+	if len(motherBrandIds) > 0 {
+		SQL += ` AND p.mother_brand_id IN (` + placeholders(motherBrandIds) + `)`
+	}
+	SQL += ` GROUP BY b.id, r.ring_meters, p.category_id, c.name, p.mother_brand_id, mb.name
+		ORDER BY b.id, r.ring_meters`
+
+	rows, err := tx.QueryContext(ctx, SQL, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := []POIDensityRow{}
+	for rows.Next() {
+		var row POIDensityRow
+		if err := rows.Scan(&row.BuildingId, &row.RingMeters, &row.CategoryId, &row.CategoryName, &row.MotherBrandId, &row.MotherBrandName, &row.Count); err != nil {
+			return nil, err
+		}
+		result = append(result, row)
+	}
+	return result, rows.Err()
+}
+
 // GetLCDPresenceSummary returns building counts grouped by citytown and lcd_presence_status
 func (repository *RepositoryBuildingImpl) GetLCDPresenceSummary(ctx context.Context, tx *sql.Tx) ([]LCDPresenceCountRow, error) {
 	SQL := `
@@ -1024,6 +1315,76 @@ func (repository *RepositoryBuildingImpl) FindAllDropdown(ctx context.Context, t
 	return buildings, rows.Err()
 }
 
+// catchmentEdgeBufferMeters widens each isochrone hull so buildings set back from the
+// last reachable road vertex are still included.
+const catchmentEdgeBufferMeters = 100
+
+// metersPerDegree is the length of one degree of latitude, used to turn a reach in meters into a
+// bounding box in degrees
+const metersPerDegree = 111320.0
+
+// buildCatchmentCondition builds an isochrone filter on b.location. originsSQL must select (lng, lat)
+// rows; for every origin the nearest road vertex is found by KNN, pgr_drivingDistance collects the
+// vertices reachable within travelSeconds, and their concave hull (buffered) becomes that origin's
+// catchment. The per-origin catchments are unioned. Drive mode honours one-way streets
+// (cost_s/reverse_cost_s); walk mode ignores direction and derives cost from length_m at
+// WalkingSpeedMetersPerSecond. Each origin only searches the edges inside a box around it that
+// the mode cannot leave within travelSeconds (at MaxDrivingSpeedMetersPerSecond when driving),
+// instead of the whole road network.
+func buildCatchmentCondition(originsSQL string, catchmentMode string, travelSeconds int, argIndex int) (string, []interface{}, int) {
+	edgesSQL := `SELECT gid AS id, source, target, cost_s AS cost, reverse_cost_s AS reverse_cost FROM ` + models.RoadNetworkEdgeTable
+	directed := "true"
+	speed := models.MaxDrivingSpeedMetersPerSecond
+	if catchmentMode == models.CatchmentModeWalk {
+		walkCost := `length_m / ` + strconv.FormatFloat(models.WalkingSpeedMetersPerSecond, 'f', -1, 64)
+		edgesSQL = `SELECT gid AS id, source, target, ` + walkCost + ` AS cost, ` + walkCost + ` AS reverse_cost FROM ` + models.RoadNetworkEdgeTable
+		directed = "false"
+		speed = models.WalkingSpeedMetersPerSecond
+	}
+	edgesSQL += ` WHERE the_geom && ST_MakeEnvelope(%s, %s, %s, %s, 4326)`
+
+	args := make([]interface{}, 0, 3)
+	secondsIndex := argIndex
+	bufferIndex := argIndex + 1
+	reachIndex := argIndex + 2
+	args = append(args, travelSeconds, catchmentEdgeBufferMeters, float64(travelSeconds)*speed/metersPerDegree)
+	argIndex += 3
+
+	cond := `ST_Intersects(b.location::geometry, (
+		SELECT ST_Union(iso.geom) FROM (
+			SELECT ST_Buffer(ST_ConcaveHull(ST_Collect(v.the_geom), 0.5)::geography, $` + strconv.Itoa(bufferIndex) + `)::geometry AS geom
+			FROM (` + originsSQL + `) AS o
+			CROSS JOIN LATERAL (
+				SELECT $` + strconv.Itoa(reachIndex) + `::DOUBLE PRECISION AS dy,
+					$` + strconv.Itoa(reachIndex) + `::DOUBLE PRECISION / cos(radians(o.lat)) AS dx
+			) reach
+			CROSS JOIN LATERAL (
+				SELECT id FROM ` + models.RoadNetworkVertexTable + `
+				ORDER BY the_geom <-> ST_SetSRID(ST_MakePoint(o.lng, o.lat), 4326) LIMIT 1
+			) start_vertex
+			CROSS JOIN LATERAL pgr_drivingDistance(
+				format('` + edgesSQL + `', o.lng - reach.dx, o.lat - reach.dy, o.lng + reach.dx, o.lat + reach.dy),
+				start_vertex.id, $` + strconv.Itoa(secondsIndex) + `::DOUBLE PRECISION, ` + directed + `
+			) dd
+			JOIN ` + models.RoadNetworkVertexTable + ` v ON v.id = dd.node
+			GROUP BY o.lng, o.lat
+		) iso
+	))`
+	return cond, args, argIndex
+}
+
+// buildIntInPlaceholders returns "$n, $n+1, ..." for ids along with the matching args and next index.
+func buildIntInPlaceholders(ids []int, argIndex int) (string, []interface{}, int) {
+	placeholders := make([]string, len(ids))
+	args := make([]interface{}, len(ids))
+	for i, id := range ids {
+		placeholders[i] = "$" + strconv.Itoa(argIndex)
+		args[i] = id
+		argIndex++
+	}
+	return strings.Join(placeholders, ", "), args, argIndex
+}
+
 // buildNotInIntCondition builds a SQL NOT IN condition for an integer column with comma-separated values.
 // Non-integer tokens are skipped. Returns empty cond when no valid ids are found.
 func buildNotInIntCondition(column string, value string, argIndex int) (string, []interface{}, int) {
@@ -0,0 +1,59 @@
+package building_test
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	repositoriesBuilding "github.com/malikabdulaziz/tmn-backend/repositories/building"
+	"github.com/malikabdulaziz/tmn-backend/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestFindAllForMapping_PrivateSalesPackageOfAnotherUser verifies that filtering by a sales package
+// only matches packages the caller may see: user 7 asks for package 12, which is private to another
+// user, so the package join is limited to visible live packages and nothing comes back.
+func TestFindAllForMapping_PrivateSalesPackageOfAnotherUser(t *testing.T) {
+	db, sqlMock := testutil.NewMockDB(t)
+	repo := repositoriesBuilding.NewRepositoryBuildingImpl()
+
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectQuery(regexp.QuoteMeta(`INNER JOIN sales_packages sp ON sp.id = spb.sales_package_id AND sp.deleted_at IS NULL
+			AND (sp.visibility = 'public' OR sp.created_by = $1`)).
+		WithArgs(7, "12").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	sqlMock.ExpectCommit()
+
+	tx, err := db.Begin()
+	assert.NoError(t, err)
+	buildings, err := repo.FindAllForMapping(context.Background(), tx, 7, "", "", "", "", "", "", "", "", "12", "", "", "", "",
+		nil, nil, nil, "", 0, nil, nil, nil, nil, nil, nil)
+	assert.NoError(t, err)
+	assert.NoError(t, tx.Commit())
+
+	assert.Empty(t, buildings)
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+}
+
+// TestFindAllForMapping_RestrictionOfAnotherUserIgnored verifies that excluding the buildings of a
+// restriction only uses restrictions the caller may see.
+func TestFindAllForMapping_RestrictionOfAnotherUserIgnored(t *testing.T) {
+	db, sqlMock := testutil.NewMockDB(t)
+	repo := repositoriesBuilding.NewRepositoryBuildingImpl()
+
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectQuery(regexp.QuoteMeta(`INNER JOIN building_restrictions br ON br.id = brb.building_restriction_id AND br.deleted_at IS NULL
+			AND (br.visibility = 'public' OR br.created_by = $1`)).
+		WithArgs(7, "4").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	sqlMock.ExpectCommit()
+
+	tx, err := db.Begin()
+	assert.NoError(t, err)
+	_, err = repo.FindAllForMapping(context.Background(), tx, 7, "", "", "", "", "", "", "", "", "", "4", "", "", "",
+		nil, nil, nil, "", 0, nil, nil, nil, nil, nil, nil)
+	assert.NoError(t, err)
+	assert.NoError(t, tx.Commit())
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+}
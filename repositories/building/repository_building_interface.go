@@ -14,6 +14,34 @@ type LCDPresenceCountRow struct {
 	Count             int
 }
 
+// NearestPOIPointRow holds the closest POI point (of the requested POIs) to a building
+type NearestPOIPointRow struct {
+	BuildingId     int
+	POIPointId     int
+	POIId          int
+	POIName        string
+	Brand          string
+	DistanceMeters float64
+}
+
+// NearestBuildingRow is a building with its distance from a reference coordinate
+type NearestBuildingRow struct {
+	Building       models.Building
+	DistanceMeters float64
+}
+
+// POIDensityRow counts the POI points of one category / mother brand pair within a ring around a building.
+// CategoryId and MotherBrandId are 0 for POIs without one.
+type POIDensityRow struct {
+	BuildingId      int
+	RingMeters      int
+	CategoryId      int
+	CategoryName    string
+	MotherBrandId   int
+	MotherBrandName string
+	Count           int
+}
+
 type RepositoryBuildingInterface interface {
 	Create(ctx context.Context, tx *sql.Tx, building models.Building) (models.Building, error)
 	FindById(ctx context.Context, tx *sql.Tx, id int) (models.Building, error)
@@ -23,9 +51,14 @@ type RepositoryBuildingInterface interface {
 	GetDistinctValues(ctx context.Context, tx *sql.Tx, columnName string) ([]string, error)
 	Update(ctx context.Context, tx *sql.Tx, building models.Building) (models.Building, error)
 	UpdateFromSync(ctx context.Context, tx *sql.Tx, building models.Building) (models.Building, error)
-	FindAllForMapping(ctx context.Context, tx *sql.Tx, buildingType string, buildingGrade string, year string, subdistrict string, progress string, sellable string, connectivity string, lcdPresence string, salesPackageIds string, buildingRestrictionIds string, lat *float64, lng *float64, radius *int, poiPoints []struct{ Lat float64; Lng float64 }, polygonPoints []struct{ Lat float64; Lng float64 }, minLat *float64, maxLat *float64, minLng *float64, maxLng *float64) ([]models.Building, error)
+	FindAllForMapping(ctx context.Context, tx *sql.Tx, userId int, buildingType string, buildingGrade string, year string, subdistrict string, progress string, sellable string, connectivity string, lcdPresence string, salesPackageIds string, buildingRestrictionIds string, provinceCodes string, cityCodes string, subdistrictCodes string, lat *float64, lng *float64, radius *int, catchmentMode string, travelSeconds int, poiIds []int, polygonPoints []struct{ Lat float64; Lng float64 }, minLat *float64, maxLat *float64, minLng *float64, maxLng *float64) ([]models.Building, error)
 	FindByIds(ctx context.Context, tx *sql.Tx, ids []int) ([]models.Building, error)
 	GetLCDPresenceSummary(ctx context.Context, tx *sql.Tx) ([]LCDPresenceCountRow, error)
 	FindAllDropdown(ctx context.Context, tx *sql.Tx) ([]models.Building, error)
+	AssignAdminBoundaries(ctx context.Context, tx *sql.Tx) (int, error)
+	FindNearestPOIPoints(ctx context.Context, tx *sql.Tx, buildingIds []int, poiIds []int) ([]NearestPOIPointRow, error)
+	FindNearest(ctx context.Context, tx *sql.Tx, lat float64, lng float64, limit int, buildingType string) ([]NearestBuildingRow, error)
+	FindDistancesToPoint(ctx context.Context, tx *sql.Tx, buildingIds []int, lat float64, lng float64) (map[int]float64, error)
+	CountPOIPointsInRings(ctx context.Context, tx *sql.Tx, buildingIds []int, ringMeters []int, categoryIds []int, motherBrandIds []int) ([]POIDensityRow, error)
 }
 
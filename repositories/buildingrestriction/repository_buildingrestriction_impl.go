@@ -30,10 +30,26 @@ func safeOrder(orderBy, orderDirection string) (string, string) {
 	return orderBy, orderDirection
 }
 
-// Create inserts a new building restriction and its building links
+const restrictionCols = `id, name, enforcement, to_char(valid_from, 'YYYY-MM-DD'), to_char(valid_until, 'YYYY-MM-DD'),
+	created_by, updated_by, visibility, created_at, updated_at`
+
+func scanRestriction(scanner interface{ Scan(...interface{}) error }) (models.BuildingRestriction, error) {
+	var n models.NullAbleBuildingRestriction
+	if err := scanner.Scan(&n.Id, &n.Name, &n.Enforcement, &n.ValidFrom, &n.ValidUntil, &n.CreatedBy, &n.UpdatedBy, &n.Visibility,
+		&n.CreatedAt, &n.UpdatedAt); err != nil {
+		return models.BuildingRestriction{}, err
+	}
+	return models.NullAbleBuildingRestrictionToBuildingRestriction(n), nil
+}
+
+// Create inserts a new building restriction owned by restriction.CreatedBy with its building links
+// and the category and mother brand rules carried on restriction
 func (r *RepositoryBuildingRestrictionImpl) Create(ctx context.Context, tx *sql.Tx, restriction models.BuildingRestriction, buildingIds []int) (models.BuildingRestriction, error) {
-	SQL := `INSERT INTO ` + models.BuildingRestrictionTable + ` (name) VALUES ($1) RETURNING id, created_at, updated_at`
-	err := tx.QueryRowContext(ctx, SQL, restriction.Name).Scan(&restriction.Id, &restriction.CreatedAt, &restriction.UpdatedAt)
+	SQL := `INSERT INTO ` + models.BuildingRestrictionTable + ` (name, enforcement, valid_from, valid_until, created_by, updated_by, visibility)
+		VALUES ($1, $2, NULLIF($3, '')::date, NULLIF($4, '')::date, $5, $5, $6) RETURNING id, created_at, updated_at`
+	err := tx.QueryRowContext(ctx, SQL, restriction.Name, enforcementOrDefault(restriction.Enforcement), restriction.ValidFrom, restriction.ValidUntil,
+		restriction.CreatedBy, restriction.Visibility).
+		Scan(&restriction.Id, &restriction.CreatedAt, &restriction.UpdatedAt)
 	if err != nil {
 		return models.BuildingRestriction{}, err
 	}
@@ -42,21 +58,44 @@ func (r *RepositoryBuildingRestrictionImpl) Create(ctx context.Context, tx *sql.
 			return models.BuildingRestriction{}, err
 		}
 	}
-	// Load building refs for response
-	buildings, err := r.findBuildingRefsByBuildingRestrictionId(ctx, tx, restriction.Id)
-	if err != nil {
+	if err := r.insertRules(ctx, tx, restriction); err != nil {
 		return models.BuildingRestriction{}, err
 	}
-	restriction.Buildings = buildings
-	return restriction, nil
+	return r.FindById(ctx, tx, restriction.Id)
+}
+
+func enforcementOrDefault(enforcement string) string {
+	if enforcement == "" {
+		return models.RestrictionEnforcementWarn
+	}
+	return enforcement
+}
+
+// insertRules links the restriction's categories and mother brands
+func (r *RepositoryBuildingRestrictionImpl) insertRules(ctx context.Context, tx *sql.Tx, restriction models.BuildingRestriction) error {
+	categorySQL := `INSERT INTO ` + models.BuildingRestrictionCategoryTable + ` (building_restriction_id, category_id) VALUES ($1, $2)`
+	for _, c := range restriction.Categories {
+		if _, err := tx.ExecContext(ctx, categorySQL, restriction.Id, c.Id); err != nil {
+			return err
+		}
+	}
+	motherBrandSQL := `INSERT INTO ` + models.BuildingRestrictionMotherBrandTable + ` (building_restriction_id, mother_brand_id) VALUES ($1, $2)`
+	for _, mb := range restriction.MotherBrands {
+		if _, err := tx.ExecContext(ctx, motherBrandSQL, restriction.Id, mb.Id); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
-// FindAll retrieves all building restrictions with pagination and ordering; loads building refs per restriction
-func (r *RepositoryBuildingRestrictionImpl) FindAll(ctx context.Context, tx *sql.Tx, take int, skip int, orderBy string, orderDirection string) ([]models.BuildingRestriction, error) {
+// FindAll retrieves the building restrictions userId may see with pagination and ordering; loads
+// building refs per restriction
+func (r *RepositoryBuildingRestrictionImpl) FindAll(ctx context.Context, tx *sql.Tx, userId int, take int, skip int, orderBy string, orderDirection string) ([]models.BuildingRestriction, error) {
 	orderBy, orderDirection = safeOrder(orderBy, orderDirection)
-	SQL := `SELECT id, name, created_at, updated_at FROM ` + models.BuildingRestrictionTable + `
-		ORDER BY ` + orderBy + ` ` + orderDirection + `, name ASC LIMIT $1 OFFSET $2`
-	rows, err := tx.QueryContext(ctx, SQL, take, skip)
+	SQL := `SELECT ` + restrictionCols + ` FROM ` + models.BuildingRestrictionTable + `
+		WHERE deleted_at IS NULL AND ` + models.VisibleToCondition(models.BuildingRestrictionTable, "$1") + `
+		ORDER BY ` + orderBy + ` ` + orderDirection + `, name ASC LIMIT $2 OFFSET $3`
+	rows, err := tx.QueryContext(ctx, SQL, userId, take, skip)
 	if err != nil {
 		return nil, err
 	}
@@ -65,11 +104,10 @@ func (r *RepositoryBuildingRestrictionImpl) FindAll(ctx context.Context, tx *sql
 	var restrictions []models.BuildingRestriction
 	var ids []int
 	for rows.Next() {
-		var n models.NullAbleBuildingRestriction
-		if err := rows.Scan(&n.Id, &n.Name, &n.CreatedAt, &n.UpdatedAt); err != nil {
+		restriction, err := scanRestriction(rows)
+		if err != nil {
 			return nil, err
 		}
-		restriction := models.NullAbleBuildingRestrictionToBuildingRestriction(n)
 		ids = append(ids, restriction.Id)
 		restrictions = append(restrictions, restriction)
 	}
@@ -86,38 +124,54 @@ func (r *RepositoryBuildingRestrictionImpl) FindAll(ctx context.Context, tx *sql
 	for i := range restrictions {
 		restrictions[i].Buildings = refsMap[restrictions[i].Id]
 	}
+	if err := r.loadRules(ctx, tx, restrictions); err != nil {
+		return nil, err
+	}
 	return restrictions, nil
 }
 
-// CountAll returns total count of building restrictions
-func (r *RepositoryBuildingRestrictionImpl) CountAll(ctx context.Context, tx *sql.Tx) (int, error) {
-	SQL := `SELECT COUNT(*) FROM ` + models.BuildingRestrictionTable
+// CountAll returns total count of building restrictions userId may see
+func (r *RepositoryBuildingRestrictionImpl) CountAll(ctx context.Context, tx *sql.Tx, userId int) (int, error) {
+	SQL := `SELECT COUNT(*) FROM ` + models.BuildingRestrictionTable + ` WHERE deleted_at IS NULL AND ` + models.VisibleToCondition(models.BuildingRestrictionTable, "$1")
 	var total int
-	err := tx.QueryRowContext(ctx, SQL).Scan(&total)
+	err := tx.QueryRowContext(ctx, SQL, userId).Scan(&total)
 	return total, err
 }
 
 // FindById retrieves a building restriction by ID with its building refs
 func (r *RepositoryBuildingRestrictionImpl) FindById(ctx context.Context, tx *sql.Tx, id int) (models.BuildingRestriction, error) {
-	SQL := `SELECT id, name, created_at, updated_at FROM ` + models.BuildingRestrictionTable + ` WHERE id = $1`
-	row := tx.QueryRowContext(ctx, SQL, id)
-	var n models.NullAbleBuildingRestriction
-	if err := row.Scan(&n.Id, &n.Name, &n.CreatedAt, &n.UpdatedAt); err != nil {
+	SQL := `SELECT ` + restrictionCols + ` FROM ` + models.BuildingRestrictionTable + ` WHERE id = $1 AND deleted_at IS NULL`
+	restriction, err := scanRestriction(tx.QueryRowContext(ctx, SQL, id))
+	if err != nil {
 		return models.BuildingRestriction{}, err
 	}
-	restriction := models.NullAbleBuildingRestrictionToBuildingRestriction(n)
 	buildings, err := r.findBuildingRefsByBuildingRestrictionId(ctx, tx, restriction.Id)
 	if err != nil {
 		return models.BuildingRestriction{}, err
 	}
 	restriction.Buildings = buildings
-	return restriction, nil
+	restrictions := []models.BuildingRestriction{restriction}
+	if err := r.loadRules(ctx, tx, restrictions); err != nil {
+		return models.BuildingRestriction{}, err
+	}
+	return restrictions[0], nil
+}
+
+// FindAccess tells whether userId may see and change a building restriction; sql.ErrNoRows when it does not exist or is in the trash
+func (r *RepositoryBuildingRestrictionImpl) FindAccess(ctx context.Context, tx *sql.Tx, id int, userId int) (models.Access, error) {
+	SQL := `SELECT ` + models.VisibleToCondition(models.BuildingRestrictionTable, "$2") + `, ` + models.EditableByCondition(models.BuildingRestrictionTable, "$2") + `
+		FROM ` + models.BuildingRestrictionTable + ` WHERE id = $1 AND deleted_at IS NULL`
+	var access models.Access
+	err := tx.QueryRowContext(ctx, SQL, id, userId).Scan(&access.Visible, &access.Editable)
+	return access, err
 }
 
-// Update updates name and replaces building links
+// Update updates name, enforcement, validity, visibility and updated_by and replaces building links and rules
 func (r *RepositoryBuildingRestrictionImpl) Update(ctx context.Context, tx *sql.Tx, restriction models.BuildingRestriction, buildingIds []int) (models.BuildingRestriction, error) {
-	SQL := `UPDATE ` + models.BuildingRestrictionTable + ` SET name = $1, updated_at = $2 WHERE id = $3 RETURNING updated_at`
-	err := tx.QueryRowContext(ctx, SQL, restriction.Name, time.Now(), restriction.Id).Scan(&restriction.UpdatedAt)
+	SQL := `UPDATE ` + models.BuildingRestrictionTable + ` SET name = $1, enforcement = $2, valid_from = NULLIF($3, '')::date,
+		valid_until = NULLIF($4, '')::date, visibility = $5, updated_by = $6, updated_at = $7 WHERE id = $8`
+	_, err := tx.ExecContext(ctx, SQL, restriction.Name, enforcementOrDefault(restriction.Enforcement), restriction.ValidFrom,
+		restriction.ValidUntil, restriction.Visibility, restriction.UpdatedBy, time.Now(), restriction.Id)
 	if err != nil {
 		return models.BuildingRestriction{}, err
 	}
@@ -129,12 +183,15 @@ func (r *RepositoryBuildingRestrictionImpl) Update(ctx context.Context, tx *sql.
 			return models.BuildingRestriction{}, err
 		}
 	}
-	buildings, err := r.findBuildingRefsByBuildingRestrictionId(ctx, tx, restriction.Id)
-	if err != nil {
+	for _, table := range []string{models.BuildingRestrictionCategoryTable, models.BuildingRestrictionMotherBrandTable} {
+		if _, err := tx.ExecContext(ctx, `DELETE FROM `+table+` WHERE building_restriction_id = $1`, restriction.Id); err != nil {
+			return models.BuildingRestriction{}, err
+		}
+	}
+	if err := r.insertRules(ctx, tx, restriction); err != nil {
 		return models.BuildingRestriction{}, err
 	}
-	restriction.Buildings = buildings
-	return restriction, nil
+	return r.FindById(ctx, tx, restriction.Id)
 }
 
 // DeleteBuildingLinksByBuildingRestrictionId removes all building links for a restriction
@@ -151,25 +208,19 @@ func (r *RepositoryBuildingRestrictionImpl) CreateBuildingLink(ctx context.Conte
 	return err
 }
 
-// Delete deletes a building restriction (CASCADE removes junction rows)
-func (r *RepositoryBuildingRestrictionImpl) Delete(ctx context.Context, tx *sql.Tx, id int) error {
-	SQL := `DELETE FROM ` + models.BuildingRestrictionTable + ` WHERE id = $1`
-	_, err := tx.ExecContext(ctx, SQL, id)
+// Delete moves a building restriction to the trash; its building links and rules stay until it is purged
+func (r *RepositoryBuildingRestrictionImpl) Delete(ctx context.Context, tx *sql.Tx, id int, deletedBy *int) error {
+	SQL := `UPDATE ` + models.BuildingRestrictionTable + ` SET deleted_at = $1, deleted_by = $2 WHERE id = $3 AND deleted_at IS NULL`
+	_, err := tx.ExecContext(ctx, SQL, time.Now(), deletedBy, id)
 	return err
 }
 
-// FindAllFlat returns all building restrictions with their buildings, optionally filtered by name search (no pagination)
-func (r *RepositoryBuildingRestrictionImpl) FindAllFlat(ctx context.Context, tx *sql.Tx, search string) ([]models.BuildingRestriction, error) {
-	var rows *sql.Rows
-	var err error
-
-	if search != "" {
-		SQL := `SELECT id, name, created_at, updated_at FROM ` + models.BuildingRestrictionTable + ` WHERE name ILIKE $1 ORDER BY name`
-		rows, err = tx.QueryContext(ctx, SQL, "%"+search+"%")
-	} else {
-		SQL := `SELECT id, name, created_at, updated_at FROM ` + models.BuildingRestrictionTable + ` ORDER BY name`
-		rows, err = tx.QueryContext(ctx, SQL)
-	}
+// FindAllFlat returns all building restrictions userId may see with their buildings, optionally filtered by name search (no pagination)
+func (r *RepositoryBuildingRestrictionImpl) FindAllFlat(ctx context.Context, tx *sql.Tx, userId int, search string) ([]models.BuildingRestriction, error) {
+	SQL := `SELECT ` + restrictionCols + ` FROM ` + models.BuildingRestrictionTable + `
+		WHERE deleted_at IS NULL AND ` + models.VisibleToCondition(models.BuildingRestrictionTable, "$1") + ` AND ($2 = '' OR name ILIKE '%' || $2 || '%')
+		ORDER BY name`
+	rows, err := tx.QueryContext(ctx, SQL, userId, search)
 	if err != nil {
 		return nil, err
 	}
@@ -178,11 +229,10 @@ func (r *RepositoryBuildingRestrictionImpl) FindAllFlat(ctx context.Context, tx
 	var restrictions []models.BuildingRestriction
 	var ids []int
 	for rows.Next() {
-		var n models.NullAbleBuildingRestriction
-		if err := rows.Scan(&n.Id, &n.Name, &n.CreatedAt, &n.UpdatedAt); err != nil {
+		restriction, err := scanRestriction(rows)
+		if err != nil {
 			return nil, err
 		}
-		restriction := models.NullAbleBuildingRestrictionToBuildingRestriction(n)
 		ids = append(ids, restriction.Id)
 		restrictions = append(restrictions, restriction)
 	}
@@ -199,10 +249,14 @@ func (r *RepositoryBuildingRestrictionImpl) FindAllFlat(ctx context.Context, tx
 	for i := range restrictions {
 		restrictions[i].Buildings = refsMap[restrictions[i].Id]
 	}
+	if err := r.loadRules(ctx, tx, restrictions); err != nil {
+		return nil, err
+	}
 	return restrictions, nil
 }
 
-// FindByNames returns building restrictions whose name matches any in the given list
+// FindByNames returns building restrictions whose name matches any in the given list, with their
+// category and mother brand rules (building refs are not loaded)
 func (r *RepositoryBuildingRestrictionImpl) FindByNames(ctx context.Context, tx *sql.Tx, names []string) ([]models.BuildingRestriction, error) {
 	if len(names) == 0 {
 		return nil, nil
@@ -213,7 +267,7 @@ func (r *RepositoryBuildingRestrictionImpl) FindByNames(ctx context.Context, tx
 		placeholders[i] = "$" + strconv.Itoa(i+1)
 		args[i] = name
 	}
-	SQL := `SELECT id, name, created_at, updated_at FROM ` + models.BuildingRestrictionTable + ` WHERE name IN (` + strings.Join(placeholders, ",") + `)`
+	SQL := `SELECT ` + restrictionCols + ` FROM ` + models.BuildingRestrictionTable + ` WHERE deleted_at IS NULL AND name IN (` + strings.Join(placeholders, ",") + `)`
 	rows, err := tx.QueryContext(ctx, SQL, args...)
 	if err != nil {
 		return nil, err
@@ -222,13 +276,112 @@ func (r *RepositoryBuildingRestrictionImpl) FindByNames(ctx context.Context, tx
 
 	var restrictions []models.BuildingRestriction
 	for rows.Next() {
-		var n models.NullAbleBuildingRestriction
-		if err := rows.Scan(&n.Id, &n.Name, &n.CreatedAt, &n.UpdatedAt); err != nil {
+		restriction, err := scanRestriction(rows)
+		if err != nil {
+			return nil, err
+		}
+		restrictions = append(restrictions, restriction)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if err := r.loadRules(ctx, tx, restrictions); err != nil {
+		return nil, err
+	}
+	return restrictions, nil
+}
+
+// FindViolations returns one row per restricted building, active restriction and matching rule
+// where the advertiser in check is barred. A zero CategoryId or MotherBrandId matches nothing.
+func (r *RepositoryBuildingRestrictionImpl) FindViolations(ctx context.Context, tx *sql.Tx, check RestrictionCheck) ([]RestrictionViolationRow, error) {
+	if len(check.BuildingIds) == 0 || (check.CategoryId == 0 && check.MotherBrandId == 0) {
+		return []RestrictionViolationRow{}, nil
+	}
+	placeholders := make([]string, len(check.BuildingIds))
+	args := []interface{}{check.CategoryId, check.MotherBrandId, check.From, check.Until}
+	for i, id := range check.BuildingIds {
+		placeholders[i] = "$" + strconv.Itoa(len(args)+1)
+		args = append(args, id)
+	}
+	// A restriction is active when it is not in the trash and its validity overlaps [from, until]; an
+	// empty until is open-ended
+	active := `r.deleted_at IS NULL AND (r.valid_from IS NULL OR NULLIF($4, '')::date IS NULL OR r.valid_from <= NULLIF($4, '')::date)
+		AND (r.valid_until IS NULL OR r.valid_until >= $3::date)
+		AND brb.building_id IN (` + strings.Join(placeholders, ",") + `)`
+	SQL := `SELECT r.id, r.name, r.enforcement, b.id, b.name, 'category', c.name
+		FROM ` + models.BuildingRestrictionTable + ` r
+		INNER JOIN ` + models.BuildingRestrictionBuildingTable + ` brb ON brb.building_restriction_id = r.id
+		INNER JOIN ` + models.BuildingTable + ` b ON b.id = brb.building_id
+		INNER JOIN ` + models.BuildingRestrictionCategoryTable + ` brc ON brc.building_restriction_id = r.id
+		INNER JOIN ` + models.CategoryTable + ` c ON c.id = brc.category_id
+		WHERE brc.category_id = $1 AND ` + active + `
+		UNION ALL
+		SELECT r.id, r.name, r.enforcement, b.id, b.name, 'mother_brand', mb.name
+		FROM ` + models.BuildingRestrictionTable + ` r
+		INNER JOIN ` + models.BuildingRestrictionBuildingTable + ` brb ON brb.building_restriction_id = r.id
+		INNER JOIN ` + models.BuildingTable + ` b ON b.id = brb.building_id
+		INNER JOIN ` + models.BuildingRestrictionMotherBrandTable + ` brm ON brm.building_restriction_id = r.id
+		INNER JOIN ` + models.MotherBrandTable + ` mb ON mb.id = brm.mother_brand_id
+		WHERE brm.mother_brand_id = $2 AND ` + active + `
+		ORDER BY 2, 5, 6`
+	rows, err := tx.QueryContext(ctx, SQL, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := []RestrictionViolationRow{}
+	for rows.Next() {
+		var row RestrictionViolationRow
+		if err := rows.Scan(&row.RestrictionId, &row.RestrictionName, &row.Enforcement, &row.BuildingId, &row.BuildingName,
+			&row.Reason, &row.AdvertiserName); err != nil {
 			return nil, err
 		}
-		restrictions = append(restrictions, models.NullAbleBuildingRestrictionToBuildingRestriction(n))
+		result = append(result, row)
+	}
+	return result, rows.Err()
+}
+
+// loadRules fills Categories and MotherBrands on each restriction in place
+func (r *RepositoryBuildingRestrictionImpl) loadRules(ctx context.Context, tx *sql.Tx, restrictions []models.BuildingRestriction) error {
+	if len(restrictions) == 0 {
+		return nil
+	}
+	index := make(map[int]int, len(restrictions))
+	placeholders := make([]string, len(restrictions))
+	args := make([]interface{}, len(restrictions))
+	for i, restriction := range restrictions {
+		index[restriction.Id] = i
+		placeholders[i] = "$" + strconv.Itoa(i+1)
+		args[i] = restriction.Id
+	}
+	SQL := `SELECT brc.building_restriction_id, 'category', c.id, c.name FROM ` + models.BuildingRestrictionCategoryTable + ` brc
+		INNER JOIN ` + models.CategoryTable + ` c ON c.id = brc.category_id
+		WHERE brc.building_restriction_id IN (` + strings.Join(placeholders, ",") + `)
+		UNION ALL
+		SELECT brm.building_restriction_id, 'mother_brand', mb.id, mb.name FROM ` + models.BuildingRestrictionMotherBrandTable + ` brm
+		INNER JOIN ` + models.MotherBrandTable + ` mb ON mb.id = brm.mother_brand_id
+		WHERE brm.building_restriction_id IN (` + strings.Join(placeholders, ",") + `)
+		ORDER BY 1, 2, 4`
+	rows, err := tx.QueryContext(ctx, SQL, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var restrictionId, id int
+		var kind, name string
+		if err := rows.Scan(&restrictionId, &kind, &id, &name); err != nil {
+			return err
+		}
+		restriction := &restrictions[index[restrictionId]]
+		if kind == "category" {
+			restriction.Categories = append(restriction.Categories, models.Category{Id: id, Name: name})
+		} else {
+			restriction.MotherBrands = append(restriction.MotherBrands, models.MotherBrand{Id: id, Name: name})
+		}
 	}
-	return restrictions, rows.Err()
+	return rows.Err()
 }
 
 // findBuildingRefsByBuildingRestrictionId returns enriched building ref rows for a restriction
@@ -7,15 +7,39 @@ import (
 	"github.com/malikabdulaziz/tmn-backend/models"
 )
 
+// RestrictionCheck asks which active restrictions bar an advertiser from the given buildings
+// between From and Until (YYYY-MM-DD; an empty Until is open-ended)
+type RestrictionCheck struct {
+	BuildingIds   []int
+	CategoryId    int
+	MotherBrandId int
+	From          string
+	Until         string
+}
+
+// RestrictionViolationRow is one restriction barring the advertiser from one building. Reason is
+// "category" or "mother_brand"; AdvertiserName is the matching category or mother brand name.
+type RestrictionViolationRow struct {
+	RestrictionId   int
+	RestrictionName string
+	Enforcement     string
+	BuildingId      int
+	BuildingName    string
+	Reason          string
+	AdvertiserName  string
+}
+
 type RepositoryBuildingRestrictionInterface interface {
 	Create(ctx context.Context, tx *sql.Tx, restriction models.BuildingRestriction, buildingIds []int) (models.BuildingRestriction, error)
-	FindAll(ctx context.Context, tx *sql.Tx, take int, skip int, orderBy string, orderDirection string) ([]models.BuildingRestriction, error)
-	CountAll(ctx context.Context, tx *sql.Tx) (int, error)
+	FindAll(ctx context.Context, tx *sql.Tx, userId int, take int, skip int, orderBy string, orderDirection string) ([]models.BuildingRestriction, error)
+	CountAll(ctx context.Context, tx *sql.Tx, userId int) (int, error)
 	FindById(ctx context.Context, tx *sql.Tx, id int) (models.BuildingRestriction, error)
+	FindAccess(ctx context.Context, tx *sql.Tx, id int, userId int) (models.Access, error)
 	Update(ctx context.Context, tx *sql.Tx, restriction models.BuildingRestriction, buildingIds []int) (models.BuildingRestriction, error)
 	DeleteBuildingLinksByBuildingRestrictionId(ctx context.Context, tx *sql.Tx, buildingRestrictionId int) error
 	CreateBuildingLink(ctx context.Context, tx *sql.Tx, buildingRestrictionId int, buildingId int) error
-	Delete(ctx context.Context, tx *sql.Tx, id int) error
-	FindAllFlat(ctx context.Context, tx *sql.Tx, search string) ([]models.BuildingRestriction, error)
+	Delete(ctx context.Context, tx *sql.Tx, id int, deletedBy *int) error
+	FindAllFlat(ctx context.Context, tx *sql.Tx, userId int, search string) ([]models.BuildingRestriction, error)
 	FindByNames(ctx context.Context, tx *sql.Tx, names []string) ([]models.BuildingRestriction, error)
+	FindViolations(ctx context.Context, tx *sql.Tx, check RestrictionCheck) ([]RestrictionViolationRow, error)
 }
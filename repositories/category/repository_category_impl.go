@@ -38,10 +38,10 @@ func (r *RepositoryCategoryImpl) FindAll(ctx context.Context, tx *sql.Tx, take i
 	var rows *sql.Rows
 	var err error
 	if search != "" {
-		SQL := `SELECT id, name, created_at, updated_at FROM ` + models.CategoryTable + ` WHERE name ILIKE $1 ORDER BY ` + orderBy + ` ` + orderDirection + `, name ASC LIMIT $2 OFFSET $3`
+		SQL := `SELECT id, name, created_at, updated_at FROM ` + models.CategoryTable + ` WHERE deleted_at IS NULL AND name ILIKE $1 ORDER BY ` + orderBy + ` ` + orderDirection + `, name ASC LIMIT $2 OFFSET $3`
 		rows, err = tx.QueryContext(ctx, SQL, "%"+search+"%", take, skip)
 	} else {
-		SQL := `SELECT id, name, created_at, updated_at FROM ` + models.CategoryTable + ` ORDER BY ` + orderBy + ` ` + orderDirection + `, name ASC LIMIT $1 OFFSET $2`
+		SQL := `SELECT id, name, created_at, updated_at FROM ` + models.CategoryTable + ` WHERE deleted_at IS NULL ORDER BY ` + orderBy + ` ` + orderDirection + `, name ASC LIMIT $1 OFFSET $2`
 		rows, err = tx.QueryContext(ctx, SQL, take, skip)
 	}
 	if err != nil {
@@ -63,17 +63,17 @@ func (r *RepositoryCategoryImpl) CountAll(ctx context.Context, tx *sql.Tx, searc
 	var total int
 	var err error
 	if search != "" {
-		SQL := `SELECT COUNT(*) FROM ` + models.CategoryTable + ` WHERE name ILIKE $1`
+		SQL := `SELECT COUNT(*) FROM ` + models.CategoryTable + ` WHERE deleted_at IS NULL AND name ILIKE $1`
 		err = tx.QueryRowContext(ctx, SQL, "%"+search+"%").Scan(&total)
 	} else {
-		SQL := `SELECT COUNT(*) FROM ` + models.CategoryTable
+		SQL := `SELECT COUNT(*) FROM ` + models.CategoryTable + ` WHERE deleted_at IS NULL`
 		err = tx.QueryRowContext(ctx, SQL).Scan(&total)
 	}
 	return total, err
 }
 
 func (r *RepositoryCategoryImpl) FindById(ctx context.Context, tx *sql.Tx, id int) (models.Category, error) {
-	SQL := `SELECT id, name, created_at, updated_at FROM ` + models.CategoryTable + ` WHERE id = $1`
+	SQL := `SELECT id, name, created_at, updated_at FROM ` + models.CategoryTable + ` WHERE id = $1 AND deleted_at IS NULL`
 	var n models.NullAbleCategory
 	err := tx.QueryRowContext(ctx, SQL, id).Scan(&n.Id, &n.Name, &n.CreatedAt, &n.UpdatedAt)
 	if err != nil {
@@ -83,19 +83,22 @@ func (r *RepositoryCategoryImpl) FindById(ctx context.Context, tx *sql.Tx, id in
 }
 
 func (r *RepositoryCategoryImpl) Update(ctx context.Context, tx *sql.Tx, category models.Category) (models.Category, error) {
-	SQL := `UPDATE ` + models.CategoryTable + ` SET name = $1, updated_at = $2 WHERE id = $3 RETURNING updated_at`
+	SQL := `UPDATE ` + models.CategoryTable + ` SET name = $1, updated_at = $2 WHERE id = $3 AND deleted_at IS NULL RETURNING updated_at`
 	err := tx.QueryRowContext(ctx, SQL, category.Name, time.Now(), category.Id).Scan(&category.UpdatedAt)
 	return category, err
 }
 
-func (r *RepositoryCategoryImpl) Delete(ctx context.Context, tx *sql.Tx, id int) error {
-	SQL := `DELETE FROM ` + models.CategoryTable + ` WHERE id = $1`
-	_, err := tx.ExecContext(ctx, SQL, id)
+// Delete moves a category to the trash; POIs keep pointing at it until it is purged
+func (r *RepositoryCategoryImpl) Delete(ctx context.Context, tx *sql.Tx, id int, deletedBy *int) error {
+	SQL := `UPDATE ` + models.CategoryTable + ` SET deleted_at = $1, deleted_by = $2 WHERE id = $3 AND deleted_at IS NULL`
+	_, err := tx.ExecContext(ctx, SQL, time.Now(), deletedBy, id)
 	return err
 }
 
+// FindByName retrieves a category by name, ignoring case, or the category a name was merged into
 func (r *RepositoryCategoryImpl) FindByName(ctx context.Context, tx *sql.Tx, name string) (models.Category, error) {
-	SQL := `SELECT id, name, created_at, updated_at FROM ` + models.CategoryTable + ` WHERE LOWER(name) = LOWER($1) LIMIT 1`
+	SQL := `SELECT id, name, created_at, updated_at FROM ` + models.CategoryTable + ` WHERE deleted_at IS NULL AND (LOWER(name) = LOWER($1) OR ` + models.AliasCondition(models.MasterDataTypeCategory, "id", "$1") + `)
+		ORDER BY LOWER(name) = LOWER($1) DESC LIMIT 1`
 	var n models.NullAbleCategory
 	err := tx.QueryRowContext(ctx, SQL, name).Scan(&n.Id, &n.Name, &n.CreatedAt, &n.UpdatedAt)
 	if err != nil {
@@ -105,7 +108,7 @@ func (r *RepositoryCategoryImpl) FindByName(ctx context.Context, tx *sql.Tx, nam
 }
 
 func (r *RepositoryCategoryImpl) FindAllDropdown(ctx context.Context, tx *sql.Tx) ([]models.Category, error) {
-	SQL := `SELECT id, name, created_at, updated_at FROM ` + models.CategoryTable + ` ORDER BY name ASC`
+	SQL := `SELECT id, name, created_at, updated_at FROM ` + models.CategoryTable + ` WHERE deleted_at IS NULL ORDER BY name ASC`
 	rows, err := tx.QueryContext(ctx, SQL)
 	if err != nil {
 		return nil, err
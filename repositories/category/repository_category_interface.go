@@ -13,7 +13,7 @@ type RepositoryCategoryInterface interface {
 	CountAll(ctx context.Context, tx *sql.Tx, search string) (int, error)
 	FindById(ctx context.Context, tx *sql.Tx, id int) (models.Category, error)
 	Update(ctx context.Context, tx *sql.Tx, category models.Category) (models.Category, error)
-	Delete(ctx context.Context, tx *sql.Tx, id int) error
+	Delete(ctx context.Context, tx *sql.Tx, id int, deletedBy *int) error
 	FindByName(ctx context.Context, tx *sql.Tx, name string) (models.Category, error)
 	FindAllDropdown(ctx context.Context, tx *sql.Tx) ([]models.Category, error)
 }
@@ -0,0 +1,47 @@
+package geocodecache
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/malikabdulaziz/tmn-backend/models"
+)
+
+// geocodeMissRetrySeconds is how long a cached miss is trusted before the provider is asked again
+const geocodeMissRetrySeconds = 7 * 24 * 60 * 60
+
+type RepositoryGeocodeCacheImpl struct{}
+
+func NewRepositoryGeocodeCacheImpl() RepositoryGeocodeCacheInterface {
+	return &RepositoryGeocodeCacheImpl{}
+}
+
+// Find returns a cached answer; misses older than a week yield sql.ErrNoRows so they are retried
+func (r *RepositoryGeocodeCacheImpl) Find(ctx context.Context, tx *sql.Tx, provider string, kind string, queryKey string) (models.GeocodeCache, error) {
+	SQL := `SELECT id, provider, kind, query_key, found, latitude, longitude, address, created_at
+		FROM ` + models.GeocodeCacheTable + `
+		WHERE provider = $1 AND kind = $2 AND query_key = $3
+			AND (found OR created_at > CURRENT_TIMESTAMP - make_interval(secs => $4))`
+	var n models.NullAbleGeocodeCache
+	err := tx.QueryRowContext(ctx, SQL, provider, kind, queryKey, geocodeMissRetrySeconds).
+		Scan(&n.Id, &n.Provider, &n.Kind, &n.QueryKey, &n.Found, &n.Latitude, &n.Longitude, &n.Address, &n.CreatedAt)
+	if err != nil {
+		return models.GeocodeCache{}, err
+	}
+	return models.NullAbleGeocodeCacheToGeocodeCache(n), nil
+}
+
+// Save stores an answer, replacing any earlier one for the same query
+func (r *RepositoryGeocodeCacheImpl) Save(ctx context.Context, tx *sql.Tx, entry models.GeocodeCache) error {
+	SQL := `INSERT INTO ` + models.GeocodeCacheTable + ` (provider, kind, query_key, found, latitude, longitude, address)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (provider, kind, query_key) DO UPDATE
+		SET found = EXCLUDED.found, latitude = EXCLUDED.latitude, longitude = EXCLUDED.longitude,
+			address = EXCLUDED.address, created_at = CURRENT_TIMESTAMP`
+	var lat, lng, address interface{}
+	if entry.Found {
+		lat, lng, address = entry.Latitude, entry.Longitude, entry.Address
+	}
+	_, err := tx.ExecContext(ctx, SQL, entry.Provider, entry.Kind, entry.QueryKey, entry.Found, lat, lng, address)
+	return err
+}
@@ -0,0 +1,13 @@
+package geocodecache
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/malikabdulaziz/tmn-backend/models"
+)
+
+type RepositoryGeocodeCacheInterface interface {
+	Find(ctx context.Context, tx *sql.Tx, provider string, kind string, queryKey string) (models.GeocodeCache, error)
+	Save(ctx context.Context, tx *sql.Tx, entry models.GeocodeCache) error
+}
@@ -0,0 +1,119 @@
+package importjob
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/malikabdulaziz/tmn-backend/models"
+)
+
+// importJobColumns is every column but file_bytes, which only ClaimNext reads
+const importJobColumns = `id, kind, file_type, options, status, progress_done, progress_total, cancel_requested,
+	imported_count, report, error, created_by, created_at, started_at, finished_at, updated_at`
+
+type RepositoryImportJobImpl struct{}
+
+func NewRepositoryImportJobImpl() RepositoryImportJobInterface {
+	return &RepositoryImportJobImpl{}
+}
+
+func scanImportJob(row *sql.Row, withFile bool) (models.ImportJob, error) {
+	var n models.NullAbleImportJob
+	dest := []interface{}{&n.Id, &n.Kind, &n.FileType, &n.Options, &n.Status, &n.ProgressDone, &n.ProgressTotal, &n.CancelRequested,
+		&n.ImportedCount, &n.Report, &n.Error, &n.CreatedBy, &n.CreatedAt, &n.StartedAt, &n.FinishedAt, &n.UpdatedAt}
+	if withFile {
+		dest = append(dest, &n.FileBytes)
+	}
+	if err := row.Scan(dest...); err != nil {
+		return models.ImportJob{}, err
+	}
+	return models.NullAbleImportJobToImportJob(n), nil
+}
+
+// Create stores an uploaded file as a queued job
+func (r *RepositoryImportJobImpl) Create(ctx context.Context, tx *sql.Tx, job models.ImportJob) (models.ImportJob, error) {
+	SQL := `INSERT INTO ` + models.ImportJobTable + ` (kind, file_type, file_bytes, options, status, created_by)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING ` + importJobColumns
+	if job.Options == "" {
+		job.Options = "{}"
+	}
+	var createdBy interface{}
+	if job.CreatedBy != nil {
+		createdBy = *job.CreatedBy
+	}
+	row := tx.QueryRowContext(ctx, SQL, job.Kind, job.FileType, job.FileBytes, job.Options, models.ImportJobStatusQueued, createdBy)
+	return scanImportJob(row, false)
+}
+
+func (r *RepositoryImportJobImpl) FindById(ctx context.Context, tx *sql.Tx, id int) (models.ImportJob, error) {
+	SQL := `SELECT ` + importJobColumns + ` FROM ` + models.ImportJobTable + ` WHERE id = $1`
+	return scanImportJob(tx.QueryRowContext(ctx, SQL, id), false)
+}
+
+// ClaimNext marks the oldest queued job as running and returns it with its file; sql.ErrNoRows
+// when the queue is empty. SKIP LOCKED lets several workers claim jobs concurrently.
+func (r *RepositoryImportJobImpl) ClaimNext(ctx context.Context, tx *sql.Tx) (models.ImportJob, error) {
+	SQL := `UPDATE ` + models.ImportJobTable + `
+		SET status = $1, started_at = CURRENT_TIMESTAMP, updated_at = CURRENT_TIMESTAMP
+		WHERE id = (
+			SELECT id FROM ` + models.ImportJobTable + `
+			WHERE status = $2
+			ORDER BY id
+			LIMIT 1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING ` + importJobColumns + `, file_bytes`
+	return scanImportJob(tx.QueryRowContext(ctx, SQL, models.ImportJobStatusRunning, models.ImportJobStatusQueued), true)
+}
+
+// UpdateProgress stores a running job's progress and returns whether a cancel was requested;
+// sql.ErrNoRows once the job is no longer running, e.g. because FailStale gave up on it
+func (r *RepositoryImportJobImpl) UpdateProgress(ctx context.Context, tx *sql.Tx, id int, done int, total int) (bool, error) {
+	SQL := `UPDATE ` + models.ImportJobTable + `
+		SET progress_done = $2, progress_total = $3, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $1 AND status = $4
+		RETURNING cancel_requested`
+	var cancelRequested bool
+	err := tx.QueryRowContext(ctx, SQL, id, done, total, models.ImportJobStatusRunning).Scan(&cancelRequested)
+	return cancelRequested, err
+}
+
+// RequestCancel flags a job for cancellation. A queued job is cancelled right away; a running
+// job is cancelled by the worker at its next batch. Finished jobs are left untouched.
+func (r *RepositoryImportJobImpl) RequestCancel(ctx context.Context, tx *sql.Tx, id int) (models.ImportJob, error) {
+	SQL := `UPDATE ` + models.ImportJobTable + `
+		SET cancel_requested = TRUE,
+			status = CASE WHEN status = $2 THEN $3 ELSE status END,
+			finished_at = CASE WHEN status = $2 THEN CURRENT_TIMESTAMP ELSE finished_at END,
+			file_bytes = CASE WHEN status = $2 THEN NULL ELSE file_bytes END,
+			updated_at = CURRENT_TIMESTAMP
+		WHERE id = $1 AND status IN ($2, $4)
+		RETURNING ` + importJobColumns
+	return scanImportJob(tx.QueryRowContext(ctx, SQL, id, models.ImportJobStatusQueued, models.ImportJobStatusCancelled, models.ImportJobStatusRunning), false)
+}
+
+// Finish records a running job's final status and result and drops the stored file. A job that
+// is no longer running, e.g. failed by FailStale, keeps the status it already has.
+func (r *RepositoryImportJobImpl) Finish(ctx context.Context, tx *sql.Tx, job models.ImportJob) error {
+	SQL := `UPDATE ` + models.ImportJobTable + `
+		SET status = $2, imported_count = $3, report = NULLIF($4, ''), error = NULLIF($5, ''),
+			file_bytes = NULL, finished_at = CURRENT_TIMESTAMP, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $1 AND status = $6`
+	_, err := tx.ExecContext(ctx, SQL, job.Id, job.Status, job.ImportedCount, job.Report, job.Error, models.ImportJobStatusRunning)
+	return err
+}
+
+// FailStale fails running jobs without progress for staleSeconds, i.e. whose worker died. Their
+// import transaction never committed, so nothing of them was written.
+func (r *RepositoryImportJobImpl) FailStale(ctx context.Context, tx *sql.Tx, staleSeconds int, message string) (int, error) {
+	SQL := `UPDATE ` + models.ImportJobTable + `
+		SET status = $1, error = $2, file_bytes = NULL, finished_at = CURRENT_TIMESTAMP, updated_at = CURRENT_TIMESTAMP
+		WHERE status = $3 AND updated_at <= CURRENT_TIMESTAMP - make_interval(secs => $4)`
+	result, err := tx.ExecContext(ctx, SQL, models.ImportJobStatusFailed, message, models.ImportJobStatusRunning, staleSeconds)
+	if err != nil {
+		return 0, err
+	}
+	affected, err := result.RowsAffected()
+	return int(affected), err
+}
@@ -0,0 +1,18 @@
+package importjob
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/malikabdulaziz/tmn-backend/models"
+)
+
+type RepositoryImportJobInterface interface {
+	Create(ctx context.Context, tx *sql.Tx, job models.ImportJob) (models.ImportJob, error)
+	FindById(ctx context.Context, tx *sql.Tx, id int) (models.ImportJob, error)
+	ClaimNext(ctx context.Context, tx *sql.Tx) (models.ImportJob, error)
+	UpdateProgress(ctx context.Context, tx *sql.Tx, id int, done int, total int) (bool, error)
+	RequestCancel(ctx context.Context, tx *sql.Tx, id int) (models.ImportJob, error)
+	Finish(ctx context.Context, tx *sql.Tx, job models.ImportJob) error
+	FailStale(ctx context.Context, tx *sql.Tx, staleSeconds int, message string) (int, error)
+}
@@ -0,0 +1,64 @@
+package importpreview
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/malikabdulaziz/tmn-backend/models"
+)
+
+type RepositoryImportPreviewImpl struct{}
+
+func NewRepositoryImportPreviewImpl() RepositoryImportPreviewInterface {
+	return &RepositoryImportPreviewImpl{}
+}
+
+// Create stores an uploaded file and its diff fingerprint; the preview expires ttlSeconds from now
+func (r *RepositoryImportPreviewImpl) Create(ctx context.Context, tx *sql.Tx, preview models.ImportPreview, ttlSeconds int) (models.ImportPreview, error) {
+	SQL := `INSERT INTO ` + models.ImportPreviewTable + ` (token, kind, file_type, file_bytes, fingerprint, options, created_by, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, CURRENT_TIMESTAMP + make_interval(secs => $8))
+		RETURNING id, expires_at, created_at`
+	if preview.Options == "" {
+		preview.Options = "{}"
+	}
+	var createdBy interface{}
+	if preview.CreatedBy != nil {
+		createdBy = *preview.CreatedBy
+	}
+	err := tx.QueryRowContext(ctx, SQL, preview.Token, preview.Kind, preview.FileType, preview.FileBytes, preview.Fingerprint, preview.Options, createdBy, ttlSeconds).
+		Scan(&preview.Id, &preview.ExpiresAt, &preview.CreatedAt)
+	if err != nil {
+		return models.ImportPreview{}, err
+	}
+	return preview, nil
+}
+
+// FindByToken returns an unexpired preview of the given kind; expired previews yield sql.ErrNoRows
+func (r *RepositoryImportPreviewImpl) FindByToken(ctx context.Context, tx *sql.Tx, kind string, token string) (models.ImportPreview, error) {
+	SQL := `SELECT id, token, kind, file_type, file_bytes, fingerprint, options, created_by, expires_at, created_at
+		FROM ` + models.ImportPreviewTable + `
+		WHERE token = $1 AND kind = $2 AND expires_at > CURRENT_TIMESTAMP`
+	var n models.NullAbleImportPreview
+	err := tx.QueryRowContext(ctx, SQL, token, kind).Scan(&n.Id, &n.Token, &n.Kind, &n.FileType, &n.FileBytes, &n.Fingerprint, &n.Options, &n.CreatedBy, &n.ExpiresAt, &n.CreatedAt)
+	if err != nil {
+		return models.ImportPreview{}, err
+	}
+	return models.NullAbleImportPreviewToImportPreview(n), nil
+}
+
+func (r *RepositoryImportPreviewImpl) Delete(ctx context.Context, tx *sql.Tx, id int) error {
+	SQL := `DELETE FROM ` + models.ImportPreviewTable + ` WHERE id = $1`
+	_, err := tx.ExecContext(ctx, SQL, id)
+	return err
+}
+
+// DeleteExpired removes previews past their expiry and returns how many were deleted
+func (r *RepositoryImportPreviewImpl) DeleteExpired(ctx context.Context, tx *sql.Tx) (int, error) {
+	SQL := `DELETE FROM ` + models.ImportPreviewTable + ` WHERE expires_at <= CURRENT_TIMESTAMP`
+	result, err := tx.ExecContext(ctx, SQL)
+	if err != nil {
+		return 0, err
+	}
+	affected, err := result.RowsAffected()
+	return int(affected), err
+}
@@ -0,0 +1,15 @@
+package importpreview
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/malikabdulaziz/tmn-backend/models"
+)
+
+type RepositoryImportPreviewInterface interface {
+	Create(ctx context.Context, tx *sql.Tx, preview models.ImportPreview, ttlSeconds int) (models.ImportPreview, error)
+	FindByToken(ctx context.Context, tx *sql.Tx, kind string, token string) (models.ImportPreview, error)
+	Delete(ctx context.Context, tx *sql.Tx, id int) error
+	DeleteExpired(ctx context.Context, tx *sql.Tx) (int, error)
+}
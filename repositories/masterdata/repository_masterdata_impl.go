@@ -0,0 +1,74 @@
+package masterdata
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/malikabdulaziz/tmn-backend/models"
+)
+
+type RepositoryMasterDataImpl struct{}
+
+func NewRepositoryMasterDataImpl() RepositoryMasterDataInterface {
+	return &RepositoryMasterDataImpl{}
+}
+
+// FindEntry retrieves a live entry of source with its parent; sql.ErrNoRows when it does not
+// exist or is in the trash
+func (r *RepositoryMasterDataImpl) FindEntry(ctx context.Context, tx *sql.Tx, source models.MergeSource, id int) (models.MasterDataEntry, error) {
+	SQL := `SELECT t.id, t.name, NULL::bigint, NULL::text FROM ` + source.Table + ` t WHERE t.id = $1 AND t.deleted_at IS NULL`
+	if source.ParentColumn != "" {
+		SQL = `SELECT t.id, t.name, t.` + source.ParentColumn + `, p.name FROM ` + source.Table + ` t
+			LEFT JOIN ` + source.ParentTable + ` p ON p.id = t.` + source.ParentColumn + `
+			WHERE t.id = $1 AND t.deleted_at IS NULL`
+	}
+	var n models.NullAbleMasterDataEntry
+	if err := tx.QueryRowContext(ctx, SQL, id).Scan(&n.Id, &n.Name, &n.ParentId, &n.ParentName); err != nil {
+		return models.MasterDataEntry{}, err
+	}
+	return models.NullAbleMasterDataEntryToMasterDataEntry(n), nil
+}
+
+// Repoint moves every reference to fromId over to toId, along with the aliases of fromId, and
+// returns how many rows now point at toId instead. Rows of a unique reference that would collide
+// with one already pointing at toId are dropped.
+func (r *RepositoryMasterDataImpl) Repoint(ctx context.Context, tx *sql.Tx, source models.MergeSource, fromId int, toId int) (int, error) {
+	repointed := 0
+	for _, ref := range source.References {
+		if ref.Key != "" {
+			SQL := `DELETE FROM ` + ref.Table + ` WHERE ` + ref.Column + ` = $1 AND ` + ref.Key + ` IN
+				(SELECT ` + ref.Key + ` FROM ` + ref.Table + ` WHERE ` + ref.Column + ` = $2)`
+			if _, err := tx.ExecContext(ctx, SQL, fromId, toId); err != nil {
+				return repointed, err
+			}
+		}
+		result, err := tx.ExecContext(ctx, `UPDATE `+ref.Table+` SET `+ref.Column+` = $1 WHERE `+ref.Column+` = $2`, toId, fromId)
+		if err != nil {
+			return repointed, err
+		}
+		count, err := result.RowsAffected()
+		if err != nil {
+			return repointed, err
+		}
+		repointed += int(count)
+	}
+	SQL := `UPDATE ` + models.MasterDataAliasTable + ` SET target_id = $1 WHERE type = $2 AND target_id = $3`
+	_, err := tx.ExecContext(ctx, SQL, toId, source.Type, fromId)
+	return repointed, err
+}
+
+// Delete removes an entry of source for good, skipping the trash
+func (r *RepositoryMasterDataImpl) Delete(ctx context.Context, tx *sql.Tx, source models.MergeSource, id int) error {
+	_, err := tx.ExecContext(ctx, `DELETE FROM `+source.Table+` WHERE id = $1`, id)
+	return err
+}
+
+// SaveAlias makes imports resolve alias to targetId; an alias already recorded for the type is
+// moved to the new target
+func (r *RepositoryMasterDataImpl) SaveAlias(ctx context.Context, tx *sql.Tx, source models.MergeSource, alias string, targetId int, createdBy *int) error {
+	SQL := `INSERT INTO ` + models.MasterDataAliasTable + ` (type, alias, target_id, created_by, created_at) VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (type, LOWER(alias)) DO UPDATE SET target_id = EXCLUDED.target_id`
+	_, err := tx.ExecContext(ctx, SQL, source.Type, alias, targetId, createdBy, time.Now())
+	return err
+}
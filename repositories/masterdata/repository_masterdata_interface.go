@@ -0,0 +1,15 @@
+package masterdata
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/malikabdulaziz/tmn-backend/models"
+)
+
+type RepositoryMasterDataInterface interface {
+	FindEntry(ctx context.Context, tx *sql.Tx, source models.MergeSource, id int) (models.MasterDataEntry, error)
+	Repoint(ctx context.Context, tx *sql.Tx, source models.MergeSource, fromId int, toId int) (int, error)
+	Delete(ctx context.Context, tx *sql.Tx, source models.MergeSource, id int) error
+	SaveAlias(ctx context.Context, tx *sql.Tx, source models.MergeSource, alias string, targetId int, createdBy *int) error
+}
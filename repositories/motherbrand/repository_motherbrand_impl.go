@@ -38,10 +38,10 @@ func (r *RepositoryMotherBrandImpl) FindAll(ctx context.Context, tx *sql.Tx, tak
 	var rows *sql.Rows
 	var err error
 	if search != "" {
-		SQL := `SELECT id, name, created_at, updated_at FROM ` + models.MotherBrandTable + ` WHERE name ILIKE $1 ORDER BY ` + orderBy + ` ` + orderDirection + `, name ASC LIMIT $2 OFFSET $3`
+		SQL := `SELECT id, name, created_at, updated_at FROM ` + models.MotherBrandTable + ` WHERE deleted_at IS NULL AND name ILIKE $1 ORDER BY ` + orderBy + ` ` + orderDirection + `, name ASC LIMIT $2 OFFSET $3`
 		rows, err = tx.QueryContext(ctx, SQL, "%"+search+"%", take, skip)
 	} else {
-		SQL := `SELECT id, name, created_at, updated_at FROM ` + models.MotherBrandTable + ` ORDER BY ` + orderBy + ` ` + orderDirection + `, name ASC LIMIT $1 OFFSET $2`
+		SQL := `SELECT id, name, created_at, updated_at FROM ` + models.MotherBrandTable + ` WHERE deleted_at IS NULL ORDER BY ` + orderBy + ` ` + orderDirection + `, name ASC LIMIT $1 OFFSET $2`
 		rows, err = tx.QueryContext(ctx, SQL, take, skip)
 	}
 	if err != nil {
@@ -63,17 +63,17 @@ func (r *RepositoryMotherBrandImpl) CountAll(ctx context.Context, tx *sql.Tx, se
 	var total int
 	var err error
 	if search != "" {
-		SQL := `SELECT COUNT(*) FROM ` + models.MotherBrandTable + ` WHERE name ILIKE $1`
+		SQL := `SELECT COUNT(*) FROM ` + models.MotherBrandTable + ` WHERE deleted_at IS NULL AND name ILIKE $1`
 		err = tx.QueryRowContext(ctx, SQL, "%"+search+"%").Scan(&total)
 	} else {
-		SQL := `SELECT COUNT(*) FROM ` + models.MotherBrandTable
+		SQL := `SELECT COUNT(*) FROM ` + models.MotherBrandTable + ` WHERE deleted_at IS NULL`
 		err = tx.QueryRowContext(ctx, SQL).Scan(&total)
 	}
 	return total, err
 }
 
 func (r *RepositoryMotherBrandImpl) FindById(ctx context.Context, tx *sql.Tx, id int) (models.MotherBrand, error) {
-	SQL := `SELECT id, name, created_at, updated_at FROM ` + models.MotherBrandTable + ` WHERE id = $1`
+	SQL := `SELECT id, name, created_at, updated_at FROM ` + models.MotherBrandTable + ` WHERE id = $1 AND deleted_at IS NULL`
 	var n models.NullAbleMotherBrand
 	err := tx.QueryRowContext(ctx, SQL, id).Scan(&n.Id, &n.Name, &n.CreatedAt, &n.UpdatedAt)
 	if err != nil {
@@ -83,19 +83,22 @@ func (r *RepositoryMotherBrandImpl) FindById(ctx context.Context, tx *sql.Tx, id
 }
 
 func (r *RepositoryMotherBrandImpl) Update(ctx context.Context, tx *sql.Tx, motherBrand models.MotherBrand) (models.MotherBrand, error) {
-	SQL := `UPDATE ` + models.MotherBrandTable + ` SET name = $1, updated_at = $2 WHERE id = $3 RETURNING updated_at`
+	SQL := `UPDATE ` + models.MotherBrandTable + ` SET name = $1, updated_at = $2 WHERE id = $3 AND deleted_at IS NULL RETURNING updated_at`
 	err := tx.QueryRowContext(ctx, SQL, motherBrand.Name, time.Now(), motherBrand.Id).Scan(&motherBrand.UpdatedAt)
 	return motherBrand, err
 }
 
-func (r *RepositoryMotherBrandImpl) Delete(ctx context.Context, tx *sql.Tx, id int) error {
-	SQL := `DELETE FROM ` + models.MotherBrandTable + ` WHERE id = $1`
-	_, err := tx.ExecContext(ctx, SQL, id)
+// Delete moves a mother brand to the trash; POIs keep pointing at it until it is purged
+func (r *RepositoryMotherBrandImpl) Delete(ctx context.Context, tx *sql.Tx, id int, deletedBy *int) error {
+	SQL := `UPDATE ` + models.MotherBrandTable + ` SET deleted_at = $1, deleted_by = $2 WHERE id = $3 AND deleted_at IS NULL`
+	_, err := tx.ExecContext(ctx, SQL, time.Now(), deletedBy, id)
 	return err
 }
 
+// FindByName retrieves a mother brand by name, ignoring case, or the mother brand a name was merged into
 func (r *RepositoryMotherBrandImpl) FindByName(ctx context.Context, tx *sql.Tx, name string) (models.MotherBrand, error) {
-	SQL := `SELECT id, name, created_at, updated_at FROM ` + models.MotherBrandTable + ` WHERE LOWER(name) = LOWER($1) LIMIT 1`
+	SQL := `SELECT id, name, created_at, updated_at FROM ` + models.MotherBrandTable + ` WHERE deleted_at IS NULL AND (LOWER(name) = LOWER($1) OR ` + models.AliasCondition(models.MasterDataTypeMotherBrand, "id", "$1") + `)
+		ORDER BY LOWER(name) = LOWER($1) DESC LIMIT 1`
 	var n models.NullAbleMotherBrand
 	err := tx.QueryRowContext(ctx, SQL, name).Scan(&n.Id, &n.Name, &n.CreatedAt, &n.UpdatedAt)
 	if err != nil {
@@ -105,7 +108,7 @@ func (r *RepositoryMotherBrandImpl) FindByName(ctx context.Context, tx *sql.Tx,
 }
 
 func (r *RepositoryMotherBrandImpl) FindAllDropdown(ctx context.Context, tx *sql.Tx) ([]models.MotherBrand, error) {
-	SQL := `SELECT id, name, created_at, updated_at FROM ` + models.MotherBrandTable + ` ORDER BY name ASC`
+	SQL := `SELECT id, name, created_at, updated_at FROM ` + models.MotherBrandTable + ` WHERE deleted_at IS NULL ORDER BY name ASC`
 	rows, err := tx.QueryContext(ctx, SQL)
 	if err != nil {
 		return nil, err
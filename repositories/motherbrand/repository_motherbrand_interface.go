@@ -13,7 +13,7 @@ type RepositoryMotherBrandInterface interface {
 	CountAll(ctx context.Context, tx *sql.Tx, search string) (int, error)
 	FindById(ctx context.Context, tx *sql.Tx, id int) (models.MotherBrand, error)
 	Update(ctx context.Context, tx *sql.Tx, motherBrand models.MotherBrand) (models.MotherBrand, error)
-	Delete(ctx context.Context, tx *sql.Tx, id int) error
+	Delete(ctx context.Context, tx *sql.Tx, id int, deletedBy *int) error
 	FindByName(ctx context.Context, tx *sql.Tx, name string) (models.MotherBrand, error)
 	FindAllDropdown(ctx context.Context, tx *sql.Tx) ([]models.MotherBrand, error)
 }
@@ -132,6 +132,86 @@ func (repository *RepositoryPOIImpl) Update(ctx context.Context, tx *sql.Tx, poi
 	return loaded, nil
 }
 
+// UpdateMetadata updates POI fields only; owned points (and their ids) are left untouched.
+func (repository *RepositoryPOIImpl) UpdateMetadata(ctx context.Context, tx *sql.Tx, poi models.POI) (models.POI, error) {
+	SQL := `UPDATE ` + models.POITable + `
+		SET brand = $1, color = $2,
+		    category_id = $3, sub_category_id = $4, mother_brand_id = $5,
+		    updated_at = $6
+		WHERE id = $7`
+
+	_, err := tx.ExecContext(ctx, SQL,
+		poi.Brand,
+		nullIfEmpty(poi.Color),
+		nullIntPtr(poi.CategoryId),
+		nullIntPtr(poi.SubCategoryId),
+		nullIntPtr(poi.MotherBrandId),
+		time.Now(),
+		poi.Id,
+	)
+	if err != nil {
+		return models.POI{}, err
+	}
+	return repository.FindById(ctx, tx, poi.Id)
+}
+
+// CreatePoint inserts a single point for an existing POI.
+func (repository *RepositoryPOIImpl) CreatePoint(ctx context.Context, tx *sql.Tx, poiId int, point models.POIPoint) (models.POIPoint, error) {
+	return repository.insertPoint(ctx, tx, poiId, point)
+}
+
+// UpdatePoint updates a point in place, keeping its id.
+func (repository *RepositoryPOIImpl) UpdatePoint(ctx context.Context, tx *sql.Tx, point models.POIPoint) (models.POIPoint, error) {
+	SQL := `UPDATE ` + models.POIPointTable + `
+		SET poi_name = $1, address = $2, latitude = $3, longitude = $4,
+		    location = CASE WHEN $3::DOUBLE PRECISION IS NOT NULL AND $4::DOUBLE PRECISION IS NOT NULL AND ($3::DOUBLE PRECISION) != 0 AND ($4::DOUBLE PRECISION) != 0
+		         THEN ST_SetSRID(ST_MakePoint($4::DOUBLE PRECISION, $3::DOUBLE PRECISION), 4326)::geography
+		         ELSE NULL END,
+		    branch_id = $5, external_key = $6, updated_at = $7
+		WHERE id = $8
+		RETURNING poi_id, created_at, updated_at`
+
+	err := tx.QueryRowContext(ctx, SQL,
+		nullIfEmpty(point.POIName),
+		nullIfEmpty(point.Address),
+		nullIfZeroFloat(point.Latitude),
+		nullIfZeroFloat(point.Longitude),
+		nullIntPtr(point.BranchId),
+		nullIfEmpty(point.ExternalKey),
+		time.Now(),
+		point.Id,
+	).Scan(&point.POIId, &point.CreatedAt, &point.UpdatedAt)
+	if err != nil {
+		return models.POIPoint{}, err
+	}
+
+	point.BranchName = ""
+	if point.BranchId != nil {
+		if err := tx.QueryRowContext(ctx,
+			`SELECT name FROM branches WHERE id = $1`, *point.BranchId,
+		).Scan(&point.BranchName); err != nil && err != sql.ErrNoRows {
+			return models.POIPoint{}, err
+		}
+	}
+
+	return point, nil
+}
+
+// DeletePoints removes the given points.
+func (repository *RepositoryPOIImpl) DeletePoints(ctx context.Context, tx *sql.Tx, ids []int) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	placeholders := make([]string, len(ids))
+	args := make([]interface{}, len(ids))
+	for i, id := range ids {
+		placeholders[i] = "$" + strconv.Itoa(i+1)
+		args[i] = id
+	}
+	_, err := tx.ExecContext(ctx, `DELETE FROM `+models.POIPointTable+` WHERE id IN (`+strings.Join(placeholders, ",")+`)`, args...)
+	return err
+}
+
 // replacePoints deletes all poi_points for a POI and reinserts the given list.
 func (repository *RepositoryPOIImpl) replacePoints(ctx context.Context, tx *sql.Tx, poiId int, points []models.POIPoint) ([]models.POIPoint, error) {
 	if _, err := tx.ExecContext(ctx, `DELETE FROM `+models.POIPointTable+` WHERE poi_id = $1`, poiId); err != nil {
@@ -151,12 +231,12 @@ func (repository *RepositoryPOIImpl) replacePoints(ctx context.Context, tx *sql.
 
 func (repository *RepositoryPOIImpl) insertPoint(ctx context.Context, tx *sql.Tx, poiId int, pt models.POIPoint) (models.POIPoint, error) {
 	SQL := `INSERT INTO ` + models.POIPointTable + `
-		(poi_id, poi_name, address, latitude, longitude, location, branch_id)
+		(poi_id, poi_name, address, latitude, longitude, location, branch_id, external_key)
 		VALUES ($1, $2, $3, $4, $5,
 		CASE WHEN $4::DOUBLE PRECISION IS NOT NULL AND $5::DOUBLE PRECISION IS NOT NULL AND ($4::DOUBLE PRECISION) != 0 AND ($5::DOUBLE PRECISION) != 0
 		     THEN ST_SetSRID(ST_MakePoint($5::DOUBLE PRECISION, $4::DOUBLE PRECISION), 4326)::geography
 		     ELSE NULL END,
-		$6)
+		$6, $7)
 		RETURNING id, created_at, updated_at`
 
 	pt.POIId = poiId
@@ -167,6 +247,7 @@ func (repository *RepositoryPOIImpl) insertPoint(ctx context.Context, tx *sql.Tx
 		nullIfZeroFloat(pt.Latitude),
 		nullIfZeroFloat(pt.Longitude),
 		nullIntPtr(pt.BranchId),
+		nullIfEmpty(pt.ExternalKey),
 	).Scan(&pt.Id, &pt.CreatedAt, &pt.UpdatedAt)
 	if err != nil {
 		return models.POIPoint{}, err
@@ -183,9 +264,11 @@ func (repository *RepositoryPOIImpl) insertPoint(ctx context.Context, tx *sql.Tx
 	return pt, nil
 }
 
-// Delete cascades to poi_points via the FK on poi_points.poi_id.
-func (repository *RepositoryPOIImpl) Delete(ctx context.Context, tx *sql.Tx, id int) error {
-	_, err := tx.ExecContext(ctx, `DELETE FROM `+models.POITable+` WHERE id = $1`, id)
+// Delete moves a POI to the trash. Its points stay until the POI is purged, which cascades to
+// poi_points via the FK on poi_points.poi_id.
+func (repository *RepositoryPOIImpl) Delete(ctx context.Context, tx *sql.Tx, id int, deletedBy *int) error {
+	_, err := tx.ExecContext(ctx, `UPDATE `+models.POITable+` SET deleted_at = $1, deleted_by = $2 WHERE id = $3 AND deleted_at IS NULL`,
+		time.Now(), deletedBy, id)
 	return err
 }
 
@@ -220,10 +303,10 @@ func (repository *RepositoryPOIImpl) CountAll(ctx context.Context, tx *sql.Tx, s
 	return total, err
 }
 
-// buildPOIFilterClauses builds SQL WHERE clauses for the POI list/count queries.
-// Mutates args and paramIdx in place. Returns the list of clauses to be joined with AND.
+// buildPOIFilterClauses builds SQL WHERE clauses for the POI list/count queries; POIs in the trash
+// are always left out. Mutates args and paramIdx in place. Returns the list of clauses to be joined with AND.
 func buildPOIFilterClauses(search, categoryIds, subCategoryIds, motherBrandIds string, args *[]interface{}, paramIdx *int) []string {
-	var clauses []string
+	clauses := []string{`p.deleted_at IS NULL`}
 	if search != "" {
 		clauses = append(clauses, `p.brand ILIKE '%' || $`+strconv.Itoa(*paramIdx)+` || '%'`)
 		*args = append(*args, search)
@@ -277,7 +360,7 @@ func (repository *RepositoryPOIImpl) FindAllFlat(ctx context.Context, tx *sql.Tx
 }
 
 func (repository *RepositoryPOIImpl) FindById(ctx context.Context, tx *sql.Tx, id int) (models.POI, error) {
-	SQL := `SELECT ` + poiSelectCols + ` FROM ` + models.POITable + ` p` + poiJoins + ` WHERE p.id = $1`
+	SQL := `SELECT ` + poiSelectCols + ` FROM ` + models.POITable + ` p` + poiJoins + ` WHERE p.id = $1 AND p.deleted_at IS NULL`
 	n, err := scanPOI(tx.QueryRowContext(ctx, SQL, id))
 	if err != nil {
 		return models.POI{}, err
@@ -304,7 +387,7 @@ func (repository *RepositoryPOIImpl) FindByBrands(ctx context.Context, tx *sql.T
 	}
 
 	SQL := `SELECT ` + poiSelectCols + ` FROM ` + models.POITable + ` p` + poiJoins +
-		` WHERE p.brand IN (` + strings.Join(placeholders, ",") + `)`
+		` WHERE p.deleted_at IS NULL AND p.brand IN (` + strings.Join(placeholders, ",") + `) ORDER BY p.id ASC`
 
 	return repository.queryPOIs(ctx, tx, SQL, args)
 }
@@ -349,7 +432,7 @@ func (repository *RepositoryPOIImpl) queryPOIs(ctx context.Context, tx *sql.Tx,
 }
 
 const poiPointSelectCols = `pp.id, pp.poi_id, pp.poi_name, pp.address, pp.latitude, pp.longitude,
-	pp.branch_id, b.name,
+	pp.external_key, pp.branch_id, b.name,
 	pp.created_at, pp.updated_at`
 
 const poiPointJoins = ` LEFT JOIN branches b ON b.id = pp.branch_id`
@@ -360,7 +443,7 @@ func scanPOIPoint(scanner interface {
 	var n models.NullAblePOIPoint
 	err := scanner.Scan(
 		&n.Id, &n.POIId, &n.POIName, &n.Address, &n.Latitude, &n.Longitude,
-		&n.BranchId, &n.BranchName,
+		&n.ExternalKey, &n.BranchId, &n.BranchName,
 		&n.CreatedAt, &n.UpdatedAt,
 	)
 	return n, err
@@ -428,3 +511,75 @@ func (repository *RepositoryPOIImpl) findPointsByPOIIds(ctx context.Context, tx
 	}
 	return pointsMap, nil
 }
+
+// FindNearbyPoints returns the POI points closest to a coordinate using KNN ordering on
+// poi_points.location, optionally restricted by the parent POI's category / sub-category / mother brand.
+func (repository *RepositoryPOIImpl) FindNearbyPoints(ctx context.Context, tx *sql.Tx, lat float64, lng float64, limit int, categoryIds string, subCategoryIds string, motherBrandIds string) ([]NearbyPOIPointRow, error) {
+	args := []interface{}{lng, lat}
+	paramIdx := 3
+	origin := `ST_SetSRID(ST_MakePoint($1, $2), 4326)::geography`
+
+	SQL := `SELECT ` + poiPointSelectCols + `, ` + poiSelectCols + `, ST_Distance(pp.location, ` + origin + `)
+		FROM ` + models.POIPointTable + ` pp` + poiPointJoins + `
+		INNER JOIN ` + models.POITable + ` p ON p.id = pp.poi_id` + poiJoins + `
+		WHERE pp.location IS NOT NULL`
+	whereClauses := buildPOIFilterClauses("", categoryIds, subCategoryIds, motherBrandIds, &args, &paramIdx)
+	if len(whereClauses) > 0 {
+		SQL += ` AND ` + strings.Join(whereClauses, " AND ")
+	}
+	SQL += ` ORDER BY pp.location <-> ` + origin + ` LIMIT $` + strconv.Itoa(paramIdx)
+	args = append(args, limit)
+
+	rows, err := tx.QueryContext(ctx, SQL, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := []NearbyPOIPointRow{}
+	for rows.Next() {
+		var point models.NullAblePOIPoint
+		var poi models.NullAblePOI
+		var distance float64
+		err := rows.Scan(
+			&point.Id, &point.POIId, &point.POIName, &point.Address, &point.Latitude, &point.Longitude,
+			&point.ExternalKey, &point.BranchId, &point.BranchName,
+			&point.CreatedAt, &point.UpdatedAt,
+			&poi.Id, &poi.Brand, &poi.Color,
+			&poi.CategoryId, &poi.SubCategoryId, &poi.MotherBrandId,
+			&poi.CategoryName, &poi.SubCategoryName, &poi.MotherBrandName,
+			&poi.CreatedAt, &poi.UpdatedAt,
+			&distance,
+		)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, NearbyPOIPointRow{
+			Point:          models.NullAblePOIPointToPOIPoint(point),
+			POI:            models.NullAblePOIToPOI(poi),
+			DistanceMeters: distance,
+		})
+	}
+	return result, rows.Err()
+}
+
+// CountPointLocations returns how many distinct locations the points of the given live POIs have,
+// i.e. how many origins a catchment around them is computed from
+func (repository *RepositoryPOIImpl) CountPointLocations(ctx context.Context, tx *sql.Tx, poiIds []int) (int, error) {
+	if len(poiIds) == 0 {
+		return 0, nil
+	}
+	placeholders := make([]string, len(poiIds))
+	args := make([]interface{}, len(poiIds))
+	for i, id := range poiIds {
+		placeholders[i] = "$" + strconv.Itoa(i+1)
+		args[i] = id
+	}
+	SQL := `SELECT COUNT(DISTINCT (ST_X(pp.location::geometry), ST_Y(pp.location::geometry)))
+		FROM ` + models.POIPointTable + ` pp
+		INNER JOIN ` + models.POITable + ` p ON p.id = pp.poi_id AND p.deleted_at IS NULL
+		WHERE pp.poi_id IN (` + strings.Join(placeholders, ", ") + `) AND pp.location IS NOT NULL`
+	var count int
+	err := tx.QueryRowContext(ctx, SQL, args...).Scan(&count)
+	return count, err
+}
@@ -7,6 +7,13 @@ import (
 	"github.com/malikabdulaziz/tmn-backend/models"
 )
 
+// NearbyPOIPointRow is a POI point with its parent POI metadata and distance from a reference coordinate
+type NearbyPOIPointRow struct {
+	Point          models.POIPoint
+	POI            models.POI
+	DistanceMeters float64
+}
+
 type RepositoryPOIInterface interface {
 	Create(ctx context.Context, tx *sql.Tx, poi models.POI, points []models.POIPoint) (models.POI, error)
 	FindAll(ctx context.Context, tx *sql.Tx, take int, skip int, orderBy string, orderDirection string, search string, categoryIds string, subCategoryIds string, motherBrandIds string) ([]models.POI, error)
@@ -15,5 +22,11 @@ type RepositoryPOIInterface interface {
 	FindById(ctx context.Context, tx *sql.Tx, id int) (models.POI, error)
 	FindByBrands(ctx context.Context, tx *sql.Tx, brands []string) ([]models.POI, error)
 	Update(ctx context.Context, tx *sql.Tx, poi models.POI, points []models.POIPoint) (models.POI, error)
-	Delete(ctx context.Context, tx *sql.Tx, id int) error
+	UpdateMetadata(ctx context.Context, tx *sql.Tx, poi models.POI) (models.POI, error)
+	CreatePoint(ctx context.Context, tx *sql.Tx, poiId int, point models.POIPoint) (models.POIPoint, error)
+	UpdatePoint(ctx context.Context, tx *sql.Tx, point models.POIPoint) (models.POIPoint, error)
+	DeletePoints(ctx context.Context, tx *sql.Tx, ids []int) error
+	Delete(ctx context.Context, tx *sql.Tx, id int, deletedBy *int) error
+	FindNearbyPoints(ctx context.Context, tx *sql.Tx, lat float64, lng float64, limit int, categoryIds string, subCategoryIds string, motherBrandIds string) ([]NearbyPOIPointRow, error)
+	CountPointLocations(ctx context.Context, tx *sql.Tx, poiIds []int) (int, error)
 }
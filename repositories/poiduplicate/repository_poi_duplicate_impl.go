@@ -0,0 +1,158 @@
+package poiduplicate
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/malikabdulaziz/tmn-backend/models"
+)
+
+type RepositoryPOIDuplicateImpl struct{}
+
+func NewRepositoryPOIDuplicateImpl() RepositoryPOIDuplicateInterface {
+	return &RepositoryPOIDuplicateImpl{}
+}
+
+// duplicatePointCols selects a poi_points row (%[1]s) with its branch name (%[2]s)
+const duplicatePointCols = `%[1]s.id, %[1]s.poi_id, %[1]s.poi_name, %[1]s.address, %[1]s.latitude, %[1]s.longitude,
+	%[1]s.external_key, %[1]s.branch_id, %[2]s.name, %[1]s.created_at, %[1]s.updated_at`
+
+// duplicateLabel is what name similarity compares, so "Starbucks / Sarinah" and
+// "Starbucks Coffee / Sarinah" match across brands
+func duplicateLabel(poiAlias, pointAlias string) string {
+	return `lower(` + poiAlias + `.brand || ' ' || COALESCE(` + pointAlias + `.poi_name, ''))`
+}
+
+var nameSimilarity = `similarity(` + duplicateLabel("pa", "a") + `, ` + duplicateLabel("pb", "b") + `)`
+
+// candidateQuery returns the FROM/WHERE part shared by FindCandidates and CountCandidates. The
+// ST_DWithin self-join runs on the GIST index on poi_points.location; b.id > a.id lists each
+// pair once.
+func candidateQuery(filter DuplicateFilter, args *[]interface{}) string {
+	*args = append(*args, filter.RadiusMeters, filter.MinSimilarity, filter.SameSpotMeters)
+
+	SQL := ` FROM ` + models.POIPointTable + ` a
+		INNER JOIN ` + models.POIPointTable + ` b ON b.id > a.id AND ST_DWithin(a.location, b.location, $1)
+		INNER JOIN ` + models.POITable + ` pa ON pa.id = a.poi_id AND pa.deleted_at IS NULL
+		INNER JOIN ` + models.POITable + ` pb ON pb.id = b.poi_id AND pb.deleted_at IS NULL
+		LEFT JOIN branches ba ON ba.id = a.branch_id
+		LEFT JOIN branches bb ON bb.id = b.branch_id
+		WHERE a.location IS NOT NULL AND b.location IS NOT NULL
+			AND (` + nameSimilarity + ` >= $2 OR ST_Distance(a.location, b.location) <= $3)
+			AND NOT EXISTS (
+				SELECT 1 FROM ` + models.POIPointDuplicateDismissalTable + ` d
+				WHERE d.point_id = a.id AND d.other_point_id = b.id
+			)`
+
+	paramIdx := len(*args) + 1
+	for _, f := range []struct{ column, ids string }{
+		{"category_id", filter.CategoryIds},
+		{"mother_brand_id", filter.MotherBrandIds},
+	} {
+		var placeholders []string
+		for _, idStr := range strings.Split(f.ids, ",") {
+			id, err := strconv.Atoi(strings.TrimSpace(idStr))
+			if err != nil {
+				continue
+			}
+			placeholders = append(placeholders, "$"+strconv.Itoa(paramIdx))
+			*args = append(*args, id)
+			paramIdx++
+		}
+		if len(placeholders) > 0 {
+			in := strings.Join(placeholders, ",")
+			SQL += ` AND (pa.` + f.column + ` IN (` + in + `) OR pb.` + f.column + ` IN (` + in + `))`
+		}
+	}
+	return SQL
+}
+
+// FindCandidates lists candidate pairs, most similar names first, then closest first
+func (repository *RepositoryPOIDuplicateImpl) FindCandidates(ctx context.Context, tx *sql.Tx, filter DuplicateFilter, take int, skip int) ([]models.POIPointDuplicate, error) {
+	args := []interface{}{}
+	from := candidateQuery(filter, &args)
+
+	SQL := `SELECT ` + fmt.Sprintf(duplicatePointCols, "a", "ba") + `, pa.brand,
+		` + fmt.Sprintf(duplicatePointCols, "b", "bb") + `, pb.brand,
+		ST_Distance(a.location, b.location) AS distance_meters, ` + nameSimilarity + ` AS name_similarity` + from + `
+		ORDER BY name_similarity DESC, distance_meters ASC, a.id, b.id
+		LIMIT $` + strconv.Itoa(len(args)+1) + ` OFFSET $` + strconv.Itoa(len(args)+2)
+	args = append(args, take, skip)
+
+	rows, err := tx.QueryContext(ctx, SQL, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	duplicates := []models.POIPointDuplicate{}
+	for rows.Next() {
+		var a, b models.NullAblePOIPoint
+		var brand, otherBrand sql.NullString
+		var duplicate models.POIPointDuplicate
+		err := rows.Scan(
+			&a.Id, &a.POIId, &a.POIName, &a.Address, &a.Latitude, &a.Longitude,
+			&a.ExternalKey, &a.BranchId, &a.BranchName, &a.CreatedAt, &a.UpdatedAt, &brand,
+			&b.Id, &b.POIId, &b.POIName, &b.Address, &b.Latitude, &b.Longitude,
+			&b.ExternalKey, &b.BranchId, &b.BranchName, &b.CreatedAt, &b.UpdatedAt, &otherBrand,
+			&duplicate.DistanceMeters, &duplicate.NameSimilarity,
+		)
+		if err != nil {
+			return nil, err
+		}
+		duplicate.Point = models.NullAblePOIPointToPOIPoint(a)
+		duplicate.Brand = brand.String
+		duplicate.OtherPoint = models.NullAblePOIPointToPOIPoint(b)
+		duplicate.OtherBrand = otherBrand.String
+		duplicates = append(duplicates, duplicate)
+	}
+	return duplicates, rows.Err()
+}
+
+func (repository *RepositoryPOIDuplicateImpl) CountCandidates(ctx context.Context, tx *sql.Tx, filter DuplicateFilter) (int, error) {
+	args := []interface{}{}
+	SQL := `SELECT COUNT(*)` + candidateQuery(filter, &args)
+
+	var total int
+	err := tx.QueryRowContext(ctx, SQL, args...).Scan(&total)
+	return total, err
+}
+
+// FindPointById retrieves a point of a POI that is not in the trash
+func (repository *RepositoryPOIDuplicateImpl) FindPointById(ctx context.Context, tx *sql.Tx, id int) (models.POIPoint, error) {
+	SQL := `SELECT ` + fmt.Sprintf(duplicatePointCols, "pp", "b") + `
+		FROM ` + models.POIPointTable + ` pp
+		INNER JOIN ` + models.POITable + ` p ON p.id = pp.poi_id AND p.deleted_at IS NULL
+		LEFT JOIN branches b ON b.id = pp.branch_id
+		WHERE pp.id = $1`
+
+	var n models.NullAblePOIPoint
+	err := tx.QueryRowContext(ctx, SQL, id).Scan(
+		&n.Id, &n.POIId, &n.POIName, &n.Address, &n.Latitude, &n.Longitude,
+		&n.ExternalKey, &n.BranchId, &n.BranchName, &n.CreatedAt, &n.UpdatedAt,
+	)
+	if err != nil {
+		return models.POIPoint{}, err
+	}
+	return models.NullAblePOIPointToPOIPoint(n), nil
+}
+
+// Dismiss records that two points are distinct outlets; dismissing a pair twice is a no-op
+func (repository *RepositoryPOIDuplicateImpl) Dismiss(ctx context.Context, tx *sql.Tx, pointId int, otherPointId int, dismissedBy *int) error {
+	if pointId > otherPointId {
+		pointId, otherPointId = otherPointId, pointId
+	}
+	SQL := `INSERT INTO ` + models.POIPointDuplicateDismissalTable + ` (point_id, other_point_id, dismissed_by)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (point_id, other_point_id) DO NOTHING`
+
+	var by interface{}
+	if dismissedBy != nil {
+		by = *dismissedBy
+	}
+	_, err := tx.ExecContext(ctx, SQL, pointId, otherPointId, by)
+	return err
+}
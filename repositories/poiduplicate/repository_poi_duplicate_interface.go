@@ -0,0 +1,27 @@
+package poiduplicate
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/malikabdulaziz/tmn-backend/models"
+)
+
+// DuplicateFilter selects candidate pairs: points within RadiusMeters of each other whose
+// "brand poi_name" labels have a trigram similarity of at least MinSimilarity, or that lie
+// within SameSpotMeters whatever their names. CategoryIds and MotherBrandIds are comma-separated
+// and match when either point's POI is in them.
+type DuplicateFilter struct {
+	RadiusMeters   float64
+	SameSpotMeters float64
+	MinSimilarity  float64
+	CategoryIds    string
+	MotherBrandIds string
+}
+
+type RepositoryPOIDuplicateInterface interface {
+	FindCandidates(ctx context.Context, tx *sql.Tx, filter DuplicateFilter, take int, skip int) ([]models.POIPointDuplicate, error)
+	CountCandidates(ctx context.Context, tx *sql.Tx, filter DuplicateFilter) (int, error)
+	FindPointById(ctx context.Context, tx *sql.Tx, id int) (models.POIPoint, error)
+	Dismiss(ctx context.Context, tx *sql.Tx, pointId int, otherPointId int, dismissedBy *int) error
+}
@@ -3,6 +3,7 @@ package salespackage
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"strconv"
 	"strings"
 	"time"
@@ -20,6 +21,27 @@ func NewRepositorySalesPackageImpl() RepositorySalesPackageInterface {
 var allowedOrderBy = map[string]bool{"id": true, "name": true, "created_at": true, "updated_at": true}
 var allowedOrderDir = map[string]bool{"ASC": true, "DESC": true}
 
+// salesPackageCols is scanned by scanSalesPackage
+const salesPackageCols = `id, name, filter, filter_refreshed_at, created_by, updated_by, visibility, created_at, updated_at`
+
+func scanSalesPackage(scanner interface {
+	Scan(dest ...any) error
+}) (models.SalesPackage, error) {
+	var n models.NullAbleSalesPackage
+	if err := scanner.Scan(&n.Id, &n.Name, &n.Filter, &n.FilterRefreshedAt, &n.CreatedBy, &n.UpdatedBy, &n.Visibility,
+		&n.CreatedAt, &n.UpdatedAt); err != nil {
+		return models.SalesPackage{}, err
+	}
+	return models.NullAbleSalesPackageToSalesPackage(n), nil
+}
+
+// latestProposalJoin joins bp, the latest building proposal of building b's project, for its screen count
+var latestProposalJoin = `LEFT JOIN LATERAL (
+			SELECT id, number_of_screen FROM ` + models.BuildingProposalTable + `
+			WHERE building_project = b.project_name
+			ORDER BY modified DESC NULLS LAST, id DESC LIMIT 1
+		) bp ON b.project_name <> ''`
+
 func safeOrder(orderBy, orderDirection string) (string, string) {
 	if !allowedOrderBy[orderBy] {
 		orderBy = "created_at"
@@ -30,13 +52,15 @@ func safeOrder(orderBy, orderDirection string) (string, string) {
 	return orderBy, orderDirection
 }
 
-// Create inserts a new sales package and its building links
+// Create inserts a new sales package owned by pkg.CreatedBy and its building links
 func (r *RepositorySalesPackageImpl) Create(ctx context.Context, tx *sql.Tx, pkg models.SalesPackage, buildingIds []int) (models.SalesPackage, error) {
-	SQL := `INSERT INTO ` + models.SalesPackageTable + ` (name) VALUES ($1) RETURNING id, created_at, updated_at`
-	err := tx.QueryRowContext(ctx, SQL, pkg.Name).Scan(&pkg.Id, &pkg.CreatedAt, &pkg.UpdatedAt)
+	SQL := `INSERT INTO ` + models.SalesPackageTable + ` (name, created_by, updated_by, visibility) VALUES ($1, $2, $2, $3)
+		RETURNING id, created_at, updated_at`
+	err := tx.QueryRowContext(ctx, SQL, pkg.Name, pkg.CreatedBy, pkg.Visibility).Scan(&pkg.Id, &pkg.CreatedAt, &pkg.UpdatedAt)
 	if err != nil {
 		return models.SalesPackage{}, err
 	}
+	pkg.UpdatedBy = pkg.CreatedBy
 	for _, bid := range buildingIds {
 		if err := r.CreateBuildingLink(ctx, tx, pkg.Id, bid); err != nil {
 			return models.SalesPackage{}, err
@@ -51,12 +75,13 @@ func (r *RepositorySalesPackageImpl) Create(ctx context.Context, tx *sql.Tx, pkg
 	return pkg, nil
 }
 
-// FindAll retrieves all sales packages with pagination and ordering; loads building refs per package
-func (r *RepositorySalesPackageImpl) FindAll(ctx context.Context, tx *sql.Tx, take int, skip int, orderBy string, orderDirection string) ([]models.SalesPackage, error) {
+// FindAll retrieves the sales packages userId may see with pagination and ordering; loads building refs per package
+func (r *RepositorySalesPackageImpl) FindAll(ctx context.Context, tx *sql.Tx, userId int, take int, skip int, orderBy string, orderDirection string) ([]models.SalesPackage, error) {
 	orderBy, orderDirection = safeOrder(orderBy, orderDirection)
-	SQL := `SELECT id, name, created_at, updated_at FROM ` + models.SalesPackageTable + `
-		ORDER BY ` + orderBy + ` ` + orderDirection + `, name ASC LIMIT $1 OFFSET $2`
-	rows, err := tx.QueryContext(ctx, SQL, take, skip)
+	SQL := `SELECT ` + salesPackageCols + ` FROM ` + models.SalesPackageTable + `
+		WHERE deleted_at IS NULL AND ` + models.VisibleToCondition(models.SalesPackageTable, "$1") + `
+		ORDER BY ` + orderBy + ` ` + orderDirection + `, name ASC LIMIT $2 OFFSET $3`
+	rows, err := tx.QueryContext(ctx, SQL, userId, take, skip)
 	if err != nil {
 		return nil, err
 	}
@@ -65,11 +90,10 @@ func (r *RepositorySalesPackageImpl) FindAll(ctx context.Context, tx *sql.Tx, ta
 	var packages []models.SalesPackage
 	var ids []int
 	for rows.Next() {
-		var n models.NullAbleSalesPackage
-		if err := rows.Scan(&n.Id, &n.Name, &n.CreatedAt, &n.UpdatedAt); err != nil {
+		pkg, err := scanSalesPackage(rows)
+		if err != nil {
 			return nil, err
 		}
-		pkg := models.NullAbleSalesPackageToSalesPackage(n)
 		ids = append(ids, pkg.Id)
 		packages = append(packages, pkg)
 	}
@@ -89,23 +113,21 @@ func (r *RepositorySalesPackageImpl) FindAll(ctx context.Context, tx *sql.Tx, ta
 	return packages, nil
 }
 
-// CountAll returns total count of sales packages
-func (r *RepositorySalesPackageImpl) CountAll(ctx context.Context, tx *sql.Tx) (int, error) {
-	SQL := `SELECT COUNT(*) FROM ` + models.SalesPackageTable
+// CountAll returns total count of sales packages userId may see
+func (r *RepositorySalesPackageImpl) CountAll(ctx context.Context, tx *sql.Tx, userId int) (int, error) {
+	SQL := `SELECT COUNT(*) FROM ` + models.SalesPackageTable + ` WHERE deleted_at IS NULL AND ` + models.VisibleToCondition(models.SalesPackageTable, "$1")
 	var total int
-	err := tx.QueryRowContext(ctx, SQL).Scan(&total)
+	err := tx.QueryRowContext(ctx, SQL, userId).Scan(&total)
 	return total, err
 }
 
 // FindById retrieves a sales package by ID with its building refs
 func (r *RepositorySalesPackageImpl) FindById(ctx context.Context, tx *sql.Tx, id int) (models.SalesPackage, error) {
-	SQL := `SELECT id, name, created_at, updated_at FROM ` + models.SalesPackageTable + ` WHERE id = $1`
-	row := tx.QueryRowContext(ctx, SQL, id)
-	var n models.NullAbleSalesPackage
-	if err := row.Scan(&n.Id, &n.Name, &n.CreatedAt, &n.UpdatedAt); err != nil {
+	SQL := `SELECT ` + salesPackageCols + ` FROM ` + models.SalesPackageTable + ` WHERE id = $1 AND deleted_at IS NULL`
+	pkg, err := scanSalesPackage(tx.QueryRowContext(ctx, SQL, id))
+	if err != nil {
 		return models.SalesPackage{}, err
 	}
-	pkg := models.NullAbleSalesPackageToSalesPackage(n)
 	buildings, err := r.findBuildingRefsBySalesPackageId(ctx, tx, pkg.Id)
 	if err != nil {
 		return models.SalesPackage{}, err
@@ -114,10 +136,20 @@ func (r *RepositorySalesPackageImpl) FindById(ctx context.Context, tx *sql.Tx, i
 	return pkg, nil
 }
 
-// Update updates name and replaces building links
+// FindAccess tells whether userId may see and change a sales package; sql.ErrNoRows when it does not exist or is in the trash
+func (r *RepositorySalesPackageImpl) FindAccess(ctx context.Context, tx *sql.Tx, id int, userId int) (models.Access, error) {
+	SQL := `SELECT ` + models.VisibleToCondition(models.SalesPackageTable, "$2") + `, ` + models.EditableByCondition(models.SalesPackageTable, "$2") + `
+		FROM ` + models.SalesPackageTable + ` WHERE id = $1 AND deleted_at IS NULL`
+	var access models.Access
+	err := tx.QueryRowContext(ctx, SQL, id, userId).Scan(&access.Visible, &access.Editable)
+	return access, err
+}
+
+// Update updates name, visibility and updated_by and replaces building links
 func (r *RepositorySalesPackageImpl) Update(ctx context.Context, tx *sql.Tx, pkg models.SalesPackage, buildingIds []int) (models.SalesPackage, error) {
-	SQL := `UPDATE ` + models.SalesPackageTable + ` SET name = $1, updated_at = $2 WHERE id = $3 RETURNING updated_at`
-	err := tx.QueryRowContext(ctx, SQL, pkg.Name, time.Now(), pkg.Id).Scan(&pkg.UpdatedAt)
+	SQL := `UPDATE ` + models.SalesPackageTable + ` SET name = $1, visibility = $2, updated_by = $3, updated_at = $4
+		WHERE id = $5 RETURNING updated_at`
+	err := tx.QueryRowContext(ctx, SQL, pkg.Name, pkg.Visibility, pkg.UpdatedBy, time.Now(), pkg.Id).Scan(&pkg.UpdatedAt)
 	if err != nil {
 		return models.SalesPackage{}, err
 	}
@@ -137,6 +169,18 @@ func (r *RepositorySalesPackageImpl) Update(ctx context.Context, tx *sql.Tx, pkg
 	return pkg, nil
 }
 
+// SetFilter stores the mapping filter (JSON) a package was built from and stamps filter_refreshed_at;
+// an empty filter clears both. Returns the stored refresh time.
+func (r *RepositorySalesPackageImpl) SetFilter(ctx context.Context, tx *sql.Tx, id int, filter string) (string, error) {
+	SQL := `UPDATE ` + models.SalesPackageTable + `
+		SET filter = NULLIF($1, '')::jsonb,
+			filter_refreshed_at = CASE WHEN $1 = '' THEN NULL ELSE NOW() END
+		WHERE id = $2 RETURNING filter_refreshed_at`
+	var refreshedAt sql.NullString
+	err := tx.QueryRowContext(ctx, SQL, filter, id).Scan(&refreshedAt)
+	return refreshedAt.String, err
+}
+
 // DeleteBuildingLinksBySalesPackageId removes all building links for a package
 func (r *RepositorySalesPackageImpl) DeleteBuildingLinksBySalesPackageId(ctx context.Context, tx *sql.Tx, salesPackageId int) error {
 	SQL := `DELETE FROM ` + models.SalesPackageBuildingTable + ` WHERE sales_package_id = $1`
@@ -151,25 +195,19 @@ func (r *RepositorySalesPackageImpl) CreateBuildingLink(ctx context.Context, tx
 	return err
 }
 
-// Delete deletes a sales package (CASCADE removes junction rows)
-func (r *RepositorySalesPackageImpl) Delete(ctx context.Context, tx *sql.Tx, id int) error {
-	SQL := `DELETE FROM ` + models.SalesPackageTable + ` WHERE id = $1`
-	_, err := tx.ExecContext(ctx, SQL, id)
+// Delete moves a sales package to the trash; its building links stay until it is purged
+func (r *RepositorySalesPackageImpl) Delete(ctx context.Context, tx *sql.Tx, id int, deletedBy *int) error {
+	SQL := `UPDATE ` + models.SalesPackageTable + ` SET deleted_at = $1, deleted_by = $2 WHERE id = $3 AND deleted_at IS NULL`
+	_, err := tx.ExecContext(ctx, SQL, time.Now(), deletedBy, id)
 	return err
 }
 
-// FindAllFlat returns all sales packages with their buildings, optionally filtered by name search (no pagination)
-func (r *RepositorySalesPackageImpl) FindAllFlat(ctx context.Context, tx *sql.Tx, search string) ([]models.SalesPackage, error) {
-	var rows *sql.Rows
-	var err error
-
-	if search != "" {
-		SQL := `SELECT id, name, created_at, updated_at FROM ` + models.SalesPackageTable + ` WHERE name ILIKE $1 ORDER BY name`
-		rows, err = tx.QueryContext(ctx, SQL, "%"+search+"%")
-	} else {
-		SQL := `SELECT id, name, created_at, updated_at FROM ` + models.SalesPackageTable + ` ORDER BY name`
-		rows, err = tx.QueryContext(ctx, SQL)
-	}
+// FindAllFlat returns all sales packages userId may see with their buildings, optionally filtered by name search (no pagination)
+func (r *RepositorySalesPackageImpl) FindAllFlat(ctx context.Context, tx *sql.Tx, userId int, search string) ([]models.SalesPackage, error) {
+	SQL := `SELECT ` + salesPackageCols + ` FROM ` + models.SalesPackageTable + `
+		WHERE deleted_at IS NULL AND ` + models.VisibleToCondition(models.SalesPackageTable, "$1") + ` AND ($2 = '' OR name ILIKE '%' || $2 || '%')
+		ORDER BY name`
+	rows, err := tx.QueryContext(ctx, SQL, userId, search)
 	if err != nil {
 		return nil, err
 	}
@@ -178,11 +216,10 @@ func (r *RepositorySalesPackageImpl) FindAllFlat(ctx context.Context, tx *sql.Tx
 	var packages []models.SalesPackage
 	var ids []int
 	for rows.Next() {
-		var n models.NullAbleSalesPackage
-		if err := rows.Scan(&n.Id, &n.Name, &n.CreatedAt, &n.UpdatedAt); err != nil {
+		pkg, err := scanSalesPackage(rows)
+		if err != nil {
 			return nil, err
 		}
-		pkg := models.NullAbleSalesPackageToSalesPackage(n)
 		ids = append(ids, pkg.Id)
 		packages = append(packages, pkg)
 	}
@@ -202,7 +239,7 @@ func (r *RepositorySalesPackageImpl) FindAllFlat(ctx context.Context, tx *sql.Tx
 	return packages, nil
 }
 
-// FindByNames returns sales packages whose name matches any in the given list
+// FindByNames returns live sales packages whose name matches any in the given list, oldest first
 func (r *RepositorySalesPackageImpl) FindByNames(ctx context.Context, tx *sql.Tx, names []string) ([]models.SalesPackage, error) {
 	if len(names) == 0 {
 		return nil, nil
@@ -213,7 +250,7 @@ func (r *RepositorySalesPackageImpl) FindByNames(ctx context.Context, tx *sql.Tx
 		placeholders[i] = "$" + strconv.Itoa(i+1)
 		args[i] = name
 	}
-	SQL := `SELECT id, name, created_at, updated_at FROM ` + models.SalesPackageTable + ` WHERE name IN (` + strings.Join(placeholders, ",") + `)`
+	SQL := `SELECT ` + salesPackageCols + ` FROM ` + models.SalesPackageTable + ` WHERE deleted_at IS NULL AND name IN (` + strings.Join(placeholders, ",") + `) ORDER BY id`
 	rows, err := tx.QueryContext(ctx, SQL, args...)
 	if err != nil {
 		return nil, err
@@ -222,15 +259,63 @@ func (r *RepositorySalesPackageImpl) FindByNames(ctx context.Context, tx *sql.Tx
 
 	var packages []models.SalesPackage
 	for rows.Next() {
-		var n models.NullAbleSalesPackage
-		if err := rows.Scan(&n.Id, &n.Name, &n.CreatedAt, &n.UpdatedAt); err != nil {
+		pkg, err := scanSalesPackage(rows)
+		if err != nil {
 			return nil, err
 		}
-		packages = append(packages, models.NullAbleSalesPackageToSalesPackage(n))
+		packages = append(packages, pkg)
 	}
 	return packages, rows.Err()
 }
 
+// FindSummaryRows returns the buildings of the given sales packages with audience, impressions,
+// screen count and the restrictions in force today, ordered by package and building name
+func (r *RepositorySalesPackageImpl) FindSummaryRows(ctx context.Context, tx *sql.Tx, salesPackageIds []int) ([]SalesPackageSummaryRow, error) {
+	if len(salesPackageIds) == 0 {
+		return []SalesPackageSummaryRow{}, nil
+	}
+	placeholders := make([]string, len(salesPackageIds))
+	args := make([]interface{}, len(salesPackageIds))
+	for i, id := range salesPackageIds {
+		placeholders[i] = "$" + strconv.Itoa(i+1)
+		args[i] = id
+	}
+	SQL := `SELECT spb.sales_package_id, b.id, b.name, COALESCE(b.building_type, ''), COALESCE(b.citytown, ''),
+		COALESCE(b.grade_resource, ''), COALESCE(b.sellable, ''), COALESCE(b.lcd_presence_status, ''),
+		COALESCE(b.audience, 0), COALESCE(b.impression, 0), COALESCE(bp.number_of_screen, 0), bp.id IS NOT NULL,
+		COALESCE((SELECT json_agg(br.name ORDER BY br.name) FROM ` + models.BuildingRestrictionBuildingTable + ` brb
+			INNER JOIN ` + models.BuildingRestrictionTable + ` br ON br.id = brb.building_restriction_id
+			WHERE brb.building_id = b.id AND br.deleted_at IS NULL
+			AND (br.valid_from IS NULL OR br.valid_from <= CURRENT_DATE)
+			AND (br.valid_until IS NULL OR br.valid_until >= CURRENT_DATE)), '[]')
+		FROM ` + models.SalesPackageBuildingTable + ` spb
+		INNER JOIN ` + models.BuildingTable + ` b ON b.id = spb.building_id
+		` + latestProposalJoin + `
+		WHERE spb.sales_package_id IN (` + strings.Join(placeholders, ",") + `)
+		ORDER BY spb.sales_package_id, b.name`
+	rows, err := tx.QueryContext(ctx, SQL, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := []SalesPackageSummaryRow{}
+	for rows.Next() {
+		var row SalesPackageSummaryRow
+		var restrictions []byte
+		if err := rows.Scan(&row.SalesPackageId, &row.BuildingId, &row.BuildingName, &row.BuildingType, &row.Citytown,
+			&row.GradeResource, &row.Sellable, &row.LcdPresenceStatus, &row.Audience, &row.Impression,
+			&row.Screens, &row.HasProposal, &restrictions); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(restrictions, &row.Restrictions); err != nil {
+			return nil, err
+		}
+		result = append(result, row)
+	}
+	return result, rows.Err()
+}
+
 // findBuildingRefsBySalesPackageId returns enriched building ref rows for a package
 func (r *RepositorySalesPackageImpl) findBuildingRefsBySalesPackageId(ctx context.Context, tx *sql.Tx, salesPackageId int) ([]models.BuildingRef, error) {
 	SQL := `SELECT b.id, b.name, b.project_name, b.subdistrict, b.citytown, b.province, b.building_type FROM ` + models.BuildingTable + ` b
@@ -289,6 +374,175 @@ func (r *RepositorySalesPackageImpl) findBuildingRefsBySalesPackageIds(ctx conte
 	return out, rows.Err()
 }
 
+// versionCols is scanned by scanVersion
+const versionCols = `v.id, v.sales_package_id, v.version, v.name, v.filter, v.note, v.building_count,
+	v.total_audience, v.total_impression, v.total_screens, v.created_by, v.created_at`
+
+func scanVersion(scanner interface {
+	Scan(dest ...any) error
+}) (models.SalesPackageVersion, error) {
+	var n models.NullAbleSalesPackageVersion
+	if err := scanner.Scan(&n.Id, &n.SalesPackageId, &n.Version, &n.Name, &n.Filter, &n.Note, &n.BuildingCount,
+		&n.TotalAudience, &n.TotalImpression, &n.TotalScreens, &n.CreatedBy, &n.CreatedAt); err != nil {
+		return models.SalesPackageVersion{}, err
+	}
+	return models.NullAbleSalesPackageVersionToSalesPackageVersion(n), nil
+}
+
+// CreateVersion snapshots the current state of a sales package as its next version: name, filter,
+// buildings with their audience, impressions and screens, and the totals of those. The package row
+// is locked so concurrent changes get consecutive version numbers.
+func (r *RepositorySalesPackageImpl) CreateVersion(ctx context.Context, tx *sql.Tx, salesPackageId int, note string, createdBy *int) (models.SalesPackageVersion, error) {
+	SQL := `SELECT id FROM ` + models.SalesPackageTable + ` WHERE id = $1 FOR UPDATE`
+	if err := tx.QueryRowContext(ctx, SQL, salesPackageId).Scan(&salesPackageId); err != nil {
+		return models.SalesPackageVersion{}, err
+	}
+
+	SQL = `INSERT INTO ` + models.SalesPackageVersionTable + ` (sales_package_id, version, name, filter, note, created_by)
+		SELECT sp.id, COALESCE((SELECT MAX(version) FROM ` + models.SalesPackageVersionTable + ` WHERE sales_package_id = sp.id), 0) + 1,
+			sp.name, sp.filter, NULLIF($2, ''), $3
+		FROM ` + models.SalesPackageTable + ` sp WHERE sp.id = $1
+		RETURNING id`
+	var versionId int
+	if err := tx.QueryRowContext(ctx, SQL, salesPackageId, note, createdBy).Scan(&versionId); err != nil {
+		return models.SalesPackageVersion{}, err
+	}
+
+	SQL = `INSERT INTO ` + models.SalesPackageVersionBuildingTable + ` (sales_package_version_id, building_id, building_name, audience, impression, screens)
+		SELECT $1, b.id, b.name, COALESCE(b.audience, 0), COALESCE(b.impression, 0), COALESCE(bp.number_of_screen, 0)
+		FROM ` + models.SalesPackageBuildingTable + ` spb
+		INNER JOIN ` + models.BuildingTable + ` b ON b.id = spb.building_id
+		` + latestProposalJoin + `
+		WHERE spb.sales_package_id = $2`
+	if _, err := tx.ExecContext(ctx, SQL, versionId, salesPackageId); err != nil {
+		return models.SalesPackageVersion{}, err
+	}
+
+	SQL = `UPDATE ` + models.SalesPackageVersionTable + ` v SET
+			building_count = t.building_count, total_audience = t.total_audience,
+			total_impression = t.total_impression, total_screens = t.total_screens
+		FROM (
+			SELECT COUNT(*) AS building_count, COALESCE(SUM(audience), 0) AS total_audience,
+				COALESCE(SUM(impression), 0) AS total_impression, COALESCE(SUM(screens), 0) AS total_screens
+			FROM ` + models.SalesPackageVersionBuildingTable + ` WHERE sales_package_version_id = $1
+		) t
+		WHERE v.id = $1
+		RETURNING ` + versionCols
+	version, err := scanVersion(tx.QueryRowContext(ctx, SQL, versionId))
+	if err != nil {
+		return models.SalesPackageVersion{}, err
+	}
+	buildings, err := r.findVersionBuildings(ctx, tx, versionId)
+	if err != nil {
+		return models.SalesPackageVersion{}, err
+	}
+	version.Buildings = buildings
+	return version, nil
+}
+
+// FindVersions returns the versions of a sales package, newest first, without their buildings
+func (r *RepositorySalesPackageImpl) FindVersions(ctx context.Context, tx *sql.Tx, salesPackageId int) ([]models.SalesPackageVersion, error) {
+	SQL := `SELECT ` + versionCols + ` FROM ` + models.SalesPackageVersionTable + ` v
+		WHERE v.sales_package_id = $1 ORDER BY v.version DESC`
+	rows, err := tx.QueryContext(ctx, SQL, salesPackageId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	versions := []models.SalesPackageVersion{}
+	for rows.Next() {
+		version, err := scanVersion(rows)
+		if err != nil {
+			return nil, err
+		}
+		versions = append(versions, version)
+	}
+	return versions, rows.Err()
+}
+
+// FindVersion returns one version of a sales package with its buildings; version 0 is the latest.
+// Returns sql.ErrNoRows when there is no such version or the package is in the trash.
+func (r *RepositorySalesPackageImpl) FindVersion(ctx context.Context, tx *sql.Tx, salesPackageId int, version int) (models.SalesPackageVersion, error) {
+	SQL := `SELECT ` + versionCols + ` FROM ` + models.SalesPackageVersionTable + ` v
+		INNER JOIN ` + models.SalesPackageTable + ` p ON p.id = v.sales_package_id AND p.deleted_at IS NULL
+		WHERE v.sales_package_id = $1 AND ($2 = 0 OR v.version = $2)
+		ORDER BY v.version DESC LIMIT 1`
+	found, err := scanVersion(tx.QueryRowContext(ctx, SQL, salesPackageId, version))
+	if err != nil {
+		return models.SalesPackageVersion{}, err
+	}
+	buildings, err := r.findVersionBuildings(ctx, tx, found.Id)
+	if err != nil {
+		return models.SalesPackageVersion{}, err
+	}
+	found.Buildings = buildings
+	return found, nil
+}
+
+// FindVersionSummaryRows returns the buildings of a version in the shape of FindSummaryRows.
+// Audience, impressions and screens are the ones stored with the version; the other columns are
+// read from the building as it is now and HasProposal is set when the version recorded screens.
+// Buildings deleted since the version was taken are left out.
+func (r *RepositorySalesPackageImpl) FindVersionSummaryRows(ctx context.Context, tx *sql.Tx, salesPackageVersionId int) ([]SalesPackageSummaryRow, error) {
+	SQL := `SELECT v.sales_package_id, b.id, vb.building_name, COALESCE(b.building_type, ''), COALESCE(b.citytown, ''),
+		COALESCE(b.grade_resource, ''), COALESCE(b.sellable, ''), COALESCE(b.lcd_presence_status, ''),
+		vb.audience, vb.impression, vb.screens
+		FROM ` + models.SalesPackageVersionBuildingTable + ` vb
+		INNER JOIN ` + models.SalesPackageVersionTable + ` v ON v.id = vb.sales_package_version_id
+		INNER JOIN ` + models.BuildingTable + ` b ON b.id = vb.building_id
+		WHERE vb.sales_package_version_id = $1
+		ORDER BY vb.building_name`
+	rows, err := tx.QueryContext(ctx, SQL, salesPackageVersionId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := []SalesPackageSummaryRow{}
+	for rows.Next() {
+		var row SalesPackageSummaryRow
+		if err := rows.Scan(&row.SalesPackageId, &row.BuildingId, &row.BuildingName, &row.BuildingType, &row.Citytown,
+			&row.GradeResource, &row.Sellable, &row.LcdPresenceStatus, &row.Audience, &row.Impression, &row.Screens); err != nil {
+			return nil, err
+		}
+		row.HasProposal = row.Screens > 0
+		result = append(result, row)
+	}
+	return result, rows.Err()
+}
+
+// findVersionBuildings returns the buildings of a version ordered by name
+func (r *RepositorySalesPackageImpl) findVersionBuildings(ctx context.Context, tx *sql.Tx, salesPackageVersionId int) ([]models.SalesPackageVersionBuilding, error) {
+	SQL := `SELECT vb.building_id, vb.building_name, COALESCE(b.citytown, ''), COALESCE(b.building_type, ''),
+		vb.audience, vb.impression, vb.screens
+		FROM ` + models.SalesPackageVersionBuildingTable + ` vb
+		LEFT JOIN ` + models.BuildingTable + ` b ON b.id = vb.building_id
+		WHERE vb.sales_package_version_id = $1
+		ORDER BY vb.building_name, vb.id`
+	rows, err := tx.QueryContext(ctx, SQL, salesPackageVersionId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	buildings := []models.SalesPackageVersionBuilding{}
+	for rows.Next() {
+		var building models.SalesPackageVersionBuilding
+		var buildingId sql.NullInt64
+		if err := rows.Scan(&buildingId, &building.BuildingName, &building.Citytown, &building.BuildingType,
+			&building.Audience, &building.Impression, &building.Screens); err != nil {
+			return nil, err
+		}
+		if buildingId.Valid {
+			id := int(buildingId.Int64)
+			building.BuildingId = &id
+		}
+		buildings = append(buildings, building)
+	}
+	return buildings, rows.Err()
+}
+
 // scanBuildingRef scans the 7-column enriched BuildingRef projection.
 // Nullable columns on the buildings table are stored as sql.NullString
 // to tolerate legacy NULLs, then collapsed to empty string for the API.
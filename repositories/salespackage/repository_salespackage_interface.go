@@ -7,15 +7,41 @@ import (
 	"github.com/malikabdulaziz/tmn-backend/models"
 )
 
+// SalesPackageSummaryRow is a building of a sales package with the figures its summary adds up.
+// Screens come from the latest building proposal of the building's project (HasProposal is false
+// when there is none); Restrictions names every building restriction that lists the building.
+type SalesPackageSummaryRow struct {
+	SalesPackageId    int
+	BuildingId        int
+	BuildingName      string
+	BuildingType      string
+	Citytown          string
+	GradeResource     string
+	Sellable          string
+	LcdPresenceStatus string
+	Audience          int
+	Impression        int
+	Screens           int
+	HasProposal       bool
+	Restrictions      []string
+}
+
 type RepositorySalesPackageInterface interface {
 	Create(ctx context.Context, tx *sql.Tx, pkg models.SalesPackage, buildingIds []int) (models.SalesPackage, error)
-	FindAll(ctx context.Context, tx *sql.Tx, take int, skip int, orderBy string, orderDirection string) ([]models.SalesPackage, error)
-	CountAll(ctx context.Context, tx *sql.Tx) (int, error)
+	FindAll(ctx context.Context, tx *sql.Tx, userId int, take int, skip int, orderBy string, orderDirection string) ([]models.SalesPackage, error)
+	CountAll(ctx context.Context, tx *sql.Tx, userId int) (int, error)
 	FindById(ctx context.Context, tx *sql.Tx, id int) (models.SalesPackage, error)
+	FindAccess(ctx context.Context, tx *sql.Tx, id int, userId int) (models.Access, error)
 	Update(ctx context.Context, tx *sql.Tx, pkg models.SalesPackage, buildingIds []int) (models.SalesPackage, error)
+	SetFilter(ctx context.Context, tx *sql.Tx, id int, filter string) (string, error)
 	DeleteBuildingLinksBySalesPackageId(ctx context.Context, tx *sql.Tx, salesPackageId int) error
 	CreateBuildingLink(ctx context.Context, tx *sql.Tx, salesPackageId int, buildingId int) error
-	Delete(ctx context.Context, tx *sql.Tx, id int) error
-	FindAllFlat(ctx context.Context, tx *sql.Tx, search string) ([]models.SalesPackage, error)
+	Delete(ctx context.Context, tx *sql.Tx, id int, deletedBy *int) error
+	FindAllFlat(ctx context.Context, tx *sql.Tx, userId int, search string) ([]models.SalesPackage, error)
 	FindByNames(ctx context.Context, tx *sql.Tx, names []string) ([]models.SalesPackage, error)
+	FindSummaryRows(ctx context.Context, tx *sql.Tx, salesPackageIds []int) ([]SalesPackageSummaryRow, error)
+	CreateVersion(ctx context.Context, tx *sql.Tx, salesPackageId int, note string, createdBy *int) (models.SalesPackageVersion, error)
+	FindVersions(ctx context.Context, tx *sql.Tx, salesPackageId int) ([]models.SalesPackageVersion, error)
+	FindVersion(ctx context.Context, tx *sql.Tx, salesPackageId int, version int) (models.SalesPackageVersion, error)
+	FindVersionSummaryRows(ctx context.Context, tx *sql.Tx, salesPackageVersionId int) ([]SalesPackageSummaryRow, error)
 }
@@ -29,13 +29,26 @@ func safeOrder(orderBy, orderDirection string) (string, string) {
 	return orderBy, orderDirection
 }
 
-// Create inserts a new saved polygon and its points
+// savedPolygonCols is scanned by scanSavedPolygon
+const savedPolygonCols = `id, name, created_by, updated_by, visibility, created_at, updated_at`
+
+func scanSavedPolygon(scanner interface{ Scan(...interface{}) error }) (models.SavedPolygon, error) {
+	var n models.NullAbleSavedPolygon
+	if err := scanner.Scan(&n.Id, &n.Name, &n.CreatedBy, &n.UpdatedBy, &n.Visibility, &n.CreatedAt, &n.UpdatedAt); err != nil {
+		return models.SavedPolygon{}, err
+	}
+	return models.NullAbleSavedPolygonToSavedPolygon(n), nil
+}
+
+// Create inserts a new saved polygon owned by polygon.CreatedBy and its points
 func (r *RepositorySavedPolygonImpl) Create(ctx context.Context, tx *sql.Tx, polygon models.SavedPolygon, points []models.SavedPolygonPoint) (models.SavedPolygon, error) {
-	SQL := `INSERT INTO ` + models.SavedPolygonTable + ` (name) VALUES ($1) RETURNING id, created_at, updated_at`
-	err := tx.QueryRowContext(ctx, SQL, polygon.Name).Scan(&polygon.Id, &polygon.CreatedAt, &polygon.UpdatedAt)
+	SQL := `INSERT INTO ` + models.SavedPolygonTable + ` (name, created_by, updated_by, visibility) VALUES ($1, $2, $2, $3)
+		RETURNING id, created_at, updated_at`
+	err := tx.QueryRowContext(ctx, SQL, polygon.Name, polygon.CreatedBy, polygon.Visibility).Scan(&polygon.Id, &polygon.CreatedAt, &polygon.UpdatedAt)
 	if err != nil {
 		return models.SavedPolygon{}, err
 	}
+	polygon.UpdatedBy = polygon.CreatedBy
 	for i := range points {
 		points[i].SavedPolygonId = polygon.Id
 		points[i].Ord = i
@@ -58,12 +71,13 @@ func (r *RepositorySavedPolygonImpl) CreatePoint(ctx context.Context, tx *sql.Tx
 	return point, nil
 }
 
-// FindAll retrieves all saved polygons with points, with pagination and ordering
-func (r *RepositorySavedPolygonImpl) FindAll(ctx context.Context, tx *sql.Tx, take int, skip int, orderBy string, orderDirection string) ([]models.SavedPolygon, error) {
+// FindAll retrieves the saved polygons userId may see with points, with pagination and ordering
+func (r *RepositorySavedPolygonImpl) FindAll(ctx context.Context, tx *sql.Tx, userId int, take int, skip int, orderBy string, orderDirection string) ([]models.SavedPolygon, error) {
 	orderBy, orderDirection = safeOrder(orderBy, orderDirection)
-	SQL := `SELECT id, name, created_at, updated_at FROM ` + models.SavedPolygonTable + `
-		ORDER BY ` + orderBy + ` ` + orderDirection + `, name ASC LIMIT $1 OFFSET $2`
-	rows, err := tx.QueryContext(ctx, SQL, take, skip)
+	SQL := `SELECT ` + savedPolygonCols + ` FROM ` + models.SavedPolygonTable + `
+		WHERE deleted_at IS NULL AND ` + models.VisibleToCondition(models.SavedPolygonTable, "$1") + `
+		ORDER BY ` + orderBy + ` ` + orderDirection + `, name ASC LIMIT $2 OFFSET $3`
+	rows, err := tx.QueryContext(ctx, SQL, userId, take, skip)
 	if err != nil {
 		return nil, err
 	}
@@ -72,11 +86,10 @@ func (r *RepositorySavedPolygonImpl) FindAll(ctx context.Context, tx *sql.Tx, ta
 	var polygons []models.SavedPolygon
 	var ids []int
 	for rows.Next() {
-		var n models.NullAbleSavedPolygon
-		if err := rows.Scan(&n.Id, &n.Name, &n.CreatedAt, &n.UpdatedAt); err != nil {
+		poly, err := scanSavedPolygon(rows)
+		if err != nil {
 			return nil, err
 		}
-		poly := models.NullAbleSavedPolygonToSavedPolygon(n)
 		ids = append(ids, poly.Id)
 		polygons = append(polygons, poly)
 	}
@@ -96,23 +109,21 @@ func (r *RepositorySavedPolygonImpl) FindAll(ctx context.Context, tx *sql.Tx, ta
 	return polygons, nil
 }
 
-// CountAll returns total count of saved polygons
-func (r *RepositorySavedPolygonImpl) CountAll(ctx context.Context, tx *sql.Tx) (int, error) {
-	SQL := `SELECT COUNT(*) FROM ` + models.SavedPolygonTable
+// CountAll returns total count of saved polygons userId may see
+func (r *RepositorySavedPolygonImpl) CountAll(ctx context.Context, tx *sql.Tx, userId int) (int, error) {
+	SQL := `SELECT COUNT(*) FROM ` + models.SavedPolygonTable + ` WHERE deleted_at IS NULL AND ` + models.VisibleToCondition(models.SavedPolygonTable, "$1")
 	var total int
-	err := tx.QueryRowContext(ctx, SQL).Scan(&total)
+	err := tx.QueryRowContext(ctx, SQL, userId).Scan(&total)
 	return total, err
 }
 
 // FindById retrieves a saved polygon by ID with its points
 func (r *RepositorySavedPolygonImpl) FindById(ctx context.Context, tx *sql.Tx, id int) (models.SavedPolygon, error) {
-	SQL := `SELECT id, name, created_at, updated_at FROM ` + models.SavedPolygonTable + ` WHERE id = $1`
-	row := tx.QueryRowContext(ctx, SQL, id)
-	var n models.NullAbleSavedPolygon
-	if err := row.Scan(&n.Id, &n.Name, &n.CreatedAt, &n.UpdatedAt); err != nil {
+	SQL := `SELECT ` + savedPolygonCols + ` FROM ` + models.SavedPolygonTable + ` WHERE id = $1 AND deleted_at IS NULL`
+	poly, err := scanSavedPolygon(tx.QueryRowContext(ctx, SQL, id))
+	if err != nil {
 		return models.SavedPolygon{}, err
 	}
-	poly := models.NullAbleSavedPolygonToSavedPolygon(n)
 	points, err := r.findPointsBySavedPolygonId(ctx, tx, poly.Id)
 	if err != nil {
 		return models.SavedPolygon{}, err
@@ -121,6 +132,15 @@ func (r *RepositorySavedPolygonImpl) FindById(ctx context.Context, tx *sql.Tx, i
 	return poly, nil
 }
 
+// FindAccess tells whether userId may see and change a saved polygon; sql.ErrNoRows when it does not exist or is in the trash
+func (r *RepositorySavedPolygonImpl) FindAccess(ctx context.Context, tx *sql.Tx, id int, userId int) (models.Access, error) {
+	SQL := `SELECT ` + models.VisibleToCondition(models.SavedPolygonTable, "$2") + `, ` + models.EditableByCondition(models.SavedPolygonTable, "$2") + `
+		FROM ` + models.SavedPolygonTable + ` WHERE id = $1 AND deleted_at IS NULL`
+	var access models.Access
+	err := tx.QueryRowContext(ctx, SQL, id, userId).Scan(&access.Visible, &access.Editable)
+	return access, err
+}
+
 func (r *RepositorySavedPolygonImpl) findPointsBySavedPolygonId(ctx context.Context, tx *sql.Tx, savedPolygonId int) ([]models.SavedPolygonPoint, error) {
 	SQL := `SELECT id, saved_polygon_id, ord, lat, lng, created_at FROM ` + models.SavedPolygonPointTable + `
 		WHERE saved_polygon_id = $1 ORDER BY ord ASC`
@@ -169,10 +189,11 @@ func (r *RepositorySavedPolygonImpl) findPointsBySavedPolygonIds(ctx context.Con
 	return out, rows.Err()
 }
 
-// Update updates a saved polygon and replaces its points
+// Update updates name, visibility and updated_by of a saved polygon and replaces its points
 func (r *RepositorySavedPolygonImpl) Update(ctx context.Context, tx *sql.Tx, polygon models.SavedPolygon, points []models.SavedPolygonPoint) (models.SavedPolygon, error) {
-	SQL := `UPDATE ` + models.SavedPolygonTable + ` SET name = $1, updated_at = $2 WHERE id = $3 RETURNING updated_at`
-	err := tx.QueryRowContext(ctx, SQL, polygon.Name, time.Now(), polygon.Id).Scan(&polygon.UpdatedAt)
+	SQL := `UPDATE ` + models.SavedPolygonTable + ` SET name = $1, visibility = $2, updated_by = $3, updated_at = $4
+		WHERE id = $5 RETURNING updated_at`
+	err := tx.QueryRowContext(ctx, SQL, polygon.Name, polygon.Visibility, polygon.UpdatedBy, time.Now(), polygon.Id).Scan(&polygon.UpdatedAt)
 	if err != nil {
 		return models.SavedPolygon{}, err
 	}
@@ -199,9 +220,9 @@ func (r *RepositorySavedPolygonImpl) DeletePointsBySavedPolygonId(ctx context.Co
 	return err
 }
 
-// Delete deletes a saved polygon (CASCADE deletes points)
-func (r *RepositorySavedPolygonImpl) Delete(ctx context.Context, tx *sql.Tx, id int) error {
-	SQL := `DELETE FROM ` + models.SavedPolygonTable + ` WHERE id = $1`
-	_, err := tx.ExecContext(ctx, SQL, id)
+// Delete moves a saved polygon to the trash; its points stay until it is purged
+func (r *RepositorySavedPolygonImpl) Delete(ctx context.Context, tx *sql.Tx, id int, deletedBy *int) error {
+	SQL := `UPDATE ` + models.SavedPolygonTable + ` SET deleted_at = $1, deleted_by = $2 WHERE id = $3 AND deleted_at IS NULL`
+	_, err := tx.ExecContext(ctx, SQL, time.Now(), deletedBy, id)
 	return err
 }
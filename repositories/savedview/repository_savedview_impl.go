@@ -0,0 +1,150 @@
+package savedview
+
+import (
+	"context"
+	"database/sql"
+	"strconv"
+	"strings"
+
+	"github.com/malikabdulaziz/tmn-backend/models"
+)
+
+type RepositorySavedViewImpl struct{}
+
+func NewRepositorySavedViewImpl() RepositorySavedViewInterface {
+	return &RepositorySavedViewImpl{}
+}
+
+var allowedOrderBy = map[string]bool{"id": true, "name": true, "created_at": true, "updated_at": true}
+var allowedOrderDir = map[string]bool{"ASC": true, "DESC": true}
+
+func safeOrder(orderBy, orderDirection string) (string, string) {
+	if !allowedOrderBy[orderBy] {
+		orderBy = "updated_at"
+	}
+	if !allowedOrderDir[orderDirection] {
+		orderDirection = "DESC"
+	}
+	return orderBy, orderDirection
+}
+
+const savedViewCols = `sv.id, sv.name, sv.filters, sv.map_center_lat, sv.map_center_lng, sv.zoom, sv.sort_by, sv.visibility,
+	sv.created_by, u.name, sv.created_at, sv.updated_at`
+
+var savedViewJoins = `
+		LEFT JOIN ` + models.UserTable + ` u ON u.id = sv.created_by`
+
+func scanSavedView(scanner interface{ Scan(...interface{}) error }) (models.SavedView, error) {
+	var n models.NullAbleSavedView
+	if err := scanner.Scan(&n.Id, &n.Name, &n.Filters, &n.MapCenterLat, &n.MapCenterLng, &n.Zoom, &n.SortBy, &n.Visibility,
+		&n.CreatedBy, &n.CreatedByName, &n.CreatedAt, &n.UpdatedAt); err != nil {
+		return models.SavedView{}, err
+	}
+	return models.NullAbleSavedViewToSavedView(n), nil
+}
+
+func buildWhere(filter SavedViewFilter) (string, []interface{}) {
+	var conditions []string
+	var args []interface{}
+	add := func(condition string, value interface{}) {
+		args = append(args, value)
+		conditions = append(conditions, strings.ReplaceAll(condition, "?", "$"+strconv.Itoa(len(args))))
+	}
+	switch filter.Scope {
+	case ScopeMine:
+		add("sv.created_by = ?", filter.UserId)
+	case ScopeShared:
+		add("sv.created_by <> ? AND "+models.VisibleToCondition("sv", "?"), filter.UserId)
+	default:
+		add(models.VisibleToCondition("sv", "?"), filter.UserId)
+	}
+	if filter.Name != "" {
+		add("sv.name ILIKE ?", "%"+filter.Name+"%")
+	}
+	return " WHERE " + strings.Join(conditions, " AND "), args
+}
+
+func (r *RepositorySavedViewImpl) Create(ctx context.Context, tx *sql.Tx, view models.SavedView) (models.SavedView, error) {
+	SQL := `INSERT INTO ` + models.SavedViewTable + ` (name, filters, map_center_lat, map_center_lng, zoom, sort_by, visibility, created_by)
+		VALUES ($1, $2::jsonb, $3, $4, $5, NULLIF($6, ''), $7, $8) RETURNING id`
+	var id int
+	err := tx.QueryRowContext(ctx, SQL, view.Name, view.Filters, view.MapCenterLat, view.MapCenterLng, view.Zoom, view.SortBy,
+		view.Visibility, view.CreatedBy).Scan(&id)
+	if err != nil {
+		return models.SavedView{}, err
+	}
+	return r.FindById(ctx, tx, id)
+}
+
+// FindAll retrieves the saved views matching the filter
+func (r *RepositorySavedViewImpl) FindAll(ctx context.Context, tx *sql.Tx, filter SavedViewFilter, take int, skip int, orderBy string, orderDirection string) ([]models.SavedView, error) {
+	orderBy, orderDirection = safeOrder(orderBy, orderDirection)
+	where, args := buildWhere(filter)
+	args = append(args, take, skip)
+	SQL := `SELECT ` + savedViewCols + ` FROM ` + models.SavedViewTable + ` sv` + savedViewJoins + where + `
+		ORDER BY sv.` + orderBy + ` ` + orderDirection + `, sv.id DESC
+		LIMIT $` + strconv.Itoa(len(args)-1) + ` OFFSET $` + strconv.Itoa(len(args))
+	rows, err := tx.QueryContext(ctx, SQL, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	views := []models.SavedView{}
+	for rows.Next() {
+		view, err := scanSavedView(rows)
+		if err != nil {
+			return nil, err
+		}
+		views = append(views, view)
+	}
+	return views, rows.Err()
+}
+
+// CountAll returns the number of saved views matching the filter
+func (r *RepositorySavedViewImpl) CountAll(ctx context.Context, tx *sql.Tx, filter SavedViewFilter) (int, error) {
+	where, args := buildWhere(filter)
+	SQL := `SELECT COUNT(*) FROM ` + models.SavedViewTable + ` sv` + where
+	var total int
+	err := tx.QueryRowContext(ctx, SQL, args...).Scan(&total)
+	return total, err
+}
+
+func (r *RepositorySavedViewImpl) FindById(ctx context.Context, tx *sql.Tx, id int) (models.SavedView, error) {
+	SQL := `SELECT ` + savedViewCols + ` FROM ` + models.SavedViewTable + ` sv` + savedViewJoins + `
+		WHERE sv.id = $1`
+	return scanSavedView(tx.QueryRowContext(ctx, SQL, id))
+}
+
+// FindAccess tells whether userId may see and change a saved view; sql.ErrNoRows when it does not exist
+func (r *RepositorySavedViewImpl) FindAccess(ctx context.Context, tx *sql.Tx, id int, userId int) (models.Access, error) {
+	SQL := `SELECT ` + models.VisibleToCondition("sv", "$2") + `, ` + models.EditableByCondition("sv", "$2") + `
+		FROM ` + models.SavedViewTable + ` sv WHERE sv.id = $1`
+	var access models.Access
+	err := tx.QueryRowContext(ctx, SQL, id, userId).Scan(&access.Visible, &access.Editable)
+	return access, err
+}
+
+// FindByName retrieves a user's saved view by its exact name, ignoring case
+func (r *RepositorySavedViewImpl) FindByName(ctx context.Context, tx *sql.Tx, createdBy int, name string) (models.SavedView, error) {
+	SQL := `SELECT ` + savedViewCols + ` FROM ` + models.SavedViewTable + ` sv` + savedViewJoins + `
+		WHERE sv.created_by = $1 AND LOWER(sv.name) = LOWER($2)`
+	return scanSavedView(tx.QueryRowContext(ctx, SQL, createdBy, name))
+}
+
+func (r *RepositorySavedViewImpl) Update(ctx context.Context, tx *sql.Tx, view models.SavedView) (models.SavedView, error) {
+	SQL := `UPDATE ` + models.SavedViewTable + ` SET name = $1, filters = $2::jsonb, map_center_lat = $3, map_center_lng = $4, zoom = $5,
+		sort_by = NULLIF($6, ''), visibility = $7, updated_at = CURRENT_TIMESTAMP WHERE id = $8`
+	_, err := tx.ExecContext(ctx, SQL, view.Name, view.Filters, view.MapCenterLat, view.MapCenterLng, view.Zoom, view.SortBy,
+		view.Visibility, view.Id)
+	if err != nil {
+		return models.SavedView{}, err
+	}
+	return r.FindById(ctx, tx, view.Id)
+}
+
+func (r *RepositorySavedViewImpl) Delete(ctx context.Context, tx *sql.Tx, id int) error {
+	SQL := `DELETE FROM ` + models.SavedViewTable + ` WHERE id = $1`
+	_, err := tx.ExecContext(ctx, SQL, id)
+	return err
+}
@@ -0,0 +1,35 @@
+package savedview_test
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	repositoriesSavedView "github.com/malikabdulaziz/tmn-backend/repositories/savedview"
+	"github.com/malikabdulaziz/tmn-backend/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestCountAll_SharedScopeUsesVisibility verifies that the views of other users are listed by
+// their visibility level, so a team view is not shown to users outside the owner's team.
+func TestCountAll_SharedScopeUsesVisibility(t *testing.T) {
+	db, sqlMock := testutil.NewMockDB(t)
+	repo := repositoriesSavedView.NewRepositorySavedViewImpl()
+
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectQuery(regexp.QuoteMeta(`WHERE sv.created_by <> $1 AND (sv.visibility = 'public' OR sv.created_by = $1`)).
+		WithArgs(7, "%offices%").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+	sqlMock.ExpectCommit()
+
+	tx, err := db.Begin()
+	assert.NoError(t, err)
+	filter := repositoriesSavedView.SavedViewFilter{UserId: 7, Scope: repositoriesSavedView.ScopeShared, Name: "offices"}
+	total, err := repo.CountAll(context.Background(), tx, filter)
+	assert.NoError(t, err)
+	assert.NoError(t, tx.Commit())
+
+	assert.Equal(t, 0, total)
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+}
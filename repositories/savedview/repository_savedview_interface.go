@@ -0,0 +1,34 @@
+package savedview
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/malikabdulaziz/tmn-backend/models"
+)
+
+// Saved view scopes for FindAll; the default lists every view the user can see
+const (
+	ScopeVisible = ""
+	ScopeMine    = "mine"
+	ScopeShared  = "shared"
+)
+
+// SavedViewFilter narrows FindAll and CountAll to the views UserId can see in Scope, optionally
+// matching Name. Shared lists the views of other users that UserId can see.
+type SavedViewFilter struct {
+	UserId int
+	Scope  string
+	Name   string
+}
+
+type RepositorySavedViewInterface interface {
+	Create(ctx context.Context, tx *sql.Tx, view models.SavedView) (models.SavedView, error)
+	FindAll(ctx context.Context, tx *sql.Tx, filter SavedViewFilter, take int, skip int, orderBy string, orderDirection string) ([]models.SavedView, error)
+	CountAll(ctx context.Context, tx *sql.Tx, filter SavedViewFilter) (int, error)
+	FindById(ctx context.Context, tx *sql.Tx, id int) (models.SavedView, error)
+	FindAccess(ctx context.Context, tx *sql.Tx, id int, userId int) (models.Access, error)
+	FindByName(ctx context.Context, tx *sql.Tx, createdBy int, name string) (models.SavedView, error)
+	Update(ctx context.Context, tx *sql.Tx, view models.SavedView) (models.SavedView, error)
+	Delete(ctx context.Context, tx *sql.Tx, id int) error
+}
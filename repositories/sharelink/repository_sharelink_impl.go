@@ -0,0 +1,147 @@
+package sharelink
+
+import (
+	"context"
+	"database/sql"
+	"strconv"
+	"strings"
+
+	"github.com/malikabdulaziz/tmn-backend/models"
+)
+
+type RepositoryShareLinkImpl struct{}
+
+func NewRepositoryShareLinkImpl() RepositoryShareLinkInterface {
+	return &RepositoryShareLinkImpl{}
+}
+
+var allowedOrderBy = map[string]bool{"id": true, "title": true, "target_type": true, "expires_at": true, "view_count": true, "created_at": true}
+var allowedOrderDir = map[string]bool{"ASC": true, "DESC": true}
+
+func safeOrder(orderBy, orderDirection string) (string, string) {
+	if !allowedOrderBy[orderBy] {
+		orderBy = "created_at"
+	}
+	if !allowedOrderDir[orderDirection] {
+		orderDirection = "DESC"
+	}
+	return orderBy, orderDirection
+}
+
+const shareLinkCols = `sl.id, sl.key, sl.target_type, sl.sales_package_id, sl.sales_package_version_id, spv.version, sl.saved_polygon_id,
+	sl.filter, sl.title, sl.password_hash, sl.expires_at, sl.expires_at <= CURRENT_TIMESTAMP, sl.revoked_at, sl.view_count,
+	sl.last_viewed_at, sl.created_by, sl.created_at`
+
+var shareLinkJoins = `
+		LEFT JOIN ` + models.SalesPackageVersionTable + ` spv ON spv.id = sl.sales_package_version_id`
+
+func scanShareLink(scanner interface{ Scan(...interface{}) error }) (models.ShareLink, error) {
+	var n models.NullAbleShareLink
+	if err := scanner.Scan(&n.Id, &n.Key, &n.TargetType, &n.SalesPackageId, &n.SalesPackageVersionId, &n.SalesPackageVersion, &n.SavedPolygonId,
+		&n.Filter, &n.Title, &n.PasswordHash, &n.ExpiresAt, &n.Expired, &n.RevokedAt, &n.ViewCount,
+		&n.LastViewedAt, &n.CreatedBy, &n.CreatedAt); err != nil {
+		return models.ShareLink{}, err
+	}
+	return models.NullAbleShareLinkToShareLink(n), nil
+}
+
+func buildWhere(filter ShareLinkFilter) (string, []interface{}) {
+	var conditions []string
+	var args []interface{}
+	add := func(condition string, value interface{}) {
+		args = append(args, value)
+		conditions = append(conditions, strings.ReplaceAll(condition, "?", "$"+strconv.Itoa(len(args))))
+	}
+	if filter.CreatedBy > 0 {
+		add("sl.created_by = ?", filter.CreatedBy)
+	}
+	if filter.TargetType != "" {
+		add("sl.target_type = ?", filter.TargetType)
+	}
+	if filter.SalesPackageId > 0 {
+		add("sl.sales_package_id = ?", filter.SalesPackageId)
+	}
+	if filter.SavedPolygonId > 0 {
+		add("sl.saved_polygon_id = ?", filter.SavedPolygonId)
+	}
+	if len(conditions) == 0 {
+		return "", args
+	}
+	return " WHERE " + strings.Join(conditions, " AND "), args
+}
+
+// Create stores a share link that expires expiresInDays from now
+func (r *RepositoryShareLinkImpl) Create(ctx context.Context, tx *sql.Tx, link models.ShareLink, expiresInDays int) (models.ShareLink, error) {
+	SQL := `INSERT INTO ` + models.ShareLinkTable + ` (key, target_type, sales_package_id, sales_package_version_id, saved_polygon_id,
+		filter, title, password_hash, expires_at, created_by)
+		VALUES ($1, $2, $3, $4, $5, NULLIF($6, '')::jsonb, $7, NULLIF($8, ''), CURRENT_TIMESTAMP + make_interval(days => $9), $10)
+		RETURNING id`
+	var id int
+	err := tx.QueryRowContext(ctx, SQL, link.Key, link.TargetType, link.SalesPackageId, link.SalesPackageVersionId, link.SavedPolygonId,
+		link.Filter, link.Title, link.PasswordHash, expiresInDays, link.CreatedBy).Scan(&id)
+	if err != nil {
+		return models.ShareLink{}, err
+	}
+	return r.FindById(ctx, tx, id)
+}
+
+// FindAll retrieves share links matching the filter, revoked and expired ones included
+func (r *RepositoryShareLinkImpl) FindAll(ctx context.Context, tx *sql.Tx, filter ShareLinkFilter, take int, skip int, orderBy string, orderDirection string) ([]models.ShareLink, error) {
+	orderBy, orderDirection = safeOrder(orderBy, orderDirection)
+	where, args := buildWhere(filter)
+	args = append(args, take, skip)
+	SQL := `SELECT ` + shareLinkCols + ` FROM ` + models.ShareLinkTable + ` sl` + shareLinkJoins + where + `
+		ORDER BY sl.` + orderBy + ` ` + orderDirection + `, sl.id DESC
+		LIMIT $` + strconv.Itoa(len(args)-1) + ` OFFSET $` + strconv.Itoa(len(args))
+	rows, err := tx.QueryContext(ctx, SQL, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	links := []models.ShareLink{}
+	for rows.Next() {
+		link, err := scanShareLink(rows)
+		if err != nil {
+			return nil, err
+		}
+		links = append(links, link)
+	}
+	return links, rows.Err()
+}
+
+// CountAll returns the number of share links matching the filter
+func (r *RepositoryShareLinkImpl) CountAll(ctx context.Context, tx *sql.Tx, filter ShareLinkFilter) (int, error) {
+	where, args := buildWhere(filter)
+	SQL := `SELECT COUNT(*) FROM ` + models.ShareLinkTable + ` sl` + where
+	var total int
+	err := tx.QueryRowContext(ctx, SQL, args...).Scan(&total)
+	return total, err
+}
+
+func (r *RepositoryShareLinkImpl) FindById(ctx context.Context, tx *sql.Tx, id int) (models.ShareLink, error) {
+	SQL := `SELECT ` + shareLinkCols + ` FROM ` + models.ShareLinkTable + ` sl` + shareLinkJoins + `
+		WHERE sl.id = $1`
+	return scanShareLink(tx.QueryRowContext(ctx, SQL, id))
+}
+
+// FindByKey retrieves a share link by its key whether or not it is still valid
+func (r *RepositoryShareLinkImpl) FindByKey(ctx context.Context, tx *sql.Tx, key string) (models.ShareLink, error) {
+	SQL := `SELECT ` + shareLinkCols + ` FROM ` + models.ShareLinkTable + ` sl` + shareLinkJoins + `
+		WHERE sl.key = $1`
+	return scanShareLink(tx.QueryRowContext(ctx, SQL, key))
+}
+
+// Revoke marks a share link revoked; revoking twice keeps the first revocation time
+func (r *RepositoryShareLinkImpl) Revoke(ctx context.Context, tx *sql.Tx, id int) error {
+	SQL := `UPDATE ` + models.ShareLinkTable + ` SET revoked_at = COALESCE(revoked_at, CURRENT_TIMESTAMP) WHERE id = $1`
+	_, err := tx.ExecContext(ctx, SQL, id)
+	return err
+}
+
+// RecordView counts one view of a share link
+func (r *RepositoryShareLinkImpl) RecordView(ctx context.Context, tx *sql.Tx, id int) error {
+	SQL := `UPDATE ` + models.ShareLinkTable + ` SET view_count = view_count + 1, last_viewed_at = CURRENT_TIMESTAMP WHERE id = $1`
+	_, err := tx.ExecContext(ctx, SQL, id)
+	return err
+}
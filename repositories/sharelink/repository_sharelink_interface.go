@@ -0,0 +1,26 @@
+package sharelink
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/malikabdulaziz/tmn-backend/models"
+)
+
+// ShareLinkFilter narrows FindAll and CountAll; zero values are ignored
+type ShareLinkFilter struct {
+	CreatedBy      int
+	TargetType     string
+	SalesPackageId int
+	SavedPolygonId int
+}
+
+type RepositoryShareLinkInterface interface {
+	Create(ctx context.Context, tx *sql.Tx, link models.ShareLink, expiresInDays int) (models.ShareLink, error)
+	FindAll(ctx context.Context, tx *sql.Tx, filter ShareLinkFilter, take int, skip int, orderBy string, orderDirection string) ([]models.ShareLink, error)
+	CountAll(ctx context.Context, tx *sql.Tx, filter ShareLinkFilter) (int, error)
+	FindById(ctx context.Context, tx *sql.Tx, id int) (models.ShareLink, error)
+	FindByKey(ctx context.Context, tx *sql.Tx, key string) (models.ShareLink, error)
+	Revoke(ctx context.Context, tx *sql.Tx, id int) error
+	RecordView(ctx context.Context, tx *sql.Tx, id int) error
+}
@@ -27,9 +27,34 @@ func mdSubCatSafeOrder(orderBy, orderDirection string) (string, string) {
 	return orderBy, orderDirection
 }
 
+// mdSubCatSelect reads sub-categories together with the name of their category
+var mdSubCatSelect = `SELECT sc.id, sc.name, sc.category_id, c.name, sc.created_at, sc.updated_at FROM ` + models.SubCategoryTable + ` sc
+	LEFT JOIN ` + models.CategoryTable + ` c ON c.id = sc.category_id`
+
+func mdSubCatScan(scanner interface{ Scan(...interface{}) error }) (models.SubCategory, error) {
+	var n models.NullAbleSubCategory
+	if err := scanner.Scan(&n.Id, &n.Name, &n.CategoryId, &n.CategoryName, &n.CreatedAt, &n.UpdatedAt); err != nil {
+		return models.SubCategory{}, err
+	}
+	return models.NullAbleSubCategoryToSubCategory(n), nil
+}
+
+func mdSubCatScanRows(rows *sql.Rows) ([]models.SubCategory, error) {
+	defer rows.Close()
+	var list []models.SubCategory
+	for rows.Next() {
+		subCategory, err := mdSubCatScan(rows)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, subCategory)
+	}
+	return list, rows.Err()
+}
+
 func (r *RepositorySubCategoryImpl) Create(ctx context.Context, tx *sql.Tx, subCategory models.SubCategory) (models.SubCategory, error) {
-	SQL := `INSERT INTO ` + models.SubCategoryTable + ` (name) VALUES ($1) RETURNING id, created_at, updated_at`
-	err := tx.QueryRowContext(ctx, SQL, subCategory.Name).Scan(&subCategory.Id, &subCategory.CreatedAt, &subCategory.UpdatedAt)
+	SQL := `INSERT INTO ` + models.SubCategoryTable + ` (name, category_id) VALUES ($1, $2) RETURNING id, created_at, updated_at`
+	err := tx.QueryRowContext(ctx, SQL, subCategory.Name, subCategory.CategoryId).Scan(&subCategory.Id, &subCategory.CreatedAt, &subCategory.UpdatedAt)
 	return subCategory, err
 }
 
@@ -38,86 +63,69 @@ func (r *RepositorySubCategoryImpl) FindAll(ctx context.Context, tx *sql.Tx, tak
 	var rows *sql.Rows
 	var err error
 	if search != "" {
-		SQL := `SELECT id, name, created_at, updated_at FROM ` + models.SubCategoryTable + ` WHERE name ILIKE $1 ORDER BY ` + orderBy + ` ` + orderDirection + `, name ASC LIMIT $2 OFFSET $3`
+		SQL := mdSubCatSelect + ` WHERE sc.deleted_at IS NULL AND sc.name ILIKE $1 ORDER BY sc.` + orderBy + ` ` + orderDirection + `, sc.name ASC LIMIT $2 OFFSET $3`
 		rows, err = tx.QueryContext(ctx, SQL, "%"+search+"%", take, skip)
 	} else {
-		SQL := `SELECT id, name, created_at, updated_at FROM ` + models.SubCategoryTable + ` ORDER BY ` + orderBy + ` ` + orderDirection + `, name ASC LIMIT $1 OFFSET $2`
+		SQL := mdSubCatSelect + ` WHERE sc.deleted_at IS NULL ORDER BY sc.` + orderBy + ` ` + orderDirection + `, sc.name ASC LIMIT $1 OFFSET $2`
 		rows, err = tx.QueryContext(ctx, SQL, take, skip)
 	}
 	if err != nil {
 		return nil, err
 	}
-	defer rows.Close()
-	var list []models.SubCategory
-	for rows.Next() {
-		var n models.NullAbleSubCategory
-		if err := rows.Scan(&n.Id, &n.Name, &n.CreatedAt, &n.UpdatedAt); err != nil {
-			return nil, err
-		}
-		list = append(list, models.NullAbleSubCategoryToSubCategory(n))
-	}
-	return list, rows.Err()
+	return mdSubCatScanRows(rows)
 }
 
 func (r *RepositorySubCategoryImpl) CountAll(ctx context.Context, tx *sql.Tx, search string) (int, error) {
 	var total int
 	var err error
 	if search != "" {
-		SQL := `SELECT COUNT(*) FROM ` + models.SubCategoryTable + ` WHERE name ILIKE $1`
+		SQL := `SELECT COUNT(*) FROM ` + models.SubCategoryTable + ` WHERE deleted_at IS NULL AND name ILIKE $1`
 		err = tx.QueryRowContext(ctx, SQL, "%"+search+"%").Scan(&total)
 	} else {
-		SQL := `SELECT COUNT(*) FROM ` + models.SubCategoryTable
+		SQL := `SELECT COUNT(*) FROM ` + models.SubCategoryTable + ` WHERE deleted_at IS NULL`
 		err = tx.QueryRowContext(ctx, SQL).Scan(&total)
 	}
 	return total, err
 }
 
 func (r *RepositorySubCategoryImpl) FindById(ctx context.Context, tx *sql.Tx, id int) (models.SubCategory, error) {
-	SQL := `SELECT id, name, created_at, updated_at FROM ` + models.SubCategoryTable + ` WHERE id = $1`
-	var n models.NullAbleSubCategory
-	err := tx.QueryRowContext(ctx, SQL, id).Scan(&n.Id, &n.Name, &n.CreatedAt, &n.UpdatedAt)
-	if err != nil {
-		return models.SubCategory{}, err
-	}
-	return models.NullAbleSubCategoryToSubCategory(n), nil
+	SQL := mdSubCatSelect + ` WHERE sc.id = $1 AND sc.deleted_at IS NULL`
+	return mdSubCatScan(tx.QueryRowContext(ctx, SQL, id))
 }
 
 func (r *RepositorySubCategoryImpl) Update(ctx context.Context, tx *sql.Tx, subCategory models.SubCategory) (models.SubCategory, error) {
-	SQL := `UPDATE ` + models.SubCategoryTable + ` SET name = $1, updated_at = $2 WHERE id = $3 RETURNING updated_at`
-	err := tx.QueryRowContext(ctx, SQL, subCategory.Name, time.Now(), subCategory.Id).Scan(&subCategory.UpdatedAt)
+	SQL := `UPDATE ` + models.SubCategoryTable + ` SET name = $1, category_id = $2, updated_at = $3 WHERE id = $4 AND deleted_at IS NULL RETURNING updated_at`
+	err := tx.QueryRowContext(ctx, SQL, subCategory.Name, subCategory.CategoryId, time.Now(), subCategory.Id).Scan(&subCategory.UpdatedAt)
 	return subCategory, err
 }
 
-func (r *RepositorySubCategoryImpl) Delete(ctx context.Context, tx *sql.Tx, id int) error {
-	SQL := `DELETE FROM ` + models.SubCategoryTable + ` WHERE id = $1`
-	_, err := tx.ExecContext(ctx, SQL, id)
+// Delete moves a sub-category to the trash; POIs keep pointing at it until it is purged
+func (r *RepositorySubCategoryImpl) Delete(ctx context.Context, tx *sql.Tx, id int, deletedBy *int) error {
+	SQL := `UPDATE ` + models.SubCategoryTable + ` SET deleted_at = $1, deleted_by = $2 WHERE id = $3 AND deleted_at IS NULL`
+	_, err := tx.ExecContext(ctx, SQL, time.Now(), deletedBy, id)
 	return err
 }
 
+// FindByName retrieves a sub-category by name, ignoring case, or the sub-category a name was merged into
 func (r *RepositorySubCategoryImpl) FindByName(ctx context.Context, tx *sql.Tx, name string) (models.SubCategory, error) {
-	SQL := `SELECT id, name, created_at, updated_at FROM ` + models.SubCategoryTable + ` WHERE LOWER(name) = LOWER($1) LIMIT 1`
-	var n models.NullAbleSubCategory
-	err := tx.QueryRowContext(ctx, SQL, name).Scan(&n.Id, &n.Name, &n.CreatedAt, &n.UpdatedAt)
-	if err != nil {
-		return models.SubCategory{}, err
-	}
-	return models.NullAbleSubCategoryToSubCategory(n), nil
+	SQL := mdSubCatSelect + ` WHERE sc.deleted_at IS NULL AND (LOWER(sc.name) = LOWER($1) OR ` + models.AliasCondition(models.MasterDataTypeSubCategory, "sc.id", "$1") + `)
+		ORDER BY LOWER(sc.name) = LOWER($1) DESC LIMIT 1`
+	return mdSubCatScan(tx.QueryRowContext(ctx, SQL, name))
 }
 
 func (r *RepositorySubCategoryImpl) FindAllDropdown(ctx context.Context, tx *sql.Tx) ([]models.SubCategory, error) {
-	SQL := `SELECT id, name, created_at, updated_at FROM ` + models.SubCategoryTable + ` ORDER BY name ASC`
+	SQL := mdSubCatSelect + ` WHERE sc.deleted_at IS NULL ORDER BY sc.name ASC`
 	rows, err := tx.QueryContext(ctx, SQL)
 	if err != nil {
 		return nil, err
 	}
-	defer rows.Close()
-	var list []models.SubCategory
-	for rows.Next() {
-		var n models.NullAbleSubCategory
-		if err := rows.Scan(&n.Id, &n.Name, &n.CreatedAt, &n.UpdatedAt); err != nil {
-			return nil, err
-		}
-		list = append(list, models.NullAbleSubCategoryToSubCategory(n))
-	}
-	return list, rows.Err()
+	return mdSubCatScanRows(rows)
+}
+
+// CountPOIsOutsideCategory counts the live POIs that use the sub-category under a category other than categoryId
+func (r *RepositorySubCategoryImpl) CountPOIsOutsideCategory(ctx context.Context, tx *sql.Tx, id int, categoryId int) (int, error) {
+	SQL := `SELECT COUNT(*) FROM ` + models.POITable + ` WHERE sub_category_id = $1 AND category_id IS NOT NULL AND category_id <> $2 AND deleted_at IS NULL`
+	var total int
+	err := tx.QueryRowContext(ctx, SQL, id, categoryId).Scan(&total)
+	return total, err
 }
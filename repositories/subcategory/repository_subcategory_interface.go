@@ -13,7 +13,8 @@ type RepositorySubCategoryInterface interface {
 	CountAll(ctx context.Context, tx *sql.Tx, search string) (int, error)
 	FindById(ctx context.Context, tx *sql.Tx, id int) (models.SubCategory, error)
 	Update(ctx context.Context, tx *sql.Tx, subCategory models.SubCategory) (models.SubCategory, error)
-	Delete(ctx context.Context, tx *sql.Tx, id int) error
+	Delete(ctx context.Context, tx *sql.Tx, id int, deletedBy *int) error
 	FindByName(ctx context.Context, tx *sql.Tx, name string) (models.SubCategory, error)
 	FindAllDropdown(ctx context.Context, tx *sql.Tx) ([]models.SubCategory, error)
+	CountPOIsOutsideCategory(ctx context.Context, tx *sql.Tx, id int, categoryId int) (int, error)
 }
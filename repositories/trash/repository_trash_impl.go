@@ -0,0 +1,146 @@
+package trash
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"time"
+
+	"github.com/malikabdulaziz/tmn-backend/models"
+)
+
+type RepositoryTrashImpl struct{}
+
+func NewRepositoryTrashImpl() RepositoryTrashInterface {
+	return &RepositoryTrashImpl{}
+}
+
+// trashParams binds the viewer ($1) and retention period ($2) once for every source in a query
+const trashParams = `WITH params AS (SELECT $1::bigint AS user_id, $2::int AS retention_days) `
+
+// trashFrom returns the FROM/WHERE part selecting the deleted rows of source the viewer may see
+func trashFrom(source models.TrashSource) string {
+	SQL := ` FROM ` + source.Table + ` t CROSS JOIN params
+		LEFT JOIN ` + models.UserTable + ` u ON u.id = t.deleted_by
+		WHERE t.deleted_at IS NOT NULL`
+	if source.Owned {
+		SQL += ` AND ` + models.VisibleToCondition("t", "params.user_id")
+	}
+	return SQL
+}
+
+// trashSelect returns the trash item columns of source; owned rows may only be restored by
+// users who may change them
+func trashSelect(source models.TrashSource) string {
+	restorable := `TRUE`
+	if source.Owned {
+		restorable = models.EditableByCondition("t", "params.user_id")
+	}
+	return `SELECT '` + source.Type + `' AS type, t.id, t.` + source.NameColumn + ` AS name, t.deleted_at, t.deleted_by, u.name,
+		t.deleted_at + params.retention_days * INTERVAL '1 day' AS purge_at, ` + restorable + ` AS restorable` + trashFrom(source)
+}
+
+// filterSources returns the sources a filter covers, every source when its Type is empty
+func filterSources(filter TrashFilter) []models.TrashSource {
+	if filter.Type == "" {
+		return models.TrashSources
+	}
+	if source, ok := models.FindTrashSource(filter.Type); ok {
+		return []models.TrashSource{source}
+	}
+	return nil
+}
+
+func scanTrashItem(scanner interface{ Scan(...interface{}) error }) (models.TrashItem, error) {
+	var n models.NullAbleTrashItem
+	if err := scanner.Scan(&n.Type, &n.Id, &n.Name, &n.DeletedAt, &n.DeletedBy, &n.DeletedByName, &n.PurgeAt, &n.Restorable); err != nil {
+		return models.TrashItem{}, err
+	}
+	return models.NullAbleTrashItemToTrashItem(n), nil
+}
+
+// FindAll lists the deleted rows matching the filter, most recently deleted first
+func (r *RepositoryTrashImpl) FindAll(ctx context.Context, tx *sql.Tx, filter TrashFilter, take int, skip int) ([]models.TrashItem, error) {
+	sources := filterSources(filter)
+	if len(sources) == 0 {
+		return []models.TrashItem{}, nil
+	}
+	selects := make([]string, len(sources))
+	for i, source := range sources {
+		selects[i] = trashSelect(source)
+	}
+	SQL := trashParams + `SELECT * FROM (` + strings.Join(selects, " UNION ALL ") + `) trash
+		ORDER BY deleted_at DESC, type, id LIMIT $3 OFFSET $4`
+	rows, err := tx.QueryContext(ctx, SQL, filter.UserId, filter.RetentionDays, take, skip)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := []models.TrashItem{}
+	for rows.Next() {
+		item, err := scanTrashItem(rows)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+	return items, rows.Err()
+}
+
+// CountAll returns the number of deleted rows matching the filter
+func (r *RepositoryTrashImpl) CountAll(ctx context.Context, tx *sql.Tx, filter TrashFilter) (int, error) {
+	sources := filterSources(filter)
+	if len(sources) == 0 {
+		return 0, nil
+	}
+	selects := make([]string, len(sources))
+	for i, source := range sources {
+		selects[i] = `SELECT t.id` + trashFrom(source)
+	}
+	SQL := trashParams + `SELECT COUNT(*) FROM (` + strings.Join(selects, " UNION ALL ") + `) trash`
+	var total int
+	err := tx.QueryRowContext(ctx, SQL, filter.UserId, filter.RetentionDays).Scan(&total)
+	return total, err
+}
+
+// FindById retrieves one deleted row of source; sql.ErrNoRows when it is not in the trash or
+// userId may not see it
+func (r *RepositoryTrashImpl) FindById(ctx context.Context, tx *sql.Tx, source models.TrashSource, id int, userId int, retentionDays int) (models.TrashItem, error) {
+	SQL := trashParams + trashSelect(source) + ` AND t.id = $3`
+	return scanTrashItem(tx.QueryRowContext(ctx, SQL, userId, retentionDays, id))
+}
+
+// NameTaken tells whether a row of source that is not in the trash already has the name
+func (r *RepositoryTrashImpl) NameTaken(ctx context.Context, tx *sql.Tx, source models.TrashSource, name string) (bool, error) {
+	SQL := `SELECT EXISTS (SELECT 1 FROM ` + source.Table + ` WHERE ` + source.NameColumn + ` = $1 AND deleted_at IS NULL)`
+	var taken bool
+	err := tx.QueryRowContext(ctx, SQL, name).Scan(&taken)
+	return taken, err
+}
+
+// Restore takes a row of source out of the trash
+func (r *RepositoryTrashImpl) Restore(ctx context.Context, tx *sql.Tx, source models.TrashSource, id int) error {
+	SQL := `UPDATE ` + source.Table + ` SET deleted_at = NULL, deleted_by = NULL, updated_at = $1 WHERE id = $2 AND deleted_at IS NOT NULL`
+	_, err := tx.ExecContext(ctx, SQL, time.Now(), id)
+	return err
+}
+
+// Purge deletes for good every row that has been in the trash for longer than retentionDays and
+// returns how many were removed. Foreign keys cascade to their points, building links and rules.
+func (r *RepositoryTrashImpl) Purge(ctx context.Context, tx *sql.Tx, retentionDays int) (int, error) {
+	cutoff := time.Now().AddDate(0, 0, -retentionDays)
+	purged := 0
+	for _, source := range models.TrashSources {
+		result, err := tx.ExecContext(ctx, `DELETE FROM `+source.Table+` WHERE deleted_at < $1`, cutoff)
+		if err != nil {
+			return purged, err
+		}
+		count, err := result.RowsAffected()
+		if err != nil {
+			return purged, err
+		}
+		purged += int(count)
+	}
+	return purged, nil
+}
@@ -0,0 +1,25 @@
+package trash
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/malikabdulaziz/tmn-backend/models"
+)
+
+// TrashFilter narrows FindAll and CountAll to the trash UserId can see, optionally of one Type.
+// RetentionDays is how long deleted rows are kept before they are purged.
+type TrashFilter struct {
+	UserId        int
+	Type          string
+	RetentionDays int
+}
+
+type RepositoryTrashInterface interface {
+	FindAll(ctx context.Context, tx *sql.Tx, filter TrashFilter, take int, skip int) ([]models.TrashItem, error)
+	CountAll(ctx context.Context, tx *sql.Tx, filter TrashFilter) (int, error)
+	FindById(ctx context.Context, tx *sql.Tx, source models.TrashSource, id int, userId int, retentionDays int) (models.TrashItem, error)
+	NameTaken(ctx context.Context, tx *sql.Tx, source models.TrashSource, name string) (bool, error)
+	Restore(ctx context.Context, tx *sql.Tx, source models.TrashSource, id int) error
+	Purge(ctx context.Context, tx *sql.Tx, retentionDays int) (int, error)
+}
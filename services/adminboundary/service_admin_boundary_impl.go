@@ -0,0 +1,186 @@
+package adminboundary
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/malikabdulaziz/tmn-backend/exceptions"
+	"github.com/malikabdulaziz/tmn-backend/helpers"
+	"github.com/malikabdulaziz/tmn-backend/models"
+	repositoriesAdminBoundary "github.com/malikabdulaziz/tmn-backend/repositories/adminboundary"
+	repositoriesBuilding "github.com/malikabdulaziz/tmn-backend/repositories/building"
+	webAdminBoundary "github.com/malikabdulaziz/tmn-backend/web/adminboundary"
+)
+
+type ServiceAdminBoundaryImpl struct {
+	DB                               *sql.DB
+	RepositoryAdminBoundaryInterface repositoriesAdminBoundary.RepositoryAdminBoundaryInterface
+	RepositoryBuildingInterface      repositoriesBuilding.RepositoryBuildingInterface
+}
+
+func NewServiceAdminBoundaryImpl(
+	db *sql.DB,
+	repositoryAdminBoundary repositoriesAdminBoundary.RepositoryAdminBoundaryInterface,
+	repositoryBuilding repositoriesBuilding.RepositoryBuildingInterface,
+) ServiceAdminBoundaryInterface {
+	return &ServiceAdminBoundaryImpl{
+		DB:                               db,
+		RepositoryAdminBoundaryInterface: repositoryAdminBoundary,
+		RepositoryBuildingInterface:      repositoryBuilding,
+	}
+}
+
+type geoJSONFeatureCollection struct {
+	Type     string           `json:"type"`
+	Features []geoJSONFeature `json:"features"`
+}
+
+type geoJSONFeature struct {
+	Properties map[string]interface{} `json:"properties"`
+	Geometry   json.RawMessage        `json:"geometry"`
+}
+
+type geoJSONGeometry struct {
+	Type string `json:"type"`
+}
+
+// featureError describes why a single feature could not be imported (Feature is 1-based)
+type featureError struct {
+	Feature int    `json:"feature"`
+	Reason  string `json:"reason"`
+}
+
+// ImportGeoJSON upserts every feature of a FeatureCollection as a boundary of the requested level,
+// then re-runs the building spatial join. The import is all-or-nothing.
+func (service *ServiceAdminBoundaryImpl) ImportGeoJSON(ctx context.Context, request webAdminBoundary.ImportGeoJSONRequest, data []byte) webAdminBoundary.ImportGeoJSONResponse {
+	level := strings.ToLower(strings.TrimSpace(request.Level))
+	if level != models.AdminBoundaryLevelProvince && level != models.AdminBoundaryLevelCity && level != models.AdminBoundaryLevelSubdistrict {
+		panic(exceptions.NewBadRequest("level must be one of province, city, subdistrict"))
+	}
+	codeProp := defaultString(request.CodeProperty, "code")
+	nameProp := defaultString(request.NameProperty, "name")
+	parentProp := defaultString(request.ParentCodeProperty, "parent_code")
+
+	var collection geoJSONFeatureCollection
+	if err := json.Unmarshal(data, &collection); err != nil || collection.Type != "FeatureCollection" {
+		panic(exceptions.NewBadRequest("invalid GeoJSON: expected a FeatureCollection"))
+	}
+	if len(collection.Features) == 0 {
+		panic(exceptions.NewBadRequest("GeoJSON contains no features"))
+	}
+
+	type pendingBoundary struct {
+		boundary models.AdminBoundary
+		geometry string
+	}
+	pending := make([]pendingBoundary, 0, len(collection.Features))
+	seenCodes := make(map[string]int)
+	var featureErrors []featureError
+
+	for i, feature := range collection.Features {
+		featureNo := i + 1
+		code := propertyString(feature.Properties, codeProp)
+		name := propertyString(feature.Properties, nameProp)
+		if code == "" {
+			featureErrors = append(featureErrors, featureError{Feature: featureNo, Reason: "missing property " + codeProp})
+			continue
+		}
+		if name == "" {
+			featureErrors = append(featureErrors, featureError{Feature: featureNo, Reason: "missing property " + nameProp})
+			continue
+		}
+		if first, dup := seenCodes[code]; dup {
+			featureErrors = append(featureErrors, featureError{Feature: featureNo, Reason: fmt.Sprintf("duplicate code %s (first seen in feature %d)", code, first)})
+			continue
+		}
+		var geometry geoJSONGeometry
+		if len(feature.Geometry) == 0 || json.Unmarshal(feature.Geometry, &geometry) != nil {
+			featureErrors = append(featureErrors, featureError{Feature: featureNo, Reason: "missing geometry"})
+			continue
+		}
+		if geometry.Type != "Polygon" && geometry.Type != "MultiPolygon" {
+			featureErrors = append(featureErrors, featureError{Feature: featureNo, Reason: "geometry must be Polygon or MultiPolygon, got " + geometry.Type})
+			continue
+		}
+		seenCodes[code] = featureNo
+
+		parentCode := ""
+		if level != models.AdminBoundaryLevelProvince {
+			parentCode = propertyString(feature.Properties, parentProp)
+		}
+		pending = append(pending, pendingBoundary{
+			boundary: models.AdminBoundary{
+				Code:       code,
+				Name:       name,
+				Level:      level,
+				ParentCode: parentCode,
+			},
+			geometry: string(feature.Geometry),
+		})
+	}
+
+	if len(featureErrors) > 0 {
+		panic(exceptions.NewBadRequestWithExtras(
+			"Some GeoJSON features are invalid. Nothing was imported.",
+			map[string]interface{}{"errors": featureErrors},
+		))
+	}
+
+	tx, err := service.DB.Begin()
+	helpers.PanicIfError(err)
+	defer helpers.CommitOrRollback(tx)
+
+	for _, p := range pending {
+		_, err := service.RepositoryAdminBoundaryInterface.Upsert(ctx, tx, p.boundary, p.geometry)
+		helpers.PanicIfError(err)
+	}
+
+	updated, err := service.RepositoryBuildingInterface.AssignAdminBoundaries(ctx, tx)
+	helpers.PanicIfError(err)
+
+	return webAdminBoundary.ImportGeoJSONResponse{
+		Level:            level,
+		Imported:         len(pending),
+		BuildingsUpdated: updated,
+	}
+}
+
+// FindAll lists boundaries for filter dropdowns, optionally scoped by level and parent code
+func (service *ServiceAdminBoundaryImpl) FindAll(ctx context.Context, level string, parentCode string) []webAdminBoundary.AdminBoundaryResponse {
+	tx, err := service.DB.Begin()
+	helpers.PanicIfError(err)
+	defer helpers.CommitOrRollback(tx)
+
+	boundaries, err := service.RepositoryAdminBoundaryInterface.FindAll(ctx, tx, strings.ToLower(strings.TrimSpace(level)), strings.TrimSpace(parentCode))
+	helpers.PanicIfError(err)
+
+	return webAdminBoundary.AdminBoundaryModelsToResponses(boundaries)
+}
+
+// propertyString reads a feature property as a trimmed string. Numeric codes
+// (common in BPS datasets) are formatted without a decimal part.
+func propertyString(properties map[string]interface{}, key string) string {
+	value, ok := properties[key]
+	if !ok || value == nil {
+		return ""
+	}
+	switch v := value.(type) {
+	case string:
+		return strings.TrimSpace(v)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	default:
+		return strings.TrimSpace(fmt.Sprint(v))
+	}
+}
+
+func defaultString(value, fallback string) string {
+	if strings.TrimSpace(value) == "" {
+		return fallback
+	}
+	return strings.TrimSpace(value)
+}
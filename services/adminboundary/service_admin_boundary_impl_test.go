@@ -0,0 +1,136 @@
+package adminboundary_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/malikabdulaziz/tmn-backend/exceptions"
+	"github.com/malikabdulaziz/tmn-backend/models"
+	serviceAdminBoundary "github.com/malikabdulaziz/tmn-backend/services/adminboundary"
+	"github.com/malikabdulaziz/tmn-backend/testutil"
+	"github.com/malikabdulaziz/tmn-backend/testutil/mocks"
+	webAdminBoundary "github.com/malikabdulaziz/tmn-backend/web/adminboundary"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newAdminBoundaryService(db *sql.DB, repoBoundary *mocks.MockRepositoryAdminBoundary, repoBuilding *mocks.MockRepositoryBuilding) serviceAdminBoundary.ServiceAdminBoundaryInterface {
+	return serviceAdminBoundary.NewServiceAdminBoundaryImpl(db, repoBoundary, repoBuilding)
+}
+
+const cityGeoJSON = `{
+	"type": "FeatureCollection",
+	"features": [
+		{"type": "Feature", "properties": {"KDPKAB": 3171, "WADMKK": "Jakarta Selatan", "KDPPUM": "31"},
+		 "geometry": {"type": "Polygon", "coordinates": [[[106.7,-6.3],[106.9,-6.3],[106.9,-6.2],[106.7,-6.3]]]}},
+		{"type": "Feature", "properties": {"KDPKAB": "3173", "WADMKK": "Jakarta Pusat", "KDPPUM": "31"},
+		 "geometry": {"type": "MultiPolygon", "coordinates": [[[[106.8,-6.2],[106.9,-6.2],[106.9,-6.1],[106.8,-6.2]]]]}}
+	]
+}`
+
+// --- ImportGeoJSON ---
+
+func TestAdminBoundaryImport_HappyPath(t *testing.T) {
+	db, sqlMock := testutil.NewMockDB(t)
+	repoBoundary := &mocks.MockRepositoryAdminBoundary{}
+	repoBuilding := &mocks.MockRepositoryBuilding{}
+	svc := newAdminBoundaryService(db, repoBoundary, repoBuilding)
+
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectCommit()
+
+	repoBoundary.On("Upsert", mock.Anything, mock.AnythingOfType("*sql.Tx"),
+		models.AdminBoundary{Code: "3171", Name: "Jakarta Selatan", Level: "city", ParentCode: "31"},
+		mock.AnythingOfType("string"),
+	).Return(models.AdminBoundary{Id: 1}, nil)
+	repoBoundary.On("Upsert", mock.Anything, mock.AnythingOfType("*sql.Tx"),
+		models.AdminBoundary{Code: "3173", Name: "Jakarta Pusat", Level: "city", ParentCode: "31"},
+		mock.AnythingOfType("string"),
+	).Return(models.AdminBoundary{Id: 2}, nil)
+	repoBuilding.On("AssignAdminBoundaries", mock.Anything, mock.AnythingOfType("*sql.Tx")).Return(12, nil)
+
+	result := svc.ImportGeoJSON(context.Background(), webAdminBoundary.ImportGeoJSONRequest{
+		Level:              "City",
+		CodeProperty:       "KDPKAB",
+		NameProperty:       "WADMKK",
+		ParentCodeProperty: "KDPPUM",
+	}, []byte(cityGeoJSON))
+
+	assert.Equal(t, "city", result.Level)
+	assert.Equal(t, 2, result.Imported)
+	assert.Equal(t, 12, result.BuildingsUpdated)
+	repoBoundary.AssertExpectations(t)
+	repoBuilding.AssertExpectations(t)
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+}
+
+func TestAdminBoundaryImport_InvalidLevel(t *testing.T) {
+	db, _ := testutil.NewMockDB(t)
+	svc := newAdminBoundaryService(db, &mocks.MockRepositoryAdminBoundary{}, &mocks.MockRepositoryBuilding{})
+
+	assert.PanicsWithValue(t,
+		exceptions.BadRequestError{Error: "level must be one of province, city, subdistrict"},
+		func() {
+			svc.ImportGeoJSON(context.Background(), webAdminBoundary.ImportGeoJSONRequest{Level: "village"}, []byte(cityGeoJSON))
+		},
+	)
+}
+
+func TestAdminBoundaryImport_NotAFeatureCollection(t *testing.T) {
+	db, _ := testutil.NewMockDB(t)
+	svc := newAdminBoundaryService(db, &mocks.MockRepositoryAdminBoundary{}, &mocks.MockRepositoryBuilding{})
+
+	assert.PanicsWithValue(t,
+		exceptions.BadRequestError{Error: "invalid GeoJSON: expected a FeatureCollection"},
+		func() {
+			svc.ImportGeoJSON(context.Background(), webAdminBoundary.ImportGeoJSONRequest{Level: "province"}, []byte(`{"type":"Feature"}`))
+		},
+	)
+}
+
+// TestAdminBoundaryImport_InvalidFeatures verifies that feature errors are reported
+// together and nothing is written (no transaction is opened).
+func TestAdminBoundaryImport_InvalidFeatures(t *testing.T) {
+	db, sqlMock := testutil.NewMockDB(t)
+	repoBoundary := &mocks.MockRepositoryAdminBoundary{}
+	svc := newAdminBoundaryService(db, repoBoundary, &mocks.MockRepositoryBuilding{})
+
+	data := `{"type":"FeatureCollection","features":[
+		{"properties":{"name":"No Code"},"geometry":{"type":"Polygon","coordinates":[]}},
+		{"properties":{"code":"31","name":"DKI Jakarta"},"geometry":{"type":"Point","coordinates":[106.8,-6.2]}}
+	]}`
+
+	defer func() {
+		r := recover()
+		badRequest, ok := r.(exceptions.BadRequestError)
+		assert.True(t, ok)
+		assert.Equal(t, "Some GeoJSON features are invalid. Nothing was imported.", badRequest.Error)
+		assert.NotNil(t, badRequest.Extras)
+		repoBoundary.AssertNotCalled(t, "Upsert", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		assert.NoError(t, sqlMock.ExpectationsWereMet())
+	}()
+
+	svc.ImportGeoJSON(context.Background(), webAdminBoundary.ImportGeoJSONRequest{Level: "province"}, []byte(data))
+}
+
+// --- FindAll ---
+
+func TestAdminBoundaryFindAll_ByParent(t *testing.T) {
+	db, sqlMock := testutil.NewMockDB(t)
+	repoBoundary := &mocks.MockRepositoryAdminBoundary{}
+	svc := newAdminBoundaryService(db, repoBoundary, &mocks.MockRepositoryBuilding{})
+
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectCommit()
+
+	repoBoundary.On("FindAll", mock.Anything, mock.AnythingOfType("*sql.Tx"), "city", "31").
+		Return([]models.AdminBoundary{{Id: 1, Code: "3171", Name: "Jakarta Selatan", Level: "city", ParentCode: "31"}}, nil)
+
+	list := svc.FindAll(context.Background(), " CITY ", "31")
+
+	assert.Len(t, list, 1)
+	assert.Equal(t, "3171", list[0].Code)
+	repoBoundary.AssertExpectations(t)
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+}
@@ -0,0 +1,12 @@
+package adminboundary
+
+import (
+	"context"
+
+	webAdminBoundary "github.com/malikabdulaziz/tmn-backend/web/adminboundary"
+)
+
+type ServiceAdminBoundaryInterface interface {
+	ImportGeoJSON(ctx context.Context, request webAdminBoundary.ImportGeoJSONRequest, data []byte) webAdminBoundary.ImportGeoJSONResponse
+	FindAll(ctx context.Context, level string, parentCode string) []webAdminBoundary.AdminBoundaryResponse
+}
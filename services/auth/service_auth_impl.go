@@ -71,6 +71,7 @@ func (implementation *ServiceAuthImpl) Login(ctx context.Context, username, pass
 			Username: user.Username,
 			Name:     user.Name,
 			Role:     user.Role,
+			Team:     user.Team,
 		},
 	}, token, int(duration.Seconds())
 }
@@ -0,0 +1,559 @@
+package booking
+
+import (
+	"context"
+	"database/sql"
+	"strconv"
+	"time"
+
+	"github.com/malikabdulaziz/tmn-backend/exceptions"
+	"github.com/malikabdulaziz/tmn-backend/helpers"
+	"github.com/malikabdulaziz/tmn-backend/models"
+	repositoriesBooking "github.com/malikabdulaziz/tmn-backend/repositories/booking"
+	repositoriesBuildingRestriction "github.com/malikabdulaziz/tmn-backend/repositories/buildingrestriction"
+	repositoriesCategory "github.com/malikabdulaziz/tmn-backend/repositories/category"
+	repositoriesMotherBrand "github.com/malikabdulaziz/tmn-backend/repositories/motherbrand"
+	repositoriesSalesPackage "github.com/malikabdulaziz/tmn-backend/repositories/salespackage"
+	servicesBuildingRestriction "github.com/malikabdulaziz/tmn-backend/services/buildingrestriction"
+	webBooking "github.com/malikabdulaziz/tmn-backend/web/booking"
+	webBuildingRestriction "github.com/malikabdulaziz/tmn-backend/web/buildingrestriction"
+)
+
+const dateLayout = "2006-01-02"
+
+type ServiceBookingImpl struct {
+	DB                                     *sql.DB
+	RepositoryBookingInterface             repositoriesBooking.RepositoryBookingInterface
+	RepositorySalesPackageInterface        repositoriesSalesPackage.RepositorySalesPackageInterface
+	RepositoryBuildingRestrictionInterface repositoriesBuildingRestriction.RepositoryBuildingRestrictionInterface
+	RepositoryCategoryInterface            repositoriesCategory.RepositoryCategoryInterface
+	RepositoryMotherBrandInterface         repositoriesMotherBrand.RepositoryMotherBrandInterface
+}
+
+func NewServiceBookingImpl(
+	db *sql.DB,
+	repositoryBooking repositoriesBooking.RepositoryBookingInterface,
+	repositorySalesPackage repositoriesSalesPackage.RepositorySalesPackageInterface,
+	repositoryBuildingRestriction repositoriesBuildingRestriction.RepositoryBuildingRestrictionInterface,
+	repositoryCategory repositoriesCategory.RepositoryCategoryInterface,
+	repositoryMotherBrand repositoriesMotherBrand.RepositoryMotherBrandInterface,
+) ServiceBookingInterface {
+	return &ServiceBookingImpl{
+		DB:                                     db,
+		RepositoryBookingInterface:             repositoryBooking,
+		RepositorySalesPackageInterface:        repositorySalesPackage,
+		RepositoryBuildingRestrictionInterface: repositoryBuildingRestriction,
+		RepositoryCategoryInterface:            repositoryCategory,
+		RepositoryMotherBrandInterface:         repositoryMotherBrand,
+	}
+}
+
+// Create books screens in a sales package or a list of buildings, rejecting the booking when any
+// building lacks free screens on any day of the range or a block restriction bars the advertiser
+func (s *ServiceBookingImpl) Create(ctx context.Context, request webBooking.CreateBookingRequest) webBooking.BookingResponse {
+	start, end := parseDateRange(request.StartDate, request.EndDate)
+	status := request.Status
+	if status == "" {
+		status = models.BookingStatusHold
+	}
+	screens := screensOrDefault(request.Screens)
+
+	tx, err := s.DB.Begin()
+	helpers.PanicIfError(err)
+	defer helpers.CommitOrRollback(tx)
+
+	buildingIds, salesPackageId, versionId := s.resolveBuildings(ctx, tx, request.SalesPackageId, request.SalesPackageVersion, request.BuildingIds)
+	err = s.RepositoryBookingInterface.LockBuildings(ctx, tx, buildingIds)
+	helpers.PanicIfError(err)
+	capacities := s.findCapacities(ctx, tx, buildingIds)
+	s.checkConflicts(ctx, tx, capacities, screens, start, end, 0)
+	categoryId, motherBrandId := s.resolveAdvertiser(ctx, tx, request.AdvertiserCategoryId, request.AdvertiserMotherBrandId)
+	warnings := s.checkRestrictions(ctx, tx, buildingIds, request.AdvertiserCategoryId, request.AdvertiserMotherBrandId, start, end)
+
+	booking := models.Booking{
+		ClientName:              request.ClientName,
+		SalesPackageId:          salesPackageId,
+		SalesPackageVersionId:   versionId,
+		StartDate:               start.Format(dateLayout),
+		EndDate:                 end.Format(dateLayout),
+		Status:                  status,
+		Notes:                   request.Notes,
+		AdvertiserCategoryId:    categoryId,
+		AdvertiserMotherBrandId: motherBrandId,
+	}
+	if userId := helpers.UserIdFromContext(ctx); userId > 0 {
+		booking.CreatedBy = &userId
+	}
+	created, err := s.RepositoryBookingInterface.Create(ctx, tx, booking, bookingBuildings(buildingIds, screens))
+	helpers.PanicIfError(err)
+	response := modelToResponse(created)
+	response.RestrictionWarnings = warnings
+	return response
+}
+
+// FindAll retrieves bookings with pagination and filters
+func (s *ServiceBookingImpl) FindAll(ctx context.Context, request webBooking.BookingRequestFindAll) ([]webBooking.BookingResponse, int) {
+	tx, err := s.DB.Begin()
+	helpers.PanicIfError(err)
+	defer helpers.CommitOrRollback(tx)
+
+	filter := repositoriesBooking.BookingFilter{
+		Status:         request.GetStatus(),
+		ClientName:     request.GetClientName(),
+		SalesPackageId: request.GetSalesPackageId(),
+		BuildingId:     request.GetBuildingId(),
+		From:           request.GetFrom(),
+		To:             request.GetTo(),
+	}
+	if filter.From != "" {
+		parseDate("from", filter.From)
+	}
+	if filter.To != "" {
+		parseDate("to", filter.To)
+	}
+
+	bookings, err := s.RepositoryBookingInterface.FindAll(ctx, tx, filter, request.GetTake(), request.GetSkip(), request.GetOrderBy(), request.GetOrderDirection())
+	helpers.PanicIfError(err)
+	total, err := s.RepositoryBookingInterface.CountAll(ctx, tx, filter)
+	helpers.PanicIfError(err)
+
+	responses := make([]webBooking.BookingResponse, len(bookings))
+	for i, booking := range bookings {
+		responses[i] = modelToResponse(booking)
+	}
+	return responses, total
+}
+
+// FindById retrieves a booking by ID
+func (s *ServiceBookingImpl) FindById(ctx context.Context, id int) webBooking.BookingResponse {
+	tx, err := s.DB.Begin()
+	helpers.PanicIfError(err)
+	defer helpers.CommitOrRollback(tx)
+
+	return modelToResponse(s.findBooking(ctx, tx, id))
+}
+
+// Update changes a booking's client, dates and buildings, re-checking screen capacity without
+// counting the booking against itself
+func (s *ServiceBookingImpl) Update(ctx context.Context, request webBooking.UpdateBookingRequest, id int) webBooking.BookingResponse {
+	start, end := parseDateRange(request.StartDate, request.EndDate)
+	screens := screensOrDefault(request.Screens)
+
+	tx, err := s.DB.Begin()
+	helpers.PanicIfError(err)
+	defer helpers.CommitOrRollback(tx)
+
+	existing := s.findBooking(ctx, tx, id)
+	if existing.Status == models.BookingStatusCancelled {
+		panic(exceptions.NewBadRequest("cancelled bookings cannot be edited"))
+	}
+
+	buildingIds, salesPackageId, versionId := s.resolveBuildings(ctx, tx, request.SalesPackageId, request.SalesPackageVersion, request.BuildingIds)
+	err = s.RepositoryBookingInterface.LockBuildings(ctx, tx, buildingIds)
+	helpers.PanicIfError(err)
+	capacities := s.findCapacities(ctx, tx, buildingIds)
+	s.checkConflicts(ctx, tx, capacities, screens, start, end, id)
+	categoryId, motherBrandId := s.resolveAdvertiser(ctx, tx, request.AdvertiserCategoryId, request.AdvertiserMotherBrandId)
+	warnings := s.checkRestrictions(ctx, tx, buildingIds, request.AdvertiserCategoryId, request.AdvertiserMotherBrandId, start, end)
+
+	existing.ClientName = request.ClientName
+	existing.SalesPackageId = salesPackageId
+	existing.SalesPackageVersionId = versionId
+	existing.StartDate = start.Format(dateLayout)
+	existing.EndDate = end.Format(dateLayout)
+	existing.Notes = request.Notes
+	existing.AdvertiserCategoryId = categoryId
+	existing.AdvertiserMotherBrandId = motherBrandId
+	updated, err := s.RepositoryBookingInterface.Update(ctx, tx, existing, bookingBuildings(buildingIds, screens))
+	helpers.PanicIfError(err)
+	response := modelToResponse(updated)
+	response.RestrictionWarnings = warnings
+	return response
+}
+
+// UpdateStatus moves a booking between hold and confirmed, or cancels it. Holds already occupy
+// screens, so confirming needs no capacity check; cancelled bookings stay cancelled.
+func (s *ServiceBookingImpl) UpdateStatus(ctx context.Context, request webBooking.UpdateBookingStatusRequest, id int) webBooking.BookingResponse {
+	tx, err := s.DB.Begin()
+	helpers.PanicIfError(err)
+	defer helpers.CommitOrRollback(tx)
+
+	booking := s.findBooking(ctx, tx, id)
+	if booking.Status == request.Status {
+		return modelToResponse(booking)
+	}
+	if booking.Status == models.BookingStatusCancelled {
+		panic(exceptions.NewBadRequest("cancelled bookings cannot be reopened"))
+	}
+
+	booking.UpdatedAt, err = s.RepositoryBookingInterface.UpdateStatus(ctx, tx, id, request.Status)
+	helpers.PanicIfError(err)
+	booking.Status = request.Status
+	return modelToResponse(booking)
+}
+
+// Delete deletes a booking
+func (s *ServiceBookingImpl) Delete(ctx context.Context, id int) {
+	tx, err := s.DB.Begin()
+	helpers.PanicIfError(err)
+	defer helpers.CommitOrRollback(tx)
+
+	s.findBooking(ctx, tx, id)
+	err = s.RepositoryBookingInterface.Delete(ctx, tx, id)
+	helpers.PanicIfError(err)
+}
+
+// Availability returns per-day confirmed, held and free screens for each building, plus the
+// bookings behind them, for the calendar view and for checking a booking before making it
+func (s *ServiceBookingImpl) Availability(ctx context.Context, request webBooking.AvailabilityRequest) webBooking.AvailabilityResponse {
+	from, to := availabilityRange(request.From, request.To)
+	screens := screensOrDefault(request.Screens)
+
+	tx, err := s.DB.Begin()
+	helpers.PanicIfError(err)
+	defer helpers.CommitOrRollback(tx)
+
+	buildingIds, _, _ := s.resolveBuildings(ctx, tx, request.SalesPackageId, request.SalesPackageVersion, request.BuildingIds)
+	if len(buildingIds) > webBooking.MaxAvailabilityBuildings {
+		panic(exceptions.NewBadRequest("at most " + strconv.Itoa(webBooking.MaxAvailabilityBuildings) + " buildings can be checked at once"))
+	}
+	capacities := s.findCapacities(ctx, tx, buildingIds)
+	allocations, err := s.RepositoryBookingInterface.FindAllocations(ctx, tx, buildingIds, from.Format(dateLayout), to.Format(dateLayout), 0)
+	helpers.PanicIfError(err)
+	byBuilding := groupAllocations(allocations)
+
+	response := webBooking.AvailabilityResponse{
+		From:      from.Format(dateLayout),
+		To:        to.Format(dateLayout),
+		Screens:   screens,
+		Available: true,
+		Buildings: make([]webBooking.BuildingAvailabilityResponse, len(capacities)),
+	}
+	for i, row := range capacities {
+		capacity, known := capacityOf(row)
+		usage := dailyUsage(byBuilding[row.BuildingId], from, to)
+		building := webBooking.BuildingAvailabilityResponse{
+			BuildingId:    row.BuildingId,
+			BuildingName:  row.BuildingName,
+			Capacity:      capacity,
+			CapacityKnown: known,
+			MinAvailable:  capacity,
+			Days:          make([]webBooking.AvailabilityDayResponse, len(usage)),
+			Bookings:      bookingSpans(byBuilding[row.BuildingId]),
+		}
+		for d, day := range usage {
+			free := capacity - day.confirmed - day.held
+			if free < 0 {
+				free = 0
+			}
+			building.Days[d] = webBooking.AvailabilityDayResponse{
+				Date:      from.AddDate(0, 0, d).Format(dateLayout),
+				Confirmed: day.confirmed,
+				Held:      day.held,
+				Available: free,
+			}
+			if free < building.MinAvailable {
+				building.MinAvailable = free
+			}
+		}
+		building.Available = building.MinAvailable >= screens
+		response.Available = response.Available && building.Available
+		response.Buildings[i] = building
+	}
+	return response
+}
+
+func (s *ServiceBookingImpl) findBooking(ctx context.Context, tx *sql.Tx, id int) models.Booking {
+	booking, err := s.RepositoryBookingInterface.FindById(ctx, tx, id)
+	if err == sql.ErrNoRows {
+		panic(exceptions.NewNotFoundError("booking not found"))
+	}
+	helpers.PanicIfError(err)
+	return booking
+}
+
+// resolveBuildings returns the booked building ids, taken from a version of the sales package
+// when one is given (the latest when version is 0), and the package and version ids to store on
+// the booking. Buildings deleted since the version was taken are left out. Packages the current
+// user may not see are reported as not found.
+func (s *ServiceBookingImpl) resolveBuildings(ctx context.Context, tx *sql.Tx, salesPackageId int, version int, buildingIds []int) ([]int, *int, *int) {
+	if salesPackageId > 0 && len(buildingIds) > 0 {
+		panic(exceptions.NewBadRequest("use either sales_package_id or building_ids, not both"))
+	}
+	if salesPackageId == 0 {
+		if version > 0 {
+			panic(exceptions.NewBadRequest("sales_package_version requires sales_package_id"))
+		}
+		if len(buildingIds) == 0 {
+			panic(exceptions.NewBadRequest("sales_package_id or building_ids is required"))
+		}
+		return uniqueIds(buildingIds), nil, nil
+	}
+
+	access, err := s.RepositorySalesPackageInterface.FindAccess(ctx, tx, salesPackageId, helpers.UserIdFromContext(ctx))
+	if err == sql.ErrNoRows || (err == nil && !access.Visible) {
+		panic(exceptions.NewNotFoundError("sales package not found"))
+	}
+	helpers.PanicIfError(err)
+	pkgVersion, err := s.RepositorySalesPackageInterface.FindVersion(ctx, tx, salesPackageId, version)
+	if err == sql.ErrNoRows {
+		if version > 0 {
+			panic(exceptions.NewNotFoundError("sales package version not found"))
+		}
+		panic(exceptions.NewNotFoundError("sales package not found"))
+	}
+	helpers.PanicIfError(err)
+	ids := []int{}
+	for _, b := range pkgVersion.Buildings {
+		if b.BuildingId != nil {
+			ids = append(ids, *b.BuildingId)
+		}
+	}
+	if len(ids) == 0 {
+		panic(exceptions.NewBadRequest("sales package has no buildings"))
+	}
+	return ids, &pkgVersion.SalesPackageId, &pkgVersion.Id
+}
+
+func (s *ServiceBookingImpl) findCapacities(ctx context.Context, tx *sql.Tx, buildingIds []int) []repositoriesBooking.BuildingCapacityRow {
+	capacities, err := s.RepositoryBookingInterface.FindBuildingCapacities(ctx, tx, buildingIds)
+	helpers.PanicIfError(err)
+	if len(capacities) < len(buildingIds) {
+		panic(exceptions.NewNotFoundError("building not found"))
+	}
+	return capacities
+}
+
+// checkConflicts panics with the overbooked buildings when another screens screens do not fit
+// in a building on some day of [start, end]
+func (s *ServiceBookingImpl) checkConflicts(ctx context.Context, tx *sql.Tx, capacities []repositoriesBooking.BuildingCapacityRow, screens int, start time.Time, end time.Time, excludeBookingId int) {
+	buildingIds := make([]int, len(capacities))
+	for i, row := range capacities {
+		buildingIds[i] = row.BuildingId
+	}
+	allocations, err := s.RepositoryBookingInterface.FindAllocations(ctx, tx, buildingIds, start.Format(dateLayout), end.Format(dateLayout), excludeBookingId)
+	helpers.PanicIfError(err)
+	byBuilding := groupAllocations(allocations)
+
+	conflicts := []webBooking.BookingConflictResponse{}
+	for _, row := range capacities {
+		capacity, _ := capacityOf(row)
+		peak, peakDay := 0, 0
+		for d, day := range dailyUsage(byBuilding[row.BuildingId], start, end) {
+			if booked := day.confirmed + day.held; booked > peak {
+				peak, peakDay = booked, d
+			}
+		}
+		if peak+screens <= capacity {
+			continue
+		}
+		conflicts = append(conflicts, webBooking.BookingConflictResponse{
+			BuildingId:   row.BuildingId,
+			BuildingName: row.BuildingName,
+			Capacity:     capacity,
+			Requested:    screens,
+			PeakBooked:   peak,
+			PeakDate:     start.AddDate(0, 0, peakDay).Format(dateLayout),
+			Bookings:     bookingSpans(byBuilding[row.BuildingId]),
+		})
+	}
+	if len(conflicts) > 0 {
+		panic(exceptions.NewBadRequestWithExtras("not enough free screens for the requested dates", conflicts))
+	}
+}
+
+type dayUsage struct {
+	confirmed int
+	held      int
+}
+
+// dailyUsage adds up the screens of the allocations on each day of [from, to]
+func dailyUsage(allocations []repositoriesBooking.BookingAllocationRow, from time.Time, to time.Time) []dayUsage {
+	usage := make([]dayUsage, daysBetween(from, to)+1)
+	for _, a := range allocations {
+		start, errStart := time.Parse(dateLayout, a.StartDate)
+		end, errEnd := time.Parse(dateLayout, a.EndDate)
+		if errStart != nil || errEnd != nil {
+			continue
+		}
+		first, last := daysBetween(from, start), daysBetween(from, end)
+		if first < 0 {
+			first = 0
+		}
+		if last > len(usage)-1 {
+			last = len(usage) - 1
+		}
+		for d := first; d <= last; d++ {
+			if a.Status == models.BookingStatusConfirmed {
+				usage[d].confirmed += a.Screens
+			} else {
+				usage[d].held += a.Screens
+			}
+		}
+	}
+	return usage
+}
+
+func groupAllocations(allocations []repositoriesBooking.BookingAllocationRow) map[int][]repositoriesBooking.BookingAllocationRow {
+	out := make(map[int][]repositoriesBooking.BookingAllocationRow)
+	for _, a := range allocations {
+		out[a.BuildingId] = append(out[a.BuildingId], a)
+	}
+	return out
+}
+
+func bookingSpans(allocations []repositoriesBooking.BookingAllocationRow) []webBooking.BookingSpanResponse {
+	spans := make([]webBooking.BookingSpanResponse, len(allocations))
+	for i, a := range allocations {
+		spans[i] = webBooking.BookingSpanResponse{
+			BookingId:  a.BookingId,
+			ClientName: a.ClientName,
+			Status:     a.Status,
+			StartDate:  a.StartDate,
+			EndDate:    a.EndDate,
+			Screens:    a.Screens,
+		}
+	}
+	return spans
+}
+
+// capacityOf returns the building's screen count, treating buildings without a recorded count
+// as a single screen so they still cannot be double-booked
+func capacityOf(row repositoriesBooking.BuildingCapacityRow) (int, bool) {
+	if row.Screens > 0 {
+		return row.Screens, true
+	}
+	return 1, false
+}
+
+func bookingBuildings(buildingIds []int, screens int) []models.BookingBuilding {
+	buildings := make([]models.BookingBuilding, len(buildingIds))
+	for i, id := range buildingIds {
+		buildings[i] = models.BookingBuilding{BuildingId: id, Screens: screens}
+	}
+	return buildings
+}
+
+func screensOrDefault(screens int) int {
+	if screens < 1 {
+		return 1
+	}
+	return screens
+}
+
+func uniqueIds(ids []int) []int {
+	seen := make(map[int]bool, len(ids))
+	out := make([]int, 0, len(ids))
+	for _, id := range ids {
+		if id > 0 && !seen[id] {
+			seen[id] = true
+			out = append(out, id)
+		}
+	}
+	return out
+}
+
+// resolveAdvertiser checks that the advertiser category and mother brand exist; zero ids stay unset
+func (s *ServiceBookingImpl) resolveAdvertiser(ctx context.Context, tx *sql.Tx, categoryId int, motherBrandId int) (*int, *int) {
+	var category, motherBrand *int
+	if categoryId > 0 {
+		_, err := s.RepositoryCategoryInterface.FindById(ctx, tx, categoryId)
+		if err == sql.ErrNoRows {
+			panic(exceptions.NewBadRequest("category not found"))
+		}
+		helpers.PanicIfError(err)
+		category = &categoryId
+	}
+	if motherBrandId > 0 {
+		_, err := s.RepositoryMotherBrandInterface.FindById(ctx, tx, motherBrandId)
+		if err == sql.ErrNoRows {
+			panic(exceptions.NewBadRequest("mother brand not found"))
+		}
+		helpers.PanicIfError(err)
+		motherBrand = &motherBrandId
+	}
+	return category, motherBrand
+}
+
+// checkRestrictions applies the building restrictions in force during [start, end] to the advertiser
+func (s *ServiceBookingImpl) checkRestrictions(ctx context.Context, tx *sql.Tx, buildingIds []int, categoryId int, motherBrandId int, start time.Time, end time.Time) []webBuildingRestriction.RestrictionViolationResponse {
+	return servicesBuildingRestriction.CheckAdvertiser(ctx, tx, s.RepositoryBuildingRestrictionInterface, repositoriesBuildingRestriction.RestrictionCheck{
+		BuildingIds:   buildingIds,
+		CategoryId:    categoryId,
+		MotherBrandId: motherBrandId,
+		From:          start.Format(dateLayout),
+		Until:         end.Format(dateLayout),
+	})
+}
+
+func parseDate(name string, value string) time.Time {
+	date, err := time.Parse(dateLayout, value)
+	if err != nil {
+		panic(exceptions.NewBadRequest(name + " must be a date in YYYY-MM-DD format"))
+	}
+	return date
+}
+
+func parseDateRange(startDate string, endDate string) (time.Time, time.Time) {
+	start := parseDate("start_date", startDate)
+	end := parseDate("end_date", endDate)
+	if end.Before(start) {
+		panic(exceptions.NewBadRequest("end_date must not be before start_date"))
+	}
+	return start, end
+}
+
+// availabilityRange defaults to DefaultAvailabilityDays starting today (or starting from) and
+// caps the range at MaxAvailabilityDays
+func availabilityRange(fromDate string, toDate string) (time.Time, time.Time) {
+	from := time.Now().UTC().Truncate(24 * time.Hour)
+	if fromDate != "" {
+		from = parseDate("from", fromDate)
+	}
+	to := from.AddDate(0, 0, webBooking.DefaultAvailabilityDays-1)
+	if toDate != "" {
+		to = parseDate("to", toDate)
+	}
+	if to.Before(from) {
+		panic(exceptions.NewBadRequest("to must not be before from"))
+	}
+	if daysBetween(from, to)+1 > webBooking.MaxAvailabilityDays {
+		panic(exceptions.NewBadRequest("at most " + strconv.Itoa(webBooking.MaxAvailabilityDays) + " days can be checked at once"))
+	}
+	return from, to
+}
+
+func daysBetween(from time.Time, to time.Time) int {
+	return int(to.Sub(from).Hours() / 24)
+}
+
+func modelToResponse(b models.Booking) webBooking.BookingResponse {
+	buildings := make([]webBooking.BookingBuildingResponse, len(b.Buildings))
+	for i, bb := range b.Buildings {
+		buildings[i] = webBooking.BookingBuildingResponse{
+			BuildingId:   bb.BuildingId,
+			BuildingName: bb.BuildingName,
+			Screens:      bb.Screens,
+		}
+	}
+	return webBooking.BookingResponse{
+		Id:                        b.Id,
+		ClientName:                b.ClientName,
+		SalesPackageId:            b.SalesPackageId,
+		SalesPackageName:          b.SalesPackageName,
+		SalesPackageVersionId:     b.SalesPackageVersionId,
+		SalesPackageVersion:       b.SalesPackageVersion,
+		StartDate:                 b.StartDate,
+		EndDate:                   b.EndDate,
+		Status:                    b.Status,
+		Notes:                     b.Notes,
+		AdvertiserCategoryId:      b.AdvertiserCategoryId,
+		AdvertiserCategoryName:    b.AdvertiserCategoryName,
+		AdvertiserMotherBrandId:   b.AdvertiserMotherBrandId,
+		AdvertiserMotherBrandName: b.AdvertiserMotherBrandName,
+		CreatedBy:                 b.CreatedBy,
+		Buildings:                 buildings,
+		CreatedAt:                 b.CreatedAt,
+		UpdatedAt:                 b.UpdatedAt,
+	}
+}
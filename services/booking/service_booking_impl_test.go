@@ -0,0 +1,460 @@
+package booking_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/malikabdulaziz/tmn-backend/exceptions"
+	"github.com/malikabdulaziz/tmn-backend/helpers"
+	"github.com/malikabdulaziz/tmn-backend/models"
+	repositoriesBooking "github.com/malikabdulaziz/tmn-backend/repositories/booking"
+	repositoriesBuildingRestriction "github.com/malikabdulaziz/tmn-backend/repositories/buildingrestriction"
+	serviceBooking "github.com/malikabdulaziz/tmn-backend/services/booking"
+	"github.com/malikabdulaziz/tmn-backend/testutil"
+	"github.com/malikabdulaziz/tmn-backend/testutil/mocks"
+	webBooking "github.com/malikabdulaziz/tmn-backend/web/booking"
+	webBuildingRestriction "github.com/malikabdulaziz/tmn-backend/web/buildingrestriction"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newBookingService(db *sql.DB, repoBooking *mocks.MockRepositoryBooking, repoPkg *mocks.MockRepositorySalesPackage) serviceBooking.ServiceBookingInterface {
+	return serviceBooking.NewServiceBookingImpl(db, repoBooking, repoPkg, &mocks.MockRepositoryBuildingRestriction{},
+		&mocks.MockRepositoryCategory{}, &mocks.MockRepositoryMotherBrand{})
+}
+
+// capacities: Tower A has 3 screens from its proposal, Tower B has no recorded screen count
+func capacities() []repositoriesBooking.BuildingCapacityRow {
+	return []repositoriesBooking.BuildingCapacityRow{
+		{BuildingId: 1, BuildingName: "Tower A", Screens: 3},
+		{BuildingId: 2, BuildingName: "Tower B"},
+	}
+}
+
+func allocation(bookingId int, status string, start, end string, buildingId, screens int) repositoriesBooking.BookingAllocationRow {
+	return repositoriesBooking.BookingAllocationRow{
+		BookingId:  bookingId,
+		ClientName: "Client " + string(rune('A'+bookingId-1)),
+		Status:     status,
+		StartDate:  start,
+		EndDate:    end,
+		BuildingId: buildingId,
+		Screens:    screens,
+	}
+}
+
+// --- Create ---
+
+func TestBookingCreate_NonOverlappingBookingsFit(t *testing.T) {
+	db, sqlMock := testutil.NewMockDB(t)
+	repoBooking := &mocks.MockRepositoryBooking{}
+	svc := newBookingService(db, repoBooking, &mocks.MockRepositorySalesPackage{})
+
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectCommit()
+
+	// 2 + 1 screens would overflow Tower A, but the two bookings never share a day
+	repoBooking.On("LockBuildings", mock.Anything, mock.AnythingOfType("*sql.Tx"), []int{1, 2}).Return(nil)
+	repoBooking.On("FindBuildingCapacities", mock.Anything, mock.AnythingOfType("*sql.Tx"), []int{1, 2}).Return(capacities(), nil)
+	repoBooking.On("FindAllocations", mock.Anything, mock.AnythingOfType("*sql.Tx"), []int{1, 2}, "2026-06-01", "2026-06-30", 0).
+		Return([]repositoriesBooking.BookingAllocationRow{
+			allocation(1, models.BookingStatusConfirmed, "2026-05-20", "2026-06-05", 1, 2),
+			allocation(2, models.BookingStatusHold, "2026-06-10", "2026-06-12", 1, 2),
+		}, nil)
+	repoBooking.On("Create", mock.Anything, mock.AnythingOfType("*sql.Tx"),
+		mock.MatchedBy(func(b models.Booking) bool {
+			return b.ClientName == "Kopi Co" && b.Status == models.BookingStatusHold && b.SalesPackageId == nil &&
+				b.CreatedBy != nil && *b.CreatedBy == 7
+		}),
+		[]models.BookingBuilding{{BuildingId: 1, Screens: 1}, {BuildingId: 2, Screens: 1}},
+	).Return(models.Booking{Id: 10, ClientName: "Kopi Co", Status: models.BookingStatusHold}, nil)
+
+	ctx := context.WithValue(context.Background(), helpers.ContextKey("userId"), "7")
+	response := svc.Create(ctx, webBooking.CreateBookingRequest{
+		ClientName:  "Kopi Co",
+		BuildingIds: []int{1, 2, 1},
+		StartDate:   "2026-06-01",
+		EndDate:     "2026-06-30",
+	})
+
+	assert.Equal(t, 10, response.Id)
+	repoBooking.AssertExpectations(t)
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+}
+
+func TestBookingCreate_Conflict(t *testing.T) {
+	db, sqlMock := testutil.NewMockDB(t)
+	repoBooking := &mocks.MockRepositoryBooking{}
+	svc := newBookingService(db, repoBooking, &mocks.MockRepositorySalesPackage{})
+
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectRollback()
+
+	repoBooking.On("LockBuildings", mock.Anything, mock.AnythingOfType("*sql.Tx"), []int{1}).Return(nil)
+	repoBooking.On("FindBuildingCapacities", mock.Anything, mock.AnythingOfType("*sql.Tx"), []int{1}).Return(capacities()[:1], nil)
+	repoBooking.On("FindAllocations", mock.Anything, mock.AnythingOfType("*sql.Tx"), []int{1}, "2026-06-05", "2026-06-15", 0).
+		Return([]repositoriesBooking.BookingAllocationRow{
+			allocation(1, models.BookingStatusConfirmed, "2026-06-01", "2026-06-10", 1, 2),
+			allocation(2, models.BookingStatusHold, "2026-06-08", "2026-06-20", 1, 1),
+		}, nil)
+
+	var recovered interface{}
+	func() {
+		defer func() { recovered = recover() }()
+		svc.Create(context.Background(), webBooking.CreateBookingRequest{
+			ClientName:  "Bank Co",
+			BuildingIds: []int{1},
+			StartDate:   "2026-06-05",
+			EndDate:     "2026-06-15",
+			Status:      models.BookingStatusConfirmed,
+		})
+	}()
+
+	err, ok := recovered.(exceptions.BadRequestError)
+	assert.True(t, ok)
+	assert.Equal(t, "not enough free screens for the requested dates", err.Error)
+	conflicts := err.Extras.([]webBooking.BookingConflictResponse)
+	assert.Len(t, conflicts, 1)
+	assert.Equal(t, 3, conflicts[0].Capacity)
+	assert.Equal(t, 3, conflicts[0].PeakBooked)
+	assert.Equal(t, "2026-06-08", conflicts[0].PeakDate)
+	assert.Len(t, conflicts[0].Bookings, 2)
+	repoBooking.AssertNotCalled(t, "Create", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+}
+
+func TestBookingCreate_FromSalesPackage(t *testing.T) {
+	db, sqlMock := testutil.NewMockDB(t)
+	repoBooking := &mocks.MockRepositoryBooking{}
+	repoPkg := &mocks.MockRepositorySalesPackage{}
+	svc := newBookingService(db, repoBooking, repoPkg)
+
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectCommit()
+
+	towerA, towerB := 1, 2
+	// no version asked for: the latest version's buildings are booked
+	repoPkg.On("FindAccess", mock.Anything, mock.AnythingOfType("*sql.Tx"), 5, 0).Return(models.Access{Visible: true}, nil)
+	repoPkg.On("FindVersion", mock.Anything, mock.AnythingOfType("*sql.Tx"), 5, 0).Return(models.SalesPackageVersion{
+		Id:             50,
+		SalesPackageId: 5,
+		Version:        3,
+		Name:           "CBD Offices",
+		Buildings:      []models.SalesPackageVersionBuilding{{BuildingId: &towerA, BuildingName: "Tower A"}, {BuildingId: &towerB, BuildingName: "Tower B"}},
+	}, nil)
+	repoBooking.On("LockBuildings", mock.Anything, mock.AnythingOfType("*sql.Tx"), []int{1, 2}).Return(nil)
+	repoBooking.On("FindBuildingCapacities", mock.Anything, mock.AnythingOfType("*sql.Tx"), []int{1, 2}).Return(capacities(), nil)
+	repoBooking.On("FindAllocations", mock.Anything, mock.AnythingOfType("*sql.Tx"), []int{1, 2}, "2026-07-01", "2026-07-31", 0).
+		Return([]repositoriesBooking.BookingAllocationRow{}, nil)
+	repoBooking.On("Create", mock.Anything, mock.AnythingOfType("*sql.Tx"),
+		mock.MatchedBy(func(b models.Booking) bool {
+			return b.SalesPackageId != nil && *b.SalesPackageId == 5 && b.SalesPackageVersionId != nil && *b.SalesPackageVersionId == 50
+		}),
+		[]models.BookingBuilding{{BuildingId: 1, Screens: 1}, {BuildingId: 2, Screens: 1}},
+	).Return(models.Booking{Id: 11}, nil)
+
+	svc.Create(context.Background(), webBooking.CreateBookingRequest{
+		ClientName:     "Kopi Co",
+		SalesPackageId: 5,
+		StartDate:      "2026-07-01",
+		EndDate:        "2026-07-31",
+	})
+
+	repoBooking.AssertExpectations(t)
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+}
+
+func TestBookingCreate_SalesPackageVersionNotFound(t *testing.T) {
+	db, sqlMock := testutil.NewMockDB(t)
+	repoBooking := &mocks.MockRepositoryBooking{}
+	repoPkg := &mocks.MockRepositorySalesPackage{}
+	svc := newBookingService(db, repoBooking, repoPkg)
+
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectRollback()
+	repoPkg.On("FindAccess", mock.Anything, mock.AnythingOfType("*sql.Tx"), 5, 0).Return(models.Access{Visible: true}, nil)
+	repoPkg.On("FindVersion", mock.Anything, mock.AnythingOfType("*sql.Tx"), 5, 9).Return(models.SalesPackageVersion{}, sql.ErrNoRows)
+
+	assert.PanicsWithValue(t, exceptions.NewNotFoundError("sales package version not found"), func() {
+		svc.Create(context.Background(), webBooking.CreateBookingRequest{
+			ClientName:          "Kopi Co",
+			SalesPackageId:      5,
+			SalesPackageVersion: 9,
+			StartDate:           "2026-07-01",
+			EndDate:             "2026-07-31",
+		})
+	})
+	repoBooking.AssertNotCalled(t, "Create", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+}
+
+func TestBookingCreate_HiddenSalesPackage(t *testing.T) {
+	db, sqlMock := testutil.NewMockDB(t)
+	repoBooking := &mocks.MockRepositoryBooking{}
+	repoPkg := &mocks.MockRepositorySalesPackage{}
+	svc := newBookingService(db, repoBooking, repoPkg)
+
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectRollback()
+	repoPkg.On("FindAccess", mock.Anything, mock.AnythingOfType("*sql.Tx"), 5, 0).Return(models.Access{}, nil)
+
+	assert.PanicsWithValue(t, exceptions.NewNotFoundError("sales package not found"), func() {
+		svc.Create(context.Background(), webBooking.CreateBookingRequest{
+			ClientName:     "Kopi Co",
+			SalesPackageId: 5,
+			StartDate:      "2026-07-01",
+			EndDate:        "2026-07-31",
+		})
+	})
+	repoPkg.AssertNotCalled(t, "FindVersion", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+}
+
+func TestBookingCreate_InvalidRequest(t *testing.T) {
+	db, _ := testutil.NewMockDB(t)
+	svc := newBookingService(db, &mocks.MockRepositoryBooking{}, &mocks.MockRepositorySalesPackage{})
+
+	assert.PanicsWithValue(t, exceptions.NewBadRequest("end_date must not be before start_date"), func() {
+		svc.Create(context.Background(), webBooking.CreateBookingRequest{BuildingIds: []int{1}, StartDate: "2026-06-10", EndDate: "2026-06-01"})
+	})
+	assert.PanicsWithValue(t, exceptions.NewBadRequest("start_date must be a date in YYYY-MM-DD format"), func() {
+		svc.Create(context.Background(), webBooking.CreateBookingRequest{BuildingIds: []int{1}, StartDate: "10/06/2026", EndDate: "2026-06-01"})
+	})
+}
+
+func TestBookingCreate_RestrictionWarning(t *testing.T) {
+	db, sqlMock := testutil.NewMockDB(t)
+	repoBooking := &mocks.MockRepositoryBooking{}
+	repoRestriction := &mocks.MockRepositoryBuildingRestriction{}
+	repoCategory := &mocks.MockRepositoryCategory{}
+	svc := serviceBooking.NewServiceBookingImpl(db, repoBooking, &mocks.MockRepositorySalesPackage{}, repoRestriction,
+		repoCategory, &mocks.MockRepositoryMotherBrand{})
+
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectCommit()
+
+	repoBooking.On("LockBuildings", mock.Anything, mock.AnythingOfType("*sql.Tx"), []int{1}).Return(nil)
+	repoBooking.On("FindBuildingCapacities", mock.Anything, mock.AnythingOfType("*sql.Tx"), []int{1}).Return(capacities()[:1], nil)
+	repoBooking.On("FindAllocations", mock.Anything, mock.AnythingOfType("*sql.Tx"), []int{1}, "2026-06-01", "2026-06-30", 0).
+		Return([]repositoriesBooking.BookingAllocationRow{}, nil)
+	repoCategory.On("FindById", mock.Anything, mock.AnythingOfType("*sql.Tx"), 4).Return(models.Category{Id: 4, Name: "Alcohol"}, nil)
+	// the restriction is checked over the booking dates
+	repoRestriction.On("FindViolations", mock.Anything, mock.AnythingOfType("*sql.Tx"), repositoriesBuildingRestriction.RestrictionCheck{
+		BuildingIds: []int{1},
+		CategoryId:  4,
+		From:        "2026-06-01",
+		Until:       "2026-06-30",
+	}).Return([]repositoriesBuildingRestriction.RestrictionViolationRow{{
+		RestrictionId: 2, RestrictionName: "Mosque nearby", Enforcement: models.RestrictionEnforcementWarn,
+		BuildingId: 1, BuildingName: "Tower A", Reason: "category", AdvertiserName: "Alcohol",
+	}}, nil)
+	repoBooking.On("Create", mock.Anything, mock.AnythingOfType("*sql.Tx"),
+		mock.MatchedBy(func(b models.Booking) bool {
+			return b.AdvertiserCategoryId != nil && *b.AdvertiserCategoryId == 4 && b.AdvertiserMotherBrandId == nil
+		}),
+		[]models.BookingBuilding{{BuildingId: 1, Screens: 1}},
+	).Return(models.Booking{Id: 12}, nil)
+
+	response := svc.Create(context.Background(), webBooking.CreateBookingRequest{
+		ClientName:           "Brew Co",
+		BuildingIds:          []int{1},
+		StartDate:            "2026-06-01",
+		EndDate:              "2026-06-30",
+		AdvertiserCategoryId: 4,
+	})
+
+	assert.Equal(t, []webBuildingRestriction.RestrictionViolationResponse{{
+		RestrictionId: 2, RestrictionName: "Mosque nearby", Enforcement: models.RestrictionEnforcementWarn,
+		BuildingId: 1, BuildingName: "Tower A", Reason: "category", Advertiser: "Alcohol",
+	}}, response.RestrictionWarnings)
+	repoBooking.AssertExpectations(t)
+	repoRestriction.AssertExpectations(t)
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+}
+
+func TestBookingCreate_RestrictionBlocks(t *testing.T) {
+	db, sqlMock := testutil.NewMockDB(t)
+	repoBooking := &mocks.MockRepositoryBooking{}
+	repoRestriction := &mocks.MockRepositoryBuildingRestriction{}
+	repoMotherBrand := &mocks.MockRepositoryMotherBrand{}
+	svc := serviceBooking.NewServiceBookingImpl(db, repoBooking, &mocks.MockRepositorySalesPackage{}, repoRestriction,
+		&mocks.MockRepositoryCategory{}, repoMotherBrand)
+
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectRollback()
+
+	repoBooking.On("LockBuildings", mock.Anything, mock.AnythingOfType("*sql.Tx"), []int{1}).Return(nil)
+	repoBooking.On("FindBuildingCapacities", mock.Anything, mock.AnythingOfType("*sql.Tx"), []int{1}).Return(capacities()[:1], nil)
+	repoBooking.On("FindAllocations", mock.Anything, mock.AnythingOfType("*sql.Tx"), []int{1}, "2026-06-01", "2026-06-30", 0).
+		Return([]repositoriesBooking.BookingAllocationRow{}, nil)
+	repoMotherBrand.On("FindById", mock.Anything, mock.AnythingOfType("*sql.Tx"), 9).Return(models.MotherBrand{Id: 9, Name: "Rival Group"}, nil)
+	repoRestriction.On("FindViolations", mock.Anything, mock.AnythingOfType("*sql.Tx"), mock.AnythingOfType("buildingrestriction.RestrictionCheck")).
+		Return([]repositoriesBuildingRestriction.RestrictionViolationRow{{
+			RestrictionId: 3, RestrictionName: "Tenant exclusivity", Enforcement: models.RestrictionEnforcementBlock,
+			BuildingId: 1, BuildingName: "Tower A", Reason: "mother_brand", AdvertiserName: "Rival Group",
+		}}, nil)
+
+	var recovered interface{}
+	func() {
+		defer func() { recovered = recover() }()
+		svc.Create(context.Background(), webBooking.CreateBookingRequest{
+			ClientName:              "Rival Co",
+			BuildingIds:             []int{1},
+			StartDate:               "2026-06-01",
+			EndDate:                 "2026-06-30",
+			AdvertiserMotherBrandId: 9,
+		})
+	}()
+
+	err, ok := recovered.(exceptions.BadRequestError)
+	assert.True(t, ok)
+	assert.Equal(t, "advertiser is restricted in some of the buildings", err.Error)
+	violations := err.Extras.([]webBuildingRestriction.RestrictionViolationResponse)
+	assert.Len(t, violations, 1)
+	assert.Equal(t, "Tenant exclusivity", violations[0].RestrictionName)
+	repoBooking.AssertNotCalled(t, "Create", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+}
+
+// --- Update ---
+
+func TestBookingUpdate_ExcludesItselfFromConflicts(t *testing.T) {
+	db, sqlMock := testutil.NewMockDB(t)
+	repoBooking := &mocks.MockRepositoryBooking{}
+	svc := newBookingService(db, repoBooking, &mocks.MockRepositorySalesPackage{})
+
+	existing := models.Booking{Id: 3, ClientName: "Kopi Co", Status: models.BookingStatusConfirmed, StartDate: "2026-06-01", EndDate: "2026-06-10"}
+
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectCommit()
+
+	repoBooking.On("FindById", mock.Anything, mock.AnythingOfType("*sql.Tx"), 3).Return(existing, nil)
+	repoBooking.On("LockBuildings", mock.Anything, mock.AnythingOfType("*sql.Tx"), []int{1}).Return(nil)
+	repoBooking.On("FindBuildingCapacities", mock.Anything, mock.AnythingOfType("*sql.Tx"), []int{1}).Return(capacities()[:1], nil)
+	repoBooking.On("FindAllocations", mock.Anything, mock.AnythingOfType("*sql.Tx"), []int{1}, "2026-06-01", "2026-06-20", 3).
+		Return([]repositoriesBooking.BookingAllocationRow{}, nil)
+	repoBooking.On("Update", mock.Anything, mock.AnythingOfType("*sql.Tx"),
+		mock.MatchedBy(func(b models.Booking) bool { return b.Id == 3 && b.EndDate == "2026-06-20" }),
+		[]models.BookingBuilding{{BuildingId: 1, Screens: 3}},
+	).Return(existing, nil)
+
+	svc.Update(context.Background(), webBooking.UpdateBookingRequest{
+		ClientName:  "Kopi Co",
+		BuildingIds: []int{1},
+		Screens:     3,
+		StartDate:   "2026-06-01",
+		EndDate:     "2026-06-20",
+	}, 3)
+
+	repoBooking.AssertExpectations(t)
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+}
+
+// --- UpdateStatus ---
+
+func TestBookingUpdateStatus_Confirm(t *testing.T) {
+	db, sqlMock := testutil.NewMockDB(t)
+	repoBooking := &mocks.MockRepositoryBooking{}
+	svc := newBookingService(db, repoBooking, &mocks.MockRepositorySalesPackage{})
+
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectCommit()
+
+	repoBooking.On("FindById", mock.Anything, mock.AnythingOfType("*sql.Tx"), 3).
+		Return(models.Booking{Id: 3, Status: models.BookingStatusHold}, nil)
+	repoBooking.On("UpdateStatus", mock.Anything, mock.AnythingOfType("*sql.Tx"), 3, models.BookingStatusConfirmed).
+		Return("2026-06-02 10:00:00", nil)
+
+	response := svc.UpdateStatus(context.Background(), webBooking.UpdateBookingStatusRequest{Status: models.BookingStatusConfirmed}, 3)
+
+	assert.Equal(t, models.BookingStatusConfirmed, response.Status)
+	assert.Equal(t, "2026-06-02 10:00:00", response.UpdatedAt)
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+}
+
+func TestBookingUpdateStatus_CancelledCannotBeReopened(t *testing.T) {
+	db, sqlMock := testutil.NewMockDB(t)
+	repoBooking := &mocks.MockRepositoryBooking{}
+	svc := newBookingService(db, repoBooking, &mocks.MockRepositorySalesPackage{})
+
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectRollback()
+
+	repoBooking.On("FindById", mock.Anything, mock.AnythingOfType("*sql.Tx"), 3).
+		Return(models.Booking{Id: 3, Status: models.BookingStatusCancelled}, nil)
+
+	assert.PanicsWithValue(t, exceptions.NewBadRequest("cancelled bookings cannot be reopened"), func() {
+		svc.UpdateStatus(context.Background(), webBooking.UpdateBookingStatusRequest{Status: models.BookingStatusHold}, 3)
+	})
+	repoBooking.AssertNotCalled(t, "UpdateStatus", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+}
+
+func TestBookingFindById_NotFound(t *testing.T) {
+	db, sqlMock := testutil.NewMockDB(t)
+	repoBooking := &mocks.MockRepositoryBooking{}
+	svc := newBookingService(db, repoBooking, &mocks.MockRepositorySalesPackage{})
+
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectRollback()
+
+	repoBooking.On("FindById", mock.Anything, mock.AnythingOfType("*sql.Tx"), 99).Return(models.Booking{}, sql.ErrNoRows)
+
+	assert.PanicsWithValue(t, exceptions.NewNotFoundError("booking not found"), func() {
+		svc.FindById(context.Background(), 99)
+	})
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+}
+
+// --- Availability ---
+
+func TestBookingAvailability_DailyCalendar(t *testing.T) {
+	db, sqlMock := testutil.NewMockDB(t)
+	repoBooking := &mocks.MockRepositoryBooking{}
+	svc := newBookingService(db, repoBooking, &mocks.MockRepositorySalesPackage{})
+
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectCommit()
+
+	repoBooking.On("FindBuildingCapacities", mock.Anything, mock.AnythingOfType("*sql.Tx"), []int{1, 2}).Return(capacities(), nil)
+	repoBooking.On("FindAllocations", mock.Anything, mock.AnythingOfType("*sql.Tx"), []int{1, 2}, "2026-06-01", "2026-06-03", 0).
+		Return([]repositoriesBooking.BookingAllocationRow{
+			allocation(1, models.BookingStatusConfirmed, "2026-05-25", "2026-06-01", 1, 2),
+			allocation(2, models.BookingStatusHold, "2026-06-02", "2026-06-09", 1, 1),
+			allocation(2, models.BookingStatusHold, "2026-06-02", "2026-06-09", 2, 1),
+		}, nil)
+
+	response := svc.Availability(context.Background(), webBooking.AvailabilityRequest{
+		BuildingIds: []int{1, 2},
+		From:        "2026-06-01",
+		To:          "2026-06-03",
+	})
+
+	assert.False(t, response.Available)
+	towerA, towerB := response.Buildings[0], response.Buildings[1]
+	assert.Equal(t, []webBooking.AvailabilityDayResponse{
+		{Date: "2026-06-01", Confirmed: 2, Held: 0, Available: 1},
+		{Date: "2026-06-02", Confirmed: 0, Held: 1, Available: 2},
+		{Date: "2026-06-03", Confirmed: 0, Held: 1, Available: 2},
+	}, towerA.Days)
+	assert.Equal(t, 1, towerA.MinAvailable)
+	assert.True(t, towerA.Available)
+	assert.True(t, towerA.CapacityKnown)
+	assert.Len(t, towerA.Bookings, 2)
+
+	assert.False(t, towerB.CapacityKnown)
+	assert.Equal(t, 1, towerB.Capacity)
+	assert.Equal(t, 0, towerB.MinAvailable)
+	assert.False(t, towerB.Available)
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+}
+
+func TestBookingAvailability_RangeTooLong(t *testing.T) {
+	db, _ := testutil.NewMockDB(t)
+	svc := newBookingService(db, &mocks.MockRepositoryBooking{}, &mocks.MockRepositorySalesPackage{})
+
+	assert.PanicsWithValue(t, exceptions.NewBadRequest("at most 366 days can be checked at once"), func() {
+		svc.Availability(context.Background(), webBooking.AvailabilityRequest{BuildingIds: []int{1}, From: "2026-01-01", To: "2027-06-01"})
+	})
+}
@@ -0,0 +1,17 @@
+package booking
+
+import (
+	"context"
+
+	webBooking "github.com/malikabdulaziz/tmn-backend/web/booking"
+)
+
+type ServiceBookingInterface interface {
+	Create(ctx context.Context, request webBooking.CreateBookingRequest) webBooking.BookingResponse
+	FindAll(ctx context.Context, request webBooking.BookingRequestFindAll) ([]webBooking.BookingResponse, int)
+	FindById(ctx context.Context, id int) webBooking.BookingResponse
+	Update(ctx context.Context, request webBooking.UpdateBookingRequest, id int) webBooking.BookingResponse
+	UpdateStatus(ctx context.Context, request webBooking.UpdateBookingStatusRequest, id int) webBooking.BookingResponse
+	Delete(ctx context.Context, id int)
+	Availability(ctx context.Context, request webBooking.AvailabilityRequest) webBooking.AvailabilityResponse
+}
@@ -1,33 +1,39 @@
 package branch
 
 import (
-	"bytes"
 	"context"
 	"database/sql"
-	"encoding/csv"
-	"io"
-	"strings"
+	"fmt"
 
 	"github.com/malikabdulaziz/tmn-backend/exceptions"
 	"github.com/malikabdulaziz/tmn-backend/helpers"
+	"github.com/malikabdulaziz/tmn-backend/importer"
 	"github.com/malikabdulaziz/tmn-backend/models"
 	repositoriesBranch "github.com/malikabdulaziz/tmn-backend/repositories/branch"
+	repositoriesMotherBrand "github.com/malikabdulaziz/tmn-backend/repositories/motherbrand"
+	"github.com/malikabdulaziz/tmn-backend/web"
 	webBranch "github.com/malikabdulaziz/tmn-backend/web/branch"
 	"github.com/xuri/excelize/v2"
 )
 
+// branchImportColumns maps each import column to its accepted header spellings
+var branchImportColumns = map[string][]string{"name": {"name"}, "mother_brand": {"mother_brand", "motherbrand"}}
+
 type ServiceBranchImpl struct {
-	DB                          *sql.DB
-	RepositoryBranchInterface repositoriesBranch.RepositoryBranchInterface
+	DB                             *sql.DB
+	RepositoryBranchInterface      repositoriesBranch.RepositoryBranchInterface
+	RepositoryMotherBrandInterface repositoriesMotherBrand.RepositoryMotherBrandInterface
 }
 
 func NewServiceBranchImpl(
 	db *sql.DB,
 	repoBranch repositoriesBranch.RepositoryBranchInterface,
+	repoMotherBrand repositoriesMotherBrand.RepositoryMotherBrandInterface,
 ) ServiceBranchInterface {
 	return &ServiceBranchImpl{
-		DB:                          db,
-		RepositoryBranchInterface: repoBranch,
+		DB:                             db,
+		RepositoryBranchInterface:      repoBranch,
+		RepositoryMotherBrandInterface: repoMotherBrand,
 	}
 }
 
@@ -36,7 +42,8 @@ func (s *ServiceBranchImpl) Create(ctx context.Context, request webBranch.Create
 	helpers.PanicIfError(err)
 	defer helpers.CommitOrRollback(tx)
 
-	branch := models.Branch{Name: request.Name}
+	branch := models.Branch{Name: request.Name, MotherBrandId: request.MotherBrandId}
+	branch.MotherBrandName = s.findMotherBrandName(ctx, tx, request.MotherBrandId)
 	created, err := s.RepositoryBranchInterface.Create(ctx, tx, branch)
 	helpers.PanicIfError(err)
 	return branchModelToResponse(created)
@@ -83,7 +90,18 @@ func (s *ServiceBranchImpl) Update(ctx context.Context, request webBranch.Update
 	}
 	helpers.PanicIfError(err)
 
+	// Moving a branch under a mother brand must not contradict the POIs already using it
+	if request.MotherBrandId != nil && (existing.MotherBrandId == nil || *existing.MotherBrandId != *request.MotherBrandId) {
+		conflicts, err := s.RepositoryBranchInterface.CountPOIsOutsideMotherBrand(ctx, tx, id, *request.MotherBrandId)
+		helpers.PanicIfError(err)
+		if conflicts > 0 {
+			panic(exceptions.NewBadRequest(fmt.Sprintf("Branch %s is used by %d POI(s) of another mother brand", existing.Name, conflicts)))
+		}
+	}
+
 	existing.Name = request.Name
+	existing.MotherBrandId = request.MotherBrandId
+	existing.MotherBrandName = s.findMotherBrandName(ctx, tx, request.MotherBrandId)
 	updated, err := s.RepositoryBranchInterface.Update(ctx, tx, existing)
 	helpers.PanicIfError(err)
 	return branchModelToResponse(updated)
@@ -99,58 +117,92 @@ func (s *ServiceBranchImpl) Delete(ctx context.Context, id int) {
 		panic(exceptions.NewNotFoundError("branch not found"))
 	}
 	helpers.PanicIfError(err)
-	err = s.RepositoryBranchInterface.Delete(ctx, tx, id)
+	err = s.RepositoryBranchInterface.Delete(ctx, tx, id, helpers.OptionalUserIdFromContext(ctx))
 	helpers.PanicIfError(err)
 }
 
-func (s *ServiceBranchImpl) Import(ctx context.Context, fileBytes []byte, fileType string) []webBranch.BranchResponse {
+func (s *ServiceBranchImpl) Import(ctx context.Context, fileBytes []byte, fileType string, onError string) ([]webBranch.BranchResponse, web.ImportReport) {
 	tx, err := s.DB.Begin()
 	helpers.PanicIfError(err)
 	defer helpers.CommitOrRollback(tx)
 
-	var rows [][]string
-	switch strings.ToLower(fileType) {
-	case "xlsx":
-		rows, err = brParseXLSX(fileBytes)
-		helpers.PanicIfError(err)
-	case "csv":
-		rows, err = brParseCSV(fileBytes)
-		helpers.PanicIfError(err)
-	default:
-		panic(exceptions.NewBadRequestError("Unsupported file type. Use xlsx or csv."))
-	}
-
-	if len(rows) < 2 {
-		panic(exceptions.NewBadRequestError("File must contain a header row and at least one data row."))
-	}
-
-	header := rows[0]
-	colMap := brMapHeaderColumns(header)
+	sheet := importer.Read(fileBytes, fileType, branchImportColumns)
+	sheet.RequireColumns("name")
 
-	if _, exists := colMap["name"]; !exists {
-		panic(exceptions.NewBadRequestError("Missing required column: name"))
+	// Mother brands are looked up while validating so an unknown or conflicting mother brand rejects
+	// the row before anything is written
+	report := importer.NewReport(sheet, onError)
+	motherBrands := map[int]models.MotherBrand{}
+	for i := range sheet.Rows {
+		if sheet.IsBlank(i) {
+			continue
+		}
+		row := importer.RowNumber(i)
+		if !report.RequireText(row, "name", 255) {
+			continue
+		}
+		motherBrandName := sheet.Value(i, "mother_brand")
+		if motherBrandName == "" {
+			continue
+		}
+		motherBrand, err := s.RepositoryMotherBrandInterface.FindByName(ctx, tx, motherBrandName)
+		if err == sql.ErrNoRows {
+			report.Reject(row, "mother_brand", "Mother Brand not found")
+			continue
+		}
+		helpers.PanicIfError(err)
+		existing, err := s.RepositoryBranchInterface.FindByName(ctx, tx, sheet.Value(i, "name"))
+		if err != sql.ErrNoRows {
+			helpers.PanicIfError(err)
+			if existing.MotherBrandId != nil && *existing.MotherBrandId != motherBrand.Id {
+				report.Reject(row, "mother_brand", fmt.Sprintf("Branch already belongs to mother brand %s", existing.MotherBrandName))
+				continue
+			}
+			if existing.MotherBrandId == nil {
+				conflicts, err := s.RepositoryBranchInterface.CountPOIsOutsideMotherBrand(ctx, tx, existing.Id, motherBrand.Id)
+				helpers.PanicIfError(err)
+				if conflicts > 0 {
+					report.Reject(row, "mother_brand", fmt.Sprintf("Branch is used by %d POI(s) of another mother brand", conflicts))
+					continue
+				}
+			}
+		}
+		motherBrands[row] = motherBrand
 	}
+	report.Check()
 
+	tracker := importer.NewTracker(ctx, len(sheet.Rows))
 	var responses []webBranch.BranchResponse
-	for _, row := range rows[1:] {
-		name := brGetColValue(row, colMap, "name")
-		if name == "" {
+	for i := range sheet.Rows {
+		tracker.Advance(1)
+		name := sheet.Value(i, "name")
+		if sheet.IsBlank(i) || report.Rejected(importer.RowNumber(i)) {
 			continue
 		}
 
+		motherBrand, hasMotherBrand := motherBrands[importer.RowNumber(i)]
 		existing, err := s.RepositoryBranchInterface.FindByName(ctx, tx, name)
 		if err == sql.ErrNoRows {
 			branch := models.Branch{Name: name}
+			if hasMotherBrand {
+				branch.MotherBrandId, branch.MotherBrandName = &motherBrand.Id, motherBrand.Name
+			}
 			created, err := s.RepositoryBranchInterface.Create(ctx, tx, branch)
 			helpers.PanicIfError(err)
 			responses = append(responses, branchModelToResponse(created))
 		} else {
 			helpers.PanicIfError(err)
+			// A branch without a mother brand is placed under the one given in the file
+			if hasMotherBrand && existing.MotherBrandId == nil {
+				existing.MotherBrandId, existing.MotherBrandName = &motherBrand.Id, motherBrand.Name
+				existing, err = s.RepositoryBranchInterface.Update(ctx, tx, existing)
+				helpers.PanicIfError(err)
+			}
 			responses = append(responses, branchModelToResponse(existing))
 		}
 	}
 
-	return responses
+	return responses, report.Result()
 }
 
 func (s *ServiceBranchImpl) Export(ctx context.Context, search string) ([]byte, error) {
@@ -168,60 +220,61 @@ func (s *ServiceBranchImpl) Export(ctx context.Context, search string) ([]byte,
 	return buildBranchExcel(list)
 }
 
-func branchModelToResponse(c models.Branch) webBranch.BranchResponse {
-	return webBranch.BranchResponse{
-		Id:        c.Id,
-		Name:      c.Name,
-		CreatedAt: c.CreatedAt,
-		UpdatedAt: c.UpdatedAt,
+// ImportTemplate returns a blank branch import xlsx whose Mother Brand column offers the
+// current mother brands as a dropdown
+func (s *ServiceBranchImpl) ImportTemplate(ctx context.Context) ([]byte, error) {
+	tx, err := s.DB.Begin()
+	if err != nil {
+		return nil, err
 	}
-}
-
-// --- Import helpers ---
+	defer helpers.CommitOrRollback(tx)
 
-func brParseXLSX(fileBytes []byte) ([][]string, error) {
-	f, err := excelize.OpenReader(bytes.NewReader(fileBytes))
+	motherBrands, err := s.RepositoryMotherBrandInterface.FindAllDropdown(ctx, tx)
 	if err != nil {
 		return nil, err
 	}
-	defer f.Close()
-	sheetName := f.GetSheetName(0)
-	return f.GetRows(sheetName)
-}
-
-func brParseCSV(fileBytes []byte) ([][]string, error) {
-	reader := csv.NewReader(bytes.NewReader(fileBytes))
-	var rows [][]string
-	for {
-		record, err := reader.Read()
-		if err == io.EOF {
-			break
-		}
-		if err != nil {
-			return nil, err
-		}
-		rows = append(rows, record)
+	motherBrandNames := make([]string, len(motherBrands))
+	for i, c := range motherBrands {
+		motherBrandNames[i] = c.Name
 	}
-	return rows, nil
+
+	return importer.BuildTemplate(importer.Template{
+		SheetName: "Branches",
+		Columns: []importer.TemplateColumn{
+			{Key: "name", Header: "Name", Description: "Name of the branch.", Required: true},
+			{Key: "mother_brand", Header: "Mother Brand", Description: "Mother brand the branch belongs to. Must already exist.", Options: motherBrandNames, Strict: true},
+		},
+		Instructions: []string{
+			"Fill one branch per row on the Branches sheet and keep the header row as it is.",
+			"Names that already exist, or were merged into an existing entry, are left as they are; new names are created.",
+			"A Mother Brand places a new branch, or one without a mother brand yet, under that mother brand. A branch cannot be moved to another mother brand by import.",
+		},
+	}, branchImportColumns)
 }
 
-func brMapHeaderColumns(header []string) map[string]int {
-	colMap := make(map[string]int)
-	for i, h := range header {
-		normalized := strings.ToLower(strings.TrimSpace(h))
-		if normalized == "name" {
-			colMap["name"] = i
-		}
+// findMotherBrandName returns the name of the mother brand a branch is placed under, panicking
+// with a BadRequest when it does not exist
+func (s *ServiceBranchImpl) findMotherBrandName(ctx context.Context, tx *sql.Tx, motherBrandId *int) string {
+	if motherBrandId == nil {
+		return ""
+	}
+	motherBrand, err := s.RepositoryMotherBrandInterface.FindById(ctx, tx, *motherBrandId)
+	if err == sql.ErrNoRows {
+		panic(exceptions.NewBadRequest("Mother Brand not found"))
 	}
-	return colMap
+	helpers.PanicIfError(err)
+	return motherBrand.Name
 }
 
-func brGetColValue(row []string, colMap map[string]int, key string) string {
-	idx, exists := colMap[key]
-	if !exists || idx >= len(row) {
-		return ""
+func branchModelToResponse(c models.Branch) webBranch.BranchResponse {
+	return webBranch.BranchResponse{
+		Id:              c.Id,
+		Name:            c.Name,
+		MotherBrandId:   c.MotherBrandId,
+		MotherBrandName: c.MotherBrandName,
+		CreatedAt:       c.CreatedAt,
+		UpdatedAt:       c.UpdatedAt,
 	}
-	return strings.TrimSpace(row[idx])
 }
 
 // --- Export helpers ---
@@ -230,15 +283,17 @@ func buildBranchExcel(list []models.Branch) ([]byte, error) {
 	f := excelize.NewFile()
 	const sheet = "Sheet1"
 
-	headers := []string{"Name"}
+	headers := []string{"Name", "Mother Brand"}
 	for i, h := range headers {
 		cell, _ := excelize.CoordinatesToCellName(i+1, 1)
 		_ = f.SetCellValue(sheet, cell, h)
 	}
 
 	for rowIdx, branch := range list {
-		cell, _ := excelize.CoordinatesToCellName(1, rowIdx+2)
-		_ = f.SetCellValue(sheet, cell, branch.Name)
+		for colIdx, value := range []string{branch.Name, branch.MotherBrandName} {
+			cell, _ := excelize.CoordinatesToCellName(colIdx+1, rowIdx+2)
+			_ = f.SetCellValue(sheet, cell, value)
+		}
 	}
 
 	_ = f.SetSheetName(sheet, "Branches")
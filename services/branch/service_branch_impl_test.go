@@ -6,6 +6,8 @@ import (
 	"testing"
 
 	"github.com/malikabdulaziz/tmn-backend/exceptions"
+	"github.com/malikabdulaziz/tmn-backend/helpers"
+	"github.com/malikabdulaziz/tmn-backend/importer"
 	"github.com/malikabdulaziz/tmn-backend/models"
 	serviceBranch "github.com/malikabdulaziz/tmn-backend/services/branch"
 	"github.com/malikabdulaziz/tmn-backend/testutil"
@@ -16,7 +18,7 @@ import (
 )
 
 func newBranchService(db *sql.DB, repo *mocks.MockRepositoryBranch) serviceBranch.ServiceBranchInterface {
-	return serviceBranch.NewServiceBranchImpl(db, repo)
+	return serviceBranch.NewServiceBranchImpl(db, repo, &mocks.MockRepositoryMotherBrand{})
 }
 
 func newBranchModel(id int, name string) models.Branch {
@@ -49,6 +51,23 @@ func TestBranchCreate_HappyPath(t *testing.T) {
 	assert.NoError(t, sqlMock.ExpectationsWereMet())
 }
 
+func TestBranchCreate_UnknownMotherBrand(t *testing.T) {
+	db, sqlMock := testutil.NewMockDB(t)
+	repo := &mocks.MockRepositoryBranch{}
+	motherBrandRepo := &mocks.MockRepositoryMotherBrand{}
+	svc := serviceBranch.NewServiceBranchImpl(db, repo, motherBrandRepo)
+
+	motherBrandId := 404
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectRollback()
+	motherBrandRepo.On("FindById", mock.Anything, mock.AnythingOfType("*sql.Tx"), 404).Return(models.MotherBrand{}, sql.ErrNoRows)
+
+	assert.PanicsWithValue(t, exceptions.NewBadRequest("Mother Brand not found"), func() {
+		svc.Create(context.Background(), webBranch.CreateBranchRequest{Name: "Jakarta Branch", MotherBrandId: &motherBrandId})
+	})
+	repo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything, mock.Anything)
+}
+
 // --- FindById ---
 
 func TestBranchFindById_HappyPath(t *testing.T) {
@@ -122,6 +141,28 @@ func TestBranchUpdate_HappyPath(t *testing.T) {
 	assert.NoError(t, sqlMock.ExpectationsWereMet())
 }
 
+func TestBranchUpdate_MotherBrandUsedOtherwiseByPOIs(t *testing.T) {
+	db, sqlMock := testutil.NewMockDB(t)
+	repo := &mocks.MockRepositoryBranch{}
+	svc := newBranchService(db, repo)
+
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectRollback()
+
+	repo.On("FindById", mock.Anything, mock.AnythingOfType("*sql.Tx"), 5).
+		Return(newBranchModel(5, "Bandung"), nil)
+	repo.On("CountPOIsOutsideMotherBrand", mock.Anything, mock.AnythingOfType("*sql.Tx"), 5, 2).
+		Return(3, nil)
+
+	motherBrand := 2
+	assert.PanicsWithValue(t, exceptions.NewBadRequest("Branch Bandung is used by 3 POI(s) of another mother brand"), func() {
+		svc.Update(context.Background(), webBranch.UpdateBranchRequest{Name: "Bandung", MotherBrandId: &motherBrand}, 5)
+	})
+
+	repo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything, mock.Anything)
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+}
+
 // --- Delete ---
 
 func TestBranchDelete_HappyPath(t *testing.T) {
@@ -134,10 +175,12 @@ func TestBranchDelete_HappyPath(t *testing.T) {
 
 	repo.On("FindById", mock.Anything, mock.AnythingOfType("*sql.Tx"), 2).
 		Return(newBranchModel(2, "ToDelete"), nil)
-	repo.On("Delete", mock.Anything, mock.AnythingOfType("*sql.Tx"), 2).
+	repo.On("Delete", mock.Anything, mock.AnythingOfType("*sql.Tx"), 2,
+		mock.MatchedBy(func(deletedBy *int) bool { return deletedBy != nil && *deletedBy == 7 })).
 		Return(nil)
 
-	assert.NotPanics(t, func() { svc.Delete(context.Background(), 2) })
+	ctx := context.WithValue(context.Background(), helpers.ContextKey("userId"), "7")
+	assert.NotPanics(t, func() { svc.Delete(ctx, 2) })
 
 	repo.AssertExpectations(t)
 	assert.NoError(t, sqlMock.ExpectationsWereMet())
@@ -183,7 +226,7 @@ func TestBranchImport_CSV_NewItem(t *testing.T) {
 		mock.MatchedBy(func(c models.Branch) bool { return c.Name == "Jakarta Branch" }),
 	).Return(created, nil)
 
-	responses := svc.Import(context.Background(), []byte(csvData), "csv")
+	responses, _ := svc.Import(context.Background(), []byte(csvData), "csv", importer.OnErrorAbort)
 
 	assert.Len(t, responses, 1)
 	assert.Equal(t, "Jakarta Branch", responses[0].Name)
@@ -206,7 +249,7 @@ func TestBranchImport_CSV_ExistingItem(t *testing.T) {
 	repo.On("FindByName", mock.Anything, mock.AnythingOfType("*sql.Tx"), "Jakarta Branch").
 		Return(existing, nil)
 
-	responses := svc.Import(context.Background(), []byte(csvData), "csv")
+	responses, _ := svc.Import(context.Background(), []byte(csvData), "csv", importer.OnErrorAbort)
 
 	assert.Len(t, responses, 1)
 	assert.Equal(t, 7, responses[0].Id)
@@ -215,3 +258,23 @@ func TestBranchImport_CSV_ExistingItem(t *testing.T) {
 	repo.AssertExpectations(t)
 	assert.NoError(t, sqlMock.ExpectationsWereMet())
 }
+
+func TestBranchImport_CSV_UnknownMotherBrand(t *testing.T) {
+	db, sqlMock := testutil.NewMockDB(t)
+	repo := &mocks.MockRepositoryBranch{}
+	motherBrandRepo := &mocks.MockRepositoryMotherBrand{}
+	svc := serviceBranch.NewServiceBranchImpl(db, repo, motherBrandRepo)
+
+	csvData := "Name,Mother Brand\nJakarta Branch,Unknown Group\n"
+
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectRollback()
+	motherBrandRepo.On("FindByName", mock.Anything, mock.AnythingOfType("*sql.Tx"), "Unknown Group").
+		Return(models.MotherBrand{}, sql.ErrNoRows)
+
+	assert.Panics(t, func() {
+		svc.Import(context.Background(), []byte(csvData), "csv", importer.OnErrorAbort)
+	})
+	repo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything, mock.Anything)
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+}
@@ -3,6 +3,7 @@ package branch
 import (
 	"context"
 
+	"github.com/malikabdulaziz/tmn-backend/web"
 	webBranch "github.com/malikabdulaziz/tmn-backend/web/branch"
 )
 
@@ -12,6 +13,7 @@ type ServiceBranchInterface interface {
 	FindById(ctx context.Context, id int) webBranch.BranchResponse
 	Update(ctx context.Context, request webBranch.UpdateBranchRequest, id int) webBranch.BranchResponse
 	Delete(ctx context.Context, id int)
-	Import(ctx context.Context, fileBytes []byte, fileType string) []webBranch.BranchResponse
+	Import(ctx context.Context, fileBytes []byte, fileType string, onError string) ([]webBranch.BranchResponse, web.ImportReport)
 	Export(ctx context.Context, search string) ([]byte, error)
+	ImportTemplate(ctx context.Context) ([]byte, error)
 }
@@ -0,0 +1,362 @@
+package building
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/malikabdulaziz/tmn-backend/exceptions"
+	"github.com/malikabdulaziz/tmn-backend/helpers"
+	webBuilding "github.com/malikabdulaziz/tmn-backend/web/building"
+	"github.com/xuri/excelize/v2"
+)
+
+// POI density bounds: rings are geodesic radii in meters around each building
+const (
+	maxPOIDensityRings      = 5
+	maxPOIDensityRingMeters = 5000
+	maxPOIDensityBuildings  = 5000
+)
+
+var defaultPOIDensityRings = []int{250, 500, 1000}
+
+// normalizePOIDensityRings sorts and de-duplicates the requested rings, falling back to the defaults
+func normalizePOIDensityRings(rings []int) []int {
+	if len(rings) == 0 {
+		return append([]int(nil), defaultPOIDensityRings...)
+	}
+	seen := make(map[int]bool, len(rings))
+	normalized := make([]int, 0, len(rings))
+	for _, ring := range rings {
+		if ring <= 0 || ring > maxPOIDensityRingMeters {
+			panic(exceptions.NewBadRequest(fmt.Sprintf("ring_meters must be between 1 and %d", maxPOIDensityRingMeters)))
+		}
+		if !seen[ring] {
+			seen[ring] = true
+			normalized = append(normalized, ring)
+		}
+	}
+	if len(normalized) > maxPOIDensityRings {
+		panic(exceptions.NewBadRequest(fmt.Sprintf("at most %d rings are allowed", maxPOIDensityRings)))
+	}
+	sort.Ints(normalized)
+	return normalized
+}
+
+// AnalyzePOIDensity scores buildings by the POI points around them: per ring, the total count and the
+// split by category and mother brand. Buildings without coordinates are left out and counted separately.
+func (service *ServiceBuildingImpl) AnalyzePOIDensity(ctx context.Context, request webBuilding.POIDensityRequest) webBuilding.POIDensityResponse {
+	rings := normalizePOIDensityRings(request.RingMeters)
+
+	sortBy := strings.ToLower(strings.TrimSpace(request.SortBy))
+	if sortBy == "" {
+		sortBy = "total"
+	}
+	switch sortBy {
+	case "total", "name":
+	case "category", "mother_brand":
+		if request.SortId <= 0 {
+			panic(exceptions.NewBadRequest("sort_id is required when sorting by " + sortBy))
+		}
+	default:
+		panic(exceptions.NewBadRequest("sort_by must be one of total, category, mother_brand, name"))
+	}
+	sortRing := rings[len(rings)-1]
+	if request.SortRingMeters != 0 {
+		sortRing = request.SortRingMeters
+		found := false
+		for _, ring := range rings {
+			found = found || ring == sortRing
+		}
+		if !found {
+			panic(exceptions.NewBadRequest("sort_ring_meters must be one of ring_meters"))
+		}
+	}
+	ascending := strings.EqualFold(request.SortDirection, "asc")
+	if sortBy == "name" {
+		ascending = !strings.EqualFold(request.SortDirection, "desc")
+	}
+
+	buildings := service.poiDensityBuildings(ctx, request)
+
+	response := webBuilding.POIDensityResponse{RingMeters: rings, Data: []webBuilding.POIDensityBuildingResponse{}}
+	located := make([]webBuilding.POIDensityBuildingResponse, 0, len(buildings))
+	ids := make([]int, 0, len(buildings))
+	for _, b := range buildings {
+		if b.Latitude == 0 || b.Longitude == 0 {
+			response.SkippedWithoutLocation++
+			continue
+		}
+		located = append(located, b)
+		ids = append(ids, b.Id)
+	}
+	if len(located) == 0 {
+		return response
+	}
+	if len(located) > maxPOIDensityBuildings {
+		panic(exceptions.NewBadRequest(fmt.Sprintf("too many buildings (%d); narrow the filters to at most %d", len(located), maxPOIDensityBuildings)))
+	}
+
+	tx, err := service.DB.Begin()
+	helpers.PanicIfError(err)
+	defer helpers.CommitOrRollback(tx)
+
+	rows, err := service.RepositoryBuildingInterface.CountPOIPointsInRings(ctx, tx, ids, rings, request.CategoryIds, request.MotherBrandIds)
+	helpers.PanicIfError(err)
+
+	type ringCounts struct {
+		total        int
+		categories   map[int]*webBuilding.POIDensityCountResponse
+		motherBrands map[int]*webBuilding.POIDensityCountResponse
+	}
+	counts := make(map[int]map[int]*ringCounts, len(located))
+	for _, row := range rows {
+		byRing, ok := counts[row.BuildingId]
+		if !ok {
+			byRing = make(map[int]*ringCounts, len(rings))
+			counts[row.BuildingId] = byRing
+		}
+		rc, ok := byRing[row.RingMeters]
+		if !ok {
+			rc = &ringCounts{categories: map[int]*webBuilding.POIDensityCountResponse{}, motherBrands: map[int]*webBuilding.POIDensityCountResponse{}}
+			byRing[row.RingMeters] = rc
+		}
+		rc.total += row.Count
+		if c, ok := rc.categories[row.CategoryId]; ok {
+			c.Count += row.Count
+		} else {
+			rc.categories[row.CategoryId] = &webBuilding.POIDensityCountResponse{Id: row.CategoryId, Name: row.CategoryName, Count: row.Count}
+		}
+		if mb, ok := rc.motherBrands[row.MotherBrandId]; ok {
+			mb.Count += row.Count
+		} else {
+			rc.motherBrands[row.MotherBrandId] = &webBuilding.POIDensityCountResponse{Id: row.MotherBrandId, Name: row.MotherBrandName, Count: row.Count}
+		}
+	}
+
+	scores := make(map[int]int, len(located))
+	for i := range located {
+		b := &located[i]
+		b.Rings = make([]webBuilding.POIDensityRingResponse, 0, len(rings))
+		for _, ring := range rings {
+			ringResponse := webBuilding.POIDensityRingResponse{
+				RingMeters:   ring,
+				Categories:   []webBuilding.POIDensityCountResponse{},
+				MotherBrands: []webBuilding.POIDensityCountResponse{},
+			}
+			if rc, ok := counts[b.Id][ring]; ok {
+				ringResponse.Total = rc.total
+				ringResponse.Categories = sortedPOIDensityCounts(rc.categories)
+				ringResponse.MotherBrands = sortedPOIDensityCounts(rc.motherBrands)
+				if ring == sortRing {
+					switch sortBy {
+					case "total":
+						scores[b.Id] = rc.total
+					case "category":
+						if c, ok := rc.categories[request.SortId]; ok {
+							scores[b.Id] = c.Count
+						}
+					case "mother_brand":
+						if mb, ok := rc.motherBrands[request.SortId]; ok {
+							scores[b.Id] = mb.Count
+						}
+					}
+				}
+			}
+			b.Rings = append(b.Rings, ringResponse)
+		}
+	}
+
+	sort.SliceStable(located, func(i, j int) bool {
+		a, b := located[i], located[j]
+		if sortBy != "name" && scores[a.Id] != scores[b.Id] {
+			if ascending {
+				return scores[a.Id] < scores[b.Id]
+			}
+			return scores[a.Id] > scores[b.Id]
+		}
+		if a.Name != b.Name {
+			if sortBy == "name" && !ascending {
+				return a.Name > b.Name
+			}
+			return a.Name < b.Name
+		}
+		return a.Id < b.Id
+	})
+	if request.Limit > 0 && len(located) > request.Limit {
+		located = located[:request.Limit]
+	}
+
+	response.Data = located
+	return response
+}
+
+// poiDensityBuildings resolves the building set of a density request
+func (service *ServiceBuildingImpl) poiDensityBuildings(ctx context.Context, request webBuilding.POIDensityRequest) []webBuilding.POIDensityBuildingResponse {
+	result := []webBuilding.POIDensityBuildingResponse{}
+
+	if len(request.BuildingIds) > 0 {
+		tx, err := service.DB.Begin()
+		helpers.PanicIfError(err)
+		defer helpers.CommitOrRollback(tx)
+
+		buildings, err := service.RepositoryBuildingInterface.FindByIds(ctx, tx, request.BuildingIds)
+		helpers.PanicIfError(err)
+		for _, b := range buildings {
+			result = append(result, webBuilding.POIDensityBuildingResponse{
+				Id:                 b.Id,
+				ExternalBuildingId: b.ExternalBuildingId,
+				Name:               b.Name,
+				BuildingType:       b.BuildingType,
+				GradeResource:      b.GradeResource,
+				Subdistrict:        b.Subdistrict,
+				Citytown:           b.Citytown,
+				Latitude:           b.Latitude,
+				Longitude:          b.Longitude,
+			})
+		}
+		return result
+	}
+
+	if request.Filters == nil {
+		panic(exceptions.NewBadRequest("building_ids or filters is required"))
+	}
+	mapping := service.FindAllForMapping(ctx, webBuilding.BuildMappingRequestFromExportBody(&webBuilding.ExportMappingByFilterRequest{
+		Filters: *request.Filters,
+	}))
+	for _, b := range mapping.Data {
+		result = append(result, webBuilding.POIDensityBuildingResponse{
+			Id:                 b.Id,
+			ExternalBuildingId: b.ExternalBuildingId,
+			Name:               b.Name,
+			BuildingType:       b.BuildingType,
+			GradeResource:      b.GradeResource,
+			Subdistrict:        b.Subdistrict,
+			Citytown:           b.Citytown,
+			Latitude:           b.Latitude,
+			Longitude:          b.Longitude,
+		})
+	}
+	return result
+}
+
+// sortedPOIDensityCounts lists counts highest first, then by name
+func sortedPOIDensityCounts(counts map[int]*webBuilding.POIDensityCountResponse) []webBuilding.POIDensityCountResponse {
+	list := make([]webBuilding.POIDensityCountResponse, 0, len(counts))
+	for _, c := range counts {
+		list = append(list, *c)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		if list[i].Count != list[j].Count {
+			return list[i].Count > list[j].Count
+		}
+		if list[i].Name != list[j].Name {
+			return list[i].Name < list[j].Name
+		}
+		return list[i].Id < list[j].Id
+	})
+	return list
+}
+
+// ExportPOIDensity returns Excel bytes for a density request, in the same order as AnalyzePOIDensity.
+// The first sheet has one row per building with the total and per-category count of every ring;
+// the Mother Brands sheet lists the mother brand counts one row per building, ring and brand.
+func (service *ServiceBuildingImpl) ExportPOIDensity(ctx context.Context, request webBuilding.POIDensityRequest) ([]byte, error) {
+	return buildExcelFromPOIDensity(service.AnalyzePOIDensity(ctx, request))
+}
+
+func buildExcelFromPOIDensity(density webBuilding.POIDensityResponse) ([]byte, error) {
+	// Category columns are every category seen in the result, by name
+	categoryNames := map[int]string{}
+	for _, b := range density.Data {
+		for _, ring := range b.Rings {
+			for _, c := range ring.Categories {
+				categoryNames[c.Id] = c.Name
+			}
+		}
+	}
+	categoryIds := make([]int, 0, len(categoryNames))
+	for id := range categoryNames {
+		categoryIds = append(categoryIds, id)
+	}
+	sort.Slice(categoryIds, func(i, j int) bool {
+		return poiDensityCountName(categoryNames[categoryIds[i]], "Uncategorised") < poiDensityCountName(categoryNames[categoryIds[j]], "Uncategorised")
+	})
+
+	f := excelize.NewFile()
+	sheetName := "POI Density"
+	const sheet = "Sheet1"
+	headers := []string{"Building ID", "Name", "Building Type", "Grade", "Subdistrict", "City", "Latitude", "Longitude"}
+	fixedColumns := len(headers)
+	for _, ring := range density.RingMeters {
+		headers = append(headers, fmt.Sprintf("Total ≤%d m", ring))
+		for _, id := range categoryIds {
+			headers = append(headers, fmt.Sprintf("%s ≤%d m", poiDensityCountName(categoryNames[id], "Uncategorised"), ring))
+		}
+	}
+	for i, h := range headers {
+		_ = f.SetCellValue(sheet, mustCell(i+1, 1), h)
+	}
+	for row, b := range density.Data {
+		rowIdx := row + 2
+		_ = f.SetCellValue(sheet, mustCell(1, rowIdx), b.ExternalBuildingId)
+		_ = f.SetCellValue(sheet, mustCell(2, rowIdx), b.Name)
+		_ = f.SetCellValue(sheet, mustCell(3, rowIdx), b.BuildingType)
+		_ = f.SetCellValue(sheet, mustCell(4, rowIdx), b.GradeResource)
+		_ = f.SetCellValue(sheet, mustCell(5, rowIdx), b.Subdistrict)
+		_ = f.SetCellValue(sheet, mustCell(6, rowIdx), b.Citytown)
+		_ = f.SetCellValue(sheet, mustCell(7, rowIdx), b.Latitude)
+		_ = f.SetCellValue(sheet, mustCell(8, rowIdx), b.Longitude)
+		col := fixedColumns + 1
+		for _, ring := range b.Rings {
+			_ = f.SetCellValue(sheet, mustCell(col, rowIdx), ring.Total)
+			col++
+			byCategory := make(map[int]int, len(ring.Categories))
+			for _, c := range ring.Categories {
+				byCategory[c.Id] = c.Count
+			}
+			for _, id := range categoryIds {
+				_ = f.SetCellValue(sheet, mustCell(col, rowIdx), byCategory[id])
+				col++
+			}
+		}
+	}
+	if sheetName != sheet {
+		_ = f.SetSheetName(sheet, sheetName)
+	}
+
+	const brandSheet = "Mother Brands"
+	if _, err := f.NewSheet(brandSheet); err != nil {
+		return nil, err
+	}
+	for i, h := range []string{"Building ID", "Name", "Ring (m)", "Mother Brand", "POI Points"} {
+		_ = f.SetCellValue(brandSheet, mustCell(i+1, 1), h)
+	}
+	rowIdx := 2
+	for _, b := range density.Data {
+		for _, ring := range b.Rings {
+			for _, mb := range ring.MotherBrands {
+				_ = f.SetCellValue(brandSheet, mustCell(1, rowIdx), b.ExternalBuildingId)
+				_ = f.SetCellValue(brandSheet, mustCell(2, rowIdx), b.Name)
+				_ = f.SetCellValue(brandSheet, mustCell(3, rowIdx), ring.RingMeters)
+				_ = f.SetCellValue(brandSheet, mustCell(4, rowIdx), poiDensityCountName(mb.Name, "No mother brand"))
+				_ = f.SetCellValue(brandSheet, mustCell(5, rowIdx), mb.Count)
+				rowIdx++
+			}
+		}
+	}
+
+	buf, err := f.WriteToBuffer()
+	if err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func poiDensityCountName(name string, fallback string) string {
+	if name == "" {
+		return fallback
+	}
+	return name
+}
@@ -0,0 +1,150 @@
+package building_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/malikabdulaziz/tmn-backend/exceptions"
+	"github.com/malikabdulaziz/tmn-backend/models"
+	repositoriesBuilding "github.com/malikabdulaziz/tmn-backend/repositories/building"
+	"github.com/malikabdulaziz/tmn-backend/testutil"
+	"github.com/malikabdulaziz/tmn-backend/testutil/mocks"
+	webBuilding "github.com/malikabdulaziz/tmn-backend/web/building"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/xuri/excelize/v2"
+)
+
+// densityRows: building 1 has 2 F&B points within 250 m and 5 within 1 km; building 2 has 3 F&B
+// within 1 km but 4 banks, so it leads on total and building 1 leads on F&B
+func densityRows() []repositoriesBuilding.POIDensityRow {
+	return []repositoriesBuilding.POIDensityRow{
+		{BuildingId: 1, RingMeters: 250, CategoryId: 7, CategoryName: "F&B", MotherBrandId: 3, MotherBrandName: "Kopi Group", Count: 2},
+		{BuildingId: 1, RingMeters: 1000, CategoryId: 7, CategoryName: "F&B", MotherBrandId: 3, MotherBrandName: "Kopi Group", Count: 4},
+		{BuildingId: 1, RingMeters: 1000, CategoryId: 7, CategoryName: "F&B", MotherBrandId: 0, Count: 1},
+		{BuildingId: 2, RingMeters: 1000, CategoryId: 7, CategoryName: "F&B", MotherBrandId: 3, MotherBrandName: "Kopi Group", Count: 3},
+		{BuildingId: 2, RingMeters: 1000, CategoryId: 9, CategoryName: "Bank", MotherBrandId: 5, MotherBrandName: "Bank Group", Count: 4},
+	}
+}
+
+func TestAnalyzePOIDensity_SortByCategory(t *testing.T) {
+	db, sqlMock := testutil.NewMockDB(t)
+	repoBuilding := &mocks.MockRepositoryBuilding{}
+	svc := newBuildingService(db, repoBuilding, &mocks.MockRepositoryPOI{})
+
+	noLocation := testutil.NewBuilding(3, "Unmapped Tower")
+	noLocation.Latitude, noLocation.Longitude = 0, 0
+
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectCommit()
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectCommit()
+
+	repoBuilding.On("FindByIds", mock.Anything, mock.AnythingOfType("*sql.Tx"), []int{1, 2, 3}).
+		Return([]models.Building{testutil.NewBuilding(1, "Alpha"), testutil.NewBuilding(2, "Beta"), noLocation}, nil)
+	repoBuilding.On("CountPOIPointsInRings", mock.Anything, mock.AnythingOfType("*sql.Tx"), []int{1, 2}, []int{250, 500, 1000}, []int(nil), []int(nil)).
+		Return(densityRows(), nil)
+
+	response := svc.AnalyzePOIDensity(context.Background(), webBuilding.POIDensityRequest{
+		BuildingIds: []int{1, 2, 3},
+		SortBy:      "category",
+		SortId:      7,
+	})
+
+	assert.Equal(t, []int{250, 500, 1000}, response.RingMeters)
+	assert.Equal(t, 1, response.SkippedWithoutLocation)
+	assert.Len(t, response.Data, 2)
+	assert.Equal(t, "Alpha", response.Data[0].Name)
+
+	alpha := response.Data[0]
+	assert.Equal(t, 2, alpha.Rings[0].Total)
+	assert.Equal(t, 0, alpha.Rings[1].Total)
+	assert.Empty(t, alpha.Rings[1].Categories)
+	assert.Equal(t, 5, alpha.Rings[2].Total)
+	assert.Equal(t, []webBuilding.POIDensityCountResponse{{Id: 7, Name: "F&B", Count: 5}}, alpha.Rings[2].Categories)
+	assert.Equal(t, []webBuilding.POIDensityCountResponse{
+		{Id: 3, Name: "Kopi Group", Count: 4},
+		{Id: 0, Name: "", Count: 1},
+	}, alpha.Rings[2].MotherBrands)
+
+	repoBuilding.AssertExpectations(t)
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+}
+
+func TestAnalyzePOIDensity_SortByTotalWithLimit(t *testing.T) {
+	db, sqlMock := testutil.NewMockDB(t)
+	repoBuilding := &mocks.MockRepositoryBuilding{}
+	svc := newBuildingService(db, repoBuilding, &mocks.MockRepositoryPOI{})
+
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectCommit()
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectCommit()
+
+	repoBuilding.On("FindByIds", mock.Anything, mock.AnythingOfType("*sql.Tx"), []int{1, 2}).
+		Return([]models.Building{testutil.NewBuilding(1, "Alpha"), testutil.NewBuilding(2, "Beta")}, nil)
+	repoBuilding.On("CountPOIPointsInRings", mock.Anything, mock.AnythingOfType("*sql.Tx"), []int{1, 2}, []int{250, 1000}, []int(nil), []int(nil)).
+		Return(densityRows(), nil)
+
+	response := svc.AnalyzePOIDensity(context.Background(), webBuilding.POIDensityRequest{
+		BuildingIds: []int{1, 2},
+		RingMeters:  []int{1000, 250, 1000},
+		Limit:       1,
+	})
+
+	assert.Len(t, response.Data, 1)
+	assert.Equal(t, "Beta", response.Data[0].Name)
+	assert.Equal(t, 7, response.Data[0].Rings[1].Total)
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+}
+
+func TestAnalyzePOIDensity_InvalidRequest(t *testing.T) {
+	db, _ := testutil.NewMockDB(t)
+	svc := newBuildingService(db, &mocks.MockRepositoryBuilding{}, &mocks.MockRepositoryPOI{})
+
+	assert.PanicsWithValue(t, exceptions.NewBadRequest("ring_meters must be between 1 and 5000"), func() {
+		svc.AnalyzePOIDensity(context.Background(), webBuilding.POIDensityRequest{BuildingIds: []int{1}, RingMeters: []int{250, 10000}})
+	})
+	assert.PanicsWithValue(t, exceptions.NewBadRequest("sort_id is required when sorting by category"), func() {
+		svc.AnalyzePOIDensity(context.Background(), webBuilding.POIDensityRequest{BuildingIds: []int{1}, SortBy: "category"})
+	})
+	assert.PanicsWithValue(t, exceptions.NewBadRequest("sort_ring_meters must be one of ring_meters"), func() {
+		svc.AnalyzePOIDensity(context.Background(), webBuilding.POIDensityRequest{BuildingIds: []int{1}, SortRingMeters: 300})
+	})
+	assert.PanicsWithValue(t, exceptions.NewBadRequest("building_ids or filters is required"), func() {
+		svc.AnalyzePOIDensity(context.Background(), webBuilding.POIDensityRequest{})
+	})
+}
+
+func TestExportPOIDensity_CategoryColumnsPerRing(t *testing.T) {
+	db, sqlMock := testutil.NewMockDB(t)
+	repoBuilding := &mocks.MockRepositoryBuilding{}
+	svc := newBuildingService(db, repoBuilding, &mocks.MockRepositoryPOI{})
+
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectCommit()
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectCommit()
+
+	repoBuilding.On("FindByIds", mock.Anything, mock.AnythingOfType("*sql.Tx"), []int{1, 2}).
+		Return([]models.Building{testutil.NewBuilding(1, "Alpha"), testutil.NewBuilding(2, "Beta")}, nil)
+	repoBuilding.On("CountPOIPointsInRings", mock.Anything, mock.AnythingOfType("*sql.Tx"), []int{1, 2}, []int{1000}, []int(nil), []int(nil)).
+		Return(densityRows(), nil)
+
+	excelBytes, err := svc.ExportPOIDensity(context.Background(), webBuilding.POIDensityRequest{BuildingIds: []int{1, 2}, RingMeters: []int{1000}})
+	assert.NoError(t, err)
+
+	f, err := excelize.OpenReader(bytes.NewReader(excelBytes))
+	assert.NoError(t, err)
+	rows, err := f.GetRows("POI Density")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Building ID", "Name", "Building Type", "Grade", "Subdistrict", "City", "Latitude", "Longitude", "Total ≤1000 m", "Bank ≤1000 m", "F&B ≤1000 m"}, rows[0])
+	assert.Equal(t, []string{"7", "4", "3"}, rows[1][8:])
+	assert.Equal(t, "Beta", rows[1][1])
+
+	brandRows, err := f.GetRows("Mother Brands")
+	assert.NoError(t, err)
+	assert.Len(t, brandRows, 5)
+	assert.Equal(t, []string{"", "Alpha", "1000", "No mother brand", "1"}, brandRows[4])
+}
@@ -544,6 +544,9 @@ func (service *ServiceBuildingImpl) SyncFromERP(ctx context.Context) error {
 	service.Logger.Info("Waiting for workers to complete")
 	wg.Wait()
 
+	// Resolve official region codes for all buildings via spatial join
+	service.assignAdminBoundaries(ctx)
+
 	// Log final summary with error details
 	service.Logger.WithFields(logrus.Fields{
 		"synced":  counters.syncedCount,
@@ -568,6 +571,30 @@ func (service *ServiceBuildingImpl) SyncFromERP(ctx context.Context) error {
 	return nil
 }
 
+// assignAdminBoundaries runs the building/admin boundary spatial join after a sync.
+// Failures are logged only: the ERP data itself has already been committed.
+func (service *ServiceBuildingImpl) assignAdminBoundaries(ctx context.Context) {
+	tx, err := service.DB.Begin()
+	if err != nil {
+		service.Logger.WithError(err).Error("Failed to start transaction for admin boundary assignment")
+		return
+	}
+
+	assigned, err := service.RepositoryBuildingInterface.AssignAdminBoundaries(ctx, tx)
+	if err != nil {
+		service.Logger.WithError(err).Error("Failed to assign admin boundaries to buildings")
+		tx.Rollback()
+		return
+	}
+
+	if err := tx.Commit(); err != nil {
+		service.Logger.WithError(err).Error("Failed to commit admin boundary assignment")
+		return
+	}
+
+	service.Logger.WithField("updated", assigned).Info("Assigned admin boundaries to buildings")
+}
+
 // GetFilterOptions returns distinct values for filter dropdowns
 func (service *ServiceBuildingImpl) GetFilterOptions(ctx context.Context) map[string][]string {
 	tx, err := service.DB.Begin()
@@ -600,10 +627,9 @@ func (service *ServiceBuildingImpl) FindAllForMapping(ctx context.Context, reque
 	var latPtr *float64
 	var lngPtr *float64
 	var radiusPtr *int
-	var poiPoints []struct {
-		Lat float64
-		Lng float64
-	}
+	var poiIds []int
+	var catchmentMode string
+	var travelSeconds int
 	var polygonPoints []struct {
 		Lat float64
 		Lng float64
@@ -631,30 +657,20 @@ func (service *ServiceBuildingImpl) FindAllForMapping(ctx context.Context, reque
 
 	// When polygon is not set, use POI / lat-lng / radius
 	if len(polygonPoints) == 0 {
+		// POI points are resolved in SQL (poi_points.location), so only the ids are collected here
 		if poiIdStr := request.GetPOIId(); poiIdStr != "" {
+			seen := make(map[int]bool)
 			for _, idStr := range strings.Split(poiIdStr, ",") {
-				idStr = strings.TrimSpace(idStr)
-				poiId, err := strconv.Atoi(idStr)
-				if err != nil || poiId <= 0 {
+				poiId, err := strconv.Atoi(strings.TrimSpace(idStr))
+				if err != nil || poiId <= 0 || seen[poiId] {
 					continue
 				}
-				poi, err := service.RepositoryPOIInterface.FindById(ctx, tx, poiId)
-				if err != nil || len(poi.Points) == 0 {
-					continue
-				}
-				for _, point := range poi.Points {
-					poiPoints = append(poiPoints, struct {
-						Lat float64
-						Lng float64
-					}{
-						Lat: point.Latitude,
-						Lng: point.Longitude,
-					})
-				}
+				seen[poiId] = true
+				poiIds = append(poiIds, poiId)
 			}
 		}
 
-		if len(poiPoints) == 0 {
+		if len(poiIds) == 0 {
 			if latStr := request.GetLat(); latStr != "" {
 				if lat, err := strconv.ParseFloat(latStr, 64); err == nil {
 					latPtr = &lat
@@ -672,6 +688,12 @@ func (service *ServiceBuildingImpl) FindAllForMapping(ctx context.Context, reque
 				radiusPtr = &radius
 			}
 		}
+
+		// Isochrone catchment replaces the straight-line radius around the POI points / lat-lng
+		catchmentMode, travelSeconds = parseCatchment(request.GetCatchmentMode(), request.GetTravelMinutes())
+		if catchmentMode != "" && len(poiIds) > 0 {
+			service.checkCatchmentOrigins(ctx, tx, poiIds)
+		}
 	}
 
 	// Parse optional map bounds (viewport); only apply when all four are valid and min < max
@@ -706,6 +728,7 @@ func (service *ServiceBuildingImpl) FindAllForMapping(ctx context.Context, reque
 	buildings, err := service.RepositoryBuildingInterface.FindAllForMapping(
 		ctx,
 		tx,
+		helpers.UserIdFromContext(ctx),
 		request.GetBuildingType(),
 		request.GetBuildingGrade(),
 		request.GetYear(),
@@ -716,10 +739,15 @@ func (service *ServiceBuildingImpl) FindAllForMapping(ctx context.Context, reque
 		request.GetLCDPresence(),
 		request.GetSalesPackageIds(),
 		request.GetBuildingRestrictionIds(),
+		request.GetProvinceCodes(),
+		request.GetCityCodes(),
+		request.GetSubdistrictCodes(),
 		latPtr,
 		lngPtr,
 		radiusPtr,
-		poiPoints,
+		catchmentMode,
+		travelSeconds,
+		poiIds,
 		polygonPoints,
 		minLatPtr,
 		maxLatPtr,
@@ -734,6 +762,7 @@ func (service *ServiceBuildingImpl) FindAllForMapping(ctx context.Context, reque
 		buildingsForTotals, err = service.RepositoryBuildingInterface.FindAllForMapping(
 			ctx,
 			tx,
+			helpers.UserIdFromContext(ctx),
 			request.GetBuildingType(),
 			request.GetBuildingGrade(),
 			request.GetYear(),
@@ -744,10 +773,15 @@ func (service *ServiceBuildingImpl) FindAllForMapping(ctx context.Context, reque
 			request.GetLCDPresence(),
 			request.GetSalesPackageIds(),
 			request.GetBuildingRestrictionIds(),
+			request.GetProvinceCodes(),
+			request.GetCityCodes(),
+			request.GetSubdistrictCodes(),
 			latPtr,
 			lngPtr,
 			radiusPtr,
-			poiPoints,
+			catchmentMode,
+			travelSeconds,
+			poiIds,
 			polygonPoints,
 			nil, nil, nil, nil,
 		)
@@ -766,6 +800,7 @@ func (service *ServiceBuildingImpl) FindAllForMapping(ctx context.Context, reque
 		key := strings.ToLower(buildingType)
 		totalsMap[key]++
 	}
+	regionTotals := buildRegionTotals(buildingsForTotals)
 
 	// Convert to mapping response (Data = buildings in view)
 	mappingBuildings := make([]webBuilding.MappingBuildingResponse, 0, len(buildings))
@@ -809,6 +844,9 @@ func (service *ServiceBuildingImpl) FindAllForMapping(ctx context.Context, reque
 			Subdistrict:        building.Subdistrict,
 			Citytown:           building.Citytown,
 			Province:           building.Province,
+			ProvinceCode:       building.ProvinceCode,
+			CityCode:           building.CityCode,
+			SubdistrictCode:    building.SubdistrictCode,
 			Address:            address,
 			BuildingStatus:     building.BuildingStatus,
 			Sellable:           building.Sellable,
@@ -822,12 +860,125 @@ func (service *ServiceBuildingImpl) FindAllForMapping(ctx context.Context, reque
 		mappingBuildings = append(mappingBuildings, mappingBuilding)
 	}
 
+	// Distance annotations: nearest matched POI point, else distance to the lat/lng center
+	if len(mappingBuildings) > 0 {
+		buildingIds := make([]int, len(mappingBuildings))
+		for i, mb := range mappingBuildings {
+			buildingIds[i] = mb.Id
+		}
+		if len(poiIds) > 0 {
+			nearest, err := service.RepositoryBuildingInterface.FindNearestPOIPoints(ctx, tx, buildingIds, poiIds)
+			helpers.PanicIfError(err)
+			nearestByBuilding := make(map[int]repositoriesBuilding.NearestPOIPointRow, len(nearest))
+			for _, row := range nearest {
+				nearestByBuilding[row.BuildingId] = row
+			}
+			for i := range mappingBuildings {
+				row, ok := nearestByBuilding[mappingBuildings[i].Id]
+				if !ok {
+					continue
+				}
+				distance := math.Round(row.DistanceMeters)
+				mappingBuildings[i].DistanceMeters = &distance
+				mappingBuildings[i].NearestPOIPoint = &webBuilding.MappingNearestPOIPointResponse{
+					Id:    row.POIPointId,
+					POIId: row.POIId,
+					Name:  row.POIName,
+					Brand: row.Brand,
+				}
+			}
+		} else if latPtr != nil && lngPtr != nil && (radiusPtr != nil || catchmentMode != "") {
+			distances, err := service.RepositoryBuildingInterface.FindDistancesToPoint(ctx, tx, buildingIds, *latPtr, *lngPtr)
+			helpers.PanicIfError(err)
+			for i := range mappingBuildings {
+				if d, ok := distances[mappingBuildings[i].Id]; ok {
+					distance := math.Round(d)
+					mappingBuildings[i].DistanceMeters = &distance
+				}
+			}
+		}
+	}
+
+	if strings.EqualFold(request.GetSortBy(), "distance") {
+		sortMappingBuildingsByDistance(mappingBuildings)
+	}
+
 	return webBuilding.MappingBuildingsResponse{
-		Data:   mappingBuildings,
-		Totals: totalsMap,
+		Data:         mappingBuildings,
+		Totals:       totalsMap,
+		RegionTotals: regionTotals,
+	}
+}
+
+// sortMappingBuildingsByDistance orders buildings by ascending distance; buildings without a distance go last
+func sortMappingBuildingsByDistance(buildings []webBuilding.MappingBuildingResponse) {
+	sort.SliceStable(buildings, func(i, j int) bool {
+		di, dj := buildings[i].DistanceMeters, buildings[j].DistanceMeters
+		if di == nil || dj == nil {
+			return di != nil && dj == nil
+		}
+		return *di < *dj
+	})
+}
+
+// maxTravelMinutes caps isochrone size; larger catchments are slow to compute and rarely useful
+const maxTravelMinutes = 120
+
+// parseCatchment validates the catchment mode and travel time. Returns an empty mode for the
+// default straight-line radius behaviour, otherwise the mode and the travel time in seconds.
+func parseCatchment(mode string, travelMinutes string) (string, int) {
+	mode = strings.ToLower(strings.TrimSpace(mode))
+	if mode == "" || mode == models.CatchmentModeRadius {
+		return "", 0
+	}
+	if mode != models.CatchmentModeDrive && mode != models.CatchmentModeWalk {
+		panic(exceptions.NewBadRequest("catchment_mode must be one of radius, drive, walk"))
+	}
+	minutes, err := strconv.Atoi(strings.TrimSpace(travelMinutes))
+	if err != nil || minutes <= 0 || minutes > maxTravelMinutes {
+		panic(exceptions.NewBadRequest("travel_minutes must be between 1 and " + strconv.Itoa(maxTravelMinutes) + " for drive and walk catchments"))
+	}
+	return mode, minutes * 60
+}
+
+// maxCatchmentOrigins caps how many POI point locations a drive/walk catchment is computed around;
+// every origin runs its own pgr_drivingDistance
+const maxCatchmentOrigins = 50
+
+// checkCatchmentOrigins rejects a drive/walk catchment around POIs with more point locations than
+// maxCatchmentOrigins
+func (service *ServiceBuildingImpl) checkCatchmentOrigins(ctx context.Context, tx *sql.Tx, poiIds []int) {
+	origins, err := service.RepositoryPOIInterface.CountPointLocations(ctx, tx, poiIds)
+	helpers.PanicIfError(err)
+	if origins > maxCatchmentOrigins {
+		panic(exceptions.NewBadRequest("drive and walk catchments support at most " + strconv.Itoa(maxCatchmentOrigins) +
+			" POI points, the selected POIs have " + strconv.Itoa(origins) + "; select fewer POIs or use a radius"))
 	}
 }
 
+// buildRegionTotals counts buildings per official region code for each boundary level
+func buildRegionTotals(buildings []models.Building) map[string]map[string]int {
+	regionTotals := map[string]map[string]int{
+		models.AdminBoundaryLevelProvince:    {},
+		models.AdminBoundaryLevelCity:        {},
+		models.AdminBoundaryLevelSubdistrict: {},
+	}
+	for _, building := range buildings {
+		codes := map[string]string{
+			models.AdminBoundaryLevelProvince:    building.ProvinceCode,
+			models.AdminBoundaryLevelCity:        building.CityCode,
+			models.AdminBoundaryLevelSubdistrict: building.SubdistrictCode,
+		}
+		for level, code := range codes {
+			if code == "" {
+				code = "unassigned"
+			}
+			regionTotals[level][code]++
+		}
+	}
+	return regionTotals
+}
+
 // ExportForMapping returns Excel file bytes for the given building IDs
 func (service *ServiceBuildingImpl) ExportForMapping(ctx context.Context, ids []int) ([]byte, error) {
 	tx, err := service.DB.Begin()
@@ -987,11 +1138,100 @@ func (service *ServiceBuildingImpl) FindAllDropdown(ctx context.Context) []webBu
 	return responses
 }
 
+// Nearest-neighbour result size bounds
+const (
+	defaultNearestLimit = 10
+	maxNearestLimit     = 100
+)
+
+func normalizeNearestLimit(limit int) int {
+	if limit <= 0 {
+		return defaultNearestLimit
+	}
+	if limit > maxNearestLimit {
+		return maxNearestLimit
+	}
+	return limit
+}
+
+// FindNearest returns the N buildings closest to a coordinate, nearest first
+func (service *ServiceBuildingImpl) FindNearest(ctx context.Context, request webBuilding.NearestBuildingsRequest) []webBuilding.NearestBuildingResponse {
+	if request.Lat < -90 || request.Lat > 90 || request.Lng < -180 || request.Lng > 180 {
+		panic(exceptions.NewBadRequest("invalid lat/lng"))
+	}
+
+	tx, err := service.DB.Begin()
+	helpers.PanicIfError(err)
+	defer helpers.CommitOrRollback(tx)
+
+	rows, err := service.RepositoryBuildingInterface.FindNearest(ctx, tx, request.Lat, request.Lng, normalizeNearestLimit(request.Limit), request.BuildingType)
+	helpers.PanicIfError(err)
+
+	responses := make([]webBuilding.NearestBuildingResponse, 0, len(rows))
+	for _, row := range rows {
+		b := row.Building
+		responses = append(responses, webBuilding.NearestBuildingResponse{
+			Id:                 b.Id,
+			ExternalBuildingId: b.ExternalBuildingId,
+			Name:               b.Name,
+			BuildingType:       b.BuildingType,
+			GradeResource:      b.GradeResource,
+			Subdistrict:        b.Subdistrict,
+			Citytown:           b.Citytown,
+			Province:           b.Province,
+			Latitude:           b.Latitude,
+			Longitude:          b.Longitude,
+			LcdPresenceStatus:  b.LcdPresenceStatus,
+			DistanceMeters:     math.Round(row.DistanceMeters),
+		})
+	}
+	return responses
+}
+
+// FindNearbyPOIs returns the POI points closest to a building, nearest first
+func (service *ServiceBuildingImpl) FindNearbyPOIs(ctx context.Context, buildingId int, request webBuilding.NearbyPOIsRequest) []webBuilding.NearbyPOIPointResponse {
+	tx, err := service.DB.Begin()
+	helpers.PanicIfError(err)
+	defer helpers.CommitOrRollback(tx)
+
+	building, err := service.RepositoryBuildingInterface.FindById(ctx, tx, buildingId)
+	if err == sql.ErrNoRows {
+		panic(exceptions.NewNotFoundError("building not found"))
+	}
+	helpers.PanicIfError(err)
+
+	if building.Latitude == 0 || building.Longitude == 0 {
+		panic(exceptions.NewBadRequest("building has no coordinates"))
+	}
+
+	rows, err := service.RepositoryPOIInterface.FindNearbyPoints(ctx, tx, building.Latitude, building.Longitude, normalizeNearestLimit(request.Limit), request.CategoryIds, request.SubCategoryIds, request.MotherBrandIds)
+	helpers.PanicIfError(err)
+
+	responses := make([]webBuilding.NearbyPOIPointResponse, 0, len(rows))
+	for _, row := range rows {
+		responses = append(responses, webBuilding.NearbyPOIPointResponse{
+			Id:              row.Point.Id,
+			POIId:           row.Point.POIId,
+			POIName:         row.Point.POIName,
+			Address:         row.Point.Address,
+			Brand:           row.POI.Brand,
+			BranchName:      row.Point.BranchName,
+			CategoryName:    row.POI.CategoryName,
+			SubCategoryName: row.POI.SubCategoryName,
+			MotherBrandName: row.POI.MotherBrandName,
+			Latitude:        row.Point.Latitude,
+			Longitude:       row.Point.Longitude,
+			DistanceMeters:  math.Round(row.DistanceMeters),
+		})
+	}
+	return responses
+}
+
 func buildExcelFromMappingBuildings(data []webBuilding.MappingBuildingResponse) ([]byte, error) {
 	f := excelize.NewFile()
 	sheetName := "Buildings"
 	const sheet = "Sheet1"
-	headers := []string{"Building ID", "Name", "Building Type", "Grade", "Completion Year", "Subdistrict", "City", "Province", "Address", "Status", "Sellable", "Connectivity", "Latitude", "Longitude", "LCD Presence"}
+	headers := []string{"Building ID", "Name", "Building Type", "Grade", "Completion Year", "Subdistrict", "City", "Province", "Address", "Status", "Sellable", "Connectivity", "Latitude", "Longitude", "LCD Presence", "Nearest POI", "Nearest POI Brand", "Distance (m)"}
 	for i, h := range headers {
 		cell, _ := excelize.CoordinatesToCellName(i+1, 1)
 		_ = f.SetCellValue(sheet, cell, h)
@@ -1013,6 +1253,13 @@ func buildExcelFromMappingBuildings(data []webBuilding.MappingBuildingResponse)
 		_ = f.SetCellValue(sheet, mustCell(13, rowIdx), b.Latitude)
 		_ = f.SetCellValue(sheet, mustCell(14, rowIdx), b.Longitude)
 		_ = f.SetCellValue(sheet, mustCell(15, rowIdx), b.LcdPresenceStatus)
+		if b.NearestPOIPoint != nil {
+			_ = f.SetCellValue(sheet, mustCell(16, rowIdx), b.NearestPOIPoint.Name)
+			_ = f.SetCellValue(sheet, mustCell(17, rowIdx), b.NearestPOIPoint.Brand)
+		}
+		if b.DistanceMeters != nil {
+			_ = f.SetCellValue(sheet, mustCell(18, rowIdx), *b.DistanceMeters)
+		}
 	}
 	if sheetName != sheet {
 		_ = f.SetSheetName(sheet, sheetName)
@@ -9,6 +9,8 @@ import (
 
 	"github.com/malikabdulaziz/tmn-backend/exceptions"
 	"github.com/malikabdulaziz/tmn-backend/models"
+	repositoriesBuilding "github.com/malikabdulaziz/tmn-backend/repositories/building"
+	repositoriesPOI "github.com/malikabdulaziz/tmn-backend/repositories/poi"
 	serviceBuilding "github.com/malikabdulaziz/tmn-backend/services/building"
 	"github.com/malikabdulaziz/tmn-backend/testutil"
 	"github.com/malikabdulaziz/tmn-backend/testutil/mocks"
@@ -226,3 +228,251 @@ func TestFindAll_HappyPath(t *testing.T) {
 	repoBuilding.AssertExpectations(t)
 	assert.NoError(t, sqlMock.ExpectationsWereMet())
 }
+
+// --- FindAllForMapping (catchment) ---
+
+// TestFindAllForMapping_DriveCatchment verifies that a drive catchment is forwarded to the
+// repository as mode + travel time in seconds.
+func TestFindAllForMapping_DriveCatchment(t *testing.T) {
+	db, sqlMock := testutil.NewMockDB(t)
+	repoBuilding := &mocks.MockRepositoryBuilding{}
+	repoPOI := &mocks.MockRepositoryPOI{}
+	svc := newBuildingService(db, repoBuilding, repoPOI)
+
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectCommit()
+
+	var request webBuilding.MappingBuildingRequest
+	request.SetLat("-6.2")
+	request.SetLng("106.8")
+	request.SetCatchmentMode("Drive")
+	request.SetTravelMinutes("10")
+
+	repoBuilding.On("FindAllForMapping",
+		mock.Anything, mock.AnythingOfType("*sql.Tx"), 0,
+		"", "", "", "", "", "", "", "", "", "", "", "", "",
+		mock.Anything, mock.Anything, mock.Anything,
+		"drive", 600,
+		mock.Anything, mock.Anything,
+		mock.Anything, mock.Anything, mock.Anything, mock.Anything,
+	).Return([]models.Building{testutil.NewBuilding(1, "Plaza Senayan")}, nil)
+	repoBuilding.On("FindDistancesToPoint", mock.Anything, mock.AnythingOfType("*sql.Tx"), []int{1}, -6.2, 106.8).
+		Return(map[int]float64{1: 1234.4}, nil)
+
+	resp := svc.FindAllForMapping(context.Background(), request)
+
+	assert.Len(t, resp.Data, 1)
+	assert.Equal(t, 1, resp.Totals["office"])
+	assert.Equal(t, 1234.0, *resp.Data[0].DistanceMeters)
+	assert.Nil(t, resp.Data[0].NearestPOIPoint)
+	repoBuilding.AssertExpectations(t)
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+}
+
+// TestFindAllForMapping_InvalidCatchment verifies catchment validation errors.
+func TestFindAllForMapping_InvalidCatchment(t *testing.T) {
+	cases := []struct {
+		name    string
+		mode    string
+		minutes string
+		message string
+	}{
+		{"unknown mode", "cycle", "10", "catchment_mode must be one of radius, drive, walk"},
+		{"missing minutes", "walk", "", "travel_minutes must be between 1 and 120 for drive and walk catchments"},
+		{"too many minutes", "drive", "240", "travel_minutes must be between 1 and 120 for drive and walk catchments"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, sqlMock := testutil.NewMockDB(t)
+			svc := newBuildingService(db, &mocks.MockRepositoryBuilding{}, &mocks.MockRepositoryPOI{})
+
+			sqlMock.ExpectBegin()
+			sqlMock.ExpectRollback()
+
+			var request webBuilding.MappingBuildingRequest
+			request.SetCatchmentMode(tc.mode)
+			request.SetTravelMinutes(tc.minutes)
+
+			assert.PanicsWithValue(t,
+				exceptions.BadRequestError{Error: tc.message},
+				func() { svc.FindAllForMapping(context.Background(), request) },
+			)
+			assert.NoError(t, sqlMock.ExpectationsWereMet())
+		})
+	}
+}
+
+// TestFindAllForMapping_TooManyCatchmentOrigins verifies that a drive/walk catchment around POIs
+// with too many point locations is rejected before any isochrone is computed.
+func TestFindAllForMapping_TooManyCatchmentOrigins(t *testing.T) {
+	db, sqlMock := testutil.NewMockDB(t)
+	repoBuilding := &mocks.MockRepositoryBuilding{}
+	repoPOI := &mocks.MockRepositoryPOI{}
+	svc := newBuildingService(db, repoBuilding, repoPOI)
+
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectRollback()
+
+	var request webBuilding.MappingBuildingRequest
+	request.SetPOIId("3,4")
+	request.SetCatchmentMode("walk")
+	request.SetTravelMinutes("15")
+
+	repoPOI.On("CountPointLocations", mock.Anything, mock.AnythingOfType("*sql.Tx"), []int{3, 4}).Return(51, nil)
+
+	assert.PanicsWithValue(t,
+		exceptions.BadRequestError{Error: "drive and walk catchments support at most 50 POI points, the selected POIs have 51; select fewer POIs or use a radius"},
+		func() { svc.FindAllForMapping(context.Background(), request) },
+	)
+	repoBuilding.AssertNotCalled(t, "FindAllForMapping")
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+}
+
+// --- FindAllForMapping (distance annotations) ---
+
+// TestFindAllForMapping_NearestPOIPointSortedByDistance verifies that each building is annotated with
+// its nearest matched POI point and that sort_by=distance orders buildings, unmatched ones last.
+func TestFindAllForMapping_NearestPOIPointSortedByDistance(t *testing.T) {
+	db, sqlMock := testutil.NewMockDB(t)
+	repoBuilding := &mocks.MockRepositoryBuilding{}
+	repoPOI := &mocks.MockRepositoryPOI{}
+	svc := newBuildingService(db, repoBuilding, repoPOI)
+
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectCommit()
+
+	var request webBuilding.MappingBuildingRequest
+	request.SetPOIId("5")
+	request.SetRadius("1000")
+	request.SetSortBy("distance")
+
+	repoBuilding.On("FindAllForMapping",
+		mock.Anything, mock.AnythingOfType("*sql.Tx"), mock.Anything,
+		mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything,
+		mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything,
+		mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything,
+		[]int{5}, mock.Anything,
+		mock.Anything, mock.Anything, mock.Anything, mock.Anything,
+	).Return([]models.Building{
+		testutil.NewBuilding(1, "Far Tower"),
+		testutil.NewBuilding(2, "No Location Tower"),
+		testutil.NewBuilding(3, "Near Tower"),
+	}, nil)
+	repoBuilding.On("FindNearestPOIPoints", mock.Anything, mock.AnythingOfType("*sql.Tx"), []int{1, 2, 3}, []int{5}).
+		Return([]repositoriesBuilding.NearestPOIPointRow{
+			{BuildingId: 1, POIPointId: 50, POIId: 5, POIName: "Starbucks Sarinah", Brand: "Starbucks", DistanceMeters: 812.6},
+			{BuildingId: 3, POIPointId: 50, POIId: 5, POIName: "Starbucks Sarinah", Brand: "Starbucks", DistanceMeters: 349.7},
+		}, nil)
+
+	resp := svc.FindAllForMapping(context.Background(), request)
+
+	assert.Len(t, resp.Data, 3)
+	assert.Equal(t, []int{3, 1, 2}, []int{resp.Data[0].Id, resp.Data[1].Id, resp.Data[2].Id})
+	assert.Equal(t, 350.0, *resp.Data[0].DistanceMeters)
+	assert.Equal(t, "Starbucks Sarinah", resp.Data[0].NearestPOIPoint.Name)
+	assert.Equal(t, "Starbucks", resp.Data[0].NearestPOIPoint.Brand)
+	assert.Equal(t, 50, resp.Data[0].NearestPOIPoint.Id)
+	assert.Nil(t, resp.Data[2].DistanceMeters)
+	repoBuilding.AssertExpectations(t)
+	repoPOI.AssertNotCalled(t, "FindById", mock.Anything, mock.Anything, mock.Anything)
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+}
+
+// --- FindNearest ---
+
+// TestFindNearest_DefaultLimitAndRounding verifies the default limit and that distances are rounded to meters.
+func TestFindNearest_DefaultLimitAndRounding(t *testing.T) {
+	db, sqlMock := testutil.NewMockDB(t)
+	repoBuilding := &mocks.MockRepositoryBuilding{}
+	svc := newBuildingService(db, repoBuilding, &mocks.MockRepositoryPOI{})
+
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectCommit()
+
+	repoBuilding.On("FindNearest", mock.Anything, mock.AnythingOfType("*sql.Tx"), -6.2, 106.8, 10, "Office").
+		Return([]repositoriesBuilding.NearestBuildingRow{
+			{Building: testutil.NewBuilding(4, "Wisma 46"), DistanceMeters: 120.6},
+		}, nil)
+
+	result := svc.FindNearest(context.Background(), webBuilding.NearestBuildingsRequest{Lat: -6.2, Lng: 106.8, BuildingType: "Office"})
+
+	assert.Len(t, result, 1)
+	assert.Equal(t, "Wisma 46", result[0].Name)
+	assert.Equal(t, 121.0, result[0].DistanceMeters)
+	repoBuilding.AssertExpectations(t)
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+}
+
+// TestFindNearest_InvalidCoordinate verifies that out-of-range coordinates are rejected before any query.
+func TestFindNearest_InvalidCoordinate(t *testing.T) {
+	db, sqlMock := testutil.NewMockDB(t)
+	svc := newBuildingService(db, &mocks.MockRepositoryBuilding{}, &mocks.MockRepositoryPOI{})
+
+	assert.PanicsWithValue(t,
+		exceptions.BadRequestError{Error: "invalid lat/lng"},
+		func() {
+			svc.FindNearest(context.Background(), webBuilding.NearestBuildingsRequest{Lat: -96, Lng: 106.8})
+		},
+	)
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+}
+
+// --- FindNearbyPOIs ---
+
+// TestFindNearbyPOIs_HappyPath verifies that nearby points are looked up from the building's
+// coordinates with the requested filters and a capped limit.
+func TestFindNearbyPOIs_HappyPath(t *testing.T) {
+	db, sqlMock := testutil.NewMockDB(t)
+	repoBuilding := &mocks.MockRepositoryBuilding{}
+	repoPOI := &mocks.MockRepositoryPOI{}
+	svc := newBuildingService(db, repoBuilding, repoPOI)
+
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectCommit()
+
+	repoBuilding.On("FindById", mock.Anything, mock.AnythingOfType("*sql.Tx"), 4).
+		Return(testutil.NewBuilding(4, "Wisma 46"), nil)
+	repoPOI.On("FindNearbyPoints", mock.Anything, mock.AnythingOfType("*sql.Tx"), -6.2, 106.8, 100, "1,2", "", "7").
+		Return([]repositoriesPOI.NearbyPOIPointRow{
+			{
+				Point:          models.POIPoint{Id: 50, POIId: 5, POIName: "Starbucks Sarinah"},
+				POI:            models.POI{Id: 5, Brand: "Starbucks", CategoryName: "F&B"},
+				DistanceMeters: 349.7,
+			},
+		}, nil)
+
+	result := svc.FindNearbyPOIs(context.Background(), 4, webBuilding.NearbyPOIsRequest{
+		Limit:          500,
+		CategoryIds:    "1,2",
+		MotherBrandIds: "7",
+	})
+
+	assert.Len(t, result, 1)
+	assert.Equal(t, "Starbucks", result[0].Brand)
+	assert.Equal(t, "F&B", result[0].CategoryName)
+	assert.Equal(t, 350.0, result[0].DistanceMeters)
+	repoBuilding.AssertExpectations(t)
+	repoPOI.AssertExpectations(t)
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+}
+
+// TestFindNearbyPOIs_BuildingWithoutCoordinates verifies the bad request for unlocated buildings.
+func TestFindNearbyPOIs_BuildingWithoutCoordinates(t *testing.T) {
+	db, sqlMock := testutil.NewMockDB(t)
+	repoBuilding := &mocks.MockRepositoryBuilding{}
+	svc := newBuildingService(db, repoBuilding, &mocks.MockRepositoryPOI{})
+
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectRollback()
+
+	building := testutil.NewBuilding(8, "Unmapped Tower")
+	building.Latitude = 0
+	building.Longitude = 0
+	repoBuilding.On("FindById", mock.Anything, mock.AnythingOfType("*sql.Tx"), 8).Return(building, nil)
+
+	assert.PanicsWithValue(t,
+		exceptions.BadRequestError{Error: "building has no coordinates"},
+		func() { svc.FindNearbyPOIs(context.Background(), 8, webBuilding.NearbyPOIsRequest{}) },
+	)
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+}
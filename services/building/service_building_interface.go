@@ -17,5 +17,9 @@ type ServiceBuildingInterface interface {
 	ExportForMappingWithFilters(ctx context.Context, request webBuilding.MappingBuildingRequest) ([]byte, error)
 	GetLCDPresenceSummary(ctx context.Context) webBuilding.LCDPresenceSummaryResponse
 	FindAllDropdown(ctx context.Context) []webBuilding.BuildingDropdownResponse
+	FindNearest(ctx context.Context, request webBuilding.NearestBuildingsRequest) []webBuilding.NearestBuildingResponse
+	AnalyzePOIDensity(ctx context.Context, request webBuilding.POIDensityRequest) webBuilding.POIDensityResponse
+	ExportPOIDensity(ctx context.Context, request webBuilding.POIDensityRequest) ([]byte, error)
+	FindNearbyPOIs(ctx context.Context, buildingId int, request webBuilding.NearbyPOIsRequest) []webBuilding.NearbyPOIPointResponse
 }
 
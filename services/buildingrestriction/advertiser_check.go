@@ -0,0 +1,45 @@
+package buildingrestriction
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/malikabdulaziz/tmn-backend/exceptions"
+	"github.com/malikabdulaziz/tmn-backend/helpers"
+	"github.com/malikabdulaziz/tmn-backend/models"
+	repositoriesBuildingRestriction "github.com/malikabdulaziz/tmn-backend/repositories/buildingrestriction"
+	webBuildingRestriction "github.com/malikabdulaziz/tmn-backend/web/buildingrestriction"
+)
+
+// CheckAdvertiser looks up the active restrictions barring the advertiser in check from its
+// buildings. Any block-level match panics with a BadRequest carrying every violation as extras;
+// otherwise the warn-level matches are returned for the caller to surface. Without an advertiser
+// nothing is checked.
+func CheckAdvertiser(ctx context.Context, tx *sql.Tx, repo repositoriesBuildingRestriction.RepositoryBuildingRestrictionInterface, check repositoriesBuildingRestriction.RestrictionCheck) []webBuildingRestriction.RestrictionViolationResponse {
+	if check.CategoryId == 0 && check.MotherBrandId == 0 {
+		return nil
+	}
+	rows, err := repo.FindViolations(ctx, tx, check)
+	helpers.PanicIfError(err)
+
+	violations := make([]webBuildingRestriction.RestrictionViolationResponse, len(rows))
+	blocked := false
+	for i, row := range rows {
+		violations[i] = webBuildingRestriction.RestrictionViolationResponse{
+			RestrictionId:   row.RestrictionId,
+			RestrictionName: row.RestrictionName,
+			Enforcement:     row.Enforcement,
+			BuildingId:      row.BuildingId,
+			BuildingName:    row.BuildingName,
+			Reason:          row.Reason,
+			Advertiser:      row.AdvertiserName,
+		}
+		if row.Enforcement == models.RestrictionEnforcementBlock {
+			blocked = true
+		}
+	}
+	if blocked {
+		panic(exceptions.NewBadRequestWithExtras("advertiser is restricted in some of the buildings", violations))
+	}
+	return violations
+}
@@ -1,38 +1,52 @@
 package buildingrestriction
 
 import (
-	"bytes"
 	"context"
 	"database/sql"
-	"encoding/csv"
 	"fmt"
-	"io"
 	"strings"
+	"time"
 
 	"github.com/malikabdulaziz/tmn-backend/exceptions"
 	"github.com/malikabdulaziz/tmn-backend/helpers"
+	"github.com/malikabdulaziz/tmn-backend/importer"
 	"github.com/malikabdulaziz/tmn-backend/models"
 	repositoriesBuilding "github.com/malikabdulaziz/tmn-backend/repositories/building"
 	repositoriesBuildingRestriction "github.com/malikabdulaziz/tmn-backend/repositories/buildingrestriction"
+	repositoriesCategory "github.com/malikabdulaziz/tmn-backend/repositories/category"
+	repositoriesMotherBrand "github.com/malikabdulaziz/tmn-backend/repositories/motherbrand"
+	"github.com/malikabdulaziz/tmn-backend/web"
 	webBuildingRestriction "github.com/malikabdulaziz/tmn-backend/web/buildingrestriction"
 	"github.com/xuri/excelize/v2"
 )
 
+// buildingRestrictionImportColumns maps each import column to its accepted header spellings
+var buildingRestrictionImportColumns = map[string][]string{
+	"name":          {"name"},
+	"building_name": {"building_name", "buildingname", "building_names", "buildingnames", "buildings"},
+}
+
 type ServiceBuildingRestrictionImpl struct {
-	DB                                    *sql.DB
+	DB                                     *sql.DB
 	RepositoryBuildingRestrictionInterface repositoriesBuildingRestriction.RepositoryBuildingRestrictionInterface
-	RepositoryBuildingInterface           repositoriesBuilding.RepositoryBuildingInterface
+	RepositoryBuildingInterface            repositoriesBuilding.RepositoryBuildingInterface
+	RepositoryCategoryInterface            repositoriesCategory.RepositoryCategoryInterface
+	RepositoryMotherBrandInterface         repositoriesMotherBrand.RepositoryMotherBrandInterface
 }
 
 func NewServiceBuildingRestrictionImpl(
 	db *sql.DB,
 	repoBuildingRestriction repositoriesBuildingRestriction.RepositoryBuildingRestrictionInterface,
 	repoBuilding repositoriesBuilding.RepositoryBuildingInterface,
+	repoCategory repositoriesCategory.RepositoryCategoryInterface,
+	repoMotherBrand repositoriesMotherBrand.RepositoryMotherBrandInterface,
 ) ServiceBuildingRestrictionInterface {
 	return &ServiceBuildingRestrictionImpl{
-		DB:                                    db,
+		DB:                                     db,
 		RepositoryBuildingRestrictionInterface: repoBuildingRestriction,
-		RepositoryBuildingInterface:           repoBuilding,
+		RepositoryBuildingInterface:            repoBuilding,
+		RepositoryCategoryInterface:            repoCategory,
+		RepositoryMotherBrandInterface:         repoMotherBrand,
 	}
 }
 
@@ -47,7 +61,53 @@ func (s *ServiceBuildingRestrictionImpl) validateBuildingIdsErr(ctx context.Cont
 	}
 }
 
-// Create creates a new building restriction with building links
+// applyRules validates the category and mother brand ids and the validity period and sets them,
+// with the enforcement level, on restriction
+func (s *ServiceBuildingRestrictionImpl) applyRules(ctx context.Context, tx *sql.Tx, restriction *models.BuildingRestriction, categoryIds []int, motherBrandIds []int, enforcement string, validFrom string, validUntil string) {
+	restriction.Categories = []models.Category{}
+	for _, id := range categoryIds {
+		category, err := s.RepositoryCategoryInterface.FindById(ctx, tx, id)
+		if err == sql.ErrNoRows {
+			panic(exceptions.NewBadRequest("category not found"))
+		}
+		helpers.PanicIfError(err)
+		restriction.Categories = append(restriction.Categories, category)
+	}
+	restriction.MotherBrands = []models.MotherBrand{}
+	for _, id := range motherBrandIds {
+		motherBrand, err := s.RepositoryMotherBrandInterface.FindById(ctx, tx, id)
+		if err == sql.ErrNoRows {
+			panic(exceptions.NewBadRequest("mother brand not found"))
+		}
+		helpers.PanicIfError(err)
+		restriction.MotherBrands = append(restriction.MotherBrands, motherBrand)
+	}
+
+	var from, until time.Time
+	var err error
+	if validFrom != "" {
+		if from, err = time.Parse("2006-01-02", validFrom); err != nil {
+			panic(exceptions.NewBadRequest("valid_from must be a date in YYYY-MM-DD format"))
+		}
+	}
+	if validUntil != "" {
+		if until, err = time.Parse("2006-01-02", validUntil); err != nil {
+			panic(exceptions.NewBadRequest("valid_until must be a date in YYYY-MM-DD format"))
+		}
+	}
+	if validFrom != "" && validUntil != "" && until.Before(from) {
+		panic(exceptions.NewBadRequest("valid_until must not be before valid_from"))
+	}
+
+	if enforcement == "" {
+		enforcement = models.RestrictionEnforcementWarn
+	}
+	restriction.Enforcement = enforcement
+	restriction.ValidFrom = validFrom
+	restriction.ValidUntil = validUntil
+}
+
+// Create creates a new building restriction with building links and rules
 func (s *ServiceBuildingRestrictionImpl) Create(ctx context.Context, request webBuildingRestriction.CreateBuildingRestrictionRequest) webBuildingRestriction.BuildingRestrictionResponse {
 	tx, err := s.DB.Begin()
 	helpers.PanicIfError(err)
@@ -55,21 +115,27 @@ func (s *ServiceBuildingRestrictionImpl) Create(ctx context.Context, request web
 
 	s.validateBuildingIdsErr(ctx, tx, request.BuildingIds)
 
-	restriction := models.BuildingRestriction{Name: request.Name}
+	restriction := models.BuildingRestriction{
+		Name:       request.Name,
+		CreatedBy:  helpers.OptionalUserIdFromContext(ctx),
+		Visibility: models.VisibilityOrDefault(request.Visibility),
+	}
+	s.applyRules(ctx, tx, &restriction, request.CategoryIds, request.MotherBrandIds, request.Enforcement, request.ValidFrom, request.ValidUntil)
 	created, err := s.RepositoryBuildingRestrictionInterface.Create(ctx, tx, restriction, request.BuildingIds)
 	helpers.PanicIfError(err)
 	return s.modelToResponse(created)
 }
 
-// FindAll retrieves all building restrictions with pagination
+// FindAll retrieves the building restrictions the current user may see with pagination
 func (s *ServiceBuildingRestrictionImpl) FindAll(ctx context.Context, request webBuildingRestriction.BuildingRestrictionRequestFindAll) ([]webBuildingRestriction.BuildingRestrictionResponse, int) {
 	tx, err := s.DB.Begin()
 	helpers.PanicIfError(err)
 	defer helpers.CommitOrRollback(tx)
 
-	list, err := s.RepositoryBuildingRestrictionInterface.FindAll(ctx, tx, request.GetTake(), request.GetSkip(), request.GetOrderBy(), request.GetOrderDirection())
+	userId := helpers.UserIdFromContext(ctx)
+	list, err := s.RepositoryBuildingRestrictionInterface.FindAll(ctx, tx, userId, request.GetTake(), request.GetSkip(), request.GetOrderBy(), request.GetOrderDirection())
 	helpers.PanicIfError(err)
-	total, err := s.RepositoryBuildingRestrictionInterface.CountAll(ctx, tx)
+	total, err := s.RepositoryBuildingRestrictionInterface.CountAll(ctx, tx, userId)
 	helpers.PanicIfError(err)
 
 	responses := make([]webBuildingRestriction.BuildingRestrictionResponse, len(list))
@@ -85,80 +151,71 @@ func (s *ServiceBuildingRestrictionImpl) FindById(ctx context.Context, id int) w
 	helpers.PanicIfError(err)
 	defer helpers.CommitOrRollback(tx)
 
-	restriction, err := s.RepositoryBuildingRestrictionInterface.FindById(ctx, tx, id)
-	if err == sql.ErrNoRows {
+	return s.modelToResponse(s.find(ctx, tx, id, false))
+}
+
+// checkAccess panics unless the current user may see the building restriction, or change it when
+// edit is set. Restrictions hidden from the user are reported as not found; they are still
+// enforced on every sales package and booking.
+func (s *ServiceBuildingRestrictionImpl) checkAccess(ctx context.Context, tx *sql.Tx, id int, edit bool) {
+	access, err := s.RepositoryBuildingRestrictionInterface.FindAccess(ctx, tx, id, helpers.UserIdFromContext(ctx))
+	if err == sql.ErrNoRows || (err == nil && !access.Visible) {
 		panic(exceptions.NewNotFoundError("building restriction not found"))
 	}
 	helpers.PanicIfError(err)
-	return s.modelToResponse(restriction)
+	if edit && !access.Editable {
+		panic(exceptions.NewForbidden("only the owner can change this building restriction"))
+	}
 }
 
-// Update updates a building restriction and replaces building links
+// find loads a building restriction after checkAccess
+func (s *ServiceBuildingRestrictionImpl) find(ctx context.Context, tx *sql.Tx, id int, edit bool) models.BuildingRestriction {
+	s.checkAccess(ctx, tx, id, edit)
+	restriction, err := s.RepositoryBuildingRestrictionInterface.FindById(ctx, tx, id)
+	helpers.PanicIfError(err)
+	return restriction
+}
+
+// Update updates a building restriction and replaces building links and rules
 func (s *ServiceBuildingRestrictionImpl) Update(ctx context.Context, request webBuildingRestriction.UpdateBuildingRestrictionRequest, id int) webBuildingRestriction.BuildingRestrictionResponse {
 	tx, err := s.DB.Begin()
 	helpers.PanicIfError(err)
 	defer helpers.CommitOrRollback(tx)
 
-	existing, err := s.RepositoryBuildingRestrictionInterface.FindById(ctx, tx, id)
-	if err == sql.ErrNoRows {
-		panic(exceptions.NewNotFoundError("building restriction not found"))
-	}
-	helpers.PanicIfError(err)
-
+	existing := s.find(ctx, tx, id, true)
 	s.validateBuildingIdsErr(ctx, tx, request.BuildingIds)
 
 	existing.Name = request.Name
+	if request.Visibility != "" {
+		existing.Visibility = request.Visibility
+	}
+	existing.UpdatedBy = helpers.OptionalUserIdFromContext(ctx)
+	s.applyRules(ctx, tx, &existing, request.CategoryIds, request.MotherBrandIds, request.Enforcement, request.ValidFrom, request.ValidUntil)
 	updated, err := s.RepositoryBuildingRestrictionInterface.Update(ctx, tx, existing, request.BuildingIds)
 	helpers.PanicIfError(err)
 	return s.modelToResponse(updated)
 }
 
-// Delete deletes a building restriction
+// Delete moves a building restriction to the trash; it stops being enforced right away
 func (s *ServiceBuildingRestrictionImpl) Delete(ctx context.Context, id int) {
 	tx, err := s.DB.Begin()
 	helpers.PanicIfError(err)
 	defer helpers.CommitOrRollback(tx)
 
-	_, err = s.RepositoryBuildingRestrictionInterface.FindById(ctx, tx, id)
-	if err == sql.ErrNoRows {
-		panic(exceptions.NewNotFoundError("building restriction not found"))
-	}
-	helpers.PanicIfError(err)
-	err = s.RepositoryBuildingRestrictionInterface.Delete(ctx, tx, id)
+	s.checkAccess(ctx, tx, id, true)
+	err = s.RepositoryBuildingRestrictionInterface.Delete(ctx, tx, id, helpers.OptionalUserIdFromContext(ctx))
 	helpers.PanicIfError(err)
 }
 
-// Import parses an xlsx or csv file and creates/replaces building restrictions
-func (s *ServiceBuildingRestrictionImpl) Import(ctx context.Context, fileBytes []byte, fileType string) []webBuildingRestriction.BuildingRestrictionResponse {
+// Import parses an xlsx or csv file and creates/replaces building restrictions. Rows naming an
+// unknown or repeated building are rejected; onError decides whether that fails the whole file.
+func (s *ServiceBuildingRestrictionImpl) Import(ctx context.Context, fileBytes []byte, fileType string, onError string) ([]webBuildingRestriction.BuildingRestrictionResponse, web.ImportReport) {
 	tx, err := s.DB.Begin()
 	helpers.PanicIfError(err)
 	defer helpers.CommitOrRollback(tx)
 
-	var rows [][]string
-	switch strings.ToLower(fileType) {
-	case "xlsx":
-		rows, err = brParseXLSX(fileBytes)
-		helpers.PanicIfError(err)
-	case "csv":
-		rows, err = brParseCSV(fileBytes)
-		helpers.PanicIfError(err)
-	default:
-		panic(exceptions.NewBadRequestError("Unsupported file type. Use xlsx or csv."))
-	}
-
-	if len(rows) < 2 {
-		panic(exceptions.NewBadRequestError("File must contain a header row and at least one data row."))
-	}
-
-	header := rows[0]
-	colMap := brMapHeaderColumns(header)
-
-	if _, exists := colMap["name"]; !exists {
-		panic(exceptions.NewBadRequestError("Missing required column: name"))
-	}
-	if _, exists := colMap["building_name"]; !exists {
-		panic(exceptions.NewBadRequestError("Missing required column: building_name"))
-	}
+	sheet := importer.Read(fileBytes, fileType, buildingRestrictionImportColumns)
+	sheet.RequireColumns("name", "building_name")
 
 	// Load all buildings for name->id resolution
 	allBuildings, err := s.RepositoryBuildingInterface.FindAllDropdown(ctx, tx)
@@ -168,7 +225,7 @@ func (s *ServiceBuildingRestrictionImpl) Import(ctx context.Context, fileBytes [
 		buildingNameMap[strings.TrimSpace(strings.ToLower(b.Name))] = b.Id
 	}
 
-	// Group rows by restriction name (each row has one building)
+	// Group rows by building restriction name (each row has one building)
 	type importGroup struct {
 		name        string
 		buildingIds []int
@@ -177,74 +234,58 @@ func (s *ServiceBuildingRestrictionImpl) Import(ctx context.Context, fileBytes [
 	nameOrder := []string{}
 	groups := map[string]*importGroup{}
 
-	type duplicateEntry struct {
-		Name         string `json:"name"`
-		BuildingName string `json:"building_name"`
-		Rows         []int  `json:"rows"`
-	}
-	duplicateIndex := map[string]*duplicateEntry{} // key: name + "|" + buildingId
-
-	for i, row := range rows[1:] {
-		excelRow := i + 2 // header is Excel row 1; data rows start at 2
-
-		name := brGetColValue(row, colMap, "name")
-		if name == "" {
+	report := importer.NewReport(sheet, onError)
+	for i := range sheet.Rows {
+		excelRow := importer.RowNumber(i)
+		if sheet.IsBlank(i) || !report.RequireText(excelRow, "name", 255) {
 			continue
 		}
-		rawBuildingName := brGetColValue(row, colMap, "building_name")
-		buildingName := strings.TrimSpace(strings.ToLower(rawBuildingName))
-
-		if _, exists := groups[name]; !exists {
-			groups[name] = &importGroup{name: name, seenAt: map[int]int{}}
-			nameOrder = append(nameOrder, name)
-		}
+		name := sheet.Value(i, "name")
+		buildingName := strings.ToLower(sheet.Value(i, "building_name"))
 
+		bid := 0
 		if buildingName != "" {
-			bid, ok := buildingNameMap[buildingName]
+			id, ok := buildingNameMap[buildingName]
 			if !ok {
-				panic(exceptions.NewBadRequestError(fmt.Sprintf("Building not found: %s", buildingName)))
+				report.Reject(excelRow, "building_name", "Building not found")
+				continue
 			}
-			group := groups[name]
-			if firstRow, dup := group.seenAt[bid]; dup {
-				key := fmt.Sprintf("%s|%d", name, bid)
-				if entry, ok := duplicateIndex[key]; ok {
-					entry.Rows = append(entry.Rows, excelRow)
-				} else {
-					duplicateIndex[key] = &duplicateEntry{
-						Name:         name,
-						BuildingName: rawBuildingName,
-						Rows:         []int{firstRow, excelRow},
-					}
+			if group, exists := groups[name]; exists {
+				if firstRow, dup := group.seenAt[id]; dup {
+					report.Reject(excelRow, "building_name", fmt.Sprintf("Building is already listed for this building restriction on row %d", firstRow))
+					continue
 				}
-				continue
 			}
+			bid = id
+		}
+
+		if _, exists := groups[name]; !exists {
+			groups[name] = &importGroup{name: name, seenAt: map[int]int{}}
+			nameOrder = append(nameOrder, name)
+		}
+		if bid != 0 {
+			group := groups[name]
 			group.seenAt[bid] = excelRow
 			group.buildingIds = append(group.buildingIds, bid)
 		}
 	}
+	report.Check()
 
-	if len(duplicateIndex) > 0 {
-		duplicates := make([]duplicateEntry, 0, len(duplicateIndex))
-		for _, nameKey := range nameOrder {
-			for _, entry := range duplicateIndex {
-				if entry.Name == nameKey {
-					duplicates = append(duplicates, *entry)
-				}
-			}
-		}
-		panic(exceptions.NewBadRequestWithExtras(
-			"Duplicate rows found: the same building restriction cannot reference the same building more than once.",
-			map[string]interface{}{"duplicates": duplicates},
-		))
-	}
+	tracker := importer.NewTracker(ctx, len(nameOrder))
 
-	// Delete existing building restrictions with matching names
+	// Move existing building restrictions with matching names to the trash; the file only carries buildings,
+	// so their enforcement, validity, visibility and barred categories and mother brands are carried over
 	existing, err := s.RepositoryBuildingRestrictionInterface.FindByNames(ctx, tx, nameOrder)
 	helpers.PanicIfError(err)
+	previous := make(map[string]models.BuildingRestriction, len(existing))
 	for _, er := range existing {
-		err = s.RepositoryBuildingRestrictionInterface.DeleteBuildingLinksByBuildingRestrictionId(ctx, tx, er.Id)
+		access, err := s.RepositoryBuildingRestrictionInterface.FindAccess(ctx, tx, er.Id, helpers.UserIdFromContext(ctx))
 		helpers.PanicIfError(err)
-		err = s.RepositoryBuildingRestrictionInterface.Delete(ctx, tx, er.Id)
+		if !access.Editable {
+			panic(exceptions.NewForbidden(fmt.Sprintf("building restriction %s can only be replaced by its owner", er.Name)))
+		}
+		previous[er.Name] = er
+		err = s.RepositoryBuildingRestrictionInterface.Delete(ctx, tx, er.Id, helpers.OptionalUserIdFromContext(ctx))
 		helpers.PanicIfError(err)
 	}
 
@@ -252,13 +293,26 @@ func (s *ServiceBuildingRestrictionImpl) Import(ctx context.Context, fileBytes [
 	var responses []webBuildingRestriction.BuildingRestrictionResponse
 	for _, name := range nameOrder {
 		group := groups[name]
-		restriction := models.BuildingRestriction{Name: group.name}
+		restriction := models.BuildingRestriction{
+			Name:       group.name,
+			CreatedBy:  helpers.OptionalUserIdFromContext(ctx),
+			Visibility: models.VisibilityTeam,
+		}
+		if prev, ok := previous[name]; ok {
+			restriction.Visibility = prev.Visibility
+			restriction.Enforcement = prev.Enforcement
+			restriction.ValidFrom = prev.ValidFrom
+			restriction.ValidUntil = prev.ValidUntil
+			restriction.Categories = prev.Categories
+			restriction.MotherBrands = prev.MotherBrands
+		}
 		created, err := s.RepositoryBuildingRestrictionInterface.Create(ctx, tx, restriction, group.buildingIds)
 		helpers.PanicIfError(err)
 		responses = append(responses, s.modelToResponse(created))
+		tracker.Advance(1)
 	}
 
-	return responses
+	return responses, report.Result()
 }
 
 // Export generates an xlsx file with all building restrictions
@@ -269,7 +323,7 @@ func (s *ServiceBuildingRestrictionImpl) Export(ctx context.Context, search stri
 	}
 	defer helpers.CommitOrRollback(tx)
 
-	restrictions, err := s.RepositoryBuildingRestrictionInterface.FindAllFlat(ctx, tx, search)
+	restrictions, err := s.RepositoryBuildingRestrictionInterface.FindAllFlat(ctx, tx, helpers.UserIdFromContext(ctx), search)
 	if err != nil {
 		return nil, err
 	}
@@ -277,6 +331,37 @@ func (s *ServiceBuildingRestrictionImpl) Export(ctx context.Context, search stri
 	return buildBuildingRestrictionExcel(restrictions)
 }
 
+// ImportTemplate returns a blank building restriction import xlsx with the current building names as a dropdown
+func (s *ServiceBuildingRestrictionImpl) ImportTemplate(ctx context.Context) ([]byte, error) {
+	tx, err := s.DB.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer helpers.CommitOrRollback(tx)
+
+	buildings, err := s.RepositoryBuildingInterface.FindAllDropdown(ctx, tx)
+	if err != nil {
+		return nil, err
+	}
+	buildingNames := make([]string, len(buildings))
+	for i, b := range buildings {
+		buildingNames[i] = b.Name
+	}
+
+	return importer.BuildTemplate(importer.Template{
+		SheetName: "Building Restrictions",
+		Columns: []importer.TemplateColumn{
+			{Key: "name", Header: "Name", Description: "Name of the building restriction. Repeat it on one row per building.", Required: true},
+			{Key: "building_name", Header: "Building Name", Description: "Building in the building restriction.", Options: buildingNames, Strict: true},
+		},
+		Instructions: []string{
+			"Fill one row per building of a building restriction on the Building Restrictions sheet and keep the header row as it is.",
+			"Importing a building restriction replaces the buildings of any existing building restriction with the same name; its barred categories, mother brands, enforcement and validity are kept.",
+			"A building may be listed only once per building restriction.",
+		},
+	}, buildingRestrictionImportColumns)
+}
+
 func (s *ServiceBuildingRestrictionImpl) modelToResponse(r models.BuildingRestriction) webBuildingRestriction.BuildingRestrictionResponse {
 	buildings := make([]webBuildingRestriction.BuildingRefResponse, len(r.Buildings))
 	for i, b := range r.Buildings {
@@ -290,65 +375,29 @@ func (s *ServiceBuildingRestrictionImpl) modelToResponse(r models.BuildingRestri
 			BuildingType: b.BuildingType,
 		}
 	}
-	return webBuildingRestriction.BuildingRestrictionResponse{
-		Id:        r.Id,
-		Name:      r.Name,
-		Buildings: buildings,
-		CreatedAt: r.CreatedAt,
-		UpdatedAt: r.UpdatedAt,
+	categories := make([]webBuildingRestriction.RuleRefResponse, len(r.Categories))
+	for i, c := range r.Categories {
+		categories[i] = webBuildingRestriction.RuleRefResponse{Id: c.Id, Name: c.Name}
 	}
-}
-
-// --- Import helpers ---
-
-func brParseXLSX(fileBytes []byte) ([][]string, error) {
-	f, err := excelize.OpenReader(bytes.NewReader(fileBytes))
-	if err != nil {
-		return nil, err
+	motherBrands := make([]webBuildingRestriction.RuleRefResponse, len(r.MotherBrands))
+	for i, mb := range r.MotherBrands {
+		motherBrands[i] = webBuildingRestriction.RuleRefResponse{Id: mb.Id, Name: mb.Name}
 	}
-	defer f.Close()
-	sheetName := f.GetSheetName(0)
-	return f.GetRows(sheetName)
-}
-
-func brParseCSV(fileBytes []byte) ([][]string, error) {
-	reader := csv.NewReader(bytes.NewReader(fileBytes))
-	var rows [][]string
-	for {
-		record, err := reader.Read()
-		if err == io.EOF {
-			break
-		}
-		if err != nil {
-			return nil, err
-		}
-		rows = append(rows, record)
-	}
-	return rows, nil
-}
-
-func brMapHeaderColumns(header []string) map[string]int {
-	colMap := make(map[string]int)
-	for i, h := range header {
-		normalized := strings.ToLower(strings.TrimSpace(h))
-		normalized = strings.ReplaceAll(normalized, "-", "_")
-		normalized = strings.ReplaceAll(normalized, " ", "_")
-		switch normalized {
-		case "name":
-			colMap["name"] = i
-		case "building_name", "buildingname", "building_names", "buildingnames", "buildings":
-			colMap["building_name"] = i
-		}
-	}
-	return colMap
-}
-
-func brGetColValue(row []string, colMap map[string]int, key string) string {
-	idx, exists := colMap[key]
-	if !exists || idx >= len(row) {
-		return ""
+	return webBuildingRestriction.BuildingRestrictionResponse{
+		Id:           r.Id,
+		Name:         r.Name,
+		Enforcement:  r.Enforcement,
+		ValidFrom:    r.ValidFrom,
+		ValidUntil:   r.ValidUntil,
+		Buildings:    buildings,
+		Categories:   categories,
+		MotherBrands: motherBrands,
+		CreatedBy:    r.CreatedBy,
+		UpdatedBy:    r.UpdatedBy,
+		Visibility:   r.Visibility,
+		CreatedAt:    r.CreatedAt,
+		UpdatedAt:    r.UpdatedAt,
 	}
-	return strings.TrimSpace(row[idx])
 }
 
 // --- Export helpers ---
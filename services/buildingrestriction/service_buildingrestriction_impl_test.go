@@ -6,6 +6,8 @@ import (
 	"testing"
 
 	"github.com/malikabdulaziz/tmn-backend/exceptions"
+	"github.com/malikabdulaziz/tmn-backend/helpers"
+	"github.com/malikabdulaziz/tmn-backend/importer"
 	"github.com/malikabdulaziz/tmn-backend/models"
 	serviceRestriction "github.com/malikabdulaziz/tmn-backend/services/buildingrestriction"
 	"github.com/malikabdulaziz/tmn-backend/testutil"
@@ -20,7 +22,8 @@ func newRestrictionService(
 	repoRestriction *mocks.MockRepositoryBuildingRestriction,
 	repoBuilding *mocks.MockRepositoryBuilding,
 ) serviceRestriction.ServiceBuildingRestrictionInterface {
-	return serviceRestriction.NewServiceBuildingRestrictionImpl(db, repoRestriction, repoBuilding)
+	return serviceRestriction.NewServiceBuildingRestrictionImpl(db, repoRestriction, repoBuilding,
+		&mocks.MockRepositoryCategory{}, &mocks.MockRepositoryMotherBrand{})
 }
 
 func newRestrictionModel(id int, name string, buildingRefs ...models.BuildingRef) models.BuildingRestriction {
@@ -79,6 +82,74 @@ func TestRestrictionCreate_HappyPath(t *testing.T) {
 	assert.NoError(t, sqlMock.ExpectationsWereMet())
 }
 
+func TestRestrictionCreate_WithRules(t *testing.T) {
+	db, sqlMock := testutil.NewMockDB(t)
+	repoRestriction := &mocks.MockRepositoryBuildingRestriction{}
+	repoBuilding := &mocks.MockRepositoryBuilding{}
+	repoCategory := &mocks.MockRepositoryCategory{}
+	repoMotherBrand := &mocks.MockRepositoryMotherBrand{}
+	svc := serviceRestriction.NewServiceBuildingRestrictionImpl(db, repoRestriction, repoBuilding, repoCategory, repoMotherBrand)
+
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectCommit()
+
+	repoBuilding.On("FindById", mock.Anything, mock.AnythingOfType("*sql.Tx"), 5).Return(testutil.NewBuilding(5, "Office A"), nil)
+	repoCategory.On("FindById", mock.Anything, mock.AnythingOfType("*sql.Tx"), 2).Return(models.Category{Id: 2, Name: "Alcohol"}, nil)
+	repoMotherBrand.On("FindById", mock.Anything, mock.AnythingOfType("*sql.Tx"), 8).Return(models.MotherBrand{Id: 8, Name: "Rival Group"}, nil)
+	created := models.BuildingRestriction{
+		Id: 1, Name: "Exclusivity", Enforcement: models.RestrictionEnforcementBlock, ValidFrom: "2026-01-01", ValidUntil: "2026-12-31",
+		Buildings:    []models.BuildingRef{{Id: 5, Name: "Office A"}},
+		Categories:   []models.Category{{Id: 2, Name: "Alcohol"}},
+		MotherBrands: []models.MotherBrand{{Id: 8, Name: "Rival Group"}},
+	}
+	repoRestriction.On("Create", mock.Anything, mock.AnythingOfType("*sql.Tx"),
+		mock.MatchedBy(func(r models.BuildingRestriction) bool {
+			return r.Enforcement == models.RestrictionEnforcementBlock && r.ValidFrom == "2026-01-01" && r.ValidUntil == "2026-12-31" &&
+				len(r.Categories) == 1 && r.Categories[0].Id == 2 && len(r.MotherBrands) == 1 && r.MotherBrands[0].Id == 8
+		}),
+		[]int{5},
+	).Return(created, nil)
+
+	response := svc.Create(context.Background(), webRestriction.CreateBuildingRestrictionRequest{
+		Name:           "Exclusivity",
+		BuildingIds:    []int{5},
+		CategoryIds:    []int{2},
+		MotherBrandIds: []int{8},
+		Enforcement:    models.RestrictionEnforcementBlock,
+		ValidFrom:      "2026-01-01",
+		ValidUntil:     "2026-12-31",
+	})
+
+	assert.Equal(t, models.RestrictionEnforcementBlock, response.Enforcement)
+	assert.Equal(t, []webRestriction.RuleRefResponse{{Id: 2, Name: "Alcohol"}}, response.Categories)
+	assert.Equal(t, []webRestriction.RuleRefResponse{{Id: 8, Name: "Rival Group"}}, response.MotherBrands)
+	repoRestriction.AssertExpectations(t)
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+}
+
+func TestRestrictionCreate_InvalidValidity(t *testing.T) {
+	db, sqlMock := testutil.NewMockDB(t)
+	repoRestriction := &mocks.MockRepositoryBuildingRestriction{}
+	repoBuilding := &mocks.MockRepositoryBuilding{}
+	svc := newRestrictionService(db, repoRestriction, repoBuilding)
+
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectRollback()
+
+	repoBuilding.On("FindById", mock.Anything, mock.AnythingOfType("*sql.Tx"), 5).Return(testutil.NewBuilding(5, "Office A"), nil)
+
+	assert.PanicsWithValue(t,
+		exceptions.BadRequestError{Error: "valid_until must not be before valid_from"},
+		func() {
+			svc.Create(context.Background(), webRestriction.CreateBuildingRestrictionRequest{
+				Name: "Zone", BuildingIds: []int{5}, ValidFrom: "2026-06-01", ValidUntil: "2026-05-01",
+			})
+		},
+	)
+	repoRestriction.AssertNotCalled(t, "Create", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+}
+
 // TestRestrictionCreate_InvalidBuilding verifies that a non-existent building ID
 // causes a BadRequestError panic before the restriction is created.
 func TestRestrictionCreate_InvalidBuilding(t *testing.T) {
@@ -149,6 +220,7 @@ func TestRestrictionFindById_HappyPath(t *testing.T) {
 	sqlMock.ExpectBegin()
 	sqlMock.ExpectCommit()
 
+	repoRestriction.On("FindAccess", mock.Anything, mock.AnythingOfType("*sql.Tx"), 4, 0).Return(models.Access{Visible: true, Editable: true}, nil)
 	repoRestriction.On("FindById", mock.Anything, mock.AnythingOfType("*sql.Tx"), 4).
 		Return(restriction, nil)
 
@@ -171,8 +243,8 @@ func TestRestrictionFindById_NotFound(t *testing.T) {
 	sqlMock.ExpectBegin()
 	sqlMock.ExpectRollback()
 
-	repoRestriction.On("FindById", mock.Anything, mock.AnythingOfType("*sql.Tx"), 404).
-		Return(models.BuildingRestriction{}, sql.ErrNoRows)
+	repoRestriction.On("FindAccess", mock.Anything, mock.AnythingOfType("*sql.Tx"), 404, 0).
+		Return(models.Access{}, sql.ErrNoRows)
 
 	assert.PanicsWithValue(t,
 		exceptions.NotFoundError{Error: "building restriction not found"},
@@ -198,6 +270,7 @@ func TestRestrictionUpdate_HappyPath(t *testing.T) {
 	sqlMock.ExpectBegin()
 	sqlMock.ExpectCommit()
 
+	repoRestriction.On("FindAccess", mock.Anything, mock.AnythingOfType("*sql.Tx"), 6, 0).Return(models.Access{Visible: true, Editable: true}, nil)
 	repoRestriction.On("FindById", mock.Anything, mock.AnythingOfType("*sql.Tx"), 6).
 		Return(existing, nil)
 	repoBuilding.On("FindById", mock.Anything, mock.AnythingOfType("*sql.Tx"), 15).
@@ -232,8 +305,8 @@ func TestRestrictionUpdate_NotFound(t *testing.T) {
 	sqlMock.ExpectBegin()
 	sqlMock.ExpectRollback()
 
-	repoRestriction.On("FindById", mock.Anything, mock.AnythingOfType("*sql.Tx"), 404).
-		Return(models.BuildingRestriction{}, sql.ErrNoRows)
+	repoRestriction.On("FindAccess", mock.Anything, mock.AnythingOfType("*sql.Tx"), 404, 0).
+		Return(models.Access{}, sql.ErrNoRows)
 
 	request := webRestriction.UpdateBuildingRestrictionRequest{Name: "X", BuildingIds: []int{1}}
 
@@ -257,9 +330,8 @@ func TestRestrictionDelete_HappyPath(t *testing.T) {
 	sqlMock.ExpectBegin()
 	sqlMock.ExpectCommit()
 
-	repoRestriction.On("FindById", mock.Anything, mock.AnythingOfType("*sql.Tx"), 2).
-		Return(newRestrictionModel(2, "ToDelete"), nil)
-	repoRestriction.On("Delete", mock.Anything, mock.AnythingOfType("*sql.Tx"), 2).
+	repoRestriction.On("FindAccess", mock.Anything, mock.AnythingOfType("*sql.Tx"), 2, 0).Return(models.Access{Visible: true, Editable: true}, nil)
+	repoRestriction.On("Delete", mock.Anything, mock.AnythingOfType("*sql.Tx"), 2, (*int)(nil)).
 		Return(nil)
 
 	assert.NotPanics(t, func() { svc.Delete(context.Background(), 2) })
@@ -277,8 +349,8 @@ func TestRestrictionDelete_NotFound(t *testing.T) {
 	sqlMock.ExpectBegin()
 	sqlMock.ExpectRollback()
 
-	repoRestriction.On("FindById", mock.Anything, mock.AnythingOfType("*sql.Tx"), 999).
-		Return(models.BuildingRestriction{}, sql.ErrNoRows)
+	repoRestriction.On("FindAccess", mock.Anything, mock.AnythingOfType("*sql.Tx"), 999, 0).
+		Return(models.Access{}, sql.ErrNoRows)
 
 	assert.PanicsWithValue(t,
 		exceptions.NotFoundError{Error: "building restriction not found"},
@@ -305,7 +377,7 @@ func TestRestrictionExport_HappyPath(t *testing.T) {
 	sqlMock.ExpectBegin()
 	sqlMock.ExpectCommit()
 
-	repoRestriction.On("FindAllFlat", mock.Anything, mock.AnythingOfType("*sql.Tx"), "").
+	repoRestriction.On("FindAllFlat", mock.Anything, mock.AnythingOfType("*sql.Tx"), 0, "").
 		Return(restrictions, nil)
 
 	excelBytes, err := svc.Export(context.Background(), "")
@@ -332,7 +404,7 @@ func TestRestrictionImport_UnsupportedFileType(t *testing.T) {
 
 	assert.PanicsWithValue(t,
 		exceptions.BadRequestError{Error: "Unsupported file type. Use xlsx or csv."},
-		func() { svc.Import(context.Background(), []byte("data"), "txt") },
+		func() { svc.Import(context.Background(), []byte("data"), "txt", importer.OnErrorAbort) },
 	)
 
 	assert.NoError(t, sqlMock.ExpectationsWereMet())
@@ -365,7 +437,7 @@ func TestRestrictionImport_CSV_HappyPath(t *testing.T) {
 		[]int{10},
 	).Return(created, nil)
 
-	responses := svc.Import(context.Background(), []byte(csvData), "csv")
+	responses, _ := svc.Import(context.Background(), []byte(csvData), "csv", importer.OnErrorAbort)
 
 	assert.Len(t, responses, 1)
 	assert.Equal(t, "Zone X", responses[0].Name)
@@ -375,3 +447,60 @@ func TestRestrictionImport_CSV_HappyPath(t *testing.T) {
 	repoBuilding.AssertExpectations(t)
 	assert.NoError(t, sqlMock.ExpectationsWereMet())
 }
+
+// --- Ownership and visibility ---
+
+func TestRestrictionFindById_HiddenFromCurrentUser(t *testing.T) {
+	db, sqlMock := testutil.NewMockDB(t)
+	repoRestriction := &mocks.MockRepositoryBuildingRestriction{}
+	svc := newRestrictionService(db, repoRestriction, &mocks.MockRepositoryBuilding{})
+	ctx := context.WithValue(context.Background(), helpers.ContextKey("userId"), "7")
+
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectRollback()
+	repoRestriction.On("FindAccess", mock.Anything, mock.AnythingOfType("*sql.Tx"), 4, 7).Return(models.Access{}, nil)
+
+	assert.PanicsWithValue(t, exceptions.NewNotFoundError("building restriction not found"), func() {
+		svc.FindById(ctx, 4)
+	})
+	repoRestriction.AssertNotCalled(t, "FindById", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestRestrictionDelete_OnlyOwner(t *testing.T) {
+	db, sqlMock := testutil.NewMockDB(t)
+	repoRestriction := &mocks.MockRepositoryBuildingRestriction{}
+	svc := newRestrictionService(db, repoRestriction, &mocks.MockRepositoryBuilding{})
+	ctx := context.WithValue(context.Background(), helpers.ContextKey("userId"), "7")
+
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectRollback()
+	repoRestriction.On("FindAccess", mock.Anything, mock.AnythingOfType("*sql.Tx"), 4, 7).Return(models.Access{Visible: true}, nil)
+
+	assert.PanicsWithValue(t, exceptions.NewForbidden("only the owner can change this building restriction"), func() {
+		svc.Delete(ctx, 4)
+	})
+	repoRestriction.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestRestrictionCreate_OwnedByCurrentUser(t *testing.T) {
+	db, sqlMock := testutil.NewMockDB(t)
+	repoRestriction := &mocks.MockRepositoryBuildingRestriction{}
+	repoBuilding := &mocks.MockRepositoryBuilding{}
+	svc := newRestrictionService(db, repoRestriction, repoBuilding)
+	ctx := context.WithValue(context.Background(), helpers.ContextKey("userId"), "7")
+
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectCommit()
+	repoBuilding.On("FindById", mock.Anything, mock.AnythingOfType("*sql.Tx"), 5).Return(testutil.NewBuilding(5, "Office A"), nil)
+	repoRestriction.On("Create", mock.Anything, mock.AnythingOfType("*sql.Tx"),
+		mock.MatchedBy(func(r models.BuildingRestriction) bool {
+			return r.CreatedBy != nil && *r.CreatedBy == 7 && r.Visibility == models.VisibilityPrivate
+		}),
+		[]int{5},
+	).Return(newRestrictionModel(1, "Private"), nil)
+
+	svc.Create(ctx, webRestriction.CreateBuildingRestrictionRequest{Name: "Private", BuildingIds: []int{5}, Visibility: models.VisibilityPrivate})
+
+	repoRestriction.AssertExpectations(t)
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+}
@@ -3,6 +3,7 @@ package buildingrestriction
 import (
 	"context"
 
+	"github.com/malikabdulaziz/tmn-backend/web"
 	webBuildingRestriction "github.com/malikabdulaziz/tmn-backend/web/buildingrestriction"
 )
 
@@ -12,6 +13,7 @@ type ServiceBuildingRestrictionInterface interface {
 	FindById(ctx context.Context, id int) webBuildingRestriction.BuildingRestrictionResponse
 	Update(ctx context.Context, request webBuildingRestriction.UpdateBuildingRestrictionRequest, id int) webBuildingRestriction.BuildingRestrictionResponse
 	Delete(ctx context.Context, id int)
-	Import(ctx context.Context, fileBytes []byte, fileType string) []webBuildingRestriction.BuildingRestrictionResponse
+	Import(ctx context.Context, fileBytes []byte, fileType string, onError string) ([]webBuildingRestriction.BuildingRestrictionResponse, web.ImportReport)
 	Export(ctx context.Context, search string) ([]byte, error)
+	ImportTemplate(ctx context.Context) ([]byte, error)
 }
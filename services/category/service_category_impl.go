@@ -1,33 +1,38 @@
 package category
 
 import (
-	"bytes"
 	"context"
 	"database/sql"
-	"encoding/csv"
-	"io"
-	"strings"
 
 	"github.com/malikabdulaziz/tmn-backend/exceptions"
 	"github.com/malikabdulaziz/tmn-backend/helpers"
+	"github.com/malikabdulaziz/tmn-backend/importer"
 	"github.com/malikabdulaziz/tmn-backend/models"
 	repositoriesCategory "github.com/malikabdulaziz/tmn-backend/repositories/category"
+	repositoriesSubCategory "github.com/malikabdulaziz/tmn-backend/repositories/subcategory"
+	"github.com/malikabdulaziz/tmn-backend/web"
 	webCategory "github.com/malikabdulaziz/tmn-backend/web/category"
 	"github.com/xuri/excelize/v2"
 )
 
+// categoryImportColumns maps each import column to its accepted header spellings
+var categoryImportColumns = map[string][]string{"name": {"name"}}
+
 type ServiceCategoryImpl struct {
-	DB                          *sql.DB
-	RepositoryCategoryInterface repositoriesCategory.RepositoryCategoryInterface
+	DB                             *sql.DB
+	RepositoryCategoryInterface    repositoriesCategory.RepositoryCategoryInterface
+	RepositorySubCategoryInterface repositoriesSubCategory.RepositorySubCategoryInterface
 }
 
 func NewServiceCategoryImpl(
 	db *sql.DB,
 	repoCategory repositoriesCategory.RepositoryCategoryInterface,
+	repoSubCategory repositoriesSubCategory.RepositorySubCategoryInterface,
 ) ServiceCategoryInterface {
 	return &ServiceCategoryImpl{
-		DB:                          db,
-		RepositoryCategoryInterface: repoCategory,
+		DB:                             db,
+		RepositoryCategoryInterface:    repoCategory,
+		RepositorySubCategoryInterface: repoSubCategory,
 	}
 }
 
@@ -72,6 +77,38 @@ func (s *ServiceCategoryImpl) FindById(ctx context.Context, id int) webCategory.
 	return categoryModelToResponse(category)
 }
 
+// FindTree returns every category with its sub-categories. A sub-category whose category is in
+// the trash is left out, since it cannot be combined with any other category.
+func (s *ServiceCategoryImpl) FindTree(ctx context.Context) webCategory.CategoryTreeResponse {
+	tx, err := s.DB.Begin()
+	helpers.PanicIfError(err)
+	defer helpers.CommitOrRollback(tx)
+
+	categories, err := s.RepositoryCategoryInterface.FindAllDropdown(ctx, tx)
+	helpers.PanicIfError(err)
+	subCategories, err := s.RepositorySubCategoryInterface.FindAllDropdown(ctx, tx)
+	helpers.PanicIfError(err)
+
+	tree := webCategory.CategoryTreeResponse{
+		Categories:              make([]webCategory.CategoryTreeNode, len(categories)),
+		UnassignedSubCategories: []webCategory.CategoryTreeSubCategory{},
+	}
+	nodeIndex := map[int]int{}
+	for i, parent := range categories {
+		tree.Categories[i] = webCategory.CategoryTreeNode{Id: parent.Id, Name: parent.Name, SubCategories: []webCategory.CategoryTreeSubCategory{}}
+		nodeIndex[parent.Id] = i
+	}
+	for _, child := range subCategories {
+		leaf := webCategory.CategoryTreeSubCategory{Id: child.Id, Name: child.Name}
+		if child.CategoryId == nil {
+			tree.UnassignedSubCategories = append(tree.UnassignedSubCategories, leaf)
+		} else if i, ok := nodeIndex[*child.CategoryId]; ok {
+			tree.Categories[i].SubCategories = append(tree.Categories[i].SubCategories, leaf)
+		}
+	}
+	return tree
+}
+
 func (s *ServiceCategoryImpl) Update(ctx context.Context, request webCategory.UpdateCategoryRequest, id int) webCategory.CategoryResponse {
 	tx, err := s.DB.Begin()
 	helpers.PanicIfError(err)
@@ -99,42 +136,33 @@ func (s *ServiceCategoryImpl) Delete(ctx context.Context, id int) {
 		panic(exceptions.NewNotFoundError("category not found"))
 	}
 	helpers.PanicIfError(err)
-	err = s.RepositoryCategoryInterface.Delete(ctx, tx, id)
+	err = s.RepositoryCategoryInterface.Delete(ctx, tx, id, helpers.OptionalUserIdFromContext(ctx))
 	helpers.PanicIfError(err)
 }
 
-func (s *ServiceCategoryImpl) Import(ctx context.Context, fileBytes []byte, fileType string) []webCategory.CategoryResponse {
+func (s *ServiceCategoryImpl) Import(ctx context.Context, fileBytes []byte, fileType string, onError string) ([]webCategory.CategoryResponse, web.ImportReport) {
 	tx, err := s.DB.Begin()
 	helpers.PanicIfError(err)
 	defer helpers.CommitOrRollback(tx)
 
-	var rows [][]string
-	switch strings.ToLower(fileType) {
-	case "xlsx":
-		rows, err = catParseXLSX(fileBytes)
-		helpers.PanicIfError(err)
-	case "csv":
-		rows, err = catParseCSV(fileBytes)
-		helpers.PanicIfError(err)
-	default:
-		panic(exceptions.NewBadRequestError("Unsupported file type. Use xlsx or csv."))
-	}
-
-	if len(rows) < 2 {
-		panic(exceptions.NewBadRequestError("File must contain a header row and at least one data row."))
-	}
+	sheet := importer.Read(fileBytes, fileType, categoryImportColumns)
+	sheet.RequireColumns("name")
 
-	header := rows[0]
-	colMap := catMapHeaderColumns(header)
-
-	if _, exists := colMap["name"]; !exists {
-		panic(exceptions.NewBadRequestError("Missing required column: name"))
+	report := importer.NewReport(sheet, onError)
+	for i := range sheet.Rows {
+		if sheet.IsBlank(i) {
+			continue
+		}
+		report.RequireText(importer.RowNumber(i), "name", 255)
 	}
+	report.Check()
 
+	tracker := importer.NewTracker(ctx, len(sheet.Rows))
 	var responses []webCategory.CategoryResponse
-	for _, row := range rows[1:] {
-		name := catGetColValue(row, colMap, "name")
-		if name == "" {
+	for i := range sheet.Rows {
+		tracker.Advance(1)
+		name := sheet.Value(i, "name")
+		if sheet.IsBlank(i) || report.Rejected(importer.RowNumber(i)) {
 			continue
 		}
 
@@ -150,7 +178,7 @@ func (s *ServiceCategoryImpl) Import(ctx context.Context, fileBytes []byte, file
 		}
 	}
 
-	return responses
+	return responses, report.Result()
 }
 
 func (s *ServiceCategoryImpl) Export(ctx context.Context, search string) ([]byte, error) {
@@ -168,6 +196,20 @@ func (s *ServiceCategoryImpl) Export(ctx context.Context, search string) ([]byte
 	return buildCategoryExcel(list)
 }
 
+// ImportTemplate returns a blank category import xlsx
+func (s *ServiceCategoryImpl) ImportTemplate(ctx context.Context) ([]byte, error) {
+	return importer.BuildTemplate(importer.Template{
+		SheetName: "Categories",
+		Columns: []importer.TemplateColumn{
+			{Key: "name", Header: "Name", Description: "Name of the category.", Required: true},
+		},
+		Instructions: []string{
+			"Fill one category per row on the Categories sheet and keep the header row as it is.",
+			"Names that already exist, or were merged into an existing entry, are left as they are; new names are created.",
+		},
+	}, categoryImportColumns)
+}
+
 func categoryModelToResponse(c models.Category) webCategory.CategoryResponse {
 	return webCategory.CategoryResponse{
 		Id:        c.Id,
@@ -177,53 +219,6 @@ func categoryModelToResponse(c models.Category) webCategory.CategoryResponse {
 	}
 }
 
-// --- Import helpers ---
-
-func catParseXLSX(fileBytes []byte) ([][]string, error) {
-	f, err := excelize.OpenReader(bytes.NewReader(fileBytes))
-	if err != nil {
-		return nil, err
-	}
-	defer f.Close()
-	sheetName := f.GetSheetName(0)
-	return f.GetRows(sheetName)
-}
-
-func catParseCSV(fileBytes []byte) ([][]string, error) {
-	reader := csv.NewReader(bytes.NewReader(fileBytes))
-	var rows [][]string
-	for {
-		record, err := reader.Read()
-		if err == io.EOF {
-			break
-		}
-		if err != nil {
-			return nil, err
-		}
-		rows = append(rows, record)
-	}
-	return rows, nil
-}
-
-func catMapHeaderColumns(header []string) map[string]int {
-	colMap := make(map[string]int)
-	for i, h := range header {
-		normalized := strings.ToLower(strings.TrimSpace(h))
-		if normalized == "name" {
-			colMap["name"] = i
-		}
-	}
-	return colMap
-}
-
-func catGetColValue(row []string, colMap map[string]int, key string) string {
-	idx, exists := colMap[key]
-	if !exists || idx >= len(row) {
-		return ""
-	}
-	return strings.TrimSpace(row[idx])
-}
-
 // --- Export helpers ---
 
 func buildCategoryExcel(list []models.Category) ([]byte, error) {
@@ -6,6 +6,8 @@ import (
 	"testing"
 
 	"github.com/malikabdulaziz/tmn-backend/exceptions"
+	"github.com/malikabdulaziz/tmn-backend/helpers"
+	"github.com/malikabdulaziz/tmn-backend/importer"
 	"github.com/malikabdulaziz/tmn-backend/models"
 	serviceCategory "github.com/malikabdulaziz/tmn-backend/services/category"
 	"github.com/malikabdulaziz/tmn-backend/testutil"
@@ -16,7 +18,7 @@ import (
 )
 
 func newCategoryService(db *sql.DB, repo *mocks.MockRepositoryCategory) serviceCategory.ServiceCategoryInterface {
-	return serviceCategory.NewServiceCategoryImpl(db, repo)
+	return serviceCategory.NewServiceCategoryImpl(db, repo, &mocks.MockRepositorySubCategory{})
 }
 
 func newCategoryModel(id int, name string) models.Category {
@@ -93,6 +95,36 @@ func TestCategoryFindById_NotFound(t *testing.T) {
 	assert.NoError(t, sqlMock.ExpectationsWereMet())
 }
 
+// --- FindTree ---
+
+func TestCategoryFindTree_GroupsSubCategoriesUnderTheirCategory(t *testing.T) {
+	db, sqlMock := testutil.NewMockDB(t)
+	repo := &mocks.MockRepositoryCategory{}
+	subRepo := &mocks.MockRepositorySubCategory{}
+	svc := serviceCategory.NewServiceCategoryImpl(db, repo, subRepo)
+
+	retail, trashed := 1, 9
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectCommit()
+	repo.On("FindAllDropdown", mock.Anything, mock.AnythingOfType("*sql.Tx")).
+		Return([]models.Category{newCategoryModel(1, "Retail"), newCategoryModel(2, "Automotive")}, nil)
+	subRepo.On("FindAllDropdown", mock.Anything, mock.AnythingOfType("*sql.Tx")).Return([]models.SubCategory{
+		{Id: 10, Name: "Coffee Shop", CategoryId: &retail},
+		{Id: 11, Name: "Kiosk"},
+		{Id: 12, Name: "Old Format", CategoryId: &trashed},
+	}, nil)
+
+	tree := svc.FindTree(context.Background())
+
+	assert.Len(t, tree.Categories, 2)
+	assert.Equal(t, []webCategory.CategoryTreeSubCategory{{Id: 10, Name: "Coffee Shop"}}, tree.Categories[0].SubCategories)
+	assert.Empty(t, tree.Categories[1].SubCategories)
+	assert.Equal(t, []webCategory.CategoryTreeSubCategory{{Id: 11, Name: "Kiosk"}}, tree.UnassignedSubCategories)
+	repo.AssertExpectations(t)
+	subRepo.AssertExpectations(t)
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+}
+
 // --- Update ---
 
 func TestCategoryUpdate_HappyPath(t *testing.T) {
@@ -134,10 +166,12 @@ func TestCategoryDelete_HappyPath(t *testing.T) {
 
 	repo.On("FindById", mock.Anything, mock.AnythingOfType("*sql.Tx"), 2).
 		Return(newCategoryModel(2, "ToDelete"), nil)
-	repo.On("Delete", mock.Anything, mock.AnythingOfType("*sql.Tx"), 2).
+	repo.On("Delete", mock.Anything, mock.AnythingOfType("*sql.Tx"), 2,
+		mock.MatchedBy(func(deletedBy *int) bool { return deletedBy != nil && *deletedBy == 7 })).
 		Return(nil)
 
-	assert.NotPanics(t, func() { svc.Delete(context.Background(), 2) })
+	ctx := context.WithValue(context.Background(), helpers.ContextKey("userId"), "7")
+	assert.NotPanics(t, func() { svc.Delete(ctx, 2) })
 
 	repo.AssertExpectations(t)
 	assert.NoError(t, sqlMock.ExpectationsWereMet())
@@ -183,7 +217,7 @@ func TestCategoryImport_CSV_NewItem(t *testing.T) {
 		mock.MatchedBy(func(c models.Category) bool { return c.Name == "Retail" }),
 	).Return(created, nil)
 
-	responses := svc.Import(context.Background(), []byte(csvData), "csv")
+	responses, _ := svc.Import(context.Background(), []byte(csvData), "csv", importer.OnErrorAbort)
 
 	assert.Len(t, responses, 1)
 	assert.Equal(t, "Retail", responses[0].Name)
@@ -206,7 +240,7 @@ func TestCategoryImport_CSV_ExistingItem(t *testing.T) {
 	repo.On("FindByName", mock.Anything, mock.AnythingOfType("*sql.Tx"), "Retail").
 		Return(existing, nil)
 
-	responses := svc.Import(context.Background(), []byte(csvData), "csv")
+	responses, _ := svc.Import(context.Background(), []byte(csvData), "csv", importer.OnErrorAbort)
 
 	assert.Len(t, responses, 1)
 	assert.Equal(t, 7, responses[0].Id)
@@ -3,6 +3,7 @@ package category
 import (
 	"context"
 
+	"github.com/malikabdulaziz/tmn-backend/web"
 	webCategory "github.com/malikabdulaziz/tmn-backend/web/category"
 )
 
@@ -10,8 +11,10 @@ type ServiceCategoryInterface interface {
 	Create(ctx context.Context, request webCategory.CreateCategoryRequest) webCategory.CategoryResponse
 	FindAll(ctx context.Context, request webCategory.CategoryRequestFindAll) ([]webCategory.CategoryResponse, int)
 	FindById(ctx context.Context, id int) webCategory.CategoryResponse
+	FindTree(ctx context.Context) webCategory.CategoryTreeResponse
 	Update(ctx context.Context, request webCategory.UpdateCategoryRequest, id int) webCategory.CategoryResponse
 	Delete(ctx context.Context, id int)
-	Import(ctx context.Context, fileBytes []byte, fileType string) []webCategory.CategoryResponse
+	Import(ctx context.Context, fileBytes []byte, fileType string, onError string) ([]webCategory.CategoryResponse, web.ImportReport)
 	Export(ctx context.Context, search string) ([]byte, error)
+	ImportTemplate(ctx context.Context) ([]byte, error)
 }
@@ -1,10 +1,12 @@
 package erp
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
+	"strings"
 	"time"
 )
 
@@ -281,3 +283,47 @@ func (c *ERPClient) FetchLOIs() ([]ERPLetterOfIntent, error) {
 	}
 	return result, nil
 }
+
+// maxImageBytes caps the size of a file downloaded with FetchImage
+const maxImageBytes = 20 << 20
+
+// NewImageRequest builds the GET request for a file served by the ERP, such as a building photo.
+// imagePath is relative to baseURL; a leading slash is ignored. The request is canceled with ctx.
+func NewImageRequest(ctx context.Context, baseURL, apiKey, apiSecret, imagePath string) (*http.Request, error) {
+	imageURL := strings.TrimSuffix(baseURL, "/") + "/" + strings.TrimPrefix(imagePath, "/")
+	req, err := http.NewRequestWithContext(ctx, "GET", imageURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	if apiKey != "" && apiSecret != "" {
+		req.Header.Set("Authorization", fmt.Sprintf("Token %s:%s", apiKey, apiSecret))
+	}
+	return req, nil
+}
+
+// FetchImage downloads a file served by the ERP and returns its bytes; it gives up when ctx is done
+func (c *ERPClient) FetchImage(ctx context.Context, imagePath string) ([]byte, error) {
+	req, err := NewImageRequest(ctx, c.BaseURL, c.APIKey, c.APISecret, imagePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch image from ERP: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("ERP image server returned status %d", resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxImageBytes+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read ERP image: %w", err)
+	}
+	if len(body) > maxImageBytes {
+		return nil, fmt.Errorf("ERP image is larger than %d bytes", maxImageBytes)
+	}
+	return body, nil
+}
@@ -0,0 +1,113 @@
+package geocoding
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// gazetteerReverseRadiusMeters is how close a coordinate must be to a gazetteer entry for
+// Reverse to return that entry's address
+const gazetteerReverseRadiusMeters = 250
+
+// GazetteerProvider answers from a fixed list of places, for offline environments and tests.
+// Geocode matches the whole address (see NormalizeAddress); Reverse returns the nearest entry
+// within 250 m.
+type GazetteerProvider struct {
+	entries []Result
+	byKey   map[string]Result
+}
+
+func NewGazetteerProvider(entries []Result) *GazetteerProvider {
+	p := &GazetteerProvider{entries: entries, byKey: map[string]Result{}}
+	for _, entry := range entries {
+		key := NormalizeAddress(entry.Address)
+		if _, exists := p.byKey[key]; !exists {
+			p.byKey[key] = entry
+		}
+	}
+	return p
+}
+
+// LoadGazetteer reads a csv file with the header address,latitude,longitude
+func LoadGazetteer(path string) (*GazetteerProvider, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return ReadGazetteer(file)
+}
+
+// ReadGazetteer parses gazetteer csv (see LoadGazetteer)
+func ReadGazetteer(r io.Reader) (*GazetteerProvider, error) {
+	records, err := csv.NewReader(r).ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return NewGazetteerProvider(nil), nil
+	}
+
+	columns := map[string]int{}
+	for i, h := range records[0] {
+		columns[strings.ToLower(strings.TrimSpace(h))] = i
+	}
+	for _, name := range []string{"address", "latitude", "longitude"} {
+		if _, ok := columns[name]; !ok {
+			return nil, fmt.Errorf("gazetteer is missing the %s column", name)
+		}
+	}
+
+	entries := make([]Result, 0, len(records)-1)
+	for i, record := range records[1:] {
+		lat, err := strconv.ParseFloat(strings.TrimSpace(record[columns["latitude"]]), 64)
+		if err != nil {
+			return nil, fmt.Errorf("gazetteer line %d: invalid latitude", i+2)
+		}
+		lng, err := strconv.ParseFloat(strings.TrimSpace(record[columns["longitude"]]), 64)
+		if err != nil {
+			return nil, fmt.Errorf("gazetteer line %d: invalid longitude", i+2)
+		}
+		entries = append(entries, Result{Latitude: lat, Longitude: lng, Address: strings.TrimSpace(record[columns["address"]])})
+	}
+	return NewGazetteerProvider(entries), nil
+}
+
+func (p *GazetteerProvider) Name() string {
+	return "gazetteer"
+}
+
+func (p *GazetteerProvider) Geocode(ctx context.Context, address string) (Result, bool, error) {
+	result, ok := p.byKey[NormalizeAddress(address)]
+	return result, ok, nil
+}
+
+func (p *GazetteerProvider) Reverse(ctx context.Context, latitude float64, longitude float64) (Result, bool, error) {
+	best, bestDistance := Result{}, math.Inf(1)
+	for _, entry := range p.entries {
+		if d := distanceMeters(latitude, longitude, entry.Latitude, entry.Longitude); d < bestDistance {
+			best, bestDistance = entry, d
+		}
+	}
+	if bestDistance > gazetteerReverseRadiusMeters {
+		return Result{}, false, nil
+	}
+	return best, true, nil
+}
+
+// distanceMeters is the haversine distance between two coordinates
+func distanceMeters(lat1, lng1, lat2, lng2 float64) float64 {
+	const earthRadiusMeters = 6371000
+	toRad := func(deg float64) float64 { return deg * math.Pi / 180 }
+	dLat := toRad(lat2 - lat1)
+	dLng := toRad(lng2 - lng1)
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(toRad(lat1))*math.Cos(toRad(lat2))*math.Sin(dLng/2)*math.Sin(dLng/2)
+	return 2 * earthRadiusMeters * math.Asin(math.Sqrt(a))
+}
@@ -0,0 +1,144 @@
+package geocoding
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// NominatimProvider queries a Nominatim-compatible HTTP API (the public OpenStreetMap instance,
+// a self-hosted one, or any service speaking the same /search and /reverse format). Requests are
+// spaced by MinInterval because the public instance allows one request per second.
+type NominatimProvider struct {
+	BaseURL      string
+	UserAgent    string
+	CountryCodes string
+	MinInterval  time.Duration
+	HTTPClient   *http.Client
+
+	mu   sync.Mutex
+	last time.Time
+}
+
+func NewNominatimProvider(baseURL string, userAgent string, minInterval time.Duration) *NominatimProvider {
+	return &NominatimProvider{
+		BaseURL:      baseURL,
+		UserAgent:    userAgent,
+		CountryCodes: "id",
+		MinInterval:  minInterval,
+		HTTPClient: &http.Client{
+			Timeout: 15 * time.Second,
+		},
+	}
+}
+
+type nominatimPlace struct {
+	Lat         string `json:"lat"`
+	Lon         string `json:"lon"`
+	DisplayName string `json:"display_name"`
+	Error       string `json:"error"`
+}
+
+func (p *NominatimProvider) Name() string {
+	return "nominatim"
+}
+
+func (p *NominatimProvider) Geocode(ctx context.Context, address string) (Result, bool, error) {
+	query := url.Values{}
+	query.Set("format", "jsonv2")
+	query.Set("limit", "1")
+	query.Set("q", address)
+	if p.CountryCodes != "" {
+		query.Set("countrycodes", p.CountryCodes)
+	}
+
+	var places []nominatimPlace
+	if err := p.get(ctx, "/search", query, &places); err != nil {
+		return Result{}, false, err
+	}
+	if len(places) == 0 {
+		return Result{}, false, nil
+	}
+	return places[0].result()
+}
+
+func (p *NominatimProvider) Reverse(ctx context.Context, latitude float64, longitude float64) (Result, bool, error) {
+	query := url.Values{}
+	query.Set("format", "jsonv2")
+	query.Set("lat", strconv.FormatFloat(latitude, 'f', -1, 64))
+	query.Set("lon", strconv.FormatFloat(longitude, 'f', -1, 64))
+
+	var place nominatimPlace
+	if err := p.get(ctx, "/reverse", query, &place); err != nil {
+		return Result{}, false, err
+	}
+	// Nominatim answers 200 with {"error": "Unable to geocode"} when nothing is there
+	if place.Error != "" || place.DisplayName == "" {
+		return Result{}, false, nil
+	}
+	return place.result()
+}
+
+func (place nominatimPlace) result() (Result, bool, error) {
+	lat, err := strconv.ParseFloat(place.Lat, 64)
+	if err != nil {
+		return Result{}, false, fmt.Errorf("invalid latitude %q in geocoder response", place.Lat)
+	}
+	lng, err := strconv.ParseFloat(place.Lon, 64)
+	if err != nil {
+		return Result{}, false, fmt.Errorf("invalid longitude %q in geocoder response", place.Lon)
+	}
+	return Result{Latitude: lat, Longitude: lng, Address: place.DisplayName}, true, nil
+}
+
+func (p *NominatimProvider) get(ctx context.Context, path string, query url.Values, out interface{}) error {
+	if err := p.wait(ctx); err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.BaseURL+path+"?"+query.Encode(), nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	if p.UserAgent != "" {
+		req.Header.Set("User-Agent", p.UserAgent)
+	}
+
+	resp, err := p.HTTPClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("geocoder request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("geocoder returned status %d", resp.StatusCode)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode geocoder response: %w", err)
+	}
+	return nil
+}
+
+// wait blocks until MinInterval has passed since the previous request
+func (p *NominatimProvider) wait(ctx context.Context) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if delay := time.Until(p.last.Add(p.MinInterval)); delay > 0 {
+		timer := time.NewTimer(delay)
+		defer timer.Stop()
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+	p.last = time.Now()
+	return nil
+}
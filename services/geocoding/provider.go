@@ -0,0 +1,43 @@
+package geocoding
+
+import (
+	"context"
+	"strings"
+)
+
+// Result is a geocoded location. Address is the provider's label for it (the display name).
+type Result struct {
+	Latitude  float64
+	Longitude float64
+	Address   string
+}
+
+// Provider turns addresses into coordinates and back. ok is false when the provider has no
+// answer; err is for failures (network, malformed response) that must not be cached.
+type Provider interface {
+	Name() string
+	Geocode(ctx context.Context, address string) (result Result, ok bool, err error)
+	Reverse(ctx context.Context, latitude float64, longitude float64) (result Result, ok bool, err error)
+}
+
+// Bounding box of Indonesia, from Sabang/Rote to Merauke with a small margin for outer islands
+const (
+	indonesiaMinLatitude  = -11.2
+	indonesiaMaxLatitude  = 6.3
+	indonesiaMinLongitude = 94.7
+	indonesiaMaxLongitude = 141.2
+)
+
+// InIndonesia reports whether a coordinate lies within Indonesia's bounding box. The box also
+// covers Singapore, Timor-Leste and parts of Malaysia; it catches swapped or mistyped coordinates,
+// not border cases.
+func InIndonesia(latitude float64, longitude float64) bool {
+	return latitude >= indonesiaMinLatitude && latitude <= indonesiaMaxLatitude &&
+		longitude >= indonesiaMinLongitude && longitude <= indonesiaMaxLongitude
+}
+
+// NormalizeAddress lower-cases an address and collapses its whitespace; it is the cache and
+// gazetteer key, so "Jl. Thamrin  11" and "jl. thamrin 11" geocode the same
+func NormalizeAddress(address string) string {
+	return strings.Join(strings.Fields(strings.ToLower(address)), " ")
+}
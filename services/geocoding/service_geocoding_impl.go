@@ -0,0 +1,113 @@
+package geocoding
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/malikabdulaziz/tmn-backend/models"
+	repositoriesGeocodeCache "github.com/malikabdulaziz/tmn-backend/repositories/geocodecache"
+	"github.com/sirupsen/logrus"
+)
+
+type ServiceGeocodingImpl struct {
+	DB                              *sql.DB
+	RepositoryGeocodeCacheInterface repositoriesGeocodeCache.RepositoryGeocodeCacheInterface
+	Provider                        Provider
+	Logger                          *logrus.Logger
+}
+
+// NewServiceGeocodingImpl returns the geocoding service. A nil provider disables geocoding:
+// every lookup answers not found.
+func NewServiceGeocodingImpl(
+	db *sql.DB,
+	repoGeocodeCache repositoriesGeocodeCache.RepositoryGeocodeCacheInterface,
+	provider Provider,
+	logger *logrus.Logger,
+) ServiceGeocodingInterface {
+	return &ServiceGeocodingImpl{
+		DB:                              db,
+		RepositoryGeocodeCacheInterface: repoGeocodeCache,
+		Provider:                        provider,
+		Logger:                          logger,
+	}
+}
+
+func (service *ServiceGeocodingImpl) Geocode(ctx context.Context, address string) (Result, bool) {
+	key := NormalizeAddress(address)
+	if service.Provider == nil || key == "" {
+		return Result{}, false
+	}
+	return service.lookup(ctx, models.GeocodeKindForward, key, func() (Result, bool, error) {
+		return service.Provider.Geocode(ctx, address)
+	})
+}
+
+func (service *ServiceGeocodingImpl) Reverse(ctx context.Context, latitude float64, longitude float64) (Result, bool) {
+	if service.Provider == nil {
+		return Result{}, false
+	}
+	// 5 decimals is ~1 m, finer than any address
+	key := fmt.Sprintf("%.5f,%.5f", latitude, longitude)
+	return service.lookup(ctx, models.GeocodeKindReverse, key, func() (Result, bool, error) {
+		return service.Provider.Reverse(ctx, latitude, longitude)
+	})
+}
+
+// lookup answers from the cache, or asks the provider and caches its answer. Geocoding is best
+// effort: cache and provider failures are logged and reported as not found, and provider
+// failures are not cached so the next lookup tries again.
+func (service *ServiceGeocodingImpl) lookup(ctx context.Context, kind string, key string, ask func() (Result, bool, error)) (Result, bool) {
+	provider := service.Provider.Name()
+	fields := logrus.Fields{"provider": provider, "kind": kind, "query": key}
+
+	cached, err := service.findCached(ctx, provider, kind, key)
+	if err == nil {
+		return Result{Latitude: cached.Latitude, Longitude: cached.Longitude, Address: cached.Address}, cached.Found
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		service.Logger.WithError(err).WithFields(fields).Warn("Failed to read geocode cache")
+	}
+
+	result, ok, err := ask()
+	if err != nil {
+		service.Logger.WithError(err).WithFields(fields).Warn("Geocoding failed")
+		return Result{}, false
+	}
+
+	entry := models.GeocodeCache{
+		Provider:  provider,
+		Kind:      kind,
+		QueryKey:  key,
+		Found:     ok,
+		Latitude:  result.Latitude,
+		Longitude: result.Longitude,
+		Address:   result.Address,
+	}
+	if err := service.saveCached(ctx, entry); err != nil {
+		service.Logger.WithError(err).WithFields(fields).Warn("Failed to write geocode cache")
+	}
+	return result, ok
+}
+
+func (service *ServiceGeocodingImpl) findCached(ctx context.Context, provider string, kind string, key string) (models.GeocodeCache, error) {
+	tx, err := service.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return models.GeocodeCache{}, err
+	}
+	defer tx.Rollback()
+	return service.RepositoryGeocodeCacheInterface.Find(ctx, tx, provider, kind, key)
+}
+
+func (service *ServiceGeocodingImpl) saveCached(ctx context.Context, entry models.GeocodeCache) error {
+	tx, err := service.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	if err := service.RepositoryGeocodeCacheInterface.Save(ctx, tx, entry); err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
@@ -0,0 +1,188 @@
+package geocoding_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/malikabdulaziz/tmn-backend/models"
+	serviceGeocoding "github.com/malikabdulaziz/tmn-backend/services/geocoding"
+	"github.com/malikabdulaziz/tmn-backend/testutil"
+	"github.com/malikabdulaziz/tmn-backend/testutil/mocks"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const gazetteerCSV = `address,latitude,longitude
+"Jl. Thamrin 11, Jakarta",-6.1870,106.8230
+"Jl. Asia Afrika 19, Jakarta",-6.2270,106.7970
+`
+
+// failingProvider fails every lookup, like a geocoder that is down
+type failingProvider struct{}
+
+func (failingProvider) Name() string { return "failing" }
+
+func (failingProvider) Geocode(ctx context.Context, address string) (serviceGeocoding.Result, bool, error) {
+	return serviceGeocoding.Result{}, false, errors.New("connection refused")
+}
+
+func (failingProvider) Reverse(ctx context.Context, latitude float64, longitude float64) (serviceGeocoding.Result, bool, error) {
+	return serviceGeocoding.Result{}, false, errors.New("connection refused")
+}
+
+// --- Service ---
+
+func TestGeocodingGeocode_CachesProviderAnswer(t *testing.T) {
+	db, sqlMock := testutil.NewMockDB(t)
+	repo := &mocks.MockRepositoryGeocodeCache{}
+	provider, err := serviceGeocoding.ReadGazetteer(strings.NewReader(gazetteerCSV))
+	assert.NoError(t, err)
+	svc := serviceGeocoding.NewServiceGeocodingImpl(db, repo, provider, logrus.New())
+
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectRollback()
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectCommit()
+
+	repo.On("Find", mock.Anything, mock.AnythingOfType("*sql.Tx"), "gazetteer", models.GeocodeKindForward, "jl. thamrin 11, jakarta").
+		Return(models.GeocodeCache{}, sql.ErrNoRows)
+	repo.On("Save", mock.Anything, mock.AnythingOfType("*sql.Tx"), models.GeocodeCache{
+		Provider:  "gazetteer",
+		Kind:      models.GeocodeKindForward,
+		QueryKey:  "jl. thamrin 11, jakarta",
+		Found:     true,
+		Latitude:  -6.1870,
+		Longitude: 106.8230,
+		Address:   "Jl. Thamrin 11, Jakarta",
+	}).Return(nil)
+
+	result, ok := svc.Geocode(context.Background(), "  JL. Thamrin 11,  Jakarta ")
+
+	assert.True(t, ok)
+	assert.Equal(t, -6.1870, result.Latitude)
+	repo.AssertExpectations(t)
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+}
+
+func TestGeocodingReverse_AnswersFromCache(t *testing.T) {
+	db, sqlMock := testutil.NewMockDB(t)
+	repo := &mocks.MockRepositoryGeocodeCache{}
+	svc := serviceGeocoding.NewServiceGeocodingImpl(db, repo, failingProvider{}, logrus.New())
+
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectRollback()
+
+	repo.On("Find", mock.Anything, mock.AnythingOfType("*sql.Tx"), "failing", models.GeocodeKindReverse, "-6.18700,106.82300").
+		Return(models.GeocodeCache{Found: true, Latitude: -6.1870, Longitude: 106.8230, Address: "Sarinah"}, nil)
+
+	result, ok := svc.Reverse(context.Background(), -6.187, 106.823)
+
+	assert.True(t, ok)
+	assert.Equal(t, "Sarinah", result.Address)
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+}
+
+func TestGeocodingGeocode_ProviderErrorNotCached(t *testing.T) {
+	db, sqlMock := testutil.NewMockDB(t)
+	repo := &mocks.MockRepositoryGeocodeCache{}
+	svc := serviceGeocoding.NewServiceGeocodingImpl(db, repo, failingProvider{}, logrus.New())
+
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectRollback()
+
+	repo.On("Find", mock.Anything, mock.AnythingOfType("*sql.Tx"), "failing", models.GeocodeKindForward, "jl. thamrin 11").
+		Return(models.GeocodeCache{}, sql.ErrNoRows)
+
+	_, ok := svc.Geocode(context.Background(), "Jl. Thamrin 11")
+
+	assert.False(t, ok)
+	repo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything, mock.Anything)
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+}
+
+func TestGeocodingGeocode_DisabledWithoutProvider(t *testing.T) {
+	db, sqlMock := testutil.NewMockDB(t)
+	repo := &mocks.MockRepositoryGeocodeCache{}
+	svc := serviceGeocoding.NewServiceGeocodingImpl(db, repo, nil, logrus.New())
+
+	_, ok := svc.Geocode(context.Background(), "Jl. Thamrin 11")
+
+	assert.False(t, ok)
+	repo.AssertNotCalled(t, "Find", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+}
+
+// --- Providers ---
+
+func TestGazetteerReverse_NearestWithinRadius(t *testing.T) {
+	provider, err := serviceGeocoding.ReadGazetteer(strings.NewReader(gazetteerCSV))
+	assert.NoError(t, err)
+
+	// ~100 m from Jl. Thamrin 11
+	result, ok, err := provider.Reverse(context.Background(), -6.1879, 106.8230)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, "Jl. Thamrin 11, Jakarta", result.Address)
+
+	// ~1 km from both entries
+	_, ok, err = provider.Reverse(context.Background(), -6.2000, 106.8300)
+	assert.NoError(t, err)
+	assert.False(t, ok)
+}
+
+func TestReadGazetteer_MissingColumn(t *testing.T) {
+	_, err := serviceGeocoding.ReadGazetteer(strings.NewReader("address,lat,lng\nSarinah,-6.1,106.8\n"))
+	assert.EqualError(t, err, "gazetteer is missing the latitude column")
+}
+
+func TestNominatimProvider_SearchAndReverse(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "tmn-test", r.Header.Get("User-Agent"))
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/search":
+			assert.Equal(t, "id", r.URL.Query().Get("countrycodes"))
+			if r.URL.Query().Get("q") == "Jl. Thamrin 11" {
+				w.Write([]byte(`[{"lat":"-6.1870","lon":"106.8230","display_name":"Jalan M.H. Thamrin 11, Jakarta"}]`))
+				return
+			}
+			w.Write([]byte(`[]`))
+		case "/reverse":
+			w.Write([]byte(`{"error":"Unable to geocode"}`))
+		}
+	}))
+	defer server.Close()
+
+	provider := serviceGeocoding.NewNominatimProvider(server.URL, "tmn-test", 0)
+
+	result, ok, err := provider.Geocode(context.Background(), "Jl. Thamrin 11")
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, serviceGeocoding.Result{Latitude: -6.1870, Longitude: 106.8230, Address: "Jalan M.H. Thamrin 11, Jakarta"}, result)
+
+	_, ok, err = provider.Geocode(context.Background(), "Jl. Nowhere")
+	assert.NoError(t, err)
+	assert.False(t, ok)
+
+	_, ok, err = provider.Reverse(context.Background(), 0.5, 100.1)
+	assert.NoError(t, err)
+	assert.False(t, ok)
+}
+
+func TestNominatimProvider_HTTPErrorIsNotAMiss(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	provider := serviceGeocoding.NewNominatimProvider(server.URL, "tmn-test", 0)
+
+	_, _, err := provider.Geocode(context.Background(), "Jl. Thamrin 11")
+	assert.EqualError(t, err, "geocoder returned status 429")
+}
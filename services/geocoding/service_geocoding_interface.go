@@ -0,0 +1,14 @@
+package geocoding
+
+import (
+	"context"
+)
+
+type ServiceGeocodingInterface interface {
+	// Geocode returns the location of an address; ok is false when it cannot be found or no
+	// provider is configured
+	Geocode(ctx context.Context, address string) (result Result, ok bool)
+	// Reverse returns the address at a coordinate; ok is false when it cannot be found or no
+	// provider is configured
+	Reverse(ctx context.Context, latitude float64, longitude float64) (result Result, ok bool)
+}
@@ -0,0 +1,44 @@
+package importjob
+
+import (
+	"context"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// StartImportJobWorker starts a background goroutine that polls for queued import jobs and runs
+// them one at a time. Jobs left running by a worker that died are failed on every poll.
+func StartImportJobWorker(service ServiceImportJobInterface, logger *logrus.Logger, pollSeconds int) {
+	if pollSeconds <= 0 {
+		pollSeconds = 5
+	}
+
+	interval := time.Duration(pollSeconds) * time.Second
+	ticker := time.NewTicker(interval)
+
+	logger.WithField("interval", interval.String()).Info("Starting import job worker")
+
+	go func() {
+		ctx := context.Background()
+
+		for range ticker.C {
+			if count, err := service.FailStale(ctx); err != nil {
+				logger.WithError(err).Error("Failed to fail stale import jobs")
+			} else if count > 0 {
+				logger.WithField("count", count).Warn("Failed stale import jobs")
+			}
+
+			for {
+				ran, err := service.RunNext(ctx)
+				if err != nil {
+					logger.WithError(err).Error("Import job run failed")
+					break
+				}
+				if !ran {
+					break
+				}
+			}
+		}
+	}()
+}
@@ -0,0 +1,376 @@
+package importjob
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/malikabdulaziz/tmn-backend/exceptions"
+	"github.com/malikabdulaziz/tmn-backend/helpers"
+	"github.com/malikabdulaziz/tmn-backend/importer"
+	"github.com/malikabdulaziz/tmn-backend/models"
+	repositoriesImportJob "github.com/malikabdulaziz/tmn-backend/repositories/importjob"
+	servicesBranch "github.com/malikabdulaziz/tmn-backend/services/branch"
+	servicesBuildingRestriction "github.com/malikabdulaziz/tmn-backend/services/buildingrestriction"
+	servicesCategory "github.com/malikabdulaziz/tmn-backend/services/category"
+	servicesMotherBrand "github.com/malikabdulaziz/tmn-backend/services/motherbrand"
+	servicesPOI "github.com/malikabdulaziz/tmn-backend/services/poi"
+	servicesSalesPackage "github.com/malikabdulaziz/tmn-backend/services/salespackage"
+	servicesSubCategory "github.com/malikabdulaziz/tmn-backend/services/subcategory"
+	"github.com/malikabdulaziz/tmn-backend/web"
+	webImportJob "github.com/malikabdulaziz/tmn-backend/web/importjob"
+	webPOI "github.com/malikabdulaziz/tmn-backend/web/poi"
+)
+
+// importJobStaleSeconds is how long a running job may go without a progress update before it is
+// considered abandoned by a worker that died (see FailStale)
+const importJobStaleSeconds = 15 * 60
+
+var importJobKinds = map[string]bool{
+	models.ImportJobKindPOI:                 true,
+	models.ImportJobKindSalesPackage:        true,
+	models.ImportJobKindBuildingRestriction: true,
+	models.ImportJobKindCategory:            true,
+	models.ImportJobKindSubCategory:         true,
+	models.ImportJobKindMotherBrand:         true,
+	models.ImportJobKindBranch:              true,
+}
+
+type ServiceImportJobImpl struct {
+	DB                                  *sql.DB
+	RepositoryImportJobInterface        repositoriesImportJob.RepositoryImportJobInterface
+	ServicePOIInterface                 servicesPOI.ServicePOIInterface
+	ServiceSalesPackageInterface        servicesSalesPackage.ServiceSalesPackageInterface
+	ServiceBuildingRestrictionInterface servicesBuildingRestriction.ServiceBuildingRestrictionInterface
+	ServiceCategoryInterface            servicesCategory.ServiceCategoryInterface
+	ServiceSubCategoryInterface         servicesSubCategory.ServiceSubCategoryInterface
+	ServiceMotherBrandInterface         servicesMotherBrand.ServiceMotherBrandInterface
+	ServiceBranchInterface              servicesBranch.ServiceBranchInterface
+}
+
+func NewServiceImportJobImpl(
+	db *sql.DB,
+	repoImportJob repositoriesImportJob.RepositoryImportJobInterface,
+	servicePOI servicesPOI.ServicePOIInterface,
+	serviceSalesPackage servicesSalesPackage.ServiceSalesPackageInterface,
+	serviceBuildingRestriction servicesBuildingRestriction.ServiceBuildingRestrictionInterface,
+	serviceCategory servicesCategory.ServiceCategoryInterface,
+	serviceSubCategory servicesSubCategory.ServiceSubCategoryInterface,
+	serviceMotherBrand servicesMotherBrand.ServiceMotherBrandInterface,
+	serviceBranch servicesBranch.ServiceBranchInterface,
+) ServiceImportJobInterface {
+	return &ServiceImportJobImpl{
+		DB:                                  db,
+		RepositoryImportJobInterface:        repoImportJob,
+		ServicePOIInterface:                 servicePOI,
+		ServiceSalesPackageInterface:        serviceSalesPackage,
+		ServiceBuildingRestrictionInterface: serviceBuildingRestriction,
+		ServiceCategoryInterface:            serviceCategory,
+		ServiceSubCategoryInterface:         serviceSubCategory,
+		ServiceMotherBrandInterface:         serviceMotherBrand,
+		ServiceBranchInterface:              serviceBranch,
+	}
+}
+
+// Enqueue stores an upload as a queued job; the import job worker picks it up (see RunNext)
+func (s *ServiceImportJobImpl) Enqueue(ctx context.Context, kind string, fileBytes []byte, fileType string, options webImportJob.ImportJobOptions) webImportJob.ImportJobResponse {
+	kind = strings.ToLower(strings.TrimSpace(kind))
+	if !importJobKinds[kind] {
+		panic(exceptions.NewBadRequestError(fmt.Sprintf("Unsupported import kind: %s. Use poi, sales_package, building_restriction, category, sub_category, mother_brand or branch.", kind)))
+	}
+	options = normalizeImportJobOptions(kind, options)
+
+	storedOptions, err := json.Marshal(options)
+	helpers.PanicIfError(err)
+
+	tx, err := s.DB.Begin()
+	helpers.PanicIfError(err)
+	defer helpers.CommitOrRollback(tx)
+
+	job := models.ImportJob{
+		Kind:      kind,
+		FileType:  strings.ToLower(fileType),
+		FileBytes: fileBytes,
+		Options:   string(storedOptions),
+	}
+	if userId := helpers.UserIdFromContext(ctx); userId > 0 {
+		job.CreatedBy = &userId
+	}
+	created, err := s.RepositoryImportJobInterface.Create(ctx, tx, job)
+	helpers.PanicIfError(err)
+	return importJobModelToResponse(created)
+}
+
+func (s *ServiceImportJobImpl) FindById(ctx context.Context, id int) webImportJob.ImportJobResponse {
+	tx, err := s.DB.Begin()
+	helpers.PanicIfError(err)
+	defer helpers.CommitOrRollback(tx)
+
+	return importJobModelToResponse(s.findOwnJob(ctx, tx, id))
+}
+
+// Cancel cancels a queued job right away. A running job is cancelled at its next batch, which
+// rolls back everything it wrote; poll FindById until the status is "cancelled".
+func (s *ServiceImportJobImpl) Cancel(ctx context.Context, id int) webImportJob.ImportJobResponse {
+	tx, err := s.DB.Begin()
+	helpers.PanicIfError(err)
+	defer helpers.CommitOrRollback(tx)
+
+	s.findOwnJob(ctx, tx, id)
+	job, err := s.RepositoryImportJobInterface.RequestCancel(ctx, tx, id)
+	if err == sql.ErrNoRows {
+		panic(exceptions.NewBadRequestError("Import job has already finished"))
+	}
+	helpers.PanicIfError(err)
+	return importJobModelToResponse(job)
+}
+
+// findOwnJob returns a job created by the current user; other users' jobs are reported as not found
+func (s *ServiceImportJobImpl) findOwnJob(ctx context.Context, tx *sql.Tx, id int) models.ImportJob {
+	job, err := s.RepositoryImportJobInterface.FindById(ctx, tx, id)
+	if err == sql.ErrNoRows {
+		panic(exceptions.NewNotFoundError("Import job not found"))
+	}
+	helpers.PanicIfError(err)
+
+	if job.CreatedBy != nil && *job.CreatedBy != helpers.UserIdFromContext(ctx) {
+		panic(exceptions.NewNotFoundError("Import job not found"))
+	}
+	return job
+}
+
+// RunNext claims the oldest queued job and runs it to completion. It returns false when the
+// queue is empty. The import itself runs in one transaction, so a job that fails or is
+// cancelled leaves no trace in the imported tables; the flip side is that the rows it writes stay
+// locked until the whole import commits, so edits to them wait for the job. POI uploads are
+// geocoded before that transaction is opened.
+func (s *ServiceImportJobImpl) RunNext(ctx context.Context) (bool, error) {
+	job, err := s.claimNext(ctx)
+	if err == sql.ErrNoRows {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	job = s.execute(ctx, job)
+	return true, s.finish(ctx, job)
+}
+
+// FailStale fails running jobs whose worker stopped reporting progress
+func (s *ServiceImportJobImpl) FailStale(ctx context.Context) (int, error) {
+	tx, err := s.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	count, err := s.RepositoryImportJobInterface.FailStale(ctx, tx, importJobStaleSeconds, "Import job was interrupted before it finished; nothing was imported. Please upload the file again.")
+	if err != nil {
+		tx.Rollback()
+		return 0, err
+	}
+	return count, tx.Commit()
+}
+
+func (s *ServiceImportJobImpl) claimNext(ctx context.Context) (models.ImportJob, error) {
+	tx, err := s.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return models.ImportJob{}, err
+	}
+	job, err := s.RepositoryImportJobInterface.ClaimNext(ctx, tx)
+	if err != nil {
+		tx.Rollback()
+		return models.ImportJob{}, err
+	}
+	return job, tx.Commit()
+}
+
+// execute runs the import of a claimed job and returns the job with its final status. Progress
+// is saved outside the import transaction after every batch; a cancel request seen there
+// cancels the import context, which makes the import panic and roll back at that batch. So does
+// a job that FailStale already gave up on, so the import never commits behind its failed status.
+// The import runs as the user who uploaded the file, so owned rows get their owner and visibility.
+func (s *ServiceImportJobImpl) execute(ctx context.Context, job models.ImportJob) (finished models.ImportJob) {
+	importCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	if job.CreatedBy != nil {
+		importCtx = context.WithValue(importCtx, helpers.ContextKey("userId"), strconv.Itoa(*job.CreatedBy))
+	}
+
+	cancelled := false
+	importCtx = importer.WithProgress(importCtx, func(done int, total int) {
+		cancelRequested, err := s.saveProgress(ctx, job.Id, done, total)
+		if err == sql.ErrNoRows {
+			cancel()
+			return
+		}
+		helpers.PanicIfError(err)
+		if cancelRequested {
+			cancelled = true
+			cancel()
+		}
+	})
+
+	finished = job
+	defer func() {
+		r := recover()
+		if r == nil {
+			finished.Status = models.ImportJobStatusSucceeded
+			return
+		}
+		if cancelled {
+			finished.Status = models.ImportJobStatusCancelled
+			finished.Error = "Import job was cancelled; nothing was imported."
+			return
+		}
+		finished.Status = models.ImportJobStatusFailed
+		switch e := r.(type) {
+		case exceptions.BadRequestError:
+			finished.Error = e.Error
+			if report, ok := e.Extras.(web.ImportReport); ok {
+				finished.Report = encodeImportReport(report)
+			}
+		case exceptions.NotFoundError:
+			finished.Error = e.Error
+		case exceptions.Forbidden:
+			finished.Error = e.Error
+		case error:
+			finished.Error = e.Error()
+		default:
+			finished.Error = fmt.Sprint(r)
+		}
+	}()
+
+	count, report := s.runImport(importCtx, job)
+	finished.ImportedCount = count
+	finished.Report = encodeImportReport(report)
+	return finished
+}
+
+// runImport hands the job's file to the import of its kind and returns how many records it
+// imported along with its report
+func (s *ServiceImportJobImpl) runImport(ctx context.Context, job models.ImportJob) (int, web.ImportReport) {
+	options := decodeImportJobOptions(job.Options)
+
+	switch job.Kind {
+	case models.ImportJobKindPOI:
+		responses, report := s.ServicePOIInterface.Import(ctx, job.FileBytes, job.FileType, webPOI.POIImportOptions{
+			Mode:          options.Mode,
+			DeleteMissing: options.DeleteMissing,
+			OnError:       options.OnError,
+		})
+		return len(responses), report
+	case models.ImportJobKindSalesPackage:
+		responses, report := s.ServiceSalesPackageInterface.Import(ctx, job.FileBytes, job.FileType, options.OnError)
+		return len(responses), report
+	case models.ImportJobKindBuildingRestriction:
+		responses, report := s.ServiceBuildingRestrictionInterface.Import(ctx, job.FileBytes, job.FileType, options.OnError)
+		return len(responses), report
+	case models.ImportJobKindCategory:
+		responses, report := s.ServiceCategoryInterface.Import(ctx, job.FileBytes, job.FileType, options.OnError)
+		return len(responses), report
+	case models.ImportJobKindSubCategory:
+		responses, report := s.ServiceSubCategoryInterface.Import(ctx, job.FileBytes, job.FileType, options.OnError)
+		return len(responses), report
+	case models.ImportJobKindMotherBrand:
+		responses, report := s.ServiceMotherBrandInterface.Import(ctx, job.FileBytes, job.FileType, options.OnError)
+		return len(responses), report
+	case models.ImportJobKindBranch:
+		responses, report := s.ServiceBranchInterface.Import(ctx, job.FileBytes, job.FileType, options.OnError)
+		return len(responses), report
+	default:
+		panic(exceptions.NewBadRequestError(fmt.Sprintf("Unsupported import kind: %s", job.Kind)))
+	}
+}
+
+func (s *ServiceImportJobImpl) saveProgress(ctx context.Context, id int, done int, total int) (bool, error) {
+	tx, err := s.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return false, err
+	}
+	cancelRequested, err := s.RepositoryImportJobInterface.UpdateProgress(ctx, tx, id, done, total)
+	if err != nil {
+		tx.Rollback()
+		return false, err
+	}
+	return cancelRequested, tx.Commit()
+}
+
+func (s *ServiceImportJobImpl) finish(ctx context.Context, job models.ImportJob) error {
+	tx, err := s.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	if err := s.RepositoryImportJobInterface.Finish(ctx, tx, job); err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
+// normalizeImportJobOptions validates the options up front so a bad form field is rejected at
+// upload rather than when the job runs. Only POI imports have a mode.
+func normalizeImportJobOptions(kind string, options webImportJob.ImportJobOptions) webImportJob.ImportJobOptions {
+	options.OnError = importer.ParseOnError(options.OnError)
+	if kind != models.ImportJobKindPOI {
+		options.Mode = ""
+		options.DeleteMissing = false
+		return options
+	}
+
+	options.Mode = strings.ToLower(strings.TrimSpace(options.Mode))
+	switch options.Mode {
+	case "":
+		options.Mode = webPOI.POIImportModeReplace
+	case webPOI.POIImportModeReplace, webPOI.POIImportModeUpsert:
+	default:
+		panic(exceptions.NewBadRequestError(fmt.Sprintf("Unsupported import mode: %s. Use replace or upsert.", options.Mode)))
+	}
+	return options
+}
+
+func decodeImportJobOptions(stored string) webImportJob.ImportJobOptions {
+	var options webImportJob.ImportJobOptions
+	if stored != "" {
+		helpers.PanicIfError(json.Unmarshal([]byte(stored), &options))
+	}
+	return options
+}
+
+func encodeImportReport(report web.ImportReport) string {
+	encoded, err := json.Marshal(report)
+	helpers.PanicIfError(err)
+	return string(encoded)
+}
+
+func importJobModelToResponse(job models.ImportJob) webImportJob.ImportJobResponse {
+	response := webImportJob.ImportJobResponse{
+		Id:              job.Id,
+		Kind:            job.Kind,
+		FileType:        job.FileType,
+		Options:         decodeImportJobOptions(job.Options),
+		Status:          job.Status,
+		ProgressDone:    job.ProgressDone,
+		ProgressTotal:   job.ProgressTotal,
+		CancelRequested: job.CancelRequested,
+		ImportedCount:   job.ImportedCount,
+		Error:           job.Error,
+		CreatedAt:       job.CreatedAt,
+		StartedAt:       job.StartedAt,
+		FinishedAt:      job.FinishedAt,
+	}
+	if job.ProgressTotal > 0 {
+		response.ProgressPercent = job.ProgressDone * 100 / job.ProgressTotal
+	}
+	if job.Status == models.ImportJobStatusSucceeded {
+		response.ProgressPercent = 100
+	}
+	if job.Report != "" {
+		var report web.ImportReport
+		helpers.PanicIfError(json.Unmarshal([]byte(job.Report), &report))
+		response.Report = &report
+	}
+	return response
+}
@@ -0,0 +1,265 @@
+package importjob_test
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"testing"
+
+	"github.com/malikabdulaziz/tmn-backend/exceptions"
+	"github.com/malikabdulaziz/tmn-backend/helpers"
+	"github.com/malikabdulaziz/tmn-backend/importer"
+	"github.com/malikabdulaziz/tmn-backend/models"
+	serviceCategory "github.com/malikabdulaziz/tmn-backend/services/category"
+	serviceImportJob "github.com/malikabdulaziz/tmn-backend/services/importjob"
+	"github.com/malikabdulaziz/tmn-backend/testutil"
+	"github.com/malikabdulaziz/tmn-backend/testutil/mocks"
+	webImportJob "github.com/malikabdulaziz/tmn-backend/web/importjob"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// newImportJobService wires only the category import; jobs of other kinds are not exercised here
+func newImportJobService(db *sql.DB, repo *mocks.MockRepositoryImportJob, categoryRepo *mocks.MockRepositoryCategory) serviceImportJob.ServiceImportJobInterface {
+	return serviceImportJob.NewServiceImportJobImpl(db, repo, nil, nil, nil, serviceCategory.NewServiceCategoryImpl(db, categoryRepo, nil), nil, nil, nil)
+}
+
+func userContext(userId string) context.Context {
+	return context.WithValue(context.Background(), helpers.ContextKey("userId"), userId)
+}
+
+func queuedCategoryJob(csvData string) models.ImportJob {
+	return models.ImportJob{
+		Id:        7,
+		Kind:      models.ImportJobKindCategory,
+		FileType:  "csv",
+		FileBytes: []byte(csvData),
+		Options:   `{"on_error":"abort"}`,
+		Status:    models.ImportJobStatusRunning,
+	}
+}
+
+// --- Enqueue ---
+
+func TestImportJobEnqueue_StoresNormalizedOptions(t *testing.T) {
+	db, sqlMock := testutil.NewMockDB(t)
+	repo := &mocks.MockRepositoryImportJob{}
+	svc := newImportJobService(db, repo, &mocks.MockRepositoryCategory{})
+
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectCommit()
+
+	repo.On("Create", mock.Anything, mock.AnythingOfType("*sql.Tx"), mock.MatchedBy(func(job models.ImportJob) bool {
+		var options webImportJob.ImportJobOptions
+		json.Unmarshal([]byte(job.Options), &options)
+		return job.Kind == models.ImportJobKindPOI && job.FileType == "xlsx" &&
+			options.Mode == "replace" && options.OnError == importer.OnErrorSkip &&
+			job.CreatedBy != nil && *job.CreatedBy == 4
+	})).Return(models.ImportJob{Id: 1, Kind: models.ImportJobKindPOI, Status: models.ImportJobStatusQueued, Options: `{"mode":"replace","on_error":"skip"}`}, nil)
+
+	response := svc.Enqueue(userContext("4"), "POI", []byte("file"), "XLSX", webImportJob.ImportJobOptions{OnError: "skip"})
+
+	assert.Equal(t, 1, response.Id)
+	assert.Equal(t, models.ImportJobStatusQueued, response.Status)
+	assert.Equal(t, "replace", response.Options.Mode)
+	repo.AssertExpectations(t)
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+}
+
+func TestImportJobEnqueue_UnknownKind(t *testing.T) {
+	db, _ := testutil.NewMockDB(t)
+	repo := &mocks.MockRepositoryImportJob{}
+	svc := newImportJobService(db, repo, &mocks.MockRepositoryCategory{})
+
+	assert.PanicsWithValue(t,
+		exceptions.NewBadRequestError("Unsupported import kind: buildings. Use poi, sales_package, building_restriction, category, sub_category, mother_brand or branch."),
+		func() {
+			svc.Enqueue(context.Background(), "buildings", []byte("file"), "csv", webImportJob.ImportJobOptions{})
+		})
+	repo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything, mock.Anything)
+}
+
+// --- RunNext ---
+
+func TestImportJobRunNext_EmptyQueue(t *testing.T) {
+	db, sqlMock := testutil.NewMockDB(t)
+	repo := &mocks.MockRepositoryImportJob{}
+	svc := newImportJobService(db, repo, &mocks.MockRepositoryCategory{})
+
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectRollback()
+	repo.On("ClaimNext", mock.Anything, mock.AnythingOfType("*sql.Tx")).Return(models.ImportJob{}, sql.ErrNoRows)
+
+	ran, err := svc.RunNext(context.Background())
+
+	assert.NoError(t, err)
+	assert.False(t, ran)
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+}
+
+func TestImportJobRunNext_Succeeds(t *testing.T) {
+	db, sqlMock := testutil.NewMockDB(t)
+	repo := &mocks.MockRepositoryImportJob{}
+	categoryRepo := &mocks.MockRepositoryCategory{}
+	svc := newImportJobService(db, repo, categoryRepo)
+
+	sqlMock.ExpectBegin() // claim
+	sqlMock.ExpectCommit()
+	sqlMock.ExpectBegin() // import
+	sqlMock.ExpectBegin() // progress 0/1
+	sqlMock.ExpectCommit()
+	sqlMock.ExpectBegin() // progress 1/1
+	sqlMock.ExpectCommit()
+	sqlMock.ExpectCommit() // import
+	sqlMock.ExpectBegin()  // finish
+	sqlMock.ExpectCommit()
+
+	job := queuedCategoryJob("Name\nRetail\n")
+	uploader := 4
+	job.CreatedBy = &uploader
+	repo.On("ClaimNext", mock.Anything, mock.AnythingOfType("*sql.Tx")).Return(job, nil)
+	repo.On("UpdateProgress", mock.Anything, mock.AnythingOfType("*sql.Tx"), 7, 0, 1).Return(false, nil)
+	repo.On("UpdateProgress", mock.Anything, mock.AnythingOfType("*sql.Tx"), 7, 1, 1).Return(false, nil)
+	// the import runs as the user who uploaded the file
+	runsAsUploader := mock.MatchedBy(func(ctx context.Context) bool { return helpers.UserIdFromContext(ctx) == 4 })
+	categoryRepo.On("FindByName", runsAsUploader, mock.AnythingOfType("*sql.Tx"), "Retail").Return(models.Category{}, sql.ErrNoRows)
+	categoryRepo.On("Create", mock.Anything, mock.AnythingOfType("*sql.Tx"), mock.Anything).Return(models.Category{Id: 1, Name: "Retail"}, nil)
+	repo.On("Finish", mock.Anything, mock.AnythingOfType("*sql.Tx"), mock.MatchedBy(func(job models.ImportJob) bool {
+		return job.Id == 7 && job.Status == models.ImportJobStatusSucceeded && job.ImportedCount == 1 && job.Error == "" && job.Report != ""
+	})).Return(nil)
+
+	ran, err := svc.RunNext(context.Background())
+
+	assert.NoError(t, err)
+	assert.True(t, ran)
+	repo.AssertExpectations(t)
+	categoryRepo.AssertExpectations(t)
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+}
+
+func TestImportJobRunNext_CancelRollsBack(t *testing.T) {
+	db, sqlMock := testutil.NewMockDB(t)
+	repo := &mocks.MockRepositoryImportJob{}
+	categoryRepo := &mocks.MockRepositoryCategory{}
+	svc := newImportJobService(db, repo, categoryRepo)
+
+	sqlMock.ExpectBegin() // claim
+	sqlMock.ExpectCommit()
+	sqlMock.ExpectBegin() // import
+	sqlMock.ExpectBegin() // progress 0/1
+	sqlMock.ExpectCommit()
+	sqlMock.ExpectRollback() // import
+	sqlMock.ExpectBegin()    // finish
+	sqlMock.ExpectCommit()
+
+	repo.On("ClaimNext", mock.Anything, mock.AnythingOfType("*sql.Tx")).Return(queuedCategoryJob("Name\nRetail\n"), nil)
+	repo.On("UpdateProgress", mock.Anything, mock.AnythingOfType("*sql.Tx"), 7, 0, 1).Return(true, nil)
+	repo.On("Finish", mock.Anything, mock.AnythingOfType("*sql.Tx"), mock.MatchedBy(func(job models.ImportJob) bool {
+		return job.Status == models.ImportJobStatusCancelled && job.ImportedCount == 0
+	})).Return(nil)
+
+	ran, err := svc.RunNext(context.Background())
+
+	assert.NoError(t, err)
+	assert.True(t, ran)
+	categoryRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything, mock.Anything)
+	repo.AssertExpectations(t)
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+}
+
+func TestImportJobRunNext_StaleJobRollsBack(t *testing.T) {
+	db, sqlMock := testutil.NewMockDB(t)
+	repo := &mocks.MockRepositoryImportJob{}
+	categoryRepo := &mocks.MockRepositoryCategory{}
+	svc := newImportJobService(db, repo, categoryRepo)
+
+	sqlMock.ExpectBegin() // claim
+	sqlMock.ExpectCommit()
+	sqlMock.ExpectBegin() // import
+	sqlMock.ExpectBegin() // progress 0/1
+	sqlMock.ExpectRollback()
+	sqlMock.ExpectRollback() // import
+	sqlMock.ExpectBegin()    // finish
+	sqlMock.ExpectCommit()
+
+	repo.On("ClaimNext", mock.Anything, mock.AnythingOfType("*sql.Tx")).Return(queuedCategoryJob("Name\nRetail\n"), nil)
+	// FailStale already failed the job
+	repo.On("UpdateProgress", mock.Anything, mock.AnythingOfType("*sql.Tx"), 7, 0, 1).Return(false, sql.ErrNoRows)
+	repo.On("Finish", mock.Anything, mock.AnythingOfType("*sql.Tx"), mock.MatchedBy(func(job models.ImportJob) bool {
+		return job.Status == models.ImportJobStatusFailed && job.ImportedCount == 0
+	})).Return(nil)
+
+	ran, err := svc.RunNext(context.Background())
+
+	assert.NoError(t, err)
+	assert.True(t, ran)
+	categoryRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything, mock.Anything)
+	repo.AssertExpectations(t)
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+}
+
+func TestImportJobRunNext_RowErrorsFailJob(t *testing.T) {
+	db, sqlMock := testutil.NewMockDB(t)
+	repo := &mocks.MockRepositoryImportJob{}
+	svc := newImportJobService(db, repo, &mocks.MockRepositoryCategory{})
+
+	sqlMock.ExpectBegin() // claim
+	sqlMock.ExpectCommit()
+	sqlMock.ExpectBegin() // import
+	sqlMock.ExpectRollback()
+	sqlMock.ExpectBegin() // finish
+	sqlMock.ExpectCommit()
+
+	repo.On("ClaimNext", mock.Anything, mock.AnythingOfType("*sql.Tx")).Return(queuedCategoryJob("Name,Note\n,x\nRetail,\n"), nil)
+	repo.On("Finish", mock.Anything, mock.AnythingOfType("*sql.Tx"), mock.MatchedBy(func(job models.ImportJob) bool {
+		var report struct {
+			Errors []struct{ Row int } `json:"errors"`
+		}
+		json.Unmarshal([]byte(job.Report), &report)
+		return job.Status == models.ImportJobStatusFailed && job.Error != "" &&
+			len(report.Errors) == 1 && report.Errors[0].Row == 2
+	})).Return(nil)
+
+	ran, err := svc.RunNext(context.Background())
+
+	assert.NoError(t, err)
+	assert.True(t, ran)
+	repo.AssertExpectations(t)
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+}
+
+// --- Cancel / FindById ---
+
+func TestImportJobCancel_Finished(t *testing.T) {
+	db, sqlMock := testutil.NewMockDB(t)
+	repo := &mocks.MockRepositoryImportJob{}
+	svc := newImportJobService(db, repo, &mocks.MockRepositoryCategory{})
+
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectRollback()
+
+	repo.On("FindById", mock.Anything, mock.AnythingOfType("*sql.Tx"), 7).Return(models.ImportJob{Id: 7, Status: models.ImportJobStatusSucceeded}, nil)
+	repo.On("RequestCancel", mock.Anything, mock.AnythingOfType("*sql.Tx"), 7).Return(models.ImportJob{}, sql.ErrNoRows)
+
+	assert.PanicsWithValue(t, exceptions.NewBadRequestError("Import job has already finished"), func() {
+		svc.Cancel(context.Background(), 7)
+	})
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+}
+
+func TestImportJobFindById_OtherUser(t *testing.T) {
+	db, sqlMock := testutil.NewMockDB(t)
+	repo := &mocks.MockRepositoryImportJob{}
+	svc := newImportJobService(db, repo, &mocks.MockRepositoryCategory{})
+
+	owner := 4
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectRollback()
+
+	repo.On("FindById", mock.Anything, mock.AnythingOfType("*sql.Tx"), 7).Return(models.ImportJob{Id: 7, CreatedBy: &owner}, nil)
+
+	assert.PanicsWithValue(t, exceptions.NewNotFoundError("Import job not found"), func() {
+		svc.FindById(userContext("5"), 7)
+	})
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+}
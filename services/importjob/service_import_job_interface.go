@@ -0,0 +1,15 @@
+package importjob
+
+import (
+	"context"
+
+	webImportJob "github.com/malikabdulaziz/tmn-backend/web/importjob"
+)
+
+type ServiceImportJobInterface interface {
+	Enqueue(ctx context.Context, kind string, fileBytes []byte, fileType string, options webImportJob.ImportJobOptions) webImportJob.ImportJobResponse
+	FindById(ctx context.Context, id int) webImportJob.ImportJobResponse
+	Cancel(ctx context.Context, id int) webImportJob.ImportJobResponse
+	RunNext(ctx context.Context) (bool, error)
+	FailStale(ctx context.Context) (int, error)
+}
@@ -0,0 +1,100 @@
+package masterdata
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/malikabdulaziz/tmn-backend/exceptions"
+	"github.com/malikabdulaziz/tmn-backend/helpers"
+	"github.com/malikabdulaziz/tmn-backend/models"
+	repositoriesMasterData "github.com/malikabdulaziz/tmn-backend/repositories/masterdata"
+	webMasterData "github.com/malikabdulaziz/tmn-backend/web/masterdata"
+)
+
+type ServiceMasterDataImpl struct {
+	DB                            *sql.DB
+	RepositoryMasterDataInterface repositoriesMasterData.RepositoryMasterDataInterface
+}
+
+func NewServiceMasterDataImpl(db *sql.DB, repositoryMasterData repositoriesMasterData.RepositoryMasterDataInterface) ServiceMasterDataInterface {
+	return &ServiceMasterDataImpl{
+		DB:                            db,
+		RepositoryMasterDataInterface: repositoryMasterData,
+	}
+}
+
+// Merge folds the source entries into the target in one transaction: everything pointing at a
+// source is repointed to the target, the sources are deleted and their names are kept as aliases
+// so imports still using them resolve to the target. Sub-categories and branches may only be
+// merged when they do not belong to different parents.
+func (s *ServiceMasterDataImpl) Merge(ctx context.Context, masterDataType string, targetId int, request webMasterData.MergeMasterDataRequest) webMasterData.MasterDataMergeResponse {
+	source := findSource(masterDataType)
+
+	sourceIds := []int{}
+	seen := map[int]bool{}
+	for _, id := range request.SourceIds {
+		if id == targetId {
+			panic(exceptions.NewBadRequest("a " + source.Label + " cannot be merged into itself"))
+		}
+		if !seen[id] {
+			seen[id] = true
+			sourceIds = append(sourceIds, id)
+		}
+	}
+
+	tx, err := s.DB.Begin()
+	helpers.PanicIfError(err)
+	defer helpers.CommitOrRollback(tx)
+
+	target := s.findEntry(ctx, tx, source, targetId)
+	entries := make([]models.MasterDataEntry, len(sourceIds))
+	for i, id := range sourceIds {
+		entry := s.findEntry(ctx, tx, source, id)
+		if target.ParentId != nil && entry.ParentId != nil && *target.ParentId != *entry.ParentId {
+			panic(exceptions.NewBadRequest(fmt.Sprintf("%s belongs to %s %s, but %s belongs to %s",
+				entry.Name, source.ParentLabel, entry.ParentName, target.Name, target.ParentName)))
+		}
+		entries[i] = entry
+	}
+
+	response := webMasterData.MasterDataMergeResponse{
+		Type:      source.Type,
+		Id:        target.Id,
+		Name:      target.Name,
+		MergedIds: sourceIds,
+		Aliases:   make([]string, len(entries)),
+	}
+	createdBy := helpers.OptionalUserIdFromContext(ctx)
+	for i, entry := range entries {
+		repointed, err := s.RepositoryMasterDataInterface.Repoint(ctx, tx, source, entry.Id, target.Id)
+		helpers.PanicIfError(err)
+		helpers.PanicIfError(s.RepositoryMasterDataInterface.Delete(ctx, tx, source, entry.Id))
+		helpers.PanicIfError(s.RepositoryMasterDataInterface.SaveAlias(ctx, tx, source, entry.Name, target.Id, createdBy))
+		response.Aliases[i] = entry.Name
+		response.Repointed += repointed
+	}
+	return response
+}
+
+func (s *ServiceMasterDataImpl) findEntry(ctx context.Context, tx *sql.Tx, source models.MergeSource, id int) models.MasterDataEntry {
+	entry, err := s.RepositoryMasterDataInterface.FindEntry(ctx, tx, source, id)
+	if err == sql.ErrNoRows {
+		panic(exceptions.NewNotFoundError(fmt.Sprintf("%s %d not found", source.Label, id)))
+	}
+	helpers.PanicIfError(err)
+	return entry
+}
+
+func findSource(masterDataType string) models.MergeSource {
+	source, ok := models.FindMergeSource(masterDataType)
+	if !ok {
+		types := make([]string, len(models.MergeSources))
+		for i, s := range models.MergeSources {
+			types[i] = s.Type
+		}
+		panic(exceptions.NewBadRequest("type must be one of " + strings.Join(types, ", ")))
+	}
+	return source
+}
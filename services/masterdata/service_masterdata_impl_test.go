@@ -0,0 +1,144 @@
+package masterdata_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/malikabdulaziz/tmn-backend/exceptions"
+	"github.com/malikabdulaziz/tmn-backend/helpers"
+	"github.com/malikabdulaziz/tmn-backend/models"
+	serviceMasterData "github.com/malikabdulaziz/tmn-backend/services/masterdata"
+	"github.com/malikabdulaziz/tmn-backend/testutil"
+	"github.com/malikabdulaziz/tmn-backend/testutil/mocks"
+	webMasterData "github.com/malikabdulaziz/tmn-backend/web/masterdata"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func userContext(userId string) context.Context {
+	return context.WithValue(context.Background(), helpers.ContextKey("userId"), userId)
+}
+
+func sourceOf(masterDataType string) models.MergeSource {
+	source, _ := models.FindMergeSource(masterDataType)
+	return source
+}
+
+func TestMasterDataMerge_RepointsDeletesAndRecordsAliases(t *testing.T) {
+	db, sqlMock := testutil.NewMockDB(t)
+	repo := &mocks.MockRepositoryMasterData{}
+	svc := serviceMasterData.NewServiceMasterDataImpl(db, repo)
+
+	source := sourceOf(models.MasterDataTypeCategory)
+	userId := 7
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectCommit()
+	repo.On("FindEntry", mock.Anything, mock.AnythingOfType("*sql.Tx"), source, 1).Return(models.MasterDataEntry{Id: 1, Name: "Food & Beverage"}, nil)
+	repo.On("FindEntry", mock.Anything, mock.AnythingOfType("*sql.Tx"), source, 2).Return(models.MasterDataEntry{Id: 2, Name: "F&B"}, nil)
+	repo.On("FindEntry", mock.Anything, mock.AnythingOfType("*sql.Tx"), source, 3).Return(models.MasterDataEntry{Id: 3, Name: "F & B"}, nil)
+	repo.On("Repoint", mock.Anything, mock.AnythingOfType("*sql.Tx"), source, 2, 1).Return(4, nil)
+	repo.On("Repoint", mock.Anything, mock.AnythingOfType("*sql.Tx"), source, 3, 1).Return(2, nil)
+	repo.On("Delete", mock.Anything, mock.AnythingOfType("*sql.Tx"), source, 2).Return(nil)
+	repo.On("Delete", mock.Anything, mock.AnythingOfType("*sql.Tx"), source, 3).Return(nil)
+	repo.On("SaveAlias", mock.Anything, mock.AnythingOfType("*sql.Tx"), source, "F&B", 1, &userId).Return(nil)
+	repo.On("SaveAlias", mock.Anything, mock.AnythingOfType("*sql.Tx"), source, "F & B", 1, &userId).Return(nil)
+
+	resp := svc.Merge(userContext("7"), models.MasterDataTypeCategory, 1, webMasterData.MergeMasterDataRequest{SourceIds: []int{2, 3, 2}})
+
+	assert.Equal(t, webMasterData.MasterDataMergeResponse{
+		Type:      models.MasterDataTypeCategory,
+		Id:        1,
+		Name:      "Food & Beverage",
+		MergedIds: []int{2, 3},
+		Aliases:   []string{"F&B", "F & B"},
+		Repointed: 6,
+	}, resp)
+	repo.AssertExpectations(t)
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+}
+
+func TestMasterDataMerge_InvalidType(t *testing.T) {
+	db, _ := testutil.NewMockDB(t)
+	repo := &mocks.MockRepositoryMasterData{}
+	svc := serviceMasterData.NewServiceMasterDataImpl(db, repo)
+
+	assert.PanicsWithValue(t, exceptions.NewBadRequest("type must be one of category, sub_category, mother_brand, branch"), func() {
+		svc.Merge(userContext("7"), "poi", 1, webMasterData.MergeMasterDataRequest{SourceIds: []int{2}})
+	})
+	repo.AssertNotCalled(t, "FindEntry", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestMasterDataMerge_IntoItself(t *testing.T) {
+	db, _ := testutil.NewMockDB(t)
+	repo := &mocks.MockRepositoryMasterData{}
+	svc := serviceMasterData.NewServiceMasterDataImpl(db, repo)
+
+	assert.PanicsWithValue(t, exceptions.NewBadRequest("a mother brand cannot be merged into itself"), func() {
+		svc.Merge(userContext("7"), models.MasterDataTypeMotherBrand, 4, webMasterData.MergeMasterDataRequest{SourceIds: []int{5, 4}})
+	})
+	repo.AssertNotCalled(t, "FindEntry", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestMasterDataMerge_SourceNotFound(t *testing.T) {
+	db, sqlMock := testutil.NewMockDB(t)
+	repo := &mocks.MockRepositoryMasterData{}
+	svc := serviceMasterData.NewServiceMasterDataImpl(db, repo)
+
+	source := sourceOf(models.MasterDataTypeBranch)
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectRollback()
+	repo.On("FindEntry", mock.Anything, mock.AnythingOfType("*sql.Tx"), source, 1).Return(models.MasterDataEntry{Id: 1, Name: "Jakarta"}, nil)
+	repo.On("FindEntry", mock.Anything, mock.AnythingOfType("*sql.Tx"), source, 9).Return(models.MasterDataEntry{}, sql.ErrNoRows)
+
+	assert.PanicsWithValue(t, exceptions.NewNotFoundError("branch 9 not found"), func() {
+		svc.Merge(userContext("7"), models.MasterDataTypeBranch, 1, webMasterData.MergeMasterDataRequest{SourceIds: []int{9}})
+	})
+	repo.AssertNotCalled(t, "Repoint", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+}
+
+func TestMasterDataMerge_DifferentParents(t *testing.T) {
+	db, sqlMock := testutil.NewMockDB(t)
+	repo := &mocks.MockRepositoryMasterData{}
+	svc := serviceMasterData.NewServiceMasterDataImpl(db, repo)
+
+	source := sourceOf(models.MasterDataTypeSubCategory)
+	retail, automotive := 1, 3
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectRollback()
+	repo.On("FindEntry", mock.Anything, mock.AnythingOfType("*sql.Tx"), source, 10).
+		Return(models.MasterDataEntry{Id: 10, Name: "Coffee Shop", ParentId: &retail, ParentName: "Retail"}, nil)
+	repo.On("FindEntry", mock.Anything, mock.AnythingOfType("*sql.Tx"), source, 11).
+		Return(models.MasterDataEntry{Id: 11, Name: "Coffee", ParentId: &automotive, ParentName: "Automotive"}, nil)
+
+	assert.PanicsWithValue(t, exceptions.NewBadRequest("Coffee belongs to category Automotive, but Coffee Shop belongs to Retail"), func() {
+		svc.Merge(userContext("7"), models.MasterDataTypeSubCategory, 10, webMasterData.MergeMasterDataRequest{SourceIds: []int{11}})
+	})
+	repo.AssertNotCalled(t, "Repoint", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+}
+
+func TestMasterDataMerge_AdoptsSourceWithoutParent(t *testing.T) {
+	db, sqlMock := testutil.NewMockDB(t)
+	repo := &mocks.MockRepositoryMasterData{}
+	svc := serviceMasterData.NewServiceMasterDataImpl(db, repo)
+
+	source := sourceOf(models.MasterDataTypeSubCategory)
+	retail := 1
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectCommit()
+	repo.On("FindEntry", mock.Anything, mock.AnythingOfType("*sql.Tx"), source, 10).
+		Return(models.MasterDataEntry{Id: 10, Name: "Coffee Shop", ParentId: &retail, ParentName: "Retail"}, nil)
+	repo.On("FindEntry", mock.Anything, mock.AnythingOfType("*sql.Tx"), source, 12).Return(models.MasterDataEntry{Id: 12, Name: "Coffeeshop"}, nil)
+	repo.On("Repoint", mock.Anything, mock.AnythingOfType("*sql.Tx"), source, 12, 10).Return(3, nil)
+	repo.On("Delete", mock.Anything, mock.AnythingOfType("*sql.Tx"), source, 12).Return(nil)
+	repo.On("SaveAlias", mock.Anything, mock.AnythingOfType("*sql.Tx"), source, "Coffeeshop", 10, (*int)(nil)).Return(nil)
+
+	resp := svc.Merge(context.Background(), models.MasterDataTypeSubCategory, 10, webMasterData.MergeMasterDataRequest{SourceIds: []int{12}})
+
+	assert.Equal(t, []string{"Coffeeshop"}, resp.Aliases)
+	assert.Equal(t, 3, resp.Repointed)
+	repo.AssertExpectations(t)
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+}
@@ -0,0 +1,11 @@
+package masterdata
+
+import (
+	"context"
+
+	webMasterData "github.com/malikabdulaziz/tmn-backend/web/masterdata"
+)
+
+type ServiceMasterDataInterface interface {
+	Merge(ctx context.Context, masterDataType string, targetId int, request webMasterData.MergeMasterDataRequest) webMasterData.MasterDataMergeResponse
+}
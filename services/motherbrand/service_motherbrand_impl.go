@@ -1,33 +1,38 @@
 package motherbrand
 
 import (
-	"bytes"
 	"context"
 	"database/sql"
-	"encoding/csv"
-	"io"
-	"strings"
 
 	"github.com/malikabdulaziz/tmn-backend/exceptions"
 	"github.com/malikabdulaziz/tmn-backend/helpers"
+	"github.com/malikabdulaziz/tmn-backend/importer"
 	"github.com/malikabdulaziz/tmn-backend/models"
+	repositoriesBranch "github.com/malikabdulaziz/tmn-backend/repositories/branch"
 	repositoriesMotherBrand "github.com/malikabdulaziz/tmn-backend/repositories/motherbrand"
+	"github.com/malikabdulaziz/tmn-backend/web"
 	webMotherBrand "github.com/malikabdulaziz/tmn-backend/web/motherbrand"
 	"github.com/xuri/excelize/v2"
 )
 
+// motherBrandImportColumns maps each import column to its accepted header spellings
+var motherBrandImportColumns = map[string][]string{"name": {"name"}}
+
 type ServiceMotherBrandImpl struct {
-	DB                               *sql.DB
+	DB                             *sql.DB
 	RepositoryMotherBrandInterface repositoriesMotherBrand.RepositoryMotherBrandInterface
+	RepositoryBranchInterface      repositoriesBranch.RepositoryBranchInterface
 }
 
 func NewServiceMotherBrandImpl(
 	db *sql.DB,
 	repoMotherBrand repositoriesMotherBrand.RepositoryMotherBrandInterface,
+	repoBranch repositoriesBranch.RepositoryBranchInterface,
 ) ServiceMotherBrandInterface {
 	return &ServiceMotherBrandImpl{
-		DB:                               db,
+		DB:                             db,
 		RepositoryMotherBrandInterface: repoMotherBrand,
+		RepositoryBranchInterface:      repoBranch,
 	}
 }
 
@@ -72,6 +77,38 @@ func (s *ServiceMotherBrandImpl) FindById(ctx context.Context, id int) webMother
 	return motherBrandModelToResponse(motherBrand)
 }
 
+// FindTree returns every mother brand with its branches. A branch whose mother brand is in
+// the trash is left out, since it cannot be combined with any other mother brand.
+func (s *ServiceMotherBrandImpl) FindTree(ctx context.Context) webMotherBrand.MotherBrandTreeResponse {
+	tx, err := s.DB.Begin()
+	helpers.PanicIfError(err)
+	defer helpers.CommitOrRollback(tx)
+
+	motherBrands, err := s.RepositoryMotherBrandInterface.FindAllDropdown(ctx, tx)
+	helpers.PanicIfError(err)
+	branches, err := s.RepositoryBranchInterface.FindAllDropdown(ctx, tx)
+	helpers.PanicIfError(err)
+
+	tree := webMotherBrand.MotherBrandTreeResponse{
+		MotherBrands:       make([]webMotherBrand.MotherBrandTreeNode, len(motherBrands)),
+		UnassignedBranches: []webMotherBrand.MotherBrandTreeBranch{},
+	}
+	nodeIndex := map[int]int{}
+	for i, parent := range motherBrands {
+		tree.MotherBrands[i] = webMotherBrand.MotherBrandTreeNode{Id: parent.Id, Name: parent.Name, Branches: []webMotherBrand.MotherBrandTreeBranch{}}
+		nodeIndex[parent.Id] = i
+	}
+	for _, child := range branches {
+		leaf := webMotherBrand.MotherBrandTreeBranch{Id: child.Id, Name: child.Name}
+		if child.MotherBrandId == nil {
+			tree.UnassignedBranches = append(tree.UnassignedBranches, leaf)
+		} else if i, ok := nodeIndex[*child.MotherBrandId]; ok {
+			tree.MotherBrands[i].Branches = append(tree.MotherBrands[i].Branches, leaf)
+		}
+	}
+	return tree
+}
+
 func (s *ServiceMotherBrandImpl) Update(ctx context.Context, request webMotherBrand.UpdateMotherBrandRequest, id int) webMotherBrand.MotherBrandResponse {
 	tx, err := s.DB.Begin()
 	helpers.PanicIfError(err)
@@ -99,42 +136,33 @@ func (s *ServiceMotherBrandImpl) Delete(ctx context.Context, id int) {
 		panic(exceptions.NewNotFoundError("mother brand not found"))
 	}
 	helpers.PanicIfError(err)
-	err = s.RepositoryMotherBrandInterface.Delete(ctx, tx, id)
+	err = s.RepositoryMotherBrandInterface.Delete(ctx, tx, id, helpers.OptionalUserIdFromContext(ctx))
 	helpers.PanicIfError(err)
 }
 
-func (s *ServiceMotherBrandImpl) Import(ctx context.Context, fileBytes []byte, fileType string) []webMotherBrand.MotherBrandResponse {
+func (s *ServiceMotherBrandImpl) Import(ctx context.Context, fileBytes []byte, fileType string, onError string) ([]webMotherBrand.MotherBrandResponse, web.ImportReport) {
 	tx, err := s.DB.Begin()
 	helpers.PanicIfError(err)
 	defer helpers.CommitOrRollback(tx)
 
-	var rows [][]string
-	switch strings.ToLower(fileType) {
-	case "xlsx":
-		rows, err = mbParseXLSX(fileBytes)
-		helpers.PanicIfError(err)
-	case "csv":
-		rows, err = mbParseCSV(fileBytes)
-		helpers.PanicIfError(err)
-	default:
-		panic(exceptions.NewBadRequestError("Unsupported file type. Use xlsx or csv."))
-	}
-
-	if len(rows) < 2 {
-		panic(exceptions.NewBadRequestError("File must contain a header row and at least one data row."))
-	}
+	sheet := importer.Read(fileBytes, fileType, motherBrandImportColumns)
+	sheet.RequireColumns("name")
 
-	header := rows[0]
-	colMap := mbMapHeaderColumns(header)
-
-	if _, exists := colMap["name"]; !exists {
-		panic(exceptions.NewBadRequestError("Missing required column: name"))
+	report := importer.NewReport(sheet, onError)
+	for i := range sheet.Rows {
+		if sheet.IsBlank(i) {
+			continue
+		}
+		report.RequireText(importer.RowNumber(i), "name", 255)
 	}
+	report.Check()
 
+	tracker := importer.NewTracker(ctx, len(sheet.Rows))
 	var responses []webMotherBrand.MotherBrandResponse
-	for _, row := range rows[1:] {
-		name := mbGetColValue(row, colMap, "name")
-		if name == "" {
+	for i := range sheet.Rows {
+		tracker.Advance(1)
+		name := sheet.Value(i, "name")
+		if sheet.IsBlank(i) || report.Rejected(importer.RowNumber(i)) {
 			continue
 		}
 
@@ -150,7 +178,7 @@ func (s *ServiceMotherBrandImpl) Import(ctx context.Context, fileBytes []byte, f
 		}
 	}
 
-	return responses
+	return responses, report.Result()
 }
 
 func (s *ServiceMotherBrandImpl) Export(ctx context.Context, search string) ([]byte, error) {
@@ -168,6 +196,20 @@ func (s *ServiceMotherBrandImpl) Export(ctx context.Context, search string) ([]b
 	return buildMotherBrandExcel(list)
 }
 
+// ImportTemplate returns a blank mother brand import xlsx
+func (s *ServiceMotherBrandImpl) ImportTemplate(ctx context.Context) ([]byte, error) {
+	return importer.BuildTemplate(importer.Template{
+		SheetName: "Mother Brands",
+		Columns: []importer.TemplateColumn{
+			{Key: "name", Header: "Name", Description: "Name of the mother brand.", Required: true},
+		},
+		Instructions: []string{
+			"Fill one mother brand per row on the Mother Brands sheet and keep the header row as it is.",
+			"Names that already exist, or were merged into an existing entry, are left as they are; new names are created.",
+		},
+	}, motherBrandImportColumns)
+}
+
 func motherBrandModelToResponse(c models.MotherBrand) webMotherBrand.MotherBrandResponse {
 	return webMotherBrand.MotherBrandResponse{
 		Id:        c.Id,
@@ -177,53 +219,6 @@ func motherBrandModelToResponse(c models.MotherBrand) webMotherBrand.MotherBrand
 	}
 }
 
-// --- Import helpers ---
-
-func mbParseXLSX(fileBytes []byte) ([][]string, error) {
-	f, err := excelize.OpenReader(bytes.NewReader(fileBytes))
-	if err != nil {
-		return nil, err
-	}
-	defer f.Close()
-	sheetName := f.GetSheetName(0)
-	return f.GetRows(sheetName)
-}
-
-func mbParseCSV(fileBytes []byte) ([][]string, error) {
-	reader := csv.NewReader(bytes.NewReader(fileBytes))
-	var rows [][]string
-	for {
-		record, err := reader.Read()
-		if err == io.EOF {
-			break
-		}
-		if err != nil {
-			return nil, err
-		}
-		rows = append(rows, record)
-	}
-	return rows, nil
-}
-
-func mbMapHeaderColumns(header []string) map[string]int {
-	colMap := make(map[string]int)
-	for i, h := range header {
-		normalized := strings.ToLower(strings.TrimSpace(h))
-		if normalized == "name" {
-			colMap["name"] = i
-		}
-	}
-	return colMap
-}
-
-func mbGetColValue(row []string, colMap map[string]int, key string) string {
-	idx, exists := colMap[key]
-	if !exists || idx >= len(row) {
-		return ""
-	}
-	return strings.TrimSpace(row[idx])
-}
-
 // --- Export helpers ---
 
 func buildMotherBrandExcel(list []models.MotherBrand) ([]byte, error) {
@@ -6,6 +6,8 @@ import (
 	"testing"
 
 	"github.com/malikabdulaziz/tmn-backend/exceptions"
+	"github.com/malikabdulaziz/tmn-backend/helpers"
+	"github.com/malikabdulaziz/tmn-backend/importer"
 	"github.com/malikabdulaziz/tmn-backend/models"
 	serviceMotherBrand "github.com/malikabdulaziz/tmn-backend/services/motherbrand"
 	"github.com/malikabdulaziz/tmn-backend/testutil"
@@ -16,7 +18,7 @@ import (
 )
 
 func newMotherBrandService(db *sql.DB, repo *mocks.MockRepositoryMotherBrand) serviceMotherBrand.ServiceMotherBrandInterface {
-	return serviceMotherBrand.NewServiceMotherBrandImpl(db, repo)
+	return serviceMotherBrand.NewServiceMotherBrandImpl(db, repo, &mocks.MockRepositoryBranch{})
 }
 
 func newMotherBrandModel(id int, name string) models.MotherBrand {
@@ -93,6 +95,34 @@ func TestMotherBrandFindById_NotFound(t *testing.T) {
 	assert.NoError(t, sqlMock.ExpectationsWereMet())
 }
 
+// --- FindTree ---
+
+func TestMotherBrandFindTree_GroupsBranchesUnderTheirMotherBrand(t *testing.T) {
+	db, sqlMock := testutil.NewMockDB(t)
+	repo := &mocks.MockRepositoryMotherBrand{}
+	branchRepo := &mocks.MockRepositoryBranch{}
+	svc := serviceMotherBrand.NewServiceMotherBrandImpl(db, repo, branchRepo)
+
+	group := 3
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectCommit()
+	repo.On("FindAllDropdown", mock.Anything, mock.AnythingOfType("*sql.Tx")).
+		Return([]models.MotherBrand{newMotherBrandModel(3, "Kawan Lama Group")}, nil)
+	branchRepo.On("FindAllDropdown", mock.Anything, mock.AnythingOfType("*sql.Tx")).Return([]models.Branch{
+		{Id: 20, Name: "Jakarta Branch", MotherBrandId: &group},
+		{Id: 21, Name: "Surabaya Branch"},
+	}, nil)
+
+	tree := svc.FindTree(context.Background())
+
+	assert.Equal(t, []webMotherBrand.MotherBrandTreeNode{{Id: 3, Name: "Kawan Lama Group",
+		Branches: []webMotherBrand.MotherBrandTreeBranch{{Id: 20, Name: "Jakarta Branch"}}}}, tree.MotherBrands)
+	assert.Equal(t, []webMotherBrand.MotherBrandTreeBranch{{Id: 21, Name: "Surabaya Branch"}}, tree.UnassignedBranches)
+	repo.AssertExpectations(t)
+	branchRepo.AssertExpectations(t)
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+}
+
 // --- Update ---
 
 func TestMotherBrandUpdate_HappyPath(t *testing.T) {
@@ -134,10 +164,12 @@ func TestMotherBrandDelete_HappyPath(t *testing.T) {
 
 	repo.On("FindById", mock.Anything, mock.AnythingOfType("*sql.Tx"), 2).
 		Return(newMotherBrandModel(2, "ToDelete"), nil)
-	repo.On("Delete", mock.Anything, mock.AnythingOfType("*sql.Tx"), 2).
+	repo.On("Delete", mock.Anything, mock.AnythingOfType("*sql.Tx"), 2,
+		mock.MatchedBy(func(deletedBy *int) bool { return deletedBy != nil && *deletedBy == 7 })).
 		Return(nil)
 
-	assert.NotPanics(t, func() { svc.Delete(context.Background(), 2) })
+	ctx := context.WithValue(context.Background(), helpers.ContextKey("userId"), "7")
+	assert.NotPanics(t, func() { svc.Delete(ctx, 2) })
 
 	repo.AssertExpectations(t)
 	assert.NoError(t, sqlMock.ExpectationsWereMet())
@@ -183,7 +215,7 @@ func TestMotherBrandImport_CSV_NewItem(t *testing.T) {
 		mock.MatchedBy(func(c models.MotherBrand) bool { return c.Name == "Nike" }),
 	).Return(created, nil)
 
-	responses := svc.Import(context.Background(), []byte(csvData), "csv")
+	responses, _ := svc.Import(context.Background(), []byte(csvData), "csv", importer.OnErrorAbort)
 
 	assert.Len(t, responses, 1)
 	assert.Equal(t, "Nike", responses[0].Name)
@@ -206,7 +238,7 @@ func TestMotherBrandImport_CSV_ExistingItem(t *testing.T) {
 	repo.On("FindByName", mock.Anything, mock.AnythingOfType("*sql.Tx"), "Nike").
 		Return(existing, nil)
 
-	responses := svc.Import(context.Background(), []byte(csvData), "csv")
+	responses, _ := svc.Import(context.Background(), []byte(csvData), "csv", importer.OnErrorAbort)
 
 	assert.Len(t, responses, 1)
 	assert.Equal(t, 7, responses[0].Id)
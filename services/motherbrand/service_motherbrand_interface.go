@@ -3,6 +3,7 @@ package motherbrand
 import (
 	"context"
 
+	"github.com/malikabdulaziz/tmn-backend/web"
 	webMotherBrand "github.com/malikabdulaziz/tmn-backend/web/motherbrand"
 )
 
@@ -10,8 +11,10 @@ type ServiceMotherBrandInterface interface {
 	Create(ctx context.Context, request webMotherBrand.CreateMotherBrandRequest) webMotherBrand.MotherBrandResponse
 	FindAll(ctx context.Context, request webMotherBrand.MotherBrandRequestFindAll) ([]webMotherBrand.MotherBrandResponse, int)
 	FindById(ctx context.Context, id int) webMotherBrand.MotherBrandResponse
+	FindTree(ctx context.Context) webMotherBrand.MotherBrandTreeResponse
 	Update(ctx context.Context, request webMotherBrand.UpdateMotherBrandRequest, id int) webMotherBrand.MotherBrandResponse
 	Delete(ctx context.Context, id int)
-	Import(ctx context.Context, fileBytes []byte, fileType string) []webMotherBrand.MotherBrandResponse
+	Import(ctx context.Context, fileBytes []byte, fileType string, onError string) ([]webMotherBrand.MotherBrandResponse, web.ImportReport)
 	Export(ctx context.Context, search string) ([]byte, error)
+	ImportTemplate(ctx context.Context) ([]byte, error)
 }
@@ -0,0 +1,445 @@
+package poi
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/malikabdulaziz/tmn-backend/exceptions"
+	"github.com/malikabdulaziz/tmn-backend/helpers"
+	"github.com/malikabdulaziz/tmn-backend/importer"
+	"github.com/malikabdulaziz/tmn-backend/models"
+	servicesGeocoding "github.com/malikabdulaziz/tmn-backend/services/geocoding"
+	webPOI "github.com/malikabdulaziz/tmn-backend/web/poi"
+)
+
+// poiImportPreviewTTLSeconds is how long a preview token can be confirmed
+const poiImportPreviewTTLSeconds = 30 * 60
+
+// outsideIndonesiaReason flags a located point that lies outside Indonesia's bounding box
+const outsideIndonesiaReason = "Coordinate is outside Indonesia"
+
+// coordinateEpsilon is the smallest lat/lng change reported as a moved point (~1cm)
+const coordinateEpsilon = 1e-7
+
+type poiImportPoint struct {
+	row         int
+	poiName     string
+	address     string
+	externalKey string
+	branchName  string
+	latitude    float64
+	longitude   float64
+	located     bool // the coordinate cell held a usable coordinate, or the address was geocoded
+}
+
+type poiImportGroup struct {
+	brand           string
+	categoryName    string
+	subCategoryName string
+	motherBrandName string
+	firstRow        int
+	rows            []int // every accepted Excel row that belongs to this brand group
+	points          []poiImportPoint
+	seenAt          map[string]int // poi_name|address -> first Excel row
+	seenKeyAt       map[string]int // external_key -> first Excel row
+}
+
+// poiImportPlan is the parsed and validated content of an import file. Building it has no side
+// effects. Rows rejected by report are left out of groups.
+type poiImportPlan struct {
+	brandOrder       []string
+	groups           map[string]*poiImportGroup
+	coordinateErrors []webPOI.POIImportCoordinateError
+	report           *importer.Report
+	pointsGeocoded   int
+	addressesFilled  int
+}
+
+// pointCount returns how many points the plan writes; it is the unit of import progress
+func (plan poiImportPlan) pointCount() int {
+	count := 0
+	for _, group := range plan.groups {
+		count += len(group.points)
+	}
+	return count
+}
+
+// poiImportColumns maps each import column to its accepted header spellings
+var poiImportColumns = map[string][]string{
+	"category":     {"category"},
+	"sub_category": {"sub_category", "subcategory"},
+	"mother_brand": {"mother_brand", "motherbrand"},
+	"brand":        {"brand"},
+	"branch":       {"branch"},
+	"poi_name":     {"poi_name", "poiname"},
+	"address":      {"address"},
+	"coordinate":   {"coordinate", "coordinates"},
+	"external_key": {"external_key", "externalkey", "store_code"},
+}
+
+// parsePOIImportFile parses xlsx/csv. Each row is a point; rows are grouped by Brand. The first
+// valid row of each brand sets the POI metadata (category/sub_category/mother_brand). A later row
+// of the same brand that disagrees, or that lists the same POI (by name and address, or by
+// external key) again, is rejected. With on_error abort any rejected row fails the whole file.
+func parsePOIImportFile(fileBytes []byte, fileType string, onError string) poiImportPlan {
+	sheet := importer.Read(fileBytes, fileType, poiImportColumns)
+	sheet.RequireColumns("brand")
+	if !sheet.Has("address") {
+		// Without an address there is nothing to geocode, so coordinates are mandatory
+		sheet.RequireColumns("coordinate")
+	}
+
+	plan := poiImportPlan{
+		brandOrder:       []string{},
+		groups:           map[string]*poiImportGroup{},
+		coordinateErrors: []webPOI.POIImportCoordinateError{},
+		report:           importer.NewReport(sheet, onError),
+	}
+
+	var lastBrand string
+	for i := range sheet.Rows {
+		excelRow := importer.RowNumber(i)
+		if sheet.IsBlank(i) {
+			continue
+		}
+
+		brandVal := sheet.Value(i, "brand")
+		if brandVal == "" {
+			// Inherit brand from the previous non-empty row (handles merged
+			// cells and spreadsheets where brand is filled once per group).
+			if lastBrand == "" {
+				plan.report.Reject(excelRow, "brand", "Value is required")
+				continue
+			}
+			brandVal = lastBrand
+		} else {
+			lastBrand = brandVal
+		}
+
+		categoryName := sheet.Value(i, "category")
+		subCategoryName := sheet.Value(i, "sub_category")
+		motherBrandName := sheet.Value(i, "mother_brand")
+		poiName := sheet.Value(i, "poi_name")
+		address := sheet.Value(i, "address")
+		externalKey := sheet.Value(i, "external_key")
+		coordinate := sheet.Value(i, "coordinate")
+
+		group, exists := plan.groups[brandVal]
+		if exists {
+			mismatch := false
+			for _, field := range []struct{ column, label, first, value string }{
+				{"category", "Category", group.categoryName, categoryName},
+				{"sub_category", "Sub-Category", group.subCategoryName, subCategoryName},
+				{"mother_brand", "Mother Brand", group.motherBrandName, motherBrandName},
+			} {
+				if !strings.EqualFold(field.first, field.value) {
+					plan.report.Reject(excelRow, field.column, fmt.Sprintf(
+						"%s must match row %d (%q): all rows of the same brand share Category, Sub-Category and Mother Brand",
+						field.label, group.firstRow, field.first))
+					mismatch = true
+				}
+			}
+			if mismatch {
+				continue
+			}
+			if firstRow, dup := group.seenAt[pointMatchKey(poiName, address)]; dup {
+				plan.report.Reject(excelRow, "poi_name", fmt.Sprintf("Same POI name and address as row %d of this brand", firstRow))
+				continue
+			}
+			if firstRow, dup := group.seenKeyAt[externalKey]; externalKey != "" && dup {
+				plan.report.Reject(excelRow, "external_key", fmt.Sprintf("External key already used on row %d of this brand", firstRow))
+				continue
+			}
+		} else {
+			group = &poiImportGroup{
+				brand:           brandVal,
+				categoryName:    categoryName,
+				subCategoryName: subCategoryName,
+				motherBrandName: motherBrandName,
+				firstRow:        excelRow,
+				seenAt:          map[string]int{},
+				seenKeyAt:       map[string]int{},
+			}
+			plan.groups[brandVal] = group
+			plan.brandOrder = append(plan.brandOrder, brandVal)
+		}
+
+		group.rows = append(group.rows, excelRow)
+		group.seenAt[pointMatchKey(poiName, address)] = excelRow
+		if externalKey != "" {
+			group.seenKeyAt[externalKey] = excelRow
+		}
+
+		reason := coordinateProblem(coordinate)
+		if reason != "" {
+			plan.coordinateErrors = append(plan.coordinateErrors, webPOI.POIImportCoordinateError{
+				Row:    excelRow,
+				Brand:  brandVal,
+				Value:  coordinate,
+				Reason: reason,
+			})
+		}
+
+		lat, lng := parseCoordinate(coordinate)
+		group.points = append(group.points, poiImportPoint{
+			row:         excelRow,
+			poiName:     poiName,
+			address:     address,
+			externalKey: externalKey,
+			branchName:  sheet.Value(i, "branch"),
+			latitude:    lat,
+			longitude:   lng,
+			located:     reason == "",
+		})
+	}
+
+	plan.report.Check()
+	return plan
+}
+
+// geocodePlan completes the located points of a plan through geocoder: a point without a usable
+// coordinate is geocoded from its address, a located point without an address gets one by reverse
+// geocoding, and a point outside Indonesia is reported as a coordinate error (it is still imported
+// with its coordinate). It runs before matching so upsert compares the completed points.
+// Geocoding is slow, so every point advances tracker, which keeps a long-running import job from
+// looking abandoned and lets it be cancelled.
+func geocodePlan(ctx context.Context, geocoder servicesGeocoding.ServiceGeocodingInterface, plan *poiImportPlan, tracker *importer.Tracker) {
+	errorAt := map[int]int{} // Excel row -> index in plan.coordinateErrors
+	for i, coordinateError := range plan.coordinateErrors {
+		errorAt[coordinateError.Row] = i
+	}
+	geocoded := map[int]bool{}
+
+	for _, brand := range plan.brandOrder {
+		group := plan.groups[brand]
+		for i := range group.points {
+			pt := &group.points[i]
+
+			if !pt.located && pt.address != "" {
+				if result, ok := geocoder.Geocode(ctx, pt.address); ok {
+					pt.latitude, pt.longitude, pt.located = result.Latitude, result.Longitude, true
+					geocoded[pt.row] = true
+					plan.pointsGeocoded++
+				} else if idx, exists := errorAt[pt.row]; exists && plan.coordinateErrors[idx].Value == "" {
+					plan.coordinateErrors[idx].Reason = "Coordinate is empty and the address could not be geocoded"
+				}
+			} else if pt.located && pt.address == "" {
+				if result, ok := geocoder.Reverse(ctx, pt.latitude, pt.longitude); ok {
+					pt.address = result.Address
+					plan.addressesFilled++
+				}
+			}
+
+			if pt.located && !servicesGeocoding.InIndonesia(pt.latitude, pt.longitude) {
+				plan.coordinateErrors = append(plan.coordinateErrors, webPOI.POIImportCoordinateError{
+					Row:    pt.row,
+					Brand:  group.brand,
+					Value:  fmt.Sprintf("%g, %g", pt.latitude, pt.longitude),
+					Reason: outsideIndonesiaReason,
+				})
+			}
+			tracker.Advance(1)
+		}
+	}
+
+	if len(geocoded) > 0 {
+		remaining := []webPOI.POIImportCoordinateError{}
+		for _, coordinateError := range plan.coordinateErrors {
+			if !geocoded[coordinateError.Row] || coordinateError.Reason == outsideIndonesiaReason {
+				remaining = append(remaining, coordinateError)
+			}
+		}
+		plan.coordinateErrors = remaining
+	}
+	sort.SliceStable(plan.coordinateErrors, func(i, j int) bool {
+		return plan.coordinateErrors[i].Row < plan.coordinateErrors[j].Row
+	})
+}
+
+// pointMatchKey identifies a point within a brand by case-insensitive POI name and address
+func pointMatchKey(poiName, address string) string {
+	return strings.ToLower(poiName) + "|" + strings.ToLower(address)
+}
+
+// poiPointMatch pairs a file point with the stored point it corresponds to; existing is nil for
+// a point that is new to the brand.
+type poiPointMatch struct {
+	point    poiImportPoint
+	existing *models.POIPoint
+}
+
+// matchImportPoints pairs file points with the stored points of a brand's POIs. A point with an
+// external key is matched on that key first; any other point is matched by POI name + address,
+// but never to a stored point carrying a different external key. Each stored point is matched at
+// most once; the ones left over are returned in stored order.
+func matchImportPoints(points []poiImportPoint, existing []models.POI) ([]poiPointMatch, []models.POIPoint) {
+	var stored []models.POIPoint
+	for _, poi := range existing {
+		stored = append(stored, poi.Points...)
+	}
+
+	byKey := map[string]int{}
+	byNameAddress := map[string][]int{}
+	for i, pt := range stored {
+		if pt.ExternalKey != "" {
+			if _, dup := byKey[pt.ExternalKey]; !dup {
+				byKey[pt.ExternalKey] = i
+			}
+		}
+		key := pointMatchKey(pt.POIName, pt.Address)
+		byNameAddress[key] = append(byNameAddress[key], i)
+	}
+
+	claimed := make([]bool, len(stored))
+	matches := make([]poiPointMatch, len(points))
+	for i, pt := range points {
+		matches[i].point = pt
+		if pt.externalKey == "" {
+			continue
+		}
+		if idx, ok := byKey[pt.externalKey]; ok && !claimed[idx] {
+			claimed[idx] = true
+			matches[i].existing = &stored[idx]
+		}
+	}
+	for i, pt := range points {
+		if matches[i].existing != nil {
+			continue
+		}
+		for _, idx := range byNameAddress[pointMatchKey(pt.poiName, pt.address)] {
+			if claimed[idx] {
+				continue
+			}
+			if stored[idx].ExternalKey != "" && pt.externalKey != "" && stored[idx].ExternalKey != pt.externalKey {
+				continue
+			}
+			claimed[idx] = true
+			matches[i].existing = &stored[idx]
+			break
+		}
+	}
+
+	var unmatched []models.POIPoint
+	for i, pt := range stored {
+		if !claimed[i] {
+			unmatched = append(unmatched, pt)
+		}
+	}
+	return matches, unmatched
+}
+
+// normalizePOIImportOptions defaults an empty mode to replace and an empty on_error to abort,
+// and rejects unknown values
+func normalizePOIImportOptions(options webPOI.POIImportOptions) webPOI.POIImportOptions {
+	options.Mode = strings.ToLower(strings.TrimSpace(options.Mode))
+	switch options.Mode {
+	case "":
+		options.Mode = webPOI.POIImportModeReplace
+	case webPOI.POIImportModeReplace, webPOI.POIImportModeUpsert:
+	default:
+		panic(exceptions.NewBadRequestError(fmt.Sprintf("Unsupported import mode: %s. Use replace or upsert.", options.Mode)))
+	}
+	options.OnError = importer.ParseOnError(options.OnError)
+	if options.Mode == webPOI.POIImportModeReplace {
+		// Replace always deletes points missing from the file
+		options.DeleteMissing = false
+	}
+	return options
+}
+
+// coordinateProblem explains why a coordinate cell will not produce a location, or "" when it is valid.
+// Such points are still imported, just without a location.
+func coordinateProblem(coord string) string {
+	if coord == "" {
+		return "Coordinate is empty"
+	}
+	parts := strings.SplitN(coord, ",", 2)
+	if len(parts) != 2 {
+		return `Coordinate must be formatted as "lat, lng"`
+	}
+	lat, err := strconv.ParseFloat(strings.TrimSpace(parts[0]), 64)
+	if err != nil {
+		return "Latitude is not a number"
+	}
+	lng, err := strconv.ParseFloat(strings.TrimSpace(parts[1]), 64)
+	if err != nil {
+		return "Longitude is not a number"
+	}
+	if lat < -90 || lat > 90 {
+		return "Latitude must be between -90 and 90"
+	}
+	if lng < -180 || lng > 180 {
+		return "Longitude must be between -180 and 180"
+	}
+	if lat == 0 && lng == 0 {
+		return "Coordinate 0, 0 is treated as missing"
+	}
+	return ""
+}
+
+// coordinatesMoved reports whether two coordinates differ by more than coordinateEpsilon
+func coordinatesMoved(lat1, lng1, lat2, lng2 float64) bool {
+	return math.Abs(lat1-lat2) > coordinateEpsilon || math.Abs(lng1-lng2) > coordinateEpsilon
+}
+
+// previewFingerprint hashes everything a preview shows except its token, so confirm can
+// detect that the database (or the file) no longer produces the same diff.
+func previewFingerprint(preview webPOI.POIImportPreviewResponse) string {
+	preview.Token = ""
+	preview.ExpiresAt = ""
+	payload, err := json.Marshal(preview)
+	helpers.PanicIfError(err)
+	sum := sha256.Sum256(payload)
+	return hex.EncodeToString(sum[:])
+}
+
+// poiImportTemplate describes the downloadable POI import file. Its headers are the ones Export
+// writes, so an export can be edited and uploaded again.
+func poiImportTemplate(categories []models.Category, subCategories []models.SubCategory, motherBrands []models.MotherBrand, branches []models.Branch) importer.Template {
+	categoryNames := make([]string, len(categories))
+	for i, c := range categories {
+		categoryNames[i] = c.Name
+	}
+	subCategoryNames := make([]string, len(subCategories))
+	for i, c := range subCategories {
+		subCategoryNames[i] = c.Name
+	}
+	motherBrandNames := make([]string, len(motherBrands))
+	for i, m := range motherBrands {
+		motherBrandNames[i] = m.Name
+	}
+	branchNames := make([]string, len(branches))
+	for i, b := range branches {
+		branchNames[i] = b.Name
+	}
+
+	return importer.Template{
+		SheetName: "POIs",
+		Columns: []importer.TemplateColumn{
+			{Key: "category", Header: "Category", Description: "Category of the brand. A new name creates the category.", Options: categoryNames},
+			{Key: "sub_category", Header: "Sub-Category", Description: "Sub-category of the brand. A new name creates the sub-category under the row's category.", Options: subCategoryNames},
+			{Key: "mother_brand", Header: "Mother Brand", Description: "Group that owns the brand. A new name creates the mother brand.", Options: motherBrandNames},
+			{Key: "brand", Header: "Brand", Description: "Brand the point belongs to. Left empty, the brand of the row above is used.", Required: true},
+			{Key: "branch", Header: "Branch", Description: "Branch of the point. A new name creates the branch under the row's mother brand.", Options: branchNames},
+			{Key: "poi_name", Header: "POI Name", Description: "Name of the point, e.g. the store name."},
+			{Key: "address", Header: "Address", Description: "Street address of the point. Left empty, it is looked up from the coordinate."},
+			{Key: "coordinate", Header: "Coordinate", Description: `Location as "lat, lng" in decimal degrees, e.g. "-6.2088, 106.8456". Left empty, it is looked up from the address.`},
+			{Key: "external_key", Header: "External Key", Description: "Optional stable id of the point, e.g. a store code. Upsert imports match points on it."},
+		},
+		Instructions: []string{
+			"Fill one row per point on the POIs sheet and keep the header row as it is.",
+			"All rows of a brand must have the same Category, Sub-Category and Mother Brand.",
+			"An existing Sub-Category or Branch must already sit under the row's Category or Mother Brand.",
+			"A brand may list the same POI Name and Address, or the same External Key, only once.",
+			"Replace mode deletes the brand's existing points; upsert mode updates them in place.",
+			"Every point needs a Coordinate or an Address; points outside Indonesia are flagged in the preview.",
+		},
+	}
+}
@@ -1,23 +1,25 @@
 package poi
 
 import (
-	"bytes"
 	"context"
 	"database/sql"
-	"encoding/csv"
+	"encoding/json"
 	"fmt"
-	"io"
 	"strconv"
 	"strings"
 
 	"github.com/malikabdulaziz/tmn-backend/exceptions"
 	"github.com/malikabdulaziz/tmn-backend/helpers"
+	"github.com/malikabdulaziz/tmn-backend/importer"
 	"github.com/malikabdulaziz/tmn-backend/models"
 	repositoriesBranch "github.com/malikabdulaziz/tmn-backend/repositories/branch"
 	repositoriesCategory "github.com/malikabdulaziz/tmn-backend/repositories/category"
+	repositoriesImportPreview "github.com/malikabdulaziz/tmn-backend/repositories/importpreview"
 	repositoriesMotherBrand "github.com/malikabdulaziz/tmn-backend/repositories/motherbrand"
 	repositoriesPOI "github.com/malikabdulaziz/tmn-backend/repositories/poi"
 	repositoriesSubCategory "github.com/malikabdulaziz/tmn-backend/repositories/subcategory"
+	servicesGeocoding "github.com/malikabdulaziz/tmn-backend/services/geocoding"
+	"github.com/malikabdulaziz/tmn-backend/web"
 	webPOI "github.com/malikabdulaziz/tmn-backend/web/poi"
 	"github.com/xuri/excelize/v2"
 )
@@ -31,12 +33,14 @@ var colorPalette = []string{
 }
 
 type ServicePOIImpl struct {
-	DB                             *sql.DB
-	RepositoryPOIInterface         repositoriesPOI.RepositoryPOIInterface
-	RepositoryCategoryInterface    repositoriesCategory.RepositoryCategoryInterface
-	RepositorySubCategoryInterface repositoriesSubCategory.RepositorySubCategoryInterface
-	RepositoryMotherBrandInterface repositoriesMotherBrand.RepositoryMotherBrandInterface
-	RepositoryBranchInterface      repositoriesBranch.RepositoryBranchInterface
+	DB                               *sql.DB
+	RepositoryPOIInterface           repositoriesPOI.RepositoryPOIInterface
+	RepositoryCategoryInterface      repositoriesCategory.RepositoryCategoryInterface
+	RepositorySubCategoryInterface   repositoriesSubCategory.RepositorySubCategoryInterface
+	RepositoryMotherBrandInterface   repositoriesMotherBrand.RepositoryMotherBrandInterface
+	RepositoryBranchInterface        repositoriesBranch.RepositoryBranchInterface
+	RepositoryImportPreviewInterface repositoriesImportPreview.RepositoryImportPreviewInterface
+	ServiceGeocodingInterface        servicesGeocoding.ServiceGeocodingInterface
 }
 
 func NewServicePOIImpl(
@@ -46,25 +50,31 @@ func NewServicePOIImpl(
 	repoSubCategory repositoriesSubCategory.RepositorySubCategoryInterface,
 	repoMotherBrand repositoriesMotherBrand.RepositoryMotherBrandInterface,
 	repoBranch repositoriesBranch.RepositoryBranchInterface,
+	repoImportPreview repositoriesImportPreview.RepositoryImportPreviewInterface,
+	serviceGeocoding servicesGeocoding.ServiceGeocodingInterface,
 ) ServicePOIInterface {
 	return &ServicePOIImpl{
-		DB:                             db,
-		RepositoryPOIInterface:         repositoryPOI,
-		RepositoryCategoryInterface:    repoCategory,
-		RepositorySubCategoryInterface: repoSubCategory,
-		RepositoryMotherBrandInterface: repoMotherBrand,
-		RepositoryBranchInterface:      repoBranch,
+		DB:                               db,
+		RepositoryPOIInterface:           repositoryPOI,
+		RepositoryCategoryInterface:      repoCategory,
+		RepositorySubCategoryInterface:   repoSubCategory,
+		RepositoryMotherBrandInterface:   repoMotherBrand,
+		RepositoryBranchInterface:        repoBranch,
+		RepositoryImportPreviewInterface: repoImportPreview,
+		ServiceGeocodingInterface:        serviceGeocoding,
 	}
 }
 
 // Create creates a new POI together with its owned points.
 func (service *ServicePOIImpl) Create(ctx context.Context, request webPOI.CreatePOIRequest) webPOI.POIResponse {
+	service.geocodePoints(ctx, request.Points)
+
 	tx, err := service.DB.Begin()
 	helpers.PanicIfError(err)
 	defer helpers.CommitOrRollback(tx)
 
-	service.validateMetadata(ctx, tx, request.CategoryId, request.SubCategoryId, request.MotherBrandId)
-	service.validateBranches(ctx, tx, request.Points)
+	request.CategoryId = service.validateMetadata(ctx, tx, request.CategoryId, request.SubCategoryId, request.MotherBrandId)
+	service.validateBranches(ctx, tx, request.MotherBrandId, request.Points)
 
 	poi := models.POI{
 		Brand:         request.Brand,
@@ -120,6 +130,8 @@ func (service *ServicePOIImpl) FindById(ctx context.Context, id int) webPOI.POIR
 
 // Update updates a POI and replaces its owned points.
 func (service *ServicePOIImpl) Update(ctx context.Context, request webPOI.UpdatePOIRequest, id int) webPOI.POIResponse {
+	service.geocodePoints(ctx, request.Points)
+
 	tx, err := service.DB.Begin()
 	helpers.PanicIfError(err)
 	defer helpers.CommitOrRollback(tx)
@@ -130,8 +142,8 @@ func (service *ServicePOIImpl) Update(ctx context.Context, request webPOI.Update
 	}
 	helpers.PanicIfError(err)
 
-	service.validateMetadata(ctx, tx, request.CategoryId, request.SubCategoryId, request.MotherBrandId)
-	service.validateBranches(ctx, tx, request.Points)
+	request.CategoryId = service.validateMetadata(ctx, tx, request.CategoryId, request.SubCategoryId, request.MotherBrandId)
+	service.validateBranches(ctx, tx, request.MotherBrandId, request.Points)
 
 	existingPOI.Brand = request.Brand
 	existingPOI.Color = request.Color
@@ -145,7 +157,7 @@ func (service *ServicePOIImpl) Update(ctx context.Context, request webPOI.Update
 	return service.poiModelToResponse(updatedPOI)
 }
 
-// Delete cascades to owned points via the FK on poi_points.poi_id.
+// Delete moves a POI, with its points, to the trash.
 func (service *ServicePOIImpl) Delete(ctx context.Context, id int) {
 	tx, err := service.DB.Begin()
 	helpers.PanicIfError(err)
@@ -157,227 +169,478 @@ func (service *ServicePOIImpl) Delete(ctx context.Context, id int) {
 	}
 	helpers.PanicIfError(err)
 
-	err = service.RepositoryPOIInterface.Delete(ctx, tx, id)
+	err = service.RepositoryPOIInterface.Delete(ctx, tx, id, helpers.OptionalUserIdFromContext(ctx))
 	helpers.PanicIfError(err)
 }
 
-// Import parses xlsx/csv. Each row is a point; rows are grouped by Brand. The first
-// row of each brand sets the POI metadata (category/sub_category/mother_brand). If
-// a later row of the same brand disagrees, the whole file is rejected.
-func (service *ServicePOIImpl) Import(ctx context.Context, fileBytes []byte, fileType string) []webPOI.POIResponse {
+// Import parses xlsx/csv and replaces or upserts, by brand, the POIs it contains (see
+// parsePOIImportFile and POIImportOptions).
+func (service *ServicePOIImpl) Import(ctx context.Context, fileBytes []byte, fileType string, options webPOI.POIImportOptions) ([]webPOI.POIResponse, web.ImportReport) {
+	options = normalizePOIImportOptions(options)
+	plan := parsePOIImportFile(fileBytes, fileType, options.OnError)
+	tracker := newPOIImportTracker(ctx, plan)
+	geocodePlan(ctx, service.ServiceGeocodingInterface, &plan, tracker)
+
 	tx, err := service.DB.Begin()
 	helpers.PanicIfError(err)
 	defer helpers.CommitOrRollback(tx)
 
-	var rows [][]string
+	service.rejectHierarchyConflicts(ctx, tx, &plan)
+	return service.applyPOIImport(ctx, tx, plan, options, tracker), plan.report.Result()
+}
 
-	switch strings.ToLower(fileType) {
-	case "xlsx":
-		rows, err = parseXLSX(fileBytes)
-		helpers.PanicIfError(err)
-	case "csv":
-		rows, err = parseCSV(fileBytes)
-		helpers.PanicIfError(err)
-	default:
-		panic(exceptions.NewBadRequestError("Unsupported file type. Use xlsx or csv."))
+// newPOIImportTracker tracks an import of plan as one job: every point is geocoded, then written.
+// Points that later checks leave out of the plan are counted as written by applyPOIImport, so
+// progress only moves forward and ends at the total.
+func newPOIImportTracker(ctx context.Context, plan poiImportPlan) *importer.Tracker {
+	return importer.NewTracker(ctx, 2*plan.pointCount())
+}
+
+// PreviewImport parses and validates an upload and returns what Import would do, without
+// writing anything but the preview itself. The returned token is accepted by ConfirmImport.
+func (service *ServicePOIImpl) PreviewImport(ctx context.Context, fileBytes []byte, fileType string, options webPOI.POIImportOptions) webPOI.POIImportPreviewResponse {
+	options = normalizePOIImportOptions(options)
+	plan := parsePOIImportFile(fileBytes, fileType, options.OnError)
+	geocodePlan(ctx, service.ServiceGeocodingInterface, &plan, importer.NewTracker(ctx, plan.pointCount()))
+
+	tx, err := service.DB.Begin()
+	helpers.PanicIfError(err)
+	defer helpers.CommitOrRollback(tx)
+
+	service.rejectHierarchyConflicts(ctx, tx, &plan)
+	preview := service.diffPOIImport(ctx, tx, plan, options)
+
+	storedOptions, err := json.Marshal(options)
+	helpers.PanicIfError(err)
+
+	_, err = service.RepositoryImportPreviewInterface.DeleteExpired(ctx, tx)
+	helpers.PanicIfError(err)
+
+	token, err := helpers.GenerateToken(32)
+	helpers.PanicIfError(err)
+
+	stored := models.ImportPreview{
+		Token:       token,
+		Kind:        models.ImportPreviewKindPOI,
+		FileType:    strings.ToLower(fileType),
+		FileBytes:   fileBytes,
+		Fingerprint: previewFingerprint(preview),
+		Options:     string(storedOptions),
+	}
+	if userId := helpers.UserIdFromContext(ctx); userId > 0 {
+		stored.CreatedBy = &userId
 	}
+	stored, err = service.RepositoryImportPreviewInterface.Create(ctx, tx, stored, poiImportPreviewTTLSeconds)
+	helpers.PanicIfError(err)
+
+	preview.Token = stored.Token
+	preview.ExpiresAt = stored.ExpiresAt
+	return preview
+}
+
+// ConfirmImport applies the upload behind a preview token. The upload is parsed and geocoded
+// before the import transaction is opened; the diff is then recomputed inside it and must match
+// the preview exactly, otherwise nothing is written.
+func (service *ServicePOIImpl) ConfirmImport(ctx context.Context, request webPOI.ConfirmPOIImportRequest) ([]webPOI.POIResponse, web.ImportReport) {
+	stored := service.readImportPreview(ctx, request.Token)
 
-	if len(rows) < 2 {
-		panic(exceptions.NewBadRequestError("File must contain a header row and at least one data row."))
+	var options webPOI.POIImportOptions
+	if stored.Options != "" {
+		helpers.PanicIfError(json.Unmarshal([]byte(stored.Options), &options))
 	}
+	options = normalizePOIImportOptions(options)
 
-	header := rows[0]
-	colMap := mapHeaderColumns(header)
+	plan := parsePOIImportFile(stored.FileBytes, stored.FileType, options.OnError)
+	tracker := newPOIImportTracker(ctx, plan)
+	geocodePlan(ctx, service.ServiceGeocodingInterface, &plan, tracker)
 
-	for _, col := range []string{"brand", "coordinate"} {
-		if _, exists := colMap[col]; !exists {
-			panic(exceptions.NewBadRequestError(fmt.Sprintf("Missing required column: %s", col)))
-		}
+	tx, err := service.DB.Begin()
+	helpers.PanicIfError(err)
+	defer helpers.CommitOrRollback(tx)
+
+	// another confirm may have used or the cleanup removed the preview while geocoding
+	stored = service.findImportPreview(ctx, tx, request.Token)
+	service.rejectHierarchyConflicts(ctx, tx, &plan)
+	if previewFingerprint(service.diffPOIImport(ctx, tx, plan, options)) != stored.Fingerprint {
+		panic(exceptions.NewBadRequest("POI data changed since this preview was generated. Please preview the import again."))
 	}
 
-	type brandGroup struct {
-		brand           string
-		categoryName    string
-		subCategoryName string
-		motherBrandName string
-		firstRow        int
-		rows            []int // every Excel row that belongs to this brand group
-		points          []models.POIPoint
-		seenAt          map[string]int // poi_name|address -> first Excel row
-	}
-	type duplicateEntry struct {
-		Brand   string `json:"brand"`
-		POIName string `json:"poi_name"`
-		Address string `json:"address"`
-		Rows    []int  `json:"rows"`
-	}
-	type metadataMismatch struct {
-		Brand string `json:"brand"`
-		Field string `json:"field"`
-		Rows  []int  `json:"rows"`
-	}
-
-	brandOrder := []string{}
-	groups := map[string]*brandGroup{}
-	duplicateIndex := map[string]*duplicateEntry{}
-	// mismatchedFields[brand] is the set of fields ("category", "sub_category",
-	// "mother_brand") where any row in that brand disagreed with another.
-	mismatchedFields := map[string]map[string]struct{}{}
-
-	noteMismatch := func(brand, field string) {
-		if _, ok := mismatchedFields[brand]; !ok {
-			mismatchedFields[brand] = map[string]struct{}{}
-		}
-		mismatchedFields[brand][field] = struct{}{}
+	responses := service.applyPOIImport(ctx, tx, plan, options, tracker)
+
+	err = service.RepositoryImportPreviewInterface.Delete(ctx, tx, stored.Id)
+	helpers.PanicIfError(err)
+
+	return responses, plan.report.Result()
+}
+
+// readImportPreview looks up a preview in a transaction of its own, so the upload can be
+// geocoded without holding one open
+func (service *ServicePOIImpl) readImportPreview(ctx context.Context, token string) models.ImportPreview {
+	tx, err := service.DB.Begin()
+	helpers.PanicIfError(err)
+	defer helpers.CommitOrRollback(tx)
+
+	return service.findImportPreview(ctx, tx, token)
+}
+
+// findImportPreview returns the unexpired POI preview behind token, which only the user who
+// generated it may confirm
+func (service *ServicePOIImpl) findImportPreview(ctx context.Context, tx *sql.Tx, token string) models.ImportPreview {
+	stored, err := service.RepositoryImportPreviewInterface.FindByToken(ctx, tx, models.ImportPreviewKindPOI, token)
+	if err == sql.ErrNoRows {
+		panic(exceptions.NewNotFoundError("Import preview not found or expired"))
 	}
+	helpers.PanicIfError(err)
 
-	var lastBrand string
-	for i, row := range rows[1:] {
-		excelRow := i + 2
+	if stored.CreatedBy != nil && *stored.CreatedBy != helpers.UserIdFromContext(ctx) {
+		panic(exceptions.NewNotFoundError("Import preview not found or expired"))
+	}
+	return stored
+}
 
-		brandVal := getColValue(row, colMap, "brand")
-		if brandVal == "" {
-			// Inherit brand from the previous non-empty row (handles merged
-			// cells and spreadsheets where brand is filled once per group).
-			// A fully blank row is still skipped.
-			if lastBrand == "" || isRowBlank(row) {
-				continue
-			}
-			brandVal = lastBrand
-		} else {
-			lastBrand = brandVal
+// rejectHierarchyConflicts rejects the rows of the plan that combine an existing sub-category with
+// another category, or an existing branch with another mother brand, and leaves them out of it. A
+// brand with a sub-category but no category takes the category of the sub-category.
+// Like the file checks it runs before anything is written, so with on_error abort any conflict
+// fails the whole file. A brand left without points is dropped.
+func (service *ServicePOIImpl) rejectHierarchyConflicts(ctx context.Context, tx *sql.Tx, plan *poiImportPlan) {
+	branches := map[string]models.Branch{}
+	findBranch := func(name string) (models.Branch, bool) {
+		key := strings.ToLower(name)
+		if branch, seen := branches[key]; seen {
+			return branch, branch.Id != 0
 		}
-
-		categoryName := getColValue(row, colMap, "category")
-		subCategoryName := getColValue(row, colMap, "sub_category")
-		motherBrandName := getColValue(row, colMap, "mother_brand")
-		branchName := getColValue(row, colMap, "branch")
-		poiName := getColValue(row, colMap, "poi_name")
-		address := getColValue(row, colMap, "address")
-		lat, lng := parseCoordinate(getColValue(row, colMap, "coordinate"))
-
-		group, exists := groups[brandVal]
-		if !exists {
-			group = &brandGroup{
-				brand:           brandVal,
-				categoryName:    categoryName,
-				subCategoryName: subCategoryName,
-				motherBrandName: motherBrandName,
-				firstRow:        excelRow,
-				seenAt:          map[string]int{},
-			}
-			groups[brandVal] = group
-			brandOrder = append(brandOrder, brandVal)
-		} else {
-			if !strings.EqualFold(group.categoryName, categoryName) {
-				noteMismatch(brandVal, "category")
-			}
-			if !strings.EqualFold(group.subCategoryName, subCategoryName) {
-				noteMismatch(brandVal, "sub_category")
-			}
-			if !strings.EqualFold(group.motherBrandName, motherBrandName) {
-				noteMismatch(brandVal, "mother_brand")
-			}
+		branch, err := service.RepositoryBranchInterface.FindByName(ctx, tx, name)
+		if !lookupExists(err) {
+			branch = models.Branch{}
 		}
-		group.rows = append(group.rows, excelRow)
-
-		dupKey := strings.ToLower(poiName) + "|" + strings.ToLower(address)
-		if firstRow, dup := group.seenAt[dupKey]; dup {
-			key := fmt.Sprintf("%s|%s", brandVal, dupKey)
-			if entry, ok := duplicateIndex[key]; ok {
-				entry.Rows = append(entry.Rows, excelRow)
-			} else {
-				duplicateIndex[key] = &duplicateEntry{
-					Brand:   brandVal,
-					POIName: poiName,
-					Address: address,
-					Rows:    []int{firstRow, excelRow},
+		branches[key] = branch
+		return branch, branch.Id != 0
+	}
+
+	brandOrder := make([]string, 0, len(plan.brandOrder))
+	for _, brandKey := range plan.brandOrder {
+		group := plan.groups[brandKey]
+		if group.subCategoryName != "" {
+			subCategory, err := service.RepositorySubCategoryInterface.FindByName(ctx, tx, group.subCategoryName)
+			if lookupExists(err) && subCategory.CategoryId != nil {
+				if group.categoryName == "" {
+					group.categoryName = subCategory.CategoryName
+				} else if !strings.EqualFold(subCategory.CategoryName, group.categoryName) {
+					for _, row := range group.rows {
+						plan.report.Reject(row, "sub_category", fmt.Sprintf("Sub-Category %s belongs to Category %s", subCategory.Name, subCategory.CategoryName))
+					}
+					delete(plan.groups, brandKey)
+					continue
 				}
 			}
-			continue
 		}
-		group.seenAt[dupKey] = excelRow
-
-		branchId := service.findOrCreateBranch(ctx, tx, branchName)
-		group.points = append(group.points, models.POIPoint{
-			POIName:   poiName,
-			Address:   address,
-			Latitude:  lat,
-			Longitude: lng,
-			BranchId:  branchId,
-		})
-	}
-
-	if len(mismatchedFields) > 0 {
-		mismatches := make([]metadataMismatch, 0)
-		for _, brand := range brandOrder {
-			fields, ok := mismatchedFields[brand]
-			if !ok {
-				continue
-			}
-			group := groups[brand]
-			for _, field := range []string{"category", "sub_category", "mother_brand"} {
-				if _, hit := fields[field]; !hit {
-					continue
+		if group.motherBrandName != "" {
+			points := make([]poiImportPoint, 0, len(group.points))
+			rows := make([]int, 0, len(group.rows))
+			for _, pt := range group.points {
+				if pt.branchName != "" {
+					branch, ok := findBranch(pt.branchName)
+					if ok && branch.MotherBrandId != nil && !strings.EqualFold(branch.MotherBrandName, group.motherBrandName) {
+						plan.report.Reject(pt.row, "branch", fmt.Sprintf("Branch %s belongs to Mother Brand %s", branch.Name, branch.MotherBrandName))
+						continue
+					}
 				}
-				rowsCopy := append([]int(nil), group.rows...)
-				mismatches = append(mismatches, metadataMismatch{
-					Brand: brand,
-					Field: field,
-					Rows:  rowsCopy,
-				})
+				points = append(points, pt)
+				rows = append(rows, pt.row)
 			}
+			if len(points) == 0 {
+				delete(plan.groups, brandKey)
+				continue
+			}
+			group.points, group.rows = points, rows
 		}
-		panic(exceptions.NewBadRequestWithExtras(
-			"Metadata mismatch within a brand group: all rows of the same brand must share the same Category, Sub-Category, and Mother Brand. Please review the rows below.",
-			map[string]interface{}{"mismatches": mismatches},
-		))
+		brandOrder = append(brandOrder, brandKey)
 	}
+	plan.brandOrder = brandOrder
+	plan.report.Check()
+}
 
-	if len(duplicateIndex) > 0 {
-		duplicates := make([]duplicateEntry, 0, len(duplicateIndex))
-		for _, entry := range duplicateIndex {
-			duplicates = append(duplicates, *entry)
-		}
-		panic(exceptions.NewBadRequestWithExtras(
-			"Duplicate rows found: the same brand cannot reference the same POI (by name and address) more than once.",
-			map[string]interface{}{"duplicates": duplicates},
-		))
+// applyPOIImport writes the plan, creating any missing category/sub-category/mother brand/branch
+// on the way, and advances tracker (see newPOIImportTracker) as points are written. Replace moves
+// existing POIs whose brand is in the plan to the trash and recreates them; upsert updates them in
+// place (see upsertImportedPOIs).
+func (service *ServicePOIImpl) applyPOIImport(ctx context.Context, tx *sql.Tx, plan poiImportPlan, options webPOI.POIImportOptions, tracker *importer.Tracker) []webPOI.POIResponse {
+	existing, err := service.RepositoryPOIInterface.FindByBrands(ctx, tx, plan.brandOrder)
+	helpers.PanicIfError(err)
+
+	// points rejected since geocoding are not written
+	tracker.Advance(tracker.Remaining() - plan.pointCount())
+	if options.Mode == webPOI.POIImportModeUpsert {
+		return service.upsertImportedPOIs(ctx, tx, plan, existing, options.DeleteMissing, tracker)
 	}
 
-	// Replace: delete any existing POIs whose brand matches the imported brands.
-	existing, err := service.RepositoryPOIInterface.FindByBrands(ctx, tx, brandOrder)
-	helpers.PanicIfError(err)
 	for _, existingPOI := range existing {
-		err = service.RepositoryPOIInterface.Delete(ctx, tx, existingPOI.Id)
+		err = service.RepositoryPOIInterface.Delete(ctx, tx, existingPOI.Id, helpers.OptionalUserIdFromContext(ctx))
 		helpers.PanicIfError(err)
 	}
 
 	var responses []webPOI.POIResponse
-	for i, brandKey := range brandOrder {
-		group := groups[brandKey]
-		color := colorPalette[i%len(colorPalette)]
-
-		categoryId := service.findOrCreateCategory(ctx, tx, group.categoryName)
-		subCategoryId := service.findOrCreateSubCategory(ctx, tx, group.subCategoryName)
-		motherBrandId := service.findOrCreateMotherBrand(ctx, tx, group.motherBrandName)
-
-		poi := models.POI{
-			Brand:         group.brand,
-			Color:         color,
-			CategoryId:    categoryId,
-			SubCategoryId: subCategoryId,
-			MotherBrandId: motherBrandId,
+	for i, brandKey := range plan.brandOrder {
+		createdPOI := service.createImportedPOI(ctx, tx, plan.groups[brandKey], colorPalette[i%len(colorPalette)])
+		responses = append(responses, service.poiModelToResponse(createdPOI))
+		tracker.Advance(len(plan.groups[brandKey].points))
+	}
+
+	return responses
+}
+
+// upsertImportedPOIs keeps the ids and colors of existing POIs. A brand's metadata is written to
+// its oldest POI, matched points (see matchImportPoints) are updated in place, new points are
+// added to the oldest POI, and unmatched stored points are deleted only when deleteMissing is set.
+// Brands not yet in the database are created as in replace mode.
+func (service *ServicePOIImpl) upsertImportedPOIs(ctx context.Context, tx *sql.Tx, plan poiImportPlan, existing []models.POI, deleteMissing bool, tracker *importer.Tracker) []webPOI.POIResponse {
+	existingByBrand := map[string][]models.POI{}
+	for _, poi := range existing {
+		existingByBrand[poi.Brand] = append(existingByBrand[poi.Brand], poi)
+	}
+
+	var responses []webPOI.POIResponse
+	for i, brandKey := range plan.brandOrder {
+		group := plan.groups[brandKey]
+		pois := existingByBrand[group.brand]
+		if len(pois) == 0 {
+			createdPOI := service.createImportedPOI(ctx, tx, group, colorPalette[i%len(colorPalette)])
+			responses = append(responses, service.poiModelToResponse(createdPOI))
+			tracker.Advance(len(group.points))
+			continue
+		}
+
+		// Metadata left blank in the file keeps the stored value
+		target := pois[0]
+		if id := service.findOrCreateCategory(ctx, tx, group.categoryName); id != nil {
+			target.CategoryId = id
+		}
+		if id := service.findOrCreateSubCategory(ctx, tx, group.subCategoryName, target.CategoryId); id != nil {
+			target.SubCategoryId = id
+		}
+		if id := service.findOrCreateMotherBrand(ctx, tx, group.motherBrandName); id != nil {
+			target.MotherBrandId = id
+		}
+
+		matches, unmatched := matchImportPoints(group.points, pois)
+		for _, match := range matches {
+			point := service.importedPoint(ctx, tx, match.point, target.MotherBrandId)
+			if match.existing == nil {
+				_, err := service.RepositoryPOIInterface.CreatePoint(ctx, tx, target.Id, point)
+				helpers.PanicIfError(err)
+			} else {
+				point.Id = match.existing.Id
+				if point.ExternalKey == "" {
+					point.ExternalKey = match.existing.ExternalKey
+				}
+				_, err := service.RepositoryPOIInterface.UpdatePoint(ctx, tx, point)
+				helpers.PanicIfError(err)
+			}
+			tracker.Advance(1)
+		}
+		if deleteMissing && len(unmatched) > 0 {
+			ids := make([]int, len(unmatched))
+			for j, pt := range unmatched {
+				ids[j] = pt.Id
+			}
+			err := service.RepositoryPOIInterface.DeletePoints(ctx, tx, ids)
+			helpers.PanicIfError(err)
 		}
 
-		createdPOI, err := service.RepositoryPOIInterface.Create(ctx, tx, poi, group.points)
+		updatedPOI, err := service.RepositoryPOIInterface.UpdateMetadata(ctx, tx, target)
 		helpers.PanicIfError(err)
-		responses = append(responses, service.poiModelToResponse(createdPOI))
+		responses = append(responses, service.poiModelToResponse(updatedPOI))
+
+		for _, other := range pois[1:] {
+			reloaded, err := service.RepositoryPOIInterface.FindById(ctx, tx, other.Id)
+			helpers.PanicIfError(err)
+			responses = append(responses, service.poiModelToResponse(reloaded))
+		}
 	}
 
 	return responses
 }
 
+// createImportedPOI creates a new POI with the given color from one brand group of the plan
+func (service *ServicePOIImpl) createImportedPOI(ctx context.Context, tx *sql.Tx, group *poiImportGroup, color string) models.POI {
+	poi := models.POI{
+		Brand:         group.brand,
+		Color:         color,
+		CategoryId:    service.findOrCreateCategory(ctx, tx, group.categoryName),
+		MotherBrandId: service.findOrCreateMotherBrand(ctx, tx, group.motherBrandName),
+	}
+	poi.SubCategoryId = service.findOrCreateSubCategory(ctx, tx, group.subCategoryName, poi.CategoryId)
+
+	points := make([]models.POIPoint, len(group.points))
+	for j, pt := range group.points {
+		points[j] = service.importedPoint(ctx, tx, pt, poi.MotherBrandId)
+	}
+
+	createdPOI, err := service.RepositoryPOIInterface.Create(ctx, tx, poi, points)
+	helpers.PanicIfError(err)
+	return createdPOI
+}
+
+func (service *ServicePOIImpl) importedPoint(ctx context.Context, tx *sql.Tx, pt poiImportPoint, motherBrandId *int) models.POIPoint {
+	return models.POIPoint{
+		POIName:     pt.poiName,
+		Address:     pt.address,
+		Latitude:    pt.latitude,
+		Longitude:   pt.longitude,
+		ExternalKey: pt.externalKey,
+		BranchId:    service.findOrCreateBranch(ctx, tx, pt.branchName, motherBrandId),
+	}
+}
+
+// diffPOIImport compares the plan against the database. Points are matched across all existing
+// POIs of the brand (see matchImportPoints); a matched point whose coordinate changed is "moved".
+func (service *ServicePOIImpl) diffPOIImport(ctx context.Context, tx *sql.Tx, plan poiImportPlan, options webPOI.POIImportOptions) webPOI.POIImportPreviewResponse {
+	existing, err := service.RepositoryPOIInterface.FindByBrands(ctx, tx, plan.brandOrder)
+	helpers.PanicIfError(err)
+	existingByBrand := map[string][]models.POI{}
+	for _, poi := range existing {
+		existingByBrand[poi.Brand] = append(existingByBrand[poi.Brand], poi)
+	}
+
+	preview := webPOI.POIImportPreviewResponse{
+		Options:          options,
+		POIs:             make([]webPOI.POIImportPOIDiff, 0, len(plan.brandOrder)),
+		CoordinateErrors: plan.coordinateErrors,
+		RowErrors:        plan.report.Errors(),
+		MasterDataToCreate: webPOI.POIImportMasterDataDiff{
+			Categories:    []string{},
+			SubCategories: []string{},
+			MotherBrands:  []string{},
+			Branches:      []string{},
+		},
+	}
+	seenMissing := map[string]bool{}
+	noteMissing := func(kind string, name string, exists func() bool, list *[]string) {
+		key := kind + "|" + strings.ToLower(name)
+		if name == "" || seenMissing[key] {
+			return
+		}
+		seenMissing[key] = true
+		if !exists() {
+			*list = append(*list, name)
+		}
+	}
+
+	for _, brandKey := range plan.brandOrder {
+		group := plan.groups[brandKey]
+
+		noteMissing("category", group.categoryName, func() bool {
+			_, err := service.RepositoryCategoryInterface.FindByName(ctx, tx, group.categoryName)
+			return lookupExists(err)
+		}, &preview.MasterDataToCreate.Categories)
+		noteMissing("sub_category", group.subCategoryName, func() bool {
+			_, err := service.RepositorySubCategoryInterface.FindByName(ctx, tx, group.subCategoryName)
+			return lookupExists(err)
+		}, &preview.MasterDataToCreate.SubCategories)
+		noteMissing("mother_brand", group.motherBrandName, func() bool {
+			_, err := service.RepositoryMotherBrandInterface.FindByName(ctx, tx, group.motherBrandName)
+			return lookupExists(err)
+		}, &preview.MasterDataToCreate.MotherBrands)
+
+		diff := webPOI.POIImportPOIDiff{
+			Brand:          group.brand,
+			Action:         "create",
+			ExistingPOIIds: []int{},
+			Rows:           append([]int(nil), group.rows...),
+			PointsAdded:    []webPOI.POIImportPointDiff{},
+			PointsRemoved:  []webPOI.POIImportPointDiff{},
+			PointsMoved:    []webPOI.POIImportPointDiff{},
+		}
+
+		for _, poi := range existingByBrand[group.brand] {
+			diff.Action = "replace"
+			if options.Mode == webPOI.POIImportModeUpsert {
+				diff.Action = "update"
+			}
+			diff.ExistingPOIIds = append(diff.ExistingPOIIds, poi.Id)
+		}
+
+		matches, unmatched := matchImportPoints(group.points, existingByBrand[group.brand])
+		for _, match := range matches {
+			pt := match.point
+			branchName := pt.branchName
+			noteMissing("branch", branchName, func() bool {
+				_, err := service.RepositoryBranchInterface.FindByName(ctx, tx, branchName)
+				return lookupExists(err)
+			}, &preview.MasterDataToCreate.Branches)
+
+			item := webPOI.POIImportPointDiff{
+				Row:         pt.row,
+				ExternalKey: pt.externalKey,
+				POIName:     pt.poiName,
+				Address:     pt.address,
+				Latitude:    pt.latitude,
+				Longitude:   pt.longitude,
+			}
+			if match.existing == nil {
+				diff.PointsAdded = append(diff.PointsAdded, item)
+				continue
+			}
+			old := *match.existing
+			if !coordinatesMoved(old.Latitude, old.Longitude, pt.latitude, pt.longitude) {
+				diff.PointsUnchanged++
+				continue
+			}
+			oldId, oldLat, oldLng := old.Id, old.Latitude, old.Longitude
+			item.ExistingPointId = &oldId
+			item.PreviousLatitude = &oldLat
+			item.PreviousLongitude = &oldLng
+			diff.PointsMoved = append(diff.PointsMoved, item)
+		}
+		for _, old := range unmatched {
+			if options.Mode == webPOI.POIImportModeUpsert && !options.DeleteMissing {
+				diff.PointsKept++
+				continue
+			}
+			oldId := old.Id
+			diff.PointsRemoved = append(diff.PointsRemoved, webPOI.POIImportPointDiff{
+				ExistingPointId: &oldId,
+				ExternalKey:     old.ExternalKey,
+				POIName:         old.POIName,
+				Address:         old.Address,
+				Latitude:        old.Latitude,
+				Longitude:       old.Longitude,
+			})
+		}
+
+		switch diff.Action {
+		case "replace":
+			preview.Summary.POIsReplaced++
+		case "update":
+			preview.Summary.POIsUpdated++
+		default:
+			preview.Summary.POIsCreated++
+		}
+		preview.Summary.PointsAdded += len(diff.PointsAdded)
+		preview.Summary.PointsRemoved += len(diff.PointsRemoved)
+		preview.Summary.PointsMoved += len(diff.PointsMoved)
+		preview.Summary.PointsUnchanged += diff.PointsUnchanged
+		preview.Summary.PointsKept += diff.PointsKept
+		preview.POIs = append(preview.POIs, diff)
+	}
+	preview.Summary.CoordinateErrors = len(preview.CoordinateErrors)
+	preview.Summary.PointsGeocoded = plan.pointsGeocoded
+	preview.Summary.AddressesFilled = plan.addressesFilled
+	preview.Summary.RowsSkipped = plan.report.RejectedRows()
+
+	return preview
+}
+
+// lookupExists turns a FindByName error into found / not found, panicking on real errors
+func lookupExists(err error) bool {
+	if err == sql.ErrNoRows {
+		return false
+	}
+	helpers.PanicIfError(err)
+	return true
+}
+
 func (service *ServicePOIImpl) Export(ctx context.Context, search string, categoryIds string, subCategoryIds string, motherBrandIds string) ([]byte, error) {
 	tx, err := service.DB.Begin()
 	if err != nil {
@@ -393,8 +656,39 @@ func (service *ServicePOIImpl) Export(ctx context.Context, search string, catego
 	return buildPOIExcel(pois)
 }
 
-// validateMetadata ensures provided category/sub/mother-brand IDs exist.
-func (service *ServicePOIImpl) validateMetadata(ctx context.Context, tx *sql.Tx, categoryId, subCategoryId, motherBrandId *int) {
+// ImportTemplate returns a blank POI import xlsx whose metadata columns offer the current
+// categories, sub-categories, mother brands and branches as dropdowns
+func (service *ServicePOIImpl) ImportTemplate(ctx context.Context) ([]byte, error) {
+	tx, err := service.DB.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer helpers.CommitOrRollback(tx)
+
+	categories, err := service.RepositoryCategoryInterface.FindAllDropdown(ctx, tx)
+	if err != nil {
+		return nil, err
+	}
+	subCategories, err := service.RepositorySubCategoryInterface.FindAllDropdown(ctx, tx)
+	if err != nil {
+		return nil, err
+	}
+	motherBrands, err := service.RepositoryMotherBrandInterface.FindAllDropdown(ctx, tx)
+	if err != nil {
+		return nil, err
+	}
+	branches, err := service.RepositoryBranchInterface.FindAllDropdown(ctx, tx)
+	if err != nil {
+		return nil, err
+	}
+
+	return importer.BuildTemplate(poiImportTemplate(categories, subCategories, motherBrands, branches), poiImportColumns)
+}
+
+// validateMetadata ensures provided category/sub/mother-brand IDs exist, and that a sub-category
+// placed under a category is only combined with that category. It returns the category the POI is
+// saved with: a sub-category under a category brings its category along when none was given.
+func (service *ServicePOIImpl) validateMetadata(ctx context.Context, tx *sql.Tx, categoryId, subCategoryId, motherBrandId *int) *int {
 	if categoryId != nil {
 		if _, err := service.RepositoryCategoryInterface.FindById(ctx, tx, *categoryId); err != nil {
 			if err == sql.ErrNoRows {
@@ -404,11 +698,16 @@ func (service *ServicePOIImpl) validateMetadata(ctx context.Context, tx *sql.Tx,
 		}
 	}
 	if subCategoryId != nil {
-		if _, err := service.RepositorySubCategoryInterface.FindById(ctx, tx, *subCategoryId); err != nil {
-			if err == sql.ErrNoRows {
-				panic(exceptions.NewBadRequest("Sub-Category not found"))
-			}
-			helpers.PanicIfError(err)
+		subCategory, err := service.RepositorySubCategoryInterface.FindById(ctx, tx, *subCategoryId)
+		if err == sql.ErrNoRows {
+			panic(exceptions.NewBadRequest("Sub-Category not found"))
+		}
+		helpers.PanicIfError(err)
+		if categoryId != nil && subCategory.CategoryId != nil && *subCategory.CategoryId != *categoryId {
+			panic(exceptions.NewBadRequest(fmt.Sprintf("Sub-Category %s belongs to Category %s", subCategory.Name, subCategory.CategoryName)))
+		}
+		if categoryId == nil {
+			categoryId = subCategory.CategoryId
 		}
 	}
 	if motherBrandId != nil {
@@ -419,19 +718,46 @@ func (service *ServicePOIImpl) validateMetadata(ctx context.Context, tx *sql.Tx,
 			helpers.PanicIfError(err)
 		}
 	}
+	return categoryId
+}
+
+// geocodePoints completes points before they are saved: a point without a coordinate (0, 0) is
+// geocoded from its address, and a point without an address gets one by reverse geocoding. It runs
+// before the transaction is opened so provider round trips do not hold a database connection.
+func (service *ServicePOIImpl) geocodePoints(ctx context.Context, points []webPOI.POIPointInput) {
+	for i := range points {
+		pt := &points[i]
+		if pt.Latitude == 0 && pt.Longitude == 0 {
+			if strings.TrimSpace(pt.Address) == "" {
+				panic(exceptions.NewBadRequest(fmt.Sprintf("Point %q needs a coordinate or an address", pt.POIName)))
+			}
+			result, ok := service.ServiceGeocodingInterface.Geocode(ctx, pt.Address)
+			if !ok {
+				panic(exceptions.NewBadRequest(fmt.Sprintf("Address of point %q could not be geocoded; enter its coordinate", pt.POIName)))
+			}
+			pt.Latitude, pt.Longitude = result.Latitude, result.Longitude
+		} else if strings.TrimSpace(pt.Address) == "" {
+			if result, ok := service.ServiceGeocodingInterface.Reverse(ctx, pt.Latitude, pt.Longitude); ok {
+				pt.Address = result.Address
+			}
+		}
+	}
 }
 
-// validateBranches ensures provided branch IDs on each point exist.
-func (service *ServicePOIImpl) validateBranches(ctx context.Context, tx *sql.Tx, points []webPOI.POIPointInput) {
+// validateBranches ensures provided branch IDs on each point exist, and that a branch placed
+// under a mother brand is only used by POIs of that mother brand.
+func (service *ServicePOIImpl) validateBranches(ctx context.Context, tx *sql.Tx, motherBrandId *int, points []webPOI.POIPointInput) {
 	for _, pt := range points {
 		if pt.BranchId == nil {
 			continue
 		}
-		if _, err := service.RepositoryBranchInterface.FindById(ctx, tx, *pt.BranchId); err != nil {
-			if err == sql.ErrNoRows {
-				panic(exceptions.NewBadRequest("Branch not found"))
-			}
-			helpers.PanicIfError(err)
+		branch, err := service.RepositoryBranchInterface.FindById(ctx, tx, *pt.BranchId)
+		if err == sql.ErrNoRows {
+			panic(exceptions.NewBadRequest("Branch not found"))
+		}
+		helpers.PanicIfError(err)
+		if motherBrandId != nil && branch.MotherBrandId != nil && *branch.MotherBrandId != *motherBrandId {
+			panic(exceptions.NewBadRequest(fmt.Sprintf("Branch %s belongs to Mother Brand %s", branch.Name, branch.MotherBrandName)))
 		}
 	}
 }
@@ -451,13 +777,14 @@ func (service *ServicePOIImpl) findOrCreateCategory(ctx context.Context, tx *sql
 	return &id
 }
 
-func (service *ServicePOIImpl) findOrCreateSubCategory(ctx context.Context, tx *sql.Tx, name string) *int {
+// findOrCreateSubCategory creates a missing sub-category under categoryId
+func (service *ServicePOIImpl) findOrCreateSubCategory(ctx context.Context, tx *sql.Tx, name string, categoryId *int) *int {
 	if name == "" {
 		return nil
 	}
 	sc, err := service.RepositorySubCategoryInterface.FindByName(ctx, tx, name)
 	if err == sql.ErrNoRows {
-		sc, err = service.RepositorySubCategoryInterface.Create(ctx, tx, models.SubCategory{Name: name})
+		sc, err = service.RepositorySubCategoryInterface.Create(ctx, tx, models.SubCategory{Name: name, CategoryId: categoryId})
 		helpers.PanicIfError(err)
 	} else {
 		helpers.PanicIfError(err)
@@ -481,13 +808,14 @@ func (service *ServicePOIImpl) findOrCreateMotherBrand(ctx context.Context, tx *
 	return &id
 }
 
-func (service *ServicePOIImpl) findOrCreateBranch(ctx context.Context, tx *sql.Tx, name string) *int {
+// findOrCreateBranch creates a missing branch under motherBrandId
+func (service *ServicePOIImpl) findOrCreateBranch(ctx context.Context, tx *sql.Tx, name string, motherBrandId *int) *int {
 	if name == "" {
 		return nil
 	}
 	br, err := service.RepositoryBranchInterface.FindByName(ctx, tx, name)
 	if err == sql.ErrNoRows {
-		br, err = service.RepositoryBranchInterface.Create(ctx, tx, models.Branch{Name: name})
+		br, err = service.RepositoryBranchInterface.Create(ctx, tx, models.Branch{Name: name, MotherBrandId: motherBrandId})
 		helpers.PanicIfError(err)
 	} else {
 		helpers.PanicIfError(err)
@@ -500,15 +828,18 @@ func (service *ServicePOIImpl) poiModelToResponse(poi models.POI) webPOI.POIResp
 	points := make([]webPOI.POIPointResponse, len(poi.Points))
 	for i, point := range poi.Points {
 		points[i] = webPOI.POIPointResponse{
-			Id:        point.Id,
-			POIName:   point.POIName,
-			Address:   point.Address,
-			Latitude:  point.Latitude,
-			Longitude: point.Longitude,
-			Branch:    point.BranchName,
-			BranchId:  point.BranchId,
-			CreatedAt: point.CreatedAt,
-			UpdatedAt: point.UpdatedAt,
+			Id:          point.Id,
+			POIName:     point.POIName,
+			Address:     point.Address,
+			Latitude:    point.Latitude,
+			Longitude:   point.Longitude,
+			ExternalKey: point.ExternalKey,
+			Branch:      point.BranchName,
+			BranchId:    point.BranchId,
+			CreatedAt:   point.CreatedAt,
+			UpdatedAt:   point.UpdatedAt,
+
+			OutsideIndonesia: !servicesGeocoding.InIndonesia(point.Latitude, point.Longitude),
 		}
 	}
 
@@ -532,11 +863,12 @@ func pointsFromInputs(inputs []webPOI.POIPointInput) []models.POIPoint {
 	out := make([]models.POIPoint, len(inputs))
 	for i, in := range inputs {
 		out[i] = models.POIPoint{
-			POIName:   in.POIName,
-			Address:   in.Address,
-			Latitude:  in.Latitude,
-			Longitude: in.Longitude,
-			BranchId:  in.BranchId,
+			POIName:     in.POIName,
+			Address:     in.Address,
+			Latitude:    in.Latitude,
+			Longitude:   in.Longitude,
+			ExternalKey: in.ExternalKey,
+			BranchId:    in.BranchId,
 		}
 	}
 	return out
@@ -544,80 +876,6 @@ func pointsFromInputs(inputs []webPOI.POIPointInput) []models.POIPoint {
 
 // --- Import helpers ---
 
-func parseXLSX(fileBytes []byte) ([][]string, error) {
-	f, err := excelize.OpenReader(bytes.NewReader(fileBytes))
-	if err != nil {
-		return nil, err
-	}
-	defer f.Close()
-
-	sheetName := f.GetSheetName(0)
-	return f.GetRows(sheetName)
-}
-
-func parseCSV(fileBytes []byte) ([][]string, error) {
-	reader := csv.NewReader(bytes.NewReader(fileBytes))
-	var rows [][]string
-	for {
-		record, err := reader.Read()
-		if err == io.EOF {
-			break
-		}
-		if err != nil {
-			return nil, err
-		}
-		rows = append(rows, record)
-	}
-	return rows, nil
-}
-
-func mapHeaderColumns(header []string) map[string]int {
-	colMap := make(map[string]int)
-	for i, h := range header {
-		normalized := strings.ToLower(strings.TrimSpace(h))
-		normalized = strings.ReplaceAll(normalized, "-", "_")
-		normalized = strings.ReplaceAll(normalized, " ", "_")
-
-		switch normalized {
-		case "category":
-			colMap["category"] = i
-		case "sub_category", "subcategory":
-			colMap["sub_category"] = i
-		case "mother_brand", "motherbrand":
-			colMap["mother_brand"] = i
-		case "brand":
-			colMap["brand"] = i
-		case "branch":
-			colMap["branch"] = i
-		case "poi_name", "poiname":
-			colMap["poi_name"] = i
-		case "address":
-			colMap["address"] = i
-		case "coordinate", "coordinates":
-			colMap["coordinate"] = i
-		}
-	}
-	return colMap
-}
-
-// isRowBlank reports whether every cell in the row is empty after trimming.
-func isRowBlank(row []string) bool {
-	for _, cell := range row {
-		if strings.TrimSpace(cell) != "" {
-			return false
-		}
-	}
-	return true
-}
-
-func getColValue(row []string, colMap map[string]int, key string) string {
-	idx, exists := colMap[key]
-	if !exists || idx >= len(row) {
-		return ""
-	}
-	return strings.TrimSpace(row[idx])
-}
-
 func parseCoordinate(coord string) (float64, float64) {
 	if coord == "" {
 		return 0, 0
@@ -653,7 +911,7 @@ func buildPOIExcel(pois []models.POI) ([]byte, error) {
 	const sheet = "Sheet1"
 	sheetName := "POI Data"
 
-	headers := []string{"Category", "Sub-Category", "Mother Brand", "Brand", "Branch", "POI Name", "Address", "Coordinate"}
+	headers := []string{"Category", "Sub-Category", "Mother Brand", "Brand", "Branch", "POI Name", "Address", "Coordinate", "External Key"}
 	for i, h := range headers {
 		cell, _ := excelize.CoordinatesToCellName(i+1, 1)
 		_ = f.SetCellValue(sheet, cell, h)
@@ -675,6 +933,7 @@ func buildPOIExcel(pois []models.POI) ([]byte, error) {
 			_ = f.SetCellValue(sheet, mustCell(6, rowIdx), point.POIName)
 			_ = f.SetCellValue(sheet, mustCell(7, rowIdx), point.Address)
 			_ = f.SetCellValue(sheet, mustCell(8, rowIdx), coordinate)
+			_ = f.SetCellValue(sheet, mustCell(9, rowIdx), point.ExternalKey)
 			rowIdx++
 		}
 	}
@@ -0,0 +1,548 @@
+package poi_test
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/malikabdulaziz/tmn-backend/exceptions"
+	"github.com/malikabdulaziz/tmn-backend/importer"
+	"github.com/malikabdulaziz/tmn-backend/models"
+	serviceGeocoding "github.com/malikabdulaziz/tmn-backend/services/geocoding"
+	servicePOI "github.com/malikabdulaziz/tmn-backend/services/poi"
+	"github.com/malikabdulaziz/tmn-backend/testutil"
+	"github.com/malikabdulaziz/tmn-backend/testutil/mocks"
+	"github.com/malikabdulaziz/tmn-backend/web"
+	webPOI "github.com/malikabdulaziz/tmn-backend/web/poi"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/xuri/excelize/v2"
+)
+
+type poiServiceMocks struct {
+	poi           *mocks.MockRepositoryPOI
+	category      *mocks.MockRepositoryCategory
+	subCategory   *mocks.MockRepositorySubCategory
+	motherBrand   *mocks.MockRepositoryMotherBrand
+	branch        *mocks.MockRepositoryBranch
+	importPreview *mocks.MockRepositoryImportPreview
+	geocodeCache  *mocks.MockRepositoryGeocodeCache
+}
+
+// newPOIService runs with geocoding disabled
+func newPOIService(db *sql.DB) (servicePOI.ServicePOIInterface, poiServiceMocks) {
+	return newPOIServiceWithGeocoder(db, nil)
+}
+
+func newPOIServiceWithGeocoder(db *sql.DB, provider serviceGeocoding.Provider) (servicePOI.ServicePOIInterface, poiServiceMocks) {
+	m := poiServiceMocks{
+		poi:           &mocks.MockRepositoryPOI{},
+		category:      &mocks.MockRepositoryCategory{},
+		subCategory:   &mocks.MockRepositorySubCategory{},
+		motherBrand:   &mocks.MockRepositoryMotherBrand{},
+		branch:        &mocks.MockRepositoryBranch{},
+		importPreview: &mocks.MockRepositoryImportPreview{},
+		geocodeCache:  &mocks.MockRepositoryGeocodeCache{},
+	}
+	geocoder := serviceGeocoding.NewServiceGeocodingImpl(db, m.geocodeCache, provider, logrus.New())
+	svc := servicePOI.NewServicePOIImpl(db, m.poi, m.category, m.subCategory, m.motherBrand, m.branch, m.importPreview, geocoder)
+	return svc, m
+}
+
+const starbucksCSV = `Category,Brand,Branch,POI Name,Address,Coordinate
+Coffee,Starbucks,Jakarta,Sarinah,Jl. Thamrin 11,"-6.1870, 106.8230"
+Coffee,Starbucks,Jakarta,Senayan City,Jl. Asia Afrika 19,"-6.2270, 106.7970"
+Coffee,Starbucks,Jakarta,Kota Kasablanka,Jl. Casablanca 88,not-a-coordinate
+`
+
+func existingStarbucks() []models.POI {
+	return []models.POI{{
+		Id:    7,
+		Brand: "Starbucks",
+		Points: []models.POIPoint{
+			{Id: 70, POIId: 7, POIName: "Sarinah", Address: "Jl. Thamrin 11", Latitude: -6.1860, Longitude: 106.8230},
+			{Id: 71, POIId: 7, POIName: "Plaza Indonesia", Address: "Jl. Thamrin 28", Latitude: -6.1930, Longitude: 106.8220},
+		},
+	}}
+}
+
+// --- PreviewImport ---
+
+func TestPOIPreviewImport_DiffAgainstExistingBrand(t *testing.T) {
+	db, sqlMock := testutil.NewMockDB(t)
+	svc, m := newPOIService(db)
+
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectCommit()
+
+	m.poi.On("FindByBrands", mock.Anything, mock.AnythingOfType("*sql.Tx"), []string{"Starbucks"}).Return(existingStarbucks(), nil)
+	m.category.On("FindByName", mock.Anything, mock.AnythingOfType("*sql.Tx"), "Coffee").Return(models.Category{}, sql.ErrNoRows)
+	m.branch.On("FindByName", mock.Anything, mock.AnythingOfType("*sql.Tx"), "Jakarta").Return(models.Branch{Id: 3, Name: "Jakarta"}, nil)
+	m.importPreview.On("DeleteExpired", mock.Anything, mock.AnythingOfType("*sql.Tx")).Return(0, nil)
+	m.importPreview.On("Create", mock.Anything, mock.AnythingOfType("*sql.Tx"),
+		mock.MatchedBy(func(p models.ImportPreview) bool {
+			return p.Kind == models.ImportPreviewKindPOI && p.FileType == "csv" && len(p.Token) == 64 && len(p.Fingerprint) == 64
+		}), mock.Anything,
+	).Return(models.ImportPreview{Id: 1, Token: "tok", ExpiresAt: "2026-10-18T10:30:00Z"}, nil)
+
+	preview := svc.PreviewImport(context.Background(), []byte(starbucksCSV), "csv", webPOI.POIImportOptions{})
+
+	assert.Equal(t, "tok", preview.Token)
+	assert.Equal(t, webPOI.POIImportSummary{
+		POIsReplaced:     1,
+		PointsAdded:      2,
+		PointsRemoved:    1,
+		PointsMoved:      1,
+		CoordinateErrors: 1,
+	}, preview.Summary)
+	assert.Equal(t, "replace", preview.POIs[0].Action)
+	assert.Equal(t, []int{7}, preview.POIs[0].ExistingPOIIds)
+	assert.Equal(t, 70, *preview.POIs[0].PointsMoved[0].ExistingPointId)
+	assert.Equal(t, -6.1860, *preview.POIs[0].PointsMoved[0].PreviousLatitude)
+	assert.Equal(t, "Plaza Indonesia", preview.POIs[0].PointsRemoved[0].POIName)
+	assert.Equal(t, []string{"Coffee"}, preview.MasterDataToCreate.Categories)
+	assert.Empty(t, preview.MasterDataToCreate.Branches)
+	assert.Equal(t, 4, preview.CoordinateErrors[0].Row)
+
+	m.poi.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything, mock.Anything)
+	m.category.AssertNotCalled(t, "Create", mock.Anything, mock.Anything, mock.Anything)
+	m.importPreview.AssertExpectations(t)
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+}
+
+func TestPOIPreviewImport_MissingCoordinateColumn(t *testing.T) {
+	db, sqlMock := testutil.NewMockDB(t)
+	svc, _ := newPOIService(db)
+
+	assert.PanicsWithValue(t,
+		exceptions.BadRequestError{Error: "Missing required column: coordinate"},
+		func() {
+			svc.PreviewImport(context.Background(), []byte("Brand,POI Name\nStarbucks,Sarinah\n"), "csv", webPOI.POIImportOptions{})
+		},
+	)
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+}
+
+// --- ConfirmImport ---
+
+func TestPOIConfirmImport_UnknownToken(t *testing.T) {
+	db, sqlMock := testutil.NewMockDB(t)
+	svc, m := newPOIService(db)
+
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectRollback()
+
+	m.importPreview.On("FindByToken", mock.Anything, mock.AnythingOfType("*sql.Tx"), models.ImportPreviewKindPOI, "missing").
+		Return(models.ImportPreview{}, sql.ErrNoRows)
+
+	assert.PanicsWithValue(t,
+		exceptions.NotFoundError{Error: "Import preview not found or expired"},
+		func() {
+			svc.ConfirmImport(context.Background(), webPOI.ConfirmPOIImportRequest{Token: "missing"})
+		},
+	)
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+}
+
+func TestPOIConfirmImport_RejectsWhenDataChanged(t *testing.T) {
+	db, sqlMock := testutil.NewMockDB(t)
+	svc, m := newPOIService(db)
+
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectCommit()
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectRollback()
+
+	m.importPreview.On("FindByToken", mock.Anything, mock.AnythingOfType("*sql.Tx"), models.ImportPreviewKindPOI, "tok").
+		Return(models.ImportPreview{Id: 1, Token: "tok", FileType: "csv", FileBytes: []byte(starbucksCSV), Fingerprint: "stale"}, nil)
+	m.poi.On("FindByBrands", mock.Anything, mock.AnythingOfType("*sql.Tx"), []string{"Starbucks"}).Return(existingStarbucks(), nil)
+	m.category.On("FindByName", mock.Anything, mock.AnythingOfType("*sql.Tx"), "Coffee").Return(models.Category{Id: 2, Name: "Coffee"}, nil)
+	m.branch.On("FindByName", mock.Anything, mock.AnythingOfType("*sql.Tx"), "Jakarta").Return(models.Branch{Id: 3, Name: "Jakarta"}, nil)
+
+	assert.PanicsWithValue(t,
+		exceptions.BadRequestError{Error: "POI data changed since this preview was generated. Please preview the import again."},
+		func() {
+			svc.ConfirmImport(context.Background(), webPOI.ConfirmPOIImportRequest{Token: "tok"})
+		},
+	)
+	m.poi.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything, mock.Anything)
+	m.importPreview.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything, mock.Anything)
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+}
+
+// --- Import (upsert) ---
+
+const starbucksUpsertCSV = `Category,Brand,Branch,POI Name,Address,Coordinate,External Key
+Coffee,Starbucks,Jakarta,Sarinah,Jl. Thamrin 11,"-6.1870, 106.8230",SB-001
+Coffee,Starbucks,Jakarta,Senayan City,Jl. Asia Afrika 19,"-6.2270, 106.7970",SB-002
+`
+
+func TestPOIImport_UpsertKeepsIdsAndColor(t *testing.T) {
+	db, sqlMock := testutil.NewMockDB(t)
+	svc, m := newPOIService(db)
+
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectCommit()
+
+	existing := existingStarbucks()
+	existing[0].Color = "#388E3C"
+	m.poi.On("FindByBrands", mock.Anything, mock.AnythingOfType("*sql.Tx"), []string{"Starbucks"}).Return(existing, nil)
+	m.category.On("FindByName", mock.Anything, mock.AnythingOfType("*sql.Tx"), "Coffee").Return(models.Category{Id: 2, Name: "Coffee"}, nil)
+	m.branch.On("FindByName", mock.Anything, mock.AnythingOfType("*sql.Tx"), "Jakarta").Return(models.Branch{Id: 3, Name: "Jakarta"}, nil)
+	m.poi.On("UpdatePoint", mock.Anything, mock.AnythingOfType("*sql.Tx"),
+		mock.MatchedBy(func(p models.POIPoint) bool { return p.Id == 70 && p.ExternalKey == "SB-001" && p.Latitude == -6.1870 }),
+	).Return(models.POIPoint{Id: 70}, nil)
+	m.poi.On("CreatePoint", mock.Anything, mock.AnythingOfType("*sql.Tx"), 7,
+		mock.MatchedBy(func(p models.POIPoint) bool { return p.POIName == "Senayan City" && p.ExternalKey == "SB-002" }),
+	).Return(models.POIPoint{Id: 72}, nil)
+	m.poi.On("UpdateMetadata", mock.Anything, mock.AnythingOfType("*sql.Tx"),
+		mock.MatchedBy(func(p models.POI) bool { return p.Id == 7 && p.Color == "#388E3C" && *p.CategoryId == 2 }),
+	).Return(models.POI{Id: 7, Brand: "Starbucks", Color: "#388E3C"}, nil)
+
+	responses, _ := svc.Import(context.Background(), []byte(starbucksUpsertCSV), "csv", webPOI.POIImportOptions{Mode: webPOI.POIImportModeUpsert})
+
+	assert.Len(t, responses, 1)
+	assert.Equal(t, 7, responses[0].Id)
+	assert.Equal(t, "#388E3C", responses[0].Color)
+	m.poi.AssertExpectations(t)
+	m.poi.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything, mock.Anything)
+	m.poi.AssertNotCalled(t, "DeletePoints", mock.Anything, mock.Anything, mock.Anything)
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+}
+
+func TestPOIImport_UpsertDeleteMissing(t *testing.T) {
+	db, sqlMock := testutil.NewMockDB(t)
+	svc, m := newPOIService(db)
+
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectCommit()
+
+	m.poi.On("FindByBrands", mock.Anything, mock.AnythingOfType("*sql.Tx"), []string{"Starbucks"}).Return(existingStarbucks(), nil)
+	m.category.On("FindByName", mock.Anything, mock.AnythingOfType("*sql.Tx"), "Coffee").Return(models.Category{Id: 2, Name: "Coffee"}, nil)
+	m.branch.On("FindByName", mock.Anything, mock.AnythingOfType("*sql.Tx"), "Jakarta").Return(models.Branch{Id: 3, Name: "Jakarta"}, nil)
+	m.poi.On("UpdatePoint", mock.Anything, mock.AnythingOfType("*sql.Tx"), mock.Anything).Return(models.POIPoint{}, nil)
+	m.poi.On("CreatePoint", mock.Anything, mock.AnythingOfType("*sql.Tx"), 7, mock.Anything).Return(models.POIPoint{}, nil)
+	m.poi.On("DeletePoints", mock.Anything, mock.AnythingOfType("*sql.Tx"), []int{71}).Return(nil)
+	m.poi.On("UpdateMetadata", mock.Anything, mock.AnythingOfType("*sql.Tx"), mock.Anything).Return(models.POI{Id: 7}, nil)
+
+	svc.Import(context.Background(), []byte(starbucksUpsertCSV), "csv", webPOI.POIImportOptions{Mode: webPOI.POIImportModeUpsert, DeleteMissing: true})
+
+	m.poi.AssertCalled(t, "DeletePoints", mock.Anything, mock.AnythingOfType("*sql.Tx"), []int{71})
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+}
+
+func TestPOIPreviewImport_UpsertKeepsMissingPoints(t *testing.T) {
+	db, sqlMock := testutil.NewMockDB(t)
+	svc, m := newPOIService(db)
+
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectCommit()
+
+	existing := existingStarbucks()
+	// Renamed in the file, but still matched through its external key
+	existing[0].Points[0].POIName = "Sarinah Thamrin"
+	existing[0].Points[0].ExternalKey = "SB-001"
+	m.poi.On("FindByBrands", mock.Anything, mock.AnythingOfType("*sql.Tx"), []string{"Starbucks"}).Return(existing, nil)
+	m.category.On("FindByName", mock.Anything, mock.AnythingOfType("*sql.Tx"), "Coffee").Return(models.Category{Id: 2, Name: "Coffee"}, nil)
+	m.branch.On("FindByName", mock.Anything, mock.AnythingOfType("*sql.Tx"), "Jakarta").Return(models.Branch{Id: 3, Name: "Jakarta"}, nil)
+	m.importPreview.On("DeleteExpired", mock.Anything, mock.AnythingOfType("*sql.Tx")).Return(0, nil)
+	m.importPreview.On("Create", mock.Anything, mock.AnythingOfType("*sql.Tx"),
+		mock.MatchedBy(func(p models.ImportPreview) bool {
+			return p.Options == `{"mode":"upsert","delete_missing":false,"on_error":"abort"}`
+		}), mock.Anything,
+	).Return(models.ImportPreview{Id: 1, Token: "tok"}, nil)
+
+	preview := svc.PreviewImport(context.Background(), []byte(starbucksUpsertCSV), "csv", webPOI.POIImportOptions{Mode: "UPSERT"})
+
+	assert.Equal(t, webPOI.POIImportSummary{
+		POIsUpdated: 1,
+		PointsAdded: 1,
+		PointsMoved: 1,
+		PointsKept:  1,
+	}, preview.Summary)
+	assert.Equal(t, "update", preview.POIs[0].Action)
+	assert.Equal(t, 70, *preview.POIs[0].PointsMoved[0].ExistingPointId)
+	assert.Empty(t, preview.POIs[0].PointsRemoved)
+	m.importPreview.AssertExpectations(t)
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+}
+
+func TestPOIImport_UnknownMode(t *testing.T) {
+	db, sqlMock := testutil.NewMockDB(t)
+	svc, _ := newPOIService(db)
+
+	assert.PanicsWithValue(t,
+		exceptions.BadRequestError{Error: "Unsupported import mode: merge. Use replace or upsert."},
+		func() {
+			svc.Import(context.Background(), []byte(starbucksUpsertCSV), "csv", webPOI.POIImportOptions{Mode: "merge"})
+		},
+	)
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+}
+
+func TestPOIPreviewImport_SkipsMismatchedRows(t *testing.T) {
+	db, sqlMock := testutil.NewMockDB(t)
+	svc, m := newPOIService(db)
+
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectCommit()
+
+	csvData := `Category,Brand,POI Name,Address,Coordinate
+Coffee,Starbucks,Sarinah,Jl. Thamrin 11,"-6.1870, 106.8230"
+Tea,Starbucks,Senayan City,Jl. Asia Afrika 19,"-6.2270, 106.7970"
+Coffee,Starbucks,Sarinah,Jl. Thamrin 11,"-6.1870, 106.8230"
+`
+	m.poi.On("FindByBrands", mock.Anything, mock.AnythingOfType("*sql.Tx"), []string{"Starbucks"}).Return([]models.POI{}, nil)
+	m.category.On("FindByName", mock.Anything, mock.AnythingOfType("*sql.Tx"), "Coffee").Return(models.Category{Id: 2, Name: "Coffee"}, nil)
+	m.importPreview.On("DeleteExpired", mock.Anything, mock.AnythingOfType("*sql.Tx")).Return(0, nil)
+	m.importPreview.On("Create", mock.Anything, mock.AnythingOfType("*sql.Tx"), mock.Anything, mock.Anything).
+		Return(models.ImportPreview{Id: 1, Token: "tok"}, nil)
+
+	preview := svc.PreviewImport(context.Background(), []byte(csvData), "csv", webPOI.POIImportOptions{OnError: importer.OnErrorSkip})
+
+	assert.Equal(t, 1, preview.Summary.PointsAdded)
+	assert.Equal(t, 2, preview.Summary.RowsSkipped)
+	assert.Equal(t, []int{2}, preview.POIs[0].Rows)
+	assert.Equal(t, 3, preview.RowErrors[0].Row)
+	assert.Equal(t, "Category", preview.RowErrors[0].Column)
+	assert.Equal(t, "Same POI name and address as row 2 of this brand", preview.RowErrors[1].Reason)
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+}
+
+func TestPOIImport_AbortsOnRowErrors(t *testing.T) {
+	db, sqlMock := testutil.NewMockDB(t)
+	svc, m := newPOIService(db)
+
+	csvData := "Brand,POI Name,Coordinate\n,Sarinah,\"-6.1870, 106.8230\"\n"
+
+	defer func() {
+		err, ok := recover().(exceptions.BadRequestError)
+		assert.True(t, ok)
+		report := err.Extras.(web.ImportReport)
+		assert.Equal(t, []web.ImportRowError{{Row: 2, Column: "Brand", Value: "", Reason: "Value is required"}}, report.Errors)
+		m.poi.AssertNotCalled(t, "FindByBrands", mock.Anything, mock.Anything, mock.Anything)
+		assert.NoError(t, sqlMock.ExpectationsWereMet())
+	}()
+	svc.Import(context.Background(), []byte(csvData), "csv", webPOI.POIImportOptions{})
+	t.Fatal("Import should panic")
+}
+
+func TestPOIImport_GeocodesAddressOnlyRows(t *testing.T) {
+	db, sqlMock := testutil.NewMockDB(t)
+	svc, m := newPOIServiceWithGeocoder(db, serviceGeocoding.NewGazetteerProvider([]serviceGeocoding.Result{
+		{Address: "Jl. Thamrin 11, Jakarta", Latitude: -6.1870, Longitude: 106.8230},
+		{Address: "Shibuya, Tokyo", Latitude: 35.6595, Longitude: 139.7005},
+	}))
+
+	csvData := `Brand,POI Name,Address,Coordinate
+Starbucks,Sarinah,"jl. thamrin 11,  jakarta",
+Starbucks,Unknown,Jl. Nowhere 1,
+Starbucks,Shibuya,,"35.6595, 139.7005"
+`
+	// Forward lookups for both address-only rows, then a reverse lookup for the address-less
+	// row: each misses the cache, asks the gazetteer and stores the answer
+	for i := 0; i < 3; i++ {
+		sqlMock.ExpectBegin()
+		sqlMock.ExpectRollback()
+		sqlMock.ExpectBegin()
+		sqlMock.ExpectCommit()
+	}
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectCommit()
+
+	m.geocodeCache.On("Find", mock.Anything, mock.AnythingOfType("*sql.Tx"), "gazetteer", mock.Anything, mock.Anything).Return(models.GeocodeCache{}, sql.ErrNoRows)
+	m.geocodeCache.On("Save", mock.Anything, mock.AnythingOfType("*sql.Tx"), mock.Anything).Return(nil)
+	m.poi.On("FindByBrands", mock.Anything, mock.AnythingOfType("*sql.Tx"), []string{"Starbucks"}).Return([]models.POI{}, nil)
+	m.poi.On("Create", mock.Anything, mock.AnythingOfType("*sql.Tx"), mock.Anything, mock.MatchedBy(func(points []models.POIPoint) bool {
+		return len(points) == 3 &&
+			points[0].Latitude == -6.1870 && points[0].Longitude == 106.8230 &&
+			points[1].Latitude == 0 &&
+			points[2].Address == "Shibuya, Tokyo"
+	})).Return(models.POI{Id: 1, Brand: "Starbucks", Points: []models.POIPoint{
+		{POIName: "Sarinah", Latitude: -6.1870, Longitude: 106.8230},
+		{POIName: "Shibuya", Latitude: 35.6595, Longitude: 139.7005},
+	}}, nil)
+
+	responses, _ := svc.Import(context.Background(), []byte(csvData), "csv", webPOI.POIImportOptions{})
+
+	assert.False(t, responses[0].Points[0].OutsideIndonesia)
+	assert.True(t, responses[0].Points[1].OutsideIndonesia)
+	m.geocodeCache.AssertNumberOfCalls(t, "Save", 3)
+	m.poi.AssertExpectations(t)
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+}
+
+func TestPOIPreviewImport_GeocodingSummary(t *testing.T) {
+	db, sqlMock := testutil.NewMockDB(t)
+	svc, m := newPOIServiceWithGeocoder(db, serviceGeocoding.NewGazetteerProvider(nil))
+
+	csvData := `Brand,POI Name,Address,Coordinate
+Starbucks,Sarinah,Jl. Thamrin 11,
+Starbucks,Shibuya,Shibuya Crossing,"35.6595, 139.7005"
+`
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectRollback()
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectCommit()
+
+	// The miss on Jl. Thamrin 11 is cached, so the gazetteer is not asked again
+	m.geocodeCache.On("Find", mock.Anything, mock.AnythingOfType("*sql.Tx"), "gazetteer", models.GeocodeKindForward, "jl. thamrin 11").Return(models.GeocodeCache{Found: false}, nil)
+	m.poi.On("FindByBrands", mock.Anything, mock.AnythingOfType("*sql.Tx"), []string{"Starbucks"}).Return([]models.POI{}, nil)
+	m.importPreview.On("DeleteExpired", mock.Anything, mock.AnythingOfType("*sql.Tx")).Return(0, nil)
+	m.importPreview.On("Create", mock.Anything, mock.AnythingOfType("*sql.Tx"), mock.Anything, mock.Anything).
+		Return(models.ImportPreview{Id: 1, Token: "tok"}, nil)
+
+	preview := svc.PreviewImport(context.Background(), []byte(csvData), "csv", webPOI.POIImportOptions{})
+
+	assert.Equal(t, []webPOI.POIImportCoordinateError{
+		{Row: 2, Brand: "Starbucks", Value: "", Reason: "Coordinate is empty and the address could not be geocoded"},
+		{Row: 3, Brand: "Starbucks", Value: "35.6595, 139.7005", Reason: "Coordinate is outside Indonesia"},
+	}, preview.CoordinateErrors)
+	assert.Equal(t, 0, preview.Summary.PointsGeocoded)
+	m.geocodeCache.AssertNotCalled(t, "Save", mock.Anything, mock.Anything, mock.Anything)
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+}
+
+func TestPOICreate_RequiresCoordinateOrAddress(t *testing.T) {
+	db, sqlMock := testutil.NewMockDB(t)
+	svc, _ := newPOIService(db)
+
+	assert.PanicsWithValue(t,
+		exceptions.NewBadRequest(`Address of point "Sarinah" could not be geocoded; enter its coordinate`),
+		func() {
+			svc.Create(context.Background(), webPOI.CreatePOIRequest{
+				Brand:  "Starbucks",
+				Color:  "#1976D2",
+				Points: []webPOI.POIPointInput{{POIName: "Sarinah", Address: "Jl. Thamrin 11"}},
+			})
+		},
+	)
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+}
+
+func TestPOICreate_SubCategoryOfAnotherCategory(t *testing.T) {
+	db, sqlMock := testutil.NewMockDB(t)
+	svc, m := newPOIService(db)
+
+	automotive, subCategory, retail := 3, 10, 1
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectRollback()
+	m.category.On("FindById", mock.Anything, mock.AnythingOfType("*sql.Tx"), 3).Return(models.Category{Id: 3, Name: "Automotive"}, nil)
+	m.subCategory.On("FindById", mock.Anything, mock.AnythingOfType("*sql.Tx"), 10).
+		Return(models.SubCategory{Id: 10, Name: "Coffee Shop", CategoryId: &retail, CategoryName: "Retail"}, nil)
+
+	assert.PanicsWithValue(t, exceptions.NewBadRequest("Sub-Category Coffee Shop belongs to Category Retail"), func() {
+		svc.Create(context.Background(), webPOI.CreatePOIRequest{
+			Brand:         "Starbucks",
+			Color:         "#1976D2",
+			CategoryId:    &automotive,
+			SubCategoryId: &subCategory,
+			Points:        []webPOI.POIPointInput{{POIName: "Sarinah", Address: "Jl. Thamrin 11", Latitude: -6.187, Longitude: 106.823}},
+		})
+	})
+	m.poi.AssertNotCalled(t, "Create", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+}
+
+func TestPOICreate_SubCategoryBringsItsCategory(t *testing.T) {
+	db, sqlMock := testutil.NewMockDB(t)
+	svc, m := newPOIService(db)
+
+	subCategory, retail := 10, 1
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectCommit()
+	m.subCategory.On("FindById", mock.Anything, mock.AnythingOfType("*sql.Tx"), 10).
+		Return(models.SubCategory{Id: 10, Name: "Coffee Shop", CategoryId: &retail, CategoryName: "Retail"}, nil)
+	m.poi.On("Create", mock.Anything, mock.AnythingOfType("*sql.Tx"),
+		mock.MatchedBy(func(p models.POI) bool { return p.CategoryId != nil && *p.CategoryId == 1 && *p.SubCategoryId == 10 }),
+		mock.Anything,
+	).Return(models.POI{Id: 9, Brand: "Starbucks", CategoryId: &retail, SubCategoryId: &subCategory}, nil)
+
+	response := svc.Create(context.Background(), webPOI.CreatePOIRequest{
+		Brand:         "Starbucks",
+		Color:         "#1976D2",
+		SubCategoryId: &subCategory,
+		Points:        []webPOI.POIPointInput{{POIName: "Sarinah", Address: "Jl. Thamrin 11", Latitude: -6.187, Longitude: 106.823}},
+	})
+
+	assert.Equal(t, 9, response.Id)
+	m.poi.AssertExpectations(t)
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+}
+
+func TestPOIImport_SkipsBranchOfAnotherMotherBrand(t *testing.T) {
+	db, sqlMock := testutil.NewMockDB(t)
+	svc, m := newPOIService(db)
+
+	csvData := `Mother Brand,Brand,Branch,POI Name,Address,Coordinate
+Starbucks Corp,Starbucks,Jakarta,Sarinah,Jl. Thamrin 11,"-6.1870, 106.8230"
+Starbucks Corp,Starbucks,Bandung,Paris Van Java,Jl. Sukajadi 131,"-6.8890, 107.5960"
+`
+	starbucksCorp, otherGroup := 5, 6
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectCommit()
+	m.poi.On("FindByBrands", mock.Anything, mock.AnythingOfType("*sql.Tx"), []string{"Starbucks"}).Return([]models.POI{}, nil)
+	m.motherBrand.On("FindByName", mock.Anything, mock.AnythingOfType("*sql.Tx"), "Starbucks Corp").Return(models.MotherBrand{Id: 5, Name: "Starbucks Corp"}, nil)
+	m.branch.On("FindByName", mock.Anything, mock.AnythingOfType("*sql.Tx"), "Jakarta").
+		Return(models.Branch{Id: 3, Name: "Jakarta", MotherBrandId: &starbucksCorp, MotherBrandName: "Starbucks Corp"}, nil)
+	m.branch.On("FindByName", mock.Anything, mock.AnythingOfType("*sql.Tx"), "Bandung").
+		Return(models.Branch{Id: 4, Name: "Bandung", MotherBrandId: &otherGroup, MotherBrandName: "Other Group"}, nil)
+	m.poi.On("Create", mock.Anything, mock.AnythingOfType("*sql.Tx"),
+		mock.MatchedBy(func(p models.POI) bool { return p.Brand == "Starbucks" && *p.MotherBrandId == 5 }),
+		mock.MatchedBy(func(points []models.POIPoint) bool { return len(points) == 1 && *points[0].BranchId == 3 }),
+	).Return(models.POI{Id: 9, Brand: "Starbucks"}, nil)
+
+	// geocoding and writing share one progress range; the skipped point counts as done
+	var progress [][2]int
+	ctx := importer.WithProgress(context.Background(), func(done int, total int) { progress = append(progress, [2]int{done, total}) })
+
+	responses, report := svc.Import(ctx, []byte(csvData), "csv", webPOI.POIImportOptions{OnError: importer.OnErrorSkip})
+
+	assert.Equal(t, [][2]int{{0, 4}, {4, 4}}, progress)
+	assert.Len(t, responses, 1)
+	assert.Equal(t, 1, report.SkippedRows)
+	assert.Equal(t, 3, report.Errors[0].Row)
+	assert.Equal(t, "Branch Bandung belongs to Mother Brand Other Group", report.Errors[0].Reason)
+	m.poi.AssertExpectations(t)
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+}
+
+// --- ImportTemplate ---
+
+func TestPOIImportTemplate_DropdownsFromMasterData(t *testing.T) {
+	db, sqlMock := testutil.NewMockDB(t)
+	svc, m := newPOIService(db)
+
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectCommit()
+
+	m.category.On("FindAllDropdown", mock.Anything, mock.AnythingOfType("*sql.Tx")).Return([]models.Category{{Id: 1, Name: "Coffee"}, {Id: 2, Name: "Fashion"}}, nil)
+	m.subCategory.On("FindAllDropdown", mock.Anything, mock.AnythingOfType("*sql.Tx")).Return([]models.SubCategory{{Id: 1, Name: "Cafe"}}, nil)
+	m.motherBrand.On("FindAllDropdown", mock.Anything, mock.AnythingOfType("*sql.Tx")).Return([]models.MotherBrand{}, nil)
+	m.branch.On("FindAllDropdown", mock.Anything, mock.AnythingOfType("*sql.Tx")).Return([]models.Branch{{Id: 1, Name: "Jakarta"}}, nil)
+
+	fileBytes, err := svc.ImportTemplate(context.Background())
+	assert.NoError(t, err)
+
+	f, err := excelize.OpenReader(bytes.NewReader(fileBytes))
+	assert.NoError(t, err)
+	defer f.Close()
+
+	rows, err := f.GetRows("POIs")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Category", "Sub-Category", "Mother Brand", "Brand", "Branch", "POI Name", "Address", "Coordinate", "External Key"}, rows[0])
+
+	lists, err := f.GetCols("Lists")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Category", "Coffee", "Fashion"}, lists[0])
+	assert.Equal(t, []string{"Sub-Category", "Cafe"}, lists[1])
+	assert.Equal(t, []string{"Branch", "Jakarta"}, lists[2])
+
+	validations, err := f.GetDataValidations("POIs")
+	assert.NoError(t, err)
+	assert.Len(t, validations, 3)
+
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+}
@@ -3,6 +3,7 @@ package poi
 import (
 	"context"
 
+	"github.com/malikabdulaziz/tmn-backend/web"
 	webPOI "github.com/malikabdulaziz/tmn-backend/web/poi"
 )
 
@@ -12,6 +13,9 @@ type ServicePOIInterface interface {
 	FindById(ctx context.Context, id int) webPOI.POIResponse
 	Update(ctx context.Context, request webPOI.UpdatePOIRequest, id int) webPOI.POIResponse
 	Delete(ctx context.Context, id int)
-	Import(ctx context.Context, fileBytes []byte, fileType string) []webPOI.POIResponse
+	Import(ctx context.Context, fileBytes []byte, fileType string, options webPOI.POIImportOptions) ([]webPOI.POIResponse, web.ImportReport)
+	PreviewImport(ctx context.Context, fileBytes []byte, fileType string, options webPOI.POIImportOptions) webPOI.POIImportPreviewResponse
+	ConfirmImport(ctx context.Context, request webPOI.ConfirmPOIImportRequest) ([]webPOI.POIResponse, web.ImportReport)
 	Export(ctx context.Context, search string, categoryIds string, subCategoryIds string, motherBrandIds string) ([]byte, error)
+	ImportTemplate(ctx context.Context) ([]byte, error)
 }
@@ -0,0 +1,188 @@
+package poiduplicate
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/malikabdulaziz/tmn-backend/exceptions"
+	"github.com/malikabdulaziz/tmn-backend/helpers"
+	"github.com/malikabdulaziz/tmn-backend/models"
+	repositoriesPOI "github.com/malikabdulaziz/tmn-backend/repositories/poi"
+	repositoriesPOIDuplicate "github.com/malikabdulaziz/tmn-backend/repositories/poiduplicate"
+	webPOIDuplicate "github.com/malikabdulaziz/tmn-backend/web/poiduplicate"
+)
+
+type ServicePOIDuplicateImpl struct {
+	DB                              *sql.DB
+	RepositoryPOIDuplicateInterface repositoriesPOIDuplicate.RepositoryPOIDuplicateInterface
+	RepositoryPOIInterface          repositoriesPOI.RepositoryPOIInterface
+}
+
+func NewServicePOIDuplicateImpl(
+	db *sql.DB,
+	repoPOIDuplicate repositoriesPOIDuplicate.RepositoryPOIDuplicateInterface,
+	repoPOI repositoriesPOI.RepositoryPOIInterface,
+) ServicePOIDuplicateInterface {
+	return &ServicePOIDuplicateImpl{
+		DB:                              db,
+		RepositoryPOIDuplicateInterface: repoPOIDuplicate,
+		RepositoryPOIInterface:          repoPOI,
+	}
+}
+
+// FindAll lists candidate duplicate pairs for review, most alike first
+func (service *ServicePOIDuplicateImpl) FindAll(ctx context.Context, request webPOIDuplicate.POIDuplicateRequestFindAll) ([]webPOIDuplicate.POIDuplicateResponse, int) {
+	filter := repositoriesPOIDuplicate.DuplicateFilter{
+		RadiusMeters:   request.GetRadiusMeters(),
+		SameSpotMeters: request.GetSameSpotMeters(),
+		MinSimilarity:  request.GetMinSimilarity(),
+		CategoryIds:    request.GetCategoryIds(),
+		MotherBrandIds: request.GetMotherBrandIds(),
+	}
+	if filter.RadiusMeters < 1 || filter.RadiusMeters > webPOIDuplicate.MaxRadiusMeters {
+		panic(exceptions.NewBadRequest(fmt.Sprintf("radius_meters must be between 1 and %d", webPOIDuplicate.MaxRadiusMeters)))
+	}
+	if filter.SameSpotMeters < 0 || filter.SameSpotMeters > filter.RadiusMeters {
+		panic(exceptions.NewBadRequest("same_spot_meters must be between 0 and radius_meters"))
+	}
+	if filter.MinSimilarity < 0 || filter.MinSimilarity > 1 {
+		panic(exceptions.NewBadRequest("min_similarity must be between 0 and 1"))
+	}
+
+	tx, err := service.DB.Begin()
+	helpers.PanicIfError(err)
+	defer helpers.CommitOrRollback(tx)
+
+	duplicates, err := service.RepositoryPOIDuplicateInterface.FindCandidates(ctx, tx, filter, request.GetTake(), request.GetSkip())
+	helpers.PanicIfError(err)
+
+	total, err := service.RepositoryPOIDuplicateInterface.CountCandidates(ctx, tx, filter)
+	helpers.PanicIfError(err)
+
+	responses := make([]webPOIDuplicate.POIDuplicateResponse, len(duplicates))
+	for i, duplicate := range duplicates {
+		responses[i] = webPOIDuplicate.POIDuplicateResponse{
+			Point:          pointToResponse(duplicate.Point, duplicate.Brand),
+			OtherPoint:     pointToResponse(duplicate.OtherPoint, duplicate.OtherBrand),
+			DistanceMeters: duplicate.DistanceMeters,
+			NameSimilarity: duplicate.NameSimilarity,
+		}
+	}
+	return responses, total
+}
+
+// Merge keeps one point and deletes the other. Fields the kept point lacks (name, address,
+// coordinate, branch, external key) are taken from the merged point, so its store code keeps
+// matching on the next upsert import. A POI left without points is deleted as well.
+func (service *ServicePOIDuplicateImpl) Merge(ctx context.Context, request webPOIDuplicate.MergePOIDuplicateRequest) webPOIDuplicate.MergePOIDuplicateResponse {
+	if request.KeepPointId == request.MergePointId {
+		panic(exceptions.NewBadRequest("A point cannot be merged into itself"))
+	}
+
+	tx, err := service.DB.Begin()
+	helpers.PanicIfError(err)
+	defer helpers.CommitOrRollback(tx)
+
+	kept := service.findPoint(ctx, tx, request.KeepPointId)
+	merged := service.findPoint(ctx, tx, request.MergePointId)
+
+	keptPOI, err := service.RepositoryPOIInterface.FindById(ctx, tx, kept.POIId)
+	helpers.PanicIfError(err)
+
+	if kept.POIName == "" {
+		kept.POIName = merged.POIName
+	}
+	if kept.Address == "" {
+		kept.Address = merged.Address
+	}
+	if kept.Latitude == 0 && kept.Longitude == 0 {
+		kept.Latitude, kept.Longitude = merged.Latitude, merged.Longitude
+	}
+	if kept.BranchId == nil {
+		kept.BranchId = merged.BranchId
+	}
+	if kept.ExternalKey == "" && merged.ExternalKey != "" && !externalKeyTaken(keptPOI, merged.ExternalKey, merged.Id) {
+		kept.ExternalKey = merged.ExternalKey
+	}
+
+	// Delete first: when both points share a POI the external key moves within the same
+	// (poi_id, external_key) unique index
+	err = service.RepositoryPOIInterface.DeletePoints(ctx, tx, []int{merged.Id})
+	helpers.PanicIfError(err)
+
+	kept, err = service.RepositoryPOIInterface.UpdatePoint(ctx, tx, kept)
+	helpers.PanicIfError(err)
+
+	response := webPOIDuplicate.MergePOIDuplicateResponse{
+		Point:         pointToResponse(kept, keptPOI.Brand),
+		MergedPointId: merged.Id,
+	}
+
+	if merged.POIId != kept.POIId {
+		mergedPOI, err := service.RepositoryPOIInterface.FindById(ctx, tx, merged.POIId)
+		helpers.PanicIfError(err)
+		if len(mergedPOI.Points) == 0 {
+			err = service.RepositoryPOIInterface.Delete(ctx, tx, mergedPOI.Id, helpers.OptionalUserIdFromContext(ctx))
+			helpers.PanicIfError(err)
+			response.RemovedPOIId = &mergedPOI.Id
+		}
+	}
+
+	return response
+}
+
+// Dismiss records that two points are distinct outlets so the pair is no longer listed
+func (service *ServicePOIDuplicateImpl) Dismiss(ctx context.Context, request webPOIDuplicate.DismissPOIDuplicateRequest) {
+	if request.PointId == request.OtherPointId {
+		panic(exceptions.NewBadRequest("A point cannot be a duplicate of itself"))
+	}
+
+	tx, err := service.DB.Begin()
+	helpers.PanicIfError(err)
+	defer helpers.CommitOrRollback(tx)
+
+	service.findPoint(ctx, tx, request.PointId)
+	service.findPoint(ctx, tx, request.OtherPointId)
+
+	var dismissedBy *int
+	if userId := helpers.UserIdFromContext(ctx); userId > 0 {
+		dismissedBy = &userId
+	}
+	err = service.RepositoryPOIDuplicateInterface.Dismiss(ctx, tx, request.PointId, request.OtherPointId, dismissedBy)
+	helpers.PanicIfError(err)
+}
+
+func (service *ServicePOIDuplicateImpl) findPoint(ctx context.Context, tx *sql.Tx, id int) models.POIPoint {
+	point, err := service.RepositoryPOIDuplicateInterface.FindPointById(ctx, tx, id)
+	if err == sql.ErrNoRows {
+		panic(exceptions.NewNotFoundError(fmt.Sprintf("POI point %d not found", id)))
+	}
+	helpers.PanicIfError(err)
+	return point
+}
+
+// externalKeyTaken reports whether another point of poi than exceptId already uses key
+func externalKeyTaken(poi models.POI, key string, exceptId int) bool {
+	for _, pt := range poi.Points {
+		if pt.Id != exceptId && pt.ExternalKey == key {
+			return true
+		}
+	}
+	return false
+}
+
+func pointToResponse(point models.POIPoint, brand string) webPOIDuplicate.POIDuplicatePointResponse {
+	return webPOIDuplicate.POIDuplicatePointResponse{
+		Id:          point.Id,
+		POIId:       point.POIId,
+		Brand:       brand,
+		POIName:     point.POIName,
+		Address:     point.Address,
+		Latitude:    point.Latitude,
+		Longitude:   point.Longitude,
+		ExternalKey: point.ExternalKey,
+		Branch:      point.BranchName,
+		BranchId:    point.BranchId,
+	}
+}
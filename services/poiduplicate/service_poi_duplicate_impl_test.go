@@ -0,0 +1,166 @@
+package poiduplicate_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/malikabdulaziz/tmn-backend/exceptions"
+	"github.com/malikabdulaziz/tmn-backend/helpers"
+	"github.com/malikabdulaziz/tmn-backend/models"
+	repositoriesPOIDuplicate "github.com/malikabdulaziz/tmn-backend/repositories/poiduplicate"
+	servicePOIDuplicate "github.com/malikabdulaziz/tmn-backend/services/poiduplicate"
+	"github.com/malikabdulaziz/tmn-backend/testutil"
+	"github.com/malikabdulaziz/tmn-backend/testutil/mocks"
+	webPOIDuplicate "github.com/malikabdulaziz/tmn-backend/web/poiduplicate"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newPOIDuplicateService(db *sql.DB) (servicePOIDuplicate.ServicePOIDuplicateInterface, *mocks.MockRepositoryPOIDuplicate, *mocks.MockRepositoryPOI) {
+	repo := &mocks.MockRepositoryPOIDuplicate{}
+	poiRepo := &mocks.MockRepositoryPOI{}
+	return servicePOIDuplicate.NewServicePOIDuplicateImpl(db, repo, poiRepo), repo, poiRepo
+}
+
+// --- FindAll ---
+
+func TestPOIDuplicateFindAll_Defaults(t *testing.T) {
+	db, sqlMock := testutil.NewMockDB(t)
+	svc, repo, _ := newPOIDuplicateService(db)
+
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectCommit()
+
+	filter := repositoriesPOIDuplicate.DuplicateFilter{RadiusMeters: 50, SameSpotMeters: 10, MinSimilarity: 0.4, CategoryIds: "2"}
+	repo.On("FindCandidates", mock.Anything, mock.AnythingOfType("*sql.Tx"), filter, 50, 0).Return([]models.POIPointDuplicate{{
+		Point:          models.POIPoint{Id: 10, POIId: 1, POIName: "Sarinah"},
+		Brand:          "Starbucks",
+		OtherPoint:     models.POIPoint{Id: 20, POIId: 2, POIName: "Sarinah Thamrin"},
+		OtherBrand:     "Starbucks Coffee",
+		DistanceMeters: 4.2,
+		NameSimilarity: 0.71,
+	}}, nil)
+	repo.On("CountCandidates", mock.Anything, mock.AnythingOfType("*sql.Tx"), filter).Return(1, nil)
+
+	request := webPOIDuplicate.POIDuplicateRequestFindAll{}
+	request.SetCategoryIds("2")
+	duplicates, total := svc.FindAll(context.Background(), request)
+
+	assert.Equal(t, 1, total)
+	assert.Equal(t, "Starbucks Coffee", duplicates[0].OtherPoint.Brand)
+	assert.Equal(t, 0.71, duplicates[0].NameSimilarity)
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+}
+
+func TestPOIDuplicateFindAll_InvalidSimilarity(t *testing.T) {
+	db, sqlMock := testutil.NewMockDB(t)
+	svc, _, _ := newPOIDuplicateService(db)
+
+	request := webPOIDuplicate.POIDuplicateRequestFindAll{}
+	request.SetMinSimilarity(1.5)
+
+	assert.PanicsWithValue(t, exceptions.NewBadRequest("min_similarity must be between 0 and 1"), func() {
+		svc.FindAll(context.Background(), request)
+	})
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+}
+
+// --- Merge ---
+
+func TestPOIDuplicateMerge_FillsKeptPointAndDropsEmptyPOI(t *testing.T) {
+	db, sqlMock := testutil.NewMockDB(t)
+	svc, repo, poiRepo := newPOIDuplicateService(db)
+
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectCommit()
+
+	branchId := 3
+	kept := models.POIPoint{Id: 10, POIId: 1, POIName: "Sarinah", Latitude: -6.1870, Longitude: 106.8230}
+	merged := models.POIPoint{Id: 20, POIId: 2, POIName: "Sarinah Thamrin", Address: "Jl. Thamrin 11", ExternalKey: "SBX-001", BranchId: &branchId, Latitude: -6.1871, Longitude: 106.8231}
+
+	repo.On("FindPointById", mock.Anything, mock.AnythingOfType("*sql.Tx"), 10).Return(kept, nil)
+	repo.On("FindPointById", mock.Anything, mock.AnythingOfType("*sql.Tx"), 20).Return(merged, nil)
+	poiRepo.On("FindById", mock.Anything, mock.AnythingOfType("*sql.Tx"), 1).Return(models.POI{Id: 1, Brand: "Starbucks", Points: []models.POIPoint{kept}}, nil)
+	poiRepo.On("DeletePoints", mock.Anything, mock.AnythingOfType("*sql.Tx"), []int{20}).Return(nil)
+	poiRepo.On("UpdatePoint", mock.Anything, mock.AnythingOfType("*sql.Tx"), mock.MatchedBy(func(pt models.POIPoint) bool {
+		return pt.Id == 10 && pt.POIName == "Sarinah" && pt.Address == "Jl. Thamrin 11" &&
+			pt.ExternalKey == "SBX-001" && pt.BranchId != nil && *pt.BranchId == 3 && pt.Latitude == -6.1870
+	})).Return(models.POIPoint{Id: 10, POIId: 1, POIName: "Sarinah", Address: "Jl. Thamrin 11", ExternalKey: "SBX-001"}, nil)
+	poiRepo.On("FindById", mock.Anything, mock.AnythingOfType("*sql.Tx"), 2).Return(models.POI{Id: 2, Brand: "Starbucks Coffee", Points: []models.POIPoint{}}, nil)
+	poiRepo.On("Delete", mock.Anything, mock.AnythingOfType("*sql.Tx"), 2, (*int)(nil)).Return(nil)
+
+	response := svc.Merge(context.Background(), webPOIDuplicate.MergePOIDuplicateRequest{KeepPointId: 10, MergePointId: 20})
+
+	assert.Equal(t, 10, response.Point.Id)
+	assert.Equal(t, "Starbucks", response.Point.Brand)
+	assert.Equal(t, 20, response.MergedPointId)
+	assert.Equal(t, 2, *response.RemovedPOIId)
+	poiRepo.AssertExpectations(t)
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+}
+
+func TestPOIDuplicateMerge_KeepsExternalKeyUniquePerPOI(t *testing.T) {
+	db, sqlMock := testutil.NewMockDB(t)
+	svc, repo, poiRepo := newPOIDuplicateService(db)
+
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectCommit()
+
+	kept := models.POIPoint{Id: 10, POIId: 1, POIName: "Sarinah"}
+	other := models.POIPoint{Id: 11, POIId: 1, POIName: "Plaza Indonesia", ExternalKey: "SBX-001"}
+	merged := models.POIPoint{Id: 20, POIId: 2, POIName: "Sarinah", ExternalKey: "SBX-001"}
+
+	repo.On("FindPointById", mock.Anything, mock.AnythingOfType("*sql.Tx"), 10).Return(kept, nil)
+	repo.On("FindPointById", mock.Anything, mock.AnythingOfType("*sql.Tx"), 20).Return(merged, nil)
+	poiRepo.On("FindById", mock.Anything, mock.AnythingOfType("*sql.Tx"), 1).Return(models.POI{Id: 1, Points: []models.POIPoint{kept, other}}, nil)
+	poiRepo.On("DeletePoints", mock.Anything, mock.AnythingOfType("*sql.Tx"), []int{20}).Return(nil)
+	poiRepo.On("UpdatePoint", mock.Anything, mock.AnythingOfType("*sql.Tx"), mock.MatchedBy(func(pt models.POIPoint) bool {
+		return pt.ExternalKey == ""
+	})).Return(kept, nil)
+	poiRepo.On("FindById", mock.Anything, mock.AnythingOfType("*sql.Tx"), 2).Return(models.POI{Id: 2, Points: []models.POIPoint{{Id: 21}}}, nil)
+
+	response := svc.Merge(context.Background(), webPOIDuplicate.MergePOIDuplicateRequest{KeepPointId: 10, MergePointId: 20})
+
+	assert.Nil(t, response.RemovedPOIId)
+	poiRepo.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything, mock.Anything)
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+}
+
+func TestPOIDuplicateMerge_UnknownPoint(t *testing.T) {
+	db, sqlMock := testutil.NewMockDB(t)
+	svc, repo, poiRepo := newPOIDuplicateService(db)
+
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectRollback()
+
+	repo.On("FindPointById", mock.Anything, mock.AnythingOfType("*sql.Tx"), 10).Return(models.POIPoint{Id: 10, POIId: 1}, nil)
+	repo.On("FindPointById", mock.Anything, mock.AnythingOfType("*sql.Tx"), 99).Return(models.POIPoint{}, sql.ErrNoRows)
+
+	assert.PanicsWithValue(t, exceptions.NewNotFoundError("POI point 99 not found"), func() {
+		svc.Merge(context.Background(), webPOIDuplicate.MergePOIDuplicateRequest{KeepPointId: 10, MergePointId: 99})
+	})
+	poiRepo.AssertNotCalled(t, "DeletePoints", mock.Anything, mock.Anything, mock.Anything)
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+}
+
+// --- Dismiss ---
+
+func TestPOIDuplicateDismiss_RecordsUser(t *testing.T) {
+	db, sqlMock := testutil.NewMockDB(t)
+	svc, repo, _ := newPOIDuplicateService(db)
+
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectCommit()
+
+	userId := 4
+	repo.On("FindPointById", mock.Anything, mock.AnythingOfType("*sql.Tx"), 20).Return(models.POIPoint{Id: 20}, nil)
+	repo.On("FindPointById", mock.Anything, mock.AnythingOfType("*sql.Tx"), 10).Return(models.POIPoint{Id: 10}, nil)
+	repo.On("Dismiss", mock.Anything, mock.AnythingOfType("*sql.Tx"), 20, 10, &userId).Return(nil)
+
+	ctx := context.WithValue(context.Background(), helpers.ContextKey("userId"), "4")
+	svc.Dismiss(ctx, webPOIDuplicate.DismissPOIDuplicateRequest{PointId: 20, OtherPointId: 10})
+
+	repo.AssertExpectations(t)
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+}
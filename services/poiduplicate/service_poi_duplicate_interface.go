@@ -0,0 +1,13 @@
+package poiduplicate
+
+import (
+	"context"
+
+	webPOIDuplicate "github.com/malikabdulaziz/tmn-backend/web/poiduplicate"
+)
+
+type ServicePOIDuplicateInterface interface {
+	FindAll(ctx context.Context, request webPOIDuplicate.POIDuplicateRequestFindAll) ([]webPOIDuplicate.POIDuplicateResponse, int)
+	Merge(ctx context.Context, request webPOIDuplicate.MergePOIDuplicateRequest) webPOIDuplicate.MergePOIDuplicateResponse
+	Dismiss(ctx context.Context, request webPOIDuplicate.DismissPOIDuplicateRequest)
+}
@@ -0,0 +1,131 @@
+package proposal
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	_ "image/gif"
+	"image/jpeg"
+	_ "image/png"
+	"time"
+
+	webSalesPackage "github.com/malikabdulaziz/tmn-backend/web/salespackage"
+)
+
+// maxImageSide is the longest side, in pixels, of an image embedded in a proposal. Larger photos
+// are scaled down so a package of a few hundred buildings stays a reasonable download.
+const maxImageSide = 1200
+
+// Document is everything a proposal shows, gathered before any page is laid out
+type Document struct {
+	Template    Template
+	PackageName string
+	// Version is the sales package version the proposal shows
+	Version     int
+	ClientName  string
+	GeneratedAt time.Time
+	Summary     webSalesPackage.SalesPackageSummaryResponse
+	Buildings   []DocumentBuilding
+	// Map is the static map snapshot, nil when none is configured or it could not be fetched
+	Map  *Image
+	Logo *Image
+}
+
+// DocumentBuilding is one building of the package; Number links it to its map marker
+type DocumentBuilding struct {
+	Number         int
+	Name           string
+	ProjectName    string
+	Subdistrict    string
+	Citytown       string
+	Province       string
+	BuildingType   string
+	GradeResource  string
+	CbdArea        string
+	CompletionYear int
+	Audience       int
+	Impression     int
+	Screens        int
+	Latitude       float64
+	Longitude      float64
+	// Photo is the front side photo, nil when the building has none or it could not be fetched
+	Photo *Image
+}
+
+// Image is a JPEG ready to embed in either output format
+type Image struct {
+	Data   []byte
+	Width  int
+	Height int
+}
+
+// NormalizeImage decodes a JPEG, PNG or GIF, flattens transparency onto white, scales it down to
+// maxImageSide and re-encodes it as an RGB JPEG
+func NormalizeImage(data []byte) (*Image, error) {
+	src, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("unsupported image: %w", err)
+	}
+	bounds := src.Bounds()
+	if bounds.Dx() == 0 || bounds.Dy() == 0 {
+		return nil, fmt.Errorf("image is empty")
+	}
+
+	width, height := bounds.Dx(), bounds.Dy()
+	if width > maxImageSide || height > maxImageSide {
+		if width >= height {
+			height = max(1, height*maxImageSide/width)
+			width = maxImageSide
+		} else {
+			width = max(1, width*maxImageSide/height)
+			height = maxImageSide
+		}
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.Draw(dst, dst.Bounds(), image.NewUniform(color.White), image.Point{}, draw.Src)
+	if width == bounds.Dx() && height == bounds.Dy() {
+		draw.Draw(dst, dst.Bounds(), src, bounds.Min, draw.Over)
+	} else {
+		scaleInto(dst, src)
+	}
+
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, dst, &jpeg.Options{Quality: 82}); err != nil {
+		return nil, err
+	}
+	return &Image{Data: buf.Bytes(), Width: width, Height: height}, nil
+}
+
+// scaleInto box-filters src onto the white background of dst
+func scaleInto(dst *image.RGBA, src image.Image) {
+	sb := src.Bounds()
+	dw, dh := dst.Bounds().Dx(), dst.Bounds().Dy()
+	for y := 0; y < dh; y++ {
+		y0 := sb.Min.Y + y*sb.Dy()/dh
+		y1 := max(y0+1, sb.Min.Y+(y+1)*sb.Dy()/dh)
+		for x := 0; x < dw; x++ {
+			x0 := sb.Min.X + x*sb.Dx()/dw
+			x1 := max(x0+1, sb.Min.X+(x+1)*sb.Dx()/dw)
+
+			var r, g, b, a, n uint64
+			for sy := y0; sy < y1; sy++ {
+				for sx := x0; sx < x1; sx++ {
+					cr, cg, cb, ca := src.At(sx, sy).RGBA()
+					r, g, b, a = r+uint64(cr), g+uint64(cg), b+uint64(cb), a+uint64(ca)
+					n++
+				}
+			}
+			// Premultiplied average composited over white
+			white := n*0xffff - a
+			dst.SetRGBA(x, y, color.RGBA{
+				R: uint8((r + white) / n >> 8),
+				G: uint8((g + white) / n >> 8),
+				B: uint8((b + white) / n >> 8),
+				A: 0xff,
+			})
+		}
+	}
+}
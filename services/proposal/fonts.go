@@ -0,0 +1,188 @@
+package proposal
+
+import (
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/malikabdulaziz/tmn-backend/helpers"
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/gofont/gobold"
+	"golang.org/x/image/font/gofont/goregular"
+	"golang.org/x/image/font/sfnt"
+	"golang.org/x/image/math/fixed"
+)
+
+// Advance widths, in 1/1000 em, of the printable ASCII characters (32..126) of the standard
+// Helvetica faces. Arial shares these metrics, so text measured here lines up in PPTX as well.
+var helveticaWidths = [95]int{
+	278, 278, 355, 556, 556, 889, 667, 191, 333, 333, 389, 584, 278, 333, 278, 278,
+	556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 278, 278, 584, 584, 584, 556,
+	1015, 667, 667, 722, 722, 667, 611, 778, 722, 278, 500, 667, 556, 833, 722, 778,
+	667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 278, 278, 278, 469, 556,
+	333, 556, 556, 500, 556, 556, 278, 556, 556, 222, 222, 500, 222, 833, 556, 556,
+	556, 556, 333, 500, 278, 556, 500, 722, 500, 500, 500, 334, 260, 334, 584,
+}
+
+var helveticaBoldWidths = [95]int{
+	278, 333, 474, 556, 556, 889, 722, 238, 333, 333, 389, 584, 278, 333, 278, 278,
+	556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 333, 333, 584, 584, 584, 611,
+	975, 722, 722, 722, 722, 667, 611, 778, 722, 278, 556, 722, 611, 833, 722, 778,
+	667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 333, 278, 333, 584, 556,
+	333, 556, 611, 556, 611, 556, 333, 611, 611, 278, 278, 556, 278, 889, 611, 611,
+	611, 611, 389, 556, 333, 611, 556, 778, 556, 556, 500, 389, 280, 389, 584,
+}
+
+// winAnsiExtras maps the characters WinAnsiEncoding places at codes 128..159 to their codes
+var winAnsiExtras = map[rune]byte{
+	'€': 0x80, '‚': 0x82, 'ƒ': 0x83, '„': 0x84, '…': 0x85, '†': 0x86, '‡': 0x87, 'ˆ': 0x88,
+	'‰': 0x89, 'Š': 0x8A, '‹': 0x8B, 'Œ': 0x8C, 'Ž': 0x8E, '‘': 0x91, '’': 0x92, '“': 0x93,
+	'”': 0x94, '•': 0x95, '–': 0x96, '—': 0x97, '˜': 0x98, '™': 0x99, 'š': 0x9A, '›': 0x9B,
+	'œ': 0x9C, 'ž': 0x9E, 'Ÿ': 0x9F,
+}
+
+// winAnsiCode returns the WinAnsiEncoding code of r; false means the standard Helvetica fonts
+// cannot draw it
+func winAnsiCode(r rune) (byte, bool) {
+	if (r >= 32 && r <= 126) || (r >= 160 && r <= 255) {
+		return byte(r), true
+	}
+	code, ok := winAnsiExtras[r]
+	return code, ok
+}
+
+// isWinAnsi tells whether the standard Helvetica fonts can draw every character of s
+func isWinAnsi(s string) bool {
+	for _, r := range s {
+		if _, ok := winAnsiCode(r); !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// unicodeFace is a TrueType face embedded in PDFs for text the standard fonts cannot draw. The Go
+// fonts cover Latin, Greek and Cyrillic scripts; other characters are drawn as the missing glyph.
+type unicodeFace struct {
+	Name string
+	Data []byte
+	font *sfnt.Font
+}
+
+var (
+	unicodeFacesOnce sync.Once
+	unicodeFaces     [2]*unicodeFace
+)
+
+// unicodeFaceFor returns the regular or bold Unicode face, parsing both on first use
+func unicodeFaceFor(bold bool) *unicodeFace {
+	unicodeFacesOnce.Do(func() {
+		for i, face := range []*unicodeFace{{Name: "GoRegular", Data: goregular.TTF}, {Name: "GoBold", Data: gobold.TTF}} {
+			parsed, err := sfnt.Parse(face.Data)
+			helpers.PanicIfError(err)
+			face.font = parsed
+			unicodeFaces[i] = face
+		}
+	})
+	if bold {
+		return unicodeFaces[1]
+	}
+	return unicodeFaces[0]
+}
+
+// units is the ppem that makes sfnt report lengths in font units
+func (f *unicodeFace) units() fixed.Int26_6 {
+	return fixed.Int26_6(f.font.UnitsPerEm())
+}
+
+// scale converts a length in font units to 1/1000 em
+func (f *unicodeFace) scale(v fixed.Int26_6) int {
+	return int(v) * 1000 / int(f.font.UnitsPerEm())
+}
+
+// glyph returns the glyph drawing r and its advance width in 1/1000 em; characters the face
+// lacks map to glyph 0
+func (f *unicodeFace) glyph(r rune) (uint16, int) {
+	var buf sfnt.Buffer
+	index, err := f.font.GlyphIndex(&buf, r)
+	if err != nil {
+		index = 0
+	}
+	advance, err := f.font.GlyphAdvance(&buf, index, f.units(), font.HintingNone)
+	if err != nil {
+		return uint16(index), 0
+	}
+	return uint16(index), f.scale(advance)
+}
+
+// textWidth measures s in points. Text the standard fonts can draw is measured with the Helvetica
+// metrics, counting characters outside ASCII as an average glyph; other text with the Unicode face.
+func textWidth(s string, size float64, bold bool) float64 {
+	if !isWinAnsi(s) {
+		face := unicodeFaceFor(bold)
+		total := 0
+		for _, r := range s {
+			_, width := face.glyph(r)
+			total += width
+		}
+		return float64(total) * size / 1000
+	}
+	widths := &helveticaWidths
+	if bold {
+		widths = &helveticaBoldWidths
+	}
+	total := 0
+	for _, r := range s {
+		if r >= 32 && r <= 126 {
+			total += widths[r-32]
+		} else {
+			total += 556
+		}
+	}
+	return float64(total) * size / 1000
+}
+
+// fitText shortens s with a trailing "..." until it fits in maxWidth points
+func fitText(s string, size float64, bold bool, maxWidth float64) string {
+	if textWidth(s, size, bold) <= maxWidth {
+		return s
+	}
+	for s != "" {
+		_, n := utf8.DecodeLastRuneInString(s)
+		s = s[:len(s)-n]
+		if textWidth(strings.TrimSpace(s)+"...", size, bold) <= maxWidth {
+			return strings.TrimSpace(s) + "..."
+		}
+	}
+	return ""
+}
+
+// wrapText breaks s into lines of at most maxWidth points, shortening the last of maxLines lines
+func wrapText(s string, size float64, bold bool, maxWidth float64, maxLines int) []string {
+	var lines []string
+	line := ""
+	words := strings.Fields(s)
+	for i, word := range words {
+		candidate := word
+		if line != "" {
+			candidate = line + " " + word
+		}
+		if textWidth(candidate, size, bold) <= maxWidth || line == "" {
+			line = candidate
+			continue
+		}
+		lines = append(lines, line)
+		line = word
+		if len(lines) == maxLines-1 {
+			line = strings.Join(words[i:], " ")
+			break
+		}
+	}
+	if line != "" {
+		lines = append(lines, line)
+	}
+	for i := range lines {
+		lines[i] = fitText(lines[i], size, bold, maxWidth)
+	}
+	return lines
+}
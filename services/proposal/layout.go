@@ -0,0 +1,480 @@
+package proposal
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	webSalesPackage "github.com/malikabdulaziz/tmn-backend/web/salespackage"
+)
+
+// Pages are 16:9 in points, the PowerPoint widescreen size, so PDF and PPTX share one layout
+const (
+	pageWidth  = 960.0
+	pageHeight = 540.0
+	margin     = 40.0
+	headerH    = 64.0
+	footerY    = 512.0
+)
+
+// Fixed colors; the template supplies the primary and accent colors
+var (
+	colorWhite = rgb{255, 255, 255}
+	colorText  = rgb{33, 37, 41}
+	colorMuted = rgb{108, 117, 125}
+	colorLight = rgb{241, 243, 245}
+	colorRule  = rgb{222, 226, 230}
+)
+
+type textAlign int
+
+const (
+	alignLeft textAlign = iota
+	alignCenter
+	alignRight
+)
+
+type opKind int
+
+const (
+	opRect opKind = iota
+	opText
+	opImage
+)
+
+// op is one drawing instruction. Coordinates are in points from the top-left corner of the page;
+// for text, Y is the top of the line box and W the width used for alignment.
+type op struct {
+	Kind  opKind
+	X, Y  float64
+	W, H  float64
+	Color rgb
+	Size  float64
+	Bold  bool
+	Align textAlign
+	Text  string
+	Image *Image
+}
+
+type page struct {
+	ops []op
+}
+
+func (p *page) rect(x, y, w, h float64, c rgb) {
+	p.ops = append(p.ops, op{Kind: opRect, X: x, Y: y, W: w, H: h, Color: c})
+}
+
+// text draws s on one line, shortened to fit w
+func (p *page) text(x, y, w, size float64, bold bool, c rgb, align textAlign, s string) {
+	s = fitText(s, size, bold, w)
+	if s == "" {
+		return
+	}
+	p.ops = append(p.ops, op{Kind: opText, X: x, Y: y, W: w, H: size * 1.2, Color: c, Size: size, Bold: bold, Align: align, Text: s})
+}
+
+// image draws img scaled to fit inside the box, centered
+func (p *page) image(img *Image, x, y, w, h float64) {
+	scale := math.Min(w/float64(img.Width), h/float64(img.Height))
+	iw, ih := float64(img.Width)*scale, float64(img.Height)*scale
+	p.ops = append(p.ops, op{Kind: opImage, X: x + (w-iw)/2, Y: y + (h-ih)/2, W: iw, H: ih, Image: img})
+}
+
+// layout turns the document into pages following the template's sections
+func layout(doc Document) []*page {
+	primary := mustColor(doc.Template.PrimaryColor)
+	accent := mustColor(doc.Template.AccentColor)
+	l := &layouter{doc: doc, primary: primary, accent: accent}
+
+	for _, section := range doc.Template.Sections {
+		switch section {
+		case SectionCover:
+			l.cover()
+		case SectionSummary:
+			l.summary()
+		case SectionMap:
+			l.buildingMap()
+		case SectionBuildings:
+			l.buildingPages()
+		case SectionTable:
+			l.table()
+		}
+	}
+	l.footers()
+	return l.pages
+}
+
+type layouter struct {
+	doc     Document
+	primary rgb
+	accent  rgb
+	pages   []*page
+	// covers marks pages that get no footer
+	covers map[*page]bool
+}
+
+func (l *layouter) newPage() *page {
+	p := &page{}
+	l.pages = append(l.pages, p)
+	return p
+}
+
+// contentPage starts a page with the header band and its title
+func (l *layouter) contentPage(title string) *page {
+	p := l.newPage()
+	p.rect(0, 0, pageWidth, headerH, l.primary)
+	p.rect(0, headerH, pageWidth, 4, l.accent)
+	right := 0.0
+	if l.doc.Logo != nil {
+		p.rect(pageWidth-margin-110, 10, 110, 44, colorWhite)
+		p.image(l.doc.Logo, pageWidth-margin-106, 14, 102, 36)
+		right = 120
+	}
+	p.text(margin, 20, pageWidth-2*margin-right-200, 22, true, colorWhite, alignLeft, title)
+	p.text(pageWidth-margin-right-200, 26, 190, 10, false, colorWhite, alignRight, l.doc.Template.CompanyName)
+	return p
+}
+
+func (l *layouter) cover() {
+	t := l.doc.Template
+	p := l.newPage()
+	if l.covers == nil {
+		l.covers = map[*page]bool{}
+	}
+	l.covers[p] = true
+
+	p.rect(0, 0, pageWidth, pageHeight, l.primary)
+	p.rect(margin, 150, 80, 6, l.accent)
+	if l.doc.Logo != nil {
+		p.rect(pageWidth-margin-180, margin, 180, 72, colorWhite)
+		p.image(l.doc.Logo, pageWidth-margin-172, margin+8, 164, 56)
+	}
+	if t.CompanyName != "" {
+		p.text(margin, margin+6, 500, 14, true, colorWhite, alignLeft, t.CompanyName)
+	}
+
+	y := 176.0
+	for _, line := range wrapText(t.Title, 40, true, pageWidth-2*margin, 2) {
+		p.text(margin, y, pageWidth-2*margin, 40, true, colorWhite, alignLeft, line)
+		y += 48
+	}
+	if t.Subtitle != "" {
+		p.text(margin, y+4, pageWidth-2*margin, 18, false, colorWhite, alignLeft, t.Subtitle)
+		y += 30
+	}
+
+	y += 30
+	if l.doc.ClientName != "" {
+		p.text(margin, y, 600, 12, false, l.accent, alignLeft, "PREPARED FOR")
+		p.text(margin, y+16, 600, 20, true, colorWhite, alignLeft, l.doc.ClientName)
+		y += 52
+	}
+	p.text(margin, y, 600, 12, false, l.accent, alignLeft, "SALES PACKAGE")
+	p.text(margin, y+16, 600, 16, false, colorWhite, alignLeft, l.doc.PackageName)
+	if l.doc.Version > 0 {
+		p.text(margin, y+38, 600, 11, false, colorWhite, alignLeft, fmt.Sprintf("Version %d", l.doc.Version))
+	}
+
+	p.text(margin, pageHeight-margin-14, 300, 11, false, colorWhite, alignLeft, l.doc.GeneratedAt.Format("2 January 2006"))
+	contactY := pageHeight - margin - 14 - 14*float64(len(t.ContactLines)-1)
+	for _, line := range t.ContactLines {
+		p.text(pageWidth-margin-400, contactY, 400, 11, false, colorWhite, alignRight, line)
+		contactY += 14
+	}
+}
+
+func (l *layouter) summary() {
+	s := l.doc.Summary
+	p := l.contentPage("Campaign Summary")
+
+	kpis := []struct {
+		label string
+		value int
+	}{
+		{"BUILDINGS", s.BuildingCount},
+		{"AUDIENCE", s.TotalAudience},
+		{"IMPRESSIONS", s.TotalImpression},
+		{"SCREENS", s.TotalScreens},
+	}
+	gap := 16.0
+	tileW := (pageWidth - 2*margin - gap*float64(len(kpis)-1)) / float64(len(kpis))
+	for i, kpi := range kpis {
+		x := margin + float64(i)*(tileW+gap)
+		p.rect(x, 92, tileW, 86, colorLight)
+		p.rect(x, 92, tileW, 4, l.accent)
+		p.text(x+16, 108, tileW-32, 10, true, colorMuted, alignLeft, kpi.label)
+		p.text(x+16, 126, tileW-32, 28, true, l.primary, alignLeft, formatNumber(kpi.value))
+	}
+
+	breakdowns := []struct {
+		title string
+		items []webSalesPackage.SalesPackageBreakdownResponse
+	}{
+		{"By Building Type", s.ByBuildingType},
+		{"By City", s.ByCity},
+		{"By Grade", s.ByGrade},
+	}
+	colW := (pageWidth - 2*margin - 2*gap) / 3
+	for i, b := range breakdowns {
+		x := margin + float64(i)*(colW+gap)
+		p.text(x, 200, colW, 13, true, l.primary, alignLeft, b.title)
+		p.rect(x, 220, colW, 1, colorRule)
+		p.text(x, 226, colW*0.55-6, 9, true, colorMuted, alignLeft, "NAME")
+		p.text(x+colW*0.55, 226, colW*0.2, 9, true, colorMuted, alignRight, "BLDG")
+		p.text(x+colW*0.75, 226, colW*0.25, 9, true, colorMuted, alignRight, "AUDIENCE")
+		y := 242.0
+		for _, item := range topBreakdown(b.items, 10) {
+			p.text(x, y, colW*0.55-6, 10, false, colorText, alignLeft, item.Key)
+			p.text(x+colW*0.55, y, colW*0.2, 10, false, colorText, alignRight, formatNumber(item.BuildingCount))
+			p.text(x+colW*0.75, y, colW*0.25, 10, false, colorText, alignRight, formatNumber(item.Audience))
+			y += 18
+		}
+	}
+
+	if s.BuildingsWithoutProposal > 0 {
+		p.text(margin, 470, pageWidth-2*margin, 10, false, colorMuted, alignLeft,
+			fmt.Sprintf("%s of %s buildings have no building proposal yet and add no screens to the total.",
+				formatNumber(s.BuildingsWithoutProposal), formatNumber(s.BuildingCount)))
+	}
+}
+
+// topBreakdown keeps the n largest groups by audience and folds the rest into "Other"
+func topBreakdown(items []webSalesPackage.SalesPackageBreakdownResponse, n int) []webSalesPackage.SalesPackageBreakdownResponse {
+	sorted := append([]webSalesPackage.SalesPackageBreakdownResponse{}, items...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Audience > sorted[j].Audience })
+	for i := range sorted {
+		if sorted[i].Key == "" {
+			sorted[i].Key = "Unspecified"
+		}
+	}
+	if len(sorted) <= n {
+		return sorted
+	}
+	other := webSalesPackage.SalesPackageBreakdownResponse{Key: "Other"}
+	for _, item := range sorted[n-1:] {
+		other.BuildingCount += item.BuildingCount
+		other.Audience += item.Audience
+		other.Impression += item.Impression
+		other.Screens += item.Screens
+	}
+	return append(sorted[:n-1], other)
+}
+
+func (l *layouter) buildingMap() {
+	p := l.contentPage("Building Locations")
+	x, y, w, h := margin, 88.0, pageWidth-2*margin, 404.0
+
+	if l.doc.Map != nil {
+		p.image(l.doc.Map, x, y, w, h)
+		return
+	}
+
+	p.rect(x, y, w, h, colorLight)
+	var located []DocumentBuilding
+	for _, b := range l.doc.Buildings {
+		if b.Latitude != 0 || b.Longitude != 0 {
+			located = append(located, b)
+		}
+	}
+	if len(located) == 0 {
+		p.text(x, y+h/2-8, w, 14, false, colorMuted, alignCenter, "No building coordinates available")
+		return
+	}
+
+	// Equirectangular projection, corrected for latitude, fitted into the plot area
+	minLat, maxLat, minLng, maxLng := located[0].Latitude, located[0].Latitude, located[0].Longitude, located[0].Longitude
+	for _, b := range located {
+		minLat, maxLat = math.Min(minLat, b.Latitude), math.Max(maxLat, b.Latitude)
+		minLng, maxLng = math.Min(minLng, b.Longitude), math.Max(maxLng, b.Longitude)
+	}
+	kx := math.Cos((minLat + maxLat) / 2 * math.Pi / 180)
+	spanX := math.Max((maxLng-minLng)*kx, 0.005)
+	spanY := math.Max(maxLat-minLat, 0.005)
+	pad := 30.0
+	scale := math.Min((w-2*pad)/spanX, (h-2*pad)/spanY)
+	cx, cy := x+w/2, y+h/2
+	midLng, midLat := (minLng+maxLng)/2, (minLat+maxLat)/2
+
+	for _, b := range located {
+		px := cx + (b.Longitude-midLng)*kx*scale
+		py := cy - (b.Latitude-midLat)*scale
+		label := strconv.Itoa(b.Number)
+		size := math.Max(14, textWidth(label, 8, true)+6)
+		p.rect(px-size/2, py-7, size, 14, l.primary)
+		p.text(px-size/2, py-5, size, 8, true, colorWhite, alignCenter, label)
+	}
+	p.text(x+8, y+h-16, w-16, 8, false, colorMuted, alignRight,
+		fmt.Sprintf("%.4f, %.4f to %.4f, %.4f  -  numbers match the building table", minLat, minLng, maxLat, maxLng))
+}
+
+func (l *layouter) buildingPages() {
+	for _, b := range l.doc.Buildings {
+		p := l.contentPage(fmt.Sprintf("%d. %s", b.Number, b.Name))
+
+		photoX, photoY, photoW, photoH := margin, 92.0, 540.0, 400.0
+		if b.Photo != nil {
+			p.rect(photoX, photoY, photoW, photoH, colorLight)
+			p.image(b.Photo, photoX, photoY, photoW, photoH)
+		} else {
+			p.rect(photoX, photoY, photoW, photoH, colorLight)
+			p.text(photoX, photoY+photoH/2-8, photoW, 13, false, colorMuted, alignCenter, "Photo not available")
+		}
+
+		x := photoX + photoW + 28
+		w := pageWidth - margin - x
+		details := []struct{ label, value string }{
+			{"Project", b.ProjectName},
+			{"Location", joinNonEmpty(", ", b.Subdistrict, b.Citytown, b.Province)},
+			{"Building Type", b.BuildingType},
+			{"Grade", b.GradeResource},
+			{"CBD Area", b.CbdArea},
+			{"Completion Year", yearText(b.CompletionYear)},
+			{"Audience", formatNumber(b.Audience)},
+			{"Impressions", formatNumber(b.Impression)},
+			{"Screens", formatNumber(b.Screens)},
+			{"Coordinates", coordinateText(b.Latitude, b.Longitude)},
+		}
+		y := 96.0
+		for _, d := range details {
+			value := d.value
+			if value == "" {
+				value = "-"
+			}
+			p.text(x, y, w, 9, true, colorMuted, alignLeft, strings.ToUpper(d.label))
+			p.text(x, y+12, w, 13, false, colorText, alignLeft, value)
+			p.rect(x, y+34, w, 0.75, colorRule)
+			y += 40
+		}
+	}
+}
+
+// tableRowsPerPage fits the building table between the header and the footer
+const tableRowsPerPage = 18
+
+func (l *layouter) table() {
+	type column struct {
+		title string
+		width float64
+		align textAlign
+		value func(b DocumentBuilding) string
+	}
+	columns := []column{
+		{"#", 36, alignRight, func(b DocumentBuilding) string { return strconv.Itoa(b.Number) }},
+		{"BUILDING", 250, alignLeft, func(b DocumentBuilding) string { return b.Name }},
+		{"CITY", 140, alignLeft, func(b DocumentBuilding) string { return b.Citytown }},
+		{"TYPE", 110, alignLeft, func(b DocumentBuilding) string { return b.BuildingType }},
+		{"GRADE", 60, alignLeft, func(b DocumentBuilding) string { return b.GradeResource }},
+		{"AUDIENCE", 96, alignRight, func(b DocumentBuilding) string { return formatNumber(b.Audience) }},
+		{"IMPRESSIONS", 110, alignRight, func(b DocumentBuilding) string { return formatNumber(b.Impression) }},
+		{"SCREENS", 78, alignRight, func(b DocumentBuilding) string { return formatNumber(b.Screens) }},
+	}
+	const cellPad = 6.0
+	const rowH = 20.0
+
+	drawRow := func(p *page, y float64, values []string, size float64, bold bool, c rgb) {
+		x := margin
+		for i, col := range columns {
+			p.text(x+cellPad, y+(rowH-size*1.2)/2, col.width-2*cellPad, size, bold, c, col.align, values[i])
+			x += col.width
+		}
+	}
+	header := make([]string, len(columns))
+	for i, col := range columns {
+		header[i] = col.title
+	}
+
+	buildings := l.doc.Buildings
+	pageCount := max(1, (len(buildings)+tableRowsPerPage-1)/tableRowsPerPage)
+	for n := 0; n < pageCount; n++ {
+		title := "Building List"
+		if pageCount > 1 {
+			title = fmt.Sprintf("Building List (%d/%d)", n+1, pageCount)
+		}
+		p := l.contentPage(title)
+		y := 84.0
+		p.rect(margin, y, pageWidth-2*margin, rowH, l.primary)
+		drawRow(p, y, header, 9, true, colorWhite)
+		y += rowH
+
+		end := min(len(buildings), (n+1)*tableRowsPerPage)
+		for i, b := range buildings[n*tableRowsPerPage : end] {
+			if i%2 == 1 {
+				p.rect(margin, y, pageWidth-2*margin, rowH, colorLight)
+			}
+			values := make([]string, len(columns))
+			for c, col := range columns {
+				values[c] = col.value(b)
+			}
+			drawRow(p, y, values, 9.5, false, colorText)
+			y += rowH
+		}
+
+		if n == pageCount-1 {
+			s := l.doc.Summary
+			p.rect(margin, y, pageWidth-2*margin, 1.5, l.primary)
+			drawRow(p, y+1.5, []string{"", "Total", "", "", "",
+				formatNumber(s.TotalAudience), formatNumber(s.TotalImpression), formatNumber(s.TotalScreens)}, 9.5, true, colorText)
+		}
+	}
+}
+
+// footers adds the footer text and page numbers once the page count is known
+func (l *layouter) footers() {
+	total := len(l.pages)
+	for i, p := range l.pages {
+		if l.covers[p] {
+			continue
+		}
+		p.rect(margin, footerY-6, pageWidth-2*margin, 0.75, colorRule)
+		footer := l.doc.Template.FooterText
+		if footer == "" {
+			footer = l.doc.PackageName
+		}
+		p.text(margin, footerY, 600, 9, false, colorMuted, alignLeft, footer)
+		p.text(pageWidth-margin-200, footerY, 200, 9, false, colorMuted, alignRight, fmt.Sprintf("Page %d of %d", i+1, total))
+	}
+}
+
+// formatNumber writes n with thousands separators
+func formatNumber(n int) string {
+	s := strconv.Itoa(n)
+	negative := strings.HasPrefix(s, "-")
+	s = strings.TrimPrefix(s, "-")
+	var out []byte
+	for i := range s {
+		if i > 0 && (len(s)-i)%3 == 0 {
+			out = append(out, ',')
+		}
+		out = append(out, s[i])
+	}
+	if negative {
+		return "-" + string(out)
+	}
+	return string(out)
+}
+
+func joinNonEmpty(sep string, parts ...string) string {
+	var kept []string
+	for _, part := range parts {
+		if strings.TrimSpace(part) != "" {
+			kept = append(kept, strings.TrimSpace(part))
+		}
+	}
+	return strings.Join(kept, sep)
+}
+
+func yearText(year int) string {
+	if year == 0 {
+		return ""
+	}
+	return strconv.Itoa(year)
+}
+
+func coordinateText(lat, lng float64) string {
+	if lat == 0 && lng == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%.6f, %.6f", lat, lng)
+}
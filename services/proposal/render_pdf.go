@@ -0,0 +1,331 @@
+package proposal
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+	"unicode/utf8"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/sfnt"
+)
+
+// pdfDocument collects numbered PDF objects; object n is objects[n-1]
+type pdfDocument struct {
+	objects [][]byte
+}
+
+func (d *pdfDocument) reserve() int {
+	d.objects = append(d.objects, nil)
+	return len(d.objects)
+}
+
+func (d *pdfDocument) set(id int, body string) {
+	d.objects[id-1] = []byte(body)
+}
+
+func (d *pdfDocument) add(body string) int {
+	id := d.reserve()
+	d.set(id, body)
+	return id
+}
+
+// addStream adds a stream object; extra is merged into its dictionary
+func (d *pdfDocument) addStream(extra string, data []byte) int {
+	id := d.reserve()
+	var buf bytes.Buffer
+	if extra != "" {
+		extra += " "
+	}
+	fmt.Fprintf(&buf, "<< %s/Length %d >>\nstream\n", extra, len(data))
+	buf.Write(data)
+	buf.WriteString("\nendstream")
+	d.objects[id-1] = buf.Bytes()
+	return id
+}
+
+func (d *pdfDocument) bytes(root int, info int) []byte {
+	var buf bytes.Buffer
+	buf.WriteString("%PDF-1.4\n%\xE2\xE3\xCF\xD3\n")
+	offsets := make([]int, len(d.objects))
+	for i, body := range d.objects {
+		offsets[i] = buf.Len()
+		fmt.Fprintf(&buf, "%d 0 obj\n", i+1)
+		buf.Write(body)
+		buf.WriteString("\nendobj\n")
+	}
+	xref := buf.Len()
+	fmt.Fprintf(&buf, "xref\n0 %d\n0000000000 65535 f \n", len(d.objects)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&buf, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&buf, "trailer\n<< /Size %d /Root %d 0 R /Info %d 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(d.objects)+1, root, info, xref)
+	return buf.Bytes()
+}
+
+// pdfUnicodeFont embeds a unicodeFace as a Type0 font with Identity-H encoding, so text is written
+// as glyph ids. Glyphs are recorded as they are drawn; the widths and the ToUnicode map list those.
+type pdfUnicodeFont struct {
+	face     *unicodeFace
+	resource string
+	id       int
+	glyphs   map[uint16]pdfGlyph
+}
+
+type pdfGlyph struct {
+	r     rune
+	width int
+}
+
+// encode returns s as a hex string of glyph ids
+func (f *pdfUnicodeFont) encode(s string) string {
+	var buf strings.Builder
+	buf.WriteByte('<')
+	for _, r := range s {
+		id, width := f.face.glyph(r)
+		if id == 0 {
+			// The missing glyph stands for any character the face lacks
+			r = utf8.RuneError
+		}
+		if _, seen := f.glyphs[id]; !seen {
+			f.glyphs[id] = pdfGlyph{r: r, width: width}
+		}
+		fmt.Fprintf(&buf, "%04X", id)
+	}
+	buf.WriteByte('>')
+	return buf.String()
+}
+
+// write adds the font program, its descriptor, the descendant font and the ToUnicode map, then
+// fills the object reserved for the font
+func (f *pdfUnicodeFont) write(d *pdfDocument) error {
+	program, err := deflate(f.face.Data)
+	if err != nil {
+		return err
+	}
+	fontFile := d.addStream(fmt.Sprintf("/Filter /FlateDecode /Length1 %d", len(f.face.Data)), program)
+
+	var buf sfnt.Buffer
+	metrics, err := f.face.font.Metrics(&buf, f.face.units(), font.HintingNone)
+	if err != nil {
+		return err
+	}
+	bounds, err := f.face.font.Bounds(&buf, f.face.units(), font.HintingNone)
+	if err != nil {
+		return err
+	}
+	// sfnt's Y axis points down
+	descriptor := d.add(fmt.Sprintf("<< /Type /FontDescriptor /FontName /%s /Flags 32 /FontBBox [%d %d %d %d] /ItalicAngle 0 /Ascent %d /Descent %d /CapHeight %d /StemV 80 /FontFile2 %d 0 R >>",
+		f.face.Name, f.face.scale(bounds.Min.X), -f.face.scale(bounds.Max.Y), f.face.scale(bounds.Max.X), -f.face.scale(bounds.Min.Y),
+		f.face.scale(metrics.Ascent), -f.face.scale(metrics.Descent), f.face.scale(metrics.CapHeight), fontFile))
+
+	ids := make([]int, 0, len(f.glyphs))
+	for id := range f.glyphs {
+		ids = append(ids, int(id))
+	}
+	sort.Ints(ids)
+	var widths, unicode strings.Builder
+	for _, id := range ids {
+		fmt.Fprintf(&widths, " %d [%d]", id, f.glyphs[uint16(id)].width)
+		fmt.Fprintf(&unicode, "<%04X> <", id)
+		for _, unit := range utf16.Encode([]rune{f.glyphs[uint16(id)].r}) {
+			fmt.Fprintf(&unicode, "%04X", unit)
+		}
+		unicode.WriteString(">\n")
+	}
+	descendant := d.add(fmt.Sprintf("<< /Type /Font /Subtype /CIDFontType2 /BaseFont /%s /CIDSystemInfo << /Registry (Adobe) /Ordering (Identity) /Supplement 0 >> /FontDescriptor %d 0 R /CIDToGIDMap /Identity /W [%s ] >>",
+		f.face.Name, descriptor, widths.String()))
+
+	// The map is split in blocks of 100 mappings, the most a bfchar section may hold
+	var cmap strings.Builder
+	cmap.WriteString("/CIDInit /ProcSet findresource begin\n12 dict begin\nbegincmap\n" +
+		"/CIDSystemInfo << /Registry (Adobe) /Ordering (UCS) /Supplement 0 >> def\n/CMapName /Adobe-Identity-UCS def\n/CMapType 2 def\n" +
+		"1 begincodespacerange\n<0000> <FFFF>\nendcodespacerange\n")
+	lines := strings.SplitAfter(unicode.String(), "\n")
+	lines = lines[:len(lines)-1]
+	for start := 0; start < len(lines); start += 100 {
+		block := lines[start:min(start+100, len(lines))]
+		fmt.Fprintf(&cmap, "%d beginbfchar\n%sendbfchar\n", len(block), strings.Join(block, ""))
+	}
+	cmap.WriteString("endcmap\nCMapName currentdict /CMap defineresource pop\nend\nend")
+	toUnicode := d.addStream("", []byte(cmap.String()))
+
+	d.set(f.id, fmt.Sprintf("<< /Type /Font /Subtype /Type0 /BaseFont /%s /Encoding /Identity-H /DescendantFonts [%d 0 R] /ToUnicode %d 0 R >>",
+		f.face.Name, descendant, toUnicode))
+	return nil
+}
+
+// RenderPDF lays the document out and writes it as a PDF. Text is set in the standard Helvetica
+// fonts; text they cannot draw is set in an embedded Unicode font (see unicodeFace).
+func RenderPDF(doc Document) ([]byte, error) {
+	pages := layout(doc)
+	d := &pdfDocument{}
+
+	catalog := d.reserve()
+	pagesId := d.reserve()
+	regular := d.add("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>")
+	bold := d.add("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>")
+	info := d.add(fmt.Sprintf("<< /Title %s /Producer (tmn-backend) /CreationDate (D:%s) >>",
+		pdfTextString(doc.Template.Title+" - "+doc.PackageName), doc.GeneratedAt.UTC().Format("20060102150405Z")))
+
+	// Images shared between pages, such as the logo, are embedded once; so are the Unicode fonts,
+	// which are only added when some text needs them
+	imageIds := map[*Image]int{}
+	unicodeFonts := [2]*pdfUnicodeFont{}
+	var kids []string
+	for _, p := range pages {
+		var content bytes.Buffer
+		xObjects := map[string]int{}
+		fonts := map[string]int{"F1": regular, "F2": bold}
+		for _, o := range p.ops {
+			switch o.Kind {
+			case opRect:
+				fmt.Fprintf(&content, "%s rg %s %s %s %s re f\n", pdfColor(o.Color),
+					pdfNum(o.X), pdfNum(pageHeight-o.Y-o.H), pdfNum(o.W), pdfNum(o.H))
+			case opText:
+				resource, text := "F1", ""
+				if o.Bold {
+					resource = "F2"
+				}
+				if isWinAnsi(o.Text) {
+					text = pdfString(o.Text)
+				} else {
+					i := 0
+					if o.Bold {
+						i = 1
+					}
+					if unicodeFonts[i] == nil {
+						unicodeFonts[i] = &pdfUnicodeFont{face: unicodeFaceFor(o.Bold), resource: "U" + strconv.Itoa(i+1), id: d.reserve(), glyphs: map[uint16]pdfGlyph{}}
+					}
+					resource, text = unicodeFonts[i].resource, unicodeFonts[i].encode(o.Text)
+					fonts[resource] = unicodeFonts[i].id
+				}
+				x := o.X
+				switch o.Align {
+				case alignCenter:
+					x += (o.W - textWidth(o.Text, o.Size, o.Bold)) / 2
+				case alignRight:
+					x += o.W - textWidth(o.Text, o.Size, o.Bold)
+				}
+				// Helvetica's ascender is about 0.72 em; leave the rest of the 1.2 line above it
+				baseline := pageHeight - o.Y - o.Size*0.95
+				fmt.Fprintf(&content, "BT %s rg /%s %s Tf %s %s Td %s Tj ET\n", pdfColor(o.Color), resource,
+					pdfNum(o.Size), pdfNum(x), pdfNum(baseline), text)
+			case opImage:
+				id, ok := imageIds[o.Image]
+				if !ok {
+					id = d.addStream(fmt.Sprintf("/Type /XObject /Subtype /Image /Width %d /Height %d /ColorSpace /DeviceRGB /BitsPerComponent 8 /Filter /DCTDecode",
+						o.Image.Width, o.Image.Height), o.Image.Data)
+					imageIds[o.Image] = id
+				}
+				name := "Im" + strconv.Itoa(id)
+				xObjects[name] = id
+				fmt.Fprintf(&content, "q %s 0 0 %s %s %s cm /%s Do Q\n",
+					pdfNum(o.W), pdfNum(o.H), pdfNum(o.X), pdfNum(pageHeight-o.Y-o.H), name)
+			}
+		}
+
+		compressed, err := deflate(content.Bytes())
+		if err != nil {
+			return nil, err
+		}
+		contentId := d.addStream("/Filter /FlateDecode", compressed)
+
+		var resources strings.Builder
+		resources.WriteString("<< /Font <<")
+		for _, name := range sortedKeys(fonts) {
+			fmt.Fprintf(&resources, " /%s %d 0 R", name, fonts[name])
+		}
+		resources.WriteString(" >>")
+		if len(xObjects) > 0 {
+			resources.WriteString(" /XObject <<")
+			for _, name := range sortedKeys(xObjects) {
+				fmt.Fprintf(&resources, " /%s %d 0 R", name, xObjects[name])
+			}
+			resources.WriteString(" >>")
+		}
+		resources.WriteString(" >>")
+
+		pageId := d.add(fmt.Sprintf("<< /Type /Page /Parent %d 0 R /MediaBox [0 0 %s %s] /Resources %s /Contents %d 0 R >>",
+			pagesId, pdfNum(pageWidth), pdfNum(pageHeight), resources.String(), contentId))
+		kids = append(kids, fmt.Sprintf("%d 0 R", pageId))
+	}
+
+	for _, f := range unicodeFonts {
+		if f != nil {
+			if err := f.write(d); err != nil {
+				return nil, err
+			}
+		}
+	}
+	d.set(pagesId, fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(kids)))
+	d.set(catalog, fmt.Sprintf("<< /Type /Catalog /Pages %d 0 R >>", pagesId))
+	return d.bytes(catalog, info), nil
+}
+
+func pdfNum(v float64) string {
+	return strconv.FormatFloat(v, 'f', 2, 64)
+}
+
+func pdfColor(c rgb) string {
+	return fmt.Sprintf("%s %s %s", pdfNum(float64(c.R)/255), pdfNum(float64(c.G)/255), pdfNum(float64(c.B)/255))
+}
+
+// pdfString encodes s as a WinAnsi literal string for the Helvetica fonts. Text with characters
+// WinAnsi lacks is drawn with a pdfUnicodeFont instead; should one get here it becomes "?".
+func pdfString(s string) string {
+	var buf strings.Builder
+	buf.WriteByte('(')
+	for _, r := range s {
+		code, ok := winAnsiCode(r)
+		switch {
+		case !ok:
+			buf.WriteByte('?')
+		case code == '(' || code == ')' || code == '\\':
+			buf.WriteByte('\\')
+			buf.WriteByte(code)
+		case code < 128:
+			buf.WriteByte(code)
+		default:
+			fmt.Fprintf(&buf, "\\%03o", code)
+		}
+	}
+	buf.WriteByte(')')
+	return buf.String()
+}
+
+// pdfTextString encodes s as a UTF-16 hex string, as used in the document information
+func pdfTextString(s string) string {
+	var buf strings.Builder
+	buf.WriteString("<FEFF")
+	for _, unit := range utf16.Encode([]rune(s)) {
+		fmt.Fprintf(&buf, "%04X", unit)
+	}
+	buf.WriteByte('>')
+	return buf.String()
+}
+
+func deflate(data []byte) ([]byte, error) {
+	var compressed bytes.Buffer
+	zw := zlib.NewWriter(&compressed)
+	if _, err := zw.Write(data); err != nil {
+		return nil, err
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return compressed.Bytes(), nil
+}
+
+func sortedKeys(m map[string]int) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,218 @@
+package proposal
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"strings"
+)
+
+// emuPerPoint converts layout points to the English Metric Units used by OOXML
+const emuPerPoint = 12700
+
+const pptxNamespaces = `xmlns:a="http://schemas.openxmlformats.org/drawingml/2006/main" ` +
+	`xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships" ` +
+	`xmlns:p="http://schemas.openxmlformats.org/presentationml/2006/main"`
+
+const xmlHeader = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>` + "\n"
+
+// RenderPPTX lays the document out and writes it as a widescreen PowerPoint deck, one slide per
+// page. Text uses Arial, which has the metrics the layout was measured with.
+func RenderPPTX(doc Document) ([]byte, error) {
+	pages := layout(doc)
+
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	files := map[string]string{}
+	var order []string
+	put := func(name, content string) {
+		files[name] = content
+		order = append(order, name)
+	}
+
+	// Every distinct image is stored once under ppt/media
+	mediaNames := map[*Image]string{}
+	for i, p := range pages {
+		var shapes strings.Builder
+		var rels strings.Builder
+		rels.WriteString(`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/slideLayout" Target="../slideLayouts/slideLayout1.xml"/>`)
+		slideImages := map[*Image]string{}
+		for n, o := range p.ops {
+			id := n + 2
+			switch o.Kind {
+			case opRect:
+				fmt.Fprintf(&shapes, `<p:sp><p:nvSpPr><p:cNvPr id="%d" name="Rectangle %d"/><p:cNvSpPr/><p:nvPr/></p:nvSpPr>`+
+					`<p:spPr>%s<a:prstGeom prst="rect"><a:avLst/></a:prstGeom><a:solidFill><a:srgbClr val="%s"/></a:solidFill><a:ln><a:noFill/></a:ln></p:spPr></p:sp>`,
+					id, id, pptxXfrm(o.X, o.Y, o.W, o.H), o.Color.hex())
+			case opText:
+				algn := "l"
+				switch o.Align {
+				case alignCenter:
+					algn = "ctr"
+				case alignRight:
+					algn = "r"
+				}
+				b := "0"
+				if o.Bold {
+					b = "1"
+				}
+				fmt.Fprintf(&shapes, `<p:sp><p:nvSpPr><p:cNvPr id="%d" name="Text %d"/><p:cNvSpPr txBox="1"/><p:nvPr/></p:nvSpPr>`+
+					`<p:spPr>%s<a:prstGeom prst="rect"><a:avLst/></a:prstGeom><a:noFill/></p:spPr>`+
+					`<p:txBody><a:bodyPr wrap="none" lIns="0" tIns="0" rIns="0" bIns="0" anchor="t"><a:noAutofit/></a:bodyPr><a:lstStyle/>`+
+					`<a:p><a:pPr algn="%s"/><a:r><a:rPr lang="en-US" sz="%d" b="%s" dirty="0"><a:solidFill><a:srgbClr val="%s"/></a:solidFill>`+
+					`<a:latin typeface="Arial"/><a:cs typeface="Arial"/></a:rPr><a:t>%s</a:t></a:r></a:p></p:txBody></p:sp>`,
+					id, id, pptxXfrm(o.X, o.Y, o.W, o.H), algn, int(o.Size*100), b, o.Color.hex(), xmlEscape(o.Text))
+			case opImage:
+				media, ok := mediaNames[o.Image]
+				if !ok {
+					media = fmt.Sprintf("image%d.jpeg", len(mediaNames)+1)
+					mediaNames[o.Image] = media
+					put("ppt/media/"+media, string(o.Image.Data))
+				}
+				rel, ok := slideImages[o.Image]
+				if !ok {
+					rel = fmt.Sprintf("rId%d", len(slideImages)+2)
+					slideImages[o.Image] = rel
+					fmt.Fprintf(&rels, `<Relationship Id="%s" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/image" Target="../media/%s"/>`, rel, media)
+				}
+				fmt.Fprintf(&shapes, `<p:pic><p:nvPicPr><p:cNvPr id="%d" name="Picture %d"/><p:cNvPicPr><a:picLocks noChangeAspect="1"/></p:cNvPicPr><p:nvPr/></p:nvPicPr>`+
+					`<p:blipFill><a:blip r:embed="%s"/><a:stretch><a:fillRect/></a:stretch></p:blipFill>`+
+					`<p:spPr>%s<a:prstGeom prst="rect"><a:avLst/></a:prstGeom></p:spPr></p:pic>`,
+					id, id, rel, pptxXfrm(o.X, o.Y, o.W, o.H))
+			}
+		}
+
+		put(fmt.Sprintf("ppt/slides/slide%d.xml", i+1), xmlHeader+`<p:sld `+pptxNamespaces+`><p:cSld><p:spTree>`+
+			`<p:nvGrpSpPr><p:cNvPr id="1" name=""/><p:cNvGrpSpPr/><p:nvPr/></p:nvGrpSpPr>`+
+			`<p:grpSpPr><a:xfrm><a:off x="0" y="0"/><a:ext cx="0" cy="0"/><a:chOff x="0" y="0"/><a:chExt cx="0" cy="0"/></a:xfrm></p:grpSpPr>`+
+			shapes.String()+`</p:spTree></p:cSld><p:clrMapOvr><a:masterClrMapping/></p:clrMapOvr></p:sld>`)
+		put(fmt.Sprintf("ppt/slides/_rels/slide%d.xml.rels", i+1), pptxRels(rels.String()))
+	}
+
+	var slideOverrides, slideIds, slideRels strings.Builder
+	for i := range pages {
+		fmt.Fprintf(&slideOverrides, `<Override PartName="/ppt/slides/slide%d.xml" ContentType="application/vnd.openxmlformats-officedocument.presentationml.slide+xml"/>`, i+1)
+		fmt.Fprintf(&slideIds, `<p:sldId id="%d" r:id="rId%d"/>`, 256+i, i+10)
+		fmt.Fprintf(&slideRels, `<Relationship Id="rId%d" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/slide" Target="slides/slide%d.xml"/>`, i+10, i+1)
+	}
+	width, height := int(pageWidth*emuPerPoint), int(pageHeight*emuPerPoint)
+	primary := mustColor(doc.Template.PrimaryColor)
+	accent := mustColor(doc.Template.AccentColor)
+
+	put("[Content_Types].xml", xmlHeader+`<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">`+
+		`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>`+
+		`<Default Extension="xml" ContentType="application/xml"/>`+
+		`<Default Extension="jpeg" ContentType="image/jpeg"/>`+
+		`<Override PartName="/ppt/presentation.xml" ContentType="application/vnd.openxmlformats-officedocument.presentationml.presentation.main+xml"/>`+
+		`<Override PartName="/ppt/slideMasters/slideMaster1.xml" ContentType="application/vnd.openxmlformats-officedocument.presentationml.slideMaster+xml"/>`+
+		`<Override PartName="/ppt/slideLayouts/slideLayout1.xml" ContentType="application/vnd.openxmlformats-officedocument.presentationml.slideLayout+xml"/>`+
+		`<Override PartName="/ppt/theme/theme1.xml" ContentType="application/vnd.openxmlformats-officedocument.theme+xml"/>`+
+		`<Override PartName="/ppt/presProps.xml" ContentType="application/vnd.openxmlformats-officedocument.presentationml.presProps+xml"/>`+
+		`<Override PartName="/ppt/viewProps.xml" ContentType="application/vnd.openxmlformats-officedocument.presentationml.viewProps+xml"/>`+
+		`<Override PartName="/ppt/tableStyles.xml" ContentType="application/vnd.openxmlformats-officedocument.presentationml.tableStyles+xml"/>`+
+		`<Override PartName="/docProps/core.xml" ContentType="application/vnd.openxmlformats-package.core-properties+xml"/>`+
+		`<Override PartName="/docProps/app.xml" ContentType="application/vnd.openxmlformats-officedocument.extended-properties+xml"/>`+
+		slideOverrides.String()+`</Types>`)
+	put("_rels/.rels", pptxRels(
+		`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="ppt/presentation.xml"/>`+
+			`<Relationship Id="rId2" Type="http://schemas.openxmlformats.org/package/2006/relationships/metadata/core-properties" Target="docProps/core.xml"/>`+
+			`<Relationship Id="rId3" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/extended-properties" Target="docProps/app.xml"/>`))
+	put("docProps/core.xml", xmlHeader+`<cp:coreProperties xmlns:cp="http://schemas.openxmlformats.org/package/2006/metadata/core-properties" `+
+		`xmlns:dc="http://purl.org/dc/elements/1.1/" xmlns:dcterms="http://purl.org/dc/terms/" xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance">`+
+		`<dc:title>`+xmlEscape(doc.Template.Title+" - "+doc.PackageName)+`</dc:title><dc:creator>`+xmlEscape(doc.Template.CompanyName)+`</dc:creator>`+
+		`<dcterms:created xsi:type="dcterms:W3CDTF">`+doc.GeneratedAt.UTC().Format("2006-01-02T15:04:05Z")+`</dcterms:created></cp:coreProperties>`)
+	put("docProps/app.xml", xmlHeader+`<Properties xmlns="http://schemas.openxmlformats.org/officeDocument/2006/extended-properties">`+
+		fmt.Sprintf(`<Application>tmn-backend</Application><Slides>%d</Slides></Properties>`, len(pages)))
+	put("ppt/presentation.xml", xmlHeader+`<p:presentation `+pptxNamespaces+` saveSubsetFonts="1">`+
+		`<p:sldMasterIdLst><p:sldMasterId id="2147483648" r:id="rId1"/></p:sldMasterIdLst>`+
+		`<p:sldIdLst>`+slideIds.String()+`</p:sldIdLst>`+
+		fmt.Sprintf(`<p:sldSz cx="%d" cy="%d"/><p:notesSz cx="6858000" cy="9144000"/>`, width, height)+
+		`<p:defaultTextStyle><a:defPPr><a:defRPr lang="en-US"/></a:defPPr></p:defaultTextStyle></p:presentation>`)
+	put("ppt/_rels/presentation.xml.rels", pptxRels(
+		`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/slideMaster" Target="slideMasters/slideMaster1.xml"/>`+
+			`<Relationship Id="rId2" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/theme" Target="theme/theme1.xml"/>`+
+			`<Relationship Id="rId3" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/presProps" Target="presProps.xml"/>`+
+			`<Relationship Id="rId4" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/viewProps" Target="viewProps.xml"/>`+
+			`<Relationship Id="rId5" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/tableStyles" Target="tableStyles.xml"/>`+
+			slideRels.String()))
+	put("ppt/presProps.xml", xmlHeader+`<p:presentationPr `+pptxNamespaces+`/>`)
+	put("ppt/viewProps.xml", xmlHeader+`<p:viewPr `+pptxNamespaces+`><p:normalViewPr/><p:gridSpacing cx="76200" cy="76200"/></p:viewPr>`)
+	put("ppt/tableStyles.xml", xmlHeader+`<a:tblStyleLst xmlns:a="http://schemas.openxmlformats.org/drawingml/2006/main" def="{5C22544A-7EE6-4342-B048-85BDC9FD1C3A}"/>`)
+	put("ppt/slideMasters/slideMaster1.xml", xmlHeader+`<p:sldMaster `+pptxNamespaces+`><p:cSld><p:bg><p:bgRef idx="1001"><a:schemeClr val="bg1"/></p:bgRef></p:bg><p:spTree>`+
+		`<p:nvGrpSpPr><p:cNvPr id="1" name=""/><p:cNvGrpSpPr/><p:nvPr/></p:nvGrpSpPr>`+
+		`<p:grpSpPr><a:xfrm><a:off x="0" y="0"/><a:ext cx="0" cy="0"/><a:chOff x="0" y="0"/><a:chExt cx="0" cy="0"/></a:xfrm></p:grpSpPr></p:spTree></p:cSld>`+
+		`<p:clrMap bg1="lt1" tx1="dk1" bg2="lt2" tx2="dk2" accent1="accent1" accent2="accent2" accent3="accent3" accent4="accent4" accent5="accent5" accent6="accent6" hlink="hlink" folHlink="folHlink"/>`+
+		`<p:sldLayoutIdLst><p:sldLayoutId id="2147483649" r:id="rId1"/></p:sldLayoutIdLst>`+
+		`<p:txStyles><p:titleStyle><a:lvl1pPr><a:defRPr sz="3200"/></a:lvl1pPr></p:titleStyle><p:bodyStyle><a:lvl1pPr><a:defRPr sz="1800"/></a:lvl1pPr></p:bodyStyle>`+
+		`<p:otherStyle><a:lvl1pPr><a:defRPr sz="1800"/></a:lvl1pPr></p:otherStyle></p:txStyles></p:sldMaster>`)
+	put("ppt/slideMasters/_rels/slideMaster1.xml.rels", pptxRels(
+		`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/slideLayout" Target="../slideLayouts/slideLayout1.xml"/>`+
+			`<Relationship Id="rId2" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/theme" Target="../theme/theme1.xml"/>`))
+	put("ppt/slideLayouts/slideLayout1.xml", xmlHeader+`<p:sldLayout `+pptxNamespaces+` type="blank" preserve="1"><p:cSld name="Blank"><p:spTree>`+
+		`<p:nvGrpSpPr><p:cNvPr id="1" name=""/><p:cNvGrpSpPr/><p:nvPr/></p:nvGrpSpPr>`+
+		`<p:grpSpPr><a:xfrm><a:off x="0" y="0"/><a:ext cx="0" cy="0"/><a:chOff x="0" y="0"/><a:chExt cx="0" cy="0"/></a:xfrm></p:grpSpPr></p:spTree></p:cSld>`+
+		`<p:clrMapOvr><a:masterClrMapping/></p:clrMapOvr></p:sldLayout>`)
+	put("ppt/slideLayouts/_rels/slideLayout1.xml.rels", pptxRels(
+		`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/slideMaster" Target="../slideMasters/slideMaster1.xml"/>`))
+	put("ppt/theme/theme1.xml", pptxTheme(primary, accent))
+
+	// The content types part goes first, as some readers expect
+	order = append([]string{"[Content_Types].xml"}, order...)
+	written := map[string]bool{}
+	for _, name := range order {
+		if written[name] {
+			continue
+		}
+		written[name] = true
+		w, err := zw.Create(name)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := w.Write([]byte(files[name])); err != nil {
+			return nil, err
+		}
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func pptxXfrm(x, y, w, h float64) string {
+	return fmt.Sprintf(`<a:xfrm><a:off x="%d" y="%d"/><a:ext cx="%d" cy="%d"/></a:xfrm>`,
+		int(x*emuPerPoint), int(y*emuPerPoint), int(w*emuPerPoint), int(h*emuPerPoint))
+}
+
+func pptxRels(relationships string) string {
+	return xmlHeader + `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` + relationships + `</Relationships>`
+}
+
+func xmlEscape(s string) string {
+	var buf strings.Builder
+	xml.EscapeText(&buf, []byte(s))
+	return buf.String()
+}
+
+// pptxTheme is the minimal theme PowerPoint requires, using the template colors as the first
+// two accents so shapes added by hand match the brand
+func pptxTheme(primary, accent rgb) string {
+	fill := `<a:solidFill><a:schemeClr val="phClr"/></a:solidFill>`
+	line := `<a:ln w="9525"><a:solidFill><a:schemeClr val="phClr"/></a:solidFill></a:ln>`
+	effect := `<a:effectStyle><a:effectLst/></a:effectStyle>`
+	return xmlHeader + `<a:theme xmlns:a="http://schemas.openxmlformats.org/drawingml/2006/main" name="Proposal"><a:themeElements>` +
+		`<a:clrScheme name="Proposal">` +
+		`<a:dk1><a:srgbClr val="212529"/></a:dk1><a:lt1><a:srgbClr val="FFFFFF"/></a:lt1>` +
+		`<a:dk2><a:srgbClr val="` + primary.hex() + `"/></a:dk2><a:lt2><a:srgbClr val="F1F3F5"/></a:lt2>` +
+		`<a:accent1><a:srgbClr val="` + primary.hex() + `"/></a:accent1><a:accent2><a:srgbClr val="` + accent.hex() + `"/></a:accent2>` +
+		`<a:accent3><a:srgbClr val="6C757D"/></a:accent3><a:accent4><a:srgbClr val="2E7D32"/></a:accent4>` +
+		`<a:accent5><a:srgbClr val="1565C0"/></a:accent5><a:accent6><a:srgbClr val="C62828"/></a:accent6>` +
+		`<a:hlink><a:srgbClr val="1565C0"/></a:hlink><a:folHlink><a:srgbClr val="6A1B9A"/></a:folHlink></a:clrScheme>` +
+		`<a:fontScheme name="Proposal"><a:majorFont><a:latin typeface="Arial"/><a:ea typeface=""/><a:cs typeface=""/></a:majorFont>` +
+		`<a:minorFont><a:latin typeface="Arial"/><a:ea typeface=""/><a:cs typeface=""/></a:minorFont></a:fontScheme>` +
+		`<a:fmtScheme name="Proposal"><a:fillStyleLst>` + fill + fill + fill + `</a:fillStyleLst>` +
+		`<a:lnStyleLst>` + line + line + line + `</a:lnStyleLst>` +
+		`<a:effectStyleLst>` + effect + effect + effect + `</a:effectStyleLst>` +
+		`<a:bgFillStyleLst>` + fill + fill + fill + `</a:bgFillStyleLst></a:fmtScheme>` +
+		`</a:themeElements><a:objectDefaults/><a:extraClrSchemeLst/></a:theme>`
+}
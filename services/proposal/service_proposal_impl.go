@@ -0,0 +1,357 @@
+package proposal
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/malikabdulaziz/tmn-backend/exceptions"
+	"github.com/malikabdulaziz/tmn-backend/helpers"
+	"github.com/malikabdulaziz/tmn-backend/models"
+	repositoriesBuilding "github.com/malikabdulaziz/tmn-backend/repositories/building"
+	repositoriesSalesPackage "github.com/malikabdulaziz/tmn-backend/repositories/salespackage"
+	"github.com/malikabdulaziz/tmn-backend/services/erp"
+	servicesSalesPackage "github.com/malikabdulaziz/tmn-backend/services/salespackage"
+	webProposal "github.com/malikabdulaziz/tmn-backend/web/proposal"
+	"github.com/sirupsen/logrus"
+)
+
+// MaxProposalBuildings caps the buildings of a package a proposal can be generated for; every
+// building gets its own page and photo
+const MaxProposalBuildings = 200
+
+// photoWorkers is the number of building photos fetched from the ERP at the same time
+const photoWorkers = 8
+
+// Static map snapshot size, in pixels
+const (
+	staticMapWidth  = 1200
+	staticMapHeight = 540
+)
+
+type ServiceProposalImpl struct {
+	DB                              *sql.DB
+	RepositorySalesPackageInterface repositoriesSalesPackage.RepositorySalesPackageInterface
+	RepositoryBuildingInterface     repositoriesBuilding.RepositoryBuildingInterface
+	// ERPClient fetches the building photos, the same files the image proxy serves
+	ERPClient *erp.ERPClient
+	Config    Config
+	// HTTPClient fetches the static map snapshot
+	HTTPClient *http.Client
+	Logger     *logrus.Logger
+}
+
+func NewServiceProposalImpl(
+	db *sql.DB,
+	repoSalesPackage repositoriesSalesPackage.RepositorySalesPackageInterface,
+	repoBuilding repositoriesBuilding.RepositoryBuildingInterface,
+	erpClient *erp.ERPClient,
+	config Config,
+	logger *logrus.Logger,
+) ServiceProposalInterface {
+	return &ServiceProposalImpl{
+		DB:                              db,
+		RepositorySalesPackageInterface: repoSalesPackage,
+		RepositoryBuildingInterface:     repoBuilding,
+		ERPClient:                       erpClient,
+		Config:                          config,
+		HTTPClient:                      &http.Client{Timeout: 15 * time.Second},
+		Logger:                          logger,
+	}
+}
+
+// Generate builds the proposal of a sales package. Photos and the map are best effort: when one
+// cannot be fetched the document shows a placeholder instead and the failure is logged.
+func (service *ServiceProposalImpl) Generate(ctx context.Context, salesPackageId int, request webProposal.GenerateProposalRequest) (webProposal.ProposalFileResponse, error) {
+	format := strings.ToLower(strings.TrimSpace(request.Format))
+	if format == "" {
+		format = webProposal.FormatPDF
+	}
+	if format != webProposal.FormatPDF && format != webProposal.FormatPPTX {
+		panic(exceptions.NewBadRequest("format must be pdf or pptx"))
+	}
+	templateName := strings.TrimSpace(request.Template)
+	if templateName == "" {
+		templateName = DefaultTemplateName
+	}
+	template, ok := service.Config.Templates[templateName]
+	if !ok {
+		panic(exceptions.NewBadRequest(fmt.Sprintf("unknown proposal template %q", templateName)))
+	}
+
+	doc, buildings := service.loadDocument(ctx, salesPackageId, request.Version)
+	doc.Template = template
+	doc.ClientName = strings.TrimSpace(request.ClientName)
+	doc.GeneratedAt = time.Now()
+
+	if len(template.Logo) > 0 {
+		logo, err := NormalizeImage(template.Logo)
+		if err != nil {
+			service.Logger.WithError(err).WithField("template", template.Name).Warn("Proposal template logo is not a usable image")
+		}
+		doc.Logo = logo
+	}
+	if template.HasSection(SectionBuildings) {
+		service.fetchPhotos(ctx, doc.Buildings, buildings)
+	}
+	if template.HasSection(SectionMap) {
+		doc.Map = service.fetchStaticMap(ctx, doc.Buildings)
+	}
+
+	file := webProposal.ProposalFileResponse{
+		Filename: proposalFilename(doc.PackageName, doc.Version, doc.GeneratedAt, format),
+		Version:  doc.Version,
+	}
+	var err error
+	if format == webProposal.FormatPPTX {
+		file.ContentType = "application/vnd.openxmlformats-officedocument.presentationml.presentation"
+		file.Content, err = RenderPPTX(doc)
+	} else {
+		file.ContentType = "application/pdf"
+		file.Content, err = RenderPDF(doc)
+	}
+	if err != nil {
+		return webProposal.ProposalFileResponse{}, err
+	}
+	return file, nil
+}
+
+// loadDocument reads a version of the package (the latest when version is 0), its summary and its
+// buildings in package order. Audience, impressions and screens are the ones stored with the
+// version. The buildings are returned alongside the document for their photo paths. Packages the
+// current user may not see are reported as not found.
+func (service *ServiceProposalImpl) loadDocument(ctx context.Context, salesPackageId int, version int) (Document, []models.Building) {
+	tx, err := service.DB.Begin()
+	helpers.PanicIfError(err)
+	defer helpers.CommitOrRollback(tx)
+
+	access, err := service.RepositorySalesPackageInterface.FindAccess(ctx, tx, salesPackageId, helpers.UserIdFromContext(ctx))
+	if err == sql.ErrNoRows || (err == nil && !access.Visible) {
+		panic(exceptions.NewNotFoundError("sales package not found"))
+	}
+	helpers.PanicIfError(err)
+	pkgVersion, err := service.RepositorySalesPackageInterface.FindVersion(ctx, tx, salesPackageId, version)
+	if err == sql.ErrNoRows {
+		if version > 0 {
+			panic(exceptions.NewNotFoundError("sales package version not found"))
+		}
+		panic(exceptions.NewNotFoundError("sales package not found"))
+	}
+	helpers.PanicIfError(err)
+
+	rows, err := service.RepositorySalesPackageInterface.FindVersionSummaryRows(ctx, tx, pkgVersion.Id)
+	helpers.PanicIfError(err)
+	if len(rows) == 0 {
+		panic(exceptions.NewBadRequest("sales package has no buildings"))
+	}
+	if len(rows) > MaxProposalBuildings {
+		panic(exceptions.NewBadRequest(fmt.Sprintf("a proposal can cover at most %d buildings, this package has %d", MaxProposalBuildings, len(rows))))
+	}
+	figures := make(map[int]repositoriesSalesPackage.SalesPackageSummaryRow, len(rows))
+	ids := make([]int, len(rows))
+	for i, row := range rows {
+		figures[row.BuildingId] = row
+		ids[i] = row.BuildingId
+	}
+	found, err := service.RepositoryBuildingInterface.FindByIds(ctx, tx, ids)
+	helpers.PanicIfError(err)
+	byId := make(map[int]models.Building, len(found))
+	for _, building := range found {
+		byId[building.Id] = building
+	}
+
+	pkg := models.SalesPackage{Id: pkgVersion.SalesPackageId, Name: pkgVersion.Name}
+	doc := Document{
+		PackageName: pkgVersion.Name,
+		Version:     pkgVersion.Version,
+		Summary:     servicesSalesPackage.SummarizeSalesPackage(pkg, rows),
+	}
+	var buildings []models.Building
+	for _, id := range ids {
+		b, ok := byId[id]
+		if !ok {
+			continue
+		}
+		buildings = append(buildings, b)
+		doc.Buildings = append(doc.Buildings, DocumentBuilding{
+			Number:         len(buildings),
+			Name:           b.Name,
+			ProjectName:    b.ProjectName,
+			Subdistrict:    b.Subdistrict,
+			Citytown:       b.Citytown,
+			Province:       b.Province,
+			BuildingType:   b.BuildingType,
+			GradeResource:  b.GradeResource,
+			CbdArea:        b.CbdArea,
+			CompletionYear: b.CompletionYear,
+			Audience:       figures[b.Id].Audience,
+			Impression:     figures[b.Id].Impression,
+			Screens:        figures[b.Id].Screens,
+			Latitude:       b.Latitude,
+			Longitude:      b.Longitude,
+		})
+	}
+	return doc, buildings
+}
+
+// fetchPhotos downloads the front side photo of every building through the ERP, a few at a time
+func (service *ServiceProposalImpl) fetchPhotos(ctx context.Context, docBuildings []DocumentBuilding, buildings []models.Building) {
+	if service.ERPClient == nil {
+		return
+	}
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < photoWorkers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				path := frontSidePhoto(buildings[i])
+				if path == "" {
+					continue
+				}
+				data, err := service.ERPClient.FetchImage(ctx, path)
+				if err == nil {
+					docBuildings[i].Photo, err = NormalizeImage(data)
+				}
+				if err != nil {
+					service.Logger.WithError(err).WithField("building_id", buildings[i].Id).Warn("Failed to load building photo for proposal")
+				}
+			}
+		}()
+	}
+	for i := range buildings {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+}
+
+func frontSidePhoto(building models.Building) string {
+	for _, image := range building.Images {
+		if image.Name == "front_side" {
+			return image.Path
+		}
+	}
+	return ""
+}
+
+// fetchStaticMap requests the configured static map centered on the buildings; nil means the
+// layout draws its own location plot
+func (service *ServiceProposalImpl) fetchStaticMap(ctx context.Context, buildings []DocumentBuilding) *Image {
+	mapURL := staticMapURL(service.Config.StaticMapURL, buildings)
+	if mapURL == "" {
+		return nil
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, mapURL, nil)
+	if err != nil {
+		service.Logger.WithError(err).Warn("Invalid proposal static map URL")
+		return nil
+	}
+	resp, err := service.HTTPClient.Do(req)
+	if err != nil {
+		service.Logger.WithError(err).Warn("Failed to fetch proposal static map")
+		return nil
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		service.Logger.WithField("status", resp.StatusCode).Warn("Static map server rejected the proposal map request")
+		return nil
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, 20<<20))
+	if err != nil {
+		service.Logger.WithError(err).Warn("Failed to read proposal static map")
+		return nil
+	}
+	img, err := NormalizeImage(data)
+	if err != nil {
+		service.Logger.WithError(err).Warn("Proposal static map is not a usable image")
+		return nil
+	}
+	return img
+}
+
+// staticMapURL fills the placeholders of the configured URL template; the marker list is query
+// escaped. The zoom is the largest web mercator zoom level that keeps every building inside the snapshot.
+func staticMapURL(template string, buildings []DocumentBuilding) string {
+	if template == "" {
+		return ""
+	}
+	var markers []string
+	minLat, maxLat, minLng, maxLng := 90.0, -90.0, 180.0, -180.0
+	for _, b := range buildings {
+		if b.Latitude == 0 && b.Longitude == 0 {
+			continue
+		}
+		markers = append(markers, fmt.Sprintf("%.6f,%.6f", b.Latitude, b.Longitude))
+		minLat, maxLat = min(minLat, b.Latitude), max(maxLat, b.Latitude)
+		minLng, maxLng = min(minLng, b.Longitude), max(maxLng, b.Longitude)
+	}
+	if len(markers) == 0 {
+		return ""
+	}
+
+	zoom := 16
+	for zoom > 1 {
+		// A 256px tile spans 360 degrees of longitude at zoom 0; leave a 10% margin
+		degreesPerPixel := 360 / (256 * float64(int(1)<<zoom))
+		if (maxLng-minLng)/degreesPerPixel <= staticMapWidth*0.9 && (maxLat-minLat)/degreesPerPixel <= staticMapHeight*0.9 {
+			break
+		}
+		zoom--
+	}
+
+	replacer := strings.NewReplacer(
+		"{lat}", strconv.FormatFloat((minLat+maxLat)/2, 'f', 6, 64),
+		"{lng}", strconv.FormatFloat((minLng+maxLng)/2, 'f', 6, 64),
+		"{zoom}", strconv.Itoa(zoom),
+		"{width}", strconv.Itoa(staticMapWidth),
+		"{height}", strconv.Itoa(staticMapHeight),
+		"{markers}", url.QueryEscape(strings.Join(markers, "|")),
+	)
+	return replacer.Replace(template)
+}
+
+var unsafeFilenameChars = regexp.MustCompile(`[^A-Za-z0-9_-]+`)
+
+func proposalFilename(packageName string, version int, at time.Time, format string) string {
+	name := strings.Trim(unsafeFilenameChars.ReplaceAllString(packageName, "_"), "_")
+	if name == "" {
+		name = "SalesPackage"
+	}
+	if version > 0 {
+		name += "_v" + strconv.Itoa(version)
+	}
+	return "Proposal_" + name + "_" + at.Format("02-01-2006") + "." + format
+}
+
+func (service *ServiceProposalImpl) Templates(ctx context.Context) []webProposal.ProposalTemplateResponse {
+	names := make([]string, 0, len(service.Config.Templates))
+	for name := range service.Config.Templates {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	responses := make([]webProposal.ProposalTemplateResponse, 0, len(names))
+	for _, name := range names {
+		t := service.Config.Templates[name]
+		responses = append(responses, webProposal.ProposalTemplateResponse{
+			Name:        t.Name,
+			Title:       t.Title,
+			Subtitle:    t.Subtitle,
+			CompanyName: t.CompanyName,
+			Sections:    t.Sections,
+			HasLogo:     len(t.Logo) > 0,
+		})
+	}
+	return responses
+}
@@ -0,0 +1,257 @@
+package proposal_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"database/sql"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/malikabdulaziz/tmn-backend/exceptions"
+	"github.com/malikabdulaziz/tmn-backend/models"
+	repositoriesSalesPackage "github.com/malikabdulaziz/tmn-backend/repositories/salespackage"
+	"github.com/malikabdulaziz/tmn-backend/services/erp"
+	serviceProposal "github.com/malikabdulaziz/tmn-backend/services/proposal"
+	"github.com/malikabdulaziz/tmn-backend/testutil"
+	"github.com/malikabdulaziz/tmn-backend/testutil/mocks"
+	webProposal "github.com/malikabdulaziz/tmn-backend/web/proposal"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func testPNG(t *testing.T, width, height int) []byte {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			img.Set(x, y, color.RGBA{R: uint8(x), G: uint8(y), B: 120, A: 255})
+		}
+	}
+	var buf bytes.Buffer
+	assert.NoError(t, png.Encode(&buf, img))
+	return buf.Bytes()
+}
+
+// newERPServer serves /files/front.png and answers 404 for anything else
+func newERPServer(t *testing.T) *httptest.Server {
+	photo := testPNG(t, 64, 48)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/files/front.png" {
+			http.NotFound(w, r)
+			return
+		}
+		assert.Equal(t, "Token key:secret", r.Header.Get("Authorization"))
+		w.Header().Set("Content-Type", "image/png")
+		w.Write(photo)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func newProposalService(db *sql.DB, repoPkg *mocks.MockRepositorySalesPackage, repoBuilding *mocks.MockRepositoryBuilding, erpURL string, config serviceProposal.Config) serviceProposal.ServiceProposalInterface {
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+	return serviceProposal.NewServiceProposalImpl(db, repoPkg, repoBuilding, erp.NewERPClient(erpURL, "key", "secret"), config, logger)
+}
+
+// expectPackage sets up version 3 of a two building package; only Tower A has a front side photo
+func expectPackage(sqlMock sqlmock.Sqlmock, repoPkg *mocks.MockRepositorySalesPackage, repoBuilding *mocks.MockRepositoryBuilding, version int) {
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectCommit()
+
+	repoPkg.On("FindAccess", mock.Anything, mock.AnythingOfType("*sql.Tx"), 1, mock.Anything).Return(models.Access{Visible: true}, nil)
+	repoPkg.On("FindVersion", mock.Anything, mock.AnythingOfType("*sql.Tx"), 1, version).
+		Return(models.SalesPackageVersion{Id: 30, SalesPackageId: 1, Version: 3, Name: "Jakarta Offices"}, nil)
+	repoPkg.On("FindVersionSummaryRows", mock.Anything, mock.AnythingOfType("*sql.Tx"), 30).
+		Return([]repositoriesSalesPackage.SalesPackageSummaryRow{
+			{SalesPackageId: 1, BuildingId: 10, BuildingName: "Tower A", BuildingType: "Office", Citytown: "Jakarta Selatan", GradeResource: "A",
+				Sellable: "sell", LcdPresenceStatus: "TMN", Audience: 1000, Impression: 20000, Screens: 4, HasProposal: true},
+			{SalesPackageId: 1, BuildingId: 20, BuildingName: "Tower B", BuildingType: "Office", Citytown: "Jakarta Pusat", GradeResource: "B",
+				Sellable: "sell", LcdPresenceStatus: "TMN", Audience: 500, Impression: 8000, Screens: 2, HasProposal: true},
+		}, nil)
+
+	towerA := testutil.NewBuilding(10, "Tower A")
+	towerA.Images = []models.BuildingImage{{Name: "front_side", Path: "/files/front.png"}}
+	towerB := testutil.NewBuilding(20, "Tower B")
+	towerB.Latitude, towerB.Longitude = -6.18, 106.83
+	// FindByIds does not keep the package order
+	repoBuilding.On("FindByIds", mock.Anything, mock.AnythingOfType("*sql.Tx"), []int{10, 20}).
+		Return([]models.Building{towerB, towerA}, nil)
+}
+
+func TestProposalGenerate_PDF(t *testing.T) {
+	db, sqlMock := testutil.NewMockDB(t)
+	repoPkg := &mocks.MockRepositorySalesPackage{}
+	repoBuilding := &mocks.MockRepositoryBuilding{}
+	server := newERPServer(t)
+	svc := newProposalService(db, repoPkg, repoBuilding, server.URL, serviceProposal.NewConfig(nil, ""))
+	expectPackage(sqlMock, repoPkg, repoBuilding, 0)
+
+	file, err := svc.Generate(context.Background(), 1, webProposal.GenerateProposalRequest{ClientName: "Acme"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "application/pdf", file.ContentType)
+	assert.True(t, strings.HasPrefix(file.Filename, "Proposal_Jakarta_Offices_v3_"))
+	assert.Equal(t, 3, file.Version)
+	assert.True(t, strings.HasSuffix(file.Filename, ".pdf"))
+	content := string(file.Content)
+	assert.True(t, strings.HasPrefix(content, "%PDF-1.4"))
+	assert.True(t, strings.HasSuffix(content, "%%EOF\n"))
+	// Cover, summary, map, one page per building and the building table
+	assert.Contains(t, content, "/Type /Pages /Kids [")
+	assert.Contains(t, content, "/Count 6 >>")
+	// Only Tower A has a photo; the map is drawn, not embedded
+	assert.Equal(t, 1, strings.Count(content, "/Subtype /Image"))
+	// Latin text needs no embedded font
+	assert.NotContains(t, content, "/FontFile2")
+	repoPkg.AssertExpectations(t)
+	repoBuilding.AssertExpectations(t)
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+}
+
+func TestProposalGenerate_PPTXWithTemplateAndStaticMap(t *testing.T) {
+	db, sqlMock := testutil.NewMockDB(t)
+	repoPkg := &mocks.MockRepositorySalesPackage{}
+	repoBuilding := &mocks.MockRepositoryBuilding{}
+	server := newERPServer(t)
+
+	var mapQuery string
+	mapImage := testPNG(t, 120, 60)
+	mapServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mapQuery = r.URL.RawQuery
+		w.Write(mapImage)
+	}))
+	defer mapServer.Close()
+
+	template := serviceProposal.DefaultTemplate()
+	template.Name = "brand"
+	template.Title = "Office Network"
+	template.Sections = []string{serviceProposal.SectionCover, serviceProposal.SectionMap, serviceProposal.SectionBuildings}
+	config := serviceProposal.NewConfig([]serviceProposal.Template{template}, mapServer.URL+"/map?size={width}x{height}&z={zoom}&markers={markers}")
+	svc := newProposalService(db, repoPkg, repoBuilding, server.URL, config)
+	expectPackage(sqlMock, repoPkg, repoBuilding, 3)
+
+	file, err := svc.Generate(context.Background(), 1, webProposal.GenerateProposalRequest{Format: "pptx", Template: "brand", ClientName: "Acme & Co", Version: 3})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "application/vnd.openxmlformats-officedocument.presentationml.presentation", file.ContentType)
+	assert.Equal(t, "size=1200x540&z=15&markers=-6.200000%2C106.800000%7C-6.180000%2C106.830000", mapQuery)
+
+	reader, err := zip.NewReader(bytes.NewReader(file.Content), int64(len(file.Content)))
+	assert.NoError(t, err)
+	files := map[string]string{}
+	for _, f := range reader.File {
+		rc, err := f.Open()
+		assert.NoError(t, err)
+		data, _ := io.ReadAll(rc)
+		rc.Close()
+		files[f.Name] = string(data)
+	}
+	assert.Equal(t, "[Content_Types].xml", reader.File[0].Name)
+	// Cover, map and the two building slides
+	for _, name := range []string{"ppt/slides/slide1.xml", "ppt/slides/slide4.xml", "ppt/media/image1.jpeg", "ppt/media/image2.jpeg"} {
+		assert.Contains(t, files, name)
+	}
+	assert.NotContains(t, files, "ppt/slides/slide5.xml")
+	assert.Contains(t, files["ppt/slides/slide1.xml"], "Acme &amp; Co")
+	assert.Contains(t, files["ppt/slides/slide1.xml"], "Office Network")
+	assert.Contains(t, files["ppt/slides/slide1.xml"], "Version 3")
+	assert.Contains(t, files["ppt/presentation.xml"], `<p:sldSz cx="12192000" cy="6858000"/>`)
+	repoPkg.AssertExpectations(t)
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+}
+
+func TestProposalGenerate_UnknownTemplate(t *testing.T) {
+	db, _ := testutil.NewMockDB(t)
+	svc := newProposalService(db, &mocks.MockRepositorySalesPackage{}, &mocks.MockRepositoryBuilding{}, "http://erp.invalid", serviceProposal.NewConfig(nil, ""))
+
+	assert.PanicsWithValue(t,
+		exceptions.BadRequestError{Error: `unknown proposal template "brand"`},
+		func() { svc.Generate(context.Background(), 1, webProposal.GenerateProposalRequest{Template: "brand"}) },
+	)
+	assert.PanicsWithValue(t,
+		exceptions.BadRequestError{Error: "format must be pdf or pptx"},
+		func() { svc.Generate(context.Background(), 1, webProposal.GenerateProposalRequest{Format: "docx"}) },
+	)
+}
+
+func TestProposalGenerate_PackageNotFound(t *testing.T) {
+	db, sqlMock := testutil.NewMockDB(t)
+	repoPkg := &mocks.MockRepositorySalesPackage{}
+	svc := newProposalService(db, repoPkg, &mocks.MockRepositoryBuilding{}, "http://erp.invalid", serviceProposal.NewConfig(nil, ""))
+
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectRollback()
+	repoPkg.On("FindAccess", mock.Anything, mock.AnythingOfType("*sql.Tx"), 9, 0).Return(models.Access{}, sql.ErrNoRows)
+	repoPkg.On("FindAccess", mock.Anything, mock.AnythingOfType("*sql.Tx"), 1, 0).Return(models.Access{Visible: true}, nil)
+	repoPkg.On("FindVersion", mock.Anything, mock.AnythingOfType("*sql.Tx"), 1, 4).
+		Return(models.SalesPackageVersion{}, sql.ErrNoRows)
+
+	assert.PanicsWithValue(t,
+		exceptions.NotFoundError{Error: "sales package not found"},
+		func() { svc.Generate(context.Background(), 9, webProposal.GenerateProposalRequest{}) },
+	)
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectRollback()
+	assert.PanicsWithValue(t,
+		exceptions.NotFoundError{Error: "sales package version not found"},
+		func() { svc.Generate(context.Background(), 1, webProposal.GenerateProposalRequest{Version: 4}) },
+	)
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+}
+
+func TestRenderPDF_EmbedsUnicodeFontForNonLatinText(t *testing.T) {
+	doc := serviceProposal.Document{
+		Template:    serviceProposal.DefaultTemplate(),
+		PackageName: "Жилой комплекс",
+		ClientName:  "Café “Ελλάδα”",
+		Buildings:   []serviceProposal.DocumentBuilding{{Number: 1, Name: "Tower A"}},
+	}
+
+	data, err := serviceProposal.RenderPDF(doc)
+
+	assert.NoError(t, err)
+	content := string(data)
+	assert.Contains(t, content, "/Subtype /Type0 /BaseFont /GoRegular /Encoding /Identity-H")
+	assert.Contains(t, content, "/FontFile2")
+	// Ж (U+0416) is mapped back to text and the title is written as UTF-16
+	assert.Regexp(t, `<[0-9A-F]{4}> <0416>`, content)
+	assert.Contains(t, content, "/Title <FEFF")
+}
+
+func TestProposalTemplates_ListsDefaultAndConfigured(t *testing.T) {
+	db, _ := testutil.NewMockDB(t)
+	template := serviceProposal.DefaultTemplate()
+	template.Name = "brand"
+	svc := newProposalService(db, &mocks.MockRepositorySalesPackage{}, &mocks.MockRepositoryBuilding{}, "http://erp.invalid",
+		serviceProposal.NewConfig([]serviceProposal.Template{template}, ""))
+
+	templates := svc.Templates(context.Background())
+
+	assert.Len(t, templates, 2)
+	assert.Equal(t, "brand", templates[0].Name)
+	assert.Equal(t, "default", templates[1].Name)
+}
+
+func TestReadTemplates_FillsDefaultsAndValidates(t *testing.T) {
+	templates, err := serviceProposal.ReadTemplates(strings.NewReader(`[{"name": "brand", "primary_color": "#112233", "sections": ["cover", "table"]}]`), ".")
+	assert.NoError(t, err)
+	assert.Len(t, templates, 1)
+	assert.Equal(t, "Advertising Proposal", templates[0].Title)
+	assert.Equal(t, "#F2A900", templates[0].AccentColor)
+	assert.Equal(t, []string{"cover", "table"}, templates[0].Sections)
+
+	_, err = serviceProposal.ReadTemplates(strings.NewReader(`[{"name": "brand", "primary_color": "blue"}]`), ".")
+	assert.EqualError(t, err, `proposal template "brand": color "blue" must be #RRGGBB`)
+
+	_, err = serviceProposal.ReadTemplates(strings.NewReader(`[{"name": "brand", "sections": ["cover", "pricing"]}]`), ".")
+	assert.EqualError(t, err, `proposal template "brand": unknown section "pricing"`)
+}
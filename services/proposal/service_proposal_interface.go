@@ -0,0 +1,12 @@
+package proposal
+
+import (
+	"context"
+
+	webProposal "github.com/malikabdulaziz/tmn-backend/web/proposal"
+)
+
+type ServiceProposalInterface interface {
+	Generate(ctx context.Context, salesPackageId int, request webProposal.GenerateProposalRequest) (webProposal.ProposalFileResponse, error)
+	Templates(ctx context.Context) []webProposal.ProposalTemplateResponse
+}
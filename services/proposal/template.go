@@ -0,0 +1,189 @@
+package proposal
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// Proposal sections, in the order the default template renders them
+const (
+	SectionCover     = "cover"
+	SectionSummary   = "summary"
+	SectionMap       = "map"
+	SectionBuildings = "buildings"
+	SectionTable     = "table"
+)
+
+var defaultSections = []string{SectionCover, SectionSummary, SectionMap, SectionBuildings, SectionTable}
+
+// DefaultTemplateName is the template used when a request names none
+const DefaultTemplateName = "default"
+
+// Template brands a proposal document. Colors are #RRGGBB; Sections lists which sections are
+// rendered and in what order. Logo holds the PNG or JPEG read from LogoPath when the template is
+// loaded.
+type Template struct {
+	Name         string   `json:"name"`
+	Title        string   `json:"title"`
+	Subtitle     string   `json:"subtitle"`
+	CompanyName  string   `json:"company_name"`
+	PrimaryColor string   `json:"primary_color"`
+	AccentColor  string   `json:"accent_color"`
+	FooterText   string   `json:"footer_text"`
+	ContactLines []string `json:"contact_lines"`
+	LogoPath     string   `json:"logo_path"`
+	Sections     []string `json:"sections"`
+	Logo         []byte   `json:"-"`
+}
+
+// DefaultTemplate is the built-in template; a configured template named "default" replaces it
+func DefaultTemplate() Template {
+	return Template{
+		Name:         DefaultTemplateName,
+		Title:        "Advertising Proposal",
+		Subtitle:     "Digital screen network",
+		CompanyName:  "TMN",
+		PrimaryColor: "#0B3C5D",
+		AccentColor:  "#F2A900",
+		Sections:     defaultSections,
+	}
+}
+
+// Config holds the proposal templates by name and the optional static map URL. StaticMapURL may
+// contain {lat}, {lng}, {zoom}, {width}, {height} and {markers} ("lat,lng|lat,lng|...", query escaped);
+// without it the map section is drawn as a plain plot of the building locations.
+type Config struct {
+	Templates    map[string]Template
+	StaticMapURL string
+}
+
+// NewConfig returns a Config with the default template plus templates, which override it by name
+func NewConfig(templates []Template, staticMapURL string) Config {
+	config := Config{Templates: map[string]Template{DefaultTemplateName: DefaultTemplate()}, StaticMapURL: staticMapURL}
+	for _, t := range templates {
+		config.Templates[t.Name] = t
+	}
+	return config
+}
+
+// LoadTemplates reads a JSON array of templates from path. Logo paths are resolved relative to
+// the file.
+func LoadTemplates(path string) ([]Template, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return ReadTemplates(file, filepath.Dir(path))
+}
+
+// ReadTemplates parses and validates templates (see LoadTemplates); baseDir resolves relative
+// logo paths. Missing fields fall back to the default template.
+func ReadTemplates(r io.Reader, baseDir string) ([]Template, error) {
+	var raw []Template
+	if err := json.NewDecoder(r).Decode(&raw); err != nil {
+		return nil, fmt.Errorf("invalid proposal templates: %w", err)
+	}
+
+	defaults := DefaultTemplate()
+	seen := map[string]bool{}
+	templates := make([]Template, 0, len(raw))
+	for i, t := range raw {
+		t.Name = strings.TrimSpace(t.Name)
+		if t.Name == "" {
+			return nil, fmt.Errorf("proposal template %d has no name", i+1)
+		}
+		if seen[t.Name] {
+			return nil, fmt.Errorf("proposal template %q is defined twice", t.Name)
+		}
+		seen[t.Name] = true
+
+		if t.Title == "" {
+			t.Title = defaults.Title
+		}
+		if t.PrimaryColor == "" {
+			t.PrimaryColor = defaults.PrimaryColor
+		}
+		if t.AccentColor == "" {
+			t.AccentColor = defaults.AccentColor
+		}
+		for _, color := range []string{t.PrimaryColor, t.AccentColor} {
+			if _, err := parseColor(color); err != nil {
+				return nil, fmt.Errorf("proposal template %q: %w", t.Name, err)
+			}
+		}
+		if len(t.Sections) == 0 {
+			t.Sections = defaultSections
+		}
+		for _, section := range t.Sections {
+			if !isSection(section) {
+				return nil, fmt.Errorf("proposal template %q: unknown section %q", t.Name, section)
+			}
+		}
+
+		if t.LogoPath != "" {
+			logoPath := t.LogoPath
+			if !filepath.IsAbs(logoPath) {
+				logoPath = filepath.Join(baseDir, logoPath)
+			}
+			logo, err := os.ReadFile(logoPath)
+			if err != nil {
+				return nil, fmt.Errorf("proposal template %q: %w", t.Name, err)
+			}
+			t.Logo = logo
+		}
+		templates = append(templates, t)
+	}
+	return templates, nil
+}
+
+// HasSection reports whether the template renders section
+func (t Template) HasSection(section string) bool {
+	for _, s := range t.Sections {
+		if s == section {
+			return true
+		}
+	}
+	return false
+}
+
+func isSection(section string) bool {
+	for _, s := range defaultSections {
+		if s == section {
+			return true
+		}
+	}
+	return false
+}
+
+// rgb is a color with components in 0..255
+type rgb struct {
+	R, G, B uint8
+}
+
+func parseColor(value string) (rgb, error) {
+	hex := strings.TrimPrefix(strings.TrimSpace(value), "#")
+	if len(hex) != 6 {
+		return rgb{}, fmt.Errorf("color %q must be #RRGGBB", value)
+	}
+	n, err := strconv.ParseUint(hex, 16, 32)
+	if err != nil {
+		return rgb{}, fmt.Errorf("color %q must be #RRGGBB", value)
+	}
+	return rgb{R: uint8(n >> 16), G: uint8(n >> 8), B: uint8(n)}, nil
+}
+
+// mustColor parses a color already checked by ReadTemplates, falling back to black
+func mustColor(value string) rgb {
+	c, _ := parseColor(value)
+	return c
+}
+
+func (c rgb) hex() string {
+	return fmt.Sprintf("%02X%02X%02X", c.R, c.G, c.B)
+}
@@ -1,41 +1,74 @@
 package salespackage
 
 import (
-	"bytes"
 	"context"
 	"database/sql"
-	"encoding/csv"
+	"encoding/json"
 	"fmt"
-	"io"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/malikabdulaziz/tmn-backend/exceptions"
 	"github.com/malikabdulaziz/tmn-backend/helpers"
+	"github.com/malikabdulaziz/tmn-backend/importer"
 	"github.com/malikabdulaziz/tmn-backend/models"
 	repositoriesBuilding "github.com/malikabdulaziz/tmn-backend/repositories/building"
+	repositoriesBuildingRestriction "github.com/malikabdulaziz/tmn-backend/repositories/buildingrestriction"
 	repositoriesSalesPackage "github.com/malikabdulaziz/tmn-backend/repositories/salespackage"
+	servicesBuilding "github.com/malikabdulaziz/tmn-backend/services/building"
+	servicesBuildingRestriction "github.com/malikabdulaziz/tmn-backend/services/buildingrestriction"
+	"github.com/malikabdulaziz/tmn-backend/web"
+	webBuilding "github.com/malikabdulaziz/tmn-backend/web/building"
+	webBuildingRestriction "github.com/malikabdulaziz/tmn-backend/web/buildingrestriction"
 	webSalesPackage "github.com/malikabdulaziz/tmn-backend/web/salespackage"
 	"github.com/xuri/excelize/v2"
 )
 
+// salesPackageImportColumns maps each import column to its accepted header spellings
+var salesPackageImportColumns = map[string][]string{
+	"name":          {"name"},
+	"building_name": {"building_name", "buildingname", "building_names", "buildingnames", "buildings"},
+}
+
 type ServiceSalesPackageImpl struct {
 	DB                            *sql.DB
 	RepositorySalesPackageInterface repositoriesSalesPackage.RepositorySalesPackageInterface
 	RepositoryBuildingInterface     repositoriesBuilding.RepositoryBuildingInterface
+	// ServiceBuildingInterface resolves mapping filters into buildings for filter-built packages
+	ServiceBuildingInterface servicesBuilding.ServiceBuildingInterface
+	// RepositoryBuildingRestrictionInterface checks the advertiser of a package against the building restrictions
+	RepositoryBuildingRestrictionInterface repositoriesBuildingRestriction.RepositoryBuildingRestrictionInterface
 }
 
 func NewServiceSalesPackageImpl(
 	db *sql.DB,
 	repoSalesPackage repositoriesSalesPackage.RepositorySalesPackageInterface,
 	repoBuilding repositoriesBuilding.RepositoryBuildingInterface,
+	serviceBuilding servicesBuilding.ServiceBuildingInterface,
+	repoBuildingRestriction repositoriesBuildingRestriction.RepositoryBuildingRestrictionInterface,
 ) ServiceSalesPackageInterface {
 	return &ServiceSalesPackageImpl{
 		DB:                            db,
 		RepositorySalesPackageInterface: repoSalesPackage,
 		RepositoryBuildingInterface:     repoBuilding,
+		ServiceBuildingInterface:        serviceBuilding,
+		RepositoryBuildingRestrictionInterface: repoBuildingRestriction,
 	}
 }
 
+// checkRestrictions rejects a package whose advertiser is barred from any of its buildings by a
+// block restriction and returns the warn-level matches. Packages have no run dates, so every
+// restriction still in force from today on counts.
+func (s *ServiceSalesPackageImpl) checkRestrictions(ctx context.Context, tx *sql.Tx, buildingIds []int, categoryId int, motherBrandId int) []webBuildingRestriction.RestrictionViolationResponse {
+	return servicesBuildingRestriction.CheckAdvertiser(ctx, tx, s.RepositoryBuildingRestrictionInterface, repositoriesBuildingRestriction.RestrictionCheck{
+		BuildingIds:   buildingIds,
+		CategoryId:    categoryId,
+		MotherBrandId: motherBrandId,
+		From:          time.Now().Format("2006-01-02"),
+	})
+}
+
 // validateBuildingIdsErr ensures all building ids exist; panics with BadRequest if any invalid
 func (s *ServiceSalesPackageImpl) validateBuildingIdsErr(ctx context.Context, tx *sql.Tx, buildingIds []int) {
 	for _, bid := range buildingIds {
@@ -54,22 +87,31 @@ func (s *ServiceSalesPackageImpl) Create(ctx context.Context, request webSalesPa
 	defer helpers.CommitOrRollback(tx)
 
 	s.validateBuildingIdsErr(ctx, tx, request.BuildingIds)
+	warnings := s.checkRestrictions(ctx, tx, request.BuildingIds, request.AdvertiserCategoryId, request.AdvertiserMotherBrandId)
 
-	pkg := models.SalesPackage{Name: request.Name}
+	pkg := models.SalesPackage{
+		Name:       request.Name,
+		CreatedBy:  helpers.OptionalUserIdFromContext(ctx),
+		Visibility: models.VisibilityOrDefault(request.Visibility),
+	}
 	created, err := s.RepositorySalesPackageInterface.Create(ctx, tx, pkg, request.BuildingIds)
 	helpers.PanicIfError(err)
-	return s.modelToResponse(created)
+	response := s.modelToResponse(created)
+	response.RestrictionWarnings = warnings
+	response.Version = s.snapshot(ctx, tx, created.Id, "created")
+	return response
 }
 
-// FindAll retrieves all sales packages with pagination
+// FindAll retrieves the sales packages the current user may see with pagination
 func (s *ServiceSalesPackageImpl) FindAll(ctx context.Context, request webSalesPackage.SalesPackageRequestFindAll) ([]webSalesPackage.SalesPackageResponse, int) {
 	tx, err := s.DB.Begin()
 	helpers.PanicIfError(err)
 	defer helpers.CommitOrRollback(tx)
 
-	list, err := s.RepositorySalesPackageInterface.FindAll(ctx, tx, request.GetTake(), request.GetSkip(), request.GetOrderBy(), request.GetOrderDirection())
+	userId := helpers.UserIdFromContext(ctx)
+	list, err := s.RepositorySalesPackageInterface.FindAll(ctx, tx, userId, request.GetTake(), request.GetSkip(), request.GetOrderBy(), request.GetOrderDirection())
 	helpers.PanicIfError(err)
-	total, err := s.RepositorySalesPackageInterface.CountAll(ctx, tx)
+	total, err := s.RepositorySalesPackageInterface.CountAll(ctx, tx, userId)
 	helpers.PanicIfError(err)
 
 	responses := make([]webSalesPackage.SalesPackageResponse, len(list))
@@ -85,81 +127,578 @@ func (s *ServiceSalesPackageImpl) FindById(ctx context.Context, id int) webSales
 	helpers.PanicIfError(err)
 	defer helpers.CommitOrRollback(tx)
 
-	pkg, err := s.RepositorySalesPackageInterface.FindById(ctx, tx, id)
-	if err == sql.ErrNoRows {
+	return s.modelToResponse(s.find(ctx, tx, id, false))
+}
+
+// checkAccess panics unless the current user may see the sales package, or change it when edit is
+// set. Packages hidden from the user are reported as not found.
+func (s *ServiceSalesPackageImpl) checkAccess(ctx context.Context, tx *sql.Tx, id int, edit bool) {
+	access, err := s.RepositorySalesPackageInterface.FindAccess(ctx, tx, id, helpers.UserIdFromContext(ctx))
+	if err == sql.ErrNoRows || (err == nil && !access.Visible) {
 		panic(exceptions.NewNotFoundError("sales package not found"))
 	}
 	helpers.PanicIfError(err)
-	return s.modelToResponse(pkg)
+	if edit && !access.Editable {
+		panic(exceptions.NewForbidden("only the owner can change this sales package"))
+	}
+}
+
+// find loads a sales package after checkAccess
+func (s *ServiceSalesPackageImpl) find(ctx context.Context, tx *sql.Tx, id int, edit bool) models.SalesPackage {
+	s.checkAccess(ctx, tx, id, edit)
+	pkg, err := s.RepositorySalesPackageInterface.FindById(ctx, tx, id)
+	helpers.PanicIfError(err)
+	return pkg
+}
+
+// touch applies the requested visibility, if any, and records the current user as the last editor
+func touch(ctx context.Context, pkg *models.SalesPackage, visibility string) {
+	if visibility != "" {
+		pkg.Visibility = visibility
+	}
+	pkg.UpdatedBy = helpers.OptionalUserIdFromContext(ctx)
 }
 
-// Update updates a sales package and replaces building links
+// Update updates a sales package and replaces building links. Changing the buildings of a package
+// built from a filter detaches it from the filter (see detachFilter).
 func (s *ServiceSalesPackageImpl) Update(ctx context.Context, request webSalesPackage.UpdateSalesPackageRequest, id int) webSalesPackage.SalesPackageResponse {
 	tx, err := s.DB.Begin()
 	helpers.PanicIfError(err)
 	defer helpers.CommitOrRollback(tx)
 
-	existing, err := s.RepositorySalesPackageInterface.FindById(ctx, tx, id)
-	if err == sql.ErrNoRows {
-		panic(exceptions.NewNotFoundError("sales package not found"))
-	}
-	helpers.PanicIfError(err)
+	existing := s.find(ctx, tx, id, true)
 
 	s.validateBuildingIdsErr(ctx, tx, request.BuildingIds)
+	warnings := s.checkRestrictions(ctx, tx, request.BuildingIds, request.AdvertiserCategoryId, request.AdvertiserMotherBrandId)
 
 	existing.Name = request.Name
+	touch(ctx, &existing, request.Visibility)
+	detached := s.detachFilter(ctx, tx, existing, request.BuildingIds)
 	updated, err := s.RepositorySalesPackageInterface.Update(ctx, tx, existing, request.BuildingIds)
 	helpers.PanicIfError(err)
-	return s.modelToResponse(updated)
+	if detached {
+		updated.Filter, updated.FilterRefreshedAt = "", ""
+	}
+	response := s.modelToResponse(updated)
+	response.RestrictionWarnings = warnings
+	response.Version = s.snapshot(ctx, tx, id, "updated")
+	return response
+}
+
+// detachFilter clears the stored filter of pkg when buildingIds are not the buildings it has now
+// and reports whether it did. A package edited by hand is no longer what its filter selects, and
+// a later Refresh would silently drop the buildings added or bring back those removed.
+func (s *ServiceSalesPackageImpl) detachFilter(ctx context.Context, tx *sql.Tx, pkg models.SalesPackage, buildingIds []int) bool {
+	if pkg.Filter == "" || sameBuildings(pkg.Buildings, buildingIds) {
+		return false
+	}
+	_, err := s.RepositorySalesPackageInterface.SetFilter(ctx, tx, pkg.Id, "")
+	helpers.PanicIfError(err)
+	return true
+}
+
+// sameBuildings tells whether refs and buildingIds name the same set of buildings
+func sameBuildings(refs []models.BuildingRef, buildingIds []int) bool {
+	current := make(map[int]bool, len(refs))
+	for _, ref := range refs {
+		current[ref.Id] = true
+	}
+	requested := make(map[int]bool, len(buildingIds))
+	for _, id := range buildingIds {
+		if !current[id] {
+			return false
+		}
+		requested[id] = true
+	}
+	return len(requested) == len(current)
 }
 
-// Delete deletes a sales package
+// Delete moves a sales package to the trash
 func (s *ServiceSalesPackageImpl) Delete(ctx context.Context, id int) {
 	tx, err := s.DB.Begin()
 	helpers.PanicIfError(err)
 	defer helpers.CommitOrRollback(tx)
 
-	_, err = s.RepositorySalesPackageInterface.FindById(ctx, tx, id)
-	if err == sql.ErrNoRows {
-		panic(exceptions.NewNotFoundError("sales package not found"))
+	s.checkAccess(ctx, tx, id, true)
+	err = s.RepositorySalesPackageInterface.Delete(ctx, tx, id, helpers.OptionalUserIdFromContext(ctx))
+	helpers.PanicIfError(err)
+}
+
+// CreateFromFilter creates a sales package with every building matching the mapping filters
+func (s *ServiceSalesPackageImpl) CreateFromFilter(ctx context.Context, request webSalesPackage.SalesPackageFromFilterRequest) webSalesPackage.SalesPackageResponse {
+	buildingIds := s.filteredBuildingIds(ctx, request.Filters)
+	if len(buildingIds) == 0 {
+		panic(exceptions.NewBadRequest("no buildings match the filters"))
 	}
+
+	tx, err := s.DB.Begin()
 	helpers.PanicIfError(err)
-	err = s.RepositorySalesPackageInterface.Delete(ctx, tx, id)
+	defer helpers.CommitOrRollback(tx)
+
+	warnings := s.checkRestrictions(ctx, tx, buildingIds, request.AdvertiserCategoryId, request.AdvertiserMotherBrandId)
+	created, err := s.RepositorySalesPackageInterface.Create(ctx, tx, models.SalesPackage{
+		Name:       request.Name,
+		CreatedBy:  helpers.OptionalUserIdFromContext(ctx),
+		Visibility: models.VisibilityOrDefault(request.Visibility),
+	}, buildingIds)
 	helpers.PanicIfError(err)
+	if request.StoreFilter {
+		created.Filter = mustFilterJSON(request.Filters)
+		created.FilterRefreshedAt, err = s.RepositorySalesPackageInterface.SetFilter(ctx, tx, created.Id, created.Filter)
+		helpers.PanicIfError(err)
+	}
+	response := s.modelToResponse(created)
+	response.RestrictionWarnings = warnings
+	response.Version = s.snapshot(ctx, tx, created.Id, "created from filter")
+	return response
 }
 
-// Import parses an xlsx or csv file and creates/replaces sales packages
-func (s *ServiceSalesPackageImpl) Import(ctx context.Context, fileBytes []byte, fileType string) []webSalesPackage.SalesPackageResponse {
+// UpdateFromFilter renames a sales package and replaces its buildings with every building matching
+// the mapping filters, storing or clearing the filter according to request.StoreFilter
+func (s *ServiceSalesPackageImpl) UpdateFromFilter(ctx context.Context, request webSalesPackage.SalesPackageFromFilterRequest, id int) webSalesPackage.SalesPackageResponse {
+	buildingIds := s.filteredBuildingIds(ctx, request.Filters)
+	if len(buildingIds) == 0 {
+		panic(exceptions.NewBadRequest("no buildings match the filters"))
+	}
+
 	tx, err := s.DB.Begin()
 	helpers.PanicIfError(err)
 	defer helpers.CommitOrRollback(tx)
 
-	var rows [][]string
-	switch strings.ToLower(fileType) {
-	case "xlsx":
-		rows, err = spParseXLSX(fileBytes)
-		helpers.PanicIfError(err)
-	case "csv":
-		rows, err = spParseCSV(fileBytes)
+	existing := s.find(ctx, tx, id, true)
+	warnings := s.checkRestrictions(ctx, tx, buildingIds, request.AdvertiserCategoryId, request.AdvertiserMotherBrandId)
+
+	existing.Name = request.Name
+	touch(ctx, &existing, request.Visibility)
+	updated, err := s.RepositorySalesPackageInterface.Update(ctx, tx, existing, buildingIds)
+	helpers.PanicIfError(err)
+	updated.Filter = ""
+	if request.StoreFilter {
+		updated.Filter = mustFilterJSON(request.Filters)
+	}
+	updated.FilterRefreshedAt, err = s.RepositorySalesPackageInterface.SetFilter(ctx, tx, id, updated.Filter)
+	helpers.PanicIfError(err)
+	response := s.modelToResponse(updated)
+	response.RestrictionWarnings = warnings
+	response.Version = s.snapshot(ctx, tx, id, "updated from filter")
+	return response
+}
+
+// PreviewRefresh shows which buildings re-running the package's stored filter would add or remove
+func (s *ServiceSalesPackageImpl) PreviewRefresh(ctx context.Context, id int) webSalesPackage.SalesPackageRefreshResponse {
+	pkg, filters := s.findFilteredPackage(ctx, id, false)
+	buildingIds := s.filteredBuildingIds(ctx, filters)
+
+	tx, err := s.DB.Begin()
+	helpers.PanicIfError(err)
+	defer helpers.CommitOrRollback(tx)
+
+	return s.refreshDiff(ctx, tx, pkg, buildingIds)
+}
+
+// Refresh re-runs the package's stored filter and replaces its buildings with the result
+func (s *ServiceSalesPackageImpl) Refresh(ctx context.Context, id int) webSalesPackage.SalesPackageRefreshResponse {
+	pkg, filters := s.findFilteredPackage(ctx, id, true)
+	buildingIds := s.filteredBuildingIds(ctx, filters)
+	if len(buildingIds) == 0 {
+		panic(exceptions.NewBadRequest("no buildings match the stored filter anymore"))
+	}
+
+	tx, err := s.DB.Begin()
+	helpers.PanicIfError(err)
+	defer helpers.CommitOrRollback(tx)
+
+	diff := s.refreshDiff(ctx, tx, pkg, buildingIds)
+	touch(ctx, &pkg, "")
+	updated, err := s.RepositorySalesPackageInterface.Update(ctx, tx, pkg, buildingIds)
+	helpers.PanicIfError(err)
+	updated.FilterRefreshedAt, err = s.RepositorySalesPackageInterface.SetFilter(ctx, tx, id, pkg.Filter)
+	helpers.PanicIfError(err)
+
+	response := s.modelToResponse(updated)
+	response.Version = s.snapshot(ctx, tx, id, "refreshed from stored filter")
+	diff.Package = &response
+	return diff
+}
+
+// findFilteredPackage loads a package that has a stored filter, with the filter decoded
+func (s *ServiceSalesPackageImpl) findFilteredPackage(ctx context.Context, id int, edit bool) (models.SalesPackage, webBuilding.ExportMappingFilters) {
+	tx, err := s.DB.Begin()
+	helpers.PanicIfError(err)
+	defer helpers.CommitOrRollback(tx)
+
+	pkg := s.find(ctx, tx, id, edit)
+	if pkg.Filter == "" {
+		panic(exceptions.NewBadRequest("sales package has no stored filter"))
+	}
+	var filters webBuilding.ExportMappingFilters
+	helpers.PanicIfError(json.Unmarshal([]byte(pkg.Filter), &filters))
+	return pkg, filters
+}
+
+// filteredBuildingIds returns every building matching the mapping filters, ignoring any viewport
+func (s *ServiceSalesPackageImpl) filteredBuildingIds(ctx context.Context, filters webBuilding.ExportMappingFilters) []int {
+	mapping := s.ServiceBuildingInterface.FindAllForMapping(ctx, webBuilding.BuildMappingRequestFromExportBody(&webBuilding.ExportMappingByFilterRequest{
+		Filters: filters,
+	}))
+	ids := make([]int, len(mapping.Data))
+	for i, b := range mapping.Data {
+		ids[i] = b.Id
+	}
+	return ids
+}
+
+// refreshDiff compares the package buildings with buildingIds
+func (s *ServiceSalesPackageImpl) refreshDiff(ctx context.Context, tx *sql.Tx, pkg models.SalesPackage, buildingIds []int) webSalesPackage.SalesPackageRefreshResponse {
+	diff := webSalesPackage.SalesPackageRefreshResponse{
+		Added:   []webSalesPackage.BuildingRefResponse{},
+		Removed: []webSalesPackage.BuildingRefResponse{},
+	}
+	matched := make(map[int]bool, len(buildingIds))
+	for _, id := range buildingIds {
+		matched[id] = true
+	}
+	current := make(map[int]bool, len(pkg.Buildings))
+	for _, b := range pkg.Buildings {
+		current[b.Id] = true
+		if matched[b.Id] {
+			diff.Unchanged++
+		} else {
+			diff.Removed = append(diff.Removed, buildingRefToResponse(b))
+		}
+	}
+
+	addedIds := []int{}
+	for _, id := range buildingIds {
+		if !current[id] {
+			addedIds = append(addedIds, id)
+		}
+	}
+	if len(addedIds) > 0 {
+		added, err := s.RepositoryBuildingInterface.FindByIds(ctx, tx, addedIds)
 		helpers.PanicIfError(err)
-	default:
-		panic(exceptions.NewBadRequestError("Unsupported file type. Use xlsx or csv."))
+		for _, b := range added {
+			diff.Added = append(diff.Added, buildingRefToResponse(models.BuildingRef{
+				Id:           b.Id,
+				Name:         b.Name,
+				ProjectName:  b.ProjectName,
+				Subdistrict:  b.Subdistrict,
+				Citytown:     b.Citytown,
+				Province:     b.Province,
+				BuildingType: b.BuildingType,
+			}))
+		}
+	}
+	return diff
+}
+
+func mustFilterJSON(filters webBuilding.ExportMappingFilters) string {
+	encoded, err := json.Marshal(filters)
+	helpers.PanicIfError(err)
+	return string(encoded)
+}
+
+// Summary adds up the audience, impressions and screens of a sales package, broken down by
+// building type, city and grade, and warns about buildings that should not be sold as is
+func (s *ServiceSalesPackageImpl) Summary(ctx context.Context, id int) webSalesPackage.SalesPackageSummaryResponse {
+	tx, err := s.DB.Begin()
+	helpers.PanicIfError(err)
+	defer helpers.CommitOrRollback(tx)
+
+	pkg := s.find(ctx, tx, id, false)
+	rows, err := s.RepositorySalesPackageInterface.FindSummaryRows(ctx, tx, []int{id})
+	helpers.PanicIfError(err)
+	return SummarizeSalesPackage(pkg, rows)
+}
+
+// SummarizeSalesPackage builds the summary of pkg from its FindSummaryRows rows
+func SummarizeSalesPackage(pkg models.SalesPackage, rows []repositoriesSalesPackage.SalesPackageSummaryRow) webSalesPackage.SalesPackageSummaryResponse {
+	summary := webSalesPackage.SalesPackageSummaryResponse{
+		Id:       pkg.Id,
+		Name:     pkg.Name,
+		Warnings: []webSalesPackage.SalesPackageWarningResponse{},
+	}
+	byBuildingType := newSalesPackageBreakdown()
+	byCity := newSalesPackageBreakdown()
+	byGrade := newSalesPackageBreakdown()
+
+	for _, row := range rows {
+		summary.BuildingCount++
+		summary.TotalAudience += row.Audience
+		summary.TotalImpression += row.Impression
+		summary.TotalScreens += row.Screens
+		if !row.HasProposal {
+			summary.BuildingsWithoutProposal++
+		}
+		byBuildingType.add(row.BuildingType, row)
+		byCity.add(row.Citytown, row)
+		byGrade.add(row.GradeResource, row)
+
+		warn := func(code string, message string) {
+			summary.Warnings = append(summary.Warnings, webSalesPackage.SalesPackageWarningResponse{
+				BuildingId:   row.BuildingId,
+				BuildingName: row.BuildingName,
+				Code:         code,
+				Message:      message,
+			})
+		}
+		if !strings.EqualFold(strings.TrimSpace(row.Sellable), "sell") {
+			warn("not_sellable", "Building is not sellable")
+		}
+		if !strings.EqualFold(strings.TrimSpace(row.LcdPresenceStatus), "TMN") {
+			status := row.LcdPresenceStatus
+			if status == "" {
+				status = "unknown"
+			}
+			warn("not_tmn", "Building LCD presence is "+status+", not TMN")
+		}
+		if len(row.Restrictions) > 0 {
+			warn("restricted", "Building is in building restriction "+strings.Join(row.Restrictions, ", "))
+		}
+	}
+
+	summary.ByBuildingType = byBuildingType.list()
+	summary.ByCity = byCity.list()
+	summary.ByGrade = byGrade.list()
+	return summary
+}
+
+// salesPackageBreakdown accumulates summary figures per key, remembering first-seen order
+type salesPackageBreakdown struct {
+	order []string
+	items map[string]*webSalesPackage.SalesPackageBreakdownResponse
+}
+
+func newSalesPackageBreakdown() *salesPackageBreakdown {
+	return &salesPackageBreakdown{items: map[string]*webSalesPackage.SalesPackageBreakdownResponse{}}
+}
+
+func (b *salesPackageBreakdown) add(key string, row repositoriesSalesPackage.SalesPackageSummaryRow) {
+	key = strings.TrimSpace(key)
+	if key == "" {
+		key = "Unspecified"
+	}
+	item, ok := b.items[key]
+	if !ok {
+		item = &webSalesPackage.SalesPackageBreakdownResponse{Key: key}
+		b.items[key] = item
+		b.order = append(b.order, key)
+	}
+	item.BuildingCount++
+	item.Audience += row.Audience
+	item.Impression += row.Impression
+	item.Screens += row.Screens
+}
+
+// list returns the breakdown with the largest audience first
+func (b *salesPackageBreakdown) list() []webSalesPackage.SalesPackageBreakdownResponse {
+	list := make([]webSalesPackage.SalesPackageBreakdownResponse, 0, len(b.order))
+	for _, key := range b.order {
+		list = append(list, *b.items[key])
+	}
+	sort.SliceStable(list, func(i, j int) bool {
+		if list[i].Audience != list[j].Audience {
+			return list[i].Audience > list[j].Audience
+		}
+		return list[i].Key < list[j].Key
+	})
+	return list
+}
+
+// snapshot records the current state of a sales package as a new version and returns its number
+func (s *ServiceSalesPackageImpl) snapshot(ctx context.Context, tx *sql.Tx, id int, note string) int {
+	var createdBy *int
+	if userId := helpers.UserIdFromContext(ctx); userId != 0 {
+		createdBy = &userId
+	}
+	version, err := s.RepositorySalesPackageInterface.CreateVersion(ctx, tx, id, note, createdBy)
+	helpers.PanicIfError(err)
+	return version.Version
+}
+
+// findVersion loads one version of a sales package; version 0 is the latest
+func (s *ServiceSalesPackageImpl) findVersion(ctx context.Context, tx *sql.Tx, id int, version int) models.SalesPackageVersion {
+	found, err := s.RepositorySalesPackageInterface.FindVersion(ctx, tx, id, version)
+	if err == sql.ErrNoRows {
+		panic(exceptions.NewNotFoundError("sales package version not found"))
 	}
+	helpers.PanicIfError(err)
+	return found
+}
 
-	if len(rows) < 2 {
-		panic(exceptions.NewBadRequestError("File must contain a header row and at least one data row."))
+// Versions lists the versions of a sales package, newest first
+func (s *ServiceSalesPackageImpl) Versions(ctx context.Context, id int) []webSalesPackage.SalesPackageVersionResponse {
+	tx, err := s.DB.Begin()
+	helpers.PanicIfError(err)
+	defer helpers.CommitOrRollback(tx)
+
+	s.checkAccess(ctx, tx, id, false)
+	versions, err := s.RepositorySalesPackageInterface.FindVersions(ctx, tx, id)
+	helpers.PanicIfError(err)
+	responses := make([]webSalesPackage.SalesPackageVersionResponse, len(versions))
+	for i, v := range versions {
+		responses[i] = versionToResponse(v)
 	}
+	return responses
+}
+
+// FindVersion returns one version of a sales package with its buildings; version 0 is the latest
+func (s *ServiceSalesPackageImpl) FindVersion(ctx context.Context, id int, version int) webSalesPackage.SalesPackageVersionResponse {
+	tx, err := s.DB.Begin()
+	helpers.PanicIfError(err)
+	defer helpers.CommitOrRollback(tx)
 
-	header := rows[0]
-	colMap := spMapHeaderColumns(header)
+	s.checkAccess(ctx, tx, id, false)
+	return versionToResponse(s.findVersion(ctx, tx, id, version))
+}
 
-	if _, exists := colMap["name"]; !exists {
-		panic(exceptions.NewBadRequestError("Missing required column: name"))
+// DiffVersions lists the buildings added and removed between two versions of a sales package and
+// how the totals moved. A to of 0 compares against the latest version.
+func (s *ServiceSalesPackageImpl) DiffVersions(ctx context.Context, id int, from int, to int) webSalesPackage.SalesPackageVersionDiffResponse {
+	if from <= 0 {
+		panic(exceptions.NewBadRequest("from must be a version number"))
 	}
-	if _, exists := colMap["building_name"]; !exists {
-		panic(exceptions.NewBadRequestError("Missing required column: building_name"))
+	if to < 0 {
+		panic(exceptions.NewBadRequest("to must be a version number"))
 	}
 
+	tx, err := s.DB.Begin()
+	helpers.PanicIfError(err)
+	defer helpers.CommitOrRollback(tx)
+
+	s.checkAccess(ctx, tx, id, false)
+	fromVersion := s.findVersion(ctx, tx, id, from)
+	toVersion := s.findVersion(ctx, tx, id, to)
+
+	diff := webSalesPackage.SalesPackageVersionDiffResponse{
+		From:               fromVersion.Version,
+		To:                 toVersion.Version,
+		FromName:           fromVersion.Name,
+		ToName:             toVersion.Name,
+		FilterChanged:      fromVersion.Filter != toVersion.Filter,
+		Added:              []webSalesPackage.SalesPackageVersionBuildingResponse{},
+		Removed:            []webSalesPackage.SalesPackageVersionBuildingResponse{},
+		BuildingCountDelta: toVersion.BuildingCount - fromVersion.BuildingCount,
+		AudienceDelta:      toVersion.TotalAudience - fromVersion.TotalAudience,
+		ImpressionDelta:    toVersion.TotalImpression - fromVersion.TotalImpression,
+		ScreensDelta:       toVersion.TotalScreens - fromVersion.TotalScreens,
+	}
+	inFrom := make(map[string]bool, len(fromVersion.Buildings))
+	for _, b := range fromVersion.Buildings {
+		inFrom[versionBuildingKey(b)] = true
+	}
+	inTo := make(map[string]bool, len(toVersion.Buildings))
+	for _, b := range toVersion.Buildings {
+		key := versionBuildingKey(b)
+		inTo[key] = true
+		if inFrom[key] {
+			diff.Unchanged++
+		} else {
+			diff.Added = append(diff.Added, versionBuildingToResponse(b))
+		}
+	}
+	for _, b := range fromVersion.Buildings {
+		if !inTo[versionBuildingKey(b)] {
+			diff.Removed = append(diff.Removed, versionBuildingToResponse(b))
+		}
+	}
+	return diff
+}
+
+// versionBuildingKey identifies a version building by id, or by name once it has been deleted
+func versionBuildingKey(b models.SalesPackageVersionBuilding) string {
+	if b.BuildingId != nil {
+		return fmt.Sprintf("id:%d", *b.BuildingId)
+	}
+	return "name:" + b.BuildingName
+}
+
+// RestoreVersion puts the name, buildings and stored filter of an earlier version back on the
+// sales package and records the result as a new version. Buildings deleted since are left out.
+func (s *ServiceSalesPackageImpl) RestoreVersion(ctx context.Context, id int, version int) webSalesPackage.SalesPackageResponse {
+	tx, err := s.DB.Begin()
+	helpers.PanicIfError(err)
+	defer helpers.CommitOrRollback(tx)
+
+	existing := s.find(ctx, tx, id, true)
+	restored := s.findVersion(ctx, tx, id, version)
+
+	buildingIds := []int{}
+	for _, b := range restored.Buildings {
+		if b.BuildingId != nil {
+			buildingIds = append(buildingIds, *b.BuildingId)
+		}
+	}
+	if len(buildingIds) == 0 {
+		panic(exceptions.NewBadRequest("none of the buildings of this version exist anymore"))
+	}
+
+	existing.Name = restored.Name
+	touch(ctx, &existing, "")
+	updated, err := s.RepositorySalesPackageInterface.Update(ctx, tx, existing, buildingIds)
+	helpers.PanicIfError(err)
+	updated.Filter = restored.Filter
+	updated.FilterRefreshedAt, err = s.RepositorySalesPackageInterface.SetFilter(ctx, tx, id, restored.Filter)
+	helpers.PanicIfError(err)
+
+	response := s.modelToResponse(updated)
+	response.Version = s.snapshot(ctx, tx, id, fmt.Sprintf("restored from version %d", restored.Version))
+	return response
+}
+
+func versionToResponse(v models.SalesPackageVersion) webSalesPackage.SalesPackageVersionResponse {
+	response := webSalesPackage.SalesPackageVersionResponse{
+		Id:              v.Id,
+		SalesPackageId:  v.SalesPackageId,
+		Version:         v.Version,
+		Name:            v.Name,
+		Note:            v.Note,
+		BuildingCount:   v.BuildingCount,
+		TotalAudience:   v.TotalAudience,
+		TotalImpression: v.TotalImpression,
+		TotalScreens:    v.TotalScreens,
+		CreatedBy:       v.CreatedBy,
+		CreatedAt:       v.CreatedAt,
+	}
+	if v.Filter != "" {
+		var filters webBuilding.ExportMappingFilters
+		if err := json.Unmarshal([]byte(v.Filter), &filters); err == nil {
+			response.Filter = &filters
+		}
+	}
+	if len(v.Buildings) > 0 {
+		response.Buildings = make([]webSalesPackage.SalesPackageVersionBuildingResponse, len(v.Buildings))
+		for i, b := range v.Buildings {
+			response.Buildings[i] = versionBuildingToResponse(b)
+		}
+	}
+	return response
+}
+
+func versionBuildingToResponse(b models.SalesPackageVersionBuilding) webSalesPackage.SalesPackageVersionBuildingResponse {
+	return webSalesPackage.SalesPackageVersionBuildingResponse{
+		BuildingId:   b.BuildingId,
+		BuildingName: b.BuildingName,
+		Citytown:     b.Citytown,
+		BuildingType: b.BuildingType,
+		Audience:     b.Audience,
+		Impression:   b.Impression,
+		Screens:      b.Screens,
+	}
+}
+
+// Import parses an xlsx or csv file and creates sales packages. A package whose name already
+// exists has its buildings replaced in place and gets a new version, so its history and the
+// bookings and share links pinned to it survive. Rows naming an unknown or repeated building are
+// rejected; onError decides whether that fails the whole file.
+func (s *ServiceSalesPackageImpl) Import(ctx context.Context, fileBytes []byte, fileType string, onError string) ([]webSalesPackage.SalesPackageResponse, web.ImportReport) {
+	tx, err := s.DB.Begin()
+	helpers.PanicIfError(err)
+	defer helpers.CommitOrRollback(tx)
+
+	sheet := importer.Read(fileBytes, fileType, salesPackageImportColumns)
+	sheet.RequireColumns("name", "building_name")
+
 	// Load all buildings for name->id resolution
 	allBuildings, err := s.RepositoryBuildingInterface.FindAllDropdown(ctx, tx)
 	helpers.PanicIfError(err)
@@ -168,7 +707,7 @@ func (s *ServiceSalesPackageImpl) Import(ctx context.Context, fileBytes []byte,
 		buildingNameMap[strings.TrimSpace(strings.ToLower(b.Name))] = b.Id
 	}
 
-	// Group rows by package name (each row has one building)
+	// Group rows by sales package name (each row has one building)
 	type importGroup struct {
 		name        string
 		buildingIds []int
@@ -177,88 +716,92 @@ func (s *ServiceSalesPackageImpl) Import(ctx context.Context, fileBytes []byte,
 	nameOrder := []string{}
 	groups := map[string]*importGroup{}
 
-	type duplicateEntry struct {
-		Name         string `json:"name"`
-		BuildingName string `json:"building_name"`
-		Rows         []int  `json:"rows"`
-	}
-	duplicateIndex := map[string]*duplicateEntry{} // key: name + "|" + buildingId
-
-	for i, row := range rows[1:] {
-		excelRow := i + 2 // header is Excel row 1; data rows start at 2
-
-		name := spGetColValue(row, colMap, "name")
-		if name == "" {
+	report := importer.NewReport(sheet, onError)
+	for i := range sheet.Rows {
+		excelRow := importer.RowNumber(i)
+		if sheet.IsBlank(i) || !report.RequireText(excelRow, "name", 255) {
 			continue
 		}
-		rawBuildingName := spGetColValue(row, colMap, "building_name")
-		buildingName := strings.TrimSpace(strings.ToLower(rawBuildingName))
-
-		if _, exists := groups[name]; !exists {
-			groups[name] = &importGroup{name: name, seenAt: map[int]int{}}
-			nameOrder = append(nameOrder, name)
-		}
+		name := sheet.Value(i, "name")
+		buildingName := strings.ToLower(sheet.Value(i, "building_name"))
 
+		bid := 0
 		if buildingName != "" {
-			bid, ok := buildingNameMap[buildingName]
+			id, ok := buildingNameMap[buildingName]
 			if !ok {
-				panic(exceptions.NewBadRequestError(fmt.Sprintf("Building not found: %s", buildingName)))
+				report.Reject(excelRow, "building_name", "Building not found")
+				continue
 			}
-			group := groups[name]
-			if firstRow, dup := group.seenAt[bid]; dup {
-				key := fmt.Sprintf("%s|%d", name, bid)
-				if entry, ok := duplicateIndex[key]; ok {
-					entry.Rows = append(entry.Rows, excelRow)
-				} else {
-					duplicateIndex[key] = &duplicateEntry{
-						Name:         name,
-						BuildingName: rawBuildingName,
-						Rows:         []int{firstRow, excelRow},
-					}
+			if group, exists := groups[name]; exists {
+				if firstRow, dup := group.seenAt[id]; dup {
+					report.Reject(excelRow, "building_name", fmt.Sprintf("Building is already listed for this sales package on row %d", firstRow))
+					continue
 				}
-				continue
 			}
+			bid = id
+		}
+
+		if _, exists := groups[name]; !exists {
+			groups[name] = &importGroup{name: name, seenAt: map[int]int{}}
+			nameOrder = append(nameOrder, name)
+		}
+		if bid != 0 {
+			group := groups[name]
 			group.seenAt[bid] = excelRow
 			group.buildingIds = append(group.buildingIds, bid)
 		}
 	}
+	report.Check()
 
-	if len(duplicateIndex) > 0 {
-		duplicates := make([]duplicateEntry, 0, len(duplicateIndex))
-		for _, nameKey := range nameOrder {
-			for _, entry := range duplicateIndex {
-				if entry.Name == nameKey {
-					duplicates = append(duplicates, *entry)
-				}
-			}
-		}
-		panic(exceptions.NewBadRequestWithExtras(
-			"Duplicate rows found: the same sales package cannot reference the same building more than once.",
-			map[string]interface{}{"duplicates": duplicates},
-		))
-	}
+	tracker := importer.NewTracker(ctx, len(nameOrder))
 
-	// Delete existing sales packages with matching names
+	// The oldest existing sales package of each name is replaced in place; any other package with
+	// the same name is moved to the trash, buildings included
 	existing, err := s.RepositorySalesPackageInterface.FindByNames(ctx, tx, nameOrder)
 	helpers.PanicIfError(err)
+	replaced := make(map[string]models.SalesPackage, len(existing))
 	for _, ep := range existing {
-		err = s.RepositorySalesPackageInterface.DeleteBuildingLinksBySalesPackageId(ctx, tx, ep.Id)
+		access, err := s.RepositorySalesPackageInterface.FindAccess(ctx, tx, ep.Id, helpers.UserIdFromContext(ctx))
 		helpers.PanicIfError(err)
-		err = s.RepositorySalesPackageInterface.Delete(ctx, tx, ep.Id)
+		if !access.Editable {
+			panic(exceptions.NewForbidden(fmt.Sprintf("sales package %s can only be replaced by its owner", ep.Name)))
+		}
+		if _, seen := replaced[ep.Name]; !seen {
+			// loaded with its buildings for detachFilter
+			replaced[ep.Name], err = s.RepositorySalesPackageInterface.FindById(ctx, tx, ep.Id)
+			helpers.PanicIfError(err)
+			continue
+		}
+		err = s.RepositorySalesPackageInterface.Delete(ctx, tx, ep.Id, helpers.OptionalUserIdFromContext(ctx))
 		helpers.PanicIfError(err)
 	}
 
-	// Create fresh sales packages
 	var responses []webSalesPackage.SalesPackageResponse
 	for _, name := range nameOrder {
 		group := groups[name]
-		pkg := models.SalesPackage{Name: group.name}
-		created, err := s.RepositorySalesPackageInterface.Create(ctx, tx, pkg, group.buildingIds)
+		var saved models.SalesPackage
+		if pkg, ok := replaced[group.name]; ok {
+			touch(ctx, &pkg, "")
+			detached := s.detachFilter(ctx, tx, pkg, group.buildingIds)
+			saved, err = s.RepositorySalesPackageInterface.Update(ctx, tx, pkg, group.buildingIds)
+			if detached {
+				saved.Filter, saved.FilterRefreshedAt = "", ""
+			}
+		} else {
+			saved, err = s.RepositorySalesPackageInterface.Create(ctx, tx, models.SalesPackage{
+				Name:       group.name,
+				CreatedBy:  helpers.OptionalUserIdFromContext(ctx),
+				Visibility: models.VisibilityOrDefault(""),
+			}, group.buildingIds)
+		}
 		helpers.PanicIfError(err)
-		responses = append(responses, s.modelToResponse(created))
+		response := s.modelToResponse(saved)
+		response.Version = s.snapshot(ctx, tx, saved.Id, "imported")
+		responses = append(responses, response)
+		tracker.Advance(1)
 	}
 
-	return responses
+	return responses, report.Result()
 }
 
 // Export generates an xlsx file with all sales packages
@@ -269,86 +812,97 @@ func (s *ServiceSalesPackageImpl) Export(ctx context.Context, search string) ([]
 	}
 	defer helpers.CommitOrRollback(tx)
 
-	packages, err := s.RepositorySalesPackageInterface.FindAllFlat(ctx, tx, search)
+	packages, err := s.RepositorySalesPackageInterface.FindAllFlat(ctx, tx, helpers.UserIdFromContext(ctx), search)
 	if err != nil {
 		return nil, err
 	}
 
-	return buildSalesPackageExcel(packages)
-}
-
-func (s *ServiceSalesPackageImpl) modelToResponse(p models.SalesPackage) webSalesPackage.SalesPackageResponse {
-	buildings := make([]webSalesPackage.BuildingRefResponse, len(p.Buildings))
-	for i, b := range p.Buildings {
-		buildings[i] = webSalesPackage.BuildingRefResponse{
-			Id:           b.Id,
-			Name:         b.Name,
-			ProjectName:  b.ProjectName,
-			Subdistrict:  b.Subdistrict,
-			Citytown:     b.Citytown,
-			Province:     b.Province,
-			BuildingType: b.BuildingType,
-		}
+	ids := make([]int, len(packages))
+	for i, pkg := range packages {
+		ids[i] = pkg.Id
+	}
+	rows, err := s.RepositorySalesPackageInterface.FindSummaryRows(ctx, tx, ids)
+	if err != nil {
+		return nil, err
+	}
+	rowsByPackage := make(map[int][]repositoriesSalesPackage.SalesPackageSummaryRow, len(packages))
+	for _, row := range rows {
+		rowsByPackage[row.SalesPackageId] = append(rowsByPackage[row.SalesPackageId], row)
 	}
-	return webSalesPackage.SalesPackageResponse{
-		Id:        p.Id,
-		Name:      p.Name,
-		Buildings: buildings,
-		CreatedAt: p.CreatedAt,
-		UpdatedAt: p.UpdatedAt,
+	summaries := make([]webSalesPackage.SalesPackageSummaryResponse, len(packages))
+	for i, pkg := range packages {
+		summaries[i] = SummarizeSalesPackage(pkg, rowsByPackage[pkg.Id])
 	}
+
+	return buildSalesPackageExcel(packages, summaries)
 }
 
-// --- Import helpers ---
+// ImportTemplate returns a blank sales package import xlsx with the current building names as a dropdown
+func (s *ServiceSalesPackageImpl) ImportTemplate(ctx context.Context) ([]byte, error) {
+	tx, err := s.DB.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer helpers.CommitOrRollback(tx)
 
-func spParseXLSX(fileBytes []byte) ([][]string, error) {
-	f, err := excelize.OpenReader(bytes.NewReader(fileBytes))
+	buildings, err := s.RepositoryBuildingInterface.FindAllDropdown(ctx, tx)
 	if err != nil {
 		return nil, err
 	}
-	defer f.Close()
-	sheetName := f.GetSheetName(0)
-	return f.GetRows(sheetName)
+	buildingNames := make([]string, len(buildings))
+	for i, b := range buildings {
+		buildingNames[i] = b.Name
+	}
+
+	return importer.BuildTemplate(importer.Template{
+		SheetName: "Sales Packages",
+		Columns: []importer.TemplateColumn{
+			{Key: "name", Header: "Name", Description: "Name of the sales package. Repeat it on one row per building.", Required: true},
+			{Key: "building_name", Header: "Building Name", Description: "Building in the sales package.", Options: buildingNames, Strict: true},
+		},
+		Instructions: []string{
+			"Fill one row per building of a sales package on the Sales Packages sheet and keep the header row as it is.",
+			"Importing a sales package replaces any existing sales package with the same name, including its buildings.",
+			"A building may be listed only once per sales package.",
+		},
+	}, salesPackageImportColumns)
 }
 
-func spParseCSV(fileBytes []byte) ([][]string, error) {
-	reader := csv.NewReader(bytes.NewReader(fileBytes))
-	var rows [][]string
-	for {
-		record, err := reader.Read()
-		if err == io.EOF {
-			break
-		}
-		if err != nil {
-			return nil, err
-		}
-		rows = append(rows, record)
-	}
-	return rows, nil
-}
-
-func spMapHeaderColumns(header []string) map[string]int {
-	colMap := make(map[string]int)
-	for i, h := range header {
-		normalized := strings.ToLower(strings.TrimSpace(h))
-		normalized = strings.ReplaceAll(normalized, "-", "_")
-		normalized = strings.ReplaceAll(normalized, " ", "_")
-		switch normalized {
-		case "name":
-			colMap["name"] = i
-		case "building_name", "buildingname", "building_names", "buildingnames", "buildings":
-			colMap["building_name"] = i
+func (s *ServiceSalesPackageImpl) modelToResponse(p models.SalesPackage) webSalesPackage.SalesPackageResponse {
+	buildings := make([]webSalesPackage.BuildingRefResponse, len(p.Buildings))
+	for i, b := range p.Buildings {
+		buildings[i] = buildingRefToResponse(b)
+	}
+	response := webSalesPackage.SalesPackageResponse{
+		Id:                p.Id,
+		Name:              p.Name,
+		Buildings:         buildings,
+		FilterRefreshedAt: p.FilterRefreshedAt,
+		CreatedBy:         p.CreatedBy,
+		UpdatedBy:         p.UpdatedBy,
+		Visibility:        p.Visibility,
+		CreatedAt:         p.CreatedAt,
+		UpdatedAt:         p.UpdatedAt,
+	}
+	if p.Filter != "" {
+		var filters webBuilding.ExportMappingFilters
+		if err := json.Unmarshal([]byte(p.Filter), &filters); err == nil {
+			response.Filter = &filters
 		}
 	}
-	return colMap
+	return response
 }
 
-func spGetColValue(row []string, colMap map[string]int, key string) string {
-	idx, exists := colMap[key]
-	if !exists || idx >= len(row) {
-		return ""
+func buildingRefToResponse(b models.BuildingRef) webSalesPackage.BuildingRefResponse {
+	return webSalesPackage.BuildingRefResponse{
+		Id:           b.Id,
+		Name:         b.Name,
+		ProjectName:  b.ProjectName,
+		Subdistrict:  b.Subdistrict,
+		Citytown:     b.Citytown,
+		Province:     b.Province,
+		BuildingType: b.BuildingType,
 	}
-	return strings.TrimSpace(row[idx])
 }
 
 // --- Export helpers ---
@@ -358,7 +912,9 @@ func spMustCell(col, row int) string {
 	return s
 }
 
-func buildSalesPackageExcel(packages []models.SalesPackage) ([]byte, error) {
+// buildSalesPackageExcel writes the import-compatible Sales Packages sheet first, followed by
+// the Summary, Breakdown and Warnings sheets built from summaries
+func buildSalesPackageExcel(packages []models.SalesPackage, summaries []webSalesPackage.SalesPackageSummaryResponse) ([]byte, error) {
 	f := excelize.NewFile()
 	const sheet = "Sheet1"
 
@@ -385,9 +941,70 @@ func buildSalesPackageExcel(packages []models.SalesPackage) ([]byte, error) {
 
 	_ = f.SetSheetName(sheet, "Sales Packages")
 
+	if err := writeSalesPackageSummarySheets(f, summaries); err != nil {
+		return nil, err
+	}
+
 	buf, err := f.WriteToBuffer()
 	if err != nil {
 		return nil, err
 	}
 	return buf.Bytes(), nil
 }
+
+func writeSalesPackageSummarySheets(f *excelize.File, summaries []webSalesPackage.SalesPackageSummaryResponse) error {
+	sheets := map[string][]string{
+		"Summary":   {"Name", "Buildings", "Audience", "Impressions", "Screens", "Buildings Without Proposal", "Warnings"},
+		"Breakdown": {"Name", "Dimension", "Value", "Buildings", "Audience", "Impressions", "Screens"},
+		"Warnings":  {"Name", "Building Name", "Warning", "Message"},
+	}
+	for _, name := range []string{"Summary", "Breakdown", "Warnings"} {
+		if _, err := f.NewSheet(name); err != nil {
+			return err
+		}
+		for i, h := range sheets[name] {
+			_ = f.SetCellValue(name, spMustCell(i+1, 1), h)
+		}
+	}
+
+	breakdownRow, warningRow := 2, 2
+	for i, summary := range summaries {
+		rowIdx := i + 2
+		_ = f.SetCellValue("Summary", spMustCell(1, rowIdx), summary.Name)
+		_ = f.SetCellValue("Summary", spMustCell(2, rowIdx), summary.BuildingCount)
+		_ = f.SetCellValue("Summary", spMustCell(3, rowIdx), summary.TotalAudience)
+		_ = f.SetCellValue("Summary", spMustCell(4, rowIdx), summary.TotalImpression)
+		_ = f.SetCellValue("Summary", spMustCell(5, rowIdx), summary.TotalScreens)
+		_ = f.SetCellValue("Summary", spMustCell(6, rowIdx), summary.BuildingsWithoutProposal)
+		_ = f.SetCellValue("Summary", spMustCell(7, rowIdx), len(summary.Warnings))
+
+		for _, dimension := range []struct {
+			name  string
+			items []webSalesPackage.SalesPackageBreakdownResponse
+		}{
+			{"Building Type", summary.ByBuildingType},
+			{"City", summary.ByCity},
+			{"Grade", summary.ByGrade},
+		} {
+			for _, item := range dimension.items {
+				_ = f.SetCellValue("Breakdown", spMustCell(1, breakdownRow), summary.Name)
+				_ = f.SetCellValue("Breakdown", spMustCell(2, breakdownRow), dimension.name)
+				_ = f.SetCellValue("Breakdown", spMustCell(3, breakdownRow), item.Key)
+				_ = f.SetCellValue("Breakdown", spMustCell(4, breakdownRow), item.BuildingCount)
+				_ = f.SetCellValue("Breakdown", spMustCell(5, breakdownRow), item.Audience)
+				_ = f.SetCellValue("Breakdown", spMustCell(6, breakdownRow), item.Impression)
+				_ = f.SetCellValue("Breakdown", spMustCell(7, breakdownRow), item.Screens)
+				breakdownRow++
+			}
+		}
+
+		for _, warning := range summary.Warnings {
+			_ = f.SetCellValue("Warnings", spMustCell(1, warningRow), summary.Name)
+			_ = f.SetCellValue("Warnings", spMustCell(2, warningRow), warning.BuildingName)
+			_ = f.SetCellValue("Warnings", spMustCell(3, warningRow), warning.Code)
+			_ = f.SetCellValue("Warnings", spMustCell(4, warningRow), warning.Message)
+			warningRow++
+		}
+	}
+	return nil
+}
@@ -1,18 +1,31 @@
 package salespackage_test
 
 import (
+	"bytes"
 	"context"
 	"database/sql"
+	"io"
+	"strings"
 	"testing"
 
+	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/malikabdulaziz/tmn-backend/exceptions"
+	"github.com/malikabdulaziz/tmn-backend/helpers"
+	"github.com/malikabdulaziz/tmn-backend/importer"
 	"github.com/malikabdulaziz/tmn-backend/models"
+	repositoriesBuildingRestriction "github.com/malikabdulaziz/tmn-backend/repositories/buildingrestriction"
+	repositoriesSalesPackage "github.com/malikabdulaziz/tmn-backend/repositories/salespackage"
+	serviceBuilding "github.com/malikabdulaziz/tmn-backend/services/building"
 	serviceSalesPackage "github.com/malikabdulaziz/tmn-backend/services/salespackage"
 	"github.com/malikabdulaziz/tmn-backend/testutil"
 	"github.com/malikabdulaziz/tmn-backend/testutil/mocks"
+	"github.com/malikabdulaziz/tmn-backend/web"
+	webBuilding "github.com/malikabdulaziz/tmn-backend/web/building"
 	webSalesPackage "github.com/malikabdulaziz/tmn-backend/web/salespackage"
+	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/xuri/excelize/v2"
 )
 
 func newSalesPackageService(
@@ -20,7 +33,40 @@ func newSalesPackageService(
 	repoPkg *mocks.MockRepositorySalesPackage,
 	repoBuilding *mocks.MockRepositoryBuilding,
 ) serviceSalesPackage.ServiceSalesPackageInterface {
-	return serviceSalesPackage.NewServiceSalesPackageImpl(db, repoPkg, repoBuilding)
+	return newSalesPackageServiceWithRestrictions(db, repoPkg, repoBuilding, &mocks.MockRepositoryBuildingRestriction{})
+}
+
+func newSalesPackageServiceWithRestrictions(
+	db *sql.DB,
+	repoPkg *mocks.MockRepositorySalesPackage,
+	repoBuilding *mocks.MockRepositoryBuilding,
+	repoRestriction *mocks.MockRepositoryBuildingRestriction,
+) serviceSalesPackage.ServiceSalesPackageInterface {
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+	svcBuilding := serviceBuilding.NewServiceBuildingImpl(db, repoBuilding, &mocks.MockRepositoryPOI{}, nil, logger)
+	return serviceSalesPackage.NewServiceSalesPackageImpl(db, repoPkg, repoBuilding, svcBuilding, repoRestriction)
+}
+
+// expectMappingBuildings makes the building service's FindAllForMapping (one transaction, no bounds)
+// return buildings for the given building_type filter
+func expectMappingBuildings(sqlMock sqlmock.Sqlmock, repoBuilding *mocks.MockRepositoryBuilding, buildingType string, buildings ...models.Building) {
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectCommit()
+	repoBuilding.On("FindAllForMapping",
+		mock.Anything, mock.AnythingOfType("*sql.Tx"), mock.Anything,
+		buildingType, "", "", "", "", "", "", "", "", "", "", "", "",
+		mock.Anything, mock.Anything, mock.Anything,
+		"", 0,
+		mock.Anything, mock.Anything,
+		mock.Anything, mock.Anything, mock.Anything, mock.Anything,
+	).Return(buildings, nil).Once()
+}
+
+// expectVersion makes CreateVersion record the given version of a package
+func expectVersion(repoPkg *mocks.MockRepositorySalesPackage, salesPackageId int, note string, version int) {
+	repoPkg.On("CreateVersion", mock.Anything, mock.AnythingOfType("*sql.Tx"), salesPackageId, note, (*int)(nil)).
+		Return(models.SalesPackageVersion{SalesPackageId: salesPackageId, Version: version}, nil).Once()
 }
 
 func newSalesPackageModel(id int, name string, buildingRefs ...models.BuildingRef) models.SalesPackage {
@@ -58,6 +104,7 @@ func TestSalesPackageCreate_HappyPath(t *testing.T) {
 		mock.MatchedBy(func(p models.SalesPackage) bool { return p.Name == "Package Alpha" }),
 		[]int{10},
 	).Return(created, nil)
+	expectVersion(repoPkg, 1, "created", 1)
 
 	request := webSalesPackage.CreateSalesPackageRequest{
 		Name:        "Package Alpha",
@@ -76,12 +123,86 @@ func TestSalesPackageCreate_HappyPath(t *testing.T) {
 	assert.Equal(t, "Jakarta Pusat", response.Buildings[0].Citytown)
 	assert.Equal(t, "DKI Jakarta", response.Buildings[0].Province)
 	assert.Equal(t, "Office", response.Buildings[0].BuildingType)
+	assert.Equal(t, 1, response.Version)
 
 	repoPkg.AssertExpectations(t)
 	repoBuilding.AssertExpectations(t)
 	assert.NoError(t, sqlMock.ExpectationsWereMet())
 }
 
+func TestSalesPackageCreate_RestrictionWarning(t *testing.T) {
+	db, sqlMock := testutil.NewMockDB(t)
+	repoPkg := &mocks.MockRepositorySalesPackage{}
+	repoBuilding := &mocks.MockRepositoryBuilding{}
+	repoRestriction := &mocks.MockRepositoryBuildingRestriction{}
+	svc := newSalesPackageServiceWithRestrictions(db, repoPkg, repoBuilding, repoRestriction)
+
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectCommit()
+
+	repoBuilding.On("FindById", mock.Anything, mock.AnythingOfType("*sql.Tx"), 10).Return(testutil.NewBuilding(10, "Tower A"), nil)
+	// packages have no run dates: restrictions are checked from today, open-ended
+	repoRestriction.On("FindViolations", mock.Anything, mock.AnythingOfType("*sql.Tx"),
+		mock.MatchedBy(func(c repositoriesBuildingRestriction.RestrictionCheck) bool {
+			return c.CategoryId == 3 && c.MotherBrandId == 0 && c.From != "" && c.Until == "" && len(c.BuildingIds) == 1
+		}),
+	).Return([]repositoriesBuildingRestriction.RestrictionViolationRow{{
+		RestrictionId: 1, RestrictionName: "School nearby", Enforcement: models.RestrictionEnforcementWarn,
+		BuildingId: 10, BuildingName: "Tower A", Reason: "category", AdvertiserName: "Tobacco",
+	}}, nil)
+	repoPkg.On("Create", mock.Anything, mock.AnythingOfType("*sql.Tx"), mock.Anything, []int{10}).
+		Return(newSalesPackageModel(1, "Package Alpha", models.BuildingRef{Id: 10, Name: "Tower A"}), nil)
+	expectVersion(repoPkg, 1, "created", 1)
+
+	response := svc.Create(context.Background(), webSalesPackage.CreateSalesPackageRequest{
+		Name:                 "Package Alpha",
+		BuildingIds:          []int{10},
+		AdvertiserCategoryId: 3,
+	})
+
+	assert.Len(t, response.RestrictionWarnings, 1)
+	assert.Equal(t, "School nearby", response.RestrictionWarnings[0].RestrictionName)
+	assert.Equal(t, "Tobacco", response.RestrictionWarnings[0].Advertiser)
+	repoRestriction.AssertExpectations(t)
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+}
+
+func TestSalesPackageCreate_RestrictionBlocks(t *testing.T) {
+	db, sqlMock := testutil.NewMockDB(t)
+	repoPkg := &mocks.MockRepositorySalesPackage{}
+	repoBuilding := &mocks.MockRepositoryBuilding{}
+	repoRestriction := &mocks.MockRepositoryBuildingRestriction{}
+	svc := newSalesPackageServiceWithRestrictions(db, repoPkg, repoBuilding, repoRestriction)
+
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectRollback()
+
+	repoBuilding.On("FindById", mock.Anything, mock.AnythingOfType("*sql.Tx"), 10).Return(testutil.NewBuilding(10, "Tower A"), nil)
+	repoRestriction.On("FindViolations", mock.Anything, mock.AnythingOfType("*sql.Tx"), mock.Anything).
+		Return([]repositoriesBuildingRestriction.RestrictionViolationRow{
+			{RestrictionId: 1, RestrictionName: "School nearby", Enforcement: models.RestrictionEnforcementWarn, BuildingId: 10, Reason: "category"},
+			{RestrictionId: 2, RestrictionName: "Tenant exclusivity", Enforcement: models.RestrictionEnforcementBlock, BuildingId: 10, Reason: "mother_brand"},
+		}, nil)
+
+	var recovered interface{}
+	func() {
+		defer func() { recovered = recover() }()
+		svc.Create(context.Background(), webSalesPackage.CreateSalesPackageRequest{
+			Name:                    "Package Alpha",
+			BuildingIds:             []int{10},
+			AdvertiserCategoryId:    3,
+			AdvertiserMotherBrandId: 7,
+		})
+	}()
+
+	err, ok := recovered.(exceptions.BadRequestError)
+	assert.True(t, ok)
+	assert.Equal(t, "advertiser is restricted in some of the buildings", err.Error)
+	assert.Len(t, err.Extras, 2)
+	repoPkg.AssertNotCalled(t, "Create", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+}
+
 // TestSalesPackageCreate_InvalidBuilding verifies that a non-existent building ID
 // causes a BadRequestError panic before the package is created.
 func TestSalesPackageCreate_InvalidBuilding(t *testing.T) {
@@ -123,6 +244,7 @@ func TestSalesPackageFindById_HappyPath(t *testing.T) {
 	sqlMock.ExpectBegin()
 	sqlMock.ExpectCommit()
 
+	repoPkg.On("FindAccess", mock.Anything, mock.AnythingOfType("*sql.Tx"), 7, 0).Return(models.Access{Visible: true, Editable: true}, nil)
 	repoPkg.On("FindById", mock.Anything, mock.AnythingOfType("*sql.Tx"), 7).
 		Return(pkg, nil)
 
@@ -145,8 +267,8 @@ func TestSalesPackageFindById_NotFound(t *testing.T) {
 	sqlMock.ExpectBegin()
 	sqlMock.ExpectRollback()
 
-	repoPkg.On("FindById", mock.Anything, mock.AnythingOfType("*sql.Tx"), 404).
-		Return(models.SalesPackage{}, sql.ErrNoRows)
+	repoPkg.On("FindAccess", mock.Anything, mock.AnythingOfType("*sql.Tx"), 404, 0).
+		Return(models.Access{}, sql.ErrNoRows)
 
 	assert.PanicsWithValue(t,
 		exceptions.NotFoundError{Error: "sales package not found"},
@@ -172,6 +294,7 @@ func TestSalesPackageUpdate_HappyPath(t *testing.T) {
 	sqlMock.ExpectBegin()
 	sqlMock.ExpectCommit()
 
+	repoPkg.On("FindAccess", mock.Anything, mock.AnythingOfType("*sql.Tx"), 5, 0).Return(models.Access{Visible: true, Editable: true}, nil)
 	repoPkg.On("FindById", mock.Anything, mock.AnythingOfType("*sql.Tx"), 5).
 		Return(existing, nil)
 	repoBuilding.On("FindById", mock.Anything, mock.AnythingOfType("*sql.Tx"), 20).
@@ -180,6 +303,7 @@ func TestSalesPackageUpdate_HappyPath(t *testing.T) {
 		mock.MatchedBy(func(p models.SalesPackage) bool { return p.Name == "NewName" }),
 		[]int{20},
 	).Return(updated, nil)
+	expectVersion(repoPkg, 5, "updated", 3)
 
 	request := webSalesPackage.UpdateSalesPackageRequest{
 		Name:        "NewName",
@@ -191,12 +315,67 @@ func TestSalesPackageUpdate_HappyPath(t *testing.T) {
 	assert.Equal(t, 5, response.Id)
 	assert.Equal(t, "NewName", response.Name)
 	assert.Equal(t, "Tower B", response.Buildings[0].Name)
+	assert.Equal(t, 3, response.Version)
 
 	repoPkg.AssertExpectations(t)
 	repoBuilding.AssertExpectations(t)
 	assert.NoError(t, sqlMock.ExpectationsWereMet())
 }
 
+func TestSalesPackageUpdate_HandEditDetachesFilter(t *testing.T) {
+	db, sqlMock := testutil.NewMockDB(t)
+	repoPkg := &mocks.MockRepositorySalesPackage{}
+	repoBuilding := &mocks.MockRepositoryBuilding{}
+	svc := newSalesPackageService(db, repoPkg, repoBuilding)
+
+	existing := newSalesPackageModel(5, "Offices", models.BuildingRef{Id: 20, Name: "Tower B"})
+	existing.Filter = `{"building_type":["Office"]}`
+	existing.FilterRefreshedAt = "2026-10-01T00:00:00Z"
+	updated := newSalesPackageModel(5, "Offices", models.BuildingRef{Id: 20, Name: "Tower B"}, models.BuildingRef{Id: 21, Name: "Tower C"})
+	updated.Filter = existing.Filter
+
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectCommit()
+
+	repoPkg.On("FindAccess", mock.Anything, mock.AnythingOfType("*sql.Tx"), 5, 0).Return(models.Access{Visible: true, Editable: true}, nil)
+	repoPkg.On("FindById", mock.Anything, mock.AnythingOfType("*sql.Tx"), 5).Return(existing, nil)
+	repoBuilding.On("FindById", mock.Anything, mock.AnythingOfType("*sql.Tx"), 20).Return(testutil.NewBuilding(20, "Tower B"), nil)
+	repoBuilding.On("FindById", mock.Anything, mock.AnythingOfType("*sql.Tx"), 21).Return(testutil.NewBuilding(21, "Tower C"), nil)
+	repoPkg.On("SetFilter", mock.Anything, mock.AnythingOfType("*sql.Tx"), 5, "").Return("", nil)
+	repoPkg.On("Update", mock.Anything, mock.AnythingOfType("*sql.Tx"), mock.Anything, []int{20, 21}).Return(updated, nil)
+	expectVersion(repoPkg, 5, "updated", 2)
+
+	response := svc.Update(context.Background(), webSalesPackage.UpdateSalesPackageRequest{Name: "Offices", BuildingIds: []int{20, 21}}, 5)
+
+	assert.Empty(t, response.Filter)
+	repoPkg.AssertExpectations(t)
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+}
+
+func TestSalesPackageUpdate_SameBuildingsKeepFilter(t *testing.T) {
+	db, sqlMock := testutil.NewMockDB(t)
+	repoPkg := &mocks.MockRepositorySalesPackage{}
+	repoBuilding := &mocks.MockRepositoryBuilding{}
+	svc := newSalesPackageService(db, repoPkg, repoBuilding)
+
+	existing := newSalesPackageModel(5, "Offices", models.BuildingRef{Id: 20, Name: "Tower B"})
+	existing.Filter = `{"building_type":["Office"]}`
+
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectCommit()
+
+	repoPkg.On("FindAccess", mock.Anything, mock.AnythingOfType("*sql.Tx"), 5, 0).Return(models.Access{Visible: true, Editable: true}, nil)
+	repoPkg.On("FindById", mock.Anything, mock.AnythingOfType("*sql.Tx"), 5).Return(existing, nil)
+	repoBuilding.On("FindById", mock.Anything, mock.AnythingOfType("*sql.Tx"), 20).Return(testutil.NewBuilding(20, "Tower B"), nil)
+	repoPkg.On("Update", mock.Anything, mock.AnythingOfType("*sql.Tx"), mock.Anything, []int{20}).Return(existing, nil)
+	expectVersion(repoPkg, 5, "updated", 2)
+
+	svc.Update(context.Background(), webSalesPackage.UpdateSalesPackageRequest{Name: "Office towers", BuildingIds: []int{20}}, 5)
+
+	repoPkg.AssertNotCalled(t, "SetFilter", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+}
+
 func TestSalesPackageUpdate_NotFound(t *testing.T) {
 	db, sqlMock := testutil.NewMockDB(t)
 	repoPkg := &mocks.MockRepositorySalesPackage{}
@@ -206,8 +385,8 @@ func TestSalesPackageUpdate_NotFound(t *testing.T) {
 	sqlMock.ExpectBegin()
 	sqlMock.ExpectRollback()
 
-	repoPkg.On("FindById", mock.Anything, mock.AnythingOfType("*sql.Tx"), 404).
-		Return(models.SalesPackage{}, sql.ErrNoRows)
+	repoPkg.On("FindAccess", mock.Anything, mock.AnythingOfType("*sql.Tx"), 404, 0).
+		Return(models.Access{}, sql.ErrNoRows)
 
 	request := webSalesPackage.UpdateSalesPackageRequest{Name: "X", BuildingIds: []int{1}}
 
@@ -231,9 +410,8 @@ func TestSalesPackageDelete_HappyPath(t *testing.T) {
 	sqlMock.ExpectBegin()
 	sqlMock.ExpectCommit()
 
-	repoPkg.On("FindById", mock.Anything, mock.AnythingOfType("*sql.Tx"), 3).
-		Return(newSalesPackageModel(3, "ToDelete"), nil)
-	repoPkg.On("Delete", mock.Anything, mock.AnythingOfType("*sql.Tx"), 3).
+	repoPkg.On("FindAccess", mock.Anything, mock.AnythingOfType("*sql.Tx"), 3, 0).Return(models.Access{Visible: true, Editable: true}, nil)
+	repoPkg.On("Delete", mock.Anything, mock.AnythingOfType("*sql.Tx"), 3, (*int)(nil)).
 		Return(nil)
 
 	assert.NotPanics(t, func() { svc.Delete(context.Background(), 3) })
@@ -251,8 +429,8 @@ func TestSalesPackageDelete_NotFound(t *testing.T) {
 	sqlMock.ExpectBegin()
 	sqlMock.ExpectRollback()
 
-	repoPkg.On("FindById", mock.Anything, mock.AnythingOfType("*sql.Tx"), 999).
-		Return(models.SalesPackage{}, sql.ErrNoRows)
+	repoPkg.On("FindAccess", mock.Anything, mock.AnythingOfType("*sql.Tx"), 999, 0).
+		Return(models.Access{}, sql.ErrNoRows)
 
 	assert.PanicsWithValue(t,
 		exceptions.NotFoundError{Error: "sales package not found"},
@@ -279,8 +457,13 @@ func TestSalesPackageExport_HappyPath(t *testing.T) {
 	sqlMock.ExpectBegin()
 	sqlMock.ExpectCommit()
 
-	repoPkg.On("FindAllFlat", mock.Anything, mock.AnythingOfType("*sql.Tx"), "").
+	repoPkg.On("FindAllFlat", mock.Anything, mock.AnythingOfType("*sql.Tx"), 0, "").
 		Return(packages, nil)
+	repoPkg.On("FindSummaryRows", mock.Anything, mock.AnythingOfType("*sql.Tx"), []int{1, 2}).
+		Return([]repositoriesSalesPackage.SalesPackageSummaryRow{
+			{SalesPackageId: 1, BuildingId: 10, BuildingName: "Tower A", Sellable: "sell", LcdPresenceStatus: "TMN", Audience: 100, Impression: 1000, Screens: 2, HasProposal: true},
+			{SalesPackageId: 2, BuildingId: 20, BuildingName: "Tower B", Sellable: "not_sell", LcdPresenceStatus: "TMN", Audience: 50},
+		}, nil)
 
 	excelBytes, err := svc.Export(context.Background(), "")
 
@@ -290,10 +473,225 @@ func TestSalesPackageExport_HappyPath(t *testing.T) {
 	assert.Equal(t, byte(0x50), excelBytes[0])
 	assert.Equal(t, byte(0x4B), excelBytes[1])
 
+	f, err := excelize.OpenReader(bytes.NewReader(excelBytes))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Sales Packages", "Summary", "Breakdown", "Warnings"}, f.GetSheetList())
+	summaryRows, err := f.GetRows("Summary")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Package A", "1", "100", "1000", "2", "0", "0"}, summaryRows[1])
+	assert.Equal(t, []string{"Package B", "1", "50", "0", "0", "1", "1"}, summaryRows[2])
+
+	repoPkg.AssertExpectations(t)
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+}
+
+// --- From filter ---
+
+func TestSalesPackageCreateFromFilter_StoresFilter(t *testing.T) {
+	db, sqlMock := testutil.NewMockDB(t)
+	repoPkg := &mocks.MockRepositorySalesPackage{}
+	repoBuilding := &mocks.MockRepositoryBuilding{}
+	svc := newSalesPackageService(db, repoPkg, repoBuilding)
+
+	expectMappingBuildings(sqlMock, repoBuilding, "Office", testutil.NewBuilding(10, "Tower A"), testutil.NewBuilding(20, "Tower B"))
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectCommit()
+
+	repoPkg.On("Create", mock.Anything, mock.AnythingOfType("*sql.Tx"),
+		mock.MatchedBy(func(p models.SalesPackage) bool { return p.Name == "Offices" }),
+		[]int{10, 20},
+	).Return(newSalesPackageModel(1, "Offices", models.BuildingRef{Id: 10, Name: "Tower A"}, models.BuildingRef{Id: 20, Name: "Tower B"}), nil)
+	repoPkg.On("SetFilter", mock.Anything, mock.AnythingOfType("*sql.Tx"), 1,
+		mock.MatchedBy(func(filter string) bool { return strings.Contains(filter, `"building_type":["Office"]`) }),
+	).Return("2026-10-19 09:00:00", nil)
+	expectVersion(repoPkg, 1, "created from filter", 1)
+
+	response := svc.CreateFromFilter(context.Background(), webSalesPackage.SalesPackageFromFilterRequest{
+		Name:        "Offices",
+		Filters:     webBuilding.ExportMappingFilters{BuildingType: []string{"Office"}},
+		StoreFilter: true,
+	})
+
+	assert.Len(t, response.Buildings, 2)
+	assert.Equal(t, []string{"Office"}, response.Filter.BuildingType)
+	assert.Equal(t, "2026-10-19 09:00:00", response.FilterRefreshedAt)
+	repoPkg.AssertExpectations(t)
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+}
+
+func TestSalesPackageCreateFromFilter_NoMatch(t *testing.T) {
+	db, sqlMock := testutil.NewMockDB(t)
+	repoPkg := &mocks.MockRepositorySalesPackage{}
+	repoBuilding := &mocks.MockRepositoryBuilding{}
+	svc := newSalesPackageService(db, repoPkg, repoBuilding)
+
+	expectMappingBuildings(sqlMock, repoBuilding, "Office")
+
+	assert.PanicsWithValue(t, exceptions.NewBadRequest("no buildings match the filters"), func() {
+		svc.CreateFromFilter(context.Background(), webSalesPackage.SalesPackageFromFilterRequest{
+			Name:    "Offices",
+			Filters: webBuilding.ExportMappingFilters{BuildingType: []string{"Office"}},
+		})
+	})
+	repoPkg.AssertNotCalled(t, "Create", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+}
+
+func TestSalesPackagePreviewRefresh_AddedAndRemoved(t *testing.T) {
+	db, sqlMock := testutil.NewMockDB(t)
+	repoPkg := &mocks.MockRepositorySalesPackage{}
+	repoBuilding := &mocks.MockRepositoryBuilding{}
+	svc := newSalesPackageService(db, repoPkg, repoBuilding)
+
+	pkg := newSalesPackageModel(1, "Offices", models.BuildingRef{Id: 10, Name: "Tower A"}, models.BuildingRef{Id: 20, Name: "Tower B"})
+	pkg.Filter = `{"building_type":["Office"]}`
+
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectCommit()
+	expectMappingBuildings(sqlMock, repoBuilding, "Office", testutil.NewBuilding(20, "Tower B"), testutil.NewBuilding(30, "Tower C"))
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectCommit()
+
+	repoPkg.On("FindAccess", mock.Anything, mock.AnythingOfType("*sql.Tx"), 1, 0).Return(models.Access{Visible: true, Editable: true}, nil)
+	repoPkg.On("FindById", mock.Anything, mock.AnythingOfType("*sql.Tx"), 1).Return(pkg, nil)
+	repoBuilding.On("FindByIds", mock.Anything, mock.AnythingOfType("*sql.Tx"), []int{30}).
+		Return([]models.Building{testutil.NewBuilding(30, "Tower C")}, nil)
+
+	preview := svc.PreviewRefresh(context.Background(), 1)
+
+	assert.Nil(t, preview.Package)
+	assert.Equal(t, 1, preview.Unchanged)
+	assert.Len(t, preview.Added, 1)
+	assert.Equal(t, "Tower C", preview.Added[0].Name)
+	assert.Len(t, preview.Removed, 1)
+	assert.Equal(t, 10, preview.Removed[0].Id)
+	repoPkg.AssertNotCalled(t, "Update", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+}
+
+func TestSalesPackageRefresh_ReplacesBuildings(t *testing.T) {
+	db, sqlMock := testutil.NewMockDB(t)
+	repoPkg := &mocks.MockRepositorySalesPackage{}
+	repoBuilding := &mocks.MockRepositoryBuilding{}
+	svc := newSalesPackageService(db, repoPkg, repoBuilding)
+
+	pkg := newSalesPackageModel(1, "Offices", models.BuildingRef{Id: 10, Name: "Tower A"})
+	pkg.Filter = `{"building_type":["Office"]}`
+	refreshed := newSalesPackageModel(1, "Offices", models.BuildingRef{Id: 30, Name: "Tower C"})
+	refreshed.Filter = pkg.Filter
+
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectCommit()
+	expectMappingBuildings(sqlMock, repoBuilding, "Office", testutil.NewBuilding(30, "Tower C"))
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectCommit()
+
+	repoPkg.On("FindAccess", mock.Anything, mock.AnythingOfType("*sql.Tx"), 1, 0).Return(models.Access{Visible: true, Editable: true}, nil)
+	repoPkg.On("FindById", mock.Anything, mock.AnythingOfType("*sql.Tx"), 1).Return(pkg, nil)
+	repoBuilding.On("FindByIds", mock.Anything, mock.AnythingOfType("*sql.Tx"), []int{30}).
+		Return([]models.Building{testutil.NewBuilding(30, "Tower C")}, nil)
+	repoPkg.On("Update", mock.Anything, mock.AnythingOfType("*sql.Tx"), pkg, []int{30}).Return(refreshed, nil)
+	repoPkg.On("SetFilter", mock.Anything, mock.AnythingOfType("*sql.Tx"), 1, pkg.Filter).Return("2026-10-19 10:00:00", nil)
+	expectVersion(repoPkg, 1, "refreshed from stored filter", 2)
+
+	result := svc.Refresh(context.Background(), 1)
+
+	assert.Len(t, result.Added, 1)
+	assert.Len(t, result.Removed, 1)
+	assert.Equal(t, 30, result.Package.Buildings[0].Id)
+	assert.Equal(t, "2026-10-19 10:00:00", result.Package.FilterRefreshedAt)
+	assert.Equal(t, 2, result.Package.Version)
+	repoPkg.AssertExpectations(t)
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+}
+
+func TestSalesPackageRefresh_WithoutStoredFilter(t *testing.T) {
+	db, sqlMock := testutil.NewMockDB(t)
+	repoPkg := &mocks.MockRepositorySalesPackage{}
+	svc := newSalesPackageService(db, repoPkg, &mocks.MockRepositoryBuilding{})
+
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectRollback()
+
+	repoPkg.On("FindAccess", mock.Anything, mock.AnythingOfType("*sql.Tx"), 1, 0).Return(models.Access{Visible: true, Editable: true}, nil)
+	repoPkg.On("FindById", mock.Anything, mock.AnythingOfType("*sql.Tx"), 1).Return(newSalesPackageModel(1, "Hand-picked"), nil)
+
+	assert.PanicsWithValue(t, exceptions.NewBadRequest("sales package has no stored filter"), func() {
+		svc.Refresh(context.Background(), 1)
+	})
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+}
+
+// --- Summary ---
+
+func TestSalesPackageSummary_TotalsBreakdownAndWarnings(t *testing.T) {
+	db, sqlMock := testutil.NewMockDB(t)
+	repoPkg := &mocks.MockRepositorySalesPackage{}
+	repoBuilding := &mocks.MockRepositoryBuilding{}
+	svc := newSalesPackageService(db, repoPkg, repoBuilding)
+
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectCommit()
+
+	repoPkg.On("FindAccess", mock.Anything, mock.AnythingOfType("*sql.Tx"), 1, 0).Return(models.Access{Visible: true, Editable: true}, nil)
+	repoPkg.On("FindById", mock.Anything, mock.AnythingOfType("*sql.Tx"), 1).
+		Return(newSalesPackageModel(1, "Package Alpha"), nil)
+	repoPkg.On("FindSummaryRows", mock.Anything, mock.AnythingOfType("*sql.Tx"), []int{1}).
+		Return([]repositoriesSalesPackage.SalesPackageSummaryRow{
+			{SalesPackageId: 1, BuildingId: 10, BuildingName: "Tower A", BuildingType: "Office", Citytown: "Jakarta Selatan", GradeResource: "A",
+				Sellable: "sell", LcdPresenceStatus: "TMN", Audience: 1000, Impression: 20000, Screens: 4, HasProposal: true},
+			{SalesPackageId: 1, BuildingId: 20, BuildingName: "Tower B", BuildingType: "Office", Citytown: "Jakarta Pusat", GradeResource: "B",
+				Sellable: "not_sell", LcdPresenceStatus: "Competitor", Audience: 500, Impression: 8000, Screens: 2, HasProposal: true,
+				Restrictions: []string{"Bank exclusivity"}},
+			{SalesPackageId: 1, BuildingId: 30, BuildingName: "Residence C", BuildingType: "Apartment", Citytown: "Jakarta Selatan",
+				Sellable: "sell", LcdPresenceStatus: "TMN", Audience: 300, Impression: 3000},
+		}, nil)
+
+	summary := svc.Summary(context.Background(), 1)
+
+	assert.Equal(t, "Package Alpha", summary.Name)
+	assert.Equal(t, 3, summary.BuildingCount)
+	assert.Equal(t, 1800, summary.TotalAudience)
+	assert.Equal(t, 31000, summary.TotalImpression)
+	assert.Equal(t, 6, summary.TotalScreens)
+	assert.Equal(t, 1, summary.BuildingsWithoutProposal)
+	assert.Equal(t, []webSalesPackage.SalesPackageBreakdownResponse{
+		{Key: "Office", BuildingCount: 2, Audience: 1500, Impression: 28000, Screens: 6},
+		{Key: "Apartment", BuildingCount: 1, Audience: 300, Impression: 3000, Screens: 0},
+	}, summary.ByBuildingType)
+	assert.Equal(t, "Jakarta Selatan", summary.ByCity[0].Key)
+	assert.Equal(t, 1300, summary.ByCity[0].Audience)
+	assert.Equal(t, "Unspecified", summary.ByGrade[2].Key)
+
+	codes := []string{}
+	for _, w := range summary.Warnings {
+		assert.Equal(t, 20, w.BuildingId)
+		codes = append(codes, w.Code)
+	}
+	assert.Equal(t, []string{"not_sellable", "not_tmn", "restricted"}, codes)
+	assert.Equal(t, "Building is in building restriction Bank exclusivity", summary.Warnings[2].Message)
+
 	repoPkg.AssertExpectations(t)
 	assert.NoError(t, sqlMock.ExpectationsWereMet())
 }
 
+func TestSalesPackageSummary_NotFound(t *testing.T) {
+	db, sqlMock := testutil.NewMockDB(t)
+	repoPkg := &mocks.MockRepositorySalesPackage{}
+	svc := newSalesPackageService(db, repoPkg, &mocks.MockRepositoryBuilding{})
+
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectRollback()
+
+	repoPkg.On("FindAccess", mock.Anything, mock.AnythingOfType("*sql.Tx"), 99, 0).
+		Return(models.Access{}, sql.ErrNoRows)
+
+	assert.PanicsWithValue(t, exceptions.NewNotFoundError("sales package not found"), func() {
+		svc.Summary(context.Background(), 99)
+	})
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+}
+
 // --- Import ---
 
 func TestSalesPackageImport_UnsupportedFileType(t *testing.T) {
@@ -307,7 +705,7 @@ func TestSalesPackageImport_UnsupportedFileType(t *testing.T) {
 
 	assert.PanicsWithValue(t,
 		exceptions.BadRequestError{Error: "Unsupported file type. Use xlsx or csv."},
-		func() { svc.Import(context.Background(), []byte("data"), "txt") },
+		func() { svc.Import(context.Background(), []byte("data"), "txt", importer.OnErrorAbort) },
 	)
 
 	assert.NoError(t, sqlMock.ExpectationsWereMet())
@@ -339,8 +737,9 @@ func TestSalesPackageImport_CSV_HappyPath(t *testing.T) {
 		mock.MatchedBy(func(p models.SalesPackage) bool { return p.Name == "Package X" }),
 		[]int{10},
 	).Return(created, nil)
+	expectVersion(repoPkg, 1, "imported", 1)
 
-	responses := svc.Import(context.Background(), []byte(csvData), "csv")
+	responses, _ := svc.Import(context.Background(), []byte(csvData), "csv", importer.OnErrorAbort)
 
 	assert.Len(t, responses, 1)
 	assert.Equal(t, "Package X", responses[0].Name)
@@ -350,3 +749,295 @@ func TestSalesPackageImport_CSV_HappyPath(t *testing.T) {
 	repoBuilding.AssertExpectations(t)
 	assert.NoError(t, sqlMock.ExpectationsWereMet())
 }
+
+func TestSalesPackageImport_ReplacesExistingPackageInPlace(t *testing.T) {
+	db, sqlMock := testutil.NewMockDB(t)
+	repoPkg := &mocks.MockRepositorySalesPackage{}
+	repoBuilding := &mocks.MockRepositoryBuilding{}
+	svc := newSalesPackageService(db, repoPkg, repoBuilding)
+
+	csvData := "Name,Building Name\nPackage X,Tower A\n"
+
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectCommit()
+
+	repoBuilding.On("FindAllDropdown", mock.Anything, mock.AnythingOfType("*sql.Tx")).
+		Return([]models.Building{testutil.NewBuilding(10, "Tower A")}, nil)
+	repoPkg.On("FindByNames", mock.Anything, mock.AnythingOfType("*sql.Tx"), []string{"Package X"}).
+		Return([]models.SalesPackage{
+			{Id: 5, Name: "Package X", Visibility: models.VisibilityPrivate},
+			{Id: 8, Name: "Package X", Visibility: models.VisibilityTeam},
+		}, nil)
+	repoPkg.On("FindAccess", mock.Anything, mock.AnythingOfType("*sql.Tx"), 5, 0).Return(models.Access{Visible: true, Editable: true}, nil)
+	repoPkg.On("FindAccess", mock.Anything, mock.AnythingOfType("*sql.Tx"), 8, 0).Return(models.Access{Visible: true, Editable: true}, nil)
+	repoPkg.On("FindById", mock.Anything, mock.AnythingOfType("*sql.Tx"), 5).
+		Return(models.SalesPackage{Id: 5, Name: "Package X", Visibility: models.VisibilityPrivate, Buildings: []models.BuildingRef{{Id: 10}}}, nil)
+	repoPkg.On("Delete", mock.Anything, mock.AnythingOfType("*sql.Tx"), 8, (*int)(nil)).Return(nil)
+	repoPkg.On("Update", mock.Anything, mock.AnythingOfType("*sql.Tx"),
+		mock.MatchedBy(func(p models.SalesPackage) bool { return p.Id == 5 && p.Visibility == models.VisibilityPrivate }),
+		[]int{10},
+	).Return(models.SalesPackage{Id: 5, Name: "Package X"}, nil)
+	expectVersion(repoPkg, 5, "imported", 4)
+
+	responses, _ := svc.Import(context.Background(), []byte(csvData), "csv", importer.OnErrorAbort)
+
+	assert.Equal(t, 5, responses[0].Id)
+	assert.Equal(t, 4, responses[0].Version)
+	repoPkg.AssertNotCalled(t, "Create", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	repoPkg.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything, 5, mock.Anything)
+	repoPkg.AssertExpectations(t)
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+}
+
+func TestSalesPackageImport_UnknownBuildingAbortsWithRowErrors(t *testing.T) {
+	db, sqlMock := testutil.NewMockDB(t)
+	repoPkg := &mocks.MockRepositorySalesPackage{}
+	repoBuilding := &mocks.MockRepositoryBuilding{}
+	svc := newSalesPackageService(db, repoPkg, repoBuilding)
+
+	csvData := "Name,Building Name\nPackage X,Tower A\nPackage X,Tower Z\nPackage Y,Tower Q\n"
+
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectRollback()
+
+	repoBuilding.On("FindAllDropdown", mock.Anything, mock.AnythingOfType("*sql.Tx")).
+		Return([]models.Building{testutil.NewBuilding(10, "Tower A")}, nil)
+
+	defer func() {
+		err, ok := recover().(exceptions.BadRequestError)
+		assert.True(t, ok)
+		report := err.Extras.(web.ImportReport)
+		assert.Equal(t, []web.ImportRowError{
+			{Row: 3, Column: "Building Name", Value: "Tower Z", Reason: "Building not found"},
+			{Row: 4, Column: "Building Name", Value: "Tower Q", Reason: "Building not found"},
+		}, report.Errors)
+		repoPkg.AssertNotCalled(t, "Create", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		assert.NoError(t, sqlMock.ExpectationsWereMet())
+	}()
+	svc.Import(context.Background(), []byte(csvData), "csv", importer.OnErrorAbort)
+	t.Fatal("Import should panic")
+}
+
+func TestSalesPackageImport_SkipBadRows(t *testing.T) {
+	db, sqlMock := testutil.NewMockDB(t)
+	repoPkg := &mocks.MockRepositorySalesPackage{}
+	repoBuilding := &mocks.MockRepositoryBuilding{}
+	svc := newSalesPackageService(db, repoPkg, repoBuilding)
+
+	csvData := "Name,Building Name\nPackage X,Tower A\nPackage X,Tower A\nPackage Y,Tower Q\n"
+
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectCommit()
+
+	repoBuilding.On("FindAllDropdown", mock.Anything, mock.AnythingOfType("*sql.Tx")).
+		Return([]models.Building{testutil.NewBuilding(10, "Tower A")}, nil)
+	repoPkg.On("FindByNames", mock.Anything, mock.AnythingOfType("*sql.Tx"), []string{"Package X"}).
+		Return([]models.SalesPackage{}, nil)
+	repoPkg.On("Create", mock.Anything, mock.AnythingOfType("*sql.Tx"),
+		mock.MatchedBy(func(p models.SalesPackage) bool { return p.Name == "Package X" }),
+		[]int{10},
+	).Return(models.SalesPackage{Id: 1, Name: "Package X"}, nil)
+	expectVersion(repoPkg, 1, "imported", 1)
+
+	responses, report := svc.Import(context.Background(), []byte(csvData), "csv", importer.OnErrorSkip)
+
+	assert.Len(t, responses, 1)
+	assert.Equal(t, 3, report.TotalRows)
+	assert.Equal(t, 1, report.ImportedRows)
+	assert.Equal(t, 2, report.SkippedRows)
+	assert.Equal(t, "Building is already listed for this sales package on row 2", report.Errors[0].Reason)
+	assert.Equal(t, 4, report.Errors[1].Row)
+	assert.NotEmpty(t, report.AnnotatedFile)
+
+	repoPkg.AssertExpectations(t)
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+}
+
+// --- Versions ---
+
+func intPtr(v int) *int {
+	return &v
+}
+
+func TestSalesPackageVersions_NewestFirst(t *testing.T) {
+	db, sqlMock := testutil.NewMockDB(t)
+	repoPkg := &mocks.MockRepositorySalesPackage{}
+	svc := newSalesPackageService(db, repoPkg, &mocks.MockRepositoryBuilding{})
+
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectCommit()
+	repoPkg.On("FindAccess", mock.Anything, mock.AnythingOfType("*sql.Tx"), 1, 0).Return(models.Access{Visible: true, Editable: true}, nil)
+	repoPkg.On("FindById", mock.Anything, mock.AnythingOfType("*sql.Tx"), 1).Return(newSalesPackageModel(1, "Offices"), nil)
+	repoPkg.On("FindVersions", mock.Anything, mock.AnythingOfType("*sql.Tx"), 1).Return([]models.SalesPackageVersion{
+		{Id: 12, SalesPackageId: 1, Version: 2, Name: "Offices", Note: "updated", BuildingCount: 3, Filter: `{"building_type":["Office"]}`},
+		{Id: 11, SalesPackageId: 1, Version: 1, Name: "Offices", Note: "created", BuildingCount: 2},
+	}, nil)
+
+	versions := svc.Versions(context.Background(), 1)
+
+	assert.Len(t, versions, 2)
+	assert.Equal(t, 2, versions[0].Version)
+	assert.Equal(t, []string{"Office"}, versions[0].Filter.BuildingType)
+	assert.Nil(t, versions[1].Filter)
+	assert.Nil(t, versions[1].Buildings)
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+}
+
+func TestSalesPackageFindVersion_NotFound(t *testing.T) {
+	db, sqlMock := testutil.NewMockDB(t)
+	repoPkg := &mocks.MockRepositorySalesPackage{}
+	svc := newSalesPackageService(db, repoPkg, &mocks.MockRepositoryBuilding{})
+
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectRollback()
+	repoPkg.On("FindAccess", mock.Anything, mock.AnythingOfType("*sql.Tx"), 1, 0).Return(models.Access{Visible: true, Editable: true}, nil)
+	repoPkg.On("FindVersion", mock.Anything, mock.AnythingOfType("*sql.Tx"), 1, 7).Return(models.SalesPackageVersion{}, sql.ErrNoRows)
+
+	assert.PanicsWithValue(t, exceptions.NewNotFoundError("sales package version not found"), func() {
+		svc.FindVersion(context.Background(), 1, 7)
+	})
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+}
+
+func TestSalesPackageDiffVersions_AddedRemovedAndTotals(t *testing.T) {
+	db, sqlMock := testutil.NewMockDB(t)
+	repoPkg := &mocks.MockRepositorySalesPackage{}
+	svc := newSalesPackageService(db, repoPkg, &mocks.MockRepositoryBuilding{})
+
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectCommit()
+	repoPkg.On("FindAccess", mock.Anything, mock.AnythingOfType("*sql.Tx"), 1, 0).Return(models.Access{Visible: true, Editable: true}, nil)
+	repoPkg.On("FindVersion", mock.Anything, mock.AnythingOfType("*sql.Tx"), 1, 1).Return(models.SalesPackageVersion{
+		Version: 1, Name: "Offices", BuildingCount: 2, TotalAudience: 1500, TotalImpression: 28000, TotalScreens: 6,
+		Buildings: []models.SalesPackageVersionBuilding{
+			{BuildingId: intPtr(10), BuildingName: "Tower A", Audience: 1000},
+			// deleted since; matched by name
+			{BuildingName: "Tower Old", Audience: 500},
+		},
+	}, nil)
+	// 0 compares against the latest version
+	repoPkg.On("FindVersion", mock.Anything, mock.AnythingOfType("*sql.Tx"), 1, 0).Return(models.SalesPackageVersion{
+		Version: 3, Name: "Jakarta Offices", BuildingCount: 2, TotalAudience: 1800, TotalImpression: 30000, TotalScreens: 7,
+		Buildings: []models.SalesPackageVersionBuilding{
+			{BuildingId: intPtr(10), BuildingName: "Tower A", Audience: 1000},
+			{BuildingId: intPtr(30), BuildingName: "Tower C", Audience: 800},
+		},
+	}, nil)
+
+	diff := svc.DiffVersions(context.Background(), 1, 1, 0)
+
+	assert.Equal(t, 1, diff.From)
+	assert.Equal(t, 3, diff.To)
+	assert.Equal(t, "Jakarta Offices", diff.ToName)
+	assert.False(t, diff.FilterChanged)
+	assert.Equal(t, 1, diff.Unchanged)
+	assert.Len(t, diff.Added, 1)
+	assert.Equal(t, "Tower C", diff.Added[0].BuildingName)
+	assert.Len(t, diff.Removed, 1)
+	assert.Equal(t, "Tower Old", diff.Removed[0].BuildingName)
+	assert.Equal(t, 0, diff.BuildingCountDelta)
+	assert.Equal(t, 300, diff.AudienceDelta)
+	assert.Equal(t, 2000, diff.ImpressionDelta)
+	assert.Equal(t, 1, diff.ScreensDelta)
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+}
+
+func TestSalesPackageDiffVersions_RequiresFrom(t *testing.T) {
+	db, _ := testutil.NewMockDB(t)
+	svc := newSalesPackageService(db, &mocks.MockRepositorySalesPackage{}, &mocks.MockRepositoryBuilding{})
+
+	assert.PanicsWithValue(t, exceptions.NewBadRequest("from must be a version number"), func() {
+		svc.DiffVersions(context.Background(), 1, 0, 2)
+	})
+}
+
+func TestSalesPackageRestoreVersion_SkipsDeletedBuildings(t *testing.T) {
+	db, sqlMock := testutil.NewMockDB(t)
+	repoPkg := &mocks.MockRepositorySalesPackage{}
+	svc := newSalesPackageService(db, repoPkg, &mocks.MockRepositoryBuilding{})
+
+	existing := newSalesPackageModel(1, "Jakarta Offices", models.BuildingRef{Id: 30, Name: "Tower C"})
+	filter := `{"building_type":["Office"]}`
+
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectCommit()
+	repoPkg.On("FindAccess", mock.Anything, mock.AnythingOfType("*sql.Tx"), 1, 0).Return(models.Access{Visible: true, Editable: true}, nil)
+	repoPkg.On("FindById", mock.Anything, mock.AnythingOfType("*sql.Tx"), 1).Return(existing, nil)
+	repoPkg.On("FindVersion", mock.Anything, mock.AnythingOfType("*sql.Tx"), 1, 1).Return(models.SalesPackageVersion{
+		Version: 1, Name: "Offices", Filter: filter,
+		Buildings: []models.SalesPackageVersionBuilding{
+			{BuildingId: intPtr(10), BuildingName: "Tower A"},
+			{BuildingName: "Tower Old"},
+		},
+	}, nil)
+	repoPkg.On("Update", mock.Anything, mock.AnythingOfType("*sql.Tx"),
+		mock.MatchedBy(func(p models.SalesPackage) bool { return p.Id == 1 && p.Name == "Offices" }),
+		[]int{10},
+	).Return(newSalesPackageModel(1, "Offices", models.BuildingRef{Id: 10, Name: "Tower A"}), nil)
+	repoPkg.On("SetFilter", mock.Anything, mock.AnythingOfType("*sql.Tx"), 1, filter).Return("2026-10-19 11:00:00", nil)
+	expectVersion(repoPkg, 1, "restored from version 1", 4)
+
+	response := svc.RestoreVersion(context.Background(), 1, 1)
+
+	assert.Equal(t, "Offices", response.Name)
+	assert.Len(t, response.Buildings, 1)
+	assert.Equal(t, []string{"Office"}, response.Filter.BuildingType)
+	assert.Equal(t, 4, response.Version)
+	repoPkg.AssertExpectations(t)
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+}
+
+// --- Ownership and visibility ---
+
+func TestSalesPackageFindAll_OnlyVisibleToCurrentUser(t *testing.T) {
+	db, sqlMock := testutil.NewMockDB(t)
+	repoPkg := &mocks.MockRepositorySalesPackage{}
+	svc := newSalesPackageService(db, repoPkg, &mocks.MockRepositoryBuilding{})
+	ctx := context.WithValue(context.Background(), helpers.ContextKey("userId"), "7")
+
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectCommit()
+	repoPkg.On("FindAll", mock.Anything, mock.AnythingOfType("*sql.Tx"), 7, 10, 0, "created_at", "DESC").
+		Return([]models.SalesPackage{newSalesPackageModel(1, "Mine")}, nil)
+	repoPkg.On("CountAll", mock.Anything, mock.AnythingOfType("*sql.Tx"), 7).Return(1, nil)
+
+	var request webSalesPackage.SalesPackageRequestFindAll
+	request.SetTake(10)
+	list, total := svc.FindAll(ctx, request)
+
+	assert.Len(t, list, 1)
+	assert.Equal(t, 1, total)
+	repoPkg.AssertExpectations(t)
+}
+
+func TestSalesPackageUpdate_OnlyOwner(t *testing.T) {
+	db, sqlMock := testutil.NewMockDB(t)
+	repoPkg := &mocks.MockRepositorySalesPackage{}
+	svc := newSalesPackageService(db, repoPkg, &mocks.MockRepositoryBuilding{})
+	ctx := context.WithValue(context.Background(), helpers.ContextKey("userId"), "7")
+
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectRollback()
+	repoPkg.On("FindAccess", mock.Anything, mock.AnythingOfType("*sql.Tx"), 5, 7).Return(models.Access{Visible: true}, nil)
+
+	assert.PanicsWithValue(t, exceptions.NewForbidden("only the owner can change this sales package"), func() {
+		svc.Update(ctx, webSalesPackage.UpdateSalesPackageRequest{Name: "Mine now", BuildingIds: []int{20}}, 5)
+	})
+	repoPkg.AssertNotCalled(t, "Update", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestSalesPackageDelete_HiddenFromCurrentUser(t *testing.T) {
+	db, sqlMock := testutil.NewMockDB(t)
+	repoPkg := &mocks.MockRepositorySalesPackage{}
+	svc := newSalesPackageService(db, repoPkg, &mocks.MockRepositoryBuilding{})
+	ctx := context.WithValue(context.Background(), helpers.ContextKey("userId"), "7")
+
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectRollback()
+	repoPkg.On("FindAccess", mock.Anything, mock.AnythingOfType("*sql.Tx"), 5, 7).Return(models.Access{}, nil)
+
+	assert.PanicsWithValue(t, exceptions.NewNotFoundError("sales package not found"), func() {
+		svc.Delete(ctx, 5)
+	})
+	repoPkg.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything, mock.Anything)
+}
@@ -3,6 +3,7 @@ package salespackage
 import (
 	"context"
 
+	"github.com/malikabdulaziz/tmn-backend/web"
 	webSalesPackage "github.com/malikabdulaziz/tmn-backend/web/salespackage"
 )
 
@@ -12,6 +13,16 @@ type ServiceSalesPackageInterface interface {
 	FindById(ctx context.Context, id int) webSalesPackage.SalesPackageResponse
 	Update(ctx context.Context, request webSalesPackage.UpdateSalesPackageRequest, id int) webSalesPackage.SalesPackageResponse
 	Delete(ctx context.Context, id int)
-	Import(ctx context.Context, fileBytes []byte, fileType string) []webSalesPackage.SalesPackageResponse
+	CreateFromFilter(ctx context.Context, request webSalesPackage.SalesPackageFromFilterRequest) webSalesPackage.SalesPackageResponse
+	UpdateFromFilter(ctx context.Context, request webSalesPackage.SalesPackageFromFilterRequest, id int) webSalesPackage.SalesPackageResponse
+	PreviewRefresh(ctx context.Context, id int) webSalesPackage.SalesPackageRefreshResponse
+	Refresh(ctx context.Context, id int) webSalesPackage.SalesPackageRefreshResponse
+	Summary(ctx context.Context, id int) webSalesPackage.SalesPackageSummaryResponse
+	Versions(ctx context.Context, id int) []webSalesPackage.SalesPackageVersionResponse
+	FindVersion(ctx context.Context, id int, version int) webSalesPackage.SalesPackageVersionResponse
+	DiffVersions(ctx context.Context, id int, from int, to int) webSalesPackage.SalesPackageVersionDiffResponse
+	RestoreVersion(ctx context.Context, id int, version int) webSalesPackage.SalesPackageResponse
+	Import(ctx context.Context, fileBytes []byte, fileType string, onError string) ([]webSalesPackage.SalesPackageResponse, web.ImportReport)
 	Export(ctx context.Context, search string) ([]byte, error)
+	ImportTemplate(ctx context.Context) ([]byte, error)
 }
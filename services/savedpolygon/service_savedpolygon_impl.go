@@ -49,7 +49,11 @@ func (s *ServiceSavedPolygonImpl) Create(ctx context.Context, request webSavedPo
 
 	s.validatePoints(request.Points)
 
-	polygon := models.SavedPolygon{Name: request.Name}
+	polygon := models.SavedPolygon{
+		Name:       request.Name,
+		CreatedBy:  helpers.OptionalUserIdFromContext(ctx),
+		Visibility: models.VisibilityOrDefault(request.Visibility),
+	}
 	points := make([]models.SavedPolygonPoint, len(request.Points))
 	for i, p := range request.Points {
 		points[i] = models.SavedPolygonPoint{Ord: i, Lat: p.Lat, Lng: p.Lng}
@@ -60,15 +64,16 @@ func (s *ServiceSavedPolygonImpl) Create(ctx context.Context, request webSavedPo
 	return s.modelToResponse(created)
 }
 
-// FindAll retrieves all saved polygons with pagination
+// FindAll retrieves the saved polygons the current user may see with pagination
 func (s *ServiceSavedPolygonImpl) FindAll(ctx context.Context, request webSavedPolygon.SavedPolygonRequestFindAll) ([]webSavedPolygon.SavedPolygonResponse, int) {
 	tx, err := s.DB.Begin()
 	helpers.PanicIfError(err)
 	defer helpers.CommitOrRollback(tx)
 
-	list, err := s.RepositorySavedPolygonInterface.FindAll(ctx, tx, request.GetTake(), request.GetSkip(), request.GetOrderBy(), request.GetOrderDirection())
+	userId := helpers.UserIdFromContext(ctx)
+	list, err := s.RepositorySavedPolygonInterface.FindAll(ctx, tx, userId, request.GetTake(), request.GetSkip(), request.GetOrderBy(), request.GetOrderDirection())
 	helpers.PanicIfError(err)
-	total, err := s.RepositorySavedPolygonInterface.CountAll(ctx, tx)
+	total, err := s.RepositorySavedPolygonInterface.CountAll(ctx, tx, userId)
 	helpers.PanicIfError(err)
 
 	responses := make([]webSavedPolygon.SavedPolygonResponse, len(list))
@@ -84,12 +89,23 @@ func (s *ServiceSavedPolygonImpl) FindById(ctx context.Context, id int) webSaved
 	helpers.PanicIfError(err)
 	defer helpers.CommitOrRollback(tx)
 
-	polygon, err := s.RepositorySavedPolygonInterface.FindById(ctx, tx, id)
-	if err == sql.ErrNoRows {
+	return s.modelToResponse(s.find(ctx, tx, id, false))
+}
+
+// find loads a saved polygon the current user may see, or may change when edit is set. Polygons
+// hidden from the user are reported as not found.
+func (s *ServiceSavedPolygonImpl) find(ctx context.Context, tx *sql.Tx, id int, edit bool) models.SavedPolygon {
+	access, err := s.RepositorySavedPolygonInterface.FindAccess(ctx, tx, id, helpers.UserIdFromContext(ctx))
+	if err == sql.ErrNoRows || (err == nil && !access.Visible) {
 		panic(exceptions.NewNotFoundError("saved polygon not found"))
 	}
 	helpers.PanicIfError(err)
-	return s.modelToResponse(polygon)
+	if edit && !access.Editable {
+		panic(exceptions.NewForbidden("only the owner can change this saved polygon"))
+	}
+	polygon, err := s.RepositorySavedPolygonInterface.FindById(ctx, tx, id)
+	helpers.PanicIfError(err)
+	return polygon
 }
 
 // Update updates a saved polygon and replaces its points
@@ -100,13 +116,12 @@ func (s *ServiceSavedPolygonImpl) Update(ctx context.Context, request webSavedPo
 
 	s.validatePoints(request.Points)
 
-	existing, err := s.RepositorySavedPolygonInterface.FindById(ctx, tx, id)
-	if err == sql.ErrNoRows {
-		panic(exceptions.NewNotFoundError("saved polygon not found"))
-	}
-	helpers.PanicIfError(err)
-
+	existing := s.find(ctx, tx, id, true)
 	existing.Name = request.Name
+	if request.Visibility != "" {
+		existing.Visibility = request.Visibility
+	}
+	existing.UpdatedBy = helpers.OptionalUserIdFromContext(ctx)
 	points := make([]models.SavedPolygonPoint, len(request.Points))
 	for i, p := range request.Points {
 		points[i] = models.SavedPolygonPoint{Ord: i, Lat: p.Lat, Lng: p.Lng}
@@ -117,18 +132,14 @@ func (s *ServiceSavedPolygonImpl) Update(ctx context.Context, request webSavedPo
 	return s.modelToResponse(updated)
 }
 
-// Delete deletes a saved polygon
+// Delete moves a saved polygon to the trash
 func (s *ServiceSavedPolygonImpl) Delete(ctx context.Context, id int) {
 	tx, err := s.DB.Begin()
 	helpers.PanicIfError(err)
 	defer helpers.CommitOrRollback(tx)
 
-	_, err = s.RepositorySavedPolygonInterface.FindById(ctx, tx, id)
-	if err == sql.ErrNoRows {
-		panic(exceptions.NewNotFoundError("saved polygon not found"))
-	}
-	helpers.PanicIfError(err)
-	err = s.RepositorySavedPolygonInterface.Delete(ctx, tx, id)
+	s.find(ctx, tx, id, true)
+	err = s.RepositorySavedPolygonInterface.Delete(ctx, tx, id, helpers.OptionalUserIdFromContext(ctx))
 	helpers.PanicIfError(err)
 }
 
@@ -138,10 +149,13 @@ func (s *ServiceSavedPolygonImpl) modelToResponse(p models.SavedPolygon) webSave
 		points[i] = webSavedPolygon.SavedPolygonPointResponse{Ord: pt.Ord, Lat: pt.Lat, Lng: pt.Lng}
 	}
 	return webSavedPolygon.SavedPolygonResponse{
-		Id:        p.Id,
-		Name:      p.Name,
-		Points:    points,
-		CreatedAt: p.CreatedAt,
-		UpdatedAt: p.UpdatedAt,
+		Id:         p.Id,
+		Name:       p.Name,
+		Points:     points,
+		CreatedBy:  p.CreatedBy,
+		UpdatedBy:  p.UpdatedBy,
+		Visibility: p.Visibility,
+		CreatedAt:  p.CreatedAt,
+		UpdatedAt:  p.UpdatedAt,
 	}
 }
@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"github.com/malikabdulaziz/tmn-backend/exceptions"
+	"github.com/malikabdulaziz/tmn-backend/helpers"
 	"github.com/malikabdulaziz/tmn-backend/models"
 	servicePolygon "github.com/malikabdulaziz/tmn-backend/services/savedpolygon"
 	"github.com/malikabdulaziz/tmn-backend/testutil"
@@ -19,6 +20,10 @@ func newPolygonService(db *sql.DB, repoPolygon *mocks.MockRepositorySavedPolygon
 	return servicePolygon.NewServiceSavedPolygonImpl(db, repoPolygon)
 }
 
+func userContext(userId string) context.Context {
+	return context.WithValue(context.Background(), helpers.ContextKey("userId"), userId)
+}
+
 // threePoints returns a minimal valid 3-point polygon request.
 func threePoints() []webPolygon.SavedPolygonPointRequest {
 	return []webPolygon.SavedPolygonPointRequest{
@@ -163,6 +168,8 @@ func TestPolygonFindById_HappyPath(t *testing.T) {
 	sqlMock.ExpectBegin()
 	sqlMock.ExpectCommit()
 
+	repoPolygon.On("FindAccess", mock.Anything, mock.AnythingOfType("*sql.Tx"), 8, 0).
+		Return(models.Access{Visible: true, Editable: true}, nil)
 	repoPolygon.On("FindById", mock.Anything, mock.AnythingOfType("*sql.Tx"), 8).
 		Return(polygon, nil)
 
@@ -184,8 +191,8 @@ func TestPolygonFindById_NotFound(t *testing.T) {
 	sqlMock.ExpectBegin()
 	sqlMock.ExpectRollback()
 
-	repoPolygon.On("FindById", mock.Anything, mock.AnythingOfType("*sql.Tx"), 999).
-		Return(models.SavedPolygon{}, sql.ErrNoRows)
+	repoPolygon.On("FindAccess", mock.Anything, mock.AnythingOfType("*sql.Tx"), 999, 0).
+		Return(models.Access{}, sql.ErrNoRows)
 
 	assert.PanicsWithValue(t,
 		exceptions.NotFoundError{Error: "saved polygon not found"},
@@ -209,6 +216,8 @@ func TestPolygonUpdate_HappyPath(t *testing.T) {
 	sqlMock.ExpectBegin()
 	sqlMock.ExpectCommit()
 
+	repoPolygon.On("FindAccess", mock.Anything, mock.AnythingOfType("*sql.Tx"), 4, 0).
+		Return(models.Access{Visible: true, Editable: true}, nil)
 	repoPolygon.On("FindById", mock.Anything, mock.AnythingOfType("*sql.Tx"), 4).
 		Return(existing, nil)
 	repoPolygon.On("Update",
@@ -244,10 +253,10 @@ func TestPolygonUpdate_NotFound(t *testing.T) {
 		Points: threePoints(),
 	}
 
-	// validatePoints runs before FindById so we need FindById to return not found
+	// validatePoints runs before FindAccess so we need FindAccess to return not found
 	// but validatePoints passes here (3 valid points), so the panic should be NotFoundError.
-	repoPolygon.On("FindById", mock.Anything, mock.AnythingOfType("*sql.Tx"), 404).
-		Return(models.SavedPolygon{}, sql.ErrNoRows)
+	repoPolygon.On("FindAccess", mock.Anything, mock.AnythingOfType("*sql.Tx"), 404, 0).
+		Return(models.Access{}, sql.ErrNoRows)
 
 	assert.PanicsWithValue(t,
 		exceptions.NotFoundError{Error: "saved polygon not found"},
@@ -294,9 +303,11 @@ func TestPolygonDelete_HappyPath(t *testing.T) {
 	sqlMock.ExpectBegin()
 	sqlMock.ExpectCommit()
 
+	repoPolygon.On("FindAccess", mock.Anything, mock.AnythingOfType("*sql.Tx"), 9, 0).
+		Return(models.Access{Visible: true, Editable: true}, nil)
 	repoPolygon.On("FindById", mock.Anything, mock.AnythingOfType("*sql.Tx"), 9).
 		Return(newPolygonModel(9, "ToDelete"), nil)
-	repoPolygon.On("Delete", mock.Anything, mock.AnythingOfType("*sql.Tx"), 9).
+	repoPolygon.On("Delete", mock.Anything, mock.AnythingOfType("*sql.Tx"), 9, (*int)(nil)).
 		Return(nil)
 
 	assert.NotPanics(t, func() { svc.Delete(context.Background(), 9) })
@@ -313,8 +324,8 @@ func TestPolygonDelete_NotFound(t *testing.T) {
 	sqlMock.ExpectBegin()
 	sqlMock.ExpectRollback()
 
-	repoPolygon.On("FindById", mock.Anything, mock.AnythingOfType("*sql.Tx"), 999).
-		Return(models.SavedPolygon{}, sql.ErrNoRows)
+	repoPolygon.On("FindAccess", mock.Anything, mock.AnythingOfType("*sql.Tx"), 999, 0).
+		Return(models.Access{}, sql.ErrNoRows)
 
 	assert.PanicsWithValue(t,
 		exceptions.NotFoundError{Error: "saved polygon not found"},
@@ -324,3 +335,112 @@ func TestPolygonDelete_NotFound(t *testing.T) {
 	repoPolygon.AssertExpectations(t)
 	assert.NoError(t, sqlMock.ExpectationsWereMet())
 }
+
+// --- Ownership and visibility ---
+
+func TestPolygonCreate_OwnedByCurrentUserWithTeamVisibility(t *testing.T) {
+	db, sqlMock := testutil.NewMockDB(t)
+	repoPolygon := &mocks.MockRepositorySavedPolygon{}
+	svc := newPolygonService(db, repoPolygon)
+
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectCommit()
+
+	repoPolygon.On("Create", mock.Anything, mock.AnythingOfType("*sql.Tx"),
+		mock.MatchedBy(func(p models.SavedPolygon) bool {
+			return p.CreatedBy != nil && *p.CreatedBy == 7 && p.Visibility == models.VisibilityTeam
+		}),
+		mock.Anything,
+	).Return(newPolygonModel(1, "My Area"), nil)
+
+	svc.Create(userContext("7"), webPolygon.CreateSavedPolygonRequest{Name: "My Area", Points: threePoints()})
+
+	repoPolygon.AssertExpectations(t)
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+}
+
+func TestPolygonFindAll_OnlyVisibleToCurrentUser(t *testing.T) {
+	db, sqlMock := testutil.NewMockDB(t)
+	repoPolygon := &mocks.MockRepositorySavedPolygon{}
+	svc := newPolygonService(db, repoPolygon)
+
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectCommit()
+
+	repoPolygon.On("FindAll", mock.Anything, mock.AnythingOfType("*sql.Tx"), 7, 10, 0, "created_at", "DESC").
+		Return([]models.SavedPolygon{newPolygonModel(1, "Mine")}, nil)
+	repoPolygon.On("CountAll", mock.Anything, mock.AnythingOfType("*sql.Tx"), 7).Return(1, nil)
+
+	var request webPolygon.SavedPolygonRequestFindAll
+	request.SetTake(10)
+	list, total := svc.FindAll(userContext("7"), request)
+
+	assert.Len(t, list, 1)
+	assert.Equal(t, 1, total)
+	repoPolygon.AssertExpectations(t)
+}
+
+func TestPolygonFindById_HiddenFromCurrentUser(t *testing.T) {
+	db, sqlMock := testutil.NewMockDB(t)
+	repoPolygon := &mocks.MockRepositorySavedPolygon{}
+	svc := newPolygonService(db, repoPolygon)
+
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectRollback()
+
+	repoPolygon.On("FindAccess", mock.Anything, mock.AnythingOfType("*sql.Tx"), 8, 7).
+		Return(models.Access{}, nil)
+
+	assert.PanicsWithValue(t,
+		exceptions.NotFoundError{Error: "saved polygon not found"},
+		func() { svc.FindById(userContext("7"), 8) },
+	)
+	repoPolygon.AssertNotCalled(t, "FindById", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestPolygonUpdate_OnlyOwner(t *testing.T) {
+	db, sqlMock := testutil.NewMockDB(t)
+	repoPolygon := &mocks.MockRepositorySavedPolygon{}
+	svc := newPolygonService(db, repoPolygon)
+
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectRollback()
+
+	repoPolygon.On("FindAccess", mock.Anything, mock.AnythingOfType("*sql.Tx"), 8, 7).
+		Return(models.Access{Visible: true}, nil)
+
+	assert.PanicsWithValue(t,
+		exceptions.NewForbidden("only the owner can change this saved polygon"),
+		func() {
+			svc.Update(userContext("7"), webPolygon.UpdateSavedPolygonRequest{Name: "Mine now", Points: threePoints()}, 8)
+		},
+	)
+	repoPolygon.AssertNotCalled(t, "Update", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestPolygonUpdate_KeepsVisibilityAndStampsEditor(t *testing.T) {
+	db, sqlMock := testutil.NewMockDB(t)
+	repoPolygon := &mocks.MockRepositorySavedPolygon{}
+	svc := newPolygonService(db, repoPolygon)
+
+	existing := newPolygonModel(4, "Area")
+	existing.Visibility = models.VisibilityPrivate
+
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectCommit()
+
+	repoPolygon.On("FindAccess", mock.Anything, mock.AnythingOfType("*sql.Tx"), 4, 7).
+		Return(models.Access{Visible: true, Editable: true}, nil)
+	repoPolygon.On("FindById", mock.Anything, mock.AnythingOfType("*sql.Tx"), 4).Return(existing, nil)
+	repoPolygon.On("Update", mock.Anything, mock.AnythingOfType("*sql.Tx"),
+		mock.MatchedBy(func(p models.SavedPolygon) bool {
+			return p.Visibility == models.VisibilityPrivate && p.UpdatedBy != nil && *p.UpdatedBy == 7
+		}),
+		mock.Anything,
+	).Return(existing, nil)
+
+	svc.Update(userContext("7"), webPolygon.UpdateSavedPolygonRequest{Name: "Area", Points: threePoints()}, 4)
+
+	repoPolygon.AssertExpectations(t)
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+}
@@ -0,0 +1,207 @@
+package savedview
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"strings"
+
+	"github.com/malikabdulaziz/tmn-backend/exceptions"
+	"github.com/malikabdulaziz/tmn-backend/helpers"
+	"github.com/malikabdulaziz/tmn-backend/models"
+	repositoriesSavedView "github.com/malikabdulaziz/tmn-backend/repositories/savedview"
+	webBuilding "github.com/malikabdulaziz/tmn-backend/web/building"
+	webSavedView "github.com/malikabdulaziz/tmn-backend/web/savedview"
+)
+
+type ServiceSavedViewImpl struct {
+	DB                           *sql.DB
+	RepositorySavedViewInterface repositoriesSavedView.RepositorySavedViewInterface
+}
+
+func NewServiceSavedViewImpl(db *sql.DB, repositorySavedView repositoriesSavedView.RepositorySavedViewInterface) ServiceSavedViewInterface {
+	return &ServiceSavedViewImpl{
+		DB:                           db,
+		RepositorySavedViewInterface: repositorySavedView,
+	}
+}
+
+// Create saves a mapping view for the current user
+func (s *ServiceSavedViewImpl) Create(ctx context.Context, request webSavedView.CreateSavedViewRequest) webSavedView.SavedViewResponse {
+	userId := helpers.UserIdFromContext(ctx)
+	if userId == 0 {
+		panic(exceptions.NewUnAuthorized("unauthorized"))
+	}
+
+	tx, err := s.DB.Begin()
+	helpers.PanicIfError(err)
+	defer helpers.CommitOrRollback(tx)
+
+	view := toModel(webSavedView.UpdateSavedViewRequest(request))
+	view.CreatedBy = userId
+	if view.Visibility == "" {
+		view.Visibility = models.VisibilityPrivate
+	}
+	s.checkNameAvailable(ctx, tx, userId, view.Name, 0)
+	created, err := s.RepositorySavedViewInterface.Create(ctx, tx, view)
+	helpers.PanicIfError(err)
+	return toResponse(created, userId)
+}
+
+// FindAll lists the views the current user may see, narrowed by scope
+func (s *ServiceSavedViewImpl) FindAll(ctx context.Context, request webSavedView.SavedViewRequestFindAll) ([]webSavedView.SavedViewResponse, int) {
+	scope := request.GetScope()
+	if scope != repositoriesSavedView.ScopeVisible && scope != repositoriesSavedView.ScopeMine && scope != repositoriesSavedView.ScopeShared {
+		panic(exceptions.NewBadRequest("scope must be mine or shared"))
+	}
+	userId := helpers.UserIdFromContext(ctx)
+
+	tx, err := s.DB.Begin()
+	helpers.PanicIfError(err)
+	defer helpers.CommitOrRollback(tx)
+
+	filter := repositoriesSavedView.SavedViewFilter{UserId: userId, Scope: scope, Name: request.GetName()}
+	views, err := s.RepositorySavedViewInterface.FindAll(ctx, tx, filter, request.GetTake(), request.GetSkip(), request.GetOrderBy(), request.GetOrderDirection())
+	helpers.PanicIfError(err)
+	total, err := s.RepositorySavedViewInterface.CountAll(ctx, tx, filter)
+	helpers.PanicIfError(err)
+
+	responses := make([]webSavedView.SavedViewResponse, len(views))
+	for i, view := range views {
+		responses[i] = toResponse(view, userId)
+	}
+	return responses, total
+}
+
+func (s *ServiceSavedViewImpl) FindById(ctx context.Context, id int) webSavedView.SavedViewResponse {
+	tx, err := s.DB.Begin()
+	helpers.PanicIfError(err)
+	defer helpers.CommitOrRollback(tx)
+
+	userId := helpers.UserIdFromContext(ctx)
+	return toResponse(s.find(ctx, tx, id, userId, false), userId)
+}
+
+// Update replaces a view; only its owner (or an admin) may change it
+func (s *ServiceSavedViewImpl) Update(ctx context.Context, request webSavedView.UpdateSavedViewRequest, id int) webSavedView.SavedViewResponse {
+	tx, err := s.DB.Begin()
+	helpers.PanicIfError(err)
+	defer helpers.CommitOrRollback(tx)
+
+	userId := helpers.UserIdFromContext(ctx)
+	existing := s.find(ctx, tx, id, userId, true)
+	view := toModel(request)
+	view.Id = existing.Id
+	view.CreatedBy = existing.CreatedBy
+	if view.Visibility == "" {
+		view.Visibility = existing.Visibility
+	}
+	s.checkNameAvailable(ctx, tx, existing.CreatedBy, view.Name, id)
+	updated, err := s.RepositorySavedViewInterface.Update(ctx, tx, view)
+	helpers.PanicIfError(err)
+	return toResponse(updated, userId)
+}
+
+// Delete removes a view; only its owner (or an admin) may delete it
+func (s *ServiceSavedViewImpl) Delete(ctx context.Context, id int) {
+	tx, err := s.DB.Begin()
+	helpers.PanicIfError(err)
+	defer helpers.CommitOrRollback(tx)
+
+	s.find(ctx, tx, id, helpers.UserIdFromContext(ctx), true)
+	helpers.PanicIfError(s.RepositorySavedViewInterface.Delete(ctx, tx, id))
+}
+
+// ApplyToMapping replaces the filters of a /mapping-buildings body with those of a saved view.
+// The view's map center and sort order are used when it has them; the body's bounds are kept.
+func (s *ServiceSavedViewImpl) ApplyToMapping(ctx context.Context, id int, body *webBuilding.MappingByFilterRequest) {
+	export := webBuilding.ExportMappingByFilterRequest{Filters: body.Filters, MapCenter: body.MapCenter, SortBy: body.SortBy}
+	s.ApplyToExport(ctx, id, &export)
+	body.Filters, body.MapCenter, body.SortBy = export.Filters, export.MapCenter, export.SortBy
+}
+
+// ApplyToExport replaces the filters of a mapping export body with those of a saved view
+func (s *ServiceSavedViewImpl) ApplyToExport(ctx context.Context, id int, body *webBuilding.ExportMappingByFilterRequest) {
+	tx, err := s.DB.Begin()
+	helpers.PanicIfError(err)
+	defer helpers.CommitOrRollback(tx)
+
+	view := s.find(ctx, tx, id, helpers.UserIdFromContext(ctx), false)
+	response := toResponse(view, 0)
+	body.Filters = response.Filters
+	if response.MapCenter != nil {
+		body.MapCenter = &struct {
+			Lat float64 `json:"lat"`
+			Lng float64 `json:"lng"`
+		}{Lat: response.MapCenter.Lat, Lng: response.MapCenter.Lng}
+	}
+	if response.SortBy != "" {
+		body.SortBy = response.SortBy
+	}
+}
+
+// find loads a view the user may see, or may change when edit is set. Views hidden from the user
+// are reported as not found.
+func (s *ServiceSavedViewImpl) find(ctx context.Context, tx *sql.Tx, id int, userId int, edit bool) models.SavedView {
+	access, err := s.RepositorySavedViewInterface.FindAccess(ctx, tx, id, userId)
+	if err == sql.ErrNoRows || (err == nil && !access.Visible) {
+		panic(exceptions.NewNotFoundError("saved view not found"))
+	}
+	helpers.PanicIfError(err)
+	if edit && !access.Editable {
+		panic(exceptions.NewForbidden("only the owner can change a saved view"))
+	}
+	view, err := s.RepositorySavedViewInterface.FindById(ctx, tx, id)
+	helpers.PanicIfError(err)
+	return view
+}
+
+// checkNameAvailable rejects a name the user already gave to another view
+func (s *ServiceSavedViewImpl) checkNameAvailable(ctx context.Context, tx *sql.Tx, userId int, name string, id int) {
+	existing, err := s.RepositorySavedViewInterface.FindByName(ctx, tx, userId, name)
+	if err == sql.ErrNoRows {
+		return
+	}
+	helpers.PanicIfError(err)
+	if existing.Id != id {
+		panic(exceptions.NewBadRequest("you already have a saved view named " + existing.Name))
+	}
+}
+
+func toModel(request webSavedView.UpdateSavedViewRequest) models.SavedView {
+	filters, err := json.Marshal(request.Filters)
+	helpers.PanicIfError(err)
+	view := models.SavedView{
+		Name:       strings.TrimSpace(request.Name),
+		Filters:    string(filters),
+		Zoom:       request.Zoom,
+		SortBy:     request.SortBy,
+		Visibility: request.Visibility,
+	}
+	if request.MapCenter != nil {
+		view.MapCenterLat, view.MapCenterLng = &request.MapCenter.Lat, &request.MapCenter.Lng
+	}
+	return view
+}
+
+func toResponse(view models.SavedView, userId int) webSavedView.SavedViewResponse {
+	resp := webSavedView.SavedViewResponse{
+		Id:            view.Id,
+		Name:          view.Name,
+		Zoom:          view.Zoom,
+		SortBy:        view.SortBy,
+		Visibility:    view.Visibility,
+		IsOwner:       userId > 0 && view.CreatedBy == userId,
+		CreatedBy:     view.CreatedBy,
+		CreatedByName: view.CreatedByName,
+		CreatedAt:     view.CreatedAt,
+		UpdatedAt:     view.UpdatedAt,
+	}
+	if view.Filters != "" {
+		helpers.PanicIfError(json.Unmarshal([]byte(view.Filters), &resp.Filters))
+	}
+	if view.MapCenterLat != nil && view.MapCenterLng != nil {
+		resp.MapCenter = &webSavedView.SavedViewPointResponse{Lat: *view.MapCenterLat, Lng: *view.MapCenterLng}
+	}
+	return resp
+}
@@ -0,0 +1,180 @@
+package savedview_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/malikabdulaziz/tmn-backend/exceptions"
+	"github.com/malikabdulaziz/tmn-backend/helpers"
+	"github.com/malikabdulaziz/tmn-backend/models"
+	repositoriesSavedView "github.com/malikabdulaziz/tmn-backend/repositories/savedview"
+	serviceSavedView "github.com/malikabdulaziz/tmn-backend/services/savedview"
+	"github.com/malikabdulaziz/tmn-backend/testutil"
+	"github.com/malikabdulaziz/tmn-backend/testutil/mocks"
+	webBuilding "github.com/malikabdulaziz/tmn-backend/web/building"
+	webSavedView "github.com/malikabdulaziz/tmn-backend/web/savedview"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func userContext(userId string) context.Context {
+	return context.WithValue(context.Background(), helpers.ContextKey("userId"), userId)
+}
+
+func floatPtr(v float64) *float64 {
+	return &v
+}
+
+// --- Create ---
+
+func TestSavedViewCreate_StoresFiltersForCurrentUser(t *testing.T) {
+	db, sqlMock := testutil.NewMockDB(t)
+	repo := &mocks.MockRepositorySavedView{}
+	svc := serviceSavedView.NewServiceSavedViewImpl(db, repo)
+
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectCommit()
+	repo.On("FindByName", mock.Anything, mock.AnythingOfType("*sql.Tx"), 7, "Grade A offices").Return(models.SavedView{}, sql.ErrNoRows)
+	repo.On("Create", mock.Anything, mock.AnythingOfType("*sql.Tx"), mock.MatchedBy(func(v models.SavedView) bool {
+		return v.Name == "Grade A offices" && v.CreatedBy == 7 && v.Visibility == models.VisibilityTeam &&
+			*v.MapCenterLat == -6.2 && *v.Zoom == 13 && v.Filters != ""
+	})).Return(models.SavedView{Id: 1, Name: "Grade A offices", CreatedBy: 7, Visibility: models.VisibilityTeam,
+		Filters: `{"building_grade":["A"]}`, MapCenterLat: floatPtr(-6.2), MapCenterLng: floatPtr(106.8), Zoom: floatPtr(13)}, nil)
+
+	resp := svc.Create(userContext("7"), webSavedView.CreateSavedViewRequest{
+		Name:       " Grade A offices ",
+		Filters:    webBuilding.ExportMappingFilters{BuildingGrade: []string{"A"}},
+		MapCenter:  &webSavedView.SavedViewPointRequest{Lat: -6.2, Lng: 106.8},
+		Zoom:       floatPtr(13),
+		Visibility: models.VisibilityTeam,
+	})
+
+	assert.Equal(t, []string{"A"}, resp.Filters.BuildingGrade)
+	assert.Equal(t, &webSavedView.SavedViewPointResponse{Lat: -6.2, Lng: 106.8}, resp.MapCenter)
+	assert.True(t, resp.IsOwner)
+	repo.AssertExpectations(t)
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+}
+
+func TestSavedViewCreate_DuplicateName(t *testing.T) {
+	db, sqlMock := testutil.NewMockDB(t)
+	repo := &mocks.MockRepositorySavedView{}
+	svc := serviceSavedView.NewServiceSavedViewImpl(db, repo)
+
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectRollback()
+	repo.On("FindByName", mock.Anything, mock.AnythingOfType("*sql.Tx"), 7, "Offices").Return(models.SavedView{Id: 3, Name: "offices"}, nil)
+
+	assert.PanicsWithValue(t, exceptions.NewBadRequest("you already have a saved view named offices"), func() {
+		svc.Create(userContext("7"), webSavedView.CreateSavedViewRequest{Name: "Offices"})
+	})
+	repo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestSavedViewCreate_PrivateByDefault(t *testing.T) {
+	db, sqlMock := testutil.NewMockDB(t)
+	repo := &mocks.MockRepositorySavedView{}
+	svc := serviceSavedView.NewServiceSavedViewImpl(db, repo)
+
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectCommit()
+	repo.On("FindByName", mock.Anything, mock.AnythingOfType("*sql.Tx"), 7, "Offices").Return(models.SavedView{}, sql.ErrNoRows)
+	repo.On("Create", mock.Anything, mock.AnythingOfType("*sql.Tx"), mock.MatchedBy(func(v models.SavedView) bool {
+		return v.Visibility == models.VisibilityPrivate
+	})).Return(models.SavedView{Id: 1, Name: "Offices", CreatedBy: 7, Visibility: models.VisibilityPrivate}, nil)
+
+	resp := svc.Create(userContext("7"), webSavedView.CreateSavedViewRequest{Name: "Offices"})
+
+	assert.Equal(t, models.VisibilityPrivate, resp.Visibility)
+	repo.AssertExpectations(t)
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+}
+
+// --- FindAll ---
+
+func TestSavedViewFindAll_ScopesToCurrentUser(t *testing.T) {
+	db, sqlMock := testutil.NewMockDB(t)
+	repo := &mocks.MockRepositorySavedView{}
+	svc := serviceSavedView.NewServiceSavedViewImpl(db, repo)
+
+	filter := repositoriesSavedView.SavedViewFilter{UserId: 7, Scope: repositoriesSavedView.ScopeShared}
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectCommit()
+	repo.On("FindAll", mock.Anything, mock.AnythingOfType("*sql.Tx"), filter, 10, 0, "updated_at", "DESC").
+		Return([]models.SavedView{{Id: 2, Name: "Team view", CreatedBy: 8, Visibility: models.VisibilityTeam}}, nil)
+	repo.On("CountAll", mock.Anything, mock.AnythingOfType("*sql.Tx"), filter).Return(1, nil)
+
+	var request webSavedView.SavedViewRequestFindAll
+	request.SetTake(10)
+	request.SetScope(repositoriesSavedView.ScopeShared)
+	list, total := svc.FindAll(userContext("7"), request)
+
+	assert.Equal(t, 1, total)
+	assert.False(t, list[0].IsOwner)
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+}
+
+// --- FindById / Update ---
+
+func TestSavedViewFindById_HidesOtherUsersPrivateViews(t *testing.T) {
+	db, sqlMock := testutil.NewMockDB(t)
+	repo := &mocks.MockRepositorySavedView{}
+	svc := serviceSavedView.NewServiceSavedViewImpl(db, repo)
+
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectRollback()
+	repo.On("FindAccess", mock.Anything, mock.AnythingOfType("*sql.Tx"), 2, 7).Return(models.Access{}, nil)
+
+	assert.PanicsWithValue(t, exceptions.NewNotFoundError("saved view not found"), func() {
+		svc.FindById(userContext("7"), 2)
+	})
+	repo.AssertNotCalled(t, "FindById", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestSavedViewUpdate_OnlyOwner(t *testing.T) {
+	db, sqlMock := testutil.NewMockDB(t)
+	repo := &mocks.MockRepositorySavedView{}
+	svc := serviceSavedView.NewServiceSavedViewImpl(db, repo)
+
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectRollback()
+	repo.On("FindAccess", mock.Anything, mock.AnythingOfType("*sql.Tx"), 2, 7).Return(models.Access{Visible: true}, nil)
+
+	assert.PanicsWithValue(t, exceptions.NewForbidden("only the owner can change a saved view"), func() {
+		svc.Update(userContext("7"), webSavedView.UpdateSavedViewRequest{Name: "Mine now"}, 2)
+	})
+	repo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything, mock.Anything)
+}
+
+// --- ApplyToMapping ---
+
+func TestSavedViewApplyToMapping_ReplacesFiltersKeepsBounds(t *testing.T) {
+	db, sqlMock := testutil.NewMockDB(t)
+	repo := &mocks.MockRepositorySavedView{}
+	svc := serviceSavedView.NewServiceSavedViewImpl(db, repo)
+
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectCommit()
+	repo.On("FindAccess", mock.Anything, mock.AnythingOfType("*sql.Tx"), 2, 7).Return(models.Access{Visible: true}, nil)
+	repo.On("FindById", mock.Anything, mock.AnythingOfType("*sql.Tx"), 2).Return(models.SavedView{Id: 2, CreatedBy: 8, Visibility: models.VisibilityTeam,
+		Filters: `{"building_type":["Office"],"poi_ids":[4]}`, MapCenterLat: floatPtr(-6.1), MapCenterLng: floatPtr(106.9), SortBy: "distance"}, nil)
+
+	bounds := &webBuilding.MappingBounds{MinLat: -6.3, MaxLat: -6.0, MinLng: 106.7, MaxLng: 107}
+	body := webBuilding.MappingByFilterRequest{
+		ViewId:  2,
+		Filters: webBuilding.ExportMappingFilters{BuildingType: []string{"Residential"}},
+		Bounds:  bounds,
+	}
+	svc.ApplyToMapping(userContext("7"), 2, &body)
+
+	assert.Equal(t, []string{"Office"}, body.Filters.BuildingType)
+	assert.Equal(t, []int{4}, body.Filters.PoiIDs)
+	assert.Equal(t, -6.1, body.MapCenter.Lat)
+	assert.Equal(t, "distance", body.SortBy)
+	assert.Equal(t, bounds, body.Bounds)
+
+	request := webBuilding.BuildMappingRequestFromBody(&body)
+	assert.Equal(t, "Office", request.GetBuildingType())
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+}
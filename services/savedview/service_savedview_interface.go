@@ -0,0 +1,18 @@
+package savedview
+
+import (
+	"context"
+
+	webBuilding "github.com/malikabdulaziz/tmn-backend/web/building"
+	webSavedView "github.com/malikabdulaziz/tmn-backend/web/savedview"
+)
+
+type ServiceSavedViewInterface interface {
+	Create(ctx context.Context, request webSavedView.CreateSavedViewRequest) webSavedView.SavedViewResponse
+	FindAll(ctx context.Context, request webSavedView.SavedViewRequestFindAll) ([]webSavedView.SavedViewResponse, int)
+	FindById(ctx context.Context, id int) webSavedView.SavedViewResponse
+	Update(ctx context.Context, request webSavedView.UpdateSavedViewRequest, id int) webSavedView.SavedViewResponse
+	Delete(ctx context.Context, id int)
+	ApplyToMapping(ctx context.Context, id int, body *webBuilding.MappingByFilterRequest)
+	ApplyToExport(ctx context.Context, id int, body *webBuilding.ExportMappingByFilterRequest)
+}
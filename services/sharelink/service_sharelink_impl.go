@@ -0,0 +1,465 @@
+package sharelink
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"strconv"
+	"strings"
+
+	"github.com/malikabdulaziz/tmn-backend/exceptions"
+	"github.com/malikabdulaziz/tmn-backend/helpers"
+	"github.com/malikabdulaziz/tmn-backend/models"
+	repositoriesBuilding "github.com/malikabdulaziz/tmn-backend/repositories/building"
+	repositoriesBuildingRestriction "github.com/malikabdulaziz/tmn-backend/repositories/buildingrestriction"
+	repositoriesSalesPackage "github.com/malikabdulaziz/tmn-backend/repositories/salespackage"
+	repositoriesSavedPolygon "github.com/malikabdulaziz/tmn-backend/repositories/savedpolygon"
+	repositoriesShareLink "github.com/malikabdulaziz/tmn-backend/repositories/sharelink"
+	servicesBuilding "github.com/malikabdulaziz/tmn-backend/services/building"
+	webBuilding "github.com/malikabdulaziz/tmn-backend/web/building"
+	webShareLink "github.com/malikabdulaziz/tmn-backend/web/sharelink"
+)
+
+// DefaultMappingTitle is the title of a mapping filter link created without one
+const DefaultMappingTitle = "Shared map"
+
+type ServiceShareLinkImpl struct {
+	DB                                     *sql.DB
+	RepositoryShareLinkInterface           repositoriesShareLink.RepositoryShareLinkInterface
+	RepositorySalesPackageInterface        repositoriesSalesPackage.RepositorySalesPackageInterface
+	RepositorySavedPolygonInterface        repositoriesSavedPolygon.RepositorySavedPolygonInterface
+	RepositoryBuildingRestrictionInterface repositoriesBuildingRestriction.RepositoryBuildingRestrictionInterface
+	RepositoryBuildingInterface            repositoriesBuilding.RepositoryBuildingInterface
+	// ServiceBuildingInterface resolves the filters of mapping and polygon links into buildings
+	ServiceBuildingInterface servicesBuilding.ServiceBuildingInterface
+	Config                   Config
+}
+
+func NewServiceShareLinkImpl(
+	db *sql.DB,
+	repositoryShareLink repositoriesShareLink.RepositoryShareLinkInterface,
+	repositorySalesPackage repositoriesSalesPackage.RepositorySalesPackageInterface,
+	repositorySavedPolygon repositoriesSavedPolygon.RepositorySavedPolygonInterface,
+	repositoryBuildingRestriction repositoriesBuildingRestriction.RepositoryBuildingRestrictionInterface,
+	repositoryBuilding repositoriesBuilding.RepositoryBuildingInterface,
+	serviceBuilding servicesBuilding.ServiceBuildingInterface,
+	config Config,
+) ServiceShareLinkInterface {
+	return &ServiceShareLinkImpl{
+		DB:                                     db,
+		RepositoryShareLinkInterface:           repositoryShareLink,
+		RepositorySalesPackageInterface:        repositorySalesPackage,
+		RepositorySavedPolygonInterface:        repositorySavedPolygon,
+		RepositoryBuildingRestrictionInterface: repositoryBuildingRestriction,
+		RepositoryBuildingInterface:            repositoryBuilding,
+		ServiceBuildingInterface:               serviceBuilding,
+		Config:                                 config,
+	}
+}
+
+// Create stores a share link for a sales package version, a saved polygon or mapping filters. The
+// creator must be able to see everything the link shares, including the sales packages and
+// building restrictions its filters refer to.
+func (s *ServiceShareLinkImpl) Create(ctx context.Context, request webShareLink.CreateShareLinkRequest) webShareLink.ShareLinkResponse {
+	days := request.ExpiresInDays
+	if days == 0 {
+		days = webShareLink.DefaultShareLinkDays
+	}
+	if days < 1 || days > webShareLink.MaxShareLinkDays {
+		panic(exceptions.NewBadRequest("expires_in_days must be between 1 and 90"))
+	}
+
+	tx, err := s.DB.Begin()
+	helpers.PanicIfError(err)
+	defer helpers.CommitOrRollback(tx)
+
+	link := models.ShareLink{TargetType: request.TargetType}
+	title := ""
+	switch request.TargetType {
+	case models.ShareLinkTargetSalesPackage:
+		if request.SalesPackageId <= 0 {
+			panic(exceptions.NewBadRequest("sales_package_id is required for sales package links"))
+		}
+		access, err := s.RepositorySalesPackageInterface.FindAccess(ctx, tx, request.SalesPackageId, helpers.UserIdFromContext(ctx))
+		requireVisible(access, err, "sales package not found")
+		version, err := s.RepositorySalesPackageInterface.FindVersion(ctx, tx, request.SalesPackageId, request.SalesPackageVersion)
+		if err == sql.ErrNoRows {
+			if request.SalesPackageVersion > 0 {
+				panic(exceptions.NewNotFoundError("sales package version not found"))
+			}
+			panic(exceptions.NewNotFoundError("sales package not found"))
+		}
+		helpers.PanicIfError(err)
+		link.SalesPackageId = &version.SalesPackageId
+		link.SalesPackageVersionId = &version.Id
+		title = version.Name
+	case models.ShareLinkTargetSavedPolygon:
+		if request.SavedPolygonId <= 0 {
+			panic(exceptions.NewBadRequest("saved_polygon_id is required for saved polygon links"))
+		}
+		access, err := s.RepositorySavedPolygonInterface.FindAccess(ctx, tx, request.SavedPolygonId, helpers.UserIdFromContext(ctx))
+		requireVisible(access, err, "saved polygon not found")
+		polygon, err := s.RepositorySavedPolygonInterface.FindById(ctx, tx, request.SavedPolygonId)
+		helpers.PanicIfError(err)
+		if len(polygon.Points) < 3 {
+			panic(exceptions.NewBadRequest("saved polygon needs at least 3 points"))
+		}
+		link.SavedPolygonId = &polygon.Id
+		title = polygon.Name
+	case models.ShareLinkTargetMappingFilter:
+		if request.Filters == nil {
+			panic(exceptions.NewBadRequest("filters are required for mapping filter links"))
+		}
+		s.requireVisibleFilters(ctx, tx, *request.Filters)
+		filter, err := json.Marshal(request.Filters)
+		helpers.PanicIfError(err)
+		link.Filter = string(filter)
+		title = DefaultMappingTitle
+	default:
+		panic(exceptions.NewBadRequest("target_type must be sales_package, mapping_filter or saved_polygon"))
+	}
+	if t := strings.TrimSpace(request.Title); t != "" {
+		title = t
+	}
+	link.Title = title
+
+	link.Key, err = helpers.GenerateToken(16)
+	helpers.PanicIfError(err)
+	if request.Password != "" {
+		link.PasswordHash, err = helpers.HashPassword(request.Password)
+		helpers.PanicIfError(err)
+	}
+	if userId := helpers.UserIdFromContext(ctx); userId > 0 {
+		link.CreatedBy = &userId
+	}
+
+	created, err := s.RepositoryShareLinkInterface.Create(ctx, tx, link, days)
+	helpers.PanicIfError(err)
+	return s.toResponse(created)
+}
+
+// requireVisible panics with message as a not found error unless the FindAccess result says the
+// current user may see the object, so users only share what they can see themselves
+func requireVisible(access models.Access, err error, message string) {
+	if err == sql.ErrNoRows || (err == nil && !access.Visible) {
+		panic(exceptions.NewNotFoundError(message))
+	}
+	helpers.PanicIfError(err)
+}
+
+// requireVisibleFilters panics with a not found error when mapping filters refer to a sales package
+// or building restriction the current user cannot see
+func (s *ServiceShareLinkImpl) requireVisibleFilters(ctx context.Context, tx *sql.Tx, filters webBuilding.ExportMappingFilters) {
+	userId := helpers.UserIdFromContext(ctx)
+	for _, id := range filters.SalesPackageIds {
+		access, err := s.RepositorySalesPackageInterface.FindAccess(ctx, tx, id, userId)
+		requireVisible(access, err, "sales package "+strconv.Itoa(id)+" not found")
+	}
+	for _, id := range filters.BuildingRestrictionIds {
+		access, err := s.RepositoryBuildingRestrictionInterface.FindAccess(ctx, tx, id, userId)
+		requireVisible(access, err, "building restriction "+strconv.Itoa(id)+" not found")
+	}
+}
+
+// FindAll lists the share links the current user created, revoked and expired ones included.
+// Links carry their signed token, so other users' links are never listed.
+func (s *ServiceShareLinkImpl) FindAll(ctx context.Context, request webShareLink.ShareLinkRequestFindAll) ([]webShareLink.ShareLinkResponse, int) {
+	userId := helpers.UserIdFromContext(ctx)
+	if userId == 0 {
+		panic(exceptions.NewUnAuthorized("unauthorized"))
+	}
+
+	tx, err := s.DB.Begin()
+	helpers.PanicIfError(err)
+	defer helpers.CommitOrRollback(tx)
+
+	filter := repositoriesShareLink.ShareLinkFilter{
+		CreatedBy:      userId,
+		TargetType:     request.GetTargetType(),
+		SalesPackageId: request.GetSalesPackageId(),
+		SavedPolygonId: request.GetSavedPolygonId(),
+	}
+	links, err := s.RepositoryShareLinkInterface.FindAll(ctx, tx, filter, request.GetTake(), request.GetSkip(), request.GetOrderBy(), request.GetOrderDirection())
+	helpers.PanicIfError(err)
+	total, err := s.RepositoryShareLinkInterface.CountAll(ctx, tx, filter)
+	helpers.PanicIfError(err)
+
+	responses := make([]webShareLink.ShareLinkResponse, len(links))
+	for i, link := range links {
+		responses[i] = s.toResponse(link)
+	}
+	return responses, total
+}
+
+// Revoke stops a share link from being opened again. Only the user who created the link, or one
+// who may change the sales package or saved polygon it shares, may revoke it.
+func (s *ServiceShareLinkImpl) Revoke(ctx context.Context, id int) webShareLink.ShareLinkResponse {
+	tx, err := s.DB.Begin()
+	helpers.PanicIfError(err)
+	defer helpers.CommitOrRollback(tx)
+
+	existing, err := s.RepositoryShareLinkInterface.FindById(ctx, tx, id)
+	if err == sql.ErrNoRows {
+		panic(exceptions.NewNotFoundError("share link not found"))
+	}
+	helpers.PanicIfError(err)
+	if !s.canRevoke(ctx, tx, existing) {
+		panic(exceptions.NewForbidden("only the creator of a share link or the owner of what it shares can revoke it"))
+	}
+	helpers.PanicIfError(s.RepositoryShareLinkInterface.Revoke(ctx, tx, id))
+	link, err := s.RepositoryShareLinkInterface.FindById(ctx, tx, id)
+	helpers.PanicIfError(err)
+	return s.toResponse(link)
+}
+
+// canRevoke tells whether the current user created link or may change the object it shares
+func (s *ServiceShareLinkImpl) canRevoke(ctx context.Context, tx *sql.Tx, link models.ShareLink) bool {
+	userId := helpers.UserIdFromContext(ctx)
+	if link.CreatedBy != nil && *link.CreatedBy == userId {
+		return true
+	}
+	var access models.Access
+	var err error
+	switch {
+	case link.SalesPackageId != nil:
+		access, err = s.RepositorySalesPackageInterface.FindAccess(ctx, tx, *link.SalesPackageId, userId)
+	case link.SavedPolygonId != nil:
+		access, err = s.RepositorySavedPolygonInterface.FindAccess(ctx, tx, *link.SavedPolygonId, userId)
+	default:
+		return false
+	}
+	if err == sql.ErrNoRows {
+		return false
+	}
+	helpers.PanicIfError(err)
+	return access.Editable
+}
+
+// View opens a share link without login and counts the view. Tampered tokens and unknown keys
+// are reported as not found; password protected links need the password. Filters are resolved as
+// the link's creator, so packages or restrictions hidden from them since are ignored.
+func (s *ServiceShareLinkImpl) View(ctx context.Context, token string, password string) webShareLink.SharedViewResponse {
+	link, polygon := s.open(ctx, token, password)
+	resp := webShareLink.SharedViewResponse{
+		Title:      link.Title,
+		TargetType: link.TargetType,
+		ExpiresAt:  link.ExpiresAt,
+		Buildings:  []webShareLink.SharedBuildingResponse{},
+	}
+
+	switch link.TargetType {
+	case models.ShareLinkTargetSalesPackage:
+		s.viewSalesPackage(ctx, link, &resp)
+	default:
+		var filters webBuilding.ExportMappingFilters
+		if link.TargetType == models.ShareLinkTargetSavedPolygon {
+			for _, p := range polygon.Points {
+				filters.Polygon = append(filters.Polygon, struct {
+					Lat float64 `json:"lat"`
+					Lng float64 `json:"lng"`
+				}{Lat: p.Lat, Lng: p.Lng})
+				resp.Polygon = append(resp.Polygon, webShareLink.SharedPointResponse{Lat: p.Lat, Lng: p.Lng})
+			}
+		} else {
+			helpers.PanicIfError(json.Unmarshal([]byte(link.Filter), &filters))
+		}
+		mapping := s.ServiceBuildingInterface.FindAllForMapping(asCreator(ctx, link), webBuilding.BuildMappingRequestFromExportBody(&webBuilding.ExportMappingByFilterRequest{
+			Filters: filters,
+		}))
+		ids := make([]int, len(mapping.Data))
+		for i, b := range mapping.Data {
+			ids[i] = b.Id
+		}
+		s.viewBuildings(ctx, ids, nil, &resp)
+	}
+	resp.BuildingCount = len(resp.Buildings)
+	return resp
+}
+
+// asCreator returns ctx acting as the user who created link, so its filters only match the sales
+// packages and building restrictions that user can still see. Links without a creator see only
+// public ones.
+func asCreator(ctx context.Context, link models.ShareLink) context.Context {
+	userId := ""
+	if link.CreatedBy != nil {
+		userId = strconv.Itoa(*link.CreatedBy)
+	}
+	return context.WithValue(ctx, helpers.ContextKey("userId"), userId)
+}
+
+// open checks a token and password, counts the view and loads the polygon of polygon links
+func (s *ServiceShareLinkImpl) open(ctx context.Context, token string, password string) (models.ShareLink, models.SavedPolygon) {
+	key, ok := s.Config.Verify(token)
+	if !ok {
+		panic(exceptions.NewNotFoundError("share link not found"))
+	}
+
+	tx, err := s.DB.Begin()
+	helpers.PanicIfError(err)
+	defer helpers.CommitOrRollback(tx)
+
+	link, err := s.RepositoryShareLinkInterface.FindByKey(ctx, tx, key)
+	if err == sql.ErrNoRows {
+		panic(exceptions.NewNotFoundError("share link not found"))
+	}
+	helpers.PanicIfError(err)
+	if link.RevokedAt != "" {
+		panic(exceptions.NewNotFoundError("share link has been revoked"))
+	}
+	if link.Expired {
+		panic(exceptions.NewNotFoundError("share link has expired"))
+	}
+	if link.PasswordHash != "" {
+		if password == "" {
+			panic(exceptions.NewUnAuthorized("share link requires a password"))
+		}
+		if !helpers.CheckPassword(password, link.PasswordHash) {
+			panic(exceptions.NewUnAuthorized("invalid share link password"))
+		}
+	}
+	helpers.PanicIfError(s.RepositoryShareLinkInterface.RecordView(ctx, tx, link.Id))
+
+	var polygon models.SavedPolygon
+	if link.TargetType == models.ShareLinkTargetSavedPolygon && link.SavedPolygonId != nil {
+		polygon, err = s.RepositorySavedPolygonInterface.FindById(ctx, tx, *link.SavedPolygonId)
+		if err == sql.ErrNoRows {
+			panic(exceptions.NewNotFoundError("saved polygon not found"))
+		}
+		helpers.PanicIfError(err)
+	}
+	return link, polygon
+}
+
+// viewSalesPackage fills resp with the pinned version of a sales package and its building figures
+func (s *ServiceShareLinkImpl) viewSalesPackage(ctx context.Context, link models.ShareLink, resp *webShareLink.SharedViewResponse) {
+	version, rows := s.findVersion(ctx, link)
+	resp.SalesPackage = &webShareLink.SharedPackageResponse{
+		Name:            version.Name,
+		Version:         version.Version,
+		BuildingCount:   version.BuildingCount,
+		TotalAudience:   version.TotalAudience,
+		TotalImpression: version.TotalImpression,
+		TotalScreens:    version.TotalScreens,
+	}
+	figures := make(map[int]repositoriesSalesPackage.SalesPackageSummaryRow, len(rows))
+	ids := make([]int, len(rows))
+	for i, row := range rows {
+		figures[row.BuildingId] = row
+		ids[i] = row.BuildingId
+	}
+	s.viewBuildings(ctx, ids, figures, resp)
+}
+
+// findVersion loads the sales package version a link is pinned to with its building figures
+func (s *ServiceShareLinkImpl) findVersion(ctx context.Context, link models.ShareLink) (models.SalesPackageVersion, []repositoriesSalesPackage.SalesPackageSummaryRow) {
+	if link.SalesPackageId == nil {
+		panic(exceptions.NewNotFoundError("sales package version not found"))
+	}
+
+	tx, err := s.DB.Begin()
+	helpers.PanicIfError(err)
+	defer helpers.CommitOrRollback(tx)
+
+	version, err := s.RepositorySalesPackageInterface.FindVersion(ctx, tx, *link.SalesPackageId, link.SalesPackageVersion)
+	if err == sql.ErrNoRows {
+		panic(exceptions.NewNotFoundError("sales package version not found"))
+	}
+	helpers.PanicIfError(err)
+	rows, err := s.RepositorySalesPackageInterface.FindVersionSummaryRows(ctx, tx, version.Id)
+	helpers.PanicIfError(err)
+	return version, rows
+}
+
+// viewBuildings adds the public view of the buildings, in ids order, and the bounds around them.
+// Figures, when given, replace the building's own audience and impression.
+func (s *ServiceShareLinkImpl) viewBuildings(ctx context.Context, ids []int, figures map[int]repositoriesSalesPackage.SalesPackageSummaryRow, resp *webShareLink.SharedViewResponse) {
+	if len(ids) == 0 {
+		return
+	}
+	found := s.findBuildings(ctx, ids)
+	byId := make(map[int]models.Building, len(found))
+	for _, b := range found {
+		byId[b.Id] = b
+	}
+	for _, id := range ids {
+		b, ok := byId[id]
+		if !ok {
+			continue
+		}
+		building := webShareLink.SharedBuildingResponse{
+			Name:           b.Name,
+			ProjectName:    b.ProjectName,
+			BuildingType:   b.BuildingType,
+			GradeResource:  b.GradeResource,
+			Subdistrict:    b.Subdistrict,
+			Citytown:       b.Citytown,
+			Province:       b.Province,
+			CompletionYear: b.CompletionYear,
+			Latitude:       b.Latitude,
+			Longitude:      b.Longitude,
+			Audience:       b.Audience,
+			Impression:     b.Impression,
+		}
+		if row, ok := figures[id]; ok {
+			building.Audience = row.Audience
+			building.Impression = row.Impression
+			building.Screens = row.Screens
+		}
+		resp.Buildings = append(resp.Buildings, building)
+		extendBounds(resp, b.Latitude, b.Longitude)
+	}
+}
+
+func (s *ServiceShareLinkImpl) findBuildings(ctx context.Context, ids []int) []models.Building {
+	tx, err := s.DB.Begin()
+	helpers.PanicIfError(err)
+	defer helpers.CommitOrRollback(tx)
+
+	found, err := s.RepositoryBuildingInterface.FindByIds(ctx, tx, ids)
+	helpers.PanicIfError(err)
+	return found
+}
+
+// extendBounds grows the response bounds to cover a located building
+func extendBounds(resp *webShareLink.SharedViewResponse, lat float64, lng float64) {
+	if lat == 0 && lng == 0 {
+		return
+	}
+	if resp.Bounds == nil {
+		resp.Bounds = &webShareLink.SharedBoundsResponse{MinLat: lat, MaxLat: lat, MinLng: lng, MaxLng: lng}
+		return
+	}
+	resp.Bounds.MinLat = min(resp.Bounds.MinLat, lat)
+	resp.Bounds.MaxLat = max(resp.Bounds.MaxLat, lat)
+	resp.Bounds.MinLng = min(resp.Bounds.MinLng, lng)
+	resp.Bounds.MaxLng = max(resp.Bounds.MaxLng, lng)
+}
+
+func (s *ServiceShareLinkImpl) toResponse(link models.ShareLink) webShareLink.ShareLinkResponse {
+	token := s.Config.Sign(link.Key)
+	status := webShareLink.ShareLinkStatusActive
+	if link.RevokedAt != "" {
+		status = webShareLink.ShareLinkStatusRevoked
+	} else if link.Expired {
+		status = webShareLink.ShareLinkStatusExpired
+	}
+	return webShareLink.ShareLinkResponse{
+		Id:                    link.Id,
+		Token:                 token,
+		Path:                  "/shared/" + token,
+		TargetType:            link.TargetType,
+		SalesPackageId:        link.SalesPackageId,
+		SalesPackageVersionId: link.SalesPackageVersionId,
+		SalesPackageVersion:   link.SalesPackageVersion,
+		SavedPolygonId:        link.SavedPolygonId,
+		Title:                 link.Title,
+		HasPassword:           link.PasswordHash != "",
+		Status:                status,
+		ExpiresAt:             link.ExpiresAt,
+		RevokedAt:             link.RevokedAt,
+		ViewCount:             link.ViewCount,
+		LastViewedAt:          link.LastViewedAt,
+		CreatedBy:             link.CreatedBy,
+		CreatedAt:             link.CreatedAt,
+	}
+}
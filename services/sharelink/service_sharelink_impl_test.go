@@ -0,0 +1,457 @@
+package sharelink_test
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/malikabdulaziz/tmn-backend/exceptions"
+	"github.com/malikabdulaziz/tmn-backend/helpers"
+	"github.com/malikabdulaziz/tmn-backend/models"
+	repositoriesSalesPackage "github.com/malikabdulaziz/tmn-backend/repositories/salespackage"
+	repositoriesShareLink "github.com/malikabdulaziz/tmn-backend/repositories/sharelink"
+	serviceBuilding "github.com/malikabdulaziz/tmn-backend/services/building"
+	serviceShareLink "github.com/malikabdulaziz/tmn-backend/services/sharelink"
+	"github.com/malikabdulaziz/tmn-backend/testutil"
+	"github.com/malikabdulaziz/tmn-backend/testutil/mocks"
+	webBuilding "github.com/malikabdulaziz/tmn-backend/web/building"
+	webShareLink "github.com/malikabdulaziz/tmn-backend/web/sharelink"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var testConfig = serviceShareLink.NewConfig("test-secret")
+
+type shareLinkMocks struct {
+	repoLink        *mocks.MockRepositoryShareLink
+	repoPkg         *mocks.MockRepositorySalesPackage
+	repoPolygon     *mocks.MockRepositorySavedPolygon
+	repoRestriction *mocks.MockRepositoryBuildingRestriction
+	repoBuilding    *mocks.MockRepositoryBuilding
+}
+
+func newShareLinkService(db *sql.DB) (serviceShareLink.ServiceShareLinkInterface, shareLinkMocks) {
+	m := shareLinkMocks{
+		repoLink:        &mocks.MockRepositoryShareLink{},
+		repoPkg:         &mocks.MockRepositorySalesPackage{},
+		repoPolygon:     &mocks.MockRepositorySavedPolygon{},
+		repoRestriction: &mocks.MockRepositoryBuildingRestriction{},
+		repoBuilding:    &mocks.MockRepositoryBuilding{},
+	}
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+	svcBuilding := serviceBuilding.NewServiceBuildingImpl(db, m.repoBuilding, &mocks.MockRepositoryPOI{}, nil, logger)
+	svc := serviceShareLink.NewServiceShareLinkImpl(db, m.repoLink, m.repoPkg, m.repoPolygon, m.repoRestriction, m.repoBuilding, svcBuilding, testConfig)
+	return svc, m
+}
+
+func intPtr(v int) *int {
+	return &v
+}
+
+// expectOpen makes the token lookup find link and count a view
+func expectOpen(sqlMock sqlmock.Sqlmock, m shareLinkMocks, link models.ShareLink) {
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectCommit()
+	m.repoLink.On("FindByKey", mock.Anything, mock.AnythingOfType("*sql.Tx"), link.Key).Return(link, nil).Once()
+	m.repoLink.On("RecordView", mock.Anything, mock.AnythingOfType("*sql.Tx"), link.Id).Return(nil).Once()
+}
+
+// --- Signing ---
+
+func TestShareLinkConfig_VerifyRejectsTamperedTokens(t *testing.T) {
+	token := testConfig.Sign("abc123")
+	key, ok := testConfig.Verify(token)
+	assert.True(t, ok)
+	assert.Equal(t, "abc123", key)
+
+	_, ok = testConfig.Verify("abc124" + token[len("abc123"):])
+	assert.False(t, ok)
+	_, ok = serviceShareLink.NewConfig("other-secret").Verify(token)
+	assert.False(t, ok)
+	_, ok = testConfig.Verify("abc123")
+	assert.False(t, ok)
+}
+
+// --- Create ---
+
+func TestShareLinkCreate_SalesPackagePinsLatestVersion(t *testing.T) {
+	db, sqlMock := testutil.NewMockDB(t)
+	svc, m := newShareLinkService(db)
+
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectCommit()
+	m.repoPkg.On("FindAccess", mock.Anything, mock.AnythingOfType("*sql.Tx"), 3, 9).Return(models.Access{Visible: true}, nil)
+	m.repoPkg.On("FindVersion", mock.Anything, mock.AnythingOfType("*sql.Tx"), 3, 0).
+		Return(models.SalesPackageVersion{Id: 30, SalesPackageId: 3, Version: 4, Name: "Jakarta CBD"}, nil)
+	m.repoLink.On("Create", mock.Anything, mock.AnythingOfType("*sql.Tx"), mock.MatchedBy(func(link models.ShareLink) bool {
+		return link.TargetType == models.ShareLinkTargetSalesPackage && *link.SalesPackageId == 3 && *link.SalesPackageVersionId == 30 &&
+			link.Title == "Jakarta CBD" && link.Key != "" && link.PasswordHash != "" && helpers.CheckPassword("s3cret", link.PasswordHash) &&
+			*link.CreatedBy == 9
+	}), webShareLink.DefaultShareLinkDays).
+		Return(models.ShareLink{Id: 1, Key: "abc", TargetType: models.ShareLinkTargetSalesPackage, Title: "Jakarta CBD", PasswordHash: "hash"}, nil)
+
+	ctx := context.WithValue(context.Background(), helpers.ContextKey("userId"), "9")
+	resp := svc.Create(ctx, webShareLink.CreateShareLinkRequest{
+		TargetType:     models.ShareLinkTargetSalesPackage,
+		SalesPackageId: 3,
+		Password:       "s3cret",
+	})
+
+	key, ok := testConfig.Verify(resp.Token)
+	assert.True(t, ok)
+	assert.Equal(t, "abc", key)
+	assert.Equal(t, "/shared/"+resp.Token, resp.Path)
+	assert.True(t, resp.HasPassword)
+	assert.Equal(t, webShareLink.ShareLinkStatusActive, resp.Status)
+	m.repoLink.AssertExpectations(t)
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+}
+
+func TestShareLinkCreate_HiddenSavedPolygon(t *testing.T) {
+	db, sqlMock := testutil.NewMockDB(t)
+	svc, m := newShareLinkService(db)
+
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectRollback()
+	m.repoPolygon.On("FindAccess", mock.Anything, mock.AnythingOfType("*sql.Tx"), 4, 9).Return(models.Access{}, nil)
+
+	ctx := context.WithValue(context.Background(), helpers.ContextKey("userId"), "9")
+	assert.PanicsWithValue(t, exceptions.NewNotFoundError("saved polygon not found"), func() {
+		svc.Create(ctx, webShareLink.CreateShareLinkRequest{TargetType: models.ShareLinkTargetSavedPolygon, SavedPolygonId: 4})
+	})
+	m.repoLink.AssertNotCalled(t, "Create", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestShareLinkCreate_MappingFilterRequiresFilters(t *testing.T) {
+	db, sqlMock := testutil.NewMockDB(t)
+	svc, _ := newShareLinkService(db)
+
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectRollback()
+	assert.PanicsWithValue(t, exceptions.NewBadRequest("filters are required for mapping filter links"), func() {
+		svc.Create(context.Background(), webShareLink.CreateShareLinkRequest{TargetType: models.ShareLinkTargetMappingFilter})
+	})
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+}
+
+func TestShareLinkCreate_MappingFilterHiddenSalesPackage(t *testing.T) {
+	db, sqlMock := testutil.NewMockDB(t)
+	svc, m := newShareLinkService(db)
+
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectRollback()
+	m.repoPkg.On("FindAccess", mock.Anything, mock.AnythingOfType("*sql.Tx"), 3, 9).Return(models.Access{Visible: true}, nil)
+	m.repoPkg.On("FindAccess", mock.Anything, mock.AnythingOfType("*sql.Tx"), 12, 9).Return(models.Access{}, nil)
+
+	ctx := context.WithValue(context.Background(), helpers.ContextKey("userId"), "9")
+	assert.PanicsWithValue(t, exceptions.NewNotFoundError("sales package 12 not found"), func() {
+		svc.Create(ctx, webShareLink.CreateShareLinkRequest{
+			TargetType: models.ShareLinkTargetMappingFilter,
+			Filters:    &webBuilding.ExportMappingFilters{SalesPackageIds: []int{3, 12}},
+		})
+	})
+	m.repoLink.AssertNotCalled(t, "Create", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+}
+
+func TestShareLinkCreate_MappingFilterHiddenRestriction(t *testing.T) {
+	db, sqlMock := testutil.NewMockDB(t)
+	svc, m := newShareLinkService(db)
+
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectRollback()
+	m.repoRestriction.On("FindAccess", mock.Anything, mock.AnythingOfType("*sql.Tx"), 5, 9).Return(models.Access{}, sql.ErrNoRows)
+
+	ctx := context.WithValue(context.Background(), helpers.ContextKey("userId"), "9")
+	assert.PanicsWithValue(t, exceptions.NewNotFoundError("building restriction 5 not found"), func() {
+		svc.Create(ctx, webShareLink.CreateShareLinkRequest{
+			TargetType: models.ShareLinkTargetMappingFilter,
+			Filters:    &webBuilding.ExportMappingFilters{BuildingRestrictionIds: []int{5}},
+		})
+	})
+	m.repoLink.AssertNotCalled(t, "Create", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+}
+
+func TestShareLinkCreate_RejectsLongExpiry(t *testing.T) {
+	db, _ := testutil.NewMockDB(t)
+	svc, _ := newShareLinkService(db)
+
+	assert.PanicsWithValue(t, exceptions.NewBadRequest("expires_in_days must be between 1 and 90"), func() {
+		svc.Create(context.Background(), webShareLink.CreateShareLinkRequest{TargetType: models.ShareLinkTargetMappingFilter, ExpiresInDays: 91})
+	})
+}
+
+// --- FindAll ---
+
+func TestShareLinkFindAll_ListsOnlyOwnLinks(t *testing.T) {
+	db, sqlMock := testutil.NewMockDB(t)
+	svc, m := newShareLinkService(db)
+
+	filter := repositoriesShareLink.ShareLinkFilter{CreatedBy: 9, SalesPackageId: 3}
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectCommit()
+	m.repoLink.On("FindAll", mock.Anything, mock.AnythingOfType("*sql.Tx"), filter, 10, 0, mock.Anything, mock.Anything).
+		Return([]models.ShareLink{{Id: 1, Key: "abc", TargetType: models.ShareLinkTargetSalesPackage, CreatedBy: intPtr(9)}}, nil)
+	m.repoLink.On("CountAll", mock.Anything, mock.AnythingOfType("*sql.Tx"), filter).Return(1, nil)
+
+	var request webShareLink.ShareLinkRequestFindAll
+	request.SetTake(10)
+	request.SetSalesPackageId(3)
+	ctx := context.WithValue(context.Background(), helpers.ContextKey("userId"), "9")
+	list, total := svc.FindAll(ctx, request)
+
+	assert.Equal(t, 1, total)
+	assert.Len(t, list, 1)
+	m.repoLink.AssertExpectations(t)
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+}
+
+// --- Revoke ---
+
+func TestShareLinkRevoke_PackageOwnerMayRevoke(t *testing.T) {
+	db, sqlMock := testutil.NewMockDB(t)
+	svc, m := newShareLinkService(db)
+
+	link := models.ShareLink{Id: 5, Key: "abc", TargetType: models.ShareLinkTargetSalesPackage, SalesPackageId: intPtr(3), CreatedBy: intPtr(8)}
+	revoked := link
+	revoked.RevokedAt = "2026-10-19T08:00:00Z"
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectCommit()
+	m.repoLink.On("FindById", mock.Anything, mock.AnythingOfType("*sql.Tx"), 5).Return(link, nil).Once()
+	m.repoPkg.On("FindAccess", mock.Anything, mock.AnythingOfType("*sql.Tx"), 3, 9).Return(models.Access{Visible: true, Editable: true}, nil)
+	m.repoLink.On("Revoke", mock.Anything, mock.AnythingOfType("*sql.Tx"), 5).Return(nil)
+	m.repoLink.On("FindById", mock.Anything, mock.AnythingOfType("*sql.Tx"), 5).Return(revoked, nil).Once()
+
+	ctx := context.WithValue(context.Background(), helpers.ContextKey("userId"), "9")
+	resp := svc.Revoke(ctx, 5)
+
+	assert.Equal(t, webShareLink.ShareLinkStatusRevoked, resp.Status)
+	m.repoLink.AssertExpectations(t)
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+}
+
+func TestShareLinkRevoke_OtherUsersLink(t *testing.T) {
+	db, sqlMock := testutil.NewMockDB(t)
+	svc, m := newShareLinkService(db)
+
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectRollback()
+	m.repoLink.On("FindById", mock.Anything, mock.AnythingOfType("*sql.Tx"), 5).
+		Return(models.ShareLink{Id: 5, Key: "abc", TargetType: models.ShareLinkTargetSalesPackage, SalesPackageId: intPtr(3), CreatedBy: intPtr(8)}, nil)
+	m.repoPkg.On("FindAccess", mock.Anything, mock.AnythingOfType("*sql.Tx"), 3, 9).Return(models.Access{Visible: true}, nil)
+
+	ctx := context.WithValue(context.Background(), helpers.ContextKey("userId"), "9")
+	assert.PanicsWithValue(t, exceptions.NewForbidden("only the creator of a share link or the owner of what it shares can revoke it"), func() {
+		svc.Revoke(ctx, 5)
+	})
+	m.repoLink.AssertNotCalled(t, "Revoke", mock.Anything, mock.Anything, mock.Anything)
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+}
+
+func TestShareLinkRevoke_NotFound(t *testing.T) {
+	db, sqlMock := testutil.NewMockDB(t)
+	svc, m := newShareLinkService(db)
+
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectRollback()
+	m.repoLink.On("FindById", mock.Anything, mock.AnythingOfType("*sql.Tx"), 5).Return(models.ShareLink{}, sql.ErrNoRows)
+
+	assert.PanicsWithValue(t, exceptions.NewNotFoundError("share link not found"), func() {
+		svc.Revoke(context.Background(), 5)
+	})
+	m.repoLink.AssertNotCalled(t, "Revoke", mock.Anything, mock.Anything, mock.Anything)
+}
+
+// --- View ---
+
+func TestShareLinkView_TamperedTokenNotFound(t *testing.T) {
+	db, _ := testutil.NewMockDB(t)
+	svc, m := newShareLinkService(db)
+
+	assert.PanicsWithValue(t, exceptions.NewNotFoundError("share link not found"), func() {
+		svc.View(context.Background(), "abc.0000", "")
+	})
+	m.repoLink.AssertNotCalled(t, "FindByKey", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestShareLinkView_RevokedAndExpired(t *testing.T) {
+	cases := []struct {
+		name string
+		link models.ShareLink
+		want string
+	}{
+		{"revoked", models.ShareLink{Id: 1, Key: "abc", RevokedAt: "2026-01-01T00:00:00Z"}, "share link has been revoked"},
+		{"expired", models.ShareLink{Id: 1, Key: "abc", Expired: true}, "share link has expired"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, sqlMock := testutil.NewMockDB(t)
+			svc, m := newShareLinkService(db)
+
+			sqlMock.ExpectBegin()
+			sqlMock.ExpectRollback()
+			m.repoLink.On("FindByKey", mock.Anything, mock.AnythingOfType("*sql.Tx"), "abc").Return(tc.link, nil)
+
+			assert.PanicsWithValue(t, exceptions.NewNotFoundError(tc.want), func() {
+				svc.View(context.Background(), testConfig.Sign("abc"), "")
+			})
+			m.repoLink.AssertNotCalled(t, "RecordView", mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestShareLinkView_TrashedSavedPolygonNotFound(t *testing.T) {
+	db, sqlMock := testutil.NewMockDB(t)
+	svc, m := newShareLinkService(db)
+
+	link := models.ShareLink{Id: 1, Key: "abc", TargetType: models.ShareLinkTargetSavedPolygon, SavedPolygonId: intPtr(4)}
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectRollback()
+	m.repoLink.On("FindByKey", mock.Anything, mock.AnythingOfType("*sql.Tx"), "abc").Return(link, nil)
+	m.repoLink.On("RecordView", mock.Anything, mock.AnythingOfType("*sql.Tx"), 1).Return(nil)
+	m.repoPolygon.On("FindById", mock.Anything, mock.AnythingOfType("*sql.Tx"), 4).Return(models.SavedPolygon{}, sql.ErrNoRows)
+
+	assert.PanicsWithValue(t, exceptions.NewNotFoundError("saved polygon not found"), func() {
+		svc.View(context.Background(), testConfig.Sign("abc"), "")
+	})
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+}
+
+func TestShareLinkView_PasswordRequired(t *testing.T) {
+	hash, err := helpers.HashPassword("s3cret")
+	assert.NoError(t, err)
+	link := models.ShareLink{Id: 1, Key: "abc", PasswordHash: hash}
+
+	cases := []struct {
+		password string
+		want     string
+	}{
+		{"", "share link requires a password"},
+		{"wrong", "invalid share link password"},
+	}
+	for _, tc := range cases {
+		db, sqlMock := testutil.NewMockDB(t)
+		svc, m := newShareLinkService(db)
+
+		sqlMock.ExpectBegin()
+		sqlMock.ExpectRollback()
+		m.repoLink.On("FindByKey", mock.Anything, mock.AnythingOfType("*sql.Tx"), "abc").Return(link, nil)
+
+		assert.PanicsWithValue(t, exceptions.NewUnAuthorized(tc.want), func() {
+			svc.View(context.Background(), testConfig.Sign("abc"), tc.password)
+		})
+		m.repoLink.AssertNotCalled(t, "RecordView", mock.Anything, mock.Anything, mock.Anything)
+	}
+}
+
+func TestShareLinkView_SalesPackageHidesInternalFields(t *testing.T) {
+	db, sqlMock := testutil.NewMockDB(t)
+	svc, m := newShareLinkService(db)
+
+	link := models.ShareLink{Id: 1, Key: "abc", TargetType: models.ShareLinkTargetSalesPackage, Title: "For client",
+		SalesPackageId: intPtr(3), SalesPackageVersionId: intPtr(30), SalesPackageVersion: 2}
+	expectOpen(sqlMock, m, link)
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectCommit()
+	m.repoPkg.On("FindVersion", mock.Anything, mock.AnythingOfType("*sql.Tx"), 3, 2).
+		Return(models.SalesPackageVersion{Id: 30, SalesPackageId: 3, Version: 2, Name: "Jakarta CBD", BuildingCount: 2, TotalAudience: 300, TotalScreens: 5}, nil)
+	m.repoPkg.On("FindVersionSummaryRows", mock.Anything, mock.AnythingOfType("*sql.Tx"), 30).Return([]repositoriesSalesPackage.SalesPackageSummaryRow{
+		{BuildingId: 11, Audience: 100, Screens: 2},
+		{BuildingId: 12, Audience: 200, Screens: 3},
+	}, nil)
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectCommit()
+	first := testutil.NewBuilding(11, "Tower A")
+	first.ExternalBuildingId = "ERP-11"
+	first.CompetitorPresence = true
+	second := testutil.NewBuilding(12, "Tower B")
+	second.Latitude, second.Longitude = -6.1, 106.9
+	m.repoBuilding.On("FindByIds", mock.Anything, mock.AnythingOfType("*sql.Tx"), []int{11, 12}).Return([]models.Building{second, first}, nil)
+
+	resp := svc.View(context.Background(), testConfig.Sign("abc"), "")
+
+	assert.Equal(t, "For client", resp.Title)
+	assert.Equal(t, 2, resp.SalesPackage.Version)
+	assert.Equal(t, 300, resp.SalesPackage.TotalAudience)
+	assert.Equal(t, 2, resp.BuildingCount)
+	assert.Equal(t, "Tower A", resp.Buildings[0].Name)
+	assert.Equal(t, 100, resp.Buildings[0].Audience)
+	assert.Equal(t, 3, resp.Buildings[1].Screens)
+	assert.Equal(t, webShareLink.SharedBoundsResponse{MinLat: -6.2, MaxLat: -6.1, MinLng: 106.8, MaxLng: 106.9}, *resp.Bounds)
+
+	body, err := json.Marshal(resp)
+	assert.NoError(t, err)
+	for _, field := range []string{`"id"`, "ERP-11", "competitor", "sellable", "images"} {
+		assert.False(t, strings.Contains(string(body), field), field)
+	}
+	m.repoLink.AssertExpectations(t)
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+}
+
+// TestShareLinkView_MappingFilterRunsAsCreator verifies that the filters of a mapping link are
+// resolved with the visibility of the user who created it, whoever opens the link
+func TestShareLinkView_MappingFilterRunsAsCreator(t *testing.T) {
+	db, sqlMock := testutil.NewMockDB(t)
+	svc, m := newShareLinkService(db)
+
+	link := models.ShareLink{Id: 1, Key: "abc", TargetType: models.ShareLinkTargetMappingFilter, Title: "Map",
+		Filter: `{"sales_package_ids":[12]}`, CreatedBy: intPtr(9)}
+	expectOpen(sqlMock, m, link)
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectCommit()
+	args := make([]interface{}, 27)
+	for i := range args {
+		args[i] = mock.Anything
+	}
+	args[2] = 9
+	args[11] = "12"
+	m.repoBuilding.On("FindAllForMapping", args...).Return([]models.Building{}, nil)
+
+	resp := svc.View(context.Background(), testConfig.Sign("abc"), "")
+
+	assert.Empty(t, resp.Buildings)
+	m.repoBuilding.AssertExpectations(t)
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+}
+
+func TestShareLinkView_SavedPolygonFiltersByPoints(t *testing.T) {
+	db, sqlMock := testutil.NewMockDB(t)
+	svc, m := newShareLinkService(db)
+
+	link := models.ShareLink{Id: 1, Key: "abc", TargetType: models.ShareLinkTargetSavedPolygon, Title: "Area", SavedPolygonId: intPtr(4)}
+	expectOpen(sqlMock, m, link)
+	m.repoPolygon.On("FindById", mock.Anything, mock.AnythingOfType("*sql.Tx"), 4).Return(models.SavedPolygon{Id: 4, Points: []models.SavedPolygonPoint{
+		{Lat: -6.1, Lng: 106.7}, {Lat: -6.1, Lng: 106.9}, {Lat: -6.3, Lng: 106.8},
+	}}, nil)
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectCommit()
+	args := make([]interface{}, 27)
+	for i := range args {
+		args[i] = mock.Anything
+	}
+	args[22] = mock.MatchedBy(func(points []struct {
+		Lat float64
+		Lng float64
+	}) bool {
+		return len(points) == 3 && points[2].Lat == -6.3
+	})
+	m.repoBuilding.On("FindAllForMapping", args...).Return([]models.Building{testutil.NewBuilding(11, "Tower A")}, nil)
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectCommit()
+	m.repoBuilding.On("FindByIds", mock.Anything, mock.AnythingOfType("*sql.Tx"), []int{11}).Return([]models.Building{testutil.NewBuilding(11, "Tower A")}, nil)
+
+	resp := svc.View(context.Background(), testConfig.Sign("abc"), "")
+
+	assert.Len(t, resp.Polygon, 3)
+	assert.Nil(t, resp.SalesPackage)
+	assert.Equal(t, []webShareLink.SharedBuildingResponse{{Name: "Tower A", BuildingType: "Office", GradeResource: "A", Latitude: -6.2, Longitude: 106.8}}, resp.Buildings)
+	m.repoBuilding.AssertExpectations(t)
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+}
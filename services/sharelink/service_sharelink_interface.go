@@ -0,0 +1,14 @@
+package sharelink
+
+import (
+	"context"
+
+	webShareLink "github.com/malikabdulaziz/tmn-backend/web/sharelink"
+)
+
+type ServiceShareLinkInterface interface {
+	Create(ctx context.Context, request webShareLink.CreateShareLinkRequest) webShareLink.ShareLinkResponse
+	FindAll(ctx context.Context, request webShareLink.ShareLinkRequestFindAll) ([]webShareLink.ShareLinkResponse, int)
+	Revoke(ctx context.Context, id int) webShareLink.ShareLinkResponse
+	View(ctx context.Context, token string, password string) webShareLink.SharedViewResponse
+}
@@ -0,0 +1,37 @@
+package sharelink
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+)
+
+// Config holds the secret share link tokens are signed with
+type Config struct {
+	secret []byte
+}
+
+func NewConfig(secret string) Config {
+	return Config{secret: []byte(secret)}
+}
+
+// Sign returns the public token for a share link key: the key and its HMAC-SHA256, joined by a dot
+func (c Config) Sign(key string) string {
+	return key + "." + c.signature(key)
+}
+
+// Verify returns the key of a token whose signature matches
+func (c Config) Verify(token string) (string, bool) {
+	key, signature, found := strings.Cut(token, ".")
+	if !found || key == "" {
+		return "", false
+	}
+	return key, hmac.Equal([]byte(signature), []byte(c.signature(key)))
+}
+
+func (c Config) signature(key string) string {
+	mac := hmac.New(sha256.New, c.secret)
+	mac.Write([]byte(key))
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -1,33 +1,39 @@
 package subcategory
 
 import (
-	"bytes"
 	"context"
 	"database/sql"
-	"encoding/csv"
-	"io"
-	"strings"
+	"fmt"
 
 	"github.com/malikabdulaziz/tmn-backend/exceptions"
 	"github.com/malikabdulaziz/tmn-backend/helpers"
+	"github.com/malikabdulaziz/tmn-backend/importer"
 	"github.com/malikabdulaziz/tmn-backend/models"
+	repositoriesCategory "github.com/malikabdulaziz/tmn-backend/repositories/category"
 	repositoriesSubCategory "github.com/malikabdulaziz/tmn-backend/repositories/subcategory"
+	"github.com/malikabdulaziz/tmn-backend/web"
 	webSubCategory "github.com/malikabdulaziz/tmn-backend/web/subcategory"
 	"github.com/xuri/excelize/v2"
 )
 
+// subCategoryImportColumns maps each import column to its accepted header spellings
+var subCategoryImportColumns = map[string][]string{"name": {"name"}, "category": {"category"}}
+
 type ServiceSubCategoryImpl struct {
-	DB                               *sql.DB
+	DB                             *sql.DB
 	RepositorySubCategoryInterface repositoriesSubCategory.RepositorySubCategoryInterface
+	RepositoryCategoryInterface    repositoriesCategory.RepositoryCategoryInterface
 }
 
 func NewServiceSubCategoryImpl(
 	db *sql.DB,
 	repoSubCategory repositoriesSubCategory.RepositorySubCategoryInterface,
+	repoCategory repositoriesCategory.RepositoryCategoryInterface,
 ) ServiceSubCategoryInterface {
 	return &ServiceSubCategoryImpl{
-		DB:                               db,
+		DB:                             db,
 		RepositorySubCategoryInterface: repoSubCategory,
+		RepositoryCategoryInterface:    repoCategory,
 	}
 }
 
@@ -36,7 +42,8 @@ func (s *ServiceSubCategoryImpl) Create(ctx context.Context, request webSubCateg
 	helpers.PanicIfError(err)
 	defer helpers.CommitOrRollback(tx)
 
-	subCategory := models.SubCategory{Name: request.Name}
+	subCategory := models.SubCategory{Name: request.Name, CategoryId: request.CategoryId}
+	subCategory.CategoryName = s.findCategoryName(ctx, tx, request.CategoryId)
 	created, err := s.RepositorySubCategoryInterface.Create(ctx, tx, subCategory)
 	helpers.PanicIfError(err)
 	return subCategoryModelToResponse(created)
@@ -83,7 +90,18 @@ func (s *ServiceSubCategoryImpl) Update(ctx context.Context, request webSubCateg
 	}
 	helpers.PanicIfError(err)
 
+	// Moving a sub-category under a category must not contradict the POIs already using it
+	if request.CategoryId != nil && (existing.CategoryId == nil || *existing.CategoryId != *request.CategoryId) {
+		conflicts, err := s.RepositorySubCategoryInterface.CountPOIsOutsideCategory(ctx, tx, id, *request.CategoryId)
+		helpers.PanicIfError(err)
+		if conflicts > 0 {
+			panic(exceptions.NewBadRequest(fmt.Sprintf("Sub-Category %s is used by %d POI(s) of another category", existing.Name, conflicts)))
+		}
+	}
+
 	existing.Name = request.Name
+	existing.CategoryId = request.CategoryId
+	existing.CategoryName = s.findCategoryName(ctx, tx, request.CategoryId)
 	updated, err := s.RepositorySubCategoryInterface.Update(ctx, tx, existing)
 	helpers.PanicIfError(err)
 	return subCategoryModelToResponse(updated)
@@ -99,58 +117,92 @@ func (s *ServiceSubCategoryImpl) Delete(ctx context.Context, id int) {
 		panic(exceptions.NewNotFoundError("sub category not found"))
 	}
 	helpers.PanicIfError(err)
-	err = s.RepositorySubCategoryInterface.Delete(ctx, tx, id)
+	err = s.RepositorySubCategoryInterface.Delete(ctx, tx, id, helpers.OptionalUserIdFromContext(ctx))
 	helpers.PanicIfError(err)
 }
 
-func (s *ServiceSubCategoryImpl) Import(ctx context.Context, fileBytes []byte, fileType string) []webSubCategory.SubCategoryResponse {
+func (s *ServiceSubCategoryImpl) Import(ctx context.Context, fileBytes []byte, fileType string, onError string) ([]webSubCategory.SubCategoryResponse, web.ImportReport) {
 	tx, err := s.DB.Begin()
 	helpers.PanicIfError(err)
 	defer helpers.CommitOrRollback(tx)
 
-	var rows [][]string
-	switch strings.ToLower(fileType) {
-	case "xlsx":
-		rows, err = scParseXLSX(fileBytes)
-		helpers.PanicIfError(err)
-	case "csv":
-		rows, err = scParseCSV(fileBytes)
-		helpers.PanicIfError(err)
-	default:
-		panic(exceptions.NewBadRequestError("Unsupported file type. Use xlsx or csv."))
-	}
-
-	if len(rows) < 2 {
-		panic(exceptions.NewBadRequestError("File must contain a header row and at least one data row."))
-	}
-
-	header := rows[0]
-	colMap := scMapHeaderColumns(header)
+	sheet := importer.Read(fileBytes, fileType, subCategoryImportColumns)
+	sheet.RequireColumns("name")
 
-	if _, exists := colMap["name"]; !exists {
-		panic(exceptions.NewBadRequestError("Missing required column: name"))
+	// Categories are looked up while validating so an unknown or conflicting category rejects
+	// the row before anything is written
+	report := importer.NewReport(sheet, onError)
+	categories := map[int]models.Category{}
+	for i := range sheet.Rows {
+		if sheet.IsBlank(i) {
+			continue
+		}
+		row := importer.RowNumber(i)
+		if !report.RequireText(row, "name", 255) {
+			continue
+		}
+		categoryName := sheet.Value(i, "category")
+		if categoryName == "" {
+			continue
+		}
+		category, err := s.RepositoryCategoryInterface.FindByName(ctx, tx, categoryName)
+		if err == sql.ErrNoRows {
+			report.Reject(row, "category", "Category not found")
+			continue
+		}
+		helpers.PanicIfError(err)
+		existing, err := s.RepositorySubCategoryInterface.FindByName(ctx, tx, sheet.Value(i, "name"))
+		if err != sql.ErrNoRows {
+			helpers.PanicIfError(err)
+			if existing.CategoryId != nil && *existing.CategoryId != category.Id {
+				report.Reject(row, "category", fmt.Sprintf("Sub-category already belongs to category %s", existing.CategoryName))
+				continue
+			}
+			if existing.CategoryId == nil {
+				conflicts, err := s.RepositorySubCategoryInterface.CountPOIsOutsideCategory(ctx, tx, existing.Id, category.Id)
+				helpers.PanicIfError(err)
+				if conflicts > 0 {
+					report.Reject(row, "category", fmt.Sprintf("Sub-category is used by %d POI(s) of another category", conflicts))
+					continue
+				}
+			}
+		}
+		categories[row] = category
 	}
+	report.Check()
 
+	tracker := importer.NewTracker(ctx, len(sheet.Rows))
 	var responses []webSubCategory.SubCategoryResponse
-	for _, row := range rows[1:] {
-		name := scGetColValue(row, colMap, "name")
-		if name == "" {
+	for i := range sheet.Rows {
+		tracker.Advance(1)
+		name := sheet.Value(i, "name")
+		if sheet.IsBlank(i) || report.Rejected(importer.RowNumber(i)) {
 			continue
 		}
 
+		category, hasCategory := categories[importer.RowNumber(i)]
 		existing, err := s.RepositorySubCategoryInterface.FindByName(ctx, tx, name)
 		if err == sql.ErrNoRows {
 			subCategory := models.SubCategory{Name: name}
+			if hasCategory {
+				subCategory.CategoryId, subCategory.CategoryName = &category.Id, category.Name
+			}
 			created, err := s.RepositorySubCategoryInterface.Create(ctx, tx, subCategory)
 			helpers.PanicIfError(err)
 			responses = append(responses, subCategoryModelToResponse(created))
 		} else {
 			helpers.PanicIfError(err)
+			// A sub-category without a category is placed under the one given in the file
+			if hasCategory && existing.CategoryId == nil {
+				existing.CategoryId, existing.CategoryName = &category.Id, category.Name
+				existing, err = s.RepositorySubCategoryInterface.Update(ctx, tx, existing)
+				helpers.PanicIfError(err)
+			}
 			responses = append(responses, subCategoryModelToResponse(existing))
 		}
 	}
 
-	return responses
+	return responses, report.Result()
 }
 
 func (s *ServiceSubCategoryImpl) Export(ctx context.Context, search string) ([]byte, error) {
@@ -168,60 +220,61 @@ func (s *ServiceSubCategoryImpl) Export(ctx context.Context, search string) ([]b
 	return buildSubCategoryExcel(list)
 }
 
-func subCategoryModelToResponse(c models.SubCategory) webSubCategory.SubCategoryResponse {
-	return webSubCategory.SubCategoryResponse{
-		Id:        c.Id,
-		Name:      c.Name,
-		CreatedAt: c.CreatedAt,
-		UpdatedAt: c.UpdatedAt,
+// ImportTemplate returns a blank sub-category import xlsx whose Category column offers the
+// current categories as a dropdown
+func (s *ServiceSubCategoryImpl) ImportTemplate(ctx context.Context) ([]byte, error) {
+	tx, err := s.DB.Begin()
+	if err != nil {
+		return nil, err
 	}
-}
-
-// --- Import helpers ---
+	defer helpers.CommitOrRollback(tx)
 
-func scParseXLSX(fileBytes []byte) ([][]string, error) {
-	f, err := excelize.OpenReader(bytes.NewReader(fileBytes))
+	categories, err := s.RepositoryCategoryInterface.FindAllDropdown(ctx, tx)
 	if err != nil {
 		return nil, err
 	}
-	defer f.Close()
-	sheetName := f.GetSheetName(0)
-	return f.GetRows(sheetName)
-}
-
-func scParseCSV(fileBytes []byte) ([][]string, error) {
-	reader := csv.NewReader(bytes.NewReader(fileBytes))
-	var rows [][]string
-	for {
-		record, err := reader.Read()
-		if err == io.EOF {
-			break
-		}
-		if err != nil {
-			return nil, err
-		}
-		rows = append(rows, record)
+	categoryNames := make([]string, len(categories))
+	for i, c := range categories {
+		categoryNames[i] = c.Name
 	}
-	return rows, nil
+
+	return importer.BuildTemplate(importer.Template{
+		SheetName: "Sub-Categories",
+		Columns: []importer.TemplateColumn{
+			{Key: "name", Header: "Name", Description: "Name of the sub-category.", Required: true},
+			{Key: "category", Header: "Category", Description: "Category the sub-category belongs to. Must already exist.", Options: categoryNames, Strict: true},
+		},
+		Instructions: []string{
+			"Fill one sub-category per row on the Sub-Categories sheet and keep the header row as it is.",
+			"Names that already exist, or were merged into an existing entry, are left as they are; new names are created.",
+			"A Category places a new sub-category, or one without a category yet, under that category. A sub-category cannot be moved to another category by import.",
+		},
+	}, subCategoryImportColumns)
 }
 
-func scMapHeaderColumns(header []string) map[string]int {
-	colMap := make(map[string]int)
-	for i, h := range header {
-		normalized := strings.ToLower(strings.TrimSpace(h))
-		if normalized == "name" {
-			colMap["name"] = i
-		}
+// findCategoryName returns the name of the category a sub-category is placed under, panicking
+// with a BadRequest when it does not exist
+func (s *ServiceSubCategoryImpl) findCategoryName(ctx context.Context, tx *sql.Tx, categoryId *int) string {
+	if categoryId == nil {
+		return ""
+	}
+	category, err := s.RepositoryCategoryInterface.FindById(ctx, tx, *categoryId)
+	if err == sql.ErrNoRows {
+		panic(exceptions.NewBadRequest("Category not found"))
 	}
-	return colMap
+	helpers.PanicIfError(err)
+	return category.Name
 }
 
-func scGetColValue(row []string, colMap map[string]int, key string) string {
-	idx, exists := colMap[key]
-	if !exists || idx >= len(row) {
-		return ""
+func subCategoryModelToResponse(c models.SubCategory) webSubCategory.SubCategoryResponse {
+	return webSubCategory.SubCategoryResponse{
+		Id:           c.Id,
+		Name:         c.Name,
+		CategoryId:   c.CategoryId,
+		CategoryName: c.CategoryName,
+		CreatedAt:    c.CreatedAt,
+		UpdatedAt:    c.UpdatedAt,
 	}
-	return strings.TrimSpace(row[idx])
 }
 
 // --- Export helpers ---
@@ -230,15 +283,17 @@ func buildSubCategoryExcel(list []models.SubCategory) ([]byte, error) {
 	f := excelize.NewFile()
 	const sheet = "Sheet1"
 
-	headers := []string{"Name"}
+	headers := []string{"Name", "Category"}
 	for i, h := range headers {
 		cell, _ := excelize.CoordinatesToCellName(i+1, 1)
 		_ = f.SetCellValue(sheet, cell, h)
 	}
 
 	for rowIdx, subCategory := range list {
-		cell, _ := excelize.CoordinatesToCellName(1, rowIdx+2)
-		_ = f.SetCellValue(sheet, cell, subCategory.Name)
+		for colIdx, value := range []string{subCategory.Name, subCategory.CategoryName} {
+			cell, _ := excelize.CoordinatesToCellName(colIdx+1, rowIdx+2)
+			_ = f.SetCellValue(sheet, cell, value)
+		}
 	}
 
 	_ = f.SetSheetName(sheet, "Sub Categories")
@@ -6,6 +6,8 @@ import (
 	"testing"
 
 	"github.com/malikabdulaziz/tmn-backend/exceptions"
+	"github.com/malikabdulaziz/tmn-backend/helpers"
+	"github.com/malikabdulaziz/tmn-backend/importer"
 	"github.com/malikabdulaziz/tmn-backend/models"
 	serviceSubCategory "github.com/malikabdulaziz/tmn-backend/services/subcategory"
 	"github.com/malikabdulaziz/tmn-backend/testutil"
@@ -16,7 +18,7 @@ import (
 )
 
 func newSubCategoryService(db *sql.DB, repo *mocks.MockRepositorySubCategory) serviceSubCategory.ServiceSubCategoryInterface {
-	return serviceSubCategory.NewServiceSubCategoryImpl(db, repo)
+	return serviceSubCategory.NewServiceSubCategoryImpl(db, repo, &mocks.MockRepositoryCategory{})
 }
 
 func newSubCategoryModel(id int, name string) models.SubCategory {
@@ -49,6 +51,46 @@ func TestSubCategoryCreate_HappyPath(t *testing.T) {
 	assert.NoError(t, sqlMock.ExpectationsWereMet())
 }
 
+func TestSubCategoryCreate_UnderCategory(t *testing.T) {
+	db, sqlMock := testutil.NewMockDB(t)
+	repo := &mocks.MockRepositorySubCategory{}
+	categoryRepo := &mocks.MockRepositoryCategory{}
+	svc := serviceSubCategory.NewServiceSubCategoryImpl(db, repo, categoryRepo)
+
+	categoryId := 2
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectCommit()
+	categoryRepo.On("FindById", mock.Anything, mock.AnythingOfType("*sql.Tx"), 2).
+		Return(models.Category{Id: 2, Name: "Food & Beverage"}, nil)
+	repo.On("Create", mock.Anything, mock.AnythingOfType("*sql.Tx"),
+		mock.MatchedBy(func(c models.SubCategory) bool { return c.CategoryId != nil && *c.CategoryId == 2 }),
+	).Return(models.SubCategory{Id: 1, Name: "Coffee Shop", CategoryId: &categoryId, CategoryName: "Food & Beverage"}, nil)
+
+	response := svc.Create(context.Background(), webSubCategory.CreateSubCategoryRequest{Name: "Coffee Shop", CategoryId: &categoryId})
+
+	assert.Equal(t, &categoryId, response.CategoryId)
+	assert.Equal(t, "Food & Beverage", response.CategoryName)
+	repo.AssertExpectations(t)
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+}
+
+func TestSubCategoryCreate_UnknownCategory(t *testing.T) {
+	db, sqlMock := testutil.NewMockDB(t)
+	repo := &mocks.MockRepositorySubCategory{}
+	categoryRepo := &mocks.MockRepositoryCategory{}
+	svc := serviceSubCategory.NewServiceSubCategoryImpl(db, repo, categoryRepo)
+
+	categoryId := 404
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectRollback()
+	categoryRepo.On("FindById", mock.Anything, mock.AnythingOfType("*sql.Tx"), 404).Return(models.Category{}, sql.ErrNoRows)
+
+	assert.PanicsWithValue(t, exceptions.NewBadRequest("Category not found"), func() {
+		svc.Create(context.Background(), webSubCategory.CreateSubCategoryRequest{Name: "Coffee Shop", CategoryId: &categoryId})
+	})
+	repo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything, mock.Anything)
+}
+
 // --- FindById ---
 
 func TestSubCategoryFindById_HappyPath(t *testing.T) {
@@ -122,6 +164,28 @@ func TestSubCategoryUpdate_HappyPath(t *testing.T) {
 	assert.NoError(t, sqlMock.ExpectationsWereMet())
 }
 
+func TestSubCategoryUpdate_CategoryUsedOtherwiseByPOIs(t *testing.T) {
+	db, sqlMock := testutil.NewMockDB(t)
+	repo := &mocks.MockRepositorySubCategory{}
+	svc := newSubCategoryService(db, repo)
+
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectRollback()
+
+	repo.On("FindById", mock.Anything, mock.AnythingOfType("*sql.Tx"), 5).
+		Return(newSubCategoryModel(5, "Coffee Shop"), nil)
+	repo.On("CountPOIsOutsideCategory", mock.Anything, mock.AnythingOfType("*sql.Tx"), 5, 2).
+		Return(1, nil)
+
+	category := 2
+	assert.PanicsWithValue(t, exceptions.NewBadRequest("Sub-Category Coffee Shop is used by 1 POI(s) of another category"), func() {
+		svc.Update(context.Background(), webSubCategory.UpdateSubCategoryRequest{Name: "Coffee Shop", CategoryId: &category}, 5)
+	})
+
+	repo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything, mock.Anything)
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+}
+
 // --- Delete ---
 
 func TestSubCategoryDelete_HappyPath(t *testing.T) {
@@ -134,10 +198,12 @@ func TestSubCategoryDelete_HappyPath(t *testing.T) {
 
 	repo.On("FindById", mock.Anything, mock.AnythingOfType("*sql.Tx"), 2).
 		Return(newSubCategoryModel(2, "ToDelete"), nil)
-	repo.On("Delete", mock.Anything, mock.AnythingOfType("*sql.Tx"), 2).
+	repo.On("Delete", mock.Anything, mock.AnythingOfType("*sql.Tx"), 2,
+		mock.MatchedBy(func(deletedBy *int) bool { return deletedBy != nil && *deletedBy == 7 })).
 		Return(nil)
 
-	assert.NotPanics(t, func() { svc.Delete(context.Background(), 2) })
+	ctx := context.WithValue(context.Background(), helpers.ContextKey("userId"), "7")
+	assert.NotPanics(t, func() { svc.Delete(ctx, 2) })
 
 	repo.AssertExpectations(t)
 	assert.NoError(t, sqlMock.ExpectationsWereMet())
@@ -183,7 +249,7 @@ func TestSubCategoryImport_CSV_NewItem(t *testing.T) {
 		mock.MatchedBy(func(c models.SubCategory) bool { return c.Name == "Fast Food" }),
 	).Return(created, nil)
 
-	responses := svc.Import(context.Background(), []byte(csvData), "csv")
+	responses, _ := svc.Import(context.Background(), []byte(csvData), "csv", importer.OnErrorAbort)
 
 	assert.Len(t, responses, 1)
 	assert.Equal(t, "Fast Food", responses[0].Name)
@@ -206,7 +272,7 @@ func TestSubCategoryImport_CSV_ExistingItem(t *testing.T) {
 	repo.On("FindByName", mock.Anything, mock.AnythingOfType("*sql.Tx"), "Fast Food").
 		Return(existing, nil)
 
-	responses := svc.Import(context.Background(), []byte(csvData), "csv")
+	responses, _ := svc.Import(context.Background(), []byte(csvData), "csv", importer.OnErrorAbort)
 
 	assert.Len(t, responses, 1)
 	assert.Equal(t, 7, responses[0].Id)
@@ -215,3 +281,60 @@ func TestSubCategoryImport_CSV_ExistingItem(t *testing.T) {
 	repo.AssertExpectations(t)
 	assert.NoError(t, sqlMock.ExpectationsWereMet())
 }
+
+func TestSubCategoryImport_CSV_PlacesUnderCategory(t *testing.T) {
+	db, sqlMock := testutil.NewMockDB(t)
+	repo := &mocks.MockRepositorySubCategory{}
+	categoryRepo := &mocks.MockRepositoryCategory{}
+	svc := serviceSubCategory.NewServiceSubCategoryImpl(db, repo, categoryRepo)
+
+	csvData := "Name,Category\nCoffee Shop,Food & Beverage\nKiosk,Food & Beverage\n"
+
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectCommit()
+	categoryRepo.On("FindByName", mock.Anything, mock.AnythingOfType("*sql.Tx"), "Food & Beverage").
+		Return(models.Category{Id: 2, Name: "Food & Beverage"}, nil)
+	repo.On("FindByName", mock.Anything, mock.AnythingOfType("*sql.Tx"), "Coffee Shop").
+		Return(models.SubCategory{}, sql.ErrNoRows)
+	repo.On("FindByName", mock.Anything, mock.AnythingOfType("*sql.Tx"), "Kiosk").
+		Return(newSubCategoryModel(8, "Kiosk"), nil)
+	repo.On("CountPOIsOutsideCategory", mock.Anything, mock.AnythingOfType("*sql.Tx"), 8, 2).Return(0, nil)
+	repo.On("Create", mock.Anything, mock.AnythingOfType("*sql.Tx"),
+		mock.MatchedBy(func(c models.SubCategory) bool { return c.Name == "Coffee Shop" && *c.CategoryId == 2 }),
+	).Return(models.SubCategory{Id: 1, Name: "Coffee Shop"}, nil)
+	repo.On("Update", mock.Anything, mock.AnythingOfType("*sql.Tx"),
+		mock.MatchedBy(func(c models.SubCategory) bool { return c.Id == 8 && *c.CategoryId == 2 }),
+	).Return(models.SubCategory{Id: 8, Name: "Kiosk"}, nil)
+
+	responses, report := svc.Import(context.Background(), []byte(csvData), "csv", importer.OnErrorAbort)
+
+	assert.Len(t, responses, 2)
+	assert.Equal(t, 2, report.ImportedRows)
+	repo.AssertExpectations(t)
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+}
+
+func TestSubCategoryImport_CSV_RejectsOtherCategory(t *testing.T) {
+	db, sqlMock := testutil.NewMockDB(t)
+	repo := &mocks.MockRepositorySubCategory{}
+	categoryRepo := &mocks.MockRepositoryCategory{}
+	svc := serviceSubCategory.NewServiceSubCategoryImpl(db, repo, categoryRepo)
+
+	csvData := "Name,Category\nCoffee Shop,Automotive\n"
+	retail := 1
+
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectCommit()
+	categoryRepo.On("FindByName", mock.Anything, mock.AnythingOfType("*sql.Tx"), "Automotive").
+		Return(models.Category{Id: 3, Name: "Automotive"}, nil)
+	repo.On("FindByName", mock.Anything, mock.AnythingOfType("*sql.Tx"), "Coffee Shop").
+		Return(models.SubCategory{Id: 4, Name: "Coffee Shop", CategoryId: &retail, CategoryName: "Retail"}, nil)
+
+	responses, report := svc.Import(context.Background(), []byte(csvData), "csv", importer.OnErrorSkip)
+
+	assert.Empty(t, responses)
+	assert.Equal(t, 1, report.SkippedRows)
+	assert.Equal(t, "Sub-category already belongs to category Retail", report.Errors[0].Reason)
+	repo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything, mock.Anything)
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+}
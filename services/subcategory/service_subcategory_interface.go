@@ -3,6 +3,7 @@ package subcategory
 import (
 	"context"
 
+	"github.com/malikabdulaziz/tmn-backend/web"
 	webSubCategory "github.com/malikabdulaziz/tmn-backend/web/subcategory"
 )
 
@@ -12,6 +13,7 @@ type ServiceSubCategoryInterface interface {
 	FindById(ctx context.Context, id int) webSubCategory.SubCategoryResponse
 	Update(ctx context.Context, request webSubCategory.UpdateSubCategoryRequest, id int) webSubCategory.SubCategoryResponse
 	Delete(ctx context.Context, id int)
-	Import(ctx context.Context, fileBytes []byte, fileType string) []webSubCategory.SubCategoryResponse
+	Import(ctx context.Context, fileBytes []byte, fileType string, onError string) ([]webSubCategory.SubCategoryResponse, web.ImportReport)
 	Export(ctx context.Context, search string) ([]byte, error)
+	ImportTemplate(ctx context.Context) ([]byte, error)
 }
@@ -0,0 +1,137 @@
+package trash
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/malikabdulaziz/tmn-backend/exceptions"
+	"github.com/malikabdulaziz/tmn-backend/helpers"
+	"github.com/malikabdulaziz/tmn-backend/models"
+	repositoriesTrash "github.com/malikabdulaziz/tmn-backend/repositories/trash"
+	webTrash "github.com/malikabdulaziz/tmn-backend/web/trash"
+)
+
+// DefaultRetentionDays is how long deleted rows stay in the trash when no retention is configured
+const DefaultRetentionDays = 30
+
+// Config holds how many days deleted rows are kept before they are purged
+type Config struct {
+	RetentionDays int
+}
+
+func NewConfig(retentionDays int) Config {
+	if retentionDays <= 0 {
+		retentionDays = DefaultRetentionDays
+	}
+	return Config{RetentionDays: retentionDays}
+}
+
+type ServiceTrashImpl struct {
+	DB                       *sql.DB
+	RepositoryTrashInterface repositoriesTrash.RepositoryTrashInterface
+	Config                   Config
+}
+
+func NewServiceTrashImpl(db *sql.DB, repositoryTrash repositoriesTrash.RepositoryTrashInterface, config Config) ServiceTrashInterface {
+	return &ServiceTrashImpl{
+		DB:                       db,
+		RepositoryTrashInterface: repositoryTrash,
+		Config:                   config,
+	}
+}
+
+// FindAll lists the deleted rows the current user can see, optionally of one type
+func (s *ServiceTrashImpl) FindAll(ctx context.Context, request webTrash.TrashRequestFindAll) ([]webTrash.TrashItemResponse, int) {
+	if request.GetType() != "" {
+		findSource(request.GetType())
+	}
+
+	tx, err := s.DB.Begin()
+	helpers.PanicIfError(err)
+	defer helpers.CommitOrRollback(tx)
+
+	filter := repositoriesTrash.TrashFilter{
+		UserId:        helpers.UserIdFromContext(ctx),
+		Type:          request.GetType(),
+		RetentionDays: s.Config.RetentionDays,
+	}
+	items, err := s.RepositoryTrashInterface.FindAll(ctx, tx, filter, request.GetTake(), request.GetSkip())
+	helpers.PanicIfError(err)
+	total, err := s.RepositoryTrashInterface.CountAll(ctx, tx, filter)
+	helpers.PanicIfError(err)
+
+	responses := make([]webTrash.TrashItemResponse, len(items))
+	for i, item := range items {
+		responses[i] = toResponse(item)
+	}
+	return responses, total
+}
+
+// Restore takes a row out of the trash. Owned rows may only be restored by users who may change
+// them; master data is not restored over a live entry with the same name.
+func (s *ServiceTrashImpl) Restore(ctx context.Context, trashType string, id int) webTrash.TrashRestoreResponse {
+	source := findSource(trashType)
+
+	tx, err := s.DB.Begin()
+	helpers.PanicIfError(err)
+	defer helpers.CommitOrRollback(tx)
+
+	item, err := s.RepositoryTrashInterface.FindById(ctx, tx, source, id, helpers.UserIdFromContext(ctx), s.Config.RetentionDays)
+	if err == sql.ErrNoRows {
+		panic(exceptions.NewNotFoundError(source.Label + " not found in the trash"))
+	}
+	helpers.PanicIfError(err)
+	if !item.Restorable {
+		panic(exceptions.NewForbidden("only the owner can restore this " + source.Label))
+	}
+	if source.UniqueName {
+		taken, err := s.RepositoryTrashInterface.NameTaken(ctx, tx, source, item.Name)
+		helpers.PanicIfError(err)
+		if taken {
+			panic(exceptions.NewBadRequest(fmt.Sprintf("a %s named %s already exists, rename or delete it before restoring", source.Label, item.Name)))
+		}
+	}
+	helpers.PanicIfError(s.RepositoryTrashInterface.Restore(ctx, tx, source, id))
+	return webTrash.TrashRestoreResponse{Type: item.Type, Id: item.Id, Name: item.Name}
+}
+
+// Purge deletes for good the rows that have been in the trash longer than the retention period
+func (s *ServiceTrashImpl) Purge(ctx context.Context) (int, error) {
+	tx, err := s.DB.Begin()
+	if err != nil {
+		return 0, err
+	}
+	count, err := s.RepositoryTrashInterface.Purge(ctx, tx, s.Config.RetentionDays)
+	if err != nil {
+		tx.Rollback()
+		return 0, err
+	}
+	return count, tx.Commit()
+}
+
+func findSource(trashType string) models.TrashSource {
+	source, ok := models.FindTrashSource(trashType)
+	if !ok {
+		types := make([]string, len(models.TrashSources))
+		for i, s := range models.TrashSources {
+			types[i] = s.Type
+		}
+		panic(exceptions.NewBadRequest("type must be one of " + strings.Join(types, ", ")))
+	}
+	return source
+}
+
+func toResponse(item models.TrashItem) webTrash.TrashItemResponse {
+	return webTrash.TrashItemResponse{
+		Type:          item.Type,
+		Id:            item.Id,
+		Name:          item.Name,
+		DeletedAt:     item.DeletedAt,
+		DeletedBy:     item.DeletedBy,
+		DeletedByName: item.DeletedByName,
+		PurgeAt:       item.PurgeAt,
+		Restorable:    item.Restorable,
+	}
+}
@@ -0,0 +1,184 @@
+package trash_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/malikabdulaziz/tmn-backend/exceptions"
+	"github.com/malikabdulaziz/tmn-backend/helpers"
+	"github.com/malikabdulaziz/tmn-backend/models"
+	repositoriesTrash "github.com/malikabdulaziz/tmn-backend/repositories/trash"
+	serviceTrash "github.com/malikabdulaziz/tmn-backend/services/trash"
+	"github.com/malikabdulaziz/tmn-backend/testutil"
+	"github.com/malikabdulaziz/tmn-backend/testutil/mocks"
+	webTrash "github.com/malikabdulaziz/tmn-backend/web/trash"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func userContext(userId string) context.Context {
+	return context.WithValue(context.Background(), helpers.ContextKey("userId"), userId)
+}
+
+func sourceOf(trashType string) models.TrashSource {
+	source, _ := models.FindTrashSource(trashType)
+	return source
+}
+
+func TestNewConfig_DefaultsRetention(t *testing.T) {
+	assert.Equal(t, serviceTrash.DefaultRetentionDays, serviceTrash.NewConfig(0).RetentionDays)
+	assert.Equal(t, 7, serviceTrash.NewConfig(7).RetentionDays)
+}
+
+// --- FindAll ---
+
+func TestTrashFindAll_ScopesToCurrentUserAndType(t *testing.T) {
+	db, sqlMock := testutil.NewMockDB(t)
+	repo := &mocks.MockRepositoryTrash{}
+	svc := serviceTrash.NewServiceTrashImpl(db, repo, serviceTrash.NewConfig(14))
+
+	filter := repositoriesTrash.TrashFilter{UserId: 7, Type: models.TrashTypeSalesPackage, RetentionDays: 14}
+	deletedBy := 7
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectCommit()
+	repo.On("FindAll", mock.Anything, mock.AnythingOfType("*sql.Tx"), filter, 10, 0).Return([]models.TrashItem{
+		{Type: models.TrashTypeSalesPackage, Id: 4, Name: "Jakarta CBD", DeletedAt: "2026-10-01T08:00:00Z",
+			DeletedBy: &deletedBy, DeletedByName: "Rina", PurgeAt: "2026-10-15T08:00:00Z", Restorable: true},
+	}, nil)
+	repo.On("CountAll", mock.Anything, mock.AnythingOfType("*sql.Tx"), filter).Return(1, nil)
+
+	var request webTrash.TrashRequestFindAll
+	request.SetTake(10)
+	request.SetType(models.TrashTypeSalesPackage)
+	list, total := svc.FindAll(userContext("7"), request)
+
+	assert.Equal(t, 1, total)
+	assert.Len(t, list, 1)
+	assert.Equal(t, "Jakarta CBD", list[0].Name)
+	assert.Equal(t, "2026-10-15T08:00:00Z", list[0].PurgeAt)
+	assert.True(t, list[0].Restorable)
+	repo.AssertExpectations(t)
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+}
+
+func TestTrashFindAll_InvalidType(t *testing.T) {
+	db, _ := testutil.NewMockDB(t)
+	repo := &mocks.MockRepositoryTrash{}
+	svc := serviceTrash.NewServiceTrashImpl(db, repo, serviceTrash.NewConfig(0))
+
+	var request webTrash.TrashRequestFindAll
+	request.SetType("building")
+
+	assert.PanicsWithValue(t, exceptions.NewBadRequest("type must be one of poi, sales_package, building_restriction, saved_polygon, category, sub_category, mother_brand, branch"), func() {
+		svc.FindAll(userContext("7"), request)
+	})
+	repo.AssertNotCalled(t, "FindAll", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+// --- Restore ---
+
+func TestTrashRestore_RestoresOwnedRow(t *testing.T) {
+	db, sqlMock := testutil.NewMockDB(t)
+	repo := &mocks.MockRepositoryTrash{}
+	svc := serviceTrash.NewServiceTrashImpl(db, repo, serviceTrash.NewConfig(0))
+
+	source := sourceOf(models.TrashTypeSavedPolygon)
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectCommit()
+	repo.On("FindById", mock.Anything, mock.AnythingOfType("*sql.Tx"), source, 5, 7, 30).
+		Return(models.TrashItem{Type: models.TrashTypeSavedPolygon, Id: 5, Name: "North zone", Restorable: true}, nil)
+	repo.On("Restore", mock.Anything, mock.AnythingOfType("*sql.Tx"), source, 5).Return(nil)
+
+	resp := svc.Restore(userContext("7"), models.TrashTypeSavedPolygon, 5)
+
+	assert.Equal(t, webTrash.TrashRestoreResponse{Type: models.TrashTypeSavedPolygon, Id: 5, Name: "North zone"}, resp)
+	repo.AssertNotCalled(t, "NameTaken", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	repo.AssertExpectations(t)
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+}
+
+func TestTrashRestore_NotInTrash(t *testing.T) {
+	db, sqlMock := testutil.NewMockDB(t)
+	repo := &mocks.MockRepositoryTrash{}
+	svc := serviceTrash.NewServiceTrashImpl(db, repo, serviceTrash.NewConfig(0))
+
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectRollback()
+	repo.On("FindById", mock.Anything, mock.AnythingOfType("*sql.Tx"), sourceOf(models.TrashTypePOI), 99, 7, 30).
+		Return(models.TrashItem{}, sql.ErrNoRows)
+
+	assert.PanicsWithValue(t, exceptions.NewNotFoundError("POI not found in the trash"), func() {
+		svc.Restore(userContext("7"), models.TrashTypePOI, 99)
+	})
+	repo.AssertNotCalled(t, "Restore", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestTrashRestore_NotOwner(t *testing.T) {
+	db, sqlMock := testutil.NewMockDB(t)
+	repo := &mocks.MockRepositoryTrash{}
+	svc := serviceTrash.NewServiceTrashImpl(db, repo, serviceTrash.NewConfig(0))
+
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectRollback()
+	repo.On("FindById", mock.Anything, mock.AnythingOfType("*sql.Tx"), sourceOf(models.TrashTypeSalesPackage), 4, 8, 30).
+		Return(models.TrashItem{Type: models.TrashTypeSalesPackage, Id: 4, Name: "Jakarta CBD", Restorable: false}, nil)
+
+	assert.PanicsWithValue(t, exceptions.NewForbidden("only the owner can restore this sales package"), func() {
+		svc.Restore(userContext("8"), models.TrashTypeSalesPackage, 4)
+	})
+	repo.AssertNotCalled(t, "Restore", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestTrashRestore_NameTaken(t *testing.T) {
+	db, sqlMock := testutil.NewMockDB(t)
+	repo := &mocks.MockRepositoryTrash{}
+	svc := serviceTrash.NewServiceTrashImpl(db, repo, serviceTrash.NewConfig(0))
+
+	source := sourceOf(models.TrashTypeCategory)
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectRollback()
+	repo.On("FindById", mock.Anything, mock.AnythingOfType("*sql.Tx"), source, 3, 7, 30).
+		Return(models.TrashItem{Type: models.TrashTypeCategory, Id: 3, Name: "Retail", Restorable: true}, nil)
+	repo.On("NameTaken", mock.Anything, mock.AnythingOfType("*sql.Tx"), source, "Retail").Return(true, nil)
+
+	assert.PanicsWithValue(t, exceptions.NewBadRequest("a category named Retail already exists, rename or delete it before restoring"), func() {
+		svc.Restore(userContext("7"), models.TrashTypeCategory, 3)
+	})
+	repo.AssertNotCalled(t, "Restore", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+// --- Purge ---
+
+func TestTrashPurge_CommitsRemovedCount(t *testing.T) {
+	db, sqlMock := testutil.NewMockDB(t)
+	repo := &mocks.MockRepositoryTrash{}
+	svc := serviceTrash.NewServiceTrashImpl(db, repo, serviceTrash.NewConfig(14))
+
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectCommit()
+	repo.On("Purge", mock.Anything, mock.AnythingOfType("*sql.Tx"), 14).Return(6, nil)
+
+	count, err := svc.Purge(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, 6, count)
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+}
+
+func TestTrashPurge_RollsBackOnError(t *testing.T) {
+	db, sqlMock := testutil.NewMockDB(t)
+	repo := &mocks.MockRepositoryTrash{}
+	svc := serviceTrash.NewServiceTrashImpl(db, repo, serviceTrash.NewConfig(0))
+
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectRollback()
+	repo.On("Purge", mock.Anything, mock.AnythingOfType("*sql.Tx"), 30).Return(0, errors.New("boom"))
+
+	count, err := svc.Purge(context.Background())
+
+	assert.EqualError(t, err, "boom")
+	assert.Equal(t, 0, count)
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+}
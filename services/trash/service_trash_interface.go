@@ -0,0 +1,13 @@
+package trash
+
+import (
+	"context"
+
+	webTrash "github.com/malikabdulaziz/tmn-backend/web/trash"
+)
+
+type ServiceTrashInterface interface {
+	FindAll(ctx context.Context, request webTrash.TrashRequestFindAll) ([]webTrash.TrashItemResponse, int)
+	Restore(ctx context.Context, trashType string, id int) webTrash.TrashRestoreResponse
+	Purge(ctx context.Context) (int, error)
+}
@@ -0,0 +1,34 @@
+package trash
+
+import (
+	"context"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// StartTrashPurgeScheduler starts a background goroutine that periodically deletes rows that have
+// been in the trash for longer than the retention period
+func StartTrashPurgeScheduler(service ServiceTrashInterface, logger *logrus.Logger, intervalMinutes int) {
+	if intervalMinutes <= 0 {
+		intervalMinutes = 60 // Default to hourly
+	}
+
+	interval := time.Duration(intervalMinutes) * time.Minute
+	ticker := time.NewTicker(interval)
+
+	logger.WithField("interval", interval.String()).Info("Starting trash purge scheduler")
+
+	go func() {
+		ctx := context.Background()
+
+		for range ticker.C {
+			count, err := service.Purge(ctx)
+			if err != nil {
+				logger.WithError(err).Error("Scheduled trash purge failed")
+			} else if count > 0 {
+				logger.WithField("count", count).Info("Purged rows from the trash")
+			}
+		}
+	}()
+}
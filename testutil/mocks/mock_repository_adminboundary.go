@@ -0,0 +1,24 @@
+package mocks
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/malikabdulaziz/tmn-backend/models"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockRepositoryAdminBoundary implements repositories/adminboundary.RepositoryAdminBoundaryInterface
+type MockRepositoryAdminBoundary struct {
+	mock.Mock
+}
+
+func (m *MockRepositoryAdminBoundary) Upsert(ctx context.Context, tx *sql.Tx, boundary models.AdminBoundary, geometry string) (models.AdminBoundary, error) {
+	args := m.Called(ctx, tx, boundary, geometry)
+	return args.Get(0).(models.AdminBoundary), args.Error(1)
+}
+
+func (m *MockRepositoryAdminBoundary) FindAll(ctx context.Context, tx *sql.Tx, level string, parentCode string) ([]models.AdminBoundary, error) {
+	args := m.Called(ctx, tx, level, parentCode)
+	return args.Get(0).([]models.AdminBoundary), args.Error(1)
+}
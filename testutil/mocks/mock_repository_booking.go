@@ -0,0 +1,65 @@
+package mocks
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/malikabdulaziz/tmn-backend/models"
+	repositoriesBooking "github.com/malikabdulaziz/tmn-backend/repositories/booking"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockRepositoryBooking implements repositories/booking.RepositoryBookingInterface
+type MockRepositoryBooking struct {
+	mock.Mock
+}
+
+func (m *MockRepositoryBooking) Create(ctx context.Context, tx *sql.Tx, booking models.Booking, buildings []models.BookingBuilding) (models.Booking, error) {
+	args := m.Called(ctx, tx, booking, buildings)
+	return args.Get(0).(models.Booking), args.Error(1)
+}
+
+func (m *MockRepositoryBooking) FindAll(ctx context.Context, tx *sql.Tx, filter repositoriesBooking.BookingFilter, take int, skip int, orderBy string, orderDirection string) ([]models.Booking, error) {
+	args := m.Called(ctx, tx, filter, take, skip, orderBy, orderDirection)
+	return args.Get(0).([]models.Booking), args.Error(1)
+}
+
+func (m *MockRepositoryBooking) CountAll(ctx context.Context, tx *sql.Tx, filter repositoriesBooking.BookingFilter) (int, error) {
+	args := m.Called(ctx, tx, filter)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockRepositoryBooking) FindById(ctx context.Context, tx *sql.Tx, id int) (models.Booking, error) {
+	args := m.Called(ctx, tx, id)
+	return args.Get(0).(models.Booking), args.Error(1)
+}
+
+func (m *MockRepositoryBooking) Update(ctx context.Context, tx *sql.Tx, booking models.Booking, buildings []models.BookingBuilding) (models.Booking, error) {
+	args := m.Called(ctx, tx, booking, buildings)
+	return args.Get(0).(models.Booking), args.Error(1)
+}
+
+func (m *MockRepositoryBooking) UpdateStatus(ctx context.Context, tx *sql.Tx, id int, status string) (string, error) {
+	args := m.Called(ctx, tx, id, status)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockRepositoryBooking) Delete(ctx context.Context, tx *sql.Tx, id int) error {
+	args := m.Called(ctx, tx, id)
+	return args.Error(0)
+}
+
+func (m *MockRepositoryBooking) LockBuildings(ctx context.Context, tx *sql.Tx, buildingIds []int) error {
+	args := m.Called(ctx, tx, buildingIds)
+	return args.Error(0)
+}
+
+func (m *MockRepositoryBooking) FindBuildingCapacities(ctx context.Context, tx *sql.Tx, buildingIds []int) ([]repositoriesBooking.BuildingCapacityRow, error) {
+	args := m.Called(ctx, tx, buildingIds)
+	return args.Get(0).([]repositoriesBooking.BuildingCapacityRow), args.Error(1)
+}
+
+func (m *MockRepositoryBooking) FindAllocations(ctx context.Context, tx *sql.Tx, buildingIds []int, startDate string, endDate string, excludeBookingId int) ([]repositoriesBooking.BookingAllocationRow, error) {
+	args := m.Called(ctx, tx, buildingIds, startDate, endDate, excludeBookingId)
+	return args.Get(0).([]repositoriesBooking.BookingAllocationRow), args.Error(1)
+}
@@ -38,8 +38,8 @@ func (m *MockRepositoryBranch) Update(ctx context.Context, tx *sql.Tx, branch mo
 	return args.Get(0).(models.Branch), args.Error(1)
 }
 
-func (m *MockRepositoryBranch) Delete(ctx context.Context, tx *sql.Tx, id int) error {
-	args := m.Called(ctx, tx, id)
+func (m *MockRepositoryBranch) Delete(ctx context.Context, tx *sql.Tx, id int, deletedBy *int) error {
+	args := m.Called(ctx, tx, id, deletedBy)
 	return args.Error(0)
 }
 
@@ -52,3 +52,8 @@ func (m *MockRepositoryBranch) FindAllDropdown(ctx context.Context, tx *sql.Tx)
 	args := m.Called(ctx, tx)
 	return args.Get(0).([]models.Branch), args.Error(1)
 }
+
+func (m *MockRepositoryBranch) CountPOIsOutsideMotherBrand(ctx context.Context, tx *sql.Tx, id int, motherBrandId int) (int, error) {
+	args := m.Called(ctx, tx, id, motherBrandId)
+	return args.Int(0), args.Error(1)
+}
@@ -54,8 +54,8 @@ func (m *MockRepositoryBuilding) UpdateFromSync(ctx context.Context, tx *sql.Tx,
 	return args.Get(0).(models.Building), args.Error(1)
 }
 
-func (m *MockRepositoryBuilding) FindAllForMapping(ctx context.Context, tx *sql.Tx, buildingType string, buildingGrade string, year string, subdistrict string, progress string, sellable string, connectivity string, lcdPresence string, salesPackageIds string, buildingRestrictionIds string, lat *float64, lng *float64, radius *int, poiPoints []struct{ Lat float64; Lng float64 }, polygonPoints []struct{ Lat float64; Lng float64 }, minLat *float64, maxLat *float64, minLng *float64, maxLng *float64) ([]models.Building, error) {
-	args := m.Called(ctx, tx, buildingType, buildingGrade, year, subdistrict, progress, sellable, connectivity, lcdPresence, salesPackageIds, buildingRestrictionIds, lat, lng, radius, poiPoints, polygonPoints, minLat, maxLat, minLng, maxLng)
+func (m *MockRepositoryBuilding) FindAllForMapping(ctx context.Context, tx *sql.Tx, userId int, buildingType string, buildingGrade string, year string, subdistrict string, progress string, sellable string, connectivity string, lcdPresence string, salesPackageIds string, buildingRestrictionIds string, provinceCodes string, cityCodes string, subdistrictCodes string, lat *float64, lng *float64, radius *int, catchmentMode string, travelSeconds int, poiIds []int, polygonPoints []struct{ Lat float64; Lng float64 }, minLat *float64, maxLat *float64, minLng *float64, maxLng *float64) ([]models.Building, error) {
+	args := m.Called(ctx, tx, userId, buildingType, buildingGrade, year, subdistrict, progress, sellable, connectivity, lcdPresence, salesPackageIds, buildingRestrictionIds, provinceCodes, cityCodes, subdistrictCodes, lat, lng, radius, catchmentMode, travelSeconds, poiIds, polygonPoints, minLat, maxLat, minLng, maxLng)
 	return args.Get(0).([]models.Building), args.Error(1)
 }
 
@@ -73,3 +73,28 @@ func (m *MockRepositoryBuilding) FindAllDropdown(ctx context.Context, tx *sql.Tx
 	args := m.Called(ctx, tx)
 	return args.Get(0).([]models.Building), args.Error(1)
 }
+
+func (m *MockRepositoryBuilding) AssignAdminBoundaries(ctx context.Context, tx *sql.Tx) (int, error) {
+	args := m.Called(ctx, tx)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockRepositoryBuilding) FindNearestPOIPoints(ctx context.Context, tx *sql.Tx, buildingIds []int, poiIds []int) ([]repositoriesBuilding.NearestPOIPointRow, error) {
+	args := m.Called(ctx, tx, buildingIds, poiIds)
+	return args.Get(0).([]repositoriesBuilding.NearestPOIPointRow), args.Error(1)
+}
+
+func (m *MockRepositoryBuilding) FindDistancesToPoint(ctx context.Context, tx *sql.Tx, buildingIds []int, lat float64, lng float64) (map[int]float64, error) {
+	args := m.Called(ctx, tx, buildingIds, lat, lng)
+	return args.Get(0).(map[int]float64), args.Error(1)
+}
+
+func (m *MockRepositoryBuilding) CountPOIPointsInRings(ctx context.Context, tx *sql.Tx, buildingIds []int, ringMeters []int, categoryIds []int, motherBrandIds []int) ([]repositoriesBuilding.POIDensityRow, error) {
+	args := m.Called(ctx, tx, buildingIds, ringMeters, categoryIds, motherBrandIds)
+	return args.Get(0).([]repositoriesBuilding.POIDensityRow), args.Error(1)
+}
+
+func (m *MockRepositoryBuilding) FindNearest(ctx context.Context, tx *sql.Tx, lat float64, lng float64, limit int, buildingType string) ([]repositoriesBuilding.NearestBuildingRow, error) {
+	args := m.Called(ctx, tx, lat, lng, limit, buildingType)
+	return args.Get(0).([]repositoriesBuilding.NearestBuildingRow), args.Error(1)
+}
@@ -5,6 +5,7 @@ import (
 	"database/sql"
 
 	"github.com/malikabdulaziz/tmn-backend/models"
+	repositoriesBuildingRestriction "github.com/malikabdulaziz/tmn-backend/repositories/buildingrestriction"
 	"github.com/stretchr/testify/mock"
 )
 
@@ -18,13 +19,13 @@ func (m *MockRepositoryBuildingRestriction) Create(ctx context.Context, tx *sql.
 	return args.Get(0).(models.BuildingRestriction), args.Error(1)
 }
 
-func (m *MockRepositoryBuildingRestriction) FindAll(ctx context.Context, tx *sql.Tx, take int, skip int, orderBy string, orderDirection string) ([]models.BuildingRestriction, error) {
-	args := m.Called(ctx, tx, take, skip, orderBy, orderDirection)
+func (m *MockRepositoryBuildingRestriction) FindAll(ctx context.Context, tx *sql.Tx, userId int, take int, skip int, orderBy string, orderDirection string) ([]models.BuildingRestriction, error) {
+	args := m.Called(ctx, tx, userId, take, skip, orderBy, orderDirection)
 	return args.Get(0).([]models.BuildingRestriction), args.Error(1)
 }
 
-func (m *MockRepositoryBuildingRestriction) CountAll(ctx context.Context, tx *sql.Tx) (int, error) {
-	args := m.Called(ctx, tx)
+func (m *MockRepositoryBuildingRestriction) CountAll(ctx context.Context, tx *sql.Tx, userId int) (int, error) {
+	args := m.Called(ctx, tx, userId)
 	return args.Int(0), args.Error(1)
 }
 
@@ -33,6 +34,11 @@ func (m *MockRepositoryBuildingRestriction) FindById(ctx context.Context, tx *sq
 	return args.Get(0).(models.BuildingRestriction), args.Error(1)
 }
 
+func (m *MockRepositoryBuildingRestriction) FindAccess(ctx context.Context, tx *sql.Tx, id int, userId int) (models.Access, error) {
+	args := m.Called(ctx, tx, id, userId)
+	return args.Get(0).(models.Access), args.Error(1)
+}
+
 func (m *MockRepositoryBuildingRestriction) Update(ctx context.Context, tx *sql.Tx, restriction models.BuildingRestriction, buildingIds []int) (models.BuildingRestriction, error) {
 	args := m.Called(ctx, tx, restriction, buildingIds)
 	return args.Get(0).(models.BuildingRestriction), args.Error(1)
@@ -48,13 +54,13 @@ func (m *MockRepositoryBuildingRestriction) CreateBuildingLink(ctx context.Conte
 	return args.Error(0)
 }
 
-func (m *MockRepositoryBuildingRestriction) Delete(ctx context.Context, tx *sql.Tx, id int) error {
-	args := m.Called(ctx, tx, id)
+func (m *MockRepositoryBuildingRestriction) Delete(ctx context.Context, tx *sql.Tx, id int, deletedBy *int) error {
+	args := m.Called(ctx, tx, id, deletedBy)
 	return args.Error(0)
 }
 
-func (m *MockRepositoryBuildingRestriction) FindAllFlat(ctx context.Context, tx *sql.Tx, search string) ([]models.BuildingRestriction, error) {
-	args := m.Called(ctx, tx, search)
+func (m *MockRepositoryBuildingRestriction) FindAllFlat(ctx context.Context, tx *sql.Tx, userId int, search string) ([]models.BuildingRestriction, error) {
+	args := m.Called(ctx, tx, userId, search)
 	return args.Get(0).([]models.BuildingRestriction), args.Error(1)
 }
 
@@ -62,3 +68,8 @@ func (m *MockRepositoryBuildingRestriction) FindByNames(ctx context.Context, tx
 	args := m.Called(ctx, tx, names)
 	return args.Get(0).([]models.BuildingRestriction), args.Error(1)
 }
+
+func (m *MockRepositoryBuildingRestriction) FindViolations(ctx context.Context, tx *sql.Tx, check repositoriesBuildingRestriction.RestrictionCheck) ([]repositoriesBuildingRestriction.RestrictionViolationRow, error) {
+	args := m.Called(ctx, tx, check)
+	return args.Get(0).([]repositoriesBuildingRestriction.RestrictionViolationRow), args.Error(1)
+}
@@ -38,8 +38,8 @@ func (m *MockRepositoryCategory) Update(ctx context.Context, tx *sql.Tx, categor
 	return args.Get(0).(models.Category), args.Error(1)
 }
 
-func (m *MockRepositoryCategory) Delete(ctx context.Context, tx *sql.Tx, id int) error {
-	args := m.Called(ctx, tx, id)
+func (m *MockRepositoryCategory) Delete(ctx context.Context, tx *sql.Tx, id int, deletedBy *int) error {
+	args := m.Called(ctx, tx, id, deletedBy)
 	return args.Error(0)
 }
 
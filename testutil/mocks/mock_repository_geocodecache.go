@@ -0,0 +1,24 @@
+package mocks
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/malikabdulaziz/tmn-backend/models"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockRepositoryGeocodeCache implements repositories/geocodecache.RepositoryGeocodeCacheInterface
+type MockRepositoryGeocodeCache struct {
+	mock.Mock
+}
+
+func (m *MockRepositoryGeocodeCache) Find(ctx context.Context, tx *sql.Tx, provider string, kind string, queryKey string) (models.GeocodeCache, error) {
+	args := m.Called(ctx, tx, provider, kind, queryKey)
+	return args.Get(0).(models.GeocodeCache), args.Error(1)
+}
+
+func (m *MockRepositoryGeocodeCache) Save(ctx context.Context, tx *sql.Tx, entry models.GeocodeCache) error {
+	args := m.Called(ctx, tx, entry)
+	return args.Error(0)
+}
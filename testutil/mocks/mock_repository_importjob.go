@@ -0,0 +1,49 @@
+package mocks
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/malikabdulaziz/tmn-backend/models"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockRepositoryImportJob implements repositories/importjob.RepositoryImportJobInterface
+type MockRepositoryImportJob struct {
+	mock.Mock
+}
+
+func (m *MockRepositoryImportJob) Create(ctx context.Context, tx *sql.Tx, job models.ImportJob) (models.ImportJob, error) {
+	args := m.Called(ctx, tx, job)
+	return args.Get(0).(models.ImportJob), args.Error(1)
+}
+
+func (m *MockRepositoryImportJob) FindById(ctx context.Context, tx *sql.Tx, id int) (models.ImportJob, error) {
+	args := m.Called(ctx, tx, id)
+	return args.Get(0).(models.ImportJob), args.Error(1)
+}
+
+func (m *MockRepositoryImportJob) ClaimNext(ctx context.Context, tx *sql.Tx) (models.ImportJob, error) {
+	args := m.Called(ctx, tx)
+	return args.Get(0).(models.ImportJob), args.Error(1)
+}
+
+func (m *MockRepositoryImportJob) UpdateProgress(ctx context.Context, tx *sql.Tx, id int, done int, total int) (bool, error) {
+	args := m.Called(ctx, tx, id, done, total)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockRepositoryImportJob) RequestCancel(ctx context.Context, tx *sql.Tx, id int) (models.ImportJob, error) {
+	args := m.Called(ctx, tx, id)
+	return args.Get(0).(models.ImportJob), args.Error(1)
+}
+
+func (m *MockRepositoryImportJob) Finish(ctx context.Context, tx *sql.Tx, job models.ImportJob) error {
+	args := m.Called(ctx, tx, job)
+	return args.Error(0)
+}
+
+func (m *MockRepositoryImportJob) FailStale(ctx context.Context, tx *sql.Tx, staleSeconds int, message string) (int, error) {
+	args := m.Called(ctx, tx, staleSeconds, message)
+	return args.Int(0), args.Error(1)
+}
@@ -0,0 +1,34 @@
+package mocks
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/malikabdulaziz/tmn-backend/models"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockRepositoryImportPreview implements repositories/importpreview.RepositoryImportPreviewInterface
+type MockRepositoryImportPreview struct {
+	mock.Mock
+}
+
+func (m *MockRepositoryImportPreview) Create(ctx context.Context, tx *sql.Tx, preview models.ImportPreview, ttlSeconds int) (models.ImportPreview, error) {
+	args := m.Called(ctx, tx, preview, ttlSeconds)
+	return args.Get(0).(models.ImportPreview), args.Error(1)
+}
+
+func (m *MockRepositoryImportPreview) FindByToken(ctx context.Context, tx *sql.Tx, kind string, token string) (models.ImportPreview, error) {
+	args := m.Called(ctx, tx, kind, token)
+	return args.Get(0).(models.ImportPreview), args.Error(1)
+}
+
+func (m *MockRepositoryImportPreview) Delete(ctx context.Context, tx *sql.Tx, id int) error {
+	args := m.Called(ctx, tx, id)
+	return args.Error(0)
+}
+
+func (m *MockRepositoryImportPreview) DeleteExpired(ctx context.Context, tx *sql.Tx) (int, error) {
+	args := m.Called(ctx, tx)
+	return args.Int(0), args.Error(1)
+}
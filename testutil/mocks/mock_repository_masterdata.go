@@ -0,0 +1,34 @@
+package mocks
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/malikabdulaziz/tmn-backend/models"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockRepositoryMasterData implements repositories/masterdata.RepositoryMasterDataInterface
+type MockRepositoryMasterData struct {
+	mock.Mock
+}
+
+func (m *MockRepositoryMasterData) FindEntry(ctx context.Context, tx *sql.Tx, source models.MergeSource, id int) (models.MasterDataEntry, error) {
+	args := m.Called(ctx, tx, source, id)
+	return args.Get(0).(models.MasterDataEntry), args.Error(1)
+}
+
+func (m *MockRepositoryMasterData) Repoint(ctx context.Context, tx *sql.Tx, source models.MergeSource, fromId int, toId int) (int, error) {
+	args := m.Called(ctx, tx, source, fromId, toId)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockRepositoryMasterData) Delete(ctx context.Context, tx *sql.Tx, source models.MergeSource, id int) error {
+	args := m.Called(ctx, tx, source, id)
+	return args.Error(0)
+}
+
+func (m *MockRepositoryMasterData) SaveAlias(ctx context.Context, tx *sql.Tx, source models.MergeSource, alias string, targetId int, createdBy *int) error {
+	args := m.Called(ctx, tx, source, alias, targetId, createdBy)
+	return args.Error(0)
+}
@@ -38,8 +38,8 @@ func (m *MockRepositoryMotherBrand) Update(ctx context.Context, tx *sql.Tx, moth
 	return args.Get(0).(models.MotherBrand), args.Error(1)
 }
 
-func (m *MockRepositoryMotherBrand) Delete(ctx context.Context, tx *sql.Tx, id int) error {
-	args := m.Called(ctx, tx, id)
+func (m *MockRepositoryMotherBrand) Delete(ctx context.Context, tx *sql.Tx, id int, deletedBy *int) error {
+	args := m.Called(ctx, tx, id, deletedBy)
 	return args.Error(0)
 }
 
@@ -5,6 +5,7 @@ import (
 	"database/sql"
 
 	"github.com/malikabdulaziz/tmn-backend/models"
+	repositoriesPOI "github.com/malikabdulaziz/tmn-backend/repositories/poi"
 	"github.com/stretchr/testify/mock"
 )
 
@@ -43,8 +44,28 @@ func (m *MockRepositoryPOI) Update(ctx context.Context, tx *sql.Tx, poi models.P
 	return args.Get(0).(models.POI), args.Error(1)
 }
 
-func (m *MockRepositoryPOI) Delete(ctx context.Context, tx *sql.Tx, id int) error {
-	args := m.Called(ctx, tx, id)
+func (m *MockRepositoryPOI) UpdateMetadata(ctx context.Context, tx *sql.Tx, poi models.POI) (models.POI, error) {
+	args := m.Called(ctx, tx, poi)
+	return args.Get(0).(models.POI), args.Error(1)
+}
+
+func (m *MockRepositoryPOI) CreatePoint(ctx context.Context, tx *sql.Tx, poiId int, point models.POIPoint) (models.POIPoint, error) {
+	args := m.Called(ctx, tx, poiId, point)
+	return args.Get(0).(models.POIPoint), args.Error(1)
+}
+
+func (m *MockRepositoryPOI) UpdatePoint(ctx context.Context, tx *sql.Tx, point models.POIPoint) (models.POIPoint, error) {
+	args := m.Called(ctx, tx, point)
+	return args.Get(0).(models.POIPoint), args.Error(1)
+}
+
+func (m *MockRepositoryPOI) DeletePoints(ctx context.Context, tx *sql.Tx, ids []int) error {
+	args := m.Called(ctx, tx, ids)
+	return args.Error(0)
+}
+
+func (m *MockRepositoryPOI) Delete(ctx context.Context, tx *sql.Tx, id int, deletedBy *int) error {
+	args := m.Called(ctx, tx, id, deletedBy)
 	return args.Error(0)
 }
 
@@ -52,3 +73,13 @@ func (m *MockRepositoryPOI) FindByBrands(ctx context.Context, tx *sql.Tx, brands
 	args := m.Called(ctx, tx, brands)
 	return args.Get(0).([]models.POI), args.Error(1)
 }
+
+func (m *MockRepositoryPOI) FindNearbyPoints(ctx context.Context, tx *sql.Tx, lat float64, lng float64, limit int, categoryIds string, subCategoryIds string, motherBrandIds string) ([]repositoriesPOI.NearbyPOIPointRow, error) {
+	args := m.Called(ctx, tx, lat, lng, limit, categoryIds, subCategoryIds, motherBrandIds)
+	return args.Get(0).([]repositoriesPOI.NearbyPOIPointRow), args.Error(1)
+}
+
+func (m *MockRepositoryPOI) CountPointLocations(ctx context.Context, tx *sql.Tx, poiIds []int) (int, error) {
+	args := m.Called(ctx, tx, poiIds)
+	return args.Int(0), args.Error(1)
+}
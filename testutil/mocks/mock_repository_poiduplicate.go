@@ -0,0 +1,35 @@
+package mocks
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/malikabdulaziz/tmn-backend/models"
+	repositoriesPOIDuplicate "github.com/malikabdulaziz/tmn-backend/repositories/poiduplicate"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockRepositoryPOIDuplicate implements repositories/poiduplicate.RepositoryPOIDuplicateInterface
+type MockRepositoryPOIDuplicate struct {
+	mock.Mock
+}
+
+func (m *MockRepositoryPOIDuplicate) FindCandidates(ctx context.Context, tx *sql.Tx, filter repositoriesPOIDuplicate.DuplicateFilter, take int, skip int) ([]models.POIPointDuplicate, error) {
+	args := m.Called(ctx, tx, filter, take, skip)
+	return args.Get(0).([]models.POIPointDuplicate), args.Error(1)
+}
+
+func (m *MockRepositoryPOIDuplicate) CountCandidates(ctx context.Context, tx *sql.Tx, filter repositoriesPOIDuplicate.DuplicateFilter) (int, error) {
+	args := m.Called(ctx, tx, filter)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockRepositoryPOIDuplicate) FindPointById(ctx context.Context, tx *sql.Tx, id int) (models.POIPoint, error) {
+	args := m.Called(ctx, tx, id)
+	return args.Get(0).(models.POIPoint), args.Error(1)
+}
+
+func (m *MockRepositoryPOIDuplicate) Dismiss(ctx context.Context, tx *sql.Tx, pointId int, otherPointId int, dismissedBy *int) error {
+	args := m.Called(ctx, tx, pointId, otherPointId, dismissedBy)
+	return args.Error(0)
+}
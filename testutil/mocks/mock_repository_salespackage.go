@@ -5,6 +5,7 @@ import (
 	"database/sql"
 
 	"github.com/malikabdulaziz/tmn-backend/models"
+	repositoriesSalesPackage "github.com/malikabdulaziz/tmn-backend/repositories/salespackage"
 	"github.com/stretchr/testify/mock"
 )
 
@@ -18,13 +19,13 @@ func (m *MockRepositorySalesPackage) Create(ctx context.Context, tx *sql.Tx, pkg
 	return args.Get(0).(models.SalesPackage), args.Error(1)
 }
 
-func (m *MockRepositorySalesPackage) FindAll(ctx context.Context, tx *sql.Tx, take int, skip int, orderBy string, orderDirection string) ([]models.SalesPackage, error) {
-	args := m.Called(ctx, tx, take, skip, orderBy, orderDirection)
+func (m *MockRepositorySalesPackage) FindAll(ctx context.Context, tx *sql.Tx, userId int, take int, skip int, orderBy string, orderDirection string) ([]models.SalesPackage, error) {
+	args := m.Called(ctx, tx, userId, take, skip, orderBy, orderDirection)
 	return args.Get(0).([]models.SalesPackage), args.Error(1)
 }
 
-func (m *MockRepositorySalesPackage) CountAll(ctx context.Context, tx *sql.Tx) (int, error) {
-	args := m.Called(ctx, tx)
+func (m *MockRepositorySalesPackage) CountAll(ctx context.Context, tx *sql.Tx, userId int) (int, error) {
+	args := m.Called(ctx, tx, userId)
 	return args.Int(0), args.Error(1)
 }
 
@@ -33,6 +34,11 @@ func (m *MockRepositorySalesPackage) FindById(ctx context.Context, tx *sql.Tx, i
 	return args.Get(0).(models.SalesPackage), args.Error(1)
 }
 
+func (m *MockRepositorySalesPackage) FindAccess(ctx context.Context, tx *sql.Tx, id int, userId int) (models.Access, error) {
+	args := m.Called(ctx, tx, id, userId)
+	return args.Get(0).(models.Access), args.Error(1)
+}
+
 func (m *MockRepositorySalesPackage) Update(ctx context.Context, tx *sql.Tx, pkg models.SalesPackage, buildingIds []int) (models.SalesPackage, error) {
 	args := m.Called(ctx, tx, pkg, buildingIds)
 	return args.Get(0).(models.SalesPackage), args.Error(1)
@@ -48,13 +54,13 @@ func (m *MockRepositorySalesPackage) CreateBuildingLink(ctx context.Context, tx
 	return args.Error(0)
 }
 
-func (m *MockRepositorySalesPackage) Delete(ctx context.Context, tx *sql.Tx, id int) error {
-	args := m.Called(ctx, tx, id)
+func (m *MockRepositorySalesPackage) Delete(ctx context.Context, tx *sql.Tx, id int, deletedBy *int) error {
+	args := m.Called(ctx, tx, id, deletedBy)
 	return args.Error(0)
 }
 
-func (m *MockRepositorySalesPackage) FindAllFlat(ctx context.Context, tx *sql.Tx, search string) ([]models.SalesPackage, error) {
-	args := m.Called(ctx, tx, search)
+func (m *MockRepositorySalesPackage) FindAllFlat(ctx context.Context, tx *sql.Tx, userId int, search string) ([]models.SalesPackage, error) {
+	args := m.Called(ctx, tx, userId, search)
 	return args.Get(0).([]models.SalesPackage), args.Error(1)
 }
 
@@ -62,3 +68,33 @@ func (m *MockRepositorySalesPackage) FindByNames(ctx context.Context, tx *sql.Tx
 	args := m.Called(ctx, tx, names)
 	return args.Get(0).([]models.SalesPackage), args.Error(1)
 }
+
+func (m *MockRepositorySalesPackage) FindSummaryRows(ctx context.Context, tx *sql.Tx, salesPackageIds []int) ([]repositoriesSalesPackage.SalesPackageSummaryRow, error) {
+	args := m.Called(ctx, tx, salesPackageIds)
+	return args.Get(0).([]repositoriesSalesPackage.SalesPackageSummaryRow), args.Error(1)
+}
+
+func (m *MockRepositorySalesPackage) SetFilter(ctx context.Context, tx *sql.Tx, id int, filter string) (string, error) {
+	args := m.Called(ctx, tx, id, filter)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockRepositorySalesPackage) CreateVersion(ctx context.Context, tx *sql.Tx, salesPackageId int, note string, createdBy *int) (models.SalesPackageVersion, error) {
+	args := m.Called(ctx, tx, salesPackageId, note, createdBy)
+	return args.Get(0).(models.SalesPackageVersion), args.Error(1)
+}
+
+func (m *MockRepositorySalesPackage) FindVersions(ctx context.Context, tx *sql.Tx, salesPackageId int) ([]models.SalesPackageVersion, error) {
+	args := m.Called(ctx, tx, salesPackageId)
+	return args.Get(0).([]models.SalesPackageVersion), args.Error(1)
+}
+
+func (m *MockRepositorySalesPackage) FindVersion(ctx context.Context, tx *sql.Tx, salesPackageId int, version int) (models.SalesPackageVersion, error) {
+	args := m.Called(ctx, tx, salesPackageId, version)
+	return args.Get(0).(models.SalesPackageVersion), args.Error(1)
+}
+
+func (m *MockRepositorySalesPackage) FindVersionSummaryRows(ctx context.Context, tx *sql.Tx, salesPackageVersionId int) ([]repositoriesSalesPackage.SalesPackageSummaryRow, error) {
+	args := m.Called(ctx, tx, salesPackageVersionId)
+	return args.Get(0).([]repositoriesSalesPackage.SalesPackageSummaryRow), args.Error(1)
+}
@@ -23,13 +23,13 @@ func (m *MockRepositorySavedPolygon) CreatePoint(ctx context.Context, tx *sql.Tx
 	return args.Get(0).(models.SavedPolygonPoint), args.Error(1)
 }
 
-func (m *MockRepositorySavedPolygon) FindAll(ctx context.Context, tx *sql.Tx, take int, skip int, orderBy string, orderDirection string) ([]models.SavedPolygon, error) {
-	args := m.Called(ctx, tx, take, skip, orderBy, orderDirection)
+func (m *MockRepositorySavedPolygon) FindAll(ctx context.Context, tx *sql.Tx, userId int, take int, skip int, orderBy string, orderDirection string) ([]models.SavedPolygon, error) {
+	args := m.Called(ctx, tx, userId, take, skip, orderBy, orderDirection)
 	return args.Get(0).([]models.SavedPolygon), args.Error(1)
 }
 
-func (m *MockRepositorySavedPolygon) CountAll(ctx context.Context, tx *sql.Tx) (int, error) {
-	args := m.Called(ctx, tx)
+func (m *MockRepositorySavedPolygon) CountAll(ctx context.Context, tx *sql.Tx, userId int) (int, error) {
+	args := m.Called(ctx, tx, userId)
 	return args.Int(0), args.Error(1)
 }
 
@@ -38,6 +38,11 @@ func (m *MockRepositorySavedPolygon) FindById(ctx context.Context, tx *sql.Tx, i
 	return args.Get(0).(models.SavedPolygon), args.Error(1)
 }
 
+func (m *MockRepositorySavedPolygon) FindAccess(ctx context.Context, tx *sql.Tx, id int, userId int) (models.Access, error) {
+	args := m.Called(ctx, tx, id, userId)
+	return args.Get(0).(models.Access), args.Error(1)
+}
+
 func (m *MockRepositorySavedPolygon) Update(ctx context.Context, tx *sql.Tx, polygon models.SavedPolygon, points []models.SavedPolygonPoint) (models.SavedPolygon, error) {
 	args := m.Called(ctx, tx, polygon, points)
 	return args.Get(0).(models.SavedPolygon), args.Error(1)
@@ -48,7 +53,7 @@ func (m *MockRepositorySavedPolygon) DeletePointsBySavedPolygonId(ctx context.Co
 	return args.Error(0)
 }
 
-func (m *MockRepositorySavedPolygon) Delete(ctx context.Context, tx *sql.Tx, id int) error {
-	args := m.Called(ctx, tx, id)
+func (m *MockRepositorySavedPolygon) Delete(ctx context.Context, tx *sql.Tx, id int, deletedBy *int) error {
+	args := m.Called(ctx, tx, id, deletedBy)
 	return args.Error(0)
 }
@@ -0,0 +1,55 @@
+package mocks
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/malikabdulaziz/tmn-backend/models"
+	repositoriesSavedView "github.com/malikabdulaziz/tmn-backend/repositories/savedview"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockRepositorySavedView implements repositories/savedview.RepositorySavedViewInterface
+type MockRepositorySavedView struct {
+	mock.Mock
+}
+
+func (m *MockRepositorySavedView) Create(ctx context.Context, tx *sql.Tx, view models.SavedView) (models.SavedView, error) {
+	args := m.Called(ctx, tx, view)
+	return args.Get(0).(models.SavedView), args.Error(1)
+}
+
+func (m *MockRepositorySavedView) FindAll(ctx context.Context, tx *sql.Tx, filter repositoriesSavedView.SavedViewFilter, take int, skip int, orderBy string, orderDirection string) ([]models.SavedView, error) {
+	args := m.Called(ctx, tx, filter, take, skip, orderBy, orderDirection)
+	return args.Get(0).([]models.SavedView), args.Error(1)
+}
+
+func (m *MockRepositorySavedView) CountAll(ctx context.Context, tx *sql.Tx, filter repositoriesSavedView.SavedViewFilter) (int, error) {
+	args := m.Called(ctx, tx, filter)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockRepositorySavedView) FindById(ctx context.Context, tx *sql.Tx, id int) (models.SavedView, error) {
+	args := m.Called(ctx, tx, id)
+	return args.Get(0).(models.SavedView), args.Error(1)
+}
+
+func (m *MockRepositorySavedView) FindAccess(ctx context.Context, tx *sql.Tx, id int, userId int) (models.Access, error) {
+	args := m.Called(ctx, tx, id, userId)
+	return args.Get(0).(models.Access), args.Error(1)
+}
+
+func (m *MockRepositorySavedView) FindByName(ctx context.Context, tx *sql.Tx, createdBy int, name string) (models.SavedView, error) {
+	args := m.Called(ctx, tx, createdBy, name)
+	return args.Get(0).(models.SavedView), args.Error(1)
+}
+
+func (m *MockRepositorySavedView) Update(ctx context.Context, tx *sql.Tx, view models.SavedView) (models.SavedView, error) {
+	args := m.Called(ctx, tx, view)
+	return args.Get(0).(models.SavedView), args.Error(1)
+}
+
+func (m *MockRepositorySavedView) Delete(ctx context.Context, tx *sql.Tx, id int) error {
+	args := m.Called(ctx, tx, id)
+	return args.Error(0)
+}
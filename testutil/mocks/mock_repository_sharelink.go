@@ -0,0 +1,50 @@
+package mocks
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/malikabdulaziz/tmn-backend/models"
+	repositoriesShareLink "github.com/malikabdulaziz/tmn-backend/repositories/sharelink"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockRepositoryShareLink implements repositories/sharelink.RepositoryShareLinkInterface
+type MockRepositoryShareLink struct {
+	mock.Mock
+}
+
+func (m *MockRepositoryShareLink) Create(ctx context.Context, tx *sql.Tx, link models.ShareLink, expiresInDays int) (models.ShareLink, error) {
+	args := m.Called(ctx, tx, link, expiresInDays)
+	return args.Get(0).(models.ShareLink), args.Error(1)
+}
+
+func (m *MockRepositoryShareLink) FindAll(ctx context.Context, tx *sql.Tx, filter repositoriesShareLink.ShareLinkFilter, take int, skip int, orderBy string, orderDirection string) ([]models.ShareLink, error) {
+	args := m.Called(ctx, tx, filter, take, skip, orderBy, orderDirection)
+	return args.Get(0).([]models.ShareLink), args.Error(1)
+}
+
+func (m *MockRepositoryShareLink) CountAll(ctx context.Context, tx *sql.Tx, filter repositoriesShareLink.ShareLinkFilter) (int, error) {
+	args := m.Called(ctx, tx, filter)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockRepositoryShareLink) FindById(ctx context.Context, tx *sql.Tx, id int) (models.ShareLink, error) {
+	args := m.Called(ctx, tx, id)
+	return args.Get(0).(models.ShareLink), args.Error(1)
+}
+
+func (m *MockRepositoryShareLink) FindByKey(ctx context.Context, tx *sql.Tx, key string) (models.ShareLink, error) {
+	args := m.Called(ctx, tx, key)
+	return args.Get(0).(models.ShareLink), args.Error(1)
+}
+
+func (m *MockRepositoryShareLink) Revoke(ctx context.Context, tx *sql.Tx, id int) error {
+	args := m.Called(ctx, tx, id)
+	return args.Error(0)
+}
+
+func (m *MockRepositoryShareLink) RecordView(ctx context.Context, tx *sql.Tx, id int) error {
+	args := m.Called(ctx, tx, id)
+	return args.Error(0)
+}
@@ -38,8 +38,8 @@ func (m *MockRepositorySubCategory) Update(ctx context.Context, tx *sql.Tx, subC
 	return args.Get(0).(models.SubCategory), args.Error(1)
 }
 
-func (m *MockRepositorySubCategory) Delete(ctx context.Context, tx *sql.Tx, id int) error {
-	args := m.Called(ctx, tx, id)
+func (m *MockRepositorySubCategory) Delete(ctx context.Context, tx *sql.Tx, id int, deletedBy *int) error {
+	args := m.Called(ctx, tx, id, deletedBy)
 	return args.Error(0)
 }
 
@@ -52,3 +52,8 @@ func (m *MockRepositorySubCategory) FindAllDropdown(ctx context.Context, tx *sql
 	args := m.Called(ctx, tx)
 	return args.Get(0).([]models.SubCategory), args.Error(1)
 }
+
+func (m *MockRepositorySubCategory) CountPOIsOutsideCategory(ctx context.Context, tx *sql.Tx, id int, categoryId int) (int, error) {
+	args := m.Called(ctx, tx, id, categoryId)
+	return args.Int(0), args.Error(1)
+}
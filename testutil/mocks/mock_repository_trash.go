@@ -0,0 +1,45 @@
+package mocks
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/malikabdulaziz/tmn-backend/models"
+	repositoriesTrash "github.com/malikabdulaziz/tmn-backend/repositories/trash"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockRepositoryTrash implements repositories/trash.RepositoryTrashInterface
+type MockRepositoryTrash struct {
+	mock.Mock
+}
+
+func (m *MockRepositoryTrash) FindAll(ctx context.Context, tx *sql.Tx, filter repositoriesTrash.TrashFilter, take int, skip int) ([]models.TrashItem, error) {
+	args := m.Called(ctx, tx, filter, take, skip)
+	return args.Get(0).([]models.TrashItem), args.Error(1)
+}
+
+func (m *MockRepositoryTrash) CountAll(ctx context.Context, tx *sql.Tx, filter repositoriesTrash.TrashFilter) (int, error) {
+	args := m.Called(ctx, tx, filter)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockRepositoryTrash) FindById(ctx context.Context, tx *sql.Tx, source models.TrashSource, id int, userId int, retentionDays int) (models.TrashItem, error) {
+	args := m.Called(ctx, tx, source, id, userId, retentionDays)
+	return args.Get(0).(models.TrashItem), args.Error(1)
+}
+
+func (m *MockRepositoryTrash) NameTaken(ctx context.Context, tx *sql.Tx, source models.TrashSource, name string) (bool, error) {
+	args := m.Called(ctx, tx, source, name)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockRepositoryTrash) Restore(ctx context.Context, tx *sql.Tx, source models.TrashSource, id int) error {
+	args := m.Called(ctx, tx, source, id)
+	return args.Error(0)
+}
+
+func (m *MockRepositoryTrash) Purge(ctx context.Context, tx *sql.Tx, retentionDays int) (int, error) {
+	args := m.Called(ctx, tx, retentionDays)
+	return args.Int(0), args.Error(1)
+}
@@ -0,0 +1,11 @@
+package adminboundary
+
+// ImportGeoJSONRequest describes how to read a GeoJSON FeatureCollection of boundaries.
+// The *Property fields name the feature properties holding the code, name and parent code;
+// they default to "code", "name" and "parent_code".
+type ImportGeoJSONRequest struct {
+	Level              string `json:"level" validate:"required,oneof=province city subdistrict"`
+	CodeProperty       string `json:"code_property"`
+	NameProperty       string `json:"name_property"`
+	ParentCodeProperty string `json:"parent_code_property"`
+}
@@ -0,0 +1,35 @@
+package adminboundary
+
+import "github.com/malikabdulaziz/tmn-backend/models"
+
+type AdminBoundaryResponse struct {
+	Id         int    `json:"id"`
+	Code       string `json:"code"`
+	Name       string `json:"name"`
+	Level      string `json:"level"`
+	ParentCode string `json:"parent_code"`
+}
+
+type ImportGeoJSONResponse struct {
+	Level            string `json:"level"`
+	Imported         int    `json:"imported"`
+	BuildingsUpdated int    `json:"buildings_updated"`
+}
+
+func AdminBoundaryModelToResponse(m models.AdminBoundary) AdminBoundaryResponse {
+	return AdminBoundaryResponse{
+		Id:         m.Id,
+		Code:       m.Code,
+		Name:       m.Name,
+		Level:      m.Level,
+		ParentCode: m.ParentCode,
+	}
+}
+
+func AdminBoundaryModelsToResponses(list []models.AdminBoundary) []AdminBoundaryResponse {
+	out := make([]AdminBoundaryResponse, 0, len(list))
+	for _, m := range list {
+		out = append(out, AdminBoundaryModelToResponse(m))
+	}
+	return out
+}
@@ -9,6 +9,7 @@ type UserResponse struct {
 	Username  string `json:"username"`
 	Name      string `json:"name"`
 	Role      string `json:"role"`
+	Team      string `json:"team"`
 	LastLogin string `json:"last_login"`
 }
 
@@ -0,0 +1,161 @@
+package booking
+
+import (
+	"strings"
+)
+
+// Limits for availability lookups; without from/to the calendar covers DefaultAvailabilityDays
+// starting today
+const (
+	DefaultAvailabilityDays  = 31
+	MaxAvailabilityDays      = 366
+	MaxAvailabilityBuildings = 500
+)
+
+// CreateBookingRequest books either a sales package (the buildings of SalesPackageVersion, the
+// latest version when 0, are copied onto the booking) or an explicit list of buildings. Screens is reserved in every building, default 1.
+// The optional advertiser category and mother brand are checked against the building restrictions
+// in force during the booking.
+type CreateBookingRequest struct {
+	ClientName              string `json:"client_name" validate:"required"`
+	SalesPackageId          int    `json:"sales_package_id"`
+	SalesPackageVersion     int    `json:"sales_package_version" validate:"omitempty,min=1"`
+	BuildingIds             []int  `json:"building_ids"`
+	Screens                 int    `json:"screens" validate:"omitempty,min=1"`
+	StartDate               string `json:"start_date" validate:"required"`
+	EndDate                 string `json:"end_date" validate:"required"`
+	Status                  string `json:"status" validate:"omitempty,oneof=hold confirmed"`
+	Notes                   string `json:"notes"`
+	AdvertiserCategoryId    int    `json:"advertiser_category_id"`
+	AdvertiserMotherBrandId int    `json:"advertiser_mother_brand_id"`
+}
+
+// UpdateBookingRequest replaces a booking's client, dates and buildings; status changes go
+// through UpdateBookingStatusRequest
+type UpdateBookingRequest struct {
+	ClientName              string `json:"client_name" validate:"required"`
+	SalesPackageId          int    `json:"sales_package_id"`
+	SalesPackageVersion     int    `json:"sales_package_version" validate:"omitempty,min=1"`
+	BuildingIds             []int  `json:"building_ids"`
+	Screens                 int    `json:"screens" validate:"omitempty,min=1"`
+	StartDate               string `json:"start_date" validate:"required"`
+	EndDate                 string `json:"end_date" validate:"required"`
+	Notes                   string `json:"notes"`
+	AdvertiserCategoryId    int    `json:"advertiser_category_id"`
+	AdvertiserMotherBrandId int    `json:"advertiser_mother_brand_id"`
+}
+
+type UpdateBookingStatusRequest struct {
+	Status string `json:"status" validate:"required,oneof=hold confirmed cancelled"`
+}
+
+// AvailabilityRequest asks whether Screens screens are free in every building on every day of
+// [From, To]. Buildings come from BuildingIds or from the sales package, at SalesPackageVersion
+// or its latest version.
+type AvailabilityRequest struct {
+	BuildingIds         []int
+	SalesPackageId      int
+	SalesPackageVersion int
+	From                string
+	To                  string
+	Screens             int
+}
+
+type BookingRequestFindAll struct {
+	take           int
+	skip           int
+	orderBy        string
+	orderDirection string
+	status         string
+	clientName     string
+	salesPackageId int
+	buildingId     int
+	from           string
+	to             string
+}
+
+func (r *BookingRequestFindAll) SetSkip(skip int) {
+	r.skip = skip
+}
+
+func (r *BookingRequestFindAll) SetTake(take int) {
+	r.take = take
+}
+
+func (r *BookingRequestFindAll) GetSkip() int {
+	return r.skip
+}
+
+func (r *BookingRequestFindAll) GetTake() int {
+	return r.take
+}
+
+func (r *BookingRequestFindAll) SetOrderBy(orderBy string) {
+	r.orderBy = orderBy
+}
+
+func (r *BookingRequestFindAll) SetOrderDirection(orderDirection string) {
+	r.orderDirection = strings.ToUpper(orderDirection)
+}
+
+func (r *BookingRequestFindAll) GetOrderBy() string {
+	if r.orderBy == "" {
+		return "start_date"
+	}
+	return r.orderBy
+}
+
+func (r *BookingRequestFindAll) GetOrderDirection() string {
+	if r.orderDirection == "" {
+		return "DESC"
+	}
+	return r.orderDirection
+}
+
+func (r *BookingRequestFindAll) SetStatus(status string) {
+	r.status = status
+}
+
+func (r *BookingRequestFindAll) GetStatus() string {
+	return r.status
+}
+
+func (r *BookingRequestFindAll) SetClientName(clientName string) {
+	r.clientName = clientName
+}
+
+func (r *BookingRequestFindAll) GetClientName() string {
+	return r.clientName
+}
+
+func (r *BookingRequestFindAll) SetSalesPackageId(salesPackageId int) {
+	r.salesPackageId = salesPackageId
+}
+
+func (r *BookingRequestFindAll) GetSalesPackageId() int {
+	return r.salesPackageId
+}
+
+func (r *BookingRequestFindAll) SetBuildingId(buildingId int) {
+	r.buildingId = buildingId
+}
+
+func (r *BookingRequestFindAll) GetBuildingId() int {
+	return r.buildingId
+}
+
+func (r *BookingRequestFindAll) SetFrom(from string) {
+	r.from = from
+}
+
+func (r *BookingRequestFindAll) GetFrom() string {
+	return r.from
+}
+
+func (r *BookingRequestFindAll) SetTo(to string) {
+	r.to = to
+}
+
+func (r *BookingRequestFindAll) GetTo() string {
+	return r.to
+}
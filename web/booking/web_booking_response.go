@@ -0,0 +1,86 @@
+package booking
+
+import webBuildingRestriction "github.com/malikabdulaziz/tmn-backend/web/buildingrestriction"
+
+type BookingBuildingResponse struct {
+	BuildingId   int    `json:"building_id"`
+	BuildingName string `json:"building_name"`
+	Screens      int    `json:"screens"`
+}
+
+type BookingResponse struct {
+	Id                        int                       `json:"id"`
+	ClientName                string                    `json:"client_name"`
+	SalesPackageId            *int                      `json:"sales_package_id"`
+	SalesPackageName          string                    `json:"sales_package_name"`
+	SalesPackageVersionId     *int                      `json:"sales_package_version_id"`
+	SalesPackageVersion       int                       `json:"sales_package_version"`
+	StartDate                 string                    `json:"start_date"`
+	EndDate                   string                    `json:"end_date"`
+	Status                    string                    `json:"status"`
+	Notes                     string                    `json:"notes"`
+	AdvertiserCategoryId      *int                      `json:"advertiser_category_id"`
+	AdvertiserCategoryName    string                    `json:"advertiser_category_name"`
+	AdvertiserMotherBrandId   *int                      `json:"advertiser_mother_brand_id"`
+	AdvertiserMotherBrandName string                    `json:"advertiser_mother_brand_name"`
+	CreatedBy                 *int                      `json:"created_by"`
+	Buildings                 []BookingBuildingResponse `json:"buildings"`
+	// RestrictionWarnings lists the warn-level building restrictions barring the advertiser,
+	// returned on create and update
+	RestrictionWarnings []webBuildingRestriction.RestrictionViolationResponse `json:"restriction_warnings,omitempty"`
+	CreatedAt           string                                                `json:"created_at"`
+	UpdatedAt           string                                                `json:"updated_at"`
+}
+
+// BookingSpanResponse is another booking occupying screens in a building, for calendars and
+// conflict details
+type BookingSpanResponse struct {
+	BookingId  int    `json:"booking_id"`
+	ClientName string `json:"client_name"`
+	Status     string `json:"status"`
+	StartDate  string `json:"start_date"`
+	EndDate    string `json:"end_date"`
+	Screens    int    `json:"screens"`
+}
+
+// BookingConflictResponse explains why a building cannot take a booking: on PeakDate the
+// overlapping bookings already use PeakBooked of Capacity screens
+type BookingConflictResponse struct {
+	BuildingId   int                   `json:"building_id"`
+	BuildingName string                `json:"building_name"`
+	Capacity     int                   `json:"capacity"`
+	Requested    int                   `json:"requested"`
+	PeakBooked   int                   `json:"peak_booked"`
+	PeakDate     string                `json:"peak_date"`
+	Bookings     []BookingSpanResponse `json:"bookings"`
+}
+
+type AvailabilityDayResponse struct {
+	Date      string `json:"date"`
+	Confirmed int    `json:"confirmed"`
+	Held      int    `json:"held"`
+	Available int    `json:"available"`
+}
+
+// BuildingAvailabilityResponse is one building's calendar. CapacityKnown is false when no
+// proposal records a screen count, in which case the building is treated as one screen.
+type BuildingAvailabilityResponse struct {
+	BuildingId    int                       `json:"building_id"`
+	BuildingName  string                    `json:"building_name"`
+	Capacity      int                       `json:"capacity"`
+	CapacityKnown bool                      `json:"capacity_known"`
+	MinAvailable  int                       `json:"min_available"`
+	Available     bool                      `json:"available"`
+	Days          []AvailabilityDayResponse `json:"days"`
+	Bookings      []BookingSpanResponse     `json:"bookings"`
+}
+
+// AvailabilityResponse is calendar data for a set of buildings; Available is true when every
+// building has Screens free on every day
+type AvailabilityResponse struct {
+	From      string                         `json:"from"`
+	To        string                         `json:"to"`
+	Screens   int                            `json:"screens"`
+	Available bool                           `json:"available"`
+	Buildings []BuildingAvailabilityResponse `json:"buildings"`
+}
@@ -2,12 +2,16 @@ package branch
 
 import "strings"
 
+// CreateBranchRequest optionally places the new entry under a mother brand
 type CreateBranchRequest struct {
-	Name string `json:"name" validate:"required"`
+	Name          string `json:"name" validate:"required"`
+	MotherBrandId *int   `json:"mother_brand_id"`
 }
 
+// UpdateBranchRequest replaces the mother brand of the entry; a null mother_brand_id detaches it
 type UpdateBranchRequest struct {
-	Name string `json:"name" validate:"required"`
+	Name          string `json:"name" validate:"required"`
+	MotherBrandId *int   `json:"mother_brand_id"`
 }
 
 type BranchRequestFindAll struct {
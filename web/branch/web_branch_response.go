@@ -1,8 +1,10 @@
 package branch
 
 type BranchResponse struct {
-	Id        int    `json:"id"`
-	Name      string `json:"name"`
-	CreatedAt string `json:"created_at"`
-	UpdatedAt string `json:"updated_at"`
+	Id              int    `json:"id"`
+	Name            string `json:"name"`
+	MotherBrandId   *int   `json:"mother_brand_id"`
+	MotherBrandName string `json:"mother_brand_name"`
+	CreatedAt       string `json:"created_at"`
+	UpdatedAt       string `json:"updated_at"`
 }
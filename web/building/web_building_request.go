@@ -184,14 +184,17 @@ type ExportMappingRequest struct {
 	Ids []int `json:"ids"`
 }
 
-// ExportMappingByFilterRequest is the POST body for export by filters (bounds always null = all matching)
+// ExportMappingByFilterRequest is the POST body for export by filters (bounds always null = all matching).
+// ViewId, when set, replaces the filters with those of a saved view.
 type ExportMappingByFilterRequest struct {
+	ViewId    int                  `json:"view_id"`
 	Filters   ExportMappingFilters `json:"filters"`
 	MapCenter *struct {
 		Lat float64 `json:"lat"`
 		Lng float64 `json:"lng"`
 	} `json:"map_center"`
 	Bounds interface{} `json:"bounds"` // always null from frontend; do not use for export
+	SortBy string      `json:"sort_by"` // "distance" sorts by distance to the nearest matched POI / center
 }
 
 // ExportMappingFilters mirrors frontend MappingFilters for export
@@ -209,7 +212,12 @@ type ExportMappingFilters struct {
 	Lat                   *float64 `json:"lat"`
 	Lng                   *float64 `json:"lng"`
 	Radius                *float64 `json:"radius"` // km; backend expects meters
+	CatchmentMode         string   `json:"catchment_mode"` // radius (default), drive or walk
+	TravelMinutes         *int     `json:"travel_minutes"` // isochrone size for drive / walk catchments
 	PoiIDs                []int    `json:"poi_ids"` // POI category ids; multi-select (matches frontend MappingFilters.poi_ids)
+	ProvinceCodes         []string `json:"province_codes"`    // official admin_boundaries codes
+	CityCodes             []string `json:"city_codes"`        // official admin_boundaries codes
+	SubdistrictCodes      []string `json:"subdistrict_codes"` // official admin_boundaries codes
 	Polygon []struct {
 		Lat float64 `json:"lat"`
 		Lng float64 `json:"lng"`
@@ -227,13 +235,16 @@ type MappingBounds struct {
 
 // MappingByFilterRequest is the POST body for /mapping-buildings.
 // Shares the filter projection with the export endpoint but carries a typed, optional bounds field.
+// ViewId, when set, replaces the filters with those of a saved view; bounds still apply.
 type MappingByFilterRequest struct {
+	ViewId    int                  `json:"view_id"`
 	Filters   ExportMappingFilters `json:"filters"`
 	MapCenter *struct {
 		Lat float64 `json:"lat"`
 		Lng float64 `json:"lng"`
 	} `json:"map_center"`
 	Bounds *MappingBounds `json:"bounds"`
+	SortBy string         `json:"sort_by"` // "distance" sorts by distance to the nearest matched POI / center
 }
 
 // BuildMappingRequestFromBody maps the POST body into the internal MappingBuildingRequest, including bounds.
@@ -242,6 +253,7 @@ func BuildMappingRequestFromBody(body *MappingByFilterRequest) MappingBuildingRe
 		Filters:   body.Filters,
 		MapCenter: body.MapCenter,
 		Bounds:    nil,
+		SortBy:    body.SortBy,
 	})
 	if body.Bounds != nil {
 		req.SetMinLat(fmt.Sprintf("%v", body.Bounds.MinLat))
@@ -288,6 +300,15 @@ func BuildMappingRequestFromExportBody(body *ExportMappingByFilterRequest) Mappi
 	if len(f.BuildingRestrictionIds) > 0 {
 		req.SetBuildingRestrictionIds(intSliceToComma(f.BuildingRestrictionIds))
 	}
+	if len(f.ProvinceCodes) > 0 {
+		req.SetProvinceCodes(strings.Join(f.ProvinceCodes, ","))
+	}
+	if len(f.CityCodes) > 0 {
+		req.SetCityCodes(strings.Join(f.CityCodes, ","))
+	}
+	if len(f.SubdistrictCodes) > 0 {
+		req.SetSubdistrictCodes(strings.Join(f.SubdistrictCodes, ","))
+	}
 	if f.Lat != nil {
 		req.SetLat(fmt.Sprintf("%v", *f.Lat))
 	} else if body.MapCenter != nil {
@@ -301,6 +322,12 @@ func BuildMappingRequestFromExportBody(body *ExportMappingByFilterRequest) Mappi
 	if f.Radius != nil {
 		req.SetRadius(fmt.Sprintf("%.0f", *f.Radius*1000)) // km -> meters
 	}
+	if f.CatchmentMode != "" {
+		req.SetCatchmentMode(f.CatchmentMode)
+	}
+	if f.TravelMinutes != nil {
+		req.SetTravelMinutes(strconv.Itoa(*f.TravelMinutes))
+	}
 	if len(f.PoiIDs) > 0 {
 		req.SetPOIId(intSliceToComma(f.PoiIDs))
 	}
@@ -308,6 +335,9 @@ func BuildMappingRequestFromExportBody(body *ExportMappingByFilterRequest) Mappi
 		polyJSON, _ := json.Marshal(f.Polygon)
 		req.SetPolygon(string(polyJSON))
 	}
+	if body.SortBy != "" {
+		req.SetSortBy(body.SortBy)
+	}
 	// Bounds are never set: export is all buildings matching filters
 	return req
 }
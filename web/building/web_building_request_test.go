@@ -161,3 +161,19 @@ func TestBuildMappingRequestFromBody_RadiusKmToMeters(t *testing.T) {
 	// 2.5 km -> 2500 m
 	assert.Equal(t, "2500", req.GetRadius())
 }
+
+func TestMappingByFilterRequest_DecodesCatchmentPayload(t *testing.T) {
+	raw := []byte(`{
+		"filters": {"poi_ids": [90], "catchment_mode": "drive", "travel_minutes": 10}
+	}`)
+
+	var body MappingByFilterRequest
+	err := json.Unmarshal(raw, &body)
+	assert.NoError(t, err)
+
+	req := BuildMappingRequestFromBody(&body)
+	assert.Equal(t, "90", req.GetPOIId())
+	assert.Equal(t, "drive", req.GetCatchmentMode())
+	assert.Equal(t, "10", req.GetTravelMinutes())
+	assert.Equal(t, "", req.GetRadius())
+}
@@ -11,15 +11,21 @@ type MappingBuildingRequest struct {
 	lcdPresence            string
 	salesPackageIds        string
 	buildingRestrictionIds string
+	provinceCodes          string
+	cityCodes              string
+	subdistrictCodes       string
 	lat                    string
 	lng                    string
 	radius                 string
+	catchmentMode          string
+	travelMinutes          string
 	poiId                  string
 	polygon                string
 	minLat                 string
 	maxLat                 string
 	minLng                 string
 	maxLng                 string
+	sortBy                 string
 }
 
 func (r *MappingBuildingRequest) SetBuildingType(buildingType string) {
@@ -173,3 +179,51 @@ func (r *MappingBuildingRequest) SetBuildingRestrictionIds(buildingRestrictionId
 func (r *MappingBuildingRequest) GetBuildingRestrictionIds() string {
 	return r.buildingRestrictionIds
 }
+
+func (r *MappingBuildingRequest) SetProvinceCodes(provinceCodes string) {
+	r.provinceCodes = provinceCodes
+}
+
+func (r *MappingBuildingRequest) GetProvinceCodes() string {
+	return r.provinceCodes
+}
+
+func (r *MappingBuildingRequest) SetCityCodes(cityCodes string) {
+	r.cityCodes = cityCodes
+}
+
+func (r *MappingBuildingRequest) GetCityCodes() string {
+	return r.cityCodes
+}
+
+func (r *MappingBuildingRequest) SetSubdistrictCodes(subdistrictCodes string) {
+	r.subdistrictCodes = subdistrictCodes
+}
+
+func (r *MappingBuildingRequest) GetSubdistrictCodes() string {
+	return r.subdistrictCodes
+}
+
+func (r *MappingBuildingRequest) SetCatchmentMode(catchmentMode string) {
+	r.catchmentMode = catchmentMode
+}
+
+func (r *MappingBuildingRequest) GetCatchmentMode() string {
+	return r.catchmentMode
+}
+
+func (r *MappingBuildingRequest) SetTravelMinutes(travelMinutes string) {
+	r.travelMinutes = travelMinutes
+}
+
+func (r *MappingBuildingRequest) GetTravelMinutes() string {
+	return r.travelMinutes
+}
+
+func (r *MappingBuildingRequest) SetSortBy(sortBy string) {
+	r.sortBy = sortBy
+}
+
+func (r *MappingBuildingRequest) GetSortBy() string {
+	return r.sortBy
+}
@@ -5,6 +5,14 @@ type MappingBuildingImageResponse struct {
 	Path string `json:"path"`
 }
 
+// MappingNearestPOIPointResponse is the closest matched POI point for a building in a POI-filtered mapping request
+type MappingNearestPOIPointResponse struct {
+	Id    int    `json:"id"`
+	POIId int    `json:"poi_id"`
+	Name  string `json:"name"`
+	Brand string `json:"brand"`
+}
+
 type MappingBuildingResponse struct {
 	Id                 int                            `json:"id"`
 	ExternalBuildingId string                         `json:"external_building_id"`
@@ -15,6 +23,9 @@ type MappingBuildingResponse struct {
 	Subdistrict        string                         `json:"subdistrict"`
 	Citytown           string                         `json:"citytown"`
 	Province           string                         `json:"province"`
+	ProvinceCode       string                         `json:"province_code"`
+	CityCode           string                         `json:"city_code"`
+	SubdistrictCode    string                         `json:"subdistrict_code"`
 	Address            string                         `json:"address"`
 	BuildingStatus     string                         `json:"building_status"`
 	Sellable           string                         `json:"sellable"`
@@ -23,9 +34,15 @@ type MappingBuildingResponse struct {
 	Longitude          float64                        `json:"longitude"`
 	LcdPresenceStatus  string                         `json:"lcd_presence_status"`
 	Images             []MappingBuildingImageResponse `json:"images"`
+	// Set only when the request filters by poi_id or lat/lng + radius / catchment
+	NearestPOIPoint *MappingNearestPOIPointResponse `json:"nearest_poi_point,omitempty"`
+	DistanceMeters  *float64                        `json:"distance_meters,omitempty"`
 }
 
 type MappingBuildingsResponse struct {
 	Data   []MappingBuildingResponse `json:"data"`
 	Totals map[string]int            `json:"totals"` // Dynamic totals for all building types
+	// RegionTotals counts buildings per official region code, keyed by level (province, city, subdistrict).
+	// Buildings not yet resolved to a boundary are counted under "unassigned".
+	RegionTotals map[string]map[string]int `json:"region_totals"`
 }
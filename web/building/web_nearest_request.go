@@ -0,0 +1,17 @@
+package building
+
+// NearestBuildingsRequest holds query params for GET /buildings-nearest
+type NearestBuildingsRequest struct {
+	Lat          float64
+	Lng          float64
+	Limit        int
+	BuildingType string // comma-separated, case-insensitive
+}
+
+// NearbyPOIsRequest holds query params for GET /buildings/:id/nearby-pois
+type NearbyPOIsRequest struct {
+	Limit          int
+	CategoryIds    string // comma-separated
+	SubCategoryIds string // comma-separated
+	MotherBrandIds string // comma-separated
+}
@@ -0,0 +1,31 @@
+package building
+
+type NearestBuildingResponse struct {
+	Id                 int     `json:"id"`
+	ExternalBuildingId string  `json:"external_building_id"`
+	Name               string  `json:"name"`
+	BuildingType       string  `json:"building_type"`
+	GradeResource      string  `json:"grade_resource"`
+	Subdistrict        string  `json:"subdistrict"`
+	Citytown           string  `json:"citytown"`
+	Province           string  `json:"province"`
+	Latitude           float64 `json:"latitude"`
+	Longitude          float64 `json:"longitude"`
+	LcdPresenceStatus  string  `json:"lcd_presence_status"`
+	DistanceMeters     float64 `json:"distance_meters"`
+}
+
+type NearbyPOIPointResponse struct {
+	Id              int     `json:"id"`
+	POIId           int     `json:"poi_id"`
+	POIName         string  `json:"poi_name"`
+	Address         string  `json:"address"`
+	Brand           string  `json:"brand"`
+	BranchName      string  `json:"branch_name"`
+	CategoryName    string  `json:"category_name"`
+	SubCategoryName string  `json:"sub_category_name"`
+	MotherBrandName string  `json:"mother_brand_name"`
+	Latitude        float64 `json:"latitude"`
+	Longitude       float64 `json:"longitude"`
+	DistanceMeters  float64 `json:"distance_meters"`
+}
@@ -0,0 +1,19 @@
+package building
+
+// POIDensityRequest is the POST body for /analytics/poi-density and its export.
+// The building set is BuildingIds when given, otherwise every building matching Filters
+// (the same projection as /mapping-buildings, without bounds).
+type POIDensityRequest struct {
+	BuildingIds    []int                 `json:"building_ids"`
+	Filters        *ExportMappingFilters `json:"filters"`
+	RingMeters     []int                 `json:"ring_meters"` // default 250, 500, 1000
+	CategoryIds    []int                 `json:"category_ids"`
+	MotherBrandIds []int                 `json:"mother_brand_ids"`
+	// SortBy is "total" (default), "category", "mother_brand" or "name".
+	// category / mother_brand sort by the count of SortId in SortRingMeters.
+	SortBy         string `json:"sort_by"`
+	SortId         int    `json:"sort_id"`
+	SortRingMeters int    `json:"sort_ring_meters"` // default the largest ring
+	SortDirection  string `json:"sort_direction"`   // asc / desc; counts default to desc, name to asc
+	Limit          int    `json:"limit"`            // 0 returns every building
+}
@@ -0,0 +1,35 @@
+package building
+
+type POIDensityCountResponse struct {
+	Id    int    `json:"id"`
+	Name  string `json:"name"`
+	Count int    `json:"count"`
+}
+
+// POIDensityRingResponse holds the POI points within RingMeters of a building
+type POIDensityRingResponse struct {
+	RingMeters   int                       `json:"ring_meters"`
+	Total        int                       `json:"total"`
+	Categories   []POIDensityCountResponse `json:"categories"`
+	MotherBrands []POIDensityCountResponse `json:"mother_brands"`
+}
+
+type POIDensityBuildingResponse struct {
+	Id                 int                      `json:"id"`
+	ExternalBuildingId string                   `json:"external_building_id"`
+	Name               string                   `json:"name"`
+	BuildingType       string                   `json:"building_type"`
+	GradeResource      string                   `json:"grade_resource"`
+	Subdistrict        string                   `json:"subdistrict"`
+	Citytown           string                   `json:"citytown"`
+	Latitude           float64                  `json:"latitude"`
+	Longitude          float64                  `json:"longitude"`
+	Rings              []POIDensityRingResponse `json:"rings"`
+}
+
+type POIDensityResponse struct {
+	RingMeters []int                        `json:"ring_meters"`
+	Data       []POIDensityBuildingResponse `json:"data"`
+	// SkippedWithoutLocation counts requested buildings that have no coordinates
+	SkippedWithoutLocation int `json:"skipped_without_location"`
+}
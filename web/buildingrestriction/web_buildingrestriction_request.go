@@ -4,14 +4,30 @@ import (
 	"strings"
 )
 
+// CreateBuildingRestrictionRequest lists the restricted buildings and, optionally, the categories
+// and mother brands barred from them between ValidFrom and ValidUntil (YYYY-MM-DD, either may be
+// empty). Enforcement defaults to warn; Visibility is private, team or public and defaults to team.
 type CreateBuildingRestrictionRequest struct {
-	Name        string `json:"name" validate:"required"`
-	BuildingIds []int  `json:"building_ids" validate:"required,min=1"`
+	Name           string `json:"name" validate:"required"`
+	BuildingIds    []int  `json:"building_ids" validate:"required,min=1"`
+	CategoryIds    []int  `json:"category_ids"`
+	MotherBrandIds []int  `json:"mother_brand_ids"`
+	Enforcement    string `json:"enforcement" validate:"omitempty,oneof=warn block"`
+	ValidFrom      string `json:"valid_from"`
+	ValidUntil     string `json:"valid_until"`
+	Visibility     string `json:"visibility" validate:"omitempty,oneof=private team public"`
 }
 
+// UpdateBuildingRestrictionRequest keeps the current visibility when Visibility is empty
 type UpdateBuildingRestrictionRequest struct {
-	Name        string `json:"name" validate:"required"`
-	BuildingIds []int  `json:"building_ids" validate:"required,min=1"`
+	Name           string `json:"name" validate:"required"`
+	BuildingIds    []int  `json:"building_ids" validate:"required,min=1"`
+	CategoryIds    []int  `json:"category_ids"`
+	MotherBrandIds []int  `json:"mother_brand_ids"`
+	Enforcement    string `json:"enforcement" validate:"omitempty,oneof=warn block"`
+	ValidFrom      string `json:"valid_from"`
+	ValidUntil     string `json:"valid_until"`
+	Visibility     string `json:"visibility" validate:"omitempty,oneof=private team public"`
 }
 
 type BuildingRestrictionRequestFindAll struct {
@@ -10,10 +10,36 @@ type BuildingRefResponse struct {
 	BuildingType string `json:"building_type"`
 }
 
+// RuleRefResponse is a category or mother brand barred by a restriction
+type RuleRefResponse struct {
+	Id   int    `json:"id"`
+	Name string `json:"name"`
+}
+
 type BuildingRestrictionResponse struct {
-	Id        int                      `json:"id"`
-	Name      string                   `json:"name"`
-	Buildings []BuildingRefResponse    `json:"buildings"`
-	CreatedAt string                   `json:"created_at"`
-	UpdatedAt string                   `json:"updated_at"`
+	Id           int                   `json:"id"`
+	Name         string                `json:"name"`
+	Enforcement  string                `json:"enforcement"`
+	ValidFrom    string                `json:"valid_from"`
+	ValidUntil   string                `json:"valid_until"`
+	Buildings    []BuildingRefResponse `json:"buildings"`
+	Categories   []RuleRefResponse     `json:"categories"`
+	MotherBrands []RuleRefResponse     `json:"mother_brands"`
+	CreatedBy    *int                  `json:"created_by"`
+	UpdatedBy    *int                  `json:"updated_by"`
+	Visibility   string                `json:"visibility"`
+	CreatedAt    string                `json:"created_at"`
+	UpdatedAt    string                `json:"updated_at"`
+}
+
+// RestrictionViolationResponse is one restriction barring the advertiser from one building. Reason
+// is "category" or "mother_brand" and Advertiser names the matching category or mother brand.
+type RestrictionViolationResponse struct {
+	RestrictionId   int    `json:"restriction_id"`
+	RestrictionName string `json:"restriction_name"`
+	Enforcement     string `json:"enforcement"`
+	BuildingId      int    `json:"building_id"`
+	BuildingName    string `json:"building_name"`
+	Reason          string `json:"reason"`
+	Advertiser      string `json:"advertiser"`
 }
@@ -6,3 +6,21 @@ type CategoryResponse struct {
 	CreatedAt string `json:"created_at"`
 	UpdatedAt string `json:"updated_at"`
 }
+
+// CategoryTreeResponse lists every category with its sub-categories for cascading dropdowns.
+// UnassignedSubCategories have no category yet and fit under any of them.
+type CategoryTreeResponse struct {
+	Categories              []CategoryTreeNode        `json:"categories"`
+	UnassignedSubCategories []CategoryTreeSubCategory `json:"unassigned_sub_categories"`
+}
+
+type CategoryTreeNode struct {
+	Id            int                       `json:"id"`
+	Name          string                    `json:"name"`
+	SubCategories []CategoryTreeSubCategory `json:"sub_categories"`
+}
+
+type CategoryTreeSubCategory struct {
+	Id   int    `json:"id"`
+	Name string `json:"name"`
+}
@@ -0,0 +1,9 @@
+package importjob
+
+// ImportJobOptions are the multipart form fields sent alongside an import job upload. Mode and
+// DeleteMissing only apply to POI jobs (see poi.POIImportOptions); OnError applies to every kind.
+type ImportJobOptions struct {
+	Mode          string `json:"mode"`
+	DeleteMissing bool   `json:"delete_missing"`
+	OnError       string `json:"on_error"`
+}
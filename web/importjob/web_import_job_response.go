@@ -0,0 +1,24 @@
+package importjob
+
+import "github.com/malikabdulaziz/tmn-backend/web"
+
+// ImportJobResponse is the state of a background import. ProgressDone/ProgressTotal count the
+// units the import writes (POI points, sales packages, building restrictions or master data rows).
+// Report is set once the file has been validated; Error explains a failed job.
+type ImportJobResponse struct {
+	Id              int               `json:"id"`
+	Kind            string            `json:"kind"`
+	FileType        string            `json:"file_type"`
+	Options         ImportJobOptions  `json:"options"`
+	Status          string            `json:"status"`
+	ProgressDone    int               `json:"progress_done"`
+	ProgressTotal   int               `json:"progress_total"`
+	ProgressPercent int               `json:"progress_percent"`
+	CancelRequested bool              `json:"cancel_requested"`
+	ImportedCount   int               `json:"imported_count"`
+	Report          *web.ImportReport `json:"report"`
+	Error           string            `json:"error,omitempty"`
+	CreatedAt       string            `json:"created_at"`
+	StartedAt       string            `json:"started_at,omitempty"`
+	FinishedAt      string            `json:"finished_at,omitempty"`
+}
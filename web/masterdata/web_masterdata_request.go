@@ -0,0 +1,6 @@
+package masterdata
+
+// MergeMasterDataRequest lists the entries merged into the one named in the path
+type MergeMasterDataRequest struct {
+	SourceIds []int `json:"source_ids" validate:"required,min=1,dive,min=1"`
+}
@@ -0,0 +1,12 @@
+package masterdata
+
+// MasterDataMergeResponse is the entry the sources were merged into. Aliases are the source names
+// imports now resolve to it; Repointed counts the rows that were moved over.
+type MasterDataMergeResponse struct {
+	Type      string   `json:"type"`
+	Id        int      `json:"id"`
+	Name      string   `json:"name"`
+	MergedIds []int    `json:"merged_ids"`
+	Aliases   []string `json:"aliases"`
+	Repointed int      `json:"repointed"`
+}
@@ -6,3 +6,21 @@ type MotherBrandResponse struct {
 	CreatedAt string `json:"created_at"`
 	UpdatedAt string `json:"updated_at"`
 }
+
+// MotherBrandTreeResponse lists every mother brand with its branches for cascading dropdowns.
+// UnassignedBranches have no mother brand yet and fit under any of them.
+type MotherBrandTreeResponse struct {
+	MotherBrands       []MotherBrandTreeNode   `json:"mother_brands"`
+	UnassignedBranches []MotherBrandTreeBranch `json:"unassigned_branches"`
+}
+
+type MotherBrandTreeNode struct {
+	Id       int                     `json:"id"`
+	Name     string                  `json:"name"`
+	Branches []MotherBrandTreeBranch `json:"branches"`
+}
+
+type MotherBrandTreeBranch struct {
+	Id   int    `json:"id"`
+	Name string `json:"name"`
+}
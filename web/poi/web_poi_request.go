@@ -5,12 +5,13 @@ import (
 )
 
 type POIPointInput struct {
-	Id        *int    `json:"id,omitempty"`
-	POIName   string  `json:"poi_name" validate:"required"`
-	Address   string  `json:"address"`
-	Latitude  float64 `json:"latitude" validate:"required"`
-	Longitude float64 `json:"longitude" validate:"required"`
-	BranchId  *int    `json:"branch_id,omitempty"`
+	Id          *int    `json:"id,omitempty"`
+	POIName     string  `json:"poi_name" validate:"required"`
+	Address     string  `json:"address"`
+	Latitude    float64 `json:"latitude"`
+	Longitude   float64 `json:"longitude"`
+	ExternalKey string  `json:"external_key" validate:"max=100"`
+	BranchId    *int    `json:"branch_id,omitempty"`
 }
 
 type CreatePOIRequest struct {
@@ -111,3 +112,24 @@ func (r *POIRequestFindAll) SetMotherBrandIds(ids string) {
 func (r *POIRequestFindAll) GetMotherBrandIds() string {
 	return r.motherBrandIds
 }
+
+// ConfirmPOIImportRequest applies a previously generated import preview
+type ConfirmPOIImportRequest struct {
+	Token string `json:"token" validate:"required"`
+}
+
+// POI import modes. Replace deletes every POI of an imported brand and recreates it; upsert
+// updates the brand's POI and points in place so their ids and colors survive the import.
+const (
+	POIImportModeReplace = "replace"
+	POIImportModeUpsert  = "upsert"
+)
+
+// POIImportOptions are the multipart form fields sent alongside an import or preview upload.
+// DeleteMissing only applies to upsert: points of an imported brand that are not in the file
+// are deleted instead of kept. OnError is "abort" or "skip" (see importer.ParseOnError).
+type POIImportOptions struct {
+	Mode          string `json:"mode"`
+	DeleteMissing bool   `json:"delete_missing"`
+	OnError       string `json:"on_error"`
+}
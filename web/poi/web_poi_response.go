@@ -1,15 +1,21 @@
 package poi
 
+import "github.com/malikabdulaziz/tmn-backend/web"
+
 type POIPointResponse struct {
-	Id        int     `json:"id"`
-	POIName   string  `json:"poi_name"`
-	Address   string  `json:"address"`
-	Latitude  float64 `json:"latitude"`
-	Longitude float64 `json:"longitude"`
-	Branch    string  `json:"branch"`
-	BranchId  *int    `json:"branch_id,omitempty"`
-	CreatedAt string  `json:"created_at"`
-	UpdatedAt string  `json:"updated_at"`
+	Id          int     `json:"id"`
+	POIName     string  `json:"poi_name"`
+	Address     string  `json:"address"`
+	Latitude    float64 `json:"latitude"`
+	Longitude   float64 `json:"longitude"`
+	ExternalKey string  `json:"external_key"`
+	Branch      string  `json:"branch"`
+	BranchId    *int    `json:"branch_id,omitempty"`
+	CreatedAt   string  `json:"created_at"`
+	UpdatedAt   string  `json:"updated_at"`
+	// OutsideIndonesia flags a coordinate outside Indonesia's bounding box, usually swapped or
+	// mistyped lat/lng
+	OutsideIndonesia bool `json:"outside_indonesia"`
 }
 
 type POIResponse struct {
@@ -26,3 +32,76 @@ type POIResponse struct {
 	CreatedAt     string             `json:"created_at"`
 	UpdatedAt     string             `json:"updated_at"`
 }
+
+// POIImportPreviewResponse is the dry-run result of a POI import. Confirming Token applies
+// exactly this diff; the confirm is rejected if the data changed in the meantime. RowErrors
+// lists the rows an on_error=skip import leaves out.
+type POIImportPreviewResponse struct {
+	Token              string                     `json:"token"`
+	ExpiresAt          string                     `json:"expires_at"`
+	Options            POIImportOptions           `json:"options"`
+	Summary            POIImportSummary           `json:"summary"`
+	POIs               []POIImportPOIDiff         `json:"pois"`
+	MasterDataToCreate POIImportMasterDataDiff    `json:"master_data_to_create"`
+	CoordinateErrors   []POIImportCoordinateError `json:"coordinate_errors"`
+	RowErrors          []web.ImportRowError       `json:"row_errors"`
+}
+
+type POIImportSummary struct {
+	POIsCreated      int `json:"pois_created"`
+	POIsReplaced     int `json:"pois_replaced"`
+	POIsUpdated      int `json:"pois_updated"`
+	PointsAdded      int `json:"points_added"`
+	PointsRemoved    int `json:"points_removed"`
+	PointsMoved      int `json:"points_moved"`
+	PointsUnchanged  int `json:"points_unchanged"`
+	PointsKept       int `json:"points_kept"`
+	CoordinateErrors int `json:"coordinate_errors"`
+	RowsSkipped      int `json:"rows_skipped"`
+	PointsGeocoded   int `json:"points_geocoded"`
+	AddressesFilled  int `json:"addresses_filled"`
+}
+
+// POIImportPOIDiff describes what happens to one brand. Action is "create" for a new brand,
+// "replace" when existing POIs with that brand are deleted and recreated, or "update" when an
+// upsert import changes them in place. PointsKept counts points an upsert leaves untouched
+// because they are missing from the file and delete_missing is off.
+type POIImportPOIDiff struct {
+	Brand           string               `json:"brand"`
+	Action          string               `json:"action"`
+	ExistingPOIIds  []int                `json:"existing_poi_ids"`
+	Rows            []int                `json:"rows"`
+	PointsAdded     []POIImportPointDiff `json:"points_added"`
+	PointsRemoved   []POIImportPointDiff `json:"points_removed"`
+	PointsMoved     []POIImportPointDiff `json:"points_moved"`
+	PointsUnchanged int                  `json:"points_unchanged"`
+	PointsKept      int                  `json:"points_kept"`
+}
+
+// POIImportPointDiff is a point matched by external key, or else by POI name + address. Row is the file row (0 for removed
+// points); ExistingPointId and the previous coordinates refer to the point currently stored.
+type POIImportPointDiff struct {
+	Row               int      `json:"row,omitempty"`
+	ExistingPointId   *int     `json:"existing_point_id,omitempty"`
+	ExternalKey       string   `json:"external_key,omitempty"`
+	POIName           string   `json:"poi_name"`
+	Address           string   `json:"address"`
+	Latitude          float64  `json:"latitude"`
+	Longitude         float64  `json:"longitude"`
+	PreviousLatitude  *float64 `json:"previous_latitude,omitempty"`
+	PreviousLongitude *float64 `json:"previous_longitude,omitempty"`
+}
+
+type POIImportMasterDataDiff struct {
+	Categories    []string `json:"categories"`
+	SubCategories []string `json:"sub_categories"`
+	MotherBrands  []string `json:"mother_brands"`
+	Branches      []string `json:"branches"`
+}
+
+type POIImportCoordinateError struct {
+	Row    int    `json:"row"`
+	Brand  string `json:"brand"`
+	Value  string `json:"value"`
+	Reason string `json:"reason"`
+}
@@ -0,0 +1,100 @@
+package poiduplicate
+
+// Defaults for POIDuplicateRequestFindAll; see repositories/poiduplicate.DuplicateFilter
+const (
+	DefaultRadiusMeters   = 50
+	MaxRadiusMeters       = 1000
+	DefaultSameSpotMeters = 10
+	DefaultMinSimilarity  = 0.4
+	DefaultTake           = 50
+)
+
+type POIDuplicateRequestFindAll struct {
+	take           int
+	skip           int
+	radiusMeters   float64
+	sameSpotMeters *float64
+	minSimilarity  *float64
+	categoryIds    string
+	motherBrandIds string
+}
+
+func (r *POIDuplicateRequestFindAll) SetSkip(skip int) {
+	r.skip = skip
+}
+
+func (r *POIDuplicateRequestFindAll) SetTake(take int) {
+	r.take = take
+}
+
+func (r *POIDuplicateRequestFindAll) GetSkip() int {
+	return r.skip
+}
+
+func (r *POIDuplicateRequestFindAll) GetTake() int {
+	if r.take <= 0 {
+		return DefaultTake
+	}
+	return r.take
+}
+
+func (r *POIDuplicateRequestFindAll) SetRadiusMeters(radius float64) {
+	r.radiusMeters = radius
+}
+
+func (r *POIDuplicateRequestFindAll) GetRadiusMeters() float64 {
+	if r.radiusMeters == 0 {
+		return DefaultRadiusMeters
+	}
+	return r.radiusMeters
+}
+
+func (r *POIDuplicateRequestFindAll) SetSameSpotMeters(meters float64) {
+	r.sameSpotMeters = &meters
+}
+
+func (r *POIDuplicateRequestFindAll) GetSameSpotMeters() float64 {
+	if r.sameSpotMeters == nil {
+		return DefaultSameSpotMeters
+	}
+	return *r.sameSpotMeters
+}
+
+func (r *POIDuplicateRequestFindAll) SetMinSimilarity(similarity float64) {
+	r.minSimilarity = &similarity
+}
+
+func (r *POIDuplicateRequestFindAll) GetMinSimilarity() float64 {
+	if r.minSimilarity == nil {
+		return DefaultMinSimilarity
+	}
+	return *r.minSimilarity
+}
+
+func (r *POIDuplicateRequestFindAll) SetCategoryIds(ids string) {
+	r.categoryIds = ids
+}
+
+func (r *POIDuplicateRequestFindAll) GetCategoryIds() string {
+	return r.categoryIds
+}
+
+func (r *POIDuplicateRequestFindAll) SetMotherBrandIds(ids string) {
+	r.motherBrandIds = ids
+}
+
+func (r *POIDuplicateRequestFindAll) GetMotherBrandIds() string {
+	return r.motherBrandIds
+}
+
+// MergePOIDuplicateRequest keeps KeepPointId and folds MergePointId into it
+type MergePOIDuplicateRequest struct {
+	KeepPointId  int `json:"keep_point_id" validate:"required"`
+	MergePointId int `json:"merge_point_id" validate:"required"`
+}
+
+// DismissPOIDuplicateRequest marks two points as distinct outlets
+type DismissPOIDuplicateRequest struct {
+	PointId      int `json:"point_id" validate:"required"`
+	OtherPointId int `json:"other_point_id" validate:"required"`
+}
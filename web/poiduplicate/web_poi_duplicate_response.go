@@ -0,0 +1,31 @@
+package poiduplicate
+
+type POIDuplicatePointResponse struct {
+	Id          int     `json:"id"`
+	POIId       int     `json:"poi_id"`
+	Brand       string  `json:"brand"`
+	POIName     string  `json:"poi_name"`
+	Address     string  `json:"address"`
+	Latitude    float64 `json:"latitude"`
+	Longitude   float64 `json:"longitude"`
+	ExternalKey string  `json:"external_key"`
+	Branch      string  `json:"branch"`
+	BranchId    *int    `json:"branch_id,omitempty"`
+}
+
+// POIDuplicateResponse is a candidate pair; NameSimilarity is the pg_trgm similarity (0..1) of
+// "brand poi_name" of both points
+type POIDuplicateResponse struct {
+	Point          POIDuplicatePointResponse `json:"point"`
+	OtherPoint     POIDuplicatePointResponse `json:"other_point"`
+	DistanceMeters float64                   `json:"distance_meters"`
+	NameSimilarity float64                   `json:"name_similarity"`
+}
+
+// MergePOIDuplicateResponse is the kept point after the merge. RemovedPOIId is set when the
+// merged point was the last point of its POI and that POI was deleted too.
+type MergePOIDuplicateResponse struct {
+	Point         POIDuplicatePointResponse `json:"point"`
+	MergedPointId int                       `json:"merged_point_id"`
+	RemovedPOIId  *int                      `json:"removed_poi_id,omitempty"`
+}
@@ -0,0 +1,17 @@
+package proposal
+
+// Proposal output formats
+const (
+	FormatPDF  = "pdf"
+	FormatPPTX = "pptx"
+)
+
+// GenerateProposalRequest asks for a proposal document of a sales package. Format defaults to
+// pdf, Template to the default template and Version to the latest version of the package;
+// ClientName is printed on the cover when set.
+type GenerateProposalRequest struct {
+	Format     string
+	Template   string
+	ClientName string
+	Version    int
+}
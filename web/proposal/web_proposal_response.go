@@ -0,0 +1,19 @@
+package proposal
+
+// ProposalFileResponse is a generated proposal ready to be sent as a download. Version is the
+// sales package version it was generated from.
+type ProposalFileResponse struct {
+	Filename    string
+	ContentType string
+	Content     []byte
+	Version     int
+}
+
+type ProposalTemplateResponse struct {
+	Name        string   `json:"name"`
+	Title       string   `json:"title"`
+	Subtitle    string   `json:"subtitle"`
+	CompanyName string   `json:"company_name"`
+	Sections    []string `json:"sections"`
+	HasLogo     bool     `json:"has_logo"`
+}
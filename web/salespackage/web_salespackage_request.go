@@ -2,16 +2,40 @@ package salespackage
 
 import (
 	"strings"
+
+	webBuilding "github.com/malikabdulaziz/tmn-backend/web/building"
 )
 
+// CreateSalesPackageRequest optionally names the advertiser the package is put together for; its
+// category and mother brand are checked against the building restrictions but not stored.
+// Visibility is private, team or public and defaults to team.
 type CreateSalesPackageRequest struct {
-	Name        string `json:"name" validate:"required"`
-	BuildingIds []int  `json:"building_ids" validate:"required,min=1"`
+	Name                    string `json:"name" validate:"required"`
+	BuildingIds             []int  `json:"building_ids" validate:"required,min=1"`
+	AdvertiserCategoryId    int    `json:"advertiser_category_id"`
+	AdvertiserMotherBrandId int    `json:"advertiser_mother_brand_id"`
+	Visibility              string `json:"visibility" validate:"omitempty,oneof=private team public"`
 }
 
+// UpdateSalesPackageRequest keeps the current visibility when Visibility is empty
 type UpdateSalesPackageRequest struct {
-	Name        string `json:"name" validate:"required"`
-	BuildingIds []int  `json:"building_ids" validate:"required,min=1"`
+	Name                    string `json:"name" validate:"required"`
+	BuildingIds             []int  `json:"building_ids" validate:"required,min=1"`
+	AdvertiserCategoryId    int    `json:"advertiser_category_id"`
+	AdvertiserMotherBrandId int    `json:"advertiser_mother_brand_id"`
+	Visibility              string `json:"visibility" validate:"omitempty,oneof=private team public"`
+}
+
+// SalesPackageFromFilterRequest creates or replaces a sales package with every building matching
+// the mapping filters (the "filters" object of /mapping-buildings). With StoreFilter the filter is
+// kept on the package so it can be refreshed later; without it any stored filter is cleared.
+type SalesPackageFromFilterRequest struct {
+	Name                    string                           `json:"name" validate:"required"`
+	Filters                 webBuilding.ExportMappingFilters `json:"filters"`
+	StoreFilter             bool                             `json:"store_filter"`
+	AdvertiserCategoryId    int                              `json:"advertiser_category_id"`
+	AdvertiserMotherBrandId int                              `json:"advertiser_mother_brand_id"`
+	Visibility              string                           `json:"visibility" validate:"omitempty,oneof=private team public"`
 }
 
 type SalesPackageRequestFindAll struct {
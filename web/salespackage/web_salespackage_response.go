@@ -1,5 +1,10 @@
 package salespackage
 
+import (
+	webBuilding "github.com/malikabdulaziz/tmn-backend/web/building"
+	webBuildingRestriction "github.com/malikabdulaziz/tmn-backend/web/buildingrestriction"
+)
+
 type BuildingRefResponse struct {
 	Id           int    `json:"id"`
 	Name         string `json:"name"`
@@ -14,6 +19,105 @@ type SalesPackageResponse struct {
 	Id        int                   `json:"id"`
 	Name      string                `json:"name"`
 	Buildings []BuildingRefResponse `json:"buildings"`
-	CreatedAt string                `json:"created_at"`
-	UpdatedAt string               `json:"updated_at"`
+	// Filter is set when the package was built from a stored mapping filter
+	Filter            *webBuilding.ExportMappingFilters `json:"filter"`
+	FilterRefreshedAt string                            `json:"filter_refreshed_at,omitempty"`
+	// RestrictionWarnings lists the warn-level building restrictions barring the advertiser given
+	// on create or update
+	RestrictionWarnings []webBuildingRestriction.RestrictionViolationResponse `json:"restriction_warnings,omitempty"`
+	// Version is the version a change created; it is not set when the package is only read
+	Version    int    `json:"version,omitempty"`
+	CreatedBy  *int   `json:"created_by"`
+	UpdatedBy  *int   `json:"updated_by"`
+	Visibility string `json:"visibility"`
+	CreatedAt  string `json:"created_at"`
+	UpdatedAt  string `json:"updated_at"`
+}
+
+// SalesPackageRefreshResponse lists how re-running a package's stored filter changes its buildings.
+// Package is only set once the refresh is applied.
+type SalesPackageRefreshResponse struct {
+	Package   *SalesPackageResponse `json:"package,omitempty"`
+	Added     []BuildingRefResponse `json:"added"`
+	Removed   []BuildingRefResponse `json:"removed"`
+	Unchanged int                   `json:"unchanged"`
+}
+
+// SalesPackageBreakdownResponse adds up the package buildings sharing one building type, city or grade
+type SalesPackageBreakdownResponse struct {
+	Key           string `json:"key"`
+	BuildingCount int    `json:"building_count"`
+	Audience      int    `json:"audience"`
+	Impression    int    `json:"impression"`
+	Screens       int    `json:"screens"`
+}
+
+// SalesPackageWarningResponse flags a package building that should not be sold as is.
+// Code is not_sellable, not_tmn or restricted.
+type SalesPackageWarningResponse struct {
+	BuildingId   int    `json:"building_id"`
+	BuildingName string `json:"building_name"`
+	Code         string `json:"code"`
+	Message      string `json:"message"`
+}
+
+type SalesPackageSummaryResponse struct {
+	Id              int    `json:"id"`
+	Name            string `json:"name"`
+	BuildingCount   int    `json:"building_count"`
+	TotalAudience   int    `json:"total_audience"`
+	TotalImpression int    `json:"total_impression"`
+	TotalScreens    int    `json:"total_screens"`
+	// BuildingsWithoutProposal have no building proposal, so they add no screens
+	BuildingsWithoutProposal int                             `json:"buildings_without_proposal"`
+	ByBuildingType           []SalesPackageBreakdownResponse `json:"by_building_type"`
+	ByCity                   []SalesPackageBreakdownResponse `json:"by_city"`
+	ByGrade                  []SalesPackageBreakdownResponse `json:"by_grade"`
+	Warnings                 []SalesPackageWarningResponse   `json:"warnings"`
+}
+
+// SalesPackageVersionBuildingResponse is a building of a version with its figures at the time.
+// BuildingId is null once the building has been deleted.
+type SalesPackageVersionBuildingResponse struct {
+	BuildingId   *int   `json:"building_id"`
+	BuildingName string `json:"building_name"`
+	Citytown     string `json:"citytown"`
+	BuildingType string `json:"building_type"`
+	Audience     int    `json:"audience"`
+	Impression   int    `json:"impression"`
+	Screens      int    `json:"screens"`
+}
+
+// SalesPackageVersionResponse is an immutable snapshot of a sales package. Buildings are only
+// listed when a single version is requested.
+type SalesPackageVersionResponse struct {
+	Id              int                                   `json:"id"`
+	SalesPackageId  int                                   `json:"sales_package_id"`
+	Version         int                                   `json:"version"`
+	Name            string                                `json:"name"`
+	Filter          *webBuilding.ExportMappingFilters     `json:"filter"`
+	Note            string                                `json:"note"`
+	BuildingCount   int                                   `json:"building_count"`
+	TotalAudience   int                                   `json:"total_audience"`
+	TotalImpression int                                   `json:"total_impression"`
+	TotalScreens    int                                   `json:"total_screens"`
+	CreatedBy       *int                                  `json:"created_by"`
+	Buildings       []SalesPackageVersionBuildingResponse `json:"buildings,omitempty"`
+	CreatedAt       string                                `json:"created_at"`
+}
+
+// SalesPackageVersionDiffResponse compares two versions of a sales package. Deltas are To minus From.
+type SalesPackageVersionDiffResponse struct {
+	From               int                                   `json:"from"`
+	To                 int                                   `json:"to"`
+	FromName           string                                `json:"from_name"`
+	ToName             string                                `json:"to_name"`
+	FilterChanged      bool                                  `json:"filter_changed"`
+	Added              []SalesPackageVersionBuildingResponse `json:"added"`
+	Removed            []SalesPackageVersionBuildingResponse `json:"removed"`
+	Unchanged          int                                   `json:"unchanged"`
+	BuildingCountDelta int                                   `json:"building_count_delta"`
+	AudienceDelta      int                                   `json:"audience_delta"`
+	ImpressionDelta    int                                   `json:"impression_delta"`
+	ScreensDelta       int                                   `json:"screens_delta"`
 }
@@ -9,14 +9,19 @@ type SavedPolygonPointRequest struct {
 	Lng float64 `json:"lng" validate:"required"`
 }
 
+// CreateSavedPolygonRequest saves a polygon owned by the current user. Visibility is private, team
+// or public and defaults to team.
 type CreateSavedPolygonRequest struct {
-	Name   string                    `json:"name" validate:"required"`
-	Points []SavedPolygonPointRequest `json:"points" validate:"required,min=3,dive"`
+	Name       string                     `json:"name" validate:"required"`
+	Points     []SavedPolygonPointRequest `json:"points" validate:"required,min=3,dive"`
+	Visibility string                     `json:"visibility" validate:"omitempty,oneof=private team public"`
 }
 
+// UpdateSavedPolygonRequest keeps the current visibility when Visibility is empty
 type UpdateSavedPolygonRequest struct {
-	Name   string                    `json:"name" validate:"required"`
-	Points []SavedPolygonPointRequest `json:"points" validate:"required,min=3,dive"`
+	Name       string                     `json:"name" validate:"required"`
+	Points     []SavedPolygonPointRequest `json:"points" validate:"required,min=3,dive"`
+	Visibility string                     `json:"visibility" validate:"omitempty,oneof=private team public"`
 }
 
 type SavedPolygonRequestFindAll struct {
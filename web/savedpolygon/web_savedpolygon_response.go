@@ -7,9 +7,12 @@ type SavedPolygonPointResponse struct {
 }
 
 type SavedPolygonResponse struct {
-	Id        int                        `json:"id"`
-	Name      string                     `json:"name"`
-	Points    []SavedPolygonPointResponse `json:"points"`
-	CreatedAt string                     `json:"created_at"`
-	UpdatedAt string                     `json:"updated_at"`
+	Id         int                         `json:"id"`
+	Name       string                      `json:"name"`
+	Points     []SavedPolygonPointResponse `json:"points"`
+	CreatedBy  *int                        `json:"created_by"`
+	UpdatedBy  *int                        `json:"updated_by"`
+	Visibility string                      `json:"visibility"`
+	CreatedAt  string                      `json:"created_at"`
+	UpdatedAt  string                      `json:"updated_at"`
 }
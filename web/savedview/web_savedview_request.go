@@ -0,0 +1,97 @@
+package savedview
+
+import (
+	"strings"
+
+	webBuilding "github.com/malikabdulaziz/tmn-backend/web/building"
+)
+
+type SavedViewPointRequest struct {
+	Lat float64 `json:"lat" validate:"min=-90,max=90"`
+	Lng float64 `json:"lng" validate:"min=-180,max=180"`
+}
+
+// CreateSavedViewRequest saves the full mapping filter state under a name unique per user.
+// Visibility is private, team or public and defaults to private.
+type CreateSavedViewRequest struct {
+	Name       string                           `json:"name" validate:"required,max=255"`
+	Filters    webBuilding.ExportMappingFilters `json:"filters"`
+	MapCenter  *SavedViewPointRequest           `json:"map_center"`
+	Zoom       *float64                         `json:"zoom" validate:"omitempty,min=0,max=22"`
+	SortBy     string                           `json:"sort_by" validate:"omitempty,oneof=distance"`
+	Visibility string                           `json:"visibility" validate:"omitempty,oneof=private team public"`
+}
+
+// UpdateSavedViewRequest keeps the current visibility when Visibility is empty
+type UpdateSavedViewRequest struct {
+	Name       string                           `json:"name" validate:"required,max=255"`
+	Filters    webBuilding.ExportMappingFilters `json:"filters"`
+	MapCenter  *SavedViewPointRequest           `json:"map_center"`
+	Zoom       *float64                         `json:"zoom" validate:"omitempty,min=0,max=22"`
+	SortBy     string                           `json:"sort_by" validate:"omitempty,oneof=distance"`
+	Visibility string                           `json:"visibility" validate:"omitempty,oneof=private team public"`
+}
+
+type SavedViewRequestFindAll struct {
+	take           int
+	skip           int
+	orderBy        string
+	orderDirection string
+	scope          string
+	name           string
+}
+
+func (r *SavedViewRequestFindAll) SetSkip(skip int) {
+	r.skip = skip
+}
+
+func (r *SavedViewRequestFindAll) SetTake(take int) {
+	r.take = take
+}
+
+func (r *SavedViewRequestFindAll) GetSkip() int {
+	return r.skip
+}
+
+func (r *SavedViewRequestFindAll) GetTake() int {
+	return r.take
+}
+
+func (r *SavedViewRequestFindAll) SetOrderBy(orderBy string) {
+	r.orderBy = orderBy
+}
+
+func (r *SavedViewRequestFindAll) SetOrderDirection(orderDirection string) {
+	r.orderDirection = strings.ToUpper(orderDirection)
+}
+
+func (r *SavedViewRequestFindAll) GetOrderBy() string {
+	if r.orderBy == "" {
+		return "updated_at"
+	}
+	return r.orderBy
+}
+
+func (r *SavedViewRequestFindAll) GetOrderDirection() string {
+	if r.orderDirection == "" {
+		return "DESC"
+	}
+	return r.orderDirection
+}
+
+// SetScope takes mine, shared or empty for every view the user can see
+func (r *SavedViewRequestFindAll) SetScope(scope string) {
+	r.scope = scope
+}
+
+func (r *SavedViewRequestFindAll) GetScope() string {
+	return r.scope
+}
+
+func (r *SavedViewRequestFindAll) SetName(name string) {
+	r.name = name
+}
+
+func (r *SavedViewRequestFindAll) GetName() string {
+	return r.name
+}
@@ -0,0 +1,24 @@
+package savedview
+
+import webBuilding "github.com/malikabdulaziz/tmn-backend/web/building"
+
+type SavedViewPointResponse struct {
+	Lat float64 `json:"lat"`
+	Lng float64 `json:"lng"`
+}
+
+// SavedViewResponse is a saved view; IsOwner tells whether the current user may change it
+type SavedViewResponse struct {
+	Id            int                              `json:"id"`
+	Name          string                           `json:"name"`
+	Filters       webBuilding.ExportMappingFilters `json:"filters"`
+	MapCenter     *SavedViewPointResponse          `json:"map_center"`
+	Zoom          *float64                         `json:"zoom"`
+	SortBy        string                           `json:"sort_by"`
+	Visibility    string                           `json:"visibility"`
+	IsOwner       bool                             `json:"is_owner"`
+	CreatedBy     int                              `json:"created_by"`
+	CreatedByName string                           `json:"created_by_name"`
+	CreatedAt     string                           `json:"created_at"`
+	UpdatedAt     string                           `json:"updated_at"`
+}
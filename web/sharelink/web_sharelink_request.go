@@ -0,0 +1,99 @@
+package sharelink
+
+import (
+	"strings"
+
+	webBuilding "github.com/malikabdulaziz/tmn-backend/web/building"
+)
+
+// Limits for share link lifetimes, in days
+const (
+	DefaultShareLinkDays = 7
+	MaxShareLinkDays     = 90
+)
+
+// CreateShareLinkRequest shares a sales package (pinned to SalesPackageVersion, the latest
+// version when 0), a saved polygon or a set of mapping filters. Title defaults to the name of
+// what is shared. When Password is set, viewers must send it in the X-Share-Password header.
+type CreateShareLinkRequest struct {
+	TargetType          string                            `json:"target_type" validate:"required,oneof=sales_package mapping_filter saved_polygon"`
+	SalesPackageId      int                               `json:"sales_package_id"`
+	SalesPackageVersion int                               `json:"sales_package_version" validate:"omitempty,min=1"`
+	SavedPolygonId      int                               `json:"saved_polygon_id"`
+	Filters             *webBuilding.ExportMappingFilters `json:"filters"`
+	Title               string                            `json:"title" validate:"omitempty,max=255"`
+	ExpiresInDays       int                               `json:"expires_in_days" validate:"omitempty,min=1,max=90"`
+	Password            string                            `json:"password" validate:"omitempty,min=4,max=72"`
+}
+
+type ShareLinkRequestFindAll struct {
+	take           int
+	skip           int
+	orderBy        string
+	orderDirection string
+	targetType     string
+	salesPackageId int
+	savedPolygonId int
+}
+
+func (r *ShareLinkRequestFindAll) SetSkip(skip int) {
+	r.skip = skip
+}
+
+func (r *ShareLinkRequestFindAll) SetTake(take int) {
+	r.take = take
+}
+
+func (r *ShareLinkRequestFindAll) GetSkip() int {
+	return r.skip
+}
+
+func (r *ShareLinkRequestFindAll) GetTake() int {
+	return r.take
+}
+
+func (r *ShareLinkRequestFindAll) SetOrderBy(orderBy string) {
+	r.orderBy = orderBy
+}
+
+func (r *ShareLinkRequestFindAll) SetOrderDirection(orderDirection string) {
+	r.orderDirection = strings.ToUpper(orderDirection)
+}
+
+func (r *ShareLinkRequestFindAll) GetOrderBy() string {
+	if r.orderBy == "" {
+		return "created_at"
+	}
+	return r.orderBy
+}
+
+func (r *ShareLinkRequestFindAll) GetOrderDirection() string {
+	if r.orderDirection == "" {
+		return "DESC"
+	}
+	return r.orderDirection
+}
+
+func (r *ShareLinkRequestFindAll) SetTargetType(targetType string) {
+	r.targetType = targetType
+}
+
+func (r *ShareLinkRequestFindAll) GetTargetType() string {
+	return r.targetType
+}
+
+func (r *ShareLinkRequestFindAll) SetSalesPackageId(salesPackageId int) {
+	r.salesPackageId = salesPackageId
+}
+
+func (r *ShareLinkRequestFindAll) GetSalesPackageId() int {
+	return r.salesPackageId
+}
+
+func (r *ShareLinkRequestFindAll) SetSavedPolygonId(savedPolygonId int) {
+	r.savedPolygonId = savedPolygonId
+}
+
+func (r *ShareLinkRequestFindAll) GetSavedPolygonId() int {
+	return r.savedPolygonId
+}
@@ -0,0 +1,82 @@
+package sharelink
+
+// Share link statuses, derived from revoked_at and expires_at
+const (
+	ShareLinkStatusActive  = "active"
+	ShareLinkStatusExpired = "expired"
+	ShareLinkStatusRevoked = "revoked"
+)
+
+// ShareLinkResponse is a share link as seen by its owners. Token is what goes in the public
+// /shared/:token URL.
+type ShareLinkResponse struct {
+	Id                    int    `json:"id"`
+	Token                 string `json:"token"`
+	Path                  string `json:"path"`
+	TargetType            string `json:"target_type"`
+	SalesPackageId        *int   `json:"sales_package_id"`
+	SalesPackageVersionId *int   `json:"sales_package_version_id"`
+	SalesPackageVersion   int    `json:"sales_package_version"`
+	SavedPolygonId        *int   `json:"saved_polygon_id"`
+	Title                 string `json:"title"`
+	HasPassword           bool   `json:"has_password"`
+	Status                string `json:"status"`
+	ExpiresAt             string `json:"expires_at"`
+	RevokedAt             string `json:"revoked_at"`
+	ViewCount             int    `json:"view_count"`
+	LastViewedAt          string `json:"last_viewed_at"`
+	CreatedBy             *int   `json:"created_by"`
+	CreatedAt             string `json:"created_at"`
+}
+
+// SharedBuildingResponse is the public view of a building: no ids, ERP codes, competitor
+// flags, sellable status or images. Screens is only known for sales package links.
+type SharedBuildingResponse struct {
+	Name           string  `json:"name"`
+	ProjectName    string  `json:"project_name"`
+	BuildingType   string  `json:"building_type"`
+	GradeResource  string  `json:"grade_resource"`
+	Subdistrict    string  `json:"subdistrict"`
+	Citytown       string  `json:"citytown"`
+	Province       string  `json:"province"`
+	CompletionYear int     `json:"completion_year"`
+	Latitude       float64 `json:"latitude"`
+	Longitude      float64 `json:"longitude"`
+	Audience       int     `json:"audience"`
+	Impression     int     `json:"impression"`
+	Screens        int     `json:"screens,omitempty"`
+}
+
+type SharedPointResponse struct {
+	Lat float64 `json:"lat"`
+	Lng float64 `json:"lng"`
+}
+
+// SharedBoundsResponse frames the located buildings; keys match MappingBounds
+type SharedBoundsResponse struct {
+	MinLat float64 `json:"minLat"`
+	MaxLat float64 `json:"maxLat"`
+	MinLng float64 `json:"minLng"`
+	MaxLng float64 `json:"maxLng"`
+}
+
+type SharedPackageResponse struct {
+	Name            string `json:"name"`
+	Version         int    `json:"version"`
+	BuildingCount   int    `json:"building_count"`
+	TotalAudience   int    `json:"total_audience"`
+	TotalImpression int    `json:"total_impression"`
+	TotalScreens    int    `json:"total_screens"`
+}
+
+// SharedViewResponse is what a share link shows to anyone holding it
+type SharedViewResponse struct {
+	Title         string                   `json:"title"`
+	TargetType    string                   `json:"target_type"`
+	ExpiresAt     string                   `json:"expires_at"`
+	SalesPackage  *SharedPackageResponse   `json:"sales_package,omitempty"`
+	Polygon       []SharedPointResponse    `json:"polygon,omitempty"`
+	Bounds        *SharedBoundsResponse    `json:"bounds"`
+	BuildingCount int                      `json:"building_count"`
+	Buildings     []SharedBuildingResponse `json:"buildings"`
+}
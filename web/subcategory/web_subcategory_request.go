@@ -2,12 +2,16 @@ package subcategory
 
 import "strings"
 
+// CreateSubCategoryRequest optionally places the new entry under a category
 type CreateSubCategoryRequest struct {
-	Name string `json:"name" validate:"required"`
+	Name       string `json:"name" validate:"required"`
+	CategoryId *int   `json:"category_id"`
 }
 
+// UpdateSubCategoryRequest replaces the category of the entry; a null category_id detaches it
 type UpdateSubCategoryRequest struct {
-	Name string `json:"name" validate:"required"`
+	Name       string `json:"name" validate:"required"`
+	CategoryId *int   `json:"category_id"`
 }
 
 type SubCategoryRequestFindAll struct {
@@ -1,8 +1,10 @@
 package subcategory
 
 type SubCategoryResponse struct {
-	Id        int    `json:"id"`
-	Name      string `json:"name"`
-	CreatedAt string `json:"created_at"`
-	UpdatedAt string `json:"updated_at"`
+	Id           int    `json:"id"`
+	Name         string `json:"name"`
+	CategoryId   *int   `json:"category_id"`
+	CategoryName string `json:"category_name"`
+	CreatedAt    string `json:"created_at"`
+	UpdatedAt    string `json:"updated_at"`
 }
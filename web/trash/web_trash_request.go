@@ -0,0 +1,32 @@
+package trash
+
+type TrashRequestFindAll struct {
+	take      int
+	skip      int
+	trashType string
+}
+
+func (r *TrashRequestFindAll) SetSkip(skip int) {
+	r.skip = skip
+}
+
+func (r *TrashRequestFindAll) SetTake(take int) {
+	r.take = take
+}
+
+func (r *TrashRequestFindAll) GetSkip() int {
+	return r.skip
+}
+
+func (r *TrashRequestFindAll) GetTake() int {
+	return r.take
+}
+
+// SetType takes one trash type (poi, sales_package, ...) or empty for the whole trash
+func (r *TrashRequestFindAll) SetType(trashType string) {
+	r.trashType = trashType
+}
+
+func (r *TrashRequestFindAll) GetType() string {
+	return r.trashType
+}
@@ -0,0 +1,21 @@
+package trash
+
+// TrashItemResponse is a deleted row; it is purged for good at PurgeAt unless it is restored first.
+// Restorable tells whether the current user may restore it.
+type TrashItemResponse struct {
+	Type          string `json:"type"`
+	Id            int    `json:"id"`
+	Name          string `json:"name"`
+	DeletedAt     string `json:"deleted_at"`
+	DeletedBy     *int   `json:"deleted_by"`
+	DeletedByName string `json:"deleted_by_name"`
+	PurgeAt       string `json:"purge_at"`
+	Restorable    bool   `json:"restorable"`
+}
+
+// TrashRestoreResponse is the row taken out of the trash
+type TrashRestoreResponse struct {
+	Type string `json:"type"`
+	Id   int    `json:"id"`
+	Name string `json:"name"`
+}
@@ -0,0 +1,22 @@
+package web
+
+// ImportRowError is one problem found in an uploaded spreadsheet. Row is the 1-based sheet row
+// (the header is row 1) and Column is the header cell as written in the file.
+type ImportRowError struct {
+	Row    int    `json:"row"`
+	Column string `json:"column"`
+	Value  string `json:"value"`
+	Reason string `json:"reason"`
+}
+
+// ImportReport summarizes an XLSX/CSV import. OnError is "abort" (nothing is written when any row
+// is invalid) or "skip" (valid rows are imported, invalid ones reported). AnnotatedFile is a
+// base64 xlsx of the upload with an Errors column, present only when some rows were rejected.
+type ImportReport struct {
+	OnError       string           `json:"on_error"`
+	TotalRows     int              `json:"total_rows"`
+	ImportedRows  int              `json:"imported_rows"`
+	SkippedRows   int              `json:"skipped_rows"`
+	Errors        []ImportRowError `json:"errors"`
+	AnnotatedFile string           `json:"annotated_file,omitempty"`
+}